@@ -749,7 +749,8 @@ set(CPACK_RPM_PLUGIN-GO_DEFAULT_USER "root")
 set(CPACK_RPM_PLUGIN-GO_DEFAULT_GROUP "netdata")
 set(CPACK_RPM_PLUGIN-GO_USER_FILELIST
     "%attr(0750,root,netdata) %caps(cap_dac_read_search,cap_net_admin,cap_net_raw,cap_net_bind_service=eip) /usr/libexec/netdata/plugins.d/go.d.plugin"
-    "%attr(0750,root,netdata) /usr/libexec/netdata/plugins.d/snmp-trap-profile-gen")
+    "%attr(0750,root,netdata) /usr/libexec/netdata/plugins.d/snmp-trap-profile-gen"
+    "%attr(0750,root,netdata) /usr/libexec/netdata/plugins.d/snmp-profile-gen")
 
 #
 # scripts.d.plugin
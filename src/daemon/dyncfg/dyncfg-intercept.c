@@ -29,6 +29,10 @@ ENUM_STR_MAP_DEFINE(DYNCFG_CMDS) = {
     { DYNCFG_CMD_DISABLE, "disable" },
     { DYNCFG_CMD_RESTART, "restart" },
     { DYNCFG_CMD_USERCONFIG, "userconfig" },
+    { DYNCFG_CMD_HISTORY, "history" },
+    { DYNCFG_CMD_DIFF, "diff" },
+    { DYNCFG_CMD_ROLLBACK, "rollback" },
+    { DYNCFG_CMD_IMPORT, "import" },
 
     // terminator
     { 0, NULL }
@@ -37,7 +41,8 @@ ENUM_STR_MAP_DEFINE(DYNCFG_CMDS) = {
 ENUM_STR_DEFINE_FUNCTIONS(DYNCFG_CMDS, DYNCFG_CMD_NONE, "none");
 
 static void dyncfg_log_user_action(DYNCFG *df, struct dyncfg_call *dc) {
-    if(dc->cmd == DYNCFG_CMD_USERCONFIG || dc->cmd == DYNCFG_CMD_GET || dc->cmd == DYNCFG_CMD_SCHEMA)
+    if(dc->cmd == DYNCFG_CMD_USERCONFIG || dc->cmd == DYNCFG_CMD_GET || dc->cmd == DYNCFG_CMD_SCHEMA ||
+       dc->cmd == DYNCFG_CMD_HISTORY || dc->cmd == DYNCFG_CMD_DIFF || dc->cmd == DYNCFG_CMD_IMPORT)
         return;
 
     const char *type;
@@ -128,7 +133,7 @@ static void dyncfg_function_intercept_job_successfully_added(DYNCFG *df_template
             DYNCFG_TYPE_JOB,
             DYNCFG_SOURCE_TYPE_DYNCFG,
             dc->source,
-            (df_template->cmds & ~DYNCFG_CMD_ADD) | DYNCFG_CMD_GET | DYNCFG_CMD_UPDATE | DYNCFG_CMD_TEST |
+            (df_template->cmds & ~(DYNCFG_CMD_ADD | DYNCFG_CMD_IMPORT)) | DYNCFG_CMD_GET | DYNCFG_CMD_UPDATE | DYNCFG_CMD_TEST |
                 DYNCFG_CMD_ENABLE | DYNCFG_CMD_DISABLE | DYNCFG_CMD_REMOVE,
             0,
             0,
@@ -166,6 +171,22 @@ static void dyncfg_function_intercept_job_successfully_updated(DYNCFG *df, int c
     df->cmds = dyncfg_sanitize_cmds(df->type, df->current.source_type, df->cmds);
 }
 
+static bool dyncfg_function_intercept_job_successfully_rolled_back(DYNCFG *df, BUFFER *wb, int code, struct dyncfg_call *dc) {
+    // the plugin responds with the restored configuration, which becomes the new payload,
+    // so that it is the one sent back to the plugin when it restarts
+    if(!wb || !buffer_strlen(wb) || wb->content_type != CT_APPLICATION_YAML) {
+        nd_log(NDLS_DAEMON, NDLP_WARNING,
+               "DYNCFG: plugin did not return the restored configuration on rollback of '%s', the saved configuration is not updated",
+               dc->id);
+        return false;
+    }
+
+    buffer_free(dc->payload);
+    dc->payload = buffer_dup(wb);
+    dyncfg_function_intercept_job_successfully_updated(df, code, dc);
+    return true;
+}
+
 void dyncfg_function_intercept_result_cb(BUFFER *wb, int code, void *result_cb_data) {
     struct dyncfg_call *dc = result_cb_data;
 
@@ -187,6 +208,9 @@ void dyncfg_function_intercept_result_cb(BUFFER *wb, int code, void *result_cb_d
                     dyncfg_function_intercept_job_successfully_updated(df, code, dc);
                     save_required = true;
                 }
+                else if (dc->cmd == DYNCFG_CMD_ROLLBACK) {
+                    save_required = dyncfg_function_intercept_job_successfully_rolled_back(df, wb, code, dc);
+                }
                 else if (dc->cmd == DYNCFG_CMD_ENABLE) {
                     df->dyncfg.user_disabled = false;
                 }
@@ -357,12 +381,12 @@ int dyncfg_function_intercept_cb(struct rrd_function_execute *rfe, void *data __
         }
     }
 
-    if((cmd == DYNCFG_CMD_ADD || cmd == DYNCFG_CMD_UPDATE || cmd == DYNCFG_CMD_TEST || cmd == DYNCFG_CMD_USERCONFIG) && !has_payload)
+    if((cmd == DYNCFG_CMD_ADD || cmd == DYNCFG_CMD_UPDATE || cmd == DYNCFG_CMD_TEST || cmd == DYNCFG_CMD_USERCONFIG || cmd == DYNCFG_CMD_IMPORT) && !has_payload)
         return dyncfg_intercept_early_error(
             rfe, HTTP_RESP_BAD_REQUEST,
             "dyncfg functions intercept: this action requires a payload");
 
-    if((cmd != DYNCFG_CMD_ADD && cmd != DYNCFG_CMD_UPDATE && cmd != DYNCFG_CMD_TEST && cmd != DYNCFG_CMD_USERCONFIG) && has_payload)
+    if((cmd != DYNCFG_CMD_ADD && cmd != DYNCFG_CMD_UPDATE && cmd != DYNCFG_CMD_TEST && cmd != DYNCFG_CMD_USERCONFIG && cmd != DYNCFG_CMD_IMPORT) && has_payload)
         return dyncfg_intercept_early_error(
             rfe, HTTP_RESP_BAD_REQUEST,
             "dyncfg functions intercept: this action does not require a payload");
@@ -388,6 +412,8 @@ int dyncfg_function_intercept_cb(struct rrd_function_execute *rfe, void *data __
         case DYNCFG_CMD_GET:
         case DYNCFG_CMD_SCHEMA:
         case DYNCFG_CMD_USERCONFIG:
+        case DYNCFG_CMD_HISTORY:
+        case DYNCFG_CMD_DIFF:
             if(!http_access_user_has_enough_access_level_for_endpoint(rfe->user_access, df->view_access)) {
                 make_the_call_to_plugin = false;
                 rc = dyncfg_default_response(
@@ -403,6 +429,8 @@ int dyncfg_function_intercept_cb(struct rrd_function_execute *rfe, void *data __
         case DYNCFG_CMD_UPDATE:
         case DYNCFG_CMD_REMOVE:
         case DYNCFG_CMD_RESTART:
+        case DYNCFG_CMD_ROLLBACK:
+        case DYNCFG_CMD_IMPORT:
             if(!http_access_user_has_enough_access_level_for_endpoint(rfe->user_access, df->edit_access)) {
                 make_the_call_to_plugin = false;
                 rc = dyncfg_default_response(
@@ -444,6 +472,12 @@ int dyncfg_function_intercept_cb(struct rrd_function_execute *rfe, void *data __
                        dyncfg_id2type(df->type), rfe->function);
             }
         }
+        else if (cmd == DYNCFG_CMD_IMPORT && df->type != DYNCFG_TYPE_TEMPLATE) {
+            make_the_call_to_plugin = false;
+            rc = dyncfg_default_response(
+                rfe->result.wb, HTTP_RESP_BAD_REQUEST,
+                "dyncfg functions intercept: import command is only allowed in templates");
+        }
         else if (
             cmd == DYNCFG_CMD_ENABLE && df->type == DYNCFG_TYPE_JOB &&
             dyncfg_is_user_disabled(string2str(df->template))) {
@@ -321,6 +321,10 @@ DYNCFG_CMDS dyncfg_sanitize_cmds(DYNCFG_TYPE type, DYNCFG_SOURCE_TYPE source_typ
         cmds &= ~DYNCFG_CMD_ADD;
     }
 
+    // import converts foreign configurations to jobs of a template
+    if(type != DYNCFG_TYPE_TEMPLATE)
+        cmds &= ~DYNCFG_CMD_IMPORT;
+
     // remove
     if(source_type == DYNCFG_SOURCE_TYPE_DYNCFG && type == DYNCFG_TYPE_JOB) {
         // dyncfg jobs must always be removable
@@ -334,7 +338,7 @@ DYNCFG_CMDS dyncfg_sanitize_cmds(DYNCFG_TYPE type, DYNCFG_SOURCE_TYPE source_typ
     // data
     if(type == DYNCFG_TYPE_TEMPLATE) {
         // templates do not have data
-        cmds &= ~(DYNCFG_CMD_GET | DYNCFG_CMD_UPDATE);
+        cmds &= ~(DYNCFG_CMD_GET | DYNCFG_CMD_UPDATE | DYNCFG_CMD_HISTORY | DYNCFG_CMD_DIFF | DYNCFG_CMD_ROLLBACK);
     }
 
     return cmds;
@@ -62,6 +62,7 @@ func main() {
 	moduleRegistry := moduleRegistryWithSystemdPolicy(collectorapi.DefaultRegistry, hostinfo.SystemdVersion)
 
 	runModePolicy := policy.Agent(isTerminal)
+	discoverers := sdext.Registry(!isInsideK8s)
 
 	a := agent.New(agent.Config{
 		Name:                      executable.Name,
@@ -76,13 +77,34 @@ func main() {
 		DiscoveryProviders: []discovery.ProviderFactory{
 			discoveryproviders.File(),
 			discoveryproviders.Dummy(),
-			discoveryproviders.SD(sdext.Registry(!isInsideK8s)),
+			discoveryproviders.SD(discoverers),
 		},
+		Discoverers:    discoverers,
 		RunModule:      opts.Module,
 		RunJob:         opts.Job,
 		MinUpdateEvery: opts.UpdateEvery,
 	})
 
+	if opts.Validate {
+		os.Exit(agenthost.Validate(a, os.Stdout))
+	}
+
+	if len(opts.Import) > 0 {
+		os.Exit(agenthost.Import(a, opts.Import, os.Stdout))
+	}
+
+	stopFixture, err := agenthost.StartFixture(a, opts.Record, opts.RecordBody, opts.Replay)
+	if err != nil {
+		a.Errorf("fixture: %v", err)
+		os.Exit(1)
+	}
+
+	if opts.Dump != "" {
+		code := agenthost.Dump(a, opts.Dump, opts.DumpCycles, os.Stdout)
+		stopFixture()
+		os.Exit(code)
+	}
+
 	a.Infof("plugin: name=%s, %s", a.Name, buildinfo.Info())
 	if u, err := user.Current(); err == nil {
 		a.Debugf("current user: name=%s, uid=%s", u.Username, u.Uid)
@@ -94,7 +116,9 @@ func main() {
 	a.Infof("directories → config: %s | collectors: %s | sd: %s | varlib: %s",
 		a.ConfigDir, a.CollectorsConfDir, a.ServiceDiscoveryConfigDir, a.VarLibDir)
 
-	if err := agenthost.Run(a); err != nil {
+	err = agenthost.Run(a)
+	stopFixture()
+	if err != nil {
 		a.Errorf("plugin exiting after Agent failure: %v", err)
 		os.Exit(1)
 	}
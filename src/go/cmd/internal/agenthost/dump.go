@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agenthost
+
+import (
+	"context"
+	"io"
+	"os/signal"
+	"syscall"
+
+	"github.com/netdata/netdata/go/plugins/plugin/agent"
+)
+
+// Dump runs the Agent's one-shot collection for the selected job and writes
+// the collected metrics to w in the given format. It returns the process exit
+// code: 0 on success and 1 when the job could not be collected.
+func Dump(a *agent.Agent, format string, cycles int, w io.Writer) int {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := a.Dump(ctx, format, cycles, w); err != nil {
+		a.Errorf("dump failed: %v", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agenthost
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/agent"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/fixture"
+)
+
+// StartFixture starts recording collector traffic to the record bundle file or
+// replaying it from the replay bundle file. The returned stop function removes
+// the hooks and, when recording, writes the bundle. Both paths empty is a no-op.
+func StartFixture(a *agent.Agent, record string, recordBodies bool, replay string) (stop func(), err error) {
+	switch {
+	case record != "" && replay != "":
+		return nil, errors.New("--record and --replay are mutually exclusive")
+	case recordBodies && record == "":
+		return nil, errors.New("--record-bodies requires --record")
+	case record != "":
+		rec, err := fixture.Record(a.RunModule, strings.Join(a.RunJob, ","), fixture.RecordOptions{RequestBodies: recordBodies})
+		if err != nil {
+			return nil, err
+		}
+		a.Infof("recording collector traffic to '%s'", record)
+		return func() {
+			if err := rec.Stop().Save(record); err != nil {
+				a.Errorf("saving fixture bundle: %v", err)
+				return
+			}
+			a.Infof("fixture bundle written to '%s'", record)
+		}, nil
+	case replay != "":
+		b, err := fixture.LoadBundle(replay)
+		if err != nil {
+			return nil, err
+		}
+		rep, err := fixture.Replay(b)
+		if err != nil {
+			return nil, err
+		}
+		a.Infof("replaying collector traffic from '%s' (module '%s', job '%s')", replay, b.Module, b.Job)
+		return rep.Stop, nil
+	default:
+		return func() {}, nil
+	}
+}
@@ -0,0 +1,20 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agenthost
+
+import (
+	"io"
+
+	"github.com/netdata/netdata/go/plugins/plugin/agent"
+)
+
+// Import converts the given foreign config files into jobs of the Agent's
+// selected module and writes them to w. It returns the process exit code: 0
+// on success and 1 when the files could not be converted.
+func Import(a *agent.Agent, files []string, w io.Writer) int {
+	if err := a.Import(files, w); err != nil {
+		a.Errorf("import failed: %v", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agenthost
+
+import (
+	"context"
+	"io"
+	"os/signal"
+	"syscall"
+
+	"github.com/netdata/netdata/go/plugins/plugin/agent"
+)
+
+// Validate runs the Agent's offline config validation and writes the JSON
+// report to w. It returns the process exit code: 0 when every config is valid,
+// 1 when the report has errors and 2 when validation could not run.
+func Validate(a *agent.Agent, w io.Writer) int {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	report, err := a.Validate(ctx)
+	if err != nil {
+		a.Errorf("config validation failed: %v", err)
+		return 2
+	}
+	if err := report.WriteJSON(w); err != nil {
+		a.Errorf("writing config validation report: %v", err)
+		return 2
+	}
+	if !report.Valid {
+		return 1
+	}
+	return 0
+}
@@ -86,6 +86,26 @@ func main() {
 		DisableServiceDiscovery: true,
 	})
 
+	if opts.Validate {
+		os.Exit(agenthost.Validate(a, os.Stdout))
+	}
+
+	if len(opts.Import) > 0 {
+		os.Exit(agenthost.Import(a, opts.Import, os.Stdout))
+	}
+
+	stopFixture, err := agenthost.StartFixture(a, opts.Record, opts.RecordBody, opts.Replay)
+	if err != nil {
+		a.Errorf("fixture: %v", err)
+		os.Exit(1)
+	}
+
+	if opts.Dump != "" {
+		code := agenthost.Dump(a, opts.Dump, opts.DumpCycles, os.Stdout)
+		stopFixture()
+		os.Exit(code)
+	}
+
 	a.Debugf("plugin: name=%s, %s", a.Name, buildinfo.Info())
 	if u, err := user.Current(); err == nil {
 		a.Debugf("current user: name=%s, uid=%s", u.Username, u.Uid)
@@ -97,7 +117,9 @@ func main() {
 	a.Infof("directories → config: %s | collectors: %s | varlib: %s",
 		a.ConfigDir, a.CollectorsConfDir, a.VarLibDir)
 
-	if err := agenthost.Run(a); err != nil {
+	err = agenthost.Run(a)
+	stopFixture()
+	if err != nil {
 		a.Errorf("plugin exiting after Agent failure: %v", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,568 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/golangsnmp/gomib"
+	gomibmib "github.com/golangsnmp/gomib/mib"
+	yamlv2 "gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp/ddsnmp/ddprofiledefinition"
+)
+
+var mibSourceExtensions = []string{"", ".mib", ".my", ".mi2", ".txt", ".smi"}
+
+// skippedTextualConventions are row-management columns that carry no
+// monitoring value even though their SYNTAX is an integer.
+var skippedTextualConventions = map[string]bool{
+	"RowStatus":   true,
+	"StorageType": true,
+}
+
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(v string) error {
+	if v == "" {
+		return nil
+	}
+	*l = append(*l, v)
+	return nil
+}
+
+type generatorOptions struct {
+	SourceDirs   []string
+	Module       string
+	Tables       []string
+	Scalars      []string
+	Extends      []string
+	SysObjectIDs []string
+	OutPath      string
+}
+
+// profileFile is the draft profile as written to disk. It mirrors the subset of
+// ddprofiledefinition.ProfileDefinition the generator fills, with field order
+// and flat enum mappings matching the stock profiles.
+type profileFile struct {
+	Extends  []string          `yaml:"extends,omitempty"`
+	Selector []profileSelector `yaml:"selector,omitempty"`
+	Metrics  []profileMetric   `yaml:"metrics"`
+}
+
+type profileSelector struct {
+	SysObjectID profileSelectorInclude `yaml:"sysobjectid"`
+}
+
+type profileSelectorInclude struct {
+	Include []string `yaml:"include"`
+}
+
+type profileMetric struct {
+	MIB        string             `yaml:"MIB"`
+	Symbol     *profileSymbol     `yaml:"symbol,omitempty"`
+	Table      *profileTable      `yaml:"table,omitempty"`
+	Symbols    []profileSymbol    `yaml:"symbols,omitempty"`
+	MetricTags []profileMetricTag `yaml:"metric_tags,omitempty"`
+}
+
+type profileTable struct {
+	OID  string `yaml:"OID"`
+	Name string `yaml:"name"`
+}
+
+type profileSymbol struct {
+	OID        string            `yaml:"OID,omitempty"`
+	Name       string            `yaml:"name"`
+	Format     string            `yaml:"format,omitempty"`
+	MetricType string            `yaml:"metric_type,omitempty"`
+	ChartMeta  *profileChartMeta `yaml:"chart_meta,omitempty"`
+	Mapping    map[int64]string  `yaml:"mapping,omitempty"`
+}
+
+type profileChartMeta struct {
+	Description string `yaml:"description,omitempty"`
+	Family      string `yaml:"family"`
+	Unit        string `yaml:"unit"`
+}
+
+type profileMetricTag struct {
+	Tag            string                  `yaml:"tag"`
+	Index          uint                    `yaml:"index,omitempty"`
+	Table          string                  `yaml:"table,omitempty"`
+	Symbol         *profileSymbol          `yaml:"symbol,omitempty"`
+	IndexTransform []profileIndexTransform `yaml:"index_transform,omitempty"`
+	Mapping        map[int64]string        `yaml:"mapping,omitempty"`
+}
+
+type profileIndexTransform struct {
+	Start uint `yaml:"start"`
+	End   uint `yaml:"end"`
+}
+
+func main() {
+	log.SetFlags(0)
+	if err := run(os.Args[1:]); err != nil {
+		log.Printf("error: %v", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	opts, err := parseOptions(args)
+	if err != nil {
+		return err
+	}
+	src, err := buildSource(opts.SourceDirs)
+	if err != nil {
+		return err
+	}
+	m, err := loadModules(context.Background(), src, []string{opts.Module})
+	if err != nil && !errors.Is(err, gomib.ErrDiagnosticThreshold) {
+		return fmt.Errorf("load %s: %w", opts.Module, err)
+	}
+	if m == nil {
+		return fmt.Errorf("load %s: no modules loaded", opts.Module)
+	}
+
+	profile, err := generateProfile(m, opts)
+	if err != nil {
+		return err
+	}
+	data, err := encodeProfile(profile, opts.Module)
+	if err != nil {
+		return err
+	}
+	if err := validateProfile(data); err != nil {
+		return fmt.Errorf("generated profile failed validation: %w", err)
+	}
+
+	if opts.OutPath == "" || opts.OutPath == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(opts.OutPath), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(opts.OutPath, data, 0o644); err != nil {
+		return err
+	}
+	log.Printf("wrote %s (%d metrics)", opts.OutPath, len(profile.Metrics))
+	return nil
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: snmp-profile-gen --source-dir DIR --module MIB [--table NAME]... [--scalar NAME]... [flags]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Without --table and --scalar, every table and scalar of the module is included.")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "common example:")
+	fmt.Fprintln(w, "  snmp-profile-gen --source-dir ./mibs --module UPS-MIB --table upsInputTable --scalar upsBatteryStatus --out ./ups.yaml")
+}
+
+func parseOptions(args []string) (generatorOptions, error) {
+	fs := flag.NewFlagSet("snmp-profile-gen", flag.ContinueOnError)
+	fs.Usage = func() {
+		usage(fs.Output())
+		fs.PrintDefaults()
+	}
+
+	var opts generatorOptions
+	var sourceDirs, tables, scalars, extends, sysObjectIDs stringList
+	fs.Var(&sourceDirs, "source-dir", "MIB source directory (repeatable)")
+	fs.StringVar(&opts.Module, "module", "", "MIB module to generate the profile from")
+	fs.Var(&tables, "table", "table object to include (repeatable)")
+	fs.Var(&scalars, "scalar", "scalar object to include (repeatable)")
+	fs.Var(&extends, "extends", "base profile to extend (repeatable)")
+	fs.Var(&sysObjectIDs, "sysobjectid", "sysObjectID pattern for the profile selector (repeatable)")
+	fs.StringVar(&opts.OutPath, "out", "", "output profile path (default: stdout)")
+
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+	if fs.NArg() > 0 {
+		return opts, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	if len(sourceDirs) == 0 {
+		return opts, errors.New("at least one --source-dir is required")
+	}
+	if opts.Module == "" {
+		return opts, errors.New("--module is required")
+	}
+
+	opts.SourceDirs = sourceDirs
+	opts.Tables = tables
+	opts.Scalars = scalars
+	opts.Extends = extends
+	opts.SysObjectIDs = sysObjectIDs
+	return opts, nil
+}
+
+func buildSource(dirs []string) (gomib.Source, error) {
+	var sources []gomib.Source
+	exts := gomib.WithExtensions(mibSourceExtensions...)
+	for _, dir := range dirs {
+		src, err := gomib.Dir(dir, exts)
+		if err != nil {
+			log.Printf("skip source %s: %v", dir, err)
+			continue
+		}
+		sources = append(sources, src)
+	}
+	if len(sources) == 0 {
+		return nil, errors.New("no usable source dirs")
+	}
+	return gomib.Multi(sources...), nil
+}
+
+func loadModules(ctx context.Context, src gomib.Source, modules []string) (*gomibmib.Mib, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+	return gomib.Load(ctx,
+		gomib.WithSource(src),
+		gomib.WithModules(modules...),
+		gomib.WithResolverStrictness(gomibmib.ResolverPermissive),
+		gomib.WithDiagnosticConfig(gomibmib.DiagnosticConfig{
+			Reporting: gomibmib.ReportingQuiet,
+			FailAt:    gomibmib.SeverityFatal,
+		}),
+	)
+}
+
+// generateProfile builds the draft profile for the selected objects of the module.
+// Objects that cannot be expressed are skipped with a log line rather than failing
+// the whole run: the output is a starting point for manual review.
+func generateProfile(m *gomibmib.Mib, opts generatorOptions) (*profileFile, error) {
+	mod := m.Module(opts.Module)
+	if mod == nil {
+		return nil, fmt.Errorf("module %s not found in source dirs", opts.Module)
+	}
+
+	tables, scalars := mod.Tables(), mod.Scalars()
+	if len(opts.Tables) > 0 || len(opts.Scalars) > 0 {
+		var err error
+		if tables, err = lookupObjects(mod, opts.Tables, (*gomibmib.Object).IsTable, "table"); err != nil {
+			return nil, err
+		}
+		if scalars, err = lookupObjects(mod, opts.Scalars, (*gomibmib.Object).IsScalar, "scalar"); err != nil {
+			return nil, err
+		}
+	}
+
+	profile := &profileFile{Extends: opts.Extends}
+	if len(opts.SysObjectIDs) > 0 {
+		profile.Selector = []profileSelector{{SysObjectID: profileSelectorInclude{Include: opts.SysObjectIDs}}}
+	}
+
+	for _, obj := range scalars {
+		sym, ok := metricSymbol(obj, oidString(obj.OID())+".0", scalarFamily(mod, obj))
+		if !ok {
+			continue
+		}
+		profile.Metrics = append(profile.Metrics, profileMetric{MIB: mod.Name(), Symbol: sym})
+	}
+	for _, tbl := range tables {
+		if metric, ok := tableMetric(mod, tbl); ok {
+			profile.Metrics = append(profile.Metrics, metric)
+		}
+	}
+
+	if len(profile.Metrics) == 0 {
+		return nil, fmt.Errorf("no metrics could be generated from %s", opts.Module)
+	}
+	return profile, nil
+}
+
+func lookupObjects(mod *gomibmib.Module, names []string, isKind func(*gomibmib.Object) bool, kind string) ([]*gomibmib.Object, error) {
+	var objs []*gomibmib.Object
+	for _, name := range names {
+		obj := mod.Object(name)
+		if obj == nil {
+			return nil, fmt.Errorf("%s %s not found in %s", kind, name, mod.Name())
+		}
+		if !isKind(obj) {
+			return nil, fmt.Errorf("%s::%s is not a %s", mod.Name(), name, kind)
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+func tableMetric(mod *gomibmib.Module, tbl *gomibmib.Object) (profileMetric, bool) {
+	row := tbl.Entry()
+	if row == nil {
+		log.Printf("skip table %s::%s: no row entry", mod.Name(), tbl.Name())
+		return profileMetric{}, false
+	}
+
+	indexes := row.EffectiveIndexes()
+	isIndex := make(map[*gomibmib.Object]bool, len(indexes))
+	for _, idx := range indexes {
+		isIndex[idx.Object] = true
+	}
+
+	metric := profileMetric{
+		MIB:   mod.Name(),
+		Table: &profileTable{OID: oidString(tbl.OID()), Name: tbl.Name()},
+	}
+	family := tableFamily(mod, tbl)
+	for _, col := range tbl.Columns() {
+		if isIndex[col] {
+			continue
+		}
+		if sym, ok := metricSymbol(col, oidString(col.OID()), family); ok {
+			metric.Symbols = append(metric.Symbols, *sym)
+		}
+	}
+	if len(metric.Symbols) == 0 {
+		log.Printf("skip table %s::%s: no numeric columns", mod.Name(), tbl.Name())
+		return profileMetric{}, false
+	}
+	metric.MetricTags = indexMetricTags(tbl, indexes)
+	return metric, true
+}
+
+// metricSymbol maps a readable numeric object to a metric symbol: counters
+// become rates, everything else a gauge, and enumerations carry their labels
+// as a value mapping.
+func metricSymbol(obj *gomibmib.Object, oid, family string) (*profileSymbol, bool) {
+	qname := obj.Module().Name() + "::" + obj.Name()
+	if !isReadable(obj) {
+		return nil, false
+	}
+	if obj.Type() == nil {
+		log.Printf("skip %s: unresolved SYNTAX", qname)
+		return nil, false
+	}
+	if tc := obj.Type().EffectiveTC(); tc != nil && skippedTextualConventions[tc.Name()] {
+		return nil, false
+	}
+
+	sym := &profileSymbol{
+		OID:  oid,
+		Name: obj.Name(),
+		ChartMeta: &profileChartMeta{
+			Description: summarizeDescription(obj.Description()),
+			Family:      family,
+			Unit:        obj.Units(),
+		},
+	}
+
+	switch obj.Type().EffectiveBase() {
+	case gomibmib.BaseCounter32, gomibmib.BaseCounter64:
+		sym.MetricType = string(ddprofiledefinition.ProfileMetricTypeRate)
+		if sym.ChartMeta.Unit == "" {
+			sym.ChartMeta.Unit = "{event}"
+		}
+		sym.ChartMeta.Unit += "/s"
+	case gomibmib.BaseInteger32, gomibmib.BaseUnsigned32, gomibmib.BaseGauge32, gomibmib.BaseTimeTicks:
+		sym.MetricType = string(ddprofiledefinition.ProfileMetricTypeGauge)
+		if enums := obj.EffectiveEnums(); len(enums) > 0 {
+			sym.Mapping = enumMapping(enums)
+			sym.ChartMeta.Unit = "{status}"
+		}
+		if sym.ChartMeta.Unit == "" {
+			sym.ChartMeta.Unit = "1"
+		}
+	default:
+		log.Printf("skip %s: SYNTAX %s is not numeric", qname, syntaxName(obj))
+		return nil, false
+	}
+	return sym, true
+}
+
+// indexMetricTags derives row tags from the INDEX clause. Readable index
+// columns are walked as symbols; not-accessible ones are decoded from the row
+// index, which is only possible while every preceding component has a fixed
+// number of sub-identifiers.
+func indexMetricTags(tbl *gomibmib.Object, indexes []gomibmib.IndexEntry) []profileMetricTag {
+	var tags []profileMetricTag
+	var pos uint
+	fixed, complete := true, true
+
+	for i, idx := range indexes {
+		obj := idx.Object
+		if obj == nil {
+			complete = false
+			fixed = false
+			continue
+		}
+
+		tag := profileMetricTag{Tag: tagName(obj.Name())}
+		if enums := obj.EffectiveEnums(); len(enums) > 0 {
+			tag.Mapping = enumMapping(enums)
+		}
+		last := i == len(indexes)-1
+
+		switch {
+		case isReadable(obj) && obj.IsColumn():
+			tag.Symbol = &profileSymbol{OID: oidString(obj.OID()), Name: obj.Name()}
+			if owner := obj.Table(); owner != nil && owner != tbl {
+				tag.Table = owner.Name()
+			}
+		case fixed && idx.Encoding == gomibmib.IndexEncodingInteger:
+			tag.Index = pos + 1
+		case fixed && idx.Encoding == gomibmib.IndexEncodingIpAddress:
+			tag.IndexTransform = []profileIndexTransform{{Start: pos, End: pos + 3}}
+			tag.Symbol = &profileSymbol{Name: obj.Name(), Format: "ip_address"}
+		case fixed && last && pos > 0 && idx.Encoding == gomibmib.IndexEncodingImplied:
+			tag.IndexTransform = []profileIndexTransform{{Start: pos}}
+		case fixed && last && idx.Encoding == gomibmib.IndexEncodingLengthPrefixed:
+			tag.IndexTransform = []profileIndexTransform{{Start: pos + 1}}
+		default:
+			log.Printf("%s: index component %s needs a manual metric tag", tbl.Name(), obj.Name())
+			complete = false
+			tag = profileMetricTag{}
+		}
+		if tag.Tag != "" {
+			tags = append(tags, tag)
+		}
+
+		switch idx.Encoding {
+		case gomibmib.IndexEncodingInteger:
+			pos++
+		case gomibmib.IndexEncodingIpAddress:
+			pos += 4
+		default:
+			fixed = false
+		}
+	}
+
+	if !complete {
+		// Keep rows distinguishable until the missing tags are written by hand.
+		tags = append(tags, profileMetricTag{
+			Tag:    "index",
+			Symbol: &profileSymbol{Name: "index", Format: "string"},
+		})
+	}
+	return tags
+}
+
+func isReadable(obj *gomibmib.Object) bool {
+	switch obj.Access() {
+	case gomibmib.AccessReadOnly, gomibmib.AccessReadWrite, gomibmib.AccessReadCreate:
+		return true
+	default:
+		return false
+	}
+}
+
+func enumMapping(enums []gomibmib.NamedValue) map[int64]string {
+	mapping := make(map[int64]string, len(enums))
+	for _, nv := range enums {
+		mapping[nv.Value] = nv.Label
+	}
+	return mapping
+}
+
+func tableFamily(mod *gomibmib.Module, tbl *gomibmib.Object) string {
+	return familyPrefix(mod) + "/" + strings.TrimSuffix(tbl.Name(), "Table")
+}
+
+func scalarFamily(mod *gomibmib.Module, obj *gomibmib.Object) string {
+	if node := obj.Node(); node != nil && node.Parent() != nil && node.Parent().Name() != "" {
+		return familyPrefix(mod) + "/" + node.Parent().Name()
+	}
+	return familyPrefix(mod)
+}
+
+func familyPrefix(mod *gomibmib.Module) string {
+	return strings.TrimSuffix(mod.Name(), "-MIB")
+}
+
+var whitespaceRe = regexp.MustCompile(`\s+`)
+
+// summarizeDescription returns the first sentence of a DESCRIPTION clause,
+// without the trailing period, the way chart descriptions are written.
+func summarizeDescription(desc string) string {
+	desc = strings.TrimSpace(whitespaceRe.ReplaceAllString(desc, " "))
+	if i := strings.Index(desc, ". "); i >= 0 {
+		desc = desc[:i]
+	}
+	return strings.TrimSuffix(desc, ".")
+}
+
+// tagName converts a MIB object name to a snake_case tag name.
+func tagName(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if r == '-' {
+			b.WriteByte('_')
+			continue
+		}
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+func syntaxName(obj *gomibmib.Object) string {
+	if obj == nil || obj.Type() == nil {
+		return ""
+	}
+	if tc := obj.Type().EffectiveTC(); tc != nil && tc.Name() != "" {
+		return tc.Name()
+	}
+	if obj.Type().Name() != "" {
+		return obj.Type().Name()
+	}
+	return obj.Type().EffectiveBase().String()
+}
+
+func oidString(oid gomibmib.OID) string {
+	if oid == nil {
+		return ""
+	}
+	return oid.String()
+}
+
+func encodeProfile(profile *profileFile, module string) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Draft profile generated by snmp-profile-gen from %s.\n", module)
+	fmt.Fprintln(&buf, "# Review metric names, chart_meta and metric_tags before use.")
+	fmt.Fprintln(&buf)
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(profile); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// validateProfile decodes the rendered profile the way the snmp collector loads
+// profiles from disk and runs the profile definition validation on it.
+func validateProfile(data []byte) error {
+	var def ddprofiledefinition.ProfileDefinition
+	if err := yamlv2.Unmarshal(data, &def); err != nil {
+		return err
+	}
+	return ddprofiledefinition.ValidateEnrichProfile(&def)
+}
@@ -0,0 +1,418 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	yamlv2 "gopkg.in/yaml.v2"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp/ddsnmp/ddprofiledefinition"
+)
+
+func TestRunGeneratesValidProfile(t *testing.T) {
+	dir := t.TempDir()
+	writeTestMIB(t, dir, "TEST-FAN-MIB.mib", testFanMIB)
+	out := filepath.Join(dir, "out", "test-fan.yaml")
+
+	err := run([]string{
+		"--source-dir", dir,
+		"--module", "TEST-FAN-MIB",
+		"--extends", "_system-base.yaml",
+		"--sysobjectid", "1.3.6.1.4.1.99990.*",
+		"--out", out,
+	})
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read profile: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "# Draft profile generated by snmp-profile-gen from TEST-FAN-MIB.") {
+		t.Fatalf("profile header missing:\n%s", data)
+	}
+
+	var def ddprofiledefinition.ProfileDefinition
+	if err := yamlv2.Unmarshal(data, &def); err != nil {
+		t.Fatalf("decode profile: %v", err)
+	}
+	if err := ddprofiledefinition.ValidateEnrichProfile(&def); err != nil {
+		t.Fatalf("validate profile: %v", err)
+	}
+	if len(def.Extends) != 1 || def.Extends[0] != "_system-base.yaml" {
+		t.Fatalf("extends = %v", def.Extends)
+	}
+	if len(def.Selector) != 1 || len(def.Selector[0].SysObjectID.Include) != 1 {
+		t.Fatalf("selector = %#v", def.Selector)
+	}
+
+	// scalars first, then tables: testFanCount, testFanTable, testFanExtTable
+	if got := len(def.Metrics); got != 3 {
+		t.Fatalf("metrics = %d, want 3:\n%s", got, data)
+	}
+
+	scalar := def.Metrics[0]
+	if scalar.Symbol.Name != "testFanCount" || scalar.Symbol.OID != "1.3.6.1.4.1.99990.1.1.0" {
+		t.Fatalf("scalar symbol = %s %s", scalar.Symbol.Name, scalar.Symbol.OID)
+	}
+	if scalar.Symbol.MetricType != ddprofiledefinition.ProfileMetricTypeGauge {
+		t.Fatalf("scalar metric_type = %q", scalar.Symbol.MetricType)
+	}
+	if scalar.Symbol.ChartMeta.Description != "Number of fans" || scalar.Symbol.ChartMeta.Unit != "fans" {
+		t.Fatalf("scalar chart_meta = %#v", scalar.Symbol.ChartMeta)
+	}
+
+	table := def.Metrics[1]
+	if table.Table.Name != "testFanTable" || table.Table.OID != "1.3.6.1.4.1.99990.1.2" {
+		t.Fatalf("table = %#v", table.Table)
+	}
+	symbols := map[string]ddprofiledefinition.SymbolConfig{}
+	for _, sym := range table.Symbols {
+		symbols[sym.Name] = sym
+	}
+	for _, name := range []string{"testFanIndex", "testFanDescr", "testFanRowStatus"} {
+		if _, ok := symbols[name]; ok {
+			t.Fatalf("table symbols unexpectedly include %s", name)
+		}
+	}
+	if sym := symbols["testFanSpeed"]; sym.MetricType != ddprofiledefinition.ProfileMetricTypeGauge || sym.ChartMeta.Unit != "rpm" {
+		t.Fatalf("testFanSpeed = %#v", sym)
+	}
+	if sym := symbols["testFanFailures"]; sym.MetricType != ddprofiledefinition.ProfileMetricTypeRate || sym.ChartMeta.Unit != "{event}/s" {
+		t.Fatalf("testFanFailures = %#v", sym)
+	}
+	status := symbols["testFanStatus"]
+	if status.ChartMeta.Unit != "{status}" {
+		t.Fatalf("testFanStatus unit = %q", status.ChartMeta.Unit)
+	}
+	if v, _ := status.Mapping.Lookup("3"); v != "failed" {
+		t.Fatalf("testFanStatus mapping = %v, want TC labels", status.Mapping)
+	}
+	if len(table.MetricTags) != 1 || table.MetricTags[0].Tag != "test_fan_index" || table.MetricTags[0].Index != 1 {
+		t.Fatalf("table metric_tags = %#v", table.MetricTags)
+	}
+
+	ext := def.Metrics[2]
+	if len(ext.MetricTags) != 1 || ext.MetricTags[0].Index != 1 {
+		t.Fatalf("augmenting table metric_tags = %#v", ext.MetricTags)
+	}
+}
+
+func TestGenerateProfileSelectsObjects(t *testing.T) {
+	dir := t.TempDir()
+	writeTestMIB(t, dir, "TEST-FAN-MIB.mib", testFanMIB)
+
+	src, err := buildSource([]string{dir})
+	if err != nil {
+		t.Fatalf("build source: %v", err)
+	}
+	m, err := loadModules(t.Context(), src, []string{"TEST-FAN-MIB"})
+	if err != nil {
+		t.Fatalf("load modules: %v", err)
+	}
+
+	profile, err := generateProfile(m, generatorOptions{Module: "TEST-FAN-MIB", Tables: []string{"testFanTable"}})
+	if err != nil {
+		t.Fatalf("generate profile: %v", err)
+	}
+	if len(profile.Metrics) != 1 || profile.Metrics[0].Table == nil || profile.Metrics[0].Table.Name != "testFanTable" {
+		t.Fatalf("metrics = %#v, want only testFanTable", profile.Metrics)
+	}
+
+	tests := map[string]generatorOptions{
+		"unknown module": {Module: "NO-SUCH-MIB"},
+		"unknown table":  {Module: "TEST-FAN-MIB", Tables: []string{"noSuchTable"}},
+		"not a table":    {Module: "TEST-FAN-MIB", Tables: []string{"testFanCount"}},
+		"not a scalar":   {Module: "TEST-FAN-MIB", Scalars: []string{"testFanTable"}},
+	}
+	for name, opts := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := generateProfile(m, opts); err == nil {
+				t.Fatalf("generateProfile(%+v) succeeded, want error", opts)
+			}
+		})
+	}
+}
+
+func TestIndexMetricTagsVariableLengthIndex(t *testing.T) {
+	dir := t.TempDir()
+	writeTestMIB(t, dir, "TEST-PEER-MIB.mib", testPeerMIB)
+
+	src, err := buildSource([]string{dir})
+	if err != nil {
+		t.Fatalf("build source: %v", err)
+	}
+	m, err := loadModules(t.Context(), src, []string{"TEST-PEER-MIB"})
+	if err != nil {
+		t.Fatalf("load modules: %v", err)
+	}
+	tbl := m.Module("TEST-PEER-MIB").Object("testPeerTable")
+	tags := indexMetricTags(tbl, tbl.Entry().EffectiveIndexes())
+
+	want := []struct {
+		tag       string
+		index     uint
+		transform []profileIndexTransform
+	}{
+		{tag: "test_peer_type", index: 1},
+		{tag: "test_peer_address", transform: []profileIndexTransform{{Start: 1, End: 4}}},
+		{tag: "test_peer_name", transform: []profileIndexTransform{{Start: 6}}},
+	}
+	if len(tags) != len(want) {
+		t.Fatalf("tags = %#v, want %d tags", tags, len(want))
+	}
+	for i, w := range want {
+		got := tags[i]
+		if got.Tag != w.tag || got.Index != w.index || len(got.IndexTransform) != len(w.transform) {
+			t.Fatalf("tag[%d] = %#v, want %+v", i, got, w)
+		}
+		for j := range w.transform {
+			if got.IndexTransform[j] != w.transform[j] {
+				t.Fatalf("tag[%d] transform = %v, want %v", i, got.IndexTransform, w.transform)
+			}
+		}
+	}
+	if tags[0].Mapping[1] != "ipv4" {
+		t.Fatalf("test_peer_type mapping = %v", tags[0].Mapping)
+	}
+	if tags[1].Symbol == nil || tags[1].Symbol.Format != "ip_address" {
+		t.Fatalf("test_peer_address symbol = %#v", tags[1].Symbol)
+	}
+}
+
+func TestTagName(t *testing.T) {
+	tests := map[string]string{
+		"ifIndex":            "if_index",
+		"upsOutputLineIndex": "ups_output_line_index",
+		"hrSWRunName":        "hr_sw_run_name",
+		"entPhysicalIndex":   "ent_physical_index",
+		"dot1dBasePort":      "dot1d_base_port",
+		"cpm-CPUTotal":       "cpm_cpu_total",
+	}
+	for in, want := range tests {
+		if got := tagName(in); got != want {
+			t.Fatalf("tagName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSummarizeDescription(t *testing.T) {
+	tests := map[string]string{
+		"The current speed.":                     "The current speed",
+		"The current\n        speed. More text.": "The current speed",
+		"v1.2 of the thing":                      "v1.2 of the thing",
+		"":                                       "",
+	}
+	for in, want := range tests {
+		if got := summarizeDescription(in); got != want {
+			t.Fatalf("summarizeDescription(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func writeTestMIB(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatalf("write MIB %s: %v", name, err)
+	}
+}
+
+const testFanMIB = `TEST-FAN-MIB DEFINITIONS ::= BEGIN
+
+IMPORTS
+    MODULE-IDENTITY, OBJECT-TYPE, Integer32, Gauge32, Counter32, enterprises
+        FROM SNMPv2-SMI
+    TEXTUAL-CONVENTION, DisplayString, RowStatus
+        FROM SNMPv2-TC;
+
+testFan MODULE-IDENTITY
+    LAST-UPDATED "202601010000Z"
+    ORGANIZATION "Netdata"
+    CONTACT-INFO ""
+    DESCRIPTION "Test fan module."
+    ::= { enterprises 99990 }
+
+TestFanStatus ::= TEXTUAL-CONVENTION
+    STATUS current
+    DESCRIPTION "Fan status."
+    SYNTAX INTEGER { ok(1), degraded(2), failed(3) }
+
+testFanObjects OBJECT IDENTIFIER ::= { testFan 1 }
+
+testFanCount OBJECT-TYPE
+    SYNTAX Integer32
+    UNITS "fans"
+    MAX-ACCESS read-only
+    STATUS current
+    DESCRIPTION "Number of fans. Includes spares."
+    ::= { testFanObjects 1 }
+
+testFanTable OBJECT-TYPE
+    SYNTAX SEQUENCE OF TestFanEntry
+    MAX-ACCESS not-accessible
+    STATUS current
+    DESCRIPTION "Fans."
+    ::= { testFanObjects 2 }
+
+testFanEntry OBJECT-TYPE
+    SYNTAX TestFanEntry
+    MAX-ACCESS not-accessible
+    STATUS current
+    DESCRIPTION "A fan."
+    INDEX { testFanIndex }
+    ::= { testFanTable 1 }
+
+TestFanEntry ::= SEQUENCE {
+    testFanIndex Integer32,
+    testFanDescr DisplayString,
+    testFanSpeed Gauge32,
+    testFanStatus TestFanStatus,
+    testFanFailures Counter32,
+    testFanRowStatus RowStatus
+}
+
+testFanIndex OBJECT-TYPE
+    SYNTAX Integer32 (1..64)
+    MAX-ACCESS not-accessible
+    STATUS current
+    DESCRIPTION "Fan index."
+    ::= { testFanEntry 1 }
+
+testFanDescr OBJECT-TYPE
+    SYNTAX DisplayString
+    MAX-ACCESS read-only
+    STATUS current
+    DESCRIPTION "Fan description."
+    ::= { testFanEntry 2 }
+
+testFanSpeed OBJECT-TYPE
+    SYNTAX Gauge32
+    UNITS "rpm"
+    MAX-ACCESS read-only
+    STATUS current
+    DESCRIPTION "Fan speed."
+    ::= { testFanEntry 3 }
+
+testFanStatus OBJECT-TYPE
+    SYNTAX TestFanStatus
+    MAX-ACCESS read-only
+    STATUS current
+    DESCRIPTION "Fan status."
+    ::= { testFanEntry 4 }
+
+testFanFailures OBJECT-TYPE
+    SYNTAX Counter32
+    MAX-ACCESS read-only
+    STATUS current
+    DESCRIPTION "Fan failures."
+    ::= { testFanEntry 5 }
+
+testFanRowStatus OBJECT-TYPE
+    SYNTAX RowStatus
+    MAX-ACCESS read-create
+    STATUS current
+    DESCRIPTION "Row status."
+    ::= { testFanEntry 6 }
+
+testFanExtTable OBJECT-TYPE
+    SYNTAX SEQUENCE OF TestFanExtEntry
+    MAX-ACCESS not-accessible
+    STATUS current
+    DESCRIPTION "Fan extensions."
+    ::= { testFanObjects 3 }
+
+testFanExtEntry OBJECT-TYPE
+    SYNTAX TestFanExtEntry
+    MAX-ACCESS not-accessible
+    STATUS current
+    DESCRIPTION "A fan extension."
+    AUGMENTS { testFanEntry }
+    ::= { testFanExtTable 1 }
+
+TestFanExtEntry ::= SEQUENCE {
+    testFanTemperature Integer32
+}
+
+testFanTemperature OBJECT-TYPE
+    SYNTAX Integer32
+    UNITS "Cel"
+    MAX-ACCESS read-only
+    STATUS current
+    DESCRIPTION "Fan temperature."
+    ::= { testFanExtEntry 1 }
+
+END
+`
+
+const testPeerMIB = `TEST-PEER-MIB DEFINITIONS ::= BEGIN
+
+IMPORTS
+    MODULE-IDENTITY, OBJECT-TYPE, Counter32, IpAddress, enterprises
+        FROM SNMPv2-SMI
+    DisplayString
+        FROM SNMPv2-TC;
+
+testPeer MODULE-IDENTITY
+    LAST-UPDATED "202601010000Z"
+    ORGANIZATION "Netdata"
+    CONTACT-INFO ""
+    DESCRIPTION "Test peer module."
+    ::= { enterprises 99991 }
+
+testPeerTable OBJECT-TYPE
+    SYNTAX SEQUENCE OF TestPeerEntry
+    MAX-ACCESS not-accessible
+    STATUS current
+    DESCRIPTION "Peers."
+    ::= { testPeer 1 }
+
+testPeerEntry OBJECT-TYPE
+    SYNTAX TestPeerEntry
+    MAX-ACCESS not-accessible
+    STATUS current
+    DESCRIPTION "A peer."
+    INDEX { testPeerType, testPeerAddress, testPeerName }
+    ::= { testPeerTable 1 }
+
+TestPeerEntry ::= SEQUENCE {
+    testPeerType INTEGER,
+    testPeerAddress IpAddress,
+    testPeerName DisplayString,
+    testPeerMessages Counter32
+}
+
+testPeerType OBJECT-TYPE
+    SYNTAX INTEGER { ipv4(1), ipv6(2) }
+    MAX-ACCESS not-accessible
+    STATUS current
+    DESCRIPTION "Peer type."
+    ::= { testPeerEntry 1 }
+
+testPeerAddress OBJECT-TYPE
+    SYNTAX IpAddress
+    MAX-ACCESS not-accessible
+    STATUS current
+    DESCRIPTION "Peer address."
+    ::= { testPeerEntry 2 }
+
+testPeerName OBJECT-TYPE
+    SYNTAX DisplayString (SIZE(0..32))
+    MAX-ACCESS not-accessible
+    STATUS current
+    DESCRIPTION "Peer name."
+    ::= { testPeerEntry 3 }
+
+testPeerMessages OBJECT-TYPE
+    SYNTAX Counter32
+    MAX-ACCESS read-only
+    STATUS current
+    DESCRIPTION "Messages received from the peer."
+    ::= { testPeerEntry 4 }
+
+END
+`
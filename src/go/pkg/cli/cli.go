@@ -19,6 +19,13 @@ type Option struct {
 	WatchPath   []string `short:"w" long:"watch-path" description:"config path to watch"`
 	Debug       bool     `short:"d" long:"debug" description:"debug mode"`
 	Version     bool     `short:"v" long:"version" description:"display the version and exit"`
+	Validate    bool     `long:"validate" description:"validate config files, print a JSON report and exit"`
+	Dump        string   `long:"dump" description:"run the selected job without the agent, print collected metrics and exit" choice:"json" choice:"openmetrics"`
+	DumpCycles  int      `long:"dump-cycles" description:"number of collection cycles in dump mode" default:"1"`
+	Record      string   `long:"record" description:"record the HTTP, SQL and socket traffic of collectors to a fixture bundle file"`
+	RecordBody  bool     `long:"record-bodies" description:"also record HTTP request bodies, which may contain credentials"`
+	Replay      string   `long:"replay" description:"serve collector HTTP, SQL and socket traffic from a fixture bundle file"`
+	Import      []string `long:"import" description:"convert foreign config files to jobs of the module selected with -m, print them as YAML and exit"`
 }
 
 // Parse returns parsed command-line flags in Option struct
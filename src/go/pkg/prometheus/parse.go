@@ -95,6 +95,14 @@ func (p *promTextParser) parseToSamples(text []byte) (SampleBatch, error) {
 	return batch, nil
 }
 
+// ParseSamples parses an in-memory exposition, such as a node_exporter textfile
+// or the output of a script, into the same flat sample stream ScrapeSamples
+// returns.
+func ParseSamples(text []byte) (SampleBatch, error) {
+	var p promTextParser
+	return p.parseToSamples(text)
+}
+
 // parseDriver runs the single exposition parse pass (iterate). On top of it,
 // parseSamples emits a flat, classified sample stream, deferring a _sum/_count
 // whose family type is not yet known and back-resolving it once the type appears
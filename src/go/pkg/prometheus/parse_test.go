@@ -2035,6 +2035,20 @@ my_summary{quantile="0.5"} 0.5
 // The stream supports Prometheus-style relabeling on __name__/le/quantile.
 // ownLabels=true isolates each sample's labels, so a transform can rename via
 // Name and mutate Labels in place without affecting later samples.
+func TestParseSamples(t *testing.T) {
+	batch, err := ParseSamples([]byte("# TYPE jobs_total counter\njobs_total{queue=\"a\"} 3\nload 0.5\n"))
+	require.NoError(t, err)
+	require.Len(t, batch.Samples, 2)
+	assert.Equal(t, "jobs_total", batch.Samples[0].Name)
+	assert.Equal(t, model.MetricTypeCounter, batch.Samples[0].FamilyType)
+	assert.Equal(t, "a", batch.Samples[0].Labels.Get("queue"))
+	assert.Equal(t, "load", batch.Samples[1].Name)
+	assert.Equal(t, 0.5, batch.Samples[1].Value)
+
+	_, err = ParseSamples([]byte("load{ 1\n"))
+	assert.Error(t, err)
+}
+
 func TestPromTextParser_parseSamples_relabelStyle(t *testing.T) {
 	data := []byte(`# TYPE req_seconds histogram
 req_seconds_bucket{le="0.1",path="/a"} 1
@@ -55,6 +55,9 @@ func NewHTTPClient(cfg ClientConfig) (*http.Client, error) {
 	if err != nil {
 		return nil, err
 	}
+	if hook := transportHook.Load(); hook != nil {
+		transport = (*hook)(transport)
+	}
 
 	client := &http.Client{
 		Timeout:       cfg.Timeout.Duration(),
@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package web
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// TransportHook wraps the transport of a client created by NewHTTPClient.
+type TransportHook func(http.RoundTripper) http.RoundTripper
+
+var transportHook atomic.Pointer[TransportHook]
+
+// SetTransportHook installs fn for every client created by NewHTTPClient
+// afterwards. A nil fn removes the hook. It exists to record and replay
+// collector traffic and is not meant for regular use.
+func SetTransportHook(fn TransportHook) {
+	if fn == nil {
+		transportHook.Store(nil)
+		return
+	}
+	transportHook.Store(&fn)
+}
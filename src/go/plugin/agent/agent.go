@@ -8,6 +8,7 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/netdata/netdata/go/plugins/pkg/netdataapi"
 	"github.com/netdata/netdata/go/plugins/pkg/safewriter"
 	"github.com/netdata/netdata/go/plugins/plugin/agent/discovery"
+	"github.com/netdata/netdata/go/plugins/plugin/agent/discovery/sd"
 	"github.com/netdata/netdata/go/plugins/plugin/agent/jobmgr/composition"
 	"github.com/netdata/netdata/go/plugins/plugin/agent/policy"
 	"github.com/netdata/netdata/go/plugins/plugin/agent/runtimechartemit"
@@ -50,6 +52,10 @@ type Config struct {
 	RunModePolicy policy.RunModePolicy
 
 	DiscoveryProviders []discovery.ProviderFactory
+
+	// Discoverers are the service discovery types used by Validate to check
+	// pipeline config files.
+	Discoverers sd.Registry
 }
 
 // Agent represents orchestrator.
@@ -77,6 +83,7 @@ type Agent struct {
 	runModePolicy policy.RunModePolicy
 
 	DiscoveryProviders []discovery.ProviderFactory
+	Discoverers        sd.Registry
 
 	ModuleRegistry collectorapi.Registry
 	In             io.Reader
@@ -108,6 +115,7 @@ func New(cfg Config) *Agent {
 		runModePolicy:             cfg.RunModePolicy,
 		ModuleRegistry:            cfg.ModuleRegistry,
 		DiscoveryProviders:        cfg.DiscoveryProviders,
+		Discoverers:               cfg.Discoverers,
 		In:                        os.Stdin,
 		Out:                       safewriter.Stdout,
 		DisableServiceDiscovery:   cfg.DisableServiceDiscovery,
@@ -187,6 +195,7 @@ func (a *Agent) run(ctx context.Context) error {
 		InitialSecrets:        a.setupSecretStoreConfigs(),
 		InitialVnodes:         a.setupVnodeRegistry(),
 		Runtime:               a.setupRuntimeService(),
+		ConfigHistoryDir:      a.configHistoryDir(),
 		KeepAlive:             !a.runModePolicy.IsTerminal,
 		ShutdownTimeout:       a.ShutdownTimeout,
 	})
@@ -221,6 +230,15 @@ func (a *Agent) setupRuntimeService() composition.RuntimeService {
 	)
 }
 
+// configHistoryDir returns the directory where dyncfg job revisions are kept.
+// History is disabled for terminal runs and when no state directory is known.
+func (a *Agent) configHistoryDir() string {
+	if a == nil || a.VarLibDir == "" || a.runModePolicy.IsTerminal {
+		return ""
+	}
+	return filepath.Join(a.VarLibDir, "config-history", a.Name)
+}
+
 func (a *Agent) markProcessReady() {
 	if a != nil {
 		a.readyOnce.Do(func() { close(a.processReady) })
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package configcheck validates collector job, service discovery and
+// secretstore config files offline: nothing is collected, no discovery runs
+// and no secretstore backend is contacted.
+package configcheck
+
+import (
+	"context"
+	"errors"
+
+	"github.com/netdata/netdata/go/plugins/plugin/agent/discovery/sd"
+	secretresolver "github.com/netdata/netdata/go/plugins/plugin/agent/secrets/resolver"
+	"github.com/netdata/netdata/go/plugins/plugin/agent/secrets/secretstore"
+	"github.com/netdata/netdata/go/plugins/plugin/agent/secrets/secretstore/backends"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/confgroup"
+)
+
+type Config struct {
+	Plugin string
+
+	// Modules are the enabled modules. JobFiles maps a module name to its
+	// config file; modules without a file are not checked.
+	Modules  collectorapi.Registry
+	Defaults confgroup.Registry
+	JobFiles map[string]string
+
+	// SDFiles are service discovery pipeline files checked against Discoverers.
+	SDFiles     []string
+	Discoverers sd.Registry
+
+	// SecretStoreRoots are the directories whose "ss/" subdirectory holds
+	// secretstore config files.
+	SecretStoreRoots []string
+}
+
+type checker struct {
+	Config
+	report   *Report
+	resolver *secretresolver.AtomicResolver
+	stores   *secretstore.CreatorCatalog
+	schemas  map[string]*jobSchema
+}
+
+// Run validates all configured files and returns the report.
+func Run(ctx context.Context, cfg Config) (*Report, error) {
+	if ctx == nil {
+		return nil, errors.New("configcheck: nil context")
+	}
+	resolver, err := secretresolver.NewDefaultAtomicResolver()
+	if err != nil {
+		return nil, err
+	}
+	stores, err := secretstore.NewCreatorCatalog(backends.Creators())
+	if err != nil {
+		return nil, err
+	}
+
+	c := &checker{
+		Config:   cfg,
+		report:   &Report{Plugin: cfg.Plugin},
+		resolver: resolver,
+		stores:   stores,
+		schemas:  make(map[string]*jobSchema),
+	}
+	for _, module := range sortedKeys(cfg.JobFiles) {
+		c.checkJobFile(ctx, module, cfg.JobFiles[module])
+	}
+	for _, path := range cfg.SDFiles {
+		c.checkSDFile(path)
+	}
+	c.checkSecretStores(ctx)
+
+	c.report.finish()
+	return c.report, nil
+}
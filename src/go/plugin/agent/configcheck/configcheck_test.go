@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package configcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/netdata/netdata/go/plugins/plugin/agent/discovery/sd"
+	"github.com/netdata/netdata/go/plugins/plugin/agent/discovery/sd/model"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/confgroup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testJobConfigSchema = `{
+  "jsonSchema": {
+    "$schema": "http://json-schema.org/draft-07/schema#",
+    "type": "object",
+    "properties": {
+      "option_str": {"type": "string"},
+      "option_int": {"type": "integer", "minimum": 0}
+    }
+  },
+  "uiSchema": {}
+}`
+
+const testJobFile = `jobs:
+  - name: ok
+    option_str: a
+    option_int: 1
+
+  - name: negative
+    option_str: a
+    option_int: -1
+
+  - name: fails_init
+    option_str: fail
+
+  - name: ok
+
+  - name: not_a_number
+    option_int: many
+
+  - name: from_store
+    option_str: ${store:vault:prod:password}
+`
+
+const testSDFile = `name: local
+discoverer:
+  fixture: {}
+services:
+  - id: any
+    match: 'true'
+`
+
+const testSecretStoreFile = `jobs:
+  - mode: token
+`
+
+type wantIssue struct {
+	file     string
+	line     int
+	job      string
+	stage    Stage
+	severity Severity
+}
+
+func TestRun(t *testing.T) {
+	// Paths without "/etc/" are treated as stock configs.
+	dir := filepath.Join(t.TempDir(), "etc", "netdata")
+	jobPath := writeFile(t, filepath.Join(dir, "mock.conf"), testJobFile)
+	brokenPath := writeFile(t, filepath.Join(dir, "broken.conf"), "jobs:\n  - name: a\n    b: [\n")
+	sdPath := writeFile(t, filepath.Join(dir, "sd", "local.conf"), testSDFile)
+	sdUnsupportedPath := writeFile(t, filepath.Join(dir, "sd", "other.conf"), "name: other\ndiscoverer:\n  other: {}\n")
+	writeFile(t, filepath.Join(dir, "ss", "vault.conf"), testSecretStoreFile)
+
+	creator := collectorapi.Creator{
+		JobConfigSchema: testJobConfigSchema,
+		Create: func() collectorapi.CollectorV1 {
+			m := &collectorapi.MockCollectorV1{}
+			m.InitFunc = func(context.Context) error {
+				if m.Config.OptionStr == "fail" {
+					return errors.New("option_str must not be 'fail'")
+				}
+				return nil
+			}
+			return m
+		},
+	}
+
+	report, err := Run(context.Background(), Config{
+		Plugin:   "go.d",
+		Modules:  collectorapi.Registry{"mock": creator, "broken": creator},
+		Defaults: confgroup.Registry{"mock": {}, "broken": {}},
+		JobFiles: map[string]string{"mock": jobPath, "broken": brokenPath},
+		SDFiles:  []string{sdPath, sdUnsupportedPath},
+		Discoverers: sd.NewRegistry(sd.Descriptor{
+			Type:            "fixture",
+			ParseJSONConfig: func(raw json.RawMessage) (any, error) { return raw, nil },
+			NewDiscoverers:  func(any, string) ([]model.Discoverer, error) { return nil, nil },
+		}),
+		SecretStoreRoots: []string{dir},
+	})
+	require.NoError(t, err)
+
+	var got []wantIssue
+	for _, issue := range report.Issues {
+		got = append(got, wantIssue{
+			file:     filepath.Base(issue.File),
+			line:     issue.Line,
+			job:      issue.Job,
+			stage:    issue.Stage,
+			severity: issue.Severity,
+		})
+	}
+	assert.Equal(t, []wantIssue{
+		{file: "broken.conf", line: 3, stage: StageParse, severity: SeverityError},
+		{file: "mock.conf", line: 8, job: "negative", stage: StageSchema, severity: SeverityError},
+		{file: "mock.conf", line: 10, job: "fails_init", stage: StageInit, severity: SeverityError},
+		{file: "mock.conf", line: 13, job: "ok", stage: StageConfig, severity: SeverityError},
+		{file: "mock.conf", line: 15, job: "not_a_number", stage: StageConfig, severity: SeverityError},
+		{file: "mock.conf", line: 18, job: "from_store", stage: StageSecrets, severity: SeverityInfo},
+		{file: "other.conf", stage: StageConfig, severity: SeverityWarning},
+		{file: "vault.conf", line: 2, stage: StageConfig, severity: SeverityError},
+	}, got)
+
+	assert.False(t, report.Valid)
+	assert.Equal(t, Summary{Files: 4, Jobs: 6, Pipelines: 2, Errors: 6, Warnings: 1}, report.Summary)
+}
+
+func TestRun_Valid(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, filepath.Join(dir, "mock.conf"), "jobs:\n  - name: ok\n    option_str: a\n")
+
+	report, err := Run(context.Background(), Config{
+		Plugin:   "go.d",
+		Modules:  collectorapi.Registry{"mock": {Create: func() collectorapi.CollectorV1 { return &collectorapi.MockCollectorV1{} }}},
+		JobFiles: map[string]string{"mock": path},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, report.Valid)
+	assert.Empty(t, report.Issues)
+	assert.Equal(t, 1, report.Summary.Jobs)
+}
+
+func writeFile(t *testing.T, path, content string) string {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
@@ -0,0 +1,240 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package configcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/logger"
+	secretresolver "github.com/netdata/netdata/go/plugins/plugin/agent/secrets/resolver"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/confgroup"
+	"gopkg.in/yaml.v2"
+	yamlnode "gopkg.in/yaml.v3"
+)
+
+type configModule interface {
+	GetBase() *collectorapi.Base
+	Init(context.Context) error
+	Cleanup(context.Context)
+	Configuration() any
+}
+
+type staticConfig struct {
+	Default confgroup.Default  `yaml:"default"`
+	Jobs    []confgroup.Config `yaml:"jobs"`
+}
+
+type jobFile struct {
+	path  string
+	jobs  []confgroup.Config
+	nodes []*yamlnode.Node
+	def   confgroup.Default
+	seen  map[string]int
+}
+
+// checkJobFile validates a module config file in either of the formats read by
+// file discovery: a {default, jobs} mapping or a list of jobs with a module key.
+func (c *checker) checkJobFile(ctx context.Context, module, path string) {
+	c.report.Summary.Files++
+
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		c.jobIssue(path, 0, module, "", StageParse, SeverityError, err.Error())
+		return
+	}
+	var doc yamlnode.Node
+	if err := yamlnode.Unmarshal(bs, &doc); err != nil {
+		c.jobIssue(path, yamlErrorLine(err), module, "", StageParse, SeverityError, err.Error())
+		return
+	}
+	if len(doc.Content) == 0 {
+		return
+	}
+
+	file := jobFile{path: path, seen: make(map[string]int)}
+	root := doc.Content[0]
+	switch root.Kind {
+	case yamlnode.MappingNode:
+		var static staticConfig
+		if err := yaml.Unmarshal(bs, &static); err != nil {
+			c.jobIssue(path, yamlErrorLine(err), module, "", StageParse, SeverityError, err.Error())
+			return
+		}
+		for _, job := range static.Jobs {
+			if job != nil {
+				job.SetModule(module)
+			}
+		}
+		file.jobs, file.def = static.Jobs, static.Default
+		if jobs := mappingValue(root, "jobs"); jobs != nil && jobs.Kind == yamlnode.SequenceNode {
+			file.nodes = jobs.Content
+		}
+	case yamlnode.SequenceNode:
+		if err := yaml.Unmarshal(bs, &file.jobs); err != nil {
+			c.jobIssue(path, yamlErrorLine(err), module, "", StageParse, SeverityError, err.Error())
+			return
+		}
+		file.nodes = root.Content
+	default:
+		c.jobIssue(path, root.Line, module, "", StageParse, SeverityError, "unknown file format")
+		return
+	}
+	if len(file.nodes) != len(file.jobs) {
+		file.nodes = nil
+	}
+
+	for i, job := range file.jobs {
+		var node *yamlnode.Node
+		if file.nodes != nil {
+			node = file.nodes[i]
+		}
+		c.checkJob(ctx, &file, node, job)
+	}
+}
+
+func (c *checker) checkJob(ctx context.Context, file *jobFile, node *yamlnode.Node, job confgroup.Config) {
+	c.report.Summary.Jobs++
+	line := nodeLine(node)
+
+	if len(job) == 0 {
+		c.jobIssue(file.path, line, "", "", StageConfig, SeverityError, "job config is empty")
+		return
+	}
+	module := job.Module()
+	creator, ok := c.Modules[module]
+	if module == "" || !ok {
+		c.jobIssue(file.path, line, module, job.Name(), StageConfig, SeverityError,
+			fmt.Sprintf("module '%s' is not registered or not enabled", module))
+		return
+	}
+	def, _ := c.Defaults.Lookup(module)
+	job.ApplyDefaults(mergeDefaults(file.def, def))
+
+	name := job.Name()
+	if first, ok := file.seen[name]; ok {
+		c.jobIssue(file.path, line, module, name, StageConfig, SeverityError,
+			fmt.Sprintf("duplicate job name '%s' (first defined on line %d)", name, first))
+		return
+	}
+	file.seen[name] = line
+
+	keys, err := secretresolver.StoreReferences(map[string]any(job))
+	if err != nil {
+		c.jobIssue(file.path, line, module, name, StageSecrets, SeverityError, err.Error())
+		return
+	}
+	if len(keys) > 0 {
+		c.jobIssue(file.path, line, module, name, StageSecrets, SeverityInfo,
+			fmt.Sprintf("uses secretstore references (%s), schema and Init checks skipped", strings.Join(keys, ", ")))
+		return
+	}
+	resolved, redact, err := c.resolver.ResolveWithReferences(ctx, map[string]any(job), nil)
+	if err != nil {
+		c.jobIssue(file.path, line, module, name, StageSecrets, SeverityError, err.Error())
+		return
+	}
+
+	instance, err := constructModule(creator)
+	if err != nil {
+		c.jobIssue(file.path, line, module, name, StageInit, SeverityError, err.Error())
+		return
+	}
+	instance.GetBase().Logger = logger.New().With(
+		slog.String("collector", module),
+		slog.String("job", name),
+	)
+	defer func() {
+		if err := callModule(func() error { instance.Cleanup(context.WithoutCancel(ctx)); return nil }); err != nil {
+			c.jobIssue(file.path, line, module, name, StageInit, SeverityWarning, redacted(err, redact))
+		}
+	}()
+
+	payload, err := yaml.Marshal(resolved)
+	if err == nil {
+		err = callModule(func() error { return yaml.Unmarshal(payload, instance) })
+	}
+	if err != nil {
+		c.jobIssue(file.path, line, module, name, StageConfig, SeverityError, redacted(err, redact))
+		return
+	}
+
+	if c.checkSchema(file.path, node, module, name, creator, instance, redact) {
+		return
+	}
+
+	if err := callModule(func() error { return instance.Init(ctx) }); err != nil {
+		c.jobIssue(file.path, line, module, name, StageInit, SeverityError, redacted(err, redact))
+	}
+}
+
+func (c *checker) jobIssue(path string, line int, module, job string, stage Stage, severity Severity, msg string) {
+	c.report.add(Issue{
+		Kind:     KindJob,
+		File:     path,
+		Line:     line,
+		Module:   module,
+		Job:      job,
+		Stage:    stage,
+		Severity: severity,
+		Message:  msg,
+	})
+}
+
+func constructModule(creator collectorapi.Creator) (module configModule, err error) {
+	err = callModule(func() error {
+		switch {
+		case creator.CreateV2 != nil:
+			module = creator.CreateV2()
+		case creator.Create != nil:
+			module = creator.Create()
+		}
+		if module == nil || module.GetBase() == nil {
+			return errors.New("collector creator returned no module")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return module, nil
+}
+
+// callModule runs collector code, turning a panic into an error.
+func callModule(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn()
+}
+
+// redacted hides error details derived from resolved secret values, matching
+// what the agent logs at runtime.
+func redacted(err error, redact bool) string {
+	if redact {
+		return "validation failed; details redacted because the config resolves secret references"
+	}
+	return err.Error()
+}
+
+func mergeDefaults(file, module confgroup.Default) confgroup.Default {
+	firstPositive := func(a, b int) int {
+		if a > 0 {
+			return a
+		}
+		return b
+	}
+	return confgroup.Default{
+		MinUpdateEvery:     firstPositive(file.MinUpdateEvery, module.MinUpdateEvery),
+		UpdateEvery:        firstPositive(file.UpdateEvery, module.UpdateEvery),
+		AutoDetectionRetry: firstPositive(file.AutoDetectionRetry, module.AutoDetectionRetry),
+		Priority:           firstPositive(file.Priority, module.Priority),
+	}
+}
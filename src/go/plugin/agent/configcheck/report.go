@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package configcheck
+
+import (
+	"cmp"
+	"encoding/json"
+	"io"
+	"slices"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Kind is the type of config file an Issue belongs to.
+type Kind string
+
+const (
+	KindJob         Kind = "job"
+	KindSD          Kind = "sd"
+	KindSecretStore Kind = "secretstore"
+)
+
+// Stage is the validation step that produced an Issue.
+type Stage string
+
+const (
+	StageParse   Stage = "parse"
+	StageConfig  Stage = "config"
+	StageSecrets Stage = "secrets"
+	StageSchema  Stage = "schema"
+	StageInit    Stage = "init"
+)
+
+// Issue is one finding in a config file. Line is 1-based and zero when the
+// location within the file is unknown.
+type Issue struct {
+	Kind     Kind     `json:"kind"`
+	File     string   `json:"file"`
+	Line     int      `json:"line,omitempty"`
+	Module   string   `json:"module,omitempty"`
+	Job      string   `json:"job,omitempty"`
+	Stage    Stage    `json:"stage"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+type Summary struct {
+	Files        int `json:"files"`
+	Jobs         int `json:"jobs"`
+	Pipelines    int `json:"pipelines"`
+	SecretStores int `json:"secretstores"`
+	Errors       int `json:"errors"`
+	Warnings     int `json:"warnings"`
+}
+
+// Report is the machine-readable result of a validation run.
+type Report struct {
+	Plugin  string  `json:"plugin"`
+	Valid   bool    `json:"valid"`
+	Summary Summary `json:"summary"`
+	Issues  []Issue `json:"issues"`
+}
+
+func (r *Report) add(issue Issue) {
+	switch issue.Severity {
+	case SeverityError:
+		r.Summary.Errors++
+	case SeverityWarning:
+		r.Summary.Warnings++
+	}
+	r.Issues = append(r.Issues, issue)
+}
+
+func (r *Report) finish() {
+	if r.Issues == nil {
+		r.Issues = []Issue{}
+	}
+	slices.SortStableFunc(r.Issues, func(a, b Issue) int {
+		return cmp.Or(cmp.Compare(a.File, b.File), cmp.Compare(a.Line, b.Line))
+	})
+	r.Valid = r.Summary.Errors == 0
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package configcheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	yamlnode "gopkg.in/yaml.v3"
+)
+
+type jobSchema struct {
+	schema *jsonschema.Schema
+	err    error
+}
+
+// moduleSchema compiles a collector's job config schema. A nil schema with a
+// nil error means the collector has no schema to check.
+func (c *checker) moduleSchema(module string, creator collectorapi.Creator) (*jsonschema.Schema, error) {
+	if s, ok := c.schemas[module]; ok {
+		return s.schema, s.err
+	}
+	s := &jobSchema{}
+	c.schemas[module] = s
+
+	if strings.TrimSpace(creator.JobConfigSchema) == "" {
+		return nil, nil
+	}
+	var doc struct {
+		JSONSchema json.RawMessage `json:"jsonSchema"`
+	}
+	if s.err = json.Unmarshal([]byte(creator.JobConfigSchema), &doc); s.err != nil {
+		return nil, s.err
+	}
+	// Collectors embed config_schema.json ({"jsonSchema": ..., "uiSchema": ...});
+	// a bare JSON schema is accepted as well.
+	if len(doc.JSONSchema) == 0 {
+		doc.JSONSchema = json.RawMessage(creator.JobConfigSchema)
+	}
+	raw, err := jsonschema.UnmarshalJSON(bytes.NewReader(doc.JSONSchema))
+	if err != nil {
+		s.err = err
+		return nil, err
+	}
+	compiler := jsonschema.NewCompiler()
+	url := module + ".config_schema.json"
+	if s.err = compiler.AddResource(url, raw); s.err != nil {
+		return nil, s.err
+	}
+	s.schema, s.err = compiler.Compile(url)
+	return s.schema, s.err
+}
+
+// checkSchema validates the effective job configuration, the module config
+// with the job file applied, against the collector schema. This is the value
+// the dyncfg UI edits, so defaults filled in by the collector count as set.
+// It reports whether any error was found.
+func (c *checker) checkSchema(
+	path string,
+	node *yamlnode.Node,
+	module, job string,
+	creator collectorapi.Creator,
+	instance configModule,
+	redact bool,
+) bool {
+	schema, err := c.moduleSchema(module, creator)
+	if err != nil {
+		c.jobIssue(path, nodeLine(node), module, job, StageSchema, SeverityWarning,
+			fmt.Sprintf("collector config schema cannot be compiled: %v", err))
+		return false
+	}
+	if schema == nil {
+		return false
+	}
+
+	var value any
+	err = callModule(func() error { value = instance.Configuration(); return nil })
+	if err != nil || value == nil {
+		return false
+	}
+	bs, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(bs))
+	if err != nil {
+		return false
+	}
+	inst = pruneUnset(inst)
+
+	err = schema.Validate(inst)
+	var verr *jsonschema.ValidationError
+	if !errors.As(err, &verr) {
+		return false
+	}
+	found := false
+	for _, unit := range verr.BasicOutput().Errors {
+		if unit.Error == nil {
+			continue
+		}
+		tokens := pointerTokens(unit.InstanceLocation)
+		msg := unit.Error.String()
+
+		// Values written in the job file are errors. The rest come from
+		// collector defaults, which the schema (made for the dyncfg form) may
+		// be stricter about than the collector itself, so Init has the final
+		// say on them.
+		severity := SeverityWarning
+		if len(tokens) > 0 && nodeAt(node, tokens) != nil {
+			severity = SeverityError
+		}
+		if additional, ok := unit.Error.Kind.(*kind.AdditionalProperties); ok {
+			// Only unknown keys written in the job file are the user's to fix.
+			var written []string
+			for _, prop := range additional.Properties {
+				if mappingValue(nodeAt(node, tokens), prop) != nil {
+					written = append(written, "'"+prop+"'")
+				}
+			}
+			if len(written) == 0 {
+				continue
+			}
+			msg = fmt.Sprintf("additional properties %s not allowed", strings.Join(written, ", "))
+			severity = SeverityError
+		}
+		if severity == SeverityError {
+			found = true
+		}
+		if redact {
+			msg = "invalid value; details redacted because the config resolves secret references"
+		}
+		if unit.InstanceLocation != "" {
+			msg = unit.InstanceLocation + ": " + msg
+		}
+		c.jobIssue(path, locate(node, tokens), module, job, StageSchema, severity, msg)
+	}
+	return found
+}
+
+func pointerTokens(pointer string) []string {
+	pointer = strings.TrimPrefix(pointer, "/")
+	if pointer == "" {
+		return nil
+	}
+	tokens := strings.Split(pointer, "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens
+}
+
+// pruneUnset drops null object members. Collectors marshal unset optional
+// slices and maps that way, and the schema treats them as absent.
+func pruneUnset(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if value == nil {
+				delete(v, key)
+				continue
+			}
+			v[key] = pruneUnset(value)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = pruneUnset(value)
+		}
+	}
+	return v
+}
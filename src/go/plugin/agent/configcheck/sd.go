@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package configcheck
+
+import (
+	"errors"
+	"os"
+
+	"github.com/netdata/netdata/go/plugins/plugin/agent/discovery/sd"
+)
+
+func (c *checker) checkSDFile(path string) {
+	c.report.Summary.Files++
+
+	issue := func(stage Stage, severity Severity, line int, msg string) {
+		c.report.add(Issue{Kind: KindSD, File: path, Line: line, Stage: stage, Severity: severity, Message: msg})
+	}
+
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		issue(StageParse, SeverityError, 0, err.Error())
+		return
+	}
+	if len(bs) == 0 {
+		return
+	}
+	c.report.Summary.Pipelines++
+
+	skipped, err := sd.ValidateFileConfig(bs, path, c.Defaults, c.Discoverers)
+	switch {
+	case errors.Is(err, sd.ErrUnsupportedDiscoverer):
+		issue(StageConfig, SeverityWarning, 0, err.Error()+", the pipeline will not run")
+	case err != nil:
+		issue(StageConfig, SeverityError, yamlErrorLine(err), err.Error())
+	case skipped:
+		issue(StageConfig, SeverityInfo, 0, "pipeline is disabled or not supported, not validated")
+	}
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package configcheck
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/agent/secrets/secretstore"
+	yamlnode "gopkg.in/yaml.v3"
+)
+
+// checkSecretStores loads the "ss/" config files the way the agent does and
+// validates every loaded store config with its backend, without testing it.
+func (c *checker) checkSecretStores(ctx context.Context) {
+	if len(c.SecretStoreRoots) == 0 {
+		return
+	}
+	cfgs, errs := secretstore.LoadFileConfigs(c.SecretStoreRoots)
+	nodes := make(map[string][]*yamlnode.Node)
+
+	for _, err := range errs {
+		var fileErr *secretstore.FileConfigError
+		if !errors.As(err, &fileErr) {
+			c.storeIssue("", 0, "", SeverityError, StageParse, err.Error())
+			continue
+		}
+		line := yamlErrorLine(fileErr.Err)
+		if fileErr.Job > 0 {
+			if jobs := c.storeJobNodes(nodes, fileErr.Path); fileErr.Job <= len(jobs) {
+				line = jobs[fileErr.Job-1].Line
+			}
+		}
+		stage := StageConfig
+		if fileErr.Job == 0 {
+			stage = StageParse
+		}
+		c.storeIssue(fileErr.Path, line, "", SeverityError, stage, fileErr.Err.Error())
+	}
+	if len(cfgs) == 0 {
+		return
+	}
+
+	store, err := secretstore.NewSecretStore(c.resolver)
+	if err != nil {
+		c.storeIssue("", 0, "", SeverityError, StageInit, err.Error())
+		return
+	}
+	defer func() { _ = store.Close(context.WithoutCancel(ctx)) }()
+
+	for _, cfg := range cfgs {
+		c.report.Summary.SecretStores++
+		path := strings.TrimPrefix(cfg.Source(), "file=")
+		if err := store.Validate(ctx, c.stores, cfg); err != nil {
+			line := 0
+			for _, job := range c.storeJobNodes(nodes, path) {
+				if name := mappingValue(job, "name"); name != nil && name.Value == cfg.Name() {
+					line = job.Line
+					break
+				}
+			}
+			c.storeIssue(path, line, cfg.Name(), SeverityError, StageInit, err.Error())
+		}
+	}
+}
+
+func (c *checker) storeJobNodes(cache map[string][]*yamlnode.Node, path string) []*yamlnode.Node {
+	if jobs, ok := cache[path]; ok {
+		return jobs
+	}
+	var jobs []*yamlnode.Node
+	if bs, err := os.ReadFile(path); err == nil {
+		var doc yamlnode.Node
+		if yamlnode.Unmarshal(bs, &doc) == nil && len(doc.Content) > 0 {
+			if seq := mappingValue(doc.Content[0], "jobs"); seq != nil && seq.Kind == yamlnode.SequenceNode {
+				jobs = seq.Content
+			}
+		}
+	}
+	cache[path] = jobs
+	return jobs
+}
+
+func (c *checker) storeIssue(path string, line int, name string, severity Severity, stage Stage, msg string) {
+	c.report.add(Issue{
+		Kind:     KindSecretStore,
+		File:     path,
+		Line:     line,
+		Job:      name,
+		Stage:    stage,
+		Severity: severity,
+		Message:  msg,
+	})
+}
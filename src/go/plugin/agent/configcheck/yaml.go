@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package configcheck
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+
+	yamlnode "gopkg.in/yaml.v3"
+)
+
+var reYAMLErrorLine = regexp.MustCompile(`line (\d+)`)
+
+// yamlErrorLine extracts the first line number mentioned by a YAML decoder error.
+func yamlErrorLine(err error) int {
+	m := reYAMLErrorLine.FindStringSubmatch(err.Error())
+	if m == nil {
+		return 0
+	}
+	line, _ := strconv.Atoi(m[1])
+	return line
+}
+
+func nodeLine(node *yamlnode.Node) int {
+	if node == nil {
+		return 0
+	}
+	return node.Line
+}
+
+func mappingValue(node *yamlnode.Node, key string) *yamlnode.Node {
+	if node == nil || node.Kind != yamlnode.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// locate returns the line of the deepest node reachable by path, falling back
+// to the line of node itself.
+func locate(node *yamlnode.Node, path []string) int {
+	line := nodeLine(node)
+	for _, token := range path {
+		if node == nil {
+			break
+		}
+		var next *yamlnode.Node
+		switch node.Kind {
+		case yamlnode.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == token {
+					line = node.Content[i].Line
+					next = node.Content[i+1]
+					break
+				}
+			}
+		case yamlnode.SequenceNode:
+			if idx, err := strconv.Atoi(token); err == nil && idx >= 0 && idx < len(node.Content) {
+				next = node.Content[idx]
+				line = next.Line
+			}
+		}
+		node = next
+	}
+	return line
+}
+
+// nodeAt returns the node reachable by path, or nil.
+func nodeAt(node *yamlnode.Node, path []string) *yamlnode.Node {
+	for _, token := range path {
+		switch {
+		case node == nil:
+			return nil
+		case node.Kind == yamlnode.MappingNode:
+			node = mappingValue(node, token)
+		case node.Kind == yamlnode.SequenceNode:
+			idx, err := strconv.Atoi(token)
+			if err != nil || idx < 0 || idx >= len(node.Content) {
+				return nil
+			}
+			node = node.Content[idx]
+		default:
+			return nil
+		}
+	}
+	return node
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	return slices.Sorted(maps.Keys(m))
+}
@@ -21,6 +21,13 @@ const (
 	sdFormat
 )
 
+// Parse reads a module config file in either of the supported formats and
+// returns its job configs with module defaults applied. Empty files yield a nil
+// group.
+func Parse(reg confgroup.Registry, path string) (*confgroup.Group, error) {
+	return parse(reg, path)
+}
+
 func parse(req confgroup.Registry, path string) (*confgroup.Group, error) {
 	bs, err := os.ReadFile(path)
 	if err != nil {
@@ -4,10 +4,13 @@ package sd
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 
 	"github.com/netdata/netdata/go/plugins/logger"
 	"github.com/netdata/netdata/go/plugins/pkg/multipath"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/confgroup"
 )
 
 type confFile struct {
@@ -67,3 +70,36 @@ func (c *confFileReader) run(ctx context.Context) {
 func (c *confFileReader) configs() chan confFile {
 	return c.confChan
 }
+
+// ErrUnsupportedDiscoverer is returned by ValidateFileConfig for a user config
+// whose discoverer type is not in the registry. Such configs are skipped with
+// a warning at runtime.
+var ErrUnsupportedDiscoverer = errors.New("unsupported discoverer type")
+
+// ValidateFileConfig checks one pipeline config file without starting it.
+// It applies the same checks as loading the file at runtime plus full
+// pipeline validation. Disabled pipelines and stock configs for unsupported
+// discoverers are not validated and are reported as skipped.
+func ValidateFileConfig(content []byte, source string, configDefaults confgroup.Registry, reg Registry) (skipped bool, err error) {
+	scfg, err := newSDConfigFromYAML(content, source, sourceTypeFromPath(source), pipelineKeyFromSource(source))
+	if err != nil {
+		return false, err
+	}
+	if disabled, _ := scfg["disabled"].(bool); disabled {
+		return true, nil
+	}
+	if scfg.DiscovererType() == "" {
+		return false, errors.New("no discoverer configured")
+	}
+	if _, ok := reg.Get(scfg.DiscovererType()); !ok {
+		if scfg.SourceType() == confgroup.TypeStock {
+			return true, nil
+		}
+		return false, fmt.Errorf("%w '%s'", ErrUnsupportedDiscoverer, scfg.DiscovererType())
+	}
+	if scfg.Name() == "" {
+		return false, errors.New("no name configured")
+	}
+	_, err = parseDyncfgPayloadContent(scfg.DataJSON(), scfg.DiscovererType(), scfg.Name(), configDefaults, reg, true)
+	return false, err
+}
@@ -155,6 +155,62 @@ func TestServiceDiscovery_UnpublishableConfigDoesNotEnterStateOrWaitGate(t *test
 	require.Empty(t, output.String())
 }
 
+func TestValidateFileConfig(t *testing.T) {
+	const source = "/etc/netdata/go.d/sd/net_listeners.conf"
+	tests := map[string]struct {
+		conf        confFile
+		wantSkipped bool
+		wantErr     bool
+		errIs       error
+	}{
+		"valid config": {
+			conf: prepareConfigFile(source, "local"),
+		},
+		"disabled config": {
+			conf:        prepareDisabledConfigFile(source, "local"),
+			wantSkipped: true,
+		},
+		"unsupported discoverer": {
+			conf:    prepareUnsupportedDiscovererConfigFile(source, "local"),
+			wantErr: true,
+			errIs:   ErrUnsupportedDiscoverer,
+		},
+		"unsupported discoverer in stock config": {
+			conf:        prepareUnsupportedDiscovererConfigFile("/usr/lib/netdata/conf.d/sd/unsupported.conf", "local"),
+			wantSkipped: true,
+		},
+		"no services": {
+			conf: func() confFile {
+				bs, _ := yaml.Marshal(pipeline.Config{
+					Name:       "local",
+					Discoverer: mustDiscovererPayload(testDiscovererTypeNetListeners, testNetListenersConfig{}),
+				})
+				return confFile{source: source, content: bs}
+			}(),
+			wantErr: true,
+		},
+		"invalid yaml": {
+			conf:    confFile{source: source, content: []byte("name: [")},
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			skipped, err := ValidateFileConfig(test.conf.content, test.conf.source, confgroup.Registry{}, testDiscovererRegistry())
+			if test.wantErr {
+				require.Error(t, err)
+				if test.errIs != nil {
+					require.ErrorIs(t, err, test.errIs)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.wantSkipped, skipped)
+		})
+	}
+}
+
 func prepareConfigFile(source, name string) confFile {
 	cfg := pipeline.Config{
 		Name:       name,
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/agent/discovery/file"
+	"github.com/netdata/netdata/go/plugins/plugin/agent/oneshot"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/confgroup"
+)
+
+const (
+	DumpFormatJSON        = "json"
+	DumpFormatOpenMetrics = "openmetrics"
+)
+
+// Dump runs one job of RunModule for the given number of cycles without
+// starting the agent and writes the collected metrics and their chart mapping
+// in the given format. The job comes from the module config file the agent
+// would read; RunJob selects it when the file defines more than one.
+func (a *Agent) Dump(ctx context.Context, format string, cycles int, w io.Writer) error {
+	if format != DumpFormatJSON && format != DumpFormatOpenMetrics {
+		return fmt.Errorf("unknown dump format '%s'", format)
+	}
+	if a.RunModule == "" || a.RunModule == "all" {
+		return errors.New("dump mode requires a single module (-m)")
+	}
+
+	enabled := a.loadEnabledModules(a.loadPluginConfig())
+	creator, ok := enabled[a.RunModule]
+	if !ok {
+		return fmt.Errorf("module '%s' is not registered or not enabled", a.RunModule)
+	}
+
+	job, err := a.dumpJob(enabled)
+	if err != nil {
+		return err
+	}
+	a.Infof("dumping job '%s' of module '%s' for %d cycle(s)", job.Name(), job.Module(), max(cycles, 1))
+
+	dump, err := oneshot.Run(ctx, oneshot.Config{
+		Creator: creator,
+		Job:     job,
+		Cycles:  cycles,
+	})
+	if err != nil {
+		return err
+	}
+	if format == DumpFormatOpenMetrics {
+		return dump.WriteOpenMetrics(w)
+	}
+	return dump.WriteJSON(w)
+}
+
+func (a *Agent) dumpJob(enabled collectorapi.Registry) (confgroup.Config, error) {
+	setup := a.buildDiscoveryConf(enabled)
+	def, _ := setup.Defaults.Lookup(a.RunModule)
+
+	var jobs []confgroup.Config
+	for _, path := range setup.BuildContext.ReadPaths {
+		if strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)) != a.RunModule {
+			continue
+		}
+		group, err := file.Parse(setup.Defaults, path)
+		if err != nil {
+			return nil, fmt.Errorf("parse '%s': %v", path, err)
+		}
+		if group != nil {
+			jobs = group.Configs
+		}
+	}
+	if len(jobs) == 0 && len(a.RunJob) == 0 {
+		cfg := confgroup.Config{}
+		cfg.SetModule(a.RunModule)
+		cfg.ApplyDefaults(def)
+		return cfg, nil
+	}
+
+	var matched []confgroup.Config
+	for _, job := range jobs {
+		if len(a.RunJob) == 0 || slices.Contains(a.RunJob, job.Name()) {
+			matched = append(matched, job)
+		}
+	}
+	switch len(matched) {
+	case 0:
+		return nil, fmt.Errorf("no job '%s' found for module '%s'", strings.Join(a.RunJob, ", "), a.RunModule)
+	case 1:
+		return matched[0], nil
+	default:
+		names := make([]string, 0, len(matched))
+		for _, job := range matched {
+			names = append(names, job.Name())
+		}
+		return nil, fmt.Errorf("module '%s' has several jobs (%s), select one with -j", a.RunModule, strings.Join(names, ", "))
+	}
+}
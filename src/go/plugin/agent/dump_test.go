@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/netdata/netdata/go/plugins/plugin/agent/oneshot"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgent_Dump(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "etc", "netdata", "go.d")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "module1.conf"),
+		[]byte("jobs:\n  - name: job1\n  - name: job2\n"), 0o644))
+
+	newModule := func() collectorapi.CollectorV1 {
+		return &collectorapi.MockCollectorV1{
+			CollectFunc: func(context.Context) map[string]int64 { return map[string]int64{"metric": 1} },
+		}
+	}
+	registry := collectorapi.Registry{
+		"module1": {Create: newModule},
+		"module2": {Create: newModule},
+	}
+
+	tests := map[string]struct {
+		module  string
+		jobs    []string
+		format  string
+		wantJob string
+		wantErr bool
+	}{
+		"job selected with -j": {
+			module:  "module1",
+			jobs:    []string{"job2"},
+			format:  DumpFormatJSON,
+			wantJob: "job2",
+		},
+		"default job without config file": {
+			module:  "module2",
+			format:  DumpFormatJSON,
+			wantJob: "module2",
+		},
+		"several jobs without -j": {
+			module:  "module1",
+			format:  DumpFormatJSON,
+			wantErr: true,
+		},
+		"unknown job": {
+			module:  "module1",
+			jobs:    []string{"job3"},
+			format:  DumpFormatJSON,
+			wantErr: true,
+		},
+		"all modules": {
+			module:  "all",
+			format:  DumpFormatJSON,
+			wantErr: true,
+		},
+		"unknown format": {
+			module:  "module2",
+			format:  "xml",
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := New(Config{
+				Name:                "test",
+				CollectorsConfigDir: []string{dir},
+				ModuleRegistry:      registry,
+				RunModule:           test.module,
+				RunJob:              test.jobs,
+			})
+
+			var buf bytes.Buffer
+			err := a.Dump(context.Background(), test.format, 1, &buf)
+
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var dump oneshot.Dump
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &dump))
+			assert.Equal(t, test.wantJob, dump.Job)
+			require.Len(t, dump.Cycles, 1)
+			assert.Len(t, dump.Cycles[0].Metrics, 1)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Import converts configuration files of another monitoring system into jobs
+// of RunModule and writes them to w in the module config file format. Nothing
+// is loaded or started; the output is meant to be reviewed and saved as the
+// module config file.
+func (a *Agent) Import(files []string, w io.Writer) error {
+	if a.RunModule == "" || a.RunModule == "all" {
+		return errors.New("import mode requires a single module (-m)")
+	}
+	creator, ok := a.ModuleRegistry[a.RunModule]
+	if !ok {
+		return fmt.Errorf("module '%s' is not registered", a.RunModule)
+	}
+	if creator.Import == nil {
+		return fmt.Errorf("module '%s' does not support configuration import", a.RunModule)
+	}
+	if len(files) == 0 {
+		return errors.New("no files to import")
+	}
+
+	sources := make([][]byte, 0, len(files))
+	for _, path := range files {
+		bs, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		sources = append(sources, bs)
+	}
+
+	out, err := creator.Import(sources...)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
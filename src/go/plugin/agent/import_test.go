@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgent_Import(t *testing.T) {
+	dir := t.TempDir()
+	file1 := filepath.Join(dir, "hosts.cfg")
+	file2 := filepath.Join(dir, "services.cfg")
+	require.NoError(t, os.WriteFile(file1, []byte("hosts"), 0o644))
+	require.NoError(t, os.WriteFile(file2, []byte("services"), 0o644))
+
+	registry := collectorapi.Registry{
+		"module1": {
+			Import: func(sources ...[]byte) ([]byte, error) {
+				return bytes.Join(sources, []byte("+")), nil
+			},
+		},
+		"module2": {},
+	}
+
+	tests := map[string]struct {
+		module  string
+		files   []string
+		want    string
+		wantErr bool
+	}{
+		"sources in order":   {module: "module1", files: []string{file1, file2}, want: "hosts+services"},
+		"all modules":        {module: "all", files: []string{file1}, wantErr: true},
+		"unknown module":     {module: "module3", files: []string{file1}, wantErr: true},
+		"import unsupported": {module: "module2", files: []string{file1}, wantErr: true},
+		"missing file":       {module: "module1", files: []string{filepath.Join(dir, "missing.cfg")}, wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := New(Config{
+				Name:           "test",
+				ModuleRegistry: registry,
+				RunModule:      test.module,
+			})
+
+			var buf strings.Builder
+			err := a.Import(test.files, &buf)
+
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, buf.String())
+		})
+	}
+}
@@ -90,6 +90,7 @@ func newDynCfgJobInitialRoute(
 				Commands: []functionadapter.ResourceTransactionCommand{
 					{Name: string(dyncfg.CommandAdd)},
 					{Name: string(dyncfg.CommandUpdate), AllocateSuccessor: true},
+					{Name: string(dyncfg.CommandRollback), AllocateSuccessor: true},
 					{Name: string(dyncfg.CommandEnable), AllocateSuccessor: true},
 					{Name: string(dyncfg.CommandRestart), AllocateSuccessor: true},
 					{Name: string(dyncfg.CommandDisable)},
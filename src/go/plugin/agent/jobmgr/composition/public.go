@@ -21,6 +21,7 @@ import (
 	"github.com/netdata/netdata/go/plugins/plugin/agent/secrets/secretstore/backends"
 	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
 	"github.com/netdata/netdata/go/plugins/plugin/framework/confgroup"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/dyncfg"
 	"github.com/netdata/netdata/go/plugins/plugin/framework/runtimecomp"
 	"github.com/netdata/netdata/go/plugins/plugin/framework/vnoderegistry"
 	"github.com/netdata/netdata/go/plugins/plugin/framework/vnodes"
@@ -66,6 +67,8 @@ type Config struct {
 
 	Runtime RuntimeService // runtime service (charts/host-scope; nil disables runtime charts)
 
+	ConfigHistoryDir string // dyncfg job revision history directory (empty disables history)
+
 	ShutdownTimeout time.Duration // per-run shutdown budget
 	KeepAlive       bool          // emit keepalive frames (long-lived agent mode)
 }
@@ -156,6 +159,7 @@ func NewProcess(config Config) (*Process, error) {
 			Runtime:       config.Runtime,
 			Vnodes:        vnoderegistry.New(),
 			InitialVnodes: initialVnodes,
+			History:       dyncfg.NewHistory(config.ConfigHistoryDir, 0),
 		},
 		Secrets: runSecretServices{
 			Initial: initialSecrets,
@@ -33,6 +33,7 @@ type runJobServices struct {
 	Runtime       runtimecomp.Service            // runtime service dependency
 	Vnodes        *vnoderegistry.Registry        // vnode metadata registry
 	InitialVnodes map[string]*vnodes.VirtualNode // file-configured vnodes
+	History       *dyncfg.History                // dyncfg job revision history (nil = disabled)
 }
 
 type runSecretServices struct {
@@ -267,6 +268,7 @@ func newRunGeneration(
 			Frames:        config.Frames,
 			Dependencies:  dependencies,
 			Diagnostics:   config.Diagnostics,
+			History:       config.Jobs.History,
 		},
 	)
 	if err != nil {
@@ -32,6 +32,9 @@ func (dcjc *DynCfgJobController) configCreateCleanup(
 	if sourceType == confgroup.TypeDyncfg && configType == dyncfg.ConfigTypeJob {
 		commands += " " + string(dyncfg.CommandRemove)
 	}
+	if dcjc.history != nil {
+		commands += " " + dyncfg.JoinCommands(dyncfg.CommandHistory, dyncfg.CommandDiff, dyncfg.CommandRollback)
+	}
 	return dcjc.protocolCleanup(func(api *netdataapi.API) error {
 		return api.TryCONFIGCREATE(
 			netdataapi.ConfigOpts{
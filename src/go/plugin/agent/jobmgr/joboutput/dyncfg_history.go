@@ -0,0 +1,272 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package joboutput
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/agent/jobmgr"
+	"github.com/netdata/netdata/go/plugins/plugin/agent/jobmgr/lifecycle"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/confgroup"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/dyncfg"
+	"gopkg.in/yaml.v2"
+)
+
+type historyResponse struct {
+	ID        string            `json:"id"`
+	Revisions []dyncfg.Revision `json:"revisions"`
+}
+
+type diffResponse struct {
+	ID   string `json:"id"`
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+	Diff string `json:"diff"`
+}
+
+func (dcjc *DynCfgJobController) configHistory(target dynCfgTarget) (lifecycle.SealedResult, error) {
+	if dcjc.history == nil {
+		return dynCfgMessage(501, "config history is not enabled.")
+	}
+	id := dcjc.configID(target.module, target.name)
+	revisions, err := dcjc.history.List(id)
+	if err != nil {
+		return dynCfgMessage(500, err.Error())
+	}
+	if revisions == nil {
+		revisions = []dyncfg.Revision{}
+	}
+	payload, err := json.Marshal(historyResponse{ID: id, Revisions: revisions})
+	if err != nil {
+		return lifecycle.SealedResult{}, err
+	}
+	return lifecycle.NewSealedResult(200, "application/json", payload)
+}
+
+// configDiff compares two revisions: "diff" compares the previous revision with
+// the latest one, "diff <from>" compares <from> with the latest one, and
+// "diff <from> <to>" compares the two given revisions.
+func (dcjc *DynCfgJobController) configDiff(
+	request DynCfgJobRequest,
+	target dynCfgTarget,
+) (lifecycle.SealedResult, error) {
+	if dcjc.history == nil {
+		return dynCfgMessage(501, "config history is not enabled.")
+	}
+	id := dcjc.configID(target.module, target.name)
+
+	var from, to dyncfg.Revision
+	var err error
+	switch len(request.Args) {
+	case 0, 1, 2:
+		if from, err = dcjc.history.Previous(id); err == nil {
+			to, err = dcjc.history.Get(id, 0)
+		}
+	default:
+		fromVersion, toVersion, ok := parseRevisionRange(request.Args[2:])
+		if !ok {
+			return dynCfgMessage(400, "invalid revision version.")
+		}
+		if from, err = dcjc.history.Get(id, fromVersion); err == nil {
+			to, err = dcjc.history.Get(id, toVersion)
+		}
+	}
+	if err != nil {
+		if errors.Is(err, dyncfg.ErrRevisionNotFound) {
+			return dynCfgMessage(404, "revision not found.")
+		}
+		return dynCfgMessage(500, err.Error())
+	}
+
+	payload, err := json.Marshal(diffResponse{
+		ID:   id,
+		From: from.Version,
+		To:   to.Version,
+		Diff: dyncfg.Diff(revisionName(from), revisionName(to), from.Payload, to.Payload),
+	})
+	if err != nil {
+		return lifecycle.SealedResult{}, err
+	}
+	return lifecycle.NewSealedResult(200, "application/json", payload)
+}
+
+// prepareRollback replays a stored revision through the update path, so the
+// rollback gets the same validation, runtime transition and status handling.
+func (dcjc *DynCfgJobController) prepareRollback(
+	ctx context.Context,
+	request DynCfgJobRequest,
+	target dynCfgTarget,
+	record dyncfg.GraphRecord,
+	exists bool,
+	current lifecycle.ReadyResource,
+	scope lifecycle.ResourceTransactionScope,
+	permit lifecycle.LongLivedPermit,
+) (lifecycle.PreparedResourceTransaction, error) {
+	if dcjc.history == nil {
+		return dcjc.noop(scope, current, permit, mustDynCfgMessage(501, "config history is not enabled."))
+	}
+	if !exists {
+		return dcjc.noop(scope, current, permit, mustDynCfgMessage(404, "config not found."))
+	}
+
+	id := dcjc.configID(target.module, target.name)
+	var revision dyncfg.Revision
+	var err error
+	if len(request.Args) >= 3 && request.Args[2] != "" {
+		version, perr := strconv.ParseUint(request.Args[2], 10, 64)
+		if perr != nil || version == 0 {
+			return dcjc.noop(scope, current, permit, mustDynCfgMessage(400, "invalid revision version."))
+		}
+		revision, err = dcjc.history.Get(id, version)
+	} else {
+		revision, err = dcjc.history.Previous(id)
+	}
+	if err != nil {
+		if errors.Is(err, dyncfg.ErrRevisionNotFound) {
+			return dcjc.noop(scope, current, permit, mustDynCfgMessage(404, "revision not found."))
+		}
+		return dcjc.noop(scope, current, permit, mustDynCfgMessage(500, err.Error()))
+	}
+
+	rollback := request
+	rollback.Payload = []byte(revision.Payload)
+	rollback.ContentType = "application/yaml"
+	rollback.HasPayload = true
+	return dcjc.prepareUpdate(ctx, rollback, target, record, exists, current, scope, permit)
+}
+
+// updateResult is the response to a successful update. A rollback responds with
+// the restored configuration, so netdata saves it in place of the rolled back one.
+func updateResult(request DynCfgJobRequest) lifecycle.SealedResult {
+	if dyncfg.CommandFromArgs(request.Args) != dyncfg.CommandRollback {
+		return mustDynCfgMessage(200, "")
+	}
+	result, err := lifecycle.NewSealedResult(200, "application/yaml", request.Payload)
+	if err != nil {
+		return mustDynCfgMessage(200, "")
+	}
+	return result
+}
+
+// revisionCleanup records the committed postimage in the config history. When
+// the history is empty and the previous config is known, it is stored first as
+// the baseline so the very first change can be rolled back.
+func (dcjc *DynCfgJobController) revisionCleanup(
+	request DynCfgJobRequest,
+	oldConfig confgroup.Config,
+	postimage dyncfg.GraphConfig,
+) lifecycle.TaskCleanup {
+	if dcjc.history == nil {
+		return nil
+	}
+	id := dcjc.configID(postimage.Module, postimage.Name)
+	revision := dyncfg.Revision{
+		Command:    dyncfg.CommandFromArgs(request.Args),
+		Source:     request.CallerSource,
+		SourceType: confgroup.TypeDyncfg,
+		Status:     postimage.Status,
+	}
+	var baseline *dyncfg.Revision
+	if oldConfig != nil {
+		bs, err := yaml.Marshal(oldConfig)
+		if err == nil {
+			baseline = &dyncfg.Revision{
+				Source:     oldConfig.Source(),
+				SourceType: oldConfig.SourceType(),
+			}
+			baseline.Payload, err = historyPayload(bs)
+		}
+		if err != nil {
+			baseline = nil
+		}
+	}
+	payload, payloadErr := historyPayload(postimage.Payload)
+
+	return func() error {
+		err := payloadErr
+		if err == nil {
+			err = dcjc.recordRevision(id, baseline, revision, payload)
+		}
+		if err != nil {
+			jobmgr.ObserveDiagnostic(dcjc.diagnostics, jobmgr.DiagnosticEvent{
+				Level:      jobmgr.DiagnosticWarning,
+				Name:       "job configuration revision not recorded",
+				Resource:   postimage.ID,
+				Command:    string(revision.Command),
+				Generation: dcjc.generation,
+				Err:        err,
+			})
+		}
+		// History is best-effort: the config change itself is already committed.
+		return nil
+	}
+}
+
+func (dcjc *DynCfgJobController) recordRevision(
+	id string,
+	baseline *dyncfg.Revision,
+	revision dyncfg.Revision,
+	payload string,
+) error {
+	if baseline != nil {
+		revisions, err := dcjc.history.List(id)
+		if err != nil {
+			return err
+		}
+		if len(revisions) == 0 {
+			if _, _, err := dcjc.history.Record(id, *baseline); err != nil {
+				return err
+			}
+		}
+	}
+	revision.Payload = payload
+	_, _, err := dcjc.history.Record(id, revision)
+	return err
+}
+
+// historyPayload drops internal ("__"-prefixed) keys from a stored graph
+// payload, keeping the user-visible key order.
+func historyPayload(payload []byte) (string, error) {
+	var values yaml.MapSlice
+	if err := yaml.Unmarshal(payload, &values); err != nil {
+		return "", err
+	}
+	kept := values[:0]
+	for _, item := range values {
+		if key, ok := item.Key.(string); ok && strings.HasPrefix(key, "__") {
+			continue
+		}
+		kept = append(kept, item)
+	}
+	bs, err := yaml.Marshal(kept)
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+func parseRevisionRange(args []string) (from, to uint64, ok bool) {
+	var err error
+	if from, err = strconv.ParseUint(args[0], 10, 64); err != nil || from == 0 {
+		return 0, 0, false
+	}
+	if len(args) > 1 && args[1] != "" {
+		if to, err = strconv.ParseUint(args[1], 10, 64); err != nil || to == 0 {
+			return 0, 0, false
+		}
+	}
+	return from, to, true
+}
+
+func revisionName(revision dyncfg.Revision) string {
+	name := fmt.Sprintf("v%d", revision.Version)
+	if revision.Command != "" {
+		name += " (" + string(revision.Command) + ")"
+	}
+	return name
+}
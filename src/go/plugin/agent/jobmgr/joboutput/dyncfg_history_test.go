@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package joboutput
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/netdata/netdata/go/plugins/plugin/agent/jobmgr/lifecycle"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/confgroup"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/dyncfg"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestDynCfgHistoryCommandsRequireHistory(t *testing.T) {
+	controller, _, _, _, _ := newDynCfgJobTestHarness(t)
+
+	for _, command := range []dyncfg.Command{dyncfg.CommandHistory, dyncfg.CommandDiff} {
+		result, err := controller.Handle(context.Background(), DynCfgJobRequest{
+			Args: []string{"go.d:collector:module:job", string(command)},
+		})
+		require.NoError(t, err)
+		require.Equal(t, mustDynCfgMessage(501, "config history is not enabled."), result, command)
+	}
+}
+
+func TestDynCfgRevisionCleanupRecordsBaselineAndChange(t *testing.T) {
+	controller, _, _, _, _ := newDynCfgJobTestHarness(t)
+	controller.history = dyncfg.NewHistory(t.TempDir(), 0)
+
+	oldConfig := factoryTestConfig(false)
+	oldConfig.SetSource("/etc/netdata/go.d/module.conf")
+	oldConfig.SetSourceType(confgroup.TypeUser)
+	newConfig := factoryTestConfig(false)
+	newConfig["option"] = "replacement"
+	newConfig.SetSource("user=test")
+	newConfig.SetSourceType(confgroup.TypeDyncfg)
+	payload, err := yaml.Marshal(newConfig)
+	require.NoError(t, err)
+
+	cleanup := controller.revisionCleanup(
+		DynCfgJobRequest{
+			Args:         []string{"go.d:collector:module:job", "update"},
+			CallerSource: "user=test",
+		},
+		oldConfig,
+		dyncfg.GraphConfig{
+			ID:      "module_job",
+			Module:  "module",
+			Name:    "job",
+			Status:  dyncfg.StatusRunning.String(),
+			Payload: payload,
+		},
+	)
+	require.NoError(t, cleanup())
+	require.NoError(t, cleanup(), "replaying the cleanup must not duplicate revisions")
+
+	revisions, err := controller.history.List("go.d:collector:module:job")
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+
+	baseline, change := revisions[0], revisions[1]
+	require.EqualValues(t, 1, baseline.Version)
+	require.Empty(t, baseline.Command)
+	require.Equal(t, confgroup.TypeUser, baseline.SourceType)
+	require.NotContains(t, baseline.Payload, "__source")
+	require.EqualValues(t, 2, change.Version)
+	require.Equal(t, dyncfg.CommandUpdate, change.Command)
+	require.Equal(t, "user=test", change.Source)
+	require.Equal(t, dyncfg.StatusRunning.String(), change.Status)
+	require.Contains(t, change.Payload, "option: replacement")
+
+	result, err := controller.Handle(context.Background(), DynCfgJobRequest{
+		Args: []string{"go.d:collector:module:job", "history"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, mustJSONResult(t, historyResponse{ID: "go.d:collector:module:job", Revisions: revisions}), result)
+
+	result, err = controller.Handle(context.Background(), DynCfgJobRequest{
+		Args: []string{"go.d:collector:module:job", "diff"},
+	})
+	require.NoError(t, err)
+	diff := dyncfg.Diff("v1", "v2 (update)", baseline.Payload, change.Payload)
+	require.Contains(t, diff, "+ option: replacement")
+	require.Equal(t, mustJSONResult(t, diffResponse{ID: "go.d:collector:module:job", From: 1, To: 2, Diff: diff}), result)
+
+	result, err = controller.Handle(context.Background(), DynCfgJobRequest{
+		Args: []string{"go.d:collector:module:job", "diff", "2", "9"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, mustDynCfgMessage(404, "revision not found."), result)
+
+	result, err = controller.Handle(context.Background(), DynCfgJobRequest{
+		Args: []string{"go.d:collector:module:job", "diff", "x"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, mustDynCfgMessage(400, "invalid revision version."), result)
+}
+
+func TestDynCfgRollbackReplaysPreviousRevision(t *testing.T) {
+	controller, graph, _, _, _ := newDynCfgJobTestHarness(t)
+	controller.history = dyncfg.NewHistory(t.TempDir(), 0)
+
+	current := factoryTestConfig(false)
+	current["option"] = "bad"
+	current.SetSource("user=test")
+	current.SetSourceType(confgroup.TypeDyncfg)
+	current.SetProvider(confgroup.TypeDyncfg)
+	seedDynCfgJobGraphRecord(t, graph, current, dyncfg.StatusDisabled)
+
+	id := "go.d:collector:module:job"
+	for _, option := range []string{"good", "bad"} {
+		config := factoryTestConfig(false)
+		config["option"] = option
+		payload, err := yaml.Marshal(config)
+		require.NoError(t, err)
+		revision, err := historyPayload(payload)
+		require.NoError(t, err)
+		_, _, err = controller.history.Record(id, dyncfg.Revision{Command: dyncfg.CommandUpdate, Payload: revision})
+		require.NoError(t, err)
+	}
+
+	// Steps run in order against the same graph record.
+	steps := []struct {
+		name       string
+		args       []string
+		wantStatus int
+		wantOption string
+	}{
+		{name: "unknown revision", args: []string{id, "rollback", "7"}, wantStatus: 404, wantOption: "bad"},
+		{name: "invalid revision", args: []string{id, "rollback", "first"}, wantStatus: 400, wantOption: "bad"},
+		{name: "previous revision", args: []string{id, "rollback"}, wantStatus: 200, wantOption: "good"},
+	}
+	for _, test := range steps {
+		t.Run(test.name, func(t *testing.T) {
+			scope := lifecycle.ResourceTransactionScope{ID: "module_job"}
+			transaction, err := controller.Prepare(
+				context.Background(),
+				DynCfgJobRequest{Args: test.args, CallerSource: "user=test"},
+				nil,
+				scope,
+				lifecycle.LongLivedPermit{},
+			)
+			require.NoError(t, err)
+			applied, err := transaction.Apply(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.wantStatus, applied.ResultStatus())
+
+			record, ok := graph.Lookup("module_job")
+			require.True(t, ok)
+			require.Equal(t, dyncfg.StatusDisabled.String(), record.Status)
+			config, err := graphRecordConfig(record)
+			require.NoError(t, err)
+			require.Equal(t, test.wantOption, config["option"])
+		})
+	}
+}
+
+func TestDynCfgUpdateResult(t *testing.T) {
+	payload := []byte("option: good\n")
+
+	result := updateResult(DynCfgJobRequest{Args: []string{"go.d:collector:module:job", "update"}, Payload: payload})
+	require.Equal(t, mustDynCfgMessage(200, ""), result)
+
+	want, err := lifecycle.NewSealedResult(200, "application/yaml", payload)
+	require.NoError(t, err)
+	result = updateResult(DynCfgJobRequest{Args: []string{"go.d:collector:module:job", "rollback"}, Payload: payload})
+	require.Equal(t, want, result, "rollback must respond with the restored config for netdata to save it")
+}
+
+func mustJSONResult(t *testing.T, value any) lifecycle.SealedResult {
+	t.Helper()
+	payload, err := json.Marshal(value)
+	require.NoError(t, err)
+	result, err := lifecycle.NewSealedResult(200, "application/json", payload)
+	require.NoError(t, err)
+	return result
+}
@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package joboutput
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/netdata/netdata/go/plugins/plugin/agent/jobmgr/lifecycle"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/dyncfg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDynCfgImportCommand(t *testing.T) {
+	controller, _, _, _, _ := newDynCfgJobTestHarness(t)
+	request := func(payload string) DynCfgJobRequest {
+		return DynCfgJobRequest{
+			Args:       []string{"go.d:collector:module", string(dyncfg.CommandImport)},
+			Payload:    []byte(payload),
+			HasPayload: payload != "",
+		}
+	}
+
+	result, err := controller.Handle(context.Background(), request("define service {}"))
+	require.NoError(t, err)
+	require.Equal(t, mustDynCfgMessage(501, "Module module does not support configuration import."), result)
+
+	creator := controller.modules["module"]
+	creator.Import = func(sources ...[]byte) ([]byte, error) {
+		if string(sources[0]) == "bad" {
+			return nil, errors.New("no service checks found")
+		}
+		return []byte("jobs:\n- name: imported\n"), nil
+	}
+	controller.modules["module"] = creator
+
+	result, err = controller.Handle(context.Background(), request("define service {}"))
+	require.NoError(t, err)
+	want, err := lifecycle.NewSealedResult(200, "application/yaml", []byte("jobs:\n- name: imported\n"))
+	require.NoError(t, err)
+	require.Equal(t, want, result)
+
+	result, err = controller.Handle(context.Background(), request("bad"))
+	require.NoError(t, err)
+	require.Equal(t, mustDynCfgMessage(400, "import failed: no service checks found."), result)
+
+	result, err = controller.Handle(context.Background(), request(""))
+	require.NoError(t, err)
+	require.Equal(t, mustDynCfgMessage(400, "missing configuration payload."), result)
+}
@@ -42,6 +42,7 @@ type DynCfgJobControllerConfig struct {
 	Frames        *lifecycle.FrameOwner     // protocol frame sink
 	Dependencies  JobDependencyIndex        // secret-dependency index (optional)
 	Diagnostics   jobmgr.DiagnosticObserver // operational log sink
+	History       *dyncfg.History           // config revision history (optional)
 }
 
 type JobDependencyIndex interface {
@@ -63,6 +64,7 @@ type DynCfgJobController struct {
 	frames        *lifecycle.FrameOwner     // protocol frame sink
 	dependencies  JobDependencyIndex        // secret-dependency index (optional)
 	diagnostics   jobmgr.DiagnosticObserver // operational log sink
+	history       *dyncfg.History           // config revision history (nil = disabled)
 	scheduler     *Scheduler                // tick + retry scheduler
 }
 
@@ -89,6 +91,7 @@ func NewDynCfgJobController(config DynCfgJobControllerConfig) (*DynCfgJobControl
 		frames:        config.Frames,
 		dependencies:  config.Dependencies,
 		diagnostics:   config.Diagnostics,
+		history:       config.History,
 		scheduler:     config.Factory.config.Scheduler,
 	}, nil
 }
@@ -143,6 +146,12 @@ func (dcjc *DynCfgJobController) Handle(
 		return lifecycle.NewSealedResult(200, "application/json", []byte(target.creator.JobConfigSchema))
 	case dyncfg.CommandUserconfig:
 		return dcjc.userConfig(request, target)
+	case dyncfg.CommandHistory:
+		return dcjc.configHistory(target)
+	case dyncfg.CommandDiff:
+		return dcjc.configDiff(request, target)
+	case dyncfg.CommandImport:
+		return dcjc.importConfig(request, target)
 	case dyncfg.CommandTest:
 		config, failure := dcjc.parseConfig(request, target.module, target.name)
 		if failure.valid {
@@ -226,6 +235,8 @@ func (dcjc *DynCfgJobController) Prepare(
 		transaction, err = dcjc.prepareAdd(ctx, request, target, current, scope)
 	case dyncfg.CommandUpdate:
 		transaction, err = dcjc.prepareUpdate(ctx, request, target, record, exists, current, scope, permit)
+	case dyncfg.CommandRollback:
+		transaction, err = dcjc.prepareRollback(ctx, request, target, record, exists, current, scope, permit)
 	case dyncfg.CommandEnable:
 		transaction, err = dcjc.prepareEnable(ctx, target, record, exists, current, scope, permit)
 	case dyncfg.CommandRestart:
@@ -68,13 +68,17 @@ func (dcjc *DynCfgJobController) prepareAdd(
 		resourceRemovalDisposition(current),
 		&postimage,
 		mustDynCfgMessage(202, ""),
-		dcjc.configCreateCleanup(postimage, confgroup.TypeDyncfg, request.CallerSource, dyncfg.ConfigTypeJob),
+		joinDynCfgCleanups(
+			dcjc.configCreateCleanup(postimage, confgroup.TypeDyncfg, request.CallerSource, dyncfg.ConfigTypeJob),
+			dcjc.revisionCleanup(request, nil, postimage),
+		),
 	)
 }
 
 // updateCleanup selects the protocol cleanup for an update postimage: a plain
 // status echo for dyncfg-sourced configs, or a full CONFIG CREATE that adopts a
-// non-dyncfg (stock/discovered) config into dyncfg ownership.
+// non-dyncfg (stock/discovered) config into dyncfg ownership. Both also record
+// the postimage in the config history when it is enabled.
 func (dcjc *DynCfgJobController) updateCleanup(
 	target dynCfgTarget,
 	request DynCfgJobRequest,
@@ -82,15 +86,19 @@ func (dcjc *DynCfgJobController) updateCleanup(
 	postimage dyncfg.GraphConfig,
 	status dyncfg.Status,
 ) lifecycle.TaskCleanup {
+	revision := dcjc.revisionCleanup(request, oldConfig, postimage)
 	if oldConfig.SourceType() != confgroup.TypeDyncfg {
-		return dcjc.configCreateCleanup(
-			postimage,
-			confgroup.TypeDyncfg,
-			request.CallerSource,
-			dcjc.configType(target.creator),
+		return joinDynCfgCleanups(
+			dcjc.configCreateCleanup(
+				postimage,
+				confgroup.TypeDyncfg,
+				request.CallerSource,
+				dcjc.configType(target.creator),
+			),
+			revision,
 		)
 	}
-	return dcjc.configStatusCleanup(target.resourceID, status)
+	return joinDynCfgCleanups(dcjc.configStatusCleanup(target.resourceID, status), revision)
 }
 
 func (dcjc *DynCfgJobController) prepareUpdate(
@@ -130,7 +138,7 @@ func (dcjc *DynCfgJobController) prepareUpdate(
 			scope,
 			current,
 			permit,
-			mustDynCfgMessage(200, ""),
+			updateResult(request),
 			dcjc.configStatusCleanup(target.resourceID, dyncfg.StatusRunning),
 		)
 	}
@@ -186,7 +194,7 @@ func (dcjc *DynCfgJobController) prepareUpdate(
 			permit,
 			lifecycle.ResourceTransactionUnchanged,
 			&postimage,
-			mustDynCfgMessage(200, ""),
+			updateResult(request),
 			cleanup,
 		)
 	}
@@ -305,7 +313,7 @@ func (dcjc *DynCfgJobController) prepareUpdate(
 		successor,
 		resourceInstallationDisposition(current),
 		&postimage,
-		mustDynCfgMessage(200, ""),
+		updateResult(request),
 		cleanup,
 		autoDetectionRetryToken{},
 		nil,
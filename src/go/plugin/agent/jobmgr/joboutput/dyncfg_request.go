@@ -63,7 +63,8 @@ func (dcjc *DynCfgJobController) resolveRequest(request DynCfgJobRequest) (dynCf
 		hasName = true
 	}
 	if !hasName {
-		if command == dyncfg.CommandSchema || command == dyncfg.CommandUserconfig || command == dyncfg.CommandTest {
+		if command == dyncfg.CommandSchema || command == dyncfg.CommandUserconfig || command == dyncfg.CommandTest ||
+			command == dyncfg.CommandImport {
 			name = "test"
 		} else {
 			return dynCfgTarget{}, newDynCfgFailure(400, "invalid config ID format.")
@@ -175,3 +176,23 @@ func (dcjc *DynCfgJobController) userConfig(
 	}
 	return lifecycle.NewSealedResult(200, "application/yaml", payload)
 }
+
+// importConfig converts the foreign configuration in the payload into jobs of
+// the module. Nothing is applied; the result is returned in the userconfig
+// format so it can be reviewed and added job by job.
+func (dcjc *DynCfgJobController) importConfig(
+	request DynCfgJobRequest,
+	target dynCfgTarget,
+) (lifecycle.SealedResult, error) {
+	if target.creator.Import == nil {
+		return dynCfgMessage(501, fmt.Sprintf("Module %s does not support configuration import.", target.module))
+	}
+	if !request.HasPayload || len(request.Payload) == 0 {
+		return dynCfgMessage(400, "missing configuration payload.")
+	}
+	payload, err := target.creator.Import(request.Payload)
+	if err != nil {
+		return dynCfgMessage(400, fmt.Sprintf("import failed: %v.", err))
+	}
+	return lifecycle.NewSealedResult(200, "application/yaml", payload)
+}
@@ -96,21 +96,25 @@ func (dcjc *DynCfgJobController) templatePublicationCleanup() lifecycle.TaskClea
 	var payload bytes.Buffer
 	api := netdataapi.New(&payload)
 	for _, name := range names {
+		commands := dyncfg.JoinCommands(
+			dyncfg.CommandAdd,
+			dyncfg.CommandSchema,
+			dyncfg.CommandEnable,
+			dyncfg.CommandDisable,
+			dyncfg.CommandTest,
+			dyncfg.CommandUserconfig,
+		)
+		if dcjc.modules[name].Import != nil {
+			commands += " " + string(dyncfg.CommandImport)
+		}
 		if err := api.TryCONFIGCREATE(netdataapi.ConfigOpts{
-			ID:         dcjc.prefix + name,
-			Status:     dyncfg.StatusAccepted.String(),
-			ConfigType: dyncfg.ConfigTypeTemplate.String(),
-			Path:       dcjc.path,
-			SourceType: "internal",
-			Source:     "internal",
-			SupportedCommands: dyncfg.JoinCommands(
-				dyncfg.CommandAdd,
-				dyncfg.CommandSchema,
-				dyncfg.CommandEnable,
-				dyncfg.CommandDisable,
-				dyncfg.CommandTest,
-				dyncfg.CommandUserconfig,
-			),
+			ID:                dcjc.prefix + name,
+			Status:            dyncfg.StatusAccepted.String(),
+			ConfigType:        dyncfg.ConfigTypeTemplate.String(),
+			Path:              dcjc.path,
+			SourceType:        "internal",
+			Source:            "internal",
+			SupportedCommands: commands,
 		}); err != nil {
 			return func() error { return err }
 		}
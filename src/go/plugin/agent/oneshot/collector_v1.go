@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package oneshot
+
+import (
+	"context"
+	"errors"
+
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+)
+
+type collectorV1 struct {
+	module collectorapi.CollectorV1
+}
+
+// collect records the collected map and the module charts as they are after
+// Collect, so charts added at runtime show up in the cycle that created them.
+func (c *collectorV1) collect(ctx context.Context, cycle *Cycle) error {
+	mx := c.module.Collect(ctx)
+
+	for key, value := range mx {
+		cycle.Metrics = append(cycle.Metrics, Metric{Name: key, Value: float64(value)})
+	}
+
+	charts := c.module.Charts()
+	if charts != nil {
+		for _, chart := range *charts {
+			if chart == nil || chart.Obsolete {
+				continue
+			}
+			cycle.Charts = append(cycle.Charts, chartV1(chart, mx))
+		}
+	}
+
+	if len(mx) == 0 {
+		return errors.New("no metrics collected")
+	}
+	return nil
+}
+
+func chartV1(chart *collectorapi.Chart, mx map[string]int64) Chart {
+	out := Chart{
+		ID:         chart.ID,
+		Context:    chart.Ctx,
+		Title:      chart.Title,
+		Units:      chart.Units,
+		Family:     chart.Fam,
+		Type:       chart.Type.String(),
+		Dimensions: []Dimension{},
+	}
+	if len(chart.Labels) > 0 {
+		out.Labels = make(map[string]string, len(chart.Labels))
+		for _, l := range chart.Labels {
+			out.Labels[l.Key] = l.Value
+		}
+	}
+	for _, dim := range chart.Dims {
+		if dim == nil || dim.Obsolete {
+			continue
+		}
+		d := Dimension{
+			ID:         dim.ID,
+			Name:       dim.Name,
+			Metric:     dim.ID,
+			Algorithm:  dim.Algo.String(),
+			Multiplier: nonZeroOr(dim.Mul, 1),
+			Divisor:    nonZeroOr(dim.Div, 1),
+			Hidden:     dim.Hidden,
+		}
+		if d.Name == "" {
+			d.Name = dim.ID
+		}
+		if v, ok := mx[dim.ID]; ok {
+			value := float64(v)
+			d.Value = &value
+		}
+		out.Dimensions = append(out.Dimensions, d)
+	}
+	return out
+}
+
+func nonZeroOr(v, def int) int {
+	if v != 0 {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,230 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package oneshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/netdata/netdata/go/plugins/logger"
+	"github.com/netdata/netdata/go/plugins/pkg/metrix"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/chartengine"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+)
+
+type collectorV2 struct {
+	module   collectorapi.CollectorV2
+	fullName string
+
+	// scopes keeps one chart engine and the charts it materialized per host
+	// scope, so later cycles see updates and removals like the job runtime.
+	scopes map[string]*scopeState
+}
+
+type scopeState struct {
+	scope  metrix.HostScope
+	engine *chartengine.Engine
+	charts map[string]*Chart
+}
+
+func newCollectorV2(module collectorapi.CollectorV2, fullName string) *collectorV2 {
+	return &collectorV2{
+		module:   module,
+		fullName: fullName,
+		scopes:   make(map[string]*scopeState),
+	}
+}
+
+func (c *collectorV2) collect(ctx context.Context, cycle *Cycle) error {
+	store := c.module.MetricStore()
+	if store == nil {
+		return errors.New("nil metric store")
+	}
+	managed, ok := metrix.AsCycleManagedStore(store)
+	if !ok {
+		return errors.New("metric store is not cycle-managed")
+	}
+
+	cc := managed.CycleController()
+	cc.BeginCycle()
+	if err := c.module.Collect(ctx); err != nil {
+		cc.AbortCycle()
+		return err
+	}
+	if err := cc.CommitCycleSuccess(); err != nil {
+		return fmt.Errorf("commit cycle: %w", err)
+	}
+
+	scopes := make(map[string]metrix.HostScope)
+	if r, ok := store.Read(metrix.ReadFlatten()).(metrix.FreshVisibleHostScopesReader); ok {
+		for _, scope := range r.FreshVisibleHostScopes() {
+			scopes[scope.ScopeKey] = scope
+		}
+	}
+	for key, state := range c.scopes {
+		if _, ok := scopes[key]; !ok {
+			scopes[key] = state.scope
+		}
+	}
+
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(scopes)) {
+		reader := store.Read(metrix.ReadRaw(), metrix.ReadFlatten(), metrix.ReadHostScope(key))
+		host := scopeHost(scopes[key])
+		readMetrics(reader, host, cycle)
+		if err := c.readCharts(reader, scopes[key], host, cycle); err != nil {
+			errs = append(errs, fmt.Errorf("host scope %q: %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *collectorV2) readCharts(reader metrix.Reader, scope metrix.HostScope, host string, cycle *Cycle) error {
+	state, err := c.scopeState(scope)
+	if err != nil {
+		return err
+	}
+	attempt, err := state.engine.PreparePlan(reader)
+	if err != nil {
+		return err
+	}
+	plan := attempt.Plan()
+	if err := attempt.Commit(); err != nil {
+		attempt.Abort()
+		return err
+	}
+
+	values := make(map[string]map[string]*float64)
+	for _, action := range plan.Actions {
+		switch v := action.(type) {
+		case chartengine.CreateChartAction:
+			state.charts[v.ChartID] = &Chart{
+				ID:         v.ChartID,
+				Host:       host,
+				Context:    v.Meta.Context,
+				Title:      v.Meta.Title,
+				Units:      v.Meta.Units,
+				Family:     v.Meta.Family,
+				Type:       string(v.Meta.Type),
+				Labels:     v.Labels,
+				Dimensions: []Dimension{},
+			}
+		case chartengine.UpdateChartLabelsAction:
+			if chart, ok := state.charts[v.ChartID]; ok {
+				chart.Labels = v.Labels
+			}
+		case chartengine.CreateDimensionAction:
+			if chart, ok := state.charts[v.ChartID]; ok {
+				chart.Dimensions = append(chart.Dimensions, Dimension{
+					ID:         v.Name,
+					Name:       v.Name,
+					Algorithm:  string(v.Algorithm),
+					Multiplier: nonZeroOr(v.Multiplier, 1),
+					Divisor:    nonZeroOr(v.Divisor, 1),
+					Hidden:     v.Hidden,
+				})
+			}
+		case chartengine.UpdateChartAction:
+			dims := make(map[string]*float64, len(v.Values))
+			for _, dv := range v.Values {
+				dims[dv.Name] = updateValue(dv)
+			}
+			values[v.ChartID] = dims
+		case chartengine.RemoveDimensionAction:
+			if chart, ok := state.charts[v.ChartID]; ok {
+				chart.Dimensions = slices.DeleteFunc(chart.Dimensions, func(d Dimension) bool { return d.Name == v.Name })
+			}
+		case chartengine.RemoveChartAction:
+			delete(state.charts, v.ChartID)
+		}
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(state.charts)) {
+		chart := *state.charts[id]
+		chart.Dimensions = slices.Clone(chart.Dimensions)
+		for i := range chart.Dimensions {
+			chart.Dimensions[i].Value = values[id][chart.Dimensions[i].Name]
+		}
+		cycle.Charts = append(cycle.Charts, chart)
+	}
+	return nil
+}
+
+func (c *collectorV2) scopeState(scope metrix.HostScope) (*scopeState, error) {
+	if state, ok := c.scopes[scope.ScopeKey]; ok {
+		return state, nil
+	}
+	opts := []chartengine.Option{
+		chartengine.WithLogger(logger.New().With(slog.String("component", "chartengine"))),
+		chartengine.WithEmitTypeIDBudgetPrefix(c.fullName),
+		chartengine.WithRuntimeStore(nil),
+	}
+	if v, ok := c.module.(collectorapi.CollectorV2EnginePolicy); ok {
+		opts = append(opts, chartengine.WithEnginePolicy(v.EnginePolicy()))
+	}
+	engine, err := chartengine.New(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := engine.LoadYAML([]byte(c.module.ChartTemplateYAML()), 1); err != nil {
+		return nil, err
+	}
+	state := &scopeState{scope: scope, engine: engine, charts: make(map[string]*Chart)}
+	c.scopes[scope.ScopeKey] = state
+	return state, nil
+}
+
+func readMetrics(reader metrix.Reader, host string, cycle *Cycle) {
+	reader.ForEachSeriesIdentity(func(_ metrix.SeriesIdentity, meta metrix.SeriesMeta, name string, labels metrix.LabelView, v metrix.SampleValue) {
+		m := Metric{Name: name, Host: host, Type: metricType(meta.Kind), Value: v}
+		if labels != nil && labels.Len() > 0 {
+			m.Labels = make(map[string]string, labels.Len())
+			labels.Range(func(key, value string) bool {
+				m.Labels[key] = value
+				return true
+			})
+		}
+		cycle.Metrics = append(cycle.Metrics, m)
+	})
+}
+
+func updateValue(v chartengine.UpdateDimensionValue) *float64 {
+	if v.IsEmpty {
+		return nil
+	}
+	value := float64(v.Int64)
+	if v.IsFloat {
+		value = v.Float64
+	}
+	return &value
+}
+
+func metricType(kind metrix.MetricKind) string {
+	switch kind {
+	case metrix.MetricKindGauge:
+		return "gauge"
+	case metrix.MetricKindCounter:
+		return "counter"
+	case metrix.MetricKindHistogram:
+		return "histogram"
+	case metrix.MetricKindSummary:
+		return "summary"
+	case metrix.MetricKindStateSet:
+		return "stateset"
+	case metrix.MetricKindMeasureSet:
+		return "measureset"
+	default:
+		return "unknown"
+	}
+}
+
+func scopeHost(scope metrix.HostScope) string {
+	if scope.Hostname != "" {
+		return scope.Hostname
+	}
+	return scope.ScopeKey
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package oneshot
+
+import (
+	"cmp"
+	"encoding/json"
+	"io"
+	"slices"
+	"time"
+)
+
+// Dump is everything a one-shot run collected.
+type Dump struct {
+	Module string  `json:"module"`
+	Job    string  `json:"job"`
+	Cycles []Cycle `json:"cycles"`
+}
+
+// Cycle holds one collection. Metrics are the raw values reported by the
+// collector, Charts are what the agent would send to Netdata for them.
+type Cycle struct {
+	Cycle     int       `json:"cycle"`
+	Timestamp time.Time `json:"timestamp"`
+	Error     string    `json:"error,omitempty"`
+	Metrics   []Metric  `json:"metrics"`
+	Charts    []Chart   `json:"charts"`
+}
+
+// Metric is one collected series. Type is empty for v1 collectors, which
+// report untyped values.
+type Metric struct {
+	Name   string            `json:"name"`
+	Host   string            `json:"host,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Type   string            `json:"type,omitempty"`
+	Value  float64           `json:"value"`
+}
+
+type Chart struct {
+	ID         string            `json:"id"`
+	Host       string            `json:"host,omitempty"`
+	Context    string            `json:"context"`
+	Title      string            `json:"title"`
+	Units      string            `json:"units"`
+	Family     string            `json:"family"`
+	Type       string            `json:"type"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Dimensions []Dimension       `json:"dimensions"`
+}
+
+// Dimension maps a chart dimension to its collected value. Metric is the
+// collected key for v1 collectors. Value is nil when nothing was collected for
+// the dimension in this cycle.
+type Dimension struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Metric     string   `json:"metric,omitempty"`
+	Algorithm  string   `json:"algorithm"`
+	Multiplier int      `json:"multiplier"`
+	Divisor    int      `json:"divisor"`
+	Hidden     bool     `json:"hidden,omitempty"`
+	Value      *float64 `json:"value"`
+}
+
+func (c *Cycle) finish() {
+	if c.Metrics == nil {
+		c.Metrics = []Metric{}
+	}
+	if c.Charts == nil {
+		c.Charts = []Chart{}
+	}
+	slices.SortStableFunc(c.Metrics, func(a, b Metric) int {
+		return cmp.Or(
+			cmp.Compare(a.Host, b.Host),
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(labelsKey(a.Labels), labelsKey(b.Labels)),
+		)
+	})
+	slices.SortStableFunc(c.Charts, func(a, b Chart) int {
+		return cmp.Or(cmp.Compare(a.Host, b.Host), cmp.Compare(a.ID, b.ID))
+	})
+}
+
+// WriteJSON writes the dump as indented JSON.
+func (d *Dump) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package oneshot runs a single collector job for a fixed number of cycles
+// without an agent and records what it collected: the raw metrics and the
+// charts and dimensions they are mapped to.
+package oneshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/logger"
+	secretresolver "github.com/netdata/netdata/go/plugins/plugin/agent/secrets/resolver"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/confgroup"
+	"gopkg.in/yaml.v2"
+)
+
+type Config struct {
+	Creator collectorapi.Creator
+	// Job is the job config with module defaults already applied.
+	Job confgroup.Config
+	// Cycles is the number of collection cycles; values below 1 mean one.
+	Cycles int
+	// Interval is the pause between cycles. Zero uses the job update_every.
+	Interval time.Duration
+}
+
+type module interface {
+	GetBase() *collectorapi.Base
+	Init(context.Context) error
+	Check(context.Context) error
+	Cleanup(context.Context)
+}
+
+type collector interface {
+	collect(ctx context.Context, cycle *Cycle) error
+}
+
+// Run initializes and checks the job, collects it cfg.Cycles times and returns
+// the recorded cycles. A failed collection is recorded in the cycle and does
+// not stop the run.
+func Run(ctx context.Context, cfg Config) (*Dump, error) {
+	if ctx == nil || cfg.Job == nil {
+		return nil, errors.New("oneshot: invalid config")
+	}
+	name, modName := cfg.Job.Name(), cfg.Job.Module()
+
+	mod, coll, err := construct(cfg.Creator, cfg.Job.FullName())
+	if err != nil {
+		return nil, err
+	}
+	mod.GetBase().Logger = logger.New().With(
+		slog.String("collector", modName),
+		slog.String("job", name),
+	)
+	defer func() { _ = callModule(func() error { mod.Cleanup(context.WithoutCancel(ctx)); return nil }) }()
+
+	if err := applyConfig(ctx, cfg.Job, mod); err != nil {
+		return nil, err
+	}
+	if err := callModule(func() error { return mod.Init(ctx) }); err != nil {
+		return nil, fmt.Errorf("oneshot: init: %w", err)
+	}
+	if err := callModule(func() error { return mod.Check(ctx) }); err != nil {
+		return nil, fmt.Errorf("oneshot: check: %w", err)
+	}
+
+	if runner, ok := mod.(collectorapi.CollectorV2Runner); ok {
+		runCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() { _ = callModule(func() error { return runner.Run(runCtx) }) }()
+	}
+
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = time.Duration(max(cfg.Job.UpdateEvery(), 1)) * time.Second
+	}
+
+	dump := &Dump{Module: modName, Job: name}
+	for i := range max(cfg.Cycles, 1) {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return dump, ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+		cycle := Cycle{Cycle: i + 1, Timestamp: time.Now().UTC()}
+		if err := callModule(func() error { return coll.collect(ctx, &cycle) }); err != nil {
+			cycle.Error = err.Error()
+		}
+		cycle.finish()
+		dump.Cycles = append(dump.Cycles, cycle)
+	}
+	return dump, nil
+}
+
+func construct(creator collectorapi.Creator, fullName string) (mod module, coll collector, err error) {
+	err = callModule(func() error {
+		switch {
+		case creator.CreateV2 != nil:
+			v2 := creator.CreateV2()
+			if v2 == nil {
+				return nil
+			}
+			mod, coll = v2, newCollectorV2(v2, fullName)
+		case creator.Create != nil:
+			v1 := creator.Create()
+			if v1 == nil {
+				return nil
+			}
+			mod, coll = v1, &collectorV1{module: v1}
+		}
+		return nil
+	})
+	if err == nil && (mod == nil || mod.GetBase() == nil) {
+		err = errors.New("collector creator returned no module")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("oneshot: %w", err)
+	}
+	return mod, coll, nil
+}
+
+// applyConfig resolves env, file and cmd secret references and applies the
+// result to the module. Secretstore references need running stores and are
+// rejected.
+func applyConfig(ctx context.Context, cfg confgroup.Config, mod module) error {
+	keys, err := secretresolver.StoreReferences(map[string]any(cfg))
+	if err != nil {
+		return fmt.Errorf("oneshot: %w", err)
+	}
+	if len(keys) > 0 {
+		return fmt.Errorf("oneshot: secretstore references are not supported (%s)", strings.Join(keys, ", "))
+	}
+	resolver, err := secretresolver.NewDefaultAtomicResolver()
+	if err != nil {
+		return fmt.Errorf("oneshot: %w", err)
+	}
+	resolved, _, err := resolver.ResolveWithReferences(ctx, map[string]any(cfg), nil)
+	if err != nil {
+		return fmt.Errorf("oneshot: resolving secrets: %w", err)
+	}
+	payload, err := yaml.Marshal(resolved)
+	if err == nil {
+		err = callModule(func() error { return yaml.Unmarshal(payload, mod) })
+	}
+	if err != nil {
+		return fmt.Errorf("oneshot: applying config: %w", err)
+	}
+	return nil
+}
+
+// callModule runs collector code, turning a panic into an error.
+func callModule(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn()
+}
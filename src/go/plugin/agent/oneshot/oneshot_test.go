@@ -0,0 +1,258 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package oneshot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/pkg/metrix"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/confgroup"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	tests := map[string]struct {
+		creator   func() collectorapi.Creator
+		config    confgroup.Config
+		cycles    int
+		wantErr   bool
+		wantCheck func(t *testing.T, dump *Dump)
+	}{
+		"v1 collector": {
+			creator: func() collectorapi.Creator { return collectorapi.Creator{Create: newMockV1} },
+			config:  newJobConfig("mock", "job1", nil),
+			cycles:  2,
+			wantCheck: func(t *testing.T, dump *Dump) {
+				assert.Equal(t, "mock", dump.Module)
+				assert.Equal(t, "job1", dump.Job)
+				require.Len(t, dump.Cycles, 2)
+
+				cycle := dump.Cycles[1]
+				assert.Equal(t, 2, cycle.Cycle)
+				assert.Empty(t, cycle.Error)
+				assert.Equal(t, []Metric{
+					{Name: "reads", Value: 20},
+					{Name: "writes", Value: 2},
+				}, cycle.Metrics)
+				require.Len(t, cycle.Charts, 1)
+				assert.Equal(t, Chart{
+					ID:      "io",
+					Context: "mock.io",
+					Title:   "IO",
+					Units:   "ops/s",
+					Family:  "io",
+					Type:    "line",
+					Dimensions: []Dimension{
+						{ID: "reads", Name: "read", Metric: "reads", Algorithm: "incremental", Multiplier: 1, Divisor: 1, Value: ptr(20)},
+						{ID: "writes", Name: "writes", Metric: "writes", Algorithm: "absolute", Multiplier: -1, Divisor: 1, Value: ptr(2)},
+						{ID: "errors", Name: "errors", Metric: "errors", Algorithm: "absolute", Multiplier: 1, Divisor: 1},
+					},
+				}, cycle.Charts[0])
+			},
+		},
+		"v2 collector": {
+			creator: func() collectorapi.Creator {
+				return collectorapi.Creator{CreateV2: func() collectorapi.CollectorV2 { return newMockV2() }}
+			},
+			config: newJobConfig("apache", "local", nil),
+			cycles: 1,
+			wantCheck: func(t *testing.T, dump *Dump) {
+				require.Len(t, dump.Cycles, 1)
+				cycle := dump.Cycles[0]
+				assert.Empty(t, cycle.Error)
+				assert.Equal(t, []Metric{{Name: "apache.workers_busy", Type: "gauge", Value: 1}}, cycle.Metrics)
+				require.Len(t, cycle.Charts, 1)
+				chart := cycle.Charts[0]
+				assert.Equal(t, "workers_busy", chart.Context)
+				assert.Equal(t, "Workers", chart.Family)
+				require.Len(t, chart.Dimensions, 1)
+				assert.Equal(t, "busy", chart.Dimensions[0].Name)
+				assert.Equal(t, ptr(1), chart.Dimensions[0].Value)
+			},
+		},
+		"collect failure is recorded in the cycle": {
+			creator: func() collectorapi.Creator {
+				return collectorapi.Creator{CreateV2: func() collectorapi.CollectorV2 {
+					m := newMockV2()
+					m.collectErr = errors.New("connection refused")
+					return m
+				}}
+			},
+			config: newJobConfig("apache", "local", nil),
+			cycles: 1,
+			wantCheck: func(t *testing.T, dump *Dump) {
+				require.Len(t, dump.Cycles, 1)
+				assert.Equal(t, "connection refused", dump.Cycles[0].Error)
+				assert.Empty(t, dump.Cycles[0].Metrics)
+			},
+		},
+		"invalid job config": {
+			creator: func() collectorapi.Creator { return collectorapi.Creator{Create: newMockV1} },
+			config:  newJobConfig("mock", "job1", map[string]any{"option_int": "not a number"}),
+			wantErr: true,
+		},
+		"init failure": {
+			creator: func() collectorapi.Creator {
+				return collectorapi.Creator{Create: func() collectorapi.CollectorV1 {
+					return &collectorapi.MockCollectorV1{FailOnInit: true}
+				}}
+			},
+			config:  newJobConfig("mock", "job1", nil),
+			wantErr: true,
+		},
+		"secretstore references are rejected": {
+			creator: func() collectorapi.Creator { return collectorapi.Creator{Create: newMockV1} },
+			config:  newJobConfig("mock", "job1", map[string]any{"option_str": "${store:vault:prod:password}"}),
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dump, err := Run(context.Background(), Config{
+				Creator:  test.creator(),
+				Job:      test.config,
+				Cycles:   test.cycles,
+				Interval: time.Millisecond,
+			})
+
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			test.wantCheck(t, dump)
+		})
+	}
+}
+
+func TestDump_WriteOpenMetrics(t *testing.T) {
+	ts := time.Unix(1700000000, 500_000_000)
+	dump := &Dump{
+		Module: "mock",
+		Job:    "job1",
+		Cycles: []Cycle{
+			{
+				Cycle:     1,
+				Timestamp: ts,
+				Metrics: []Metric{
+					{Name: "reads", Value: 10},
+					{Name: "http.requests_total", Type: "counter", Labels: map[string]string{"code": `2"x`}, Value: 5},
+				},
+				Charts: []Chart{
+					{ID: "io", Context: "mock.io", Units: "ops/s", Dimensions: []Dimension{
+						{Name: "read", Value: ptr(10)},
+						{Name: "errors"},
+					}},
+				},
+			},
+			{
+				Cycle:     2,
+				Timestamp: ts.Add(time.Second),
+				Metrics:   []Metric{{Name: "reads", Value: 12}},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, dump.WriteOpenMetrics(&buf))
+
+	want := `# TYPE http_requests counter
+http_requests_total{code="2\"x",job="job1"} 5 1700000000.500
+# TYPE mock_reads unknown
+mock_reads{job="job1"} 10 1700000000.500
+mock_reads{job="job1"} 12 1700000001.500
+# TYPE netdata_chart_dimension gauge
+# HELP netdata_chart_dimension Collected chart dimension value before the dimension algorithm is applied.
+netdata_chart_dimension{chart="io",context="mock.io",dimension="read",job="job1",units="ops/s"} 10 1700000000.500
+# EOF
+`
+	assert.Equal(t, want, buf.String())
+}
+
+func newJobConfig(module, name string, extra map[string]any) confgroup.Config {
+	cfg := confgroup.Config{}
+	for k, v := range extra {
+		cfg[k] = v
+	}
+	cfg.SetModule(module)
+	cfg.SetName(name)
+	cfg.ApplyDefaults(confgroup.Default{UpdateEvery: 1})
+	return cfg
+}
+
+func newMockV1() collectorapi.CollectorV1 {
+	var n int64
+	return &collectorapi.MockCollectorV1{
+		ChartsFunc: func() *collectorapi.Charts {
+			return &collectorapi.Charts{
+				{
+					ID: "io", Title: "IO", Units: "ops/s", Fam: "io", Ctx: "mock.io",
+					Dims: collectorapi.Dims{
+						{ID: "reads", Name: "read", Algo: collectorapi.Incremental},
+						{ID: "writes", Mul: -1},
+						{ID: "errors"},
+					},
+				},
+				{ID: "old", Opts: collectorapi.Opts{Obsolete: true}},
+			}
+		},
+		CollectFunc: func(context.Context) map[string]int64 {
+			n++
+			return map[string]int64{"reads": n * 10, "writes": 2}
+		},
+	}
+}
+
+type mockV2 struct {
+	collectorapi.Base
+
+	store      metrix.CollectorStore
+	collectErr error
+}
+
+func newMockV2() *mockV2 {
+	return &mockV2{store: metrix.NewCollectorStore()}
+}
+
+func (m *mockV2) Init(context.Context) error  { return nil }
+func (m *mockV2) Check(context.Context) error { return nil }
+func (m *mockV2) Cleanup(context.Context)     {}
+func (m *mockV2) Configuration() any          { return nil }
+
+func (m *mockV2) Collect(context.Context) error {
+	if m.collectErr != nil {
+		return m.collectErr
+	}
+	m.store.Write().SnapshotMeter("apache").Gauge("workers_busy").Observe(1)
+	return nil
+}
+
+func (m *mockV2) MetricStore() metrix.CollectorStore { return m.store }
+
+func (m *mockV2) ChartTemplateYAML() string {
+	return `
+version: v1
+groups:
+  - family: Workers
+    metrics:
+      - apache.workers_busy
+    charts:
+      - id: workers_busy
+        title: Workers Busy
+        context: workers_busy
+        units: workers
+        dimensions:
+          - selector: apache.workers_busy
+            name: busy
+`
+}
+
+func ptr(v float64) *float64 { return &v }
@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package oneshot
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const chartDimensionFamily = "netdata_chart_dimension"
+
+type omFamily struct {
+	typ    string
+	help   string
+	series map[string]*omSeries
+}
+
+type omSeries struct {
+	labels  string
+	samples []omSample
+}
+
+type omSample struct {
+	value float64
+	ts    time.Time
+}
+
+// WriteOpenMetrics writes the dump in the OpenMetrics text format. Samples
+// carry the cycle timestamp, so every cycle is kept. V1 metrics are prefixed
+// with the module name since their keys are not globally unique. Chart
+// dimension values are exposed as the netdata_chart_dimension family.
+func (d *Dump) WriteOpenMetrics(w io.Writer) error {
+	families := make(map[string]*omFamily)
+
+	family := func(name, typ, help string) *omFamily {
+		f, ok := families[name]
+		if !ok {
+			f = &omFamily{typ: typ, help: help, series: make(map[string]*omSeries)}
+			families[name] = f
+		} else if f.typ != typ {
+			f.typ = "unknown"
+		}
+		return f
+	}
+	add := func(f *omFamily, labels map[string]string, v float64, ts time.Time) {
+		key := formatLabels(labels)
+		s, ok := f.series[key]
+		if !ok {
+			s = &omSeries{labels: key}
+			f.series[key] = s
+		}
+		s.samples = append(s.samples, omSample{value: v, ts: ts})
+	}
+
+	for _, cycle := range d.Cycles {
+		for _, m := range cycle.Metrics {
+			name, typ := sanitizeMetricName(m.Name), omType(m.Type)
+			if m.Type == "" {
+				name = sanitizeMetricName(d.Module + "_" + m.Name)
+			}
+			if typ == "counter" {
+				name = strings.TrimSuffix(name, "_total")
+			}
+			labels := make(map[string]string, len(m.Labels)+2)
+			for k, v := range m.Labels {
+				labels[sanitizeLabelName(k)] = v
+			}
+			setDefault(labels, "job", d.Job)
+			if m.Host != "" {
+				setDefault(labels, "host", m.Host)
+			}
+			add(family(name, typ, ""), labels, m.Value, cycle.Timestamp)
+		}
+		for _, chart := range cycle.Charts {
+			for _, dim := range chart.Dimensions {
+				if dim.Value == nil {
+					continue
+				}
+				labels := map[string]string{
+					"job":       d.Job,
+					"chart":     chart.ID,
+					"context":   chart.Context,
+					"dimension": dim.Name,
+					"units":     chart.Units,
+				}
+				if chart.Host != "" {
+					labels["host"] = chart.Host
+				}
+				f := family(chartDimensionFamily, "gauge", "Collected chart dimension value before the dimension algorithm is applied.")
+				add(f, labels, *dim.Value, cycle.Timestamp)
+			}
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, name := range slices.Sorted(maps.Keys(families)) {
+		f := families[name]
+		_, _ = fmt.Fprintf(bw, "# TYPE %s %s\n", name, f.typ)
+		if f.help != "" {
+			_, _ = fmt.Fprintf(bw, "# HELP %s %s\n", name, f.help)
+		}
+		sample := name
+		if f.typ == "counter" {
+			sample += "_total"
+		}
+		for _, key := range slices.Sorted(maps.Keys(f.series)) {
+			s := f.series[key]
+			for _, smp := range s.samples {
+				_, _ = fmt.Fprintf(bw, "%s%s %s %s\n", sample, s.labels, formatFloat(smp.value), formatTimestamp(smp.ts))
+			}
+		}
+	}
+	_, _ = bw.WriteString("# EOF\n")
+	return bw.Flush()
+}
+
+func omType(typ string) string {
+	switch typ {
+	case "gauge", "counter":
+		return typ
+	default:
+		return "unknown"
+	}
+}
+
+func setDefault(labels map[string]string, key, value string) {
+	if _, ok := labels[key]; !ok {
+		labels[key] = value
+	}
+}
+
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, k := range slices.Sorted(maps.Keys(labels)) {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(k)
+		sb.WriteString(`="`)
+		sb.WriteString(escapeLabelValue(labels[k]))
+		sb.WriteByte('"')
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// labelsKey returns a stable key for sorting label sets.
+func labelsKey(labels map[string]string) string {
+	return formatLabels(labels)
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(v string) string {
+	return labelValueReplacer.Replace(v)
+}
+
+func sanitizeMetricName(name string) string {
+	return sanitizeName(name, true)
+}
+
+func sanitizeLabelName(name string) string {
+	return sanitizeName(name, false)
+}
+
+func sanitizeName(name string, allowColon bool) string {
+	if name == "" {
+		return "_"
+	}
+	var sb strings.Builder
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_', r == ':' && allowColon:
+			sb.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				sb.WriteByte('_')
+			}
+			sb.WriteRune(r)
+		default:
+			sb.WriteByte('_')
+		}
+	}
+	return sb.String()
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func formatTimestamp(ts time.Time) string {
+	return strconv.FormatFloat(float64(ts.UnixMilli())/1000, 'f', 3, 64)
+}
@@ -3,6 +3,7 @@
 package secretstore
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -14,6 +15,24 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
+// FileConfigError is a secretstore file config load error. Job is the 1-based
+// position of the store config in the file's jobs list, or zero when the error
+// concerns the whole file.
+type FileConfigError struct {
+	Path string
+	Job  int
+	Err  error
+}
+
+func (e *FileConfigError) Error() string {
+	if e.Job > 0 {
+		return fmt.Sprintf("secretstore file config '%s' job %d: %v", e.Path, e.Job, e.Err)
+	}
+	return fmt.Sprintf("secretstore file config '%s': %v", e.Path, e.Err)
+}
+
+func (e *FileConfigError) Unwrap() error { return e.Err }
+
 type fileRootConfig struct {
 	Jobs []map[string]any `yaml:"jobs"`
 }
@@ -59,7 +78,7 @@ func LoadFileConfigs(roots []string) ([]Config, []error) {
 				if job == nil {
 					errs = append(
 						errs,
-						fmt.Errorf("secretstore file config '%s' job %d: store config is nil", path, i+1),
+						&FileConfigError{Path: path, Job: i + 1, Err: errors.New("store config is nil")},
 					)
 					continue
 				}
@@ -70,7 +89,7 @@ func LoadFileConfigs(roots []string) ([]Config, []error) {
 					if !ok {
 						errs = append(
 							errs,
-							fmt.Errorf("secretstore file config '%s' job %d: store kind must be a string", path, i+1),
+							&FileConfigError{Path: path, Job: i + 1, Err: errors.New("store kind must be a string")},
 						)
 						continue
 					}
@@ -83,7 +102,7 @@ func LoadFileConfigs(roots []string) ([]Config, []error) {
 				cfg.SetSource(source)
 				cfg.SetSourceType(sourceType)
 				if err := validateFileConfig(cfg); err != nil {
-					errs = append(errs, fmt.Errorf("secretstore file config '%s' job %d: %w", path, i+1, err))
+					errs = append(errs, &FileConfigError{Path: path, Job: i + 1, Err: err})
 					continue
 				}
 				cfgs = append(cfgs, cfg)
@@ -97,12 +116,12 @@ func LoadFileConfigs(roots []string) ([]Config, []error) {
 func loadFileConfigJobs(path string) ([]map[string]any, []error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, []error{fmt.Errorf("secretstore file config '%s': %w", path, err)}
+		return nil, []error{&FileConfigError{Path: path, Err: err}}
 	}
 
 	var root fileRootConfig
 	if err := yaml.Unmarshal(data, &root); err != nil {
-		return nil, []error{fmt.Errorf("secretstore file config '%s': %w", path, err)}
+		return nil, []error{&FileConfigError{Path: path, Err: err}}
 	}
 
 	return root.Jobs, nil
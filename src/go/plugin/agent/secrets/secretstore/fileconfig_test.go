@@ -149,6 +149,14 @@ jobs:
 		joined := strings.Join(messages, "\n")
 		assert.Contains(t, joined, "store name is required")
 		assert.Contains(t, joined, "secretstore file config")
+
+		var fileErr *secretstore.FileConfigError
+		require.ErrorAs(t, errs[0], &fileErr)
+		assert.Equal(t, filepath.Join(userRoot, "ss", "gcp-sm.conf"), fileErr.Path)
+		assert.Zero(t, fileErr.Job)
+		require.ErrorAs(t, errs[1], &fileErr)
+		assert.Equal(t, filepath.Join(userRoot, "ss", "vault.conf"), fileErr.Path)
+		assert.Equal(t, 1, fileErr.Job)
 	})
 
 	t.Run("skips null jobs without losing valid siblings", func(t *testing.T) {
@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/agent/configcheck"
+)
+
+// Validate checks the job, service discovery and secretstore config files the
+// Agent would load, without running discovery or any collector. It selects
+// files the same way RunContext does.
+func (a *Agent) Validate(ctx context.Context) (*configcheck.Report, error) {
+	enabled := a.loadEnabledModules(a.loadPluginConfig())
+	setup := a.buildDiscoveryConf(enabled)
+
+	cfg := configcheck.Config{
+		Plugin:           a.Name,
+		Modules:          enabled,
+		Defaults:         setup.Defaults,
+		JobFiles:         make(map[string]string, len(setup.BuildContext.ReadPaths)),
+		SecretStoreRoots: a.CollectorsConfDir,
+	}
+	for _, path := range setup.BuildContext.ReadPaths {
+		module := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		cfg.JobFiles[module] = path
+	}
+
+	if !a.DisableServiceDiscovery && a.Discoverers != nil && len(a.ServiceDiscoveryConfigDir) > 0 {
+		files, err := a.ServiceDiscoveryConfigDir.FindFiles(".conf")
+		if err != nil {
+			return nil, err
+		}
+		cfg.SDFiles = files
+		cfg.Discoverers = a.Discoverers
+	}
+
+	return configcheck.Run(ctx, cfg)
+}
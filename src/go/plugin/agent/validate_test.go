@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/netdata/netdata/go/plugins/plugin/agent/configcheck"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgent_Validate(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "etc", "netdata", "go.d")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "module1.conf"), []byte("jobs:\n  - name: job1\n    option_int: x\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "unused.conf"), []byte("jobs: ["), 0o644))
+
+	newModule := func() collectorapi.CollectorV1 { return &collectorapi.MockCollectorV1{} }
+	a := New(Config{
+		Name:                "test",
+		CollectorsConfigDir: []string{dir},
+		ModuleRegistry: collectorapi.Registry{
+			"module1": {Create: newModule},
+			"module2": {Create: newModule},
+		},
+		RunModule: "all",
+	})
+
+	report, err := a.Validate(context.Background())
+	require.NoError(t, err)
+
+	assert.False(t, report.Valid)
+	assert.Equal(t, 1, report.Summary.Files, "only config files of enabled modules are checked")
+	require.Len(t, report.Issues, 1)
+	assert.Equal(t, configcheck.Issue{
+		Kind:     configcheck.KindJob,
+		File:     filepath.Join(dir, "module1.conf"),
+		Line:     2,
+		Module:   "module1",
+		Job:      "job1",
+		Stage:    configcheck.StageConfig,
+		Severity: configcheck.SeverityError,
+		Message:  report.Issues[0].Message,
+	}, report.Issues[0])
+}
@@ -90,6 +90,12 @@ type (
 		// publication for each returned Function ID.
 		InstanceFunctions func(job RuntimeJob) []funcapi.FunctionConfig
 
+		// Optional: Import converts configuration files of another monitoring
+		// system into jobs of this module, rendered as YAML with a top-level
+		// 'jobs' list. It backs the --import command line mode and the dyncfg
+		// 'import' command of the module template.
+		Import func(sources ...[]byte) ([]byte, error)
+
 		// FunctionOnly indicates this module provides only functions, no metrics.
 		// Jobs created from this module skip data collection and chart creation.
 		// The module must still implement Init() and Check() for connectivity validation.
@@ -48,6 +48,10 @@ const (
 	CommandTest       Command = "test"
 	CommandSchema     Command = "schema"
 	CommandUserconfig Command = "userconfig"
+	CommandHistory    Command = "history"
+	CommandDiff       Command = "diff"
+	CommandRollback   Command = "rollback"
+	CommandImport     Command = "import"
 )
 
 // Testable is an optional operational-test capability for configured resources.
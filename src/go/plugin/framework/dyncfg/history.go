@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package dyncfg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultHistoryLimit is the number of revisions kept per config when
+// NewHistory is called with a non-positive limit.
+const DefaultHistoryLimit = 20
+
+// maxHistoryPayload bounds one stored revision payload.
+const maxHistoryPayload = 256 << 10
+
+var ErrRevisionNotFound = errors.New("dyncfg history: revision not found")
+
+// Revision is one stored config payload and the change that produced it.
+type Revision struct {
+	Version    uint64    `json:"version"`
+	Timestamp  time.Time `json:"timestamp"`
+	Command    Command   `json:"command,omitempty"`
+	Source     string    `json:"source,omitempty"`
+	SourceType string    `json:"source_type,omitempty"`
+	Status     string    `json:"status,omitempty"`
+	Payload    string    `json:"payload"`
+}
+
+type historyFile struct {
+	ID        string     `json:"id"`
+	Revisions []Revision `json:"revisions"`
+}
+
+// History keeps a bounded, per-config revision log on disk.
+// A nil *History is valid and records nothing.
+type History struct {
+	mu    sync.Mutex
+	dir   string
+	limit int
+	now   func() time.Time
+}
+
+// NewHistory returns a History that stores one JSON file per config ID in dir.
+// Returns nil when dir is empty.
+func NewHistory(dir string, limit int) *History {
+	if dir == "" {
+		return nil
+	}
+	if limit <= 0 {
+		limit = DefaultHistoryLimit
+	}
+	return &History{dir: dir, limit: limit, now: time.Now}
+}
+
+// Record appends rev to the config's history, assigning the next version and
+// timestamp. Payloads identical to the latest revision are not recorded again.
+// Returns the stored revision and whether anything was written.
+func (h *History) Record(id string, rev Revision) (Revision, bool, error) {
+	if h == nil {
+		return Revision{}, false, nil
+	}
+	if id == "" {
+		return Revision{}, false, errors.New("dyncfg history: empty config ID")
+	}
+	if len(rev.Payload) > maxHistoryPayload {
+		return Revision{}, false, fmt.Errorf("dyncfg history: payload exceeds %d bytes", maxHistoryPayload)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	file, err := h.load(id)
+	if err != nil {
+		return Revision{}, false, err
+	}
+	if n := len(file.Revisions); n > 0 {
+		latest := file.Revisions[n-1]
+		if latest.Payload == rev.Payload {
+			return latest, false, nil
+		}
+		rev.Version = latest.Version + 1
+	} else {
+		rev.Version = 1
+	}
+	if rev.Timestamp.IsZero() {
+		rev.Timestamp = h.now().UTC()
+	}
+
+	file.ID = id
+	file.Revisions = append(file.Revisions, rev)
+	if over := len(file.Revisions) - h.limit; over > 0 {
+		file.Revisions = append(file.Revisions[:0], file.Revisions[over:]...)
+	}
+	if err := h.save(file); err != nil {
+		return Revision{}, false, err
+	}
+	return rev, true, nil
+}
+
+// List returns the config's revisions, oldest first.
+func (h *History) List(id string) ([]Revision, error) {
+	if h == nil {
+		return nil, nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	file, err := h.load(id)
+	if err != nil {
+		return nil, err
+	}
+	return file.Revisions, nil
+}
+
+// Get returns one revision by version. Version 0 selects the latest revision.
+func (h *History) Get(id string, version uint64) (Revision, error) {
+	revs, err := h.List(id)
+	if err != nil {
+		return Revision{}, err
+	}
+	if len(revs) == 0 {
+		return Revision{}, ErrRevisionNotFound
+	}
+	if version == 0 {
+		return revs[len(revs)-1], nil
+	}
+	for _, rev := range revs {
+		if rev.Version == version {
+			return rev, nil
+		}
+	}
+	return Revision{}, ErrRevisionNotFound
+}
+
+// Previous returns the revision recorded immediately before the latest one.
+func (h *History) Previous(id string) (Revision, error) {
+	revs, err := h.List(id)
+	if err != nil {
+		return Revision{}, err
+	}
+	if len(revs) < 2 {
+		return Revision{}, ErrRevisionNotFound
+	}
+	return revs[len(revs)-2], nil
+}
+
+func (h *History) load(id string) (historyFile, error) {
+	bs, err := os.ReadFile(h.path(id))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return historyFile{ID: id}, nil
+		}
+		return historyFile{}, fmt.Errorf("dyncfg history: %w", err)
+	}
+	var file historyFile
+	if err := json.Unmarshal(bs, &file); err != nil {
+		return historyFile{}, fmt.Errorf("dyncfg history: corrupted file for '%s': %w", id, err)
+	}
+	if file.ID != id {
+		return historyFile{}, fmt.Errorf("dyncfg history: file for '%s' belongs to '%s'", id, file.ID)
+	}
+	return file, nil
+}
+
+func (h *History) save(file historyFile) error {
+	bs, err := json.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("dyncfg history: %w", err)
+	}
+	if err := os.MkdirAll(h.dir, 0o755); err != nil {
+		return fmt.Errorf("dyncfg history: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(h.dir, ".history-*")
+	if err != nil {
+		return fmt.Errorf("dyncfg history: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(bs); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("dyncfg history: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("dyncfg history: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), h.path(file.ID)); err != nil {
+		return fmt.Errorf("dyncfg history: %w", err)
+	}
+	return nil
+}
+
+// path escapes the config ID so that every ID maps to a distinct file name.
+func (h *History) path(id string) string {
+	return filepath.Join(h.dir, url.QueryEscape(id)+".json")
+}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package dyncfg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHistory_EmptyDir(t *testing.T) {
+	h := NewHistory("", 5)
+	require.Nil(t, h)
+
+	rev, recorded, err := h.Record("id", Revision{Payload: "a"})
+	require.NoError(t, err)
+	assert.False(t, recorded)
+	assert.Zero(t, rev)
+
+	revs, err := h.List("id")
+	require.NoError(t, err)
+	assert.Empty(t, revs)
+}
+
+func TestHistory_Record(t *testing.T) {
+	h := NewHistory(t.TempDir(), 3)
+
+	rev, recorded, err := h.Record("go.d:collector:nginx:local", Revision{Command: CommandAdd, Source: "user=a", Payload: "a: 1\n"})
+	require.NoError(t, err)
+	require.True(t, recorded)
+	assert.Equal(t, uint64(1), rev.Version)
+	assert.False(t, rev.Timestamp.IsZero())
+
+	_, recorded, err = h.Record("go.d:collector:nginx:local", Revision{Command: CommandUpdate, Payload: "a: 1\n"})
+	require.NoError(t, err)
+	assert.False(t, recorded, "identical payload must not create a revision")
+
+	for _, payload := range []string{"a: 2\n", "a: 3\n", "a: 4\n"} {
+		_, recorded, err = h.Record("go.d:collector:nginx:local", Revision{Command: CommandUpdate, Payload: payload})
+		require.NoError(t, err)
+		require.True(t, recorded)
+	}
+
+	revs, err := h.List("go.d:collector:nginx:local")
+	require.NoError(t, err)
+	require.Len(t, revs, 3)
+	assert.Equal(t, uint64(2), revs[0].Version)
+	assert.Equal(t, uint64(4), revs[2].Version)
+	assert.Equal(t, "a: 4\n", revs[2].Payload)
+}
+
+func TestHistory_PersistsAcrossInstances(t *testing.T) {
+	dir := t.TempDir()
+
+	_, _, err := NewHistory(dir, 0).Record("job:a", Revision{Payload: "x"})
+	require.NoError(t, err)
+	_, _, err = NewHistory(dir, 0).Record("job:a", Revision{Payload: "y"})
+	require.NoError(t, err)
+
+	h := NewHistory(dir, 0)
+	latest, err := h.Get("job:a", 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), latest.Version)
+	assert.Equal(t, "y", latest.Payload)
+
+	prev, err := h.Previous("job:a")
+	require.NoError(t, err)
+	assert.Equal(t, "x", prev.Payload)
+
+	_, err = h.Get("job:a", 7)
+	assert.ErrorIs(t, err, ErrRevisionNotFound)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "temporary files must not be left behind")
+}
+
+func TestHistory_DistinctIDsDoNotCollide(t *testing.T) {
+	h := NewHistory(t.TempDir(), 0)
+
+	_, _, err := h.Record("a:b", Revision{Payload: "1"})
+	require.NoError(t, err)
+	_, _, err = h.Record("a_b", Revision{Payload: "2"})
+	require.NoError(t, err)
+
+	rev, err := h.Get("a:b", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "1", rev.Payload)
+	rev, err = h.Get("a_b", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "2", rev.Payload)
+}
+
+func TestHistory_CorruptedFile(t *testing.T) {
+	dir := t.TempDir()
+	h := NewHistory(dir, 0)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "job.json"), []byte("{"), 0o644))
+
+	_, err := h.List("job")
+	assert.Error(t, err)
+	_, _, err = h.Record("job", Revision{Payload: "x"})
+	assert.Error(t, err)
+}
+
+func TestDiff(t *testing.T) {
+	tests := map[string]struct {
+		from string
+		to   string
+		want string
+	}{
+		"equal": {
+			from: "a: 1\n",
+			to:   "a: 1\n",
+			want: "",
+		},
+		"changed line": {
+			from: "a: 1\nb: 2\nc: 3\n",
+			to:   "a: 1\nb: 5\nc: 3\n",
+			want: "--- v1\n+++ v2\n  a: 1\n- b: 2\n+ b: 5\n  c: 3\n",
+		},
+		"added and removed": {
+			from: "a: 1\nb: 2\n",
+			to:   "b: 2\nc: 3\n",
+			want: "--- v1\n+++ v2\n- a: 1\n  b: 2\n+ c: 3\n",
+		},
+		"from empty": {
+			from: "",
+			to:   "a: 1\n",
+			want: "--- v1\n+++ v2\n+ a: 1\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, Diff("v1", "v2", test.from, test.to))
+		})
+	}
+}
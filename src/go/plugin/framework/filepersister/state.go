@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package filepersister
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/pkg/pluginconfig"
+)
+
+// StatePath returns the path of a state file in the Netdata lib dir,
+// or "" when the lib dir is unknown and state must not be persisted.
+func StatePath(name string) string {
+	dir := pluginconfig.VarLibDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, name)
+}
+
+// State tracks a JSON state file that is read on first use and written at
+// most once per SaveInterval. It is not safe for concurrent use.
+type State struct {
+	// Path returns the state file path. A nil Path, or one returning "",
+	// keeps the state in memory only.
+	Path         func() string
+	SaveInterval time.Duration
+
+	loaded   bool
+	lastSave time.Time
+}
+
+// Load decodes the state file into v on the first call. It reports whether
+// v was filled; later calls, and calls without a readable file, return false.
+func (s *State) Load(v any) bool {
+	if s.loaded {
+		return false
+	}
+	s.loaded = true
+
+	path := s.path()
+	if path == "" {
+		return false
+	}
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(bs, v) == nil
+}
+
+// Loaded reports whether Load has been called.
+func (s *State) Loaded() bool {
+	return s.loaded
+}
+
+// SaveDue reports whether SaveInterval has passed since the last save.
+func (s *State) SaveDue(now time.Time) bool {
+	return s.lastSave.IsZero() || now.Sub(s.lastSave) >= s.SaveInterval
+}
+
+// Save writes data to the state file.
+func (s *State) Save(now time.Time, data interface{ Bytes() ([]byte, error) }) {
+	s.lastSave = now
+	Save(s.path(), data)
+}
+
+func (s *State) path() string {
+	if s.Path == nil {
+		return ""
+	}
+	return s.Path()
+}
@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package filepersister
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	s := &State{Path: func() string { return path }, SaveInterval: time.Minute}
+	var v map[string]int
+	require.False(t, s.Load(&v), "no state file yet")
+	require.True(t, s.Loaded())
+	require.True(t, s.SaveDue(now), "never saved")
+
+	s.Save(now, saveTestData{value: `{"a":1}`})
+	require.False(t, s.SaveDue(now.Add(30*time.Second)))
+	require.True(t, s.SaveDue(now.Add(time.Minute)))
+
+	restarted := &State{Path: func() string { return path }}
+	require.True(t, restarted.Load(&v))
+	require.Equal(t, map[string]int{"a": 1}, v)
+	require.False(t, restarted.Load(&v), "the state file is read once")
+}
+
+func TestState_InMemory(t *testing.T) {
+	s := &State{SaveInterval: time.Minute}
+	var v map[string]int
+	require.False(t, s.Load(&v))
+	s.Save(time.Now(), saveTestData{value: `{"a":1}`})
+	require.False(t, s.SaveDue(time.Now()), "SaveDue tracks saves without a state file too")
+}
@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cron
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+)
+
+const (
+	prioRuns = collectorapi.Priority + iota
+	prioRunning
+	prioJobs
+
+	prioJobRuns
+	prioJobRunning
+	prioJobLastRunDuration
+	prioJobScheduleStatus
+)
+
+var baseCharts = collectorapi.Charts{
+	runsChart.Copy(),
+	runningChart.Copy(),
+	jobsChart.Copy(),
+}
+
+var (
+	runsChart = collectorapi.Chart{
+		ID:       "runs",
+		Title:    "Cron Job Runs",
+		Units:    "runs/s",
+		Fam:      "runs",
+		Ctx:      "cron.runs",
+		Priority: prioRuns,
+		Dims: collectorapi.Dims{
+			{ID: "runs_started", Name: "started", Algo: collectorapi.Incremental},
+			{ID: "runs_succeeded", Name: "succeeded", Algo: collectorapi.Incremental},
+			{ID: "runs_failed", Name: "failed", Algo: collectorapi.Incremental},
+			{ID: "runs_missed", Name: "missed", Algo: collectorapi.Incremental},
+		},
+	}
+	runningChart = collectorapi.Chart{
+		ID:       "running",
+		Title:    "Cron Jobs Running",
+		Units:    "jobs",
+		Fam:      "runs",
+		Ctx:      "cron.running",
+		Priority: prioRunning,
+		Dims: collectorapi.Dims{
+			{ID: "running"},
+		},
+	}
+	jobsChart = collectorapi.Chart{
+		ID:       "jobs",
+		Title:    "Cron Jobs",
+		Units:    "jobs",
+		Fam:      "jobs",
+		Ctx:      "cron.jobs",
+		Priority: prioJobs,
+		Dims: collectorapi.Dims{
+			{ID: "jobs_scheduled", Name: "scheduled"},
+			{ID: "jobs_missed", Name: "missed"},
+		},
+	}
+)
+
+var jobChartsTmpl = collectorapi.Charts{
+	jobRunsChartTmpl.Copy(),
+	jobRunningChartTmpl.Copy(),
+	jobLastRunDurationChartTmpl.Copy(),
+	jobScheduleStatusChartTmpl.Copy(),
+}
+
+var (
+	jobRunsChartTmpl = collectorapi.Chart{
+		ID:       "job_%s_runs",
+		Title:    "Cron Job Runs",
+		Units:    "runs/s",
+		Fam:      "job runs",
+		Ctx:      "cron.job_runs",
+		Priority: prioJobRuns,
+		Dims: collectorapi.Dims{
+			{ID: "job_%s_runs_started", Name: "started", Algo: collectorapi.Incremental},
+			{ID: "job_%s_runs_succeeded", Name: "succeeded", Algo: collectorapi.Incremental},
+			{ID: "job_%s_runs_failed", Name: "failed", Algo: collectorapi.Incremental},
+			{ID: "job_%s_runs_missed", Name: "missed", Algo: collectorapi.Incremental},
+		},
+	}
+	jobRunningChartTmpl = collectorapi.Chart{
+		ID:       "job_%s_running",
+		Title:    "Cron Job Running Instances",
+		Units:    "instances",
+		Fam:      "job runs",
+		Ctx:      "cron.job_running",
+		Priority: prioJobRunning,
+		Dims: collectorapi.Dims{
+			{ID: "job_%s_running", Name: "running"},
+		},
+	}
+	jobLastRunDurationChartTmpl = collectorapi.Chart{
+		ID:       "job_%s_last_run_duration",
+		Title:    "Cron Job Last Run Duration",
+		Units:    "seconds",
+		Fam:      "job duration",
+		Ctx:      "cron.job_last_run_duration",
+		Priority: prioJobLastRunDuration,
+		Dims: collectorapi.Dims{
+			{ID: "job_%s_last_run_duration", Name: "duration", Div: 1000},
+		},
+	}
+	jobScheduleStatusChartTmpl = collectorapi.Chart{
+		ID:       "job_%s_schedule_status",
+		Title:    "Cron Job Schedule Status",
+		Units:    "status",
+		Fam:      "job schedule",
+		Ctx:      "cron.job_schedule_status",
+		Priority: prioJobScheduleStatus,
+		Dims: collectorapi.Dims{
+			{ID: "job_%s_schedule_status_ok", Name: "ok"},
+			{ID: "job_%s_schedule_status_missed", Name: "missed"},
+		},
+	}
+)
+
+// maxCommandLabelLength limits the command chart label, long commands are truncated.
+const maxCommandLabelLength = 200
+
+func (c *Collector) addJobCharts(j *cronJob) {
+	charts := jobChartsTmpl.Copy()
+
+	command := j.command
+	if len(command) > maxCommandLabelLength {
+		command = command[:maxCommandLabelLength] + "..."
+	}
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, j.id)
+		chart.Labels = []collectorapi.Label{
+			{Key: "user", Value: j.user},
+			{Key: "command", Value: command},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, j.id)
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) removeJobCharts(j *cronJob) {
+	px := fmt.Sprintf("job_%s_", j.id)
+	for _, chart := range *c.Charts() {
+		if strings.HasPrefix(chart.ID, px) {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
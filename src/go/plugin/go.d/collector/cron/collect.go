@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cron
+
+import (
+	"errors"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/oldmetrix"
+)
+
+// jobInactivityTimeout is how long a job that is no longer in crontab files is kept after its last run.
+const jobInactivityTimeout = 24 * time.Hour
+
+func (c *Collector) collect() (map[string]int64, error) {
+	if c.source == nil {
+		return nil, errors.New("event source is not initialized")
+	}
+
+	now := c.now()
+	events, err := c.source.read()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.lastCrontabLoad.IsZero() || now.Sub(c.lastCrontabLoad) >= c.CrontabReloadEvery.Duration() {
+		c.lastCrontabLoad = now
+		c.updateCrontabJobs(c.loadCrontabs())
+	}
+
+	for _, ev := range events {
+		c.processEvent(ev)
+	}
+
+	c.expireRuns(now)
+	c.removeInactiveJobs(now)
+	c.checkMissedRuns(now)
+
+	mx := make(map[string]int64)
+
+	mx["runs_started"] = c.totals.started
+	mx["runs_succeeded"] = c.totals.succeeded
+	mx["runs_failed"] = c.totals.failed
+	mx["runs_missed"] = c.totals.missed
+	mx["running"] = int64(len(c.runs))
+
+	running := make(map[*cronJob]int64)
+	for _, run := range c.runs {
+		if run.job != nil {
+			running[run.job]++
+		}
+	}
+
+	var scheduled, missed int64
+	for _, j := range c.jobs {
+		if len(j.entries) > 0 {
+			scheduled++
+		}
+		if j.missedNow {
+			missed++
+		}
+
+		if !j.hasCharts {
+			j.hasCharts = true
+			c.addJobCharts(j)
+		}
+
+		px := "job_" + j.id + "_"
+		mx[px+"runs_started"] = j.started
+		mx[px+"runs_succeeded"] = j.succeeded
+		mx[px+"runs_failed"] = j.failed
+		mx[px+"runs_missed"] = j.missed
+		mx[px+"running"] = running[j]
+		mx[px+"last_run_duration"] = j.lastDuration.Milliseconds()
+		mx[px+"schedule_status_ok"] = oldmetrix.Bool(!j.missedNow)
+		mx[px+"schedule_status_missed"] = oldmetrix.Bool(j.missedNow)
+	}
+
+	mx["jobs_scheduled"] = scheduled
+	mx["jobs_missed"] = missed
+
+	return mx, err
+}
+
+func (c *Collector) removeInactiveJobs(now time.Time) {
+	for key, j := range c.jobs {
+		if len(j.entries) > 0 || now.Sub(j.lastStart) < jobInactivityTimeout || c.isJobRunning(j) {
+			continue
+		}
+		delete(c.jobs, key)
+		if j.hasCharts {
+			c.removeJobCharts(j)
+		}
+	}
+}
+
+func (c *Collector) isJobRunning(j *cronJob) bool {
+	for _, run := range c.runs {
+		if run.job == j {
+			return true
+		}
+	}
+	return false
+}
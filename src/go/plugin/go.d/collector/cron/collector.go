@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cron
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/pkg/confopt"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+)
+
+//go:embed "config_schema.json"
+var configSchema string
+
+func init() {
+	collectorapi.Register("cron", collectorapi.Creator{
+		JobConfigSchema: configSchema,
+		Defaults: collectorapi.Defaults{
+			UpdateEvery: 10,
+			Disabled:    true,
+		},
+		Create:          func() collectorapi.CollectorV1 { return New() },
+		Config:          func() any { return &Config{} },
+		SharedFunctions: cronMethods,
+		MethodHandler:   cronFunctionHandler,
+	})
+}
+
+const (
+	sourceAuto    = "auto"
+	sourceFile    = "file"
+	sourceJournal = "journal"
+)
+
+func New() *Collector {
+	return &Collector{
+		Config: Config{
+			Source:             sourceAuto,
+			Path:               "/var/log/cron",
+			ExcludePath:        "*.gz",
+			SystemCrontabs:     []string{"/etc/crontab", "/etc/cron.d/*"},
+			UserCrontabs:       []string{"/var/spool/cron/crontabs/*", "/var/spool/cron/*"},
+			CrontabReloadEvery: confopt.Duration(time.Minute * 5),
+			MissedRunGrace:     confopt.Duration(time.Minute * 5),
+			MaxJobs:            100,
+		},
+		charts: baseCharts.Copy(),
+		now:    time.Now,
+		jobs:   make(map[string]*cronJob),
+		runs:   make(map[int]*cronRun),
+	}
+}
+
+type Config struct {
+	UpdateEvery        int              `yaml:"update_every,omitempty" json:"update_every"`
+	Source             string           `yaml:"source,omitempty" json:"source"`
+	Path               string           `yaml:"path,omitempty" json:"path"`
+	ExcludePath        string           `yaml:"exclude_path,omitempty" json:"exclude_path"`
+	JournalctlPath     string           `yaml:"journalctl_path,omitempty" json:"journalctl_path"`
+	SystemCrontabs     []string         `yaml:"system_crontabs,omitempty" json:"system_crontabs"`
+	UserCrontabs       []string         `yaml:"user_crontabs,omitempty" json:"user_crontabs"`
+	CrontabReloadEvery confopt.Duration `yaml:"crontab_reload_every,omitempty" json:"crontab_reload_every"`
+	MissedRunGrace     confopt.Duration `yaml:"missed_run_grace,omitempty" json:"missed_run_grace"`
+	MaxJobs            int              `yaml:"max_jobs,omitempty" json:"max_jobs"`
+}
+
+type Collector struct {
+	collectorapi.Base
+	Config `yaml:",inline" json:""`
+
+	charts *collectorapi.Charts
+
+	now       func() time.Time
+	newSource func() (eventSource, error)
+
+	source    eventSource
+	startTime time.Time
+
+	lastCrontabLoad time.Time
+
+	// mu protects the jobs state read by the function handler
+	mu           sync.RWMutex
+	jobs         map[string]*cronJob // by user and command
+	runs         map[int]*cronRun    // in progress, by cron child PID
+	totals       cronTotals
+	jobsLimitHit bool
+
+	funcRouter *funcRouter
+}
+
+type cronTotals struct {
+	started   int64
+	succeeded int64
+	failed    int64
+	missed    int64
+}
+
+func (c *Collector) Configuration() any {
+	return c.Config
+}
+
+func (c *Collector) Init(context.Context) error {
+	if err := c.validateConfig(); err != nil {
+		return fmt.Errorf("config validation: %v", err)
+	}
+
+	if c.newSource == nil {
+		c.newSource = c.initEventSource
+	}
+
+	c.funcRouter = newFuncRouter(c)
+
+	c.Debugf("source: '%s', path: '%s'", c.Source, c.Path)
+	c.Debugf("crontabs: system %v, user %v", c.SystemCrontabs, c.UserCrontabs)
+
+	return nil
+}
+
+func (c *Collector) Check(context.Context) error {
+	// Note: the event source is created here to make auto-detection retry working
+	if c.source == nil {
+		src, err := c.newSource()
+		if err != nil {
+			return err
+		}
+		c.source = src
+		c.startTime = c.now()
+	}
+
+	mx, err := c.collect()
+	if err != nil {
+		return err
+	}
+	if len(mx) == 0 {
+		return errors.New("no metrics collected")
+	}
+	return nil
+}
+
+func (c *Collector) Charts() *collectorapi.Charts {
+	return c.charts
+}
+
+func (c *Collector) Collect(context.Context) map[string]int64 {
+	mx, err := c.collect()
+	if err != nil {
+		c.Error(err)
+	}
+
+	if len(mx) == 0 {
+		return nil
+	}
+	return mx
+}
+
+func (c *Collector) Cleanup(ctx context.Context) {
+	if c.source != nil {
+		c.source.close()
+		c.source = nil
+	}
+	if c.funcRouter != nil {
+		c.funcRouter.Cleanup(ctx)
+	}
+}
@@ -0,0 +1,333 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cron
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/collecttest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	dataConfigJSON, _ = os.ReadFile("testdata/config.json")
+	dataConfigYAML, _ = os.ReadFile("testdata/config.yaml")
+)
+
+func Test_testDataIsValid(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"dataConfigJSON": dataConfigJSON,
+		"dataConfigYAML": dataConfigYAML,
+	} {
+		require.NotNil(t, data, name)
+	}
+}
+
+func TestCollector_ConfigurationSerialize(t *testing.T) {
+	collecttest.TestConfigurationSerialize(t, &Collector{}, dataConfigJSON, dataConfigYAML)
+}
+
+func TestCollector_Init(t *testing.T) {
+	tests := map[string]struct {
+		config   Config
+		wantFail bool
+	}{
+		"success on default config": {
+			config: New().Config,
+		},
+		"fails on unknown source": {
+			config:   Config{Source: "syslog"},
+			wantFail: true,
+		},
+		"fails on file source without path": {
+			config:   Config{Source: sourceFile},
+			wantFail: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collr := New()
+			collr.Config = test.config
+
+			if test.wantFail {
+				assert.Error(t, collr.Init(context.Background()))
+			} else {
+				assert.NoError(t, collr.Init(context.Background()))
+			}
+		})
+	}
+}
+
+func TestCollector_Charts(t *testing.T) {
+	assert.NotNil(t, New().Charts())
+}
+
+func TestCollector_Cleanup(t *testing.T) {
+	assert.NotPanics(t, func() { New().Cleanup(context.Background()) })
+}
+
+func TestCollector_Check(t *testing.T) {
+	tests := map[string]struct {
+		prepare  func(t *testing.T) *Collector
+		wantFail bool
+	}{
+		"success on existing log file": {
+			prepare: func(t *testing.T) *Collector {
+				collr, _, _ := prepareCollector(t)
+				return collr
+			},
+		},
+		"fails on missing log file": {
+			wantFail: true,
+			prepare: func(t *testing.T) *Collector {
+				collr := New()
+				collr.Source = sourceFile
+				collr.Path = filepath.Join(t.TempDir(), "cron")
+				return collr
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collr := test.prepare(t)
+			require.NoError(t, collr.Init(context.Background()))
+			defer collr.Cleanup(context.Background())
+
+			if test.wantFail {
+				assert.Error(t, collr.Check(context.Background()))
+			} else {
+				assert.NoError(t, collr.Check(context.Background()))
+			}
+		})
+	}
+}
+
+func TestCollector_Collect(t *testing.T) {
+	collr, logFile, clock := prepareCollector(t)
+	require.NoError(t, collr.Init(context.Background()))
+	defer collr.Cleanup(context.Background())
+
+	*clock = time.Date(2026, 10, 19, 10, 0, 30, 0, time.UTC)
+	require.NoError(t, collr.Check(context.Background()))
+
+	appendLines(t, logFile,
+		"Oct 19 10:15:01 host CRON[100]: (root) CMD (/usr/local/bin/backup.sh --incremental)",
+		"Oct 19 10:15:31 host CRON[100]: (CRON) error (grandchild #102 failed with exit status 2)",
+		"Oct 19 10:15:31 host CRON[100]: pam_unix(cron:session): session closed for user root",
+		"Oct 19 10:17:01 host CRON[110]: (root) CMD (   cd / && run-parts --report /etc/cron.hourly)",
+		"2026-10-19T10:17:03.120000+00:00 host CRON[110]: (root) END (   cd / && run-parts --report /etc/cron.hourly)",
+		"Oct 19 10:20:00 host CROND[120]: (bob) CMD (/usr/bin/unscheduled)",
+		"Oct 19 10:20:00 host sshd[5]: Accepted publickey for bob",
+	)
+
+	*clock = time.Date(2026, 10, 19, 10, 36, 0, 0, time.UTC)
+	mx := collr.Collect(context.Background())
+
+	backup := "job_" + jobID("root", "/usr/local/bin/backup.sh --incremental") + "_"
+	hourly := "job_" + jobID("root", "cd / && run-parts --report /etc/cron.hourly") + "_"
+	daily := "job_" + jobID("root", "test -x /usr/sbin/anacron || { cd / && run-parts --report /etc/cron.daily; }") + "_"
+	reports := "job_" + jobID("alice", "/home/alice/bin/sync-reports") + "_"
+	unscheduled := "job_" + jobID("bob", "/usr/bin/unscheduled") + "_"
+
+	expected := map[string]int64{
+		"runs_started":   3,
+		"runs_succeeded": 1,
+		"runs_failed":    1,
+		"runs_missed":    1,
+		"running":        1,
+		"jobs_scheduled": 4,
+		"jobs_missed":    1,
+	}
+	for px, v := range map[string][8]int64{
+		// started, succeeded, failed, missed, running, duration, ok, missed
+		backup:      {1, 0, 1, 1, 0, 30000, 0, 1},
+		hourly:      {1, 1, 0, 0, 0, 2120, 1, 0},
+		daily:       {0, 0, 0, 0, 0, 0, 1, 0},
+		reports:     {0, 0, 0, 0, 0, 0, 1, 0},
+		unscheduled: {1, 0, 0, 0, 1, 0, 1, 0},
+	} {
+		expected[px+"runs_started"] = v[0]
+		expected[px+"runs_succeeded"] = v[1]
+		expected[px+"runs_failed"] = v[2]
+		expected[px+"runs_missed"] = v[3]
+		expected[px+"running"] = v[4]
+		expected[px+"last_run_duration"] = v[5]
+		expected[px+"schedule_status_ok"] = v[6]
+		expected[px+"schedule_status_missed"] = v[7]
+	}
+
+	assert.Equal(t, expected, mx)
+	collecttest.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+
+	// the same missed run is counted once
+	*clock = time.Date(2026, 10, 19, 10, 40, 0, 0, time.UTC)
+	mx = collr.Collect(context.Background())
+	assert.Equal(t, int64(1), mx["runs_missed"])
+
+	// the next scheduled run (10:45) is missed too
+	*clock = time.Date(2026, 10, 19, 10, 51, 0, 0, time.UTC)
+	mx = collr.Collect(context.Background())
+	assert.Equal(t, int64(2), mx[backup+"runs_missed"])
+
+	// the 11:00 run started on time
+	appendLines(t, logFile, "Oct 19 11:00:02 host CRON[130]: (root) CMD (/usr/local/bin/backup.sh --incremental)")
+	*clock = time.Date(2026, 10, 19, 11, 6, 0, 0, time.UTC)
+	mx = collr.Collect(context.Background())
+	assert.Equal(t, int64(2), mx[backup+"runs_missed"])
+	assert.Equal(t, int64(1), mx[backup+"schedule_status_ok"])
+}
+
+func TestCollector_missedJobsFunction(t *testing.T) {
+	collr, _, clock := prepareCollector(t)
+	require.NoError(t, collr.Init(context.Background()))
+	defer collr.Cleanup(context.Background())
+
+	*clock = time.Date(2026, 10, 19, 10, 0, 30, 0, time.UTC)
+	require.NoError(t, collr.Check(context.Background()))
+
+	*clock = time.Date(2026, 10, 19, 10, 23, 0, 0, time.UTC)
+	_ = collr.Collect(context.Background())
+
+	resp := collr.funcRouter.Handle(context.Background(), missedJobsMethodID, nil)
+	require.Equal(t, 200, resp.Status)
+
+	data, ok := resp.Data.([][]any)
+	require.True(t, ok)
+	require.Len(t, data, 2)
+
+	var commands []string
+	for _, row := range data {
+		commands = append(commands, row[2].(string))
+		assert.Equal(t, "missed", row[3])
+	}
+	assert.ElementsMatch(t, []string{
+		"/usr/local/bin/backup.sh --incremental",
+		"cd / && run-parts --report /etc/cron.hourly",
+	}, commands)
+}
+
+func TestCollector_loadCrontabs(t *testing.T) {
+	collr, _, _ := prepareCollector(t)
+
+	var jobs []string
+	for _, e := range collr.loadCrontabs() {
+		jobs = append(jobs, e.user+" "+e.schedule.String()+" "+e.command)
+	}
+
+	assert.ElementsMatch(t, []string{
+		"root 17 * * * * cd / && run-parts --report /etc/cron.hourly",
+		"root 25 6 * * * test -x /usr/sbin/anacron || { cd / && run-parts --report /etc/cron.daily; }",
+		"root */15 * * * * /usr/local/bin/backup.sh --incremental",
+		"alice 0 */2 * * 1-5 /home/alice/bin/sync-reports",
+	}, jobs)
+}
+
+func Test_parseCronMessage(t *testing.T) {
+	ts := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		msg    string
+		wantOK bool
+		want   cronEvent
+	}{
+		"start": {
+			msg:    "(root) CMD (command -v debian-sa1 > /dev/null && debian-sa1 1 1)",
+			wantOK: true,
+			want:   cronEvent{kind: eventStart, user: "root", command: "command -v debian-sa1 > /dev/null && debian-sa1 1 1"},
+		},
+		"cronie end": {
+			msg:    "(root) CMDEND (run-parts /etc/cron.hourly)",
+			wantOK: true,
+			want:   cronEvent{kind: eventEnd, user: "root", command: "run-parts /etc/cron.hourly"},
+		},
+		"failed": {
+			msg:    "(CRON) error (grandchild #4242 failed with exit status 127)",
+			wantOK: true,
+			want:   cronEvent{kind: eventFailed, exitStatus: 127},
+		},
+		"cronie pam session closed": {
+			msg:    "pam_unix(crond:session): session closed for user root",
+			wantOK: true,
+			want:   cronEvent{kind: eventEnd},
+		},
+		"no MTA": {
+			msg: "(CRON) info (No MTA installed, discarding output)",
+		},
+		"startup": {
+			msg: "(CRON) STARTUP (1.5.7)",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ev, ok := parseCronMessage(test.msg, 10, ts)
+			assert.Equal(t, test.wantOK, ok)
+			if test.wantOK {
+				test.want.pid, test.want.time = 10, ts
+				assert.Equal(t, test.want, ev)
+			}
+		})
+	}
+}
+
+func Test_parseJournalEntry(t *testing.T) {
+	line := `{"__REALTIME_TIMESTAMP":"1760868901000000","SYSLOG_IDENTIFIER":"CRON","SYSLOG_PID":"100","_PID":"100","MESSAGE":"(root) CMD (/usr/bin/true)"}`
+
+	ev, ok := parseJournalEntry([]byte(line))
+	require.True(t, ok)
+	assert.Equal(t, cronEvent{
+		kind:    eventStart,
+		time:    time.UnixMicro(1760868901000000),
+		pid:     100,
+		user:    "root",
+		command: "/usr/bin/true",
+	}, ev)
+}
+
+func Test_parseSyslogTime(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 5, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, 12, 31, 23, 59, 1, 0, time.UTC), parseSyslogTime("Dec 31 23:59:01", now))
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 1, 0, 0, time.UTC), parseSyslogTime("Jan  1 00:01:00", now))
+	assert.True(t, parseSyslogTime("2026-01-01T00:01:00.5+02:00", now).Equal(time.Date(2025, 12, 31, 22, 1, 0, 5e8, time.UTC)))
+	assert.True(t, parseSyslogTime("yesterday", now).IsZero())
+}
+
+func prepareCollector(t *testing.T) (*Collector, string, *time.Time) {
+	t.Helper()
+
+	logFile := filepath.Join(t.TempDir(), "cron")
+	require.NoError(t, os.WriteFile(logFile, []byte("Oct 19 09:59:01 host CRON[1]: (root) CMD (/usr/local/bin/backup.sh --incremental)\n"), 0644))
+
+	clock := new(time.Time)
+	*clock = time.Date(2026, 10, 19, 10, 0, 30, 0, time.UTC)
+
+	collr := New()
+	collr.Source = sourceFile
+	collr.Path = logFile
+	collr.SystemCrontabs = []string{"testdata/crontab", "testdata/cron.d/*"}
+	collr.UserCrontabs = []string{"testdata/crontabs/*"}
+	collr.now = func() time.Time { return *clock }
+
+	return collr, logFile, clock
+}
+
+func appendLines(t *testing.T, path string, lines ...string) {
+	t.Helper()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	_, err = f.WriteString(strings.Join(lines, "\n") + "\n")
+	require.NoError(t, err)
+}
@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cron
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// crontabEntry is a scheduled job defined in a crontab file.
+type crontabEntry struct {
+	file     string
+	user     string
+	command  string
+	schedule *schedule
+}
+
+// loadCrontabs reads the system (with the user field) and user (named after the user) crontab files.
+// Files that cannot be read are skipped: user crontabs are usually readable only by root.
+func (c *Collector) loadCrontabs() []crontabEntry {
+	var entries []crontabEntry
+
+	for _, pattern := range c.SystemCrontabs {
+		for _, path := range globFiles(pattern) {
+			es, err := parseCrontabFile(path, "")
+			if err != nil {
+				c.Debugf("skipping crontab '%s': %v", path, err)
+				continue
+			}
+			entries = append(entries, es...)
+		}
+	}
+
+	for _, pattern := range c.UserCrontabs {
+		for _, path := range globFiles(pattern) {
+			es, err := parseCrontabFile(path, filepath.Base(path))
+			if err != nil {
+				c.Debugf("skipping crontab '%s': %v", path, err)
+				continue
+			}
+			entries = append(entries, es...)
+		}
+	}
+
+	return entries
+}
+
+func globFiles(pattern string) []string {
+	matches, _ := filepath.Glob(pattern)
+
+	files := matches[:0]
+	for _, path := range matches {
+		if fi, err := os.Stat(path); err == nil && fi.Mode().IsRegular() && !ignoredCrontabFile(path) {
+			files = append(files, path)
+		}
+	}
+	return files
+}
+
+// ignoredCrontabFile follows the cron.d naming rules: editor backups and package manager leftovers are not loaded.
+func ignoredCrontabFile(path string) bool {
+	name := filepath.Base(path)
+	return strings.HasPrefix(name, ".") ||
+		strings.HasSuffix(name, "~") ||
+		strings.HasSuffix(name, ".dpkg-old") ||
+		strings.HasSuffix(name, ".dpkg-dist") ||
+		strings.HasSuffix(name, ".dpkg-new") ||
+		strings.HasSuffix(name, ".rpmsave") ||
+		strings.HasSuffix(name, ".rpmnew") ||
+		strings.HasSuffix(name, ".placeholder")
+}
+
+// parseCrontabFile parses a crontab file. If user is empty, the file is in the system format: the user
+// is the field after the schedule.
+func parseCrontabFile(path, user string) ([]crontabEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var entries []crontabEntry
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		e, err := parseCrontabLine(sc.Text(), user)
+		if err != nil || e == nil {
+			continue
+		}
+		e.file = path
+		entries = append(entries, *e)
+	}
+
+	return entries, sc.Err()
+}
+
+// parseCrontabLine returns nil for blank lines, comments, environment settings and @reboot jobs.
+func parseCrontabLine(line, user string) (*crontabEntry, error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") || isEnvSetting(line) {
+		return nil, nil
+	}
+
+	var spec string
+	var rest string
+	if strings.HasPrefix(line, "@") {
+		spec, rest = cutField(line)
+	} else {
+		var fields [5]string
+		rest = line
+		for i := range fields {
+			fields[i], rest = cutField(rest)
+		}
+		spec = strings.Join(fields[:], " ")
+	}
+
+	if user == "" {
+		user, rest = cutField(rest)
+	}
+
+	command := strings.TrimSpace(rest)
+	if user == "" || command == "" {
+		return nil, errors.New("missing user or command")
+	}
+
+	sched, err := parseSchedule(spec)
+	if err != nil {
+		if errors.Is(err, errRebootSchedule) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("schedule '%s': %v", spec, err)
+	}
+
+	return &crontabEntry{user: user, command: command, schedule: sched}, nil
+}
+
+func cutField(s string) (string, string) {
+	s = strings.TrimLeft(s, " \t")
+	i := strings.IndexAny(s, " \t")
+	if i == -1 {
+		return s, ""
+	}
+	return s[:i], s[i+1:]
+}
+
+// isEnvSetting reports whether the line is a "NAME = value" environment setting.
+func isEnvSetting(line string) bool {
+	name, _, ok := strings.Cut(line, "=")
+	if !ok {
+		return false
+	}
+	name = strings.TrimSpace(name)
+	return name != "" && !strings.ContainsAny(name, " \t*@/,")
+}
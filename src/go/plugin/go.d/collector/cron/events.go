@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cron
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type eventKind int
+
+const (
+	eventStart eventKind = iota + 1
+	eventEnd
+	eventFailed
+)
+
+// cronEvent is a cron daemon log message about a job run. Messages about the same run are logged
+// by the same cron child process, so they are correlated by PID.
+type cronEvent struct {
+	kind       eventKind
+	time       time.Time
+	pid        int
+	user       string
+	command    string
+	exitStatus int64
+}
+
+var (
+	// "(root) CMD (command)" - all implementations
+	// "(root) CMDEND (command)" - cronie
+	// "(root) END (command)" - Debian cron with '-L 2'
+	reJobMessage = regexp.MustCompile(`^\(([^)]+)\) (CMD|CMDEND|END) \((.*)\)$`)
+	// "(CRON) error (grandchild #1234 failed with exit status 1)" - Debian cron with '-L 4'
+	reFailedMessage = regexp.MustCompile(`grandchild #\d+ failed with exit status (\d+)`)
+	// "pam_unix(cron:session): session closed for user root" - logged by the cron child after the job exits
+	reSessionClosed = regexp.MustCompile(`^pam_unix\(crond?:session\): session closed for user `)
+)
+
+// parseCronMessage parses the message part of a cron daemon log line. It returns false for messages
+// not related to job runs.
+func parseCronMessage(msg string, pid int, ts time.Time) (cronEvent, bool) {
+	ev := cronEvent{time: ts, pid: pid}
+
+	if m := reJobMessage.FindStringSubmatch(msg); m != nil {
+		ev.user, ev.command = m[1], strings.TrimSpace(m[3])
+		if m[2] == "CMD" {
+			ev.kind = eventStart
+		} else {
+			ev.kind = eventEnd
+		}
+		return ev, true
+	}
+
+	if m := reFailedMessage.FindStringSubmatch(msg); m != nil {
+		ev.kind = eventFailed
+		ev.exitStatus, _ = strconv.ParseInt(m[1], 10, 64)
+		return ev, true
+	}
+
+	if reSessionClosed.MatchString(msg) {
+		ev.kind = eventEnd
+		return ev, true
+	}
+
+	return ev, false
+}
+
+// parseSyslogTime parses RFC 3339 timestamps (rsyslog high precision format) and BSD syslog timestamps
+// ("Oct 19 08:17:01", no year). It returns the zero time if the timestamp cannot be parsed.
+func parseSyslogTime(s string, now time.Time) time.Time {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t
+	}
+
+	t, err := time.ParseInLocation(time.Stamp, s, now.Location())
+	if err != nil {
+		return time.Time{}
+	}
+	t = t.AddDate(now.Year(), 0, 0)
+	// December messages read in January
+	if t.After(now.Add(time.Hour * 24)) {
+		t = t.AddDate(-1, 0, 0)
+	}
+	return t
+}
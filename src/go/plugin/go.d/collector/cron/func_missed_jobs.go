@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cron
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/pkg/funcapi"
+)
+
+const missedJobsMethodID = "missed-jobs"
+
+const missedJobsHelp = "Cron jobs that did not start when their crontab schedule said they should. " +
+	"Only runs scheduled after the collector started are checked."
+
+func missedJobsFunctionConfig() funcapi.FunctionConfig {
+	return funcapi.FunctionConfig{
+		ID:          missedJobsMethodID,
+		Name:        "Missed Cron Jobs",
+		UpdateEvery: 10,
+		Help:        missedJobsHelp,
+	}
+}
+
+// Compile-time interface check.
+var _ funcapi.MethodHandler = (*funcMissedJobs)(nil)
+
+// funcMissedJobs handles the "missed-jobs" function.
+type funcMissedJobs struct {
+	router *funcRouter
+}
+
+func newFuncMissedJobs(r *funcRouter) *funcMissedJobs {
+	return &funcMissedJobs{router: r}
+}
+
+// MethodParams implements funcapi.MethodHandler.
+func (f *funcMissedJobs) MethodParams(_ context.Context, method string) ([]funcapi.ParamConfig, error) {
+	if method != missedJobsMethodID {
+		return nil, fmt.Errorf("unknown method: %s", method)
+	}
+	return nil, nil
+}
+
+// Handle implements funcapi.MethodHandler.
+func (f *funcMissedJobs) Handle(_ context.Context, method string, _ funcapi.ResolvedParams) *funcapi.FunctionResponse {
+	if method != missedJobsMethodID {
+		return funcapi.NotFoundResponse(method)
+	}
+
+	jobs := f.router.collector.missedJobs()
+
+	cs := missedJobColumnSet(missedJobColumns)
+	data := make([][]any, 0, len(jobs))
+	for _, j := range jobs {
+		row := make([]any, len(missedJobColumns))
+		for i, col := range missedJobColumns {
+			row[i] = col.Value(j)
+		}
+		data = append(data, row)
+	}
+
+	return &funcapi.FunctionResponse{
+		Status:            200,
+		Help:              missedJobsHelp,
+		Columns:           cs.BuildColumns(),
+		Data:              data,
+		DefaultSortColumn: "Missed Run",
+	}
+}
+
+// Cleanup implements funcapi.MethodHandler.
+func (f *funcMissedJobs) Cleanup(context.Context) {}
+
+// missedJob is a copy of the job state, safe to use outside the collector lock.
+type missedJob struct {
+	user           string
+	command        string
+	schedule       string
+	crontab        string
+	missedNow      bool
+	missed         int64
+	lastMissed     time.Time
+	lastStart      time.Time
+	nextRun        time.Time
+	lastFailed     bool
+	lastExitStatus int64
+}
+
+// missedJobs returns the scheduled jobs that missed at least one run.
+func (c *Collector) missedJobs() []missedJob {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var jobs []missedJob
+	for _, j := range c.jobs {
+		if j.missed == 0 {
+			continue
+		}
+		jobs = append(jobs, missedJob{
+			user:           j.user,
+			command:        j.command,
+			schedule:       j.scheduleString(),
+			crontab:        j.crontabFiles(),
+			missedNow:      j.missedNow,
+			missed:         j.missed,
+			lastMissed:     j.lastMissed,
+			lastStart:      j.lastStart,
+			nextRun:        j.nextRunTime,
+			lastFailed:     j.lastFailed,
+			lastExitStatus: j.lastExitStatus,
+		})
+	}
+	return jobs
+}
+
+type missedJobColumn struct {
+	funcapi.ColumnMeta
+	Value func(missedJob) any
+}
+
+func missedJobColumnSet(cols []missedJobColumn) funcapi.ColumnSet[missedJobColumn] {
+	return funcapi.Columns(cols, func(c missedJobColumn) funcapi.ColumnMeta { return c.ColumnMeta })
+}
+
+var missedJobColumns = []missedJobColumn{
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Job", Tooltip: "User and command", Type: funcapi.FieldTypeString, Visible: false, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterNone, Sortable: false, UniqueKey: true}, Value: func(j missedJob) any { return j.user + ": " + j.command }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "User", Tooltip: "User the job runs as", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true, Sticky: true}, Value: func(j missedJob) any { return j.user }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Command", Tooltip: "Job command", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true, FullWidth: true}, Value: func(j missedJob) any { return j.command }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Status", Tooltip: "missed: the latest scheduled run did not start; recovered: the job ran on schedule since", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true, Visualization: funcapi.FieldVisualPill}, Value: func(j missedJob) any {
+		if j.missedNow {
+			return "missed"
+		}
+		return "recovered"
+	}},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Schedule", Tooltip: "Crontab schedule", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true}, Value: func(j missedJob) any { return j.schedule }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Missed Run", Tooltip: "The latest scheduled time the job did not start at", Type: funcapi.FieldTypeTimestamp, Visible: true, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummaryMax, Filter: funcapi.FieldFilterRange, Sortable: true, Transform: funcapi.FieldTransformDatetime}, Value: func(j missedJob) any { return timeMilliCell(j.lastMissed) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Times Missed", Tooltip: "Scheduled runs that did not start since the collector started", Type: funcapi.FieldTypeInteger, Visible: true, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummarySum, Filter: funcapi.FieldFilterRange, Sortable: true}, Value: func(j missedJob) any { return j.missed }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Last Run", Tooltip: "When the job last started", Type: funcapi.FieldTypeTimestamp, Visible: true, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummaryMax, Filter: funcapi.FieldFilterRange, Sortable: true, Transform: funcapi.FieldTransformDatetime}, Value: func(j missedJob) any { return timeMilliCell(j.lastStart) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Next Run", Tooltip: "The next scheduled time", Type: funcapi.FieldTypeTimestamp, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryMin, Filter: funcapi.FieldFilterRange, Sortable: true, Transform: funcapi.FieldTransformDatetime}, Value: func(j missedJob) any { return timeMilliCell(j.nextRun) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Last Exit Status", Tooltip: "Exit status of the last failed run, if the cron daemon logs it", Type: funcapi.FieldTypeInteger, Visible: false, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummaryMax, Filter: funcapi.FieldFilterRange, Sortable: true}, Value: func(j missedJob) any {
+		if !j.lastFailed {
+			return nil
+		}
+		return j.lastExitStatus
+	}},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Crontab", Tooltip: "Crontab file(s) defining the job", Type: funcapi.FieldTypeString, Visible: false, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true}, Value: func(j missedJob) any { return j.crontab }},
+}
+
+func timeMilliCell(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UnixMilli()
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cron
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/netdata/netdata/go/plugins/pkg/funcapi"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+)
+
+// funcRouter routes method calls to appropriate function handlers.
+type funcRouter struct {
+	collector *Collector
+
+	handlers map[string]funcapi.MethodHandler
+}
+
+func newFuncRouter(c *Collector) *funcRouter {
+	r := &funcRouter{
+		collector: c,
+		handlers:  make(map[string]funcapi.MethodHandler),
+	}
+	r.handlers[missedJobsMethodID] = newFuncMissedJobs(r)
+	return r
+}
+
+// Compile-time interface check.
+var _ funcapi.MethodHandler = (*funcRouter)(nil)
+
+func (r *funcRouter) MethodParams(ctx context.Context, method string) ([]funcapi.ParamConfig, error) {
+	if h, ok := r.handlers[method]; ok {
+		return h.MethodParams(ctx, method)
+	}
+	return nil, fmt.Errorf("unknown method: %s", method)
+}
+
+func (r *funcRouter) Handle(ctx context.Context, method string, params funcapi.ResolvedParams) *funcapi.FunctionResponse {
+	if h, ok := r.handlers[method]; ok {
+		return h.Handle(ctx, method, params)
+	}
+	return funcapi.NotFoundResponse(method)
+}
+
+func (r *funcRouter) Cleanup(ctx context.Context) {
+	for _, h := range r.handlers {
+		h.Cleanup(ctx)
+	}
+}
+
+func cronMethods() []funcapi.FunctionConfig {
+	return []funcapi.FunctionConfig{
+		missedJobsFunctionConfig(),
+	}
+}
+
+func cronFunctionHandler(job collectorapi.RuntimeJob) funcapi.MethodHandler {
+	c, ok := job.Collector().(*Collector)
+	if !ok {
+		return nil
+	}
+	return c.funcRouter
+}
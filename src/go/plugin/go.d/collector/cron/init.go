@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+)
+
+func (c *Collector) validateConfig() error {
+	switch c.Source {
+	case sourceAuto, sourceFile, sourceJournal:
+	default:
+		return fmt.Errorf("invalid 'source' value '%s' (expected '%s', '%s' or '%s')", c.Source, sourceAuto, sourceFile, sourceJournal)
+	}
+	if c.Source == sourceFile && c.Path == "" {
+		return errors.New("'path' not set")
+	}
+	return nil
+}
+
+func (c *Collector) initEventSource() (eventSource, error) {
+	source := c.Source
+	if source == sourceAuto {
+		source = sourceJournal
+		if matches, _ := filepath.Glob(c.Path); c.Path != "" && len(matches) > 0 {
+			source = sourceFile
+		}
+		c.Debugf("auto-detected source: '%s'", source)
+	}
+
+	if source == sourceFile {
+		src, err := newFileSource(c.Path, c.ExcludePath, c.Logger, c.now)
+		if err != nil {
+			return nil, fmt.Errorf("file source '%s': %v", c.Path, err)
+		}
+		return src, nil
+	}
+
+	src, err := newJournalSource(c.JournalctlPath, c.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("journal source: %v", err)
+	}
+	return src, nil
+}
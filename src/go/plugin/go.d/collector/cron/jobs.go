@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cron
+
+import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxRunDuration is how long a started run is tracked if its completion is never logged
+// (e.g. PAM session messages go to a different log file).
+const maxRunDuration = 24 * time.Hour
+
+type (
+	cronJob struct {
+		id        string
+		user      string
+		command   string
+		entries   []crontabEntry // empty for jobs seen only in the log
+		hasCharts bool
+
+		started   int64
+		succeeded int64
+		failed    int64
+		missed    int64
+
+		lastStart      time.Time
+		lastEnd        time.Time
+		lastDuration   time.Duration
+		lastExitStatus int64
+		lastFailed     bool
+
+		lastDue     time.Time // the latest scheduled run evaluated for missed detection
+		missedNow   bool
+		lastMissed  time.Time
+		nextRunTime time.Time
+	}
+	cronRun struct {
+		job    *cronJob
+		start  time.Time
+		failed bool
+	}
+)
+
+func jobKey(user, command string) string {
+	return user + "\x00" + command
+}
+
+func jobID(user, command string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(command))
+	return fmt.Sprintf("%s_%08x", strings.ReplaceAll(user, ".", "_"), h.Sum32())
+}
+
+// lastDueTime returns the latest scheduled time of the job at or before t.
+func (j *cronJob) lastDueTime(t time.Time) time.Time {
+	var due time.Time
+	for _, e := range j.entries {
+		if v := e.schedule.prev(t); v.After(due) {
+			due = v
+		}
+	}
+	return due
+}
+
+func (j *cronJob) nextDueTime(t time.Time) time.Time {
+	var due time.Time
+	for _, e := range j.entries {
+		if v := e.schedule.next(t); !v.IsZero() && (due.IsZero() || v.Before(due)) {
+			due = v
+		}
+	}
+	return due
+}
+
+func (j *cronJob) scheduleString() string {
+	var specs []string
+	for _, e := range j.entries {
+		specs = append(specs, e.schedule.String())
+	}
+	return strings.Join(specs, ", ")
+}
+
+func (j *cronJob) crontabFiles() string {
+	var files []string
+	for _, e := range j.entries {
+		if !slices.Contains(files, e.file) {
+			files = append(files, e.file)
+		}
+	}
+	return strings.Join(files, ", ")
+}
+
+// updateCrontabJobs replaces the schedules of the known jobs with the ones from the crontab entries.
+func (c *Collector) updateCrontabJobs(entries []crontabEntry) {
+	for _, j := range c.jobs {
+		j.entries = nil
+	}
+
+	for _, e := range entries {
+		j := c.getJob(e.user, e.command)
+		if j == nil {
+			continue
+		}
+		j.entries = append(j.entries, e)
+	}
+}
+
+// getJob returns the job, creating it if the number of jobs is under the limit.
+func (c *Collector) getJob(user, command string) *cronJob {
+	key := jobKey(user, command)
+	if j, ok := c.jobs[key]; ok {
+		return j
+	}
+	if c.MaxJobs > 0 && len(c.jobs) >= c.MaxJobs {
+		if !c.jobsLimitHit {
+			c.jobsLimitHit = true
+			c.Warningf("reached the limit of %d jobs (max_jobs), runs of new jobs are counted only in totals", c.MaxJobs)
+		}
+		return nil
+	}
+	j := &cronJob{id: jobID(user, command), user: user, command: command}
+	c.jobs[key] = j
+	return j
+}
+
+func (c *Collector) processEvent(ev cronEvent) {
+	switch ev.kind {
+	case eventStart:
+		c.totals.started++
+		// a reused PID replaces a run whose completion was not logged
+		j := c.getJob(ev.user, ev.command)
+		c.runs[ev.pid] = &cronRun{job: j, start: ev.time}
+		if j != nil {
+			j.started++
+			j.lastStart = ev.time
+		}
+	case eventFailed:
+		c.totals.failed++
+		run, ok := c.runs[ev.pid]
+		if !ok {
+			return
+		}
+		run.failed = true
+		if j := run.job; j != nil {
+			j.failed++
+			j.lastFailed = true
+			j.lastExitStatus = ev.exitStatus
+		}
+	case eventEnd:
+		run, ok := c.runs[ev.pid]
+		if !ok {
+			return
+		}
+		delete(c.runs, ev.pid)
+		if !run.failed {
+			c.totals.succeeded++
+		}
+		if j := run.job; j != nil {
+			j.lastEnd = ev.time
+			j.lastDuration = max(ev.time.Sub(run.start), 0)
+			if !run.failed {
+				j.succeeded++
+				j.lastFailed = false
+				j.lastExitStatus = 0
+			}
+		}
+	}
+}
+
+func (c *Collector) expireRuns(now time.Time) {
+	for pid, run := range c.runs {
+		if now.Sub(run.start) > maxRunDuration {
+			delete(c.runs, pid)
+		}
+	}
+}
+
+// checkMissedRuns detects scheduled runs that were not started within the grace period.
+// Only runs scheduled after the collector started following the log are evaluated.
+func (c *Collector) checkMissedRuns(now time.Time) {
+	grace := c.MissedRunGrace.Duration()
+
+	for _, j := range c.jobs {
+		j.missedNow = false
+		j.nextRunTime = time.Time{}
+		if len(j.entries) == 0 {
+			continue
+		}
+
+		j.nextRunTime = j.nextDueTime(now)
+
+		due := j.lastDueTime(now.Add(-grace))
+		if due.IsZero() || due.Before(c.startTime) {
+			continue
+		}
+		j.lastDue = due
+
+		// cron logs the start within seconds of the scheduled minute; allow for clock skew
+		if !j.lastStart.Before(due.Add(-time.Minute)) {
+			continue
+		}
+
+		j.missedNow = true
+		if !j.lastMissed.Equal(due) {
+			j.lastMissed = due
+			j.missed++
+			c.totals.missed++
+		}
+	}
+}
@@ -0,0 +1,279 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// schedule is a parsed crontab time specification (see crontab(5)).
+type schedule struct {
+	spec string
+
+	minute uint64 // bits 0-59
+	hour   uint64 // bits 0-23
+	dom    uint64 // bits 1-31
+	month  uint64 // bits 1-12
+	dow    uint64 // bits 0-6, Sunday is 0
+
+	// If both day of month and day of week are restricted (not '*'), a day matches if either field matches.
+	domStar bool
+	dowStar bool
+}
+
+type fieldBounds struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteBounds = fieldBounds{min: 0, max: 59}
+	hourBounds   = fieldBounds{min: 0, max: 23}
+	domBounds    = fieldBounds{min: 1, max: 31}
+	monthBounds  = fieldBounds{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 7 is an alias for Sunday
+	dowBounds = fieldBounds{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var scheduleMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var errRebootSchedule = errors.New("@reboot jobs have no schedule")
+
+// parseSchedule parses the five time and date fields of a crontab entry or one of the '@' macros.
+func parseSchedule(spec string) (*schedule, error) {
+	spec = strings.TrimSpace(spec)
+
+	expr := spec
+	if strings.HasPrefix(spec, "@") {
+		if strings.EqualFold(spec, "@reboot") {
+			return nil, errRebootSchedule
+		}
+		v, ok := scheduleMacros[strings.ToLower(spec)]
+		if !ok {
+			return nil, fmt.Errorf("unknown macro '%s'", spec)
+		}
+		expr = v
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields, got %d", len(fields))
+	}
+
+	s := &schedule{spec: spec}
+	var err error
+
+	if s.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, fmt.Errorf("minute: %v", err)
+	}
+	if s.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, fmt.Errorf("hour: %v", err)
+	}
+	if s.dom, err = parseField(fields[2], domBounds); err != nil {
+		return nil, fmt.Errorf("day of month: %v", err)
+	}
+	if s.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, fmt.Errorf("month: %v", err)
+	}
+	if s.dow, err = parseField(fields[4], dowBounds); err != nil {
+		return nil, fmt.Errorf("day of week: %v", err)
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+
+	s.domStar = strings.HasPrefix(fields[2], "*")
+	s.dowStar = strings.HasPrefix(fields[4], "*")
+
+	return s, nil
+}
+
+func parseField(field string, b fieldBounds) (uint64, error) {
+	var bits uint64
+	for part := range strings.SplitSeq(field, ",") {
+		v, err := parseFieldPart(part, b)
+		if err != nil {
+			return 0, err
+		}
+		bits |= v
+	}
+	return bits, nil
+}
+
+// parseFieldPart parses '*', 'N', 'N-M' and any of them followed by '/STEP'.
+func parseFieldPart(part string, b fieldBounds) (uint64, error) {
+	rng, stepStr, hasStep := strings.Cut(part, "/")
+
+	step := 1
+	if hasStep {
+		v, err := strconv.Atoi(stepStr)
+		if err != nil || v <= 0 {
+			return 0, fmt.Errorf("invalid step '%s'", stepStr)
+		}
+		step = v
+	}
+
+	var lo, hi int
+	switch {
+	case rng == "*":
+		lo, hi = b.min, b.max
+	case strings.Contains(rng, "-"):
+		a, z, _ := strings.Cut(rng, "-")
+		var err error
+		if lo, err = parseFieldValue(a, b); err != nil {
+			return 0, err
+		}
+		if hi, err = parseFieldValue(z, b); err != nil {
+			return 0, err
+		}
+		if lo > hi {
+			return 0, fmt.Errorf("invalid range '%s'", rng)
+		}
+	default:
+		v, err := parseFieldValue(rng, b)
+		if err != nil {
+			return 0, err
+		}
+		lo, hi = v, v
+		if hasStep {
+			// 'N/STEP' means from N to the maximum
+			hi = b.max
+		}
+	}
+
+	var bits uint64
+	for i := lo; i <= hi; i += step {
+		bits |= 1 << uint(i)
+	}
+	return bits, nil
+}
+
+func parseFieldValue(s string, b fieldBounds) (int, error) {
+	if v, ok := b.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value '%s'", s)
+	}
+	if v < b.min || v > b.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", v, b.min, b.max)
+	}
+	return v, nil
+}
+
+func (s *schedule) String() string {
+	return s.spec
+}
+
+func (s *schedule) matchDay(t time.Time) bool {
+	if s.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if !s.domStar && !s.dowStar {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
+
+// maxScheduleLookback bounds the search for schedules that rarely match (e.g. "0 0 29 2 *" needs up to 8 years).
+const maxScheduleLookback = 366 * 9
+
+// prev returns the latest scheduled time at or before t (truncated to the minute),
+// or the zero time if there is none within the lookback window.
+func (s *schedule) prev(t time.Time) time.Time {
+	t = t.Truncate(time.Minute)
+	loc := t.Location()
+
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	for i := 0; i < maxScheduleLookback; i++ {
+		if s.matchDay(day) {
+			maxHour, maxMinute := 23, 59
+			if i == 0 {
+				maxHour, maxMinute = t.Hour(), t.Minute()
+			}
+			if v, ok := s.lastTimeOfDay(day, maxHour, maxMinute); ok {
+				return v
+			}
+		}
+		day = day.AddDate(0, 0, -1)
+	}
+	return time.Time{}
+}
+
+// lastTimeOfDay returns the latest scheduled time of the day not after maxHour:maxMinute.
+func (s *schedule) lastTimeOfDay(day time.Time, maxHour, maxMinute int) (time.Time, bool) {
+	for h := maxHour; h >= 0; h-- {
+		if s.hour&(1<<uint(h)) == 0 {
+			continue
+		}
+		m := 59
+		if h == maxHour {
+			m = maxMinute
+		}
+		for ; m >= 0; m-- {
+			if s.minute&(1<<uint(m)) != 0 {
+				return time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, day.Location()), true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// next returns the earliest scheduled time after t, or the zero time if there is none within the lookahead window.
+func (s *schedule) next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	loc := t.Location()
+
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	for i := 0; i < maxScheduleLookback; i++ {
+		if s.matchDay(day) {
+			minHour, minMinute := 0, 0
+			if i == 0 {
+				minHour, minMinute = t.Hour(), t.Minute()
+			}
+			if v, ok := s.firstTimeOfDay(day, minHour, minMinute); ok {
+				return v
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return time.Time{}
+}
+
+func (s *schedule) firstTimeOfDay(day time.Time, minHour, minMinute int) (time.Time, bool) {
+	for h := minHour; h <= 23; h++ {
+		if s.hour&(1<<uint(h)) == 0 {
+			continue
+		}
+		m := 0
+		if h == minHour {
+			m = minMinute
+		}
+		for ; m <= 59; m++ {
+			if s.minute&(1<<uint(m)) != 0 {
+				return time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, day.Location()), true
+			}
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	tests := map[string]struct {
+		spec    string
+		wantErr bool
+	}{
+		"every minute":        {spec: "* * * * *"},
+		"lists and ranges":    {spec: "0,30 8-18 * * 1-5"},
+		"steps":               {spec: "*/15 */2 1-31/2 * *"},
+		"value with step":     {spec: "5/10 * * * *"},
+		"names":               {spec: "0 0 * jan-mar sun,Sat"},
+		"sunday as 7":         {spec: "0 0 * * 7"},
+		"macro":               {spec: "@daily"},
+		"reboot":              {spec: "@reboot", wantErr: true},
+		"unknown macro":       {spec: "@sometimes", wantErr: true},
+		"too few fields":      {spec: "* * * *", wantErr: true},
+		"out of range":        {spec: "60 * * * *", wantErr: true},
+		"zero day of month":   {spec: "0 0 0 * *", wantErr: true},
+		"bad range":           {spec: "0 10-5 * * *", wantErr: true},
+		"bad step":            {spec: "*/0 * * * *", wantErr: true},
+		"unknown month name":  {spec: "0 0 1 foo *", wantErr: true},
+		"not a number":        {spec: "a * * * *", wantErr: true},
+		"empty list elements": {spec: "1,,2 * * * *", wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseSchedule(test.spec)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSchedule_prevNext(t *testing.T) {
+	// Monday
+	now := time.Date(2026, 10, 19, 10, 31, 45, 0, time.UTC)
+
+	tests := map[string]struct {
+		spec     string
+		wantPrev time.Time
+		wantNext time.Time
+	}{
+		"every 15 minutes": {
+			spec:     "*/15 * * * *",
+			wantPrev: time.Date(2026, 10, 19, 10, 30, 0, 0, time.UTC),
+			wantNext: time.Date(2026, 10, 19, 10, 45, 0, 0, time.UTC),
+		},
+		"current minute": {
+			spec:     "31 10 * * *",
+			wantPrev: time.Date(2026, 10, 19, 10, 31, 0, 0, time.UTC),
+			wantNext: time.Date(2026, 10, 20, 10, 31, 0, 0, time.UTC),
+		},
+		"daily": {
+			spec:     "@daily",
+			wantPrev: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			wantNext: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+		},
+		"weekdays only": {
+			spec:     "0 9 * * sat,sun",
+			wantPrev: time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC),
+			wantNext: time.Date(2026, 10, 24, 9, 0, 0, 0, time.UTC),
+		},
+		"day of month or day of week": {
+			spec:     "0 12 1 * fri",
+			wantPrev: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+			wantNext: time.Date(2026, 10, 23, 12, 0, 0, 0, time.UTC),
+		},
+		"day of week with restricted month": {
+			spec:     "0 12 * nov mon",
+			wantPrev: time.Date(2025, 11, 24, 12, 0, 0, 0, time.UTC),
+			wantNext: time.Date(2026, 11, 2, 12, 0, 0, 0, time.UTC),
+		},
+		"leap day": {
+			spec:     "0 0 29 2 *",
+			wantPrev: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			wantNext: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		"never": {
+			spec:     "0 0 31 2 *",
+			wantPrev: time.Time{},
+			wantNext: time.Time{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, err := parseSchedule(test.spec)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.wantPrev, s.prev(now), "prev")
+			assert.Equal(t, test.wantNext, s.next(now), "next")
+		})
+	}
+}
+
+func TestParseCrontabLine(t *testing.T) {
+	tests := map[string]struct {
+		line      string
+		user      string
+		wantEntry *crontabEntry
+		wantErr   bool
+	}{
+		"comment": {
+			line: "# m h dom mon dow command",
+		},
+		"env setting": {
+			line: "MAILTO = admin@example.com",
+		},
+		"reboot": {
+			line: "@reboot /usr/bin/start.sh",
+			user: "alice",
+		},
+		"user crontab": {
+			line:      "*/5 * * * * /usr/bin/check --all > /dev/null 2>&1",
+			user:      "alice",
+			wantEntry: &crontabEntry{user: "alice", command: "/usr/bin/check --all > /dev/null 2>&1"},
+		},
+		"system crontab": {
+			line:      "17 *\t* * *\troot    cd / && run-parts --report /etc/cron.hourly",
+			wantEntry: &crontabEntry{user: "root", command: "cd / && run-parts --report /etc/cron.hourly"},
+		},
+		"system crontab macro": {
+			line:      "@hourly www-data php /var/www/cron.php",
+			wantEntry: &crontabEntry{user: "www-data", command: "php /var/www/cron.php"},
+		},
+		"missing command": {
+			line:    "* * * * * root",
+			wantErr: true,
+		},
+		"invalid schedule": {
+			line:    "* * * * 9 root /bin/true",
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			e, err := parseCrontabLine(test.line, test.user)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if test.wantEntry == nil {
+				assert.Nil(t, e)
+				return
+			}
+			require.NotNil(t, e)
+			assert.Equal(t, test.wantEntry.user, e.user)
+			assert.Equal(t, test.wantEntry.command, e.command)
+		})
+	}
+}
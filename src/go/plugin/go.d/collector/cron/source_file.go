@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/logger"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/logs"
+)
+
+// eventSource provides cron job events logged since the previous read.
+type eventSource interface {
+	read() ([]cronEvent, error)
+	close()
+}
+
+// syslogLinePattern matches cron daemon messages in syslog files (/var/log/cron, /var/log/syslog),
+// both in the traditional and in the RFC 3339 (rsyslog high precision) timestamp formats.
+const syslogLinePattern = `^(?P<time>\d{4}-\d\d-\d\dT\S+|[A-Z][a-z]{2} [ \d]\d \d\d:\d\d:\d\d) \S+ ` +
+	`(?P<ident>(?i:(?:/usr/sbin/)?crond?))\[(?P<pid>\d+)\]: (?P<msg>.*)$`
+
+type fileSource struct {
+	reader *logs.Reader
+	parser logs.Parser
+	line   *syslogLine
+	now    func() time.Time
+}
+
+func newFileSource(path, excludePath string, log *logger.Logger, now func() time.Time) (*fileSource, error) {
+	reader, err := logs.Open(path, excludePath, log)
+	if err != nil {
+		return nil, fmt.Errorf("creating log reader: %v", err)
+	}
+
+	parser, err := logs.NewParser(logs.ParserConfig{
+		LogType: logs.TypeRegExp,
+		RegExp:  logs.RegExpConfig{Pattern: syslogLinePattern},
+	}, reader)
+	if err != nil {
+		_ = reader.Close()
+		return nil, fmt.Errorf("creating log parser: %v", err)
+	}
+
+	return &fileSource{reader: reader, parser: parser, line: &syslogLine{}, now: now}, nil
+}
+
+func (s *fileSource) read() ([]cronEvent, error) {
+	var events []cronEvent
+	now := s.now()
+
+	for {
+		s.line.reset()
+		err := s.parser.ReadLine(s.line)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return events, nil
+			}
+			if logs.IsParseError(err) {
+				// not a cron daemon message
+				continue
+			}
+			return events, err
+		}
+
+		ts := parseSyslogTime(s.line.time, now)
+		if ts.IsZero() {
+			ts = now
+		}
+		if ev, ok := parseCronMessage(s.line.msg, s.line.pid, ts); ok {
+			events = append(events, ev)
+		}
+	}
+}
+
+func (s *fileSource) close() {
+	_ = s.reader.Close()
+}
+
+type syslogLine struct {
+	time string
+	pid  int
+	msg  string
+}
+
+func (l *syslogLine) reset() {
+	*l = syslogLine{}
+}
+
+func (l *syslogLine) Assign(name, value string) error {
+	switch name {
+	case "time":
+		l.time = value
+	case "pid":
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid pid '%s'", value)
+		}
+		l.pid = v
+	case "msg":
+		l.msg = value
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cron
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/logger"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/ndexec"
+)
+
+const journalRestartInterval = 10 * time.Second
+
+// cron daemon syslog identifiers: Debian cron logs as "CRON" (job processes) and "cron", cronie as "CROND" and "crond".
+var journalIdentifiers = []string{"CRON", "cron", "CROND", "crond"}
+
+// journalSource follows the systemd journal with journalctl in the background; read returns
+// the events received since the previous call.
+type journalSource struct {
+	*logger.Logger
+
+	path string
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	events  []cronEvent
+	err     error
+	started bool
+}
+
+func newJournalSource(journalctlPath string, log *logger.Logger) (*journalSource, error) {
+	path := journalctlPath
+	if path == "" {
+		p, err := ndexec.FindBinary([]string{"journalctl"}, []string{"/usr/bin/journalctl", "/bin/journalctl"})
+		if err != nil {
+			return nil, fmt.Errorf("journalctl binary not found: %v", err)
+		}
+		path = p
+	}
+
+	s := &journalSource{Logger: log, path: path}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.wg.Go(func() { s.runLoop(ctx) })
+
+	return s, nil
+}
+
+func (s *journalSource) read() ([]cronEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := s.events
+	s.events = nil
+
+	if !s.started && s.err != nil {
+		return events, s.err
+	}
+	return events, nil
+}
+
+func (s *journalSource) close() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *journalSource) runLoop(ctx context.Context) {
+	var warned bool
+
+	for {
+		err := s.run(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.started, s.err = false, err
+		s.mu.Unlock()
+
+		if !warned {
+			s.Warningf("journal reader: %v (restarting every %s)", err, journalRestartInterval)
+			warned = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(journalRestartInterval):
+		}
+	}
+}
+
+func (s *journalSource) run(ctx context.Context) error {
+	args := []string{"--output=json", "--follow", "--no-pager", "--quiet", "--lines=0"}
+	for _, id := range journalIdentifiers {
+		args = append(args, "--identifier="+id)
+	}
+
+	cmd := exec.CommandContext(ctx, s.path, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start '%s': %v", s.path, err)
+	}
+
+	s.mu.Lock()
+	s.started, s.err = true, nil
+	s.mu.Unlock()
+
+	sc := bufio.NewScanner(stdout)
+	sc.Buffer(make([]byte, 64*1024), 1<<20)
+	for sc.Scan() {
+		if ev, ok := parseJournalEntry(sc.Bytes()); ok {
+			s.mu.Lock()
+			s.events = append(s.events, ev)
+			s.mu.Unlock()
+		}
+	}
+
+	waitErr := cmd.Wait()
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return fmt.Errorf("journalctl exited: %v: %s", waitErr, msg)
+	}
+	if waitErr != nil {
+		return fmt.Errorf("journalctl exited: %v", waitErr)
+	}
+	return errors.New("journalctl exited")
+}
+
+type journalEntry struct {
+	RealtimeTimestamp string `json:"__REALTIME_TIMESTAMP"`
+	SyslogPID         string `json:"SYSLOG_PID"`
+	PID               string `json:"_PID"`
+	Message           any    `json:"MESSAGE"`
+}
+
+func parseJournalEntry(line []byte) (cronEvent, bool) {
+	var e journalEntry
+	if err := json.Unmarshal(line, &e); err != nil {
+		return cronEvent{}, false
+	}
+
+	// binary messages are arrays of bytes
+	msg, ok := e.Message.(string)
+	if !ok {
+		return cronEvent{}, false
+	}
+
+	pid, err := strconv.Atoi(e.SyslogPID)
+	if err != nil {
+		if pid, err = strconv.Atoi(e.PID); err != nil {
+			return cronEvent{}, false
+		}
+	}
+
+	ts := time.Now()
+	if usec, err := strconv.ParseInt(e.RealtimeTimestamp, 10, 64); err == nil {
+		ts = time.UnixMicro(usec)
+	}
+
+	return parseCronMessage(msg, pid, ts)
+}
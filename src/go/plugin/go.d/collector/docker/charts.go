@@ -12,10 +12,27 @@ import (
 const (
 	prioContainersState = collectorapi.Priority + iota
 	prioContainersHealthy
+	prioContainersEvents
+	prioContainersHealthTransitions
+	prioContainersCrashLooping
 
 	prioContainerState
 	prioContainerHealthStatus
 	prioContainerWritableLayerSize
+	prioContainerEvents
+	prioContainerHealthTransitions
+	prioContainerLastExitCode
+	prioContainerRestartsInWindow
+	prioContainerCrashLoopStatus
+	prioContainerCPUUsage
+	prioContainerCPUThrottledTime
+	prioContainerMemUsage
+	prioContainerMemUtilization
+	prioContainerNetTraffic
+	prioContainerNetPackets
+	prioContainerNetErrors
+	prioContainerBlkioBytes
+	prioContainerPids
 
 	prioImagesCount
 	prioImagesSize
@@ -61,6 +78,53 @@ var (
 	}
 )
 
+var (
+	containersEventsCharts = collectorapi.Charts{
+		containersEventsChart.Copy(),
+		containersHealthTransitionsChart.Copy(),
+		containersCrashLoopingChart.Copy(),
+	}
+
+	containersEventsChart = collectorapi.Chart{
+		ID:       "containers_events",
+		Title:    "Docker container lifecycle events",
+		Units:    "events/s",
+		Fam:      "events",
+		Ctx:      "docker.containers_events",
+		Priority: prioContainersEvents,
+		Dims: collectorapi.Dims{
+			{ID: "containers_events_oom_kill", Name: "oom_kill", Algo: collectorapi.Incremental},
+			{ID: "containers_events_die", Name: "die", Algo: collectorapi.Incremental},
+			{ID: "containers_events_die_nonzero", Name: "die_nonzero", Algo: collectorapi.Incremental},
+			{ID: "containers_events_restart", Name: "restart", Algo: collectorapi.Incremental},
+		},
+	}
+	containersHealthTransitionsChart = collectorapi.Chart{
+		ID:       "containers_health_transitions",
+		Title:    "Docker container health status transitions",
+		Units:    "transitions/s",
+		Fam:      "events",
+		Ctx:      "docker.containers_health_transitions",
+		Priority: prioContainersHealthTransitions,
+		Dims: collectorapi.Dims{
+			{ID: "containers_health_transitions_healthy", Name: "healthy", Algo: collectorapi.Incremental},
+			{ID: "containers_health_transitions_unhealthy", Name: "unhealthy", Algo: collectorapi.Incremental},
+			{ID: "containers_health_transitions_starting", Name: "starting", Algo: collectorapi.Incremental},
+		},
+	}
+	containersCrashLoopingChart = collectorapi.Chart{
+		ID:       "containers_crash_looping",
+		Title:    "Docker containers in a restart loop",
+		Units:    "containers",
+		Fam:      "events",
+		Ctx:      "docker.containers_crash_looping",
+		Priority: prioContainersCrashLooping,
+		Dims: collectorapi.Dims{
+			{ID: "containers_crash_looping", Name: "crash_looping"},
+		},
+	}
+)
+
 var (
 	imagesCountChart = collectorapi.Chart{
 		ID:       "images_count",
@@ -140,12 +204,231 @@ var (
 	}
 )
 
+var (
+	containerEventsChartsTmpl = collectorapi.Charts{
+		containerEventsChartTmpl.Copy(),
+		containerHealthTransitionsChartTmpl.Copy(),
+		containerLastExitCodeChartTmpl.Copy(),
+		containerRestartsInWindowChartTmpl.Copy(),
+		containerCrashLoopStatusChartTmpl.Copy(),
+	}
+
+	containerEventsChartTmpl = collectorapi.Chart{
+		ID:       "container_%s_events",
+		Title:    "Docker container lifecycle events",
+		Units:    "events/s",
+		Fam:      "containers",
+		Ctx:      "docker.container_events",
+		Priority: prioContainerEvents,
+		Dims: collectorapi.Dims{
+			{ID: "container_%s_events_oom_kill", Name: "oom_kill", Algo: collectorapi.Incremental},
+			{ID: "container_%s_events_die", Name: "die", Algo: collectorapi.Incremental},
+			{ID: "container_%s_events_restart", Name: "restart", Algo: collectorapi.Incremental},
+		},
+	}
+	containerHealthTransitionsChartTmpl = collectorapi.Chart{
+		ID:       "container_%s_health_transitions",
+		Title:    "Docker container health status transitions",
+		Units:    "transitions/s",
+		Fam:      "containers",
+		Ctx:      "docker.container_health_transitions",
+		Priority: prioContainerHealthTransitions,
+		Dims: collectorapi.Dims{
+			{ID: "container_%s_health_transitions_healthy", Name: "healthy", Algo: collectorapi.Incremental},
+			{ID: "container_%s_health_transitions_unhealthy", Name: "unhealthy", Algo: collectorapi.Incremental},
+			{ID: "container_%s_health_transitions_starting", Name: "starting", Algo: collectorapi.Incremental},
+		},
+	}
+	containerLastExitCodeChartTmpl = collectorapi.Chart{
+		ID:       "container_%s_last_exit_code",
+		Title:    "Docker container last exit code",
+		Units:    "code",
+		Fam:      "containers",
+		Ctx:      "docker.container_last_exit_code",
+		Priority: prioContainerLastExitCode,
+		Dims: collectorapi.Dims{
+			{ID: "container_%s_last_exit_code", Name: "exit_code"},
+		},
+	}
+	containerRestartsInWindowChartTmpl = collectorapi.Chart{
+		ID:       "container_%s_restarts_in_window",
+		Title:    "Docker container restarts within the restart loop window",
+		Units:    "restarts",
+		Fam:      "containers",
+		Ctx:      "docker.container_restarts_in_window",
+		Priority: prioContainerRestartsInWindow,
+		Dims: collectorapi.Dims{
+			{ID: "container_%s_restarts_in_window", Name: "restarts"},
+		},
+	}
+	containerCrashLoopStatusChartTmpl = collectorapi.Chart{
+		ID:       "container_%s_crash_loop_status",
+		Title:    "Docker container restart loop status",
+		Units:    "status",
+		Fam:      "containers",
+		Ctx:      "docker.container_crash_loop_status",
+		Priority: prioContainerCrashLoopStatus,
+		Dims: collectorapi.Dims{
+			{ID: "container_%s_crash_looping", Name: "crash_looping"},
+		},
+	}
+)
+
+var (
+	containerStatsChartsTmpl = collectorapi.Charts{
+		containerCPUUsageChartTmpl.Copy(),
+		containerCPUThrottledTimeChartTmpl.Copy(),
+		containerMemUsageChartTmpl.Copy(),
+		containerMemUtilizationChartTmpl.Copy(),
+		containerNetTrafficChartTmpl.Copy(),
+		containerNetPacketsChartTmpl.Copy(),
+		containerNetErrorsChartTmpl.Copy(),
+		containerBlkioBytesChartTmpl.Copy(),
+		containerPidsChartTmpl.Copy(),
+	}
+
+	containerCPUUsageChartTmpl = collectorapi.Chart{
+		ID:       "container_%s_cpu_usage",
+		Title:    "Docker container CPU usage",
+		Units:    "percentage",
+		Fam:      "cpu",
+		Ctx:      "docker.container_cpu_usage",
+		Priority: prioContainerCPUUsage,
+		Type:     collectorapi.Stacked,
+		Dims: collectorapi.Dims{
+			{ID: "container_%s_cpu_usage_user", Name: "user", Algo: collectorapi.Incremental, Mul: 100, Div: 1e9},
+			{ID: "container_%s_cpu_usage_system", Name: "system", Algo: collectorapi.Incremental, Mul: 100, Div: 1e9},
+		},
+	}
+	containerCPUThrottledTimeChartTmpl = collectorapi.Chart{
+		ID:       "container_%s_cpu_throttled_time",
+		Title:    "Docker container CPU throttled time",
+		Units:    "ms",
+		Fam:      "cpu",
+		Ctx:      "docker.container_cpu_throttled_time",
+		Priority: prioContainerCPUThrottledTime,
+		Dims: collectorapi.Dims{
+			{ID: "container_%s_cpu_throttled_time", Name: "throttled", Algo: collectorapi.Incremental, Div: 1e6},
+		},
+	}
+	containerMemUsageChartTmpl = collectorapi.Chart{
+		ID:       "container_%s_mem_usage",
+		Title:    "Docker container memory usage",
+		Units:    "bytes",
+		Fam:      "mem",
+		Ctx:      "docker.container_mem_usage",
+		Priority: prioContainerMemUsage,
+		Dims: collectorapi.Dims{
+			{ID: "container_%s_mem_used", Name: "used"},
+			{ID: "container_%s_mem_limit", Name: "limit"},
+		},
+	}
+	containerMemUtilizationChartTmpl = collectorapi.Chart{
+		ID:       "container_%s_mem_utilization",
+		Title:    "Docker container memory utilization",
+		Units:    "percentage",
+		Fam:      "mem",
+		Ctx:      "docker.container_mem_utilization",
+		Priority: prioContainerMemUtilization,
+		Dims: collectorapi.Dims{
+			{ID: "container_%s_mem_utilization", Name: "utilization", Div: precision},
+		},
+	}
+	containerNetTrafficChartTmpl = collectorapi.Chart{
+		ID:       "container_%s_net_traffic",
+		Title:    "Docker container network traffic",
+		Units:    "kilobits/s",
+		Fam:      "net",
+		Ctx:      "docker.container_net_traffic",
+		Priority: prioContainerNetTraffic,
+		Type:     collectorapi.Area,
+		Dims: collectorapi.Dims{
+			{ID: "container_%s_net_rx_bytes", Name: "received", Algo: collectorapi.Incremental, Mul: 8, Div: 1000},
+			{ID: "container_%s_net_tx_bytes", Name: "sent", Algo: collectorapi.Incremental, Mul: -8, Div: 1000},
+		},
+	}
+	containerNetPacketsChartTmpl = collectorapi.Chart{
+		ID:       "container_%s_net_packets",
+		Title:    "Docker container network packets",
+		Units:    "pps",
+		Fam:      "net",
+		Ctx:      "docker.container_net_packets",
+		Priority: prioContainerNetPackets,
+		Dims: collectorapi.Dims{
+			{ID: "container_%s_net_rx_packets", Name: "received", Algo: collectorapi.Incremental},
+			{ID: "container_%s_net_tx_packets", Name: "sent", Algo: collectorapi.Incremental, Mul: -1},
+		},
+	}
+	containerNetErrorsChartTmpl = collectorapi.Chart{
+		ID:       "container_%s_net_errors",
+		Title:    "Docker container network errors and drops",
+		Units:    "packets/s",
+		Fam:      "net",
+		Ctx:      "docker.container_net_errors",
+		Priority: prioContainerNetErrors,
+		Dims: collectorapi.Dims{
+			{ID: "container_%s_net_rx_errors", Name: "rx_errors", Algo: collectorapi.Incremental},
+			{ID: "container_%s_net_tx_errors", Name: "tx_errors", Algo: collectorapi.Incremental, Mul: -1},
+			{ID: "container_%s_net_rx_dropped", Name: "rx_dropped", Algo: collectorapi.Incremental},
+			{ID: "container_%s_net_tx_dropped", Name: "tx_dropped", Algo: collectorapi.Incremental, Mul: -1},
+		},
+	}
+	containerBlkioBytesChartTmpl = collectorapi.Chart{
+		ID:       "container_%s_blkio_bytes",
+		Title:    "Docker container block I/O bandwidth",
+		Units:    "bytes/s",
+		Fam:      "disk",
+		Ctx:      "docker.container_blkio_bytes",
+		Priority: prioContainerBlkioBytes,
+		Type:     collectorapi.Area,
+		Dims: collectorapi.Dims{
+			{ID: "container_%s_blkio_read_bytes", Name: "read", Algo: collectorapi.Incremental},
+			{ID: "container_%s_blkio_write_bytes", Name: "write", Algo: collectorapi.Incremental, Mul: -1},
+		},
+	}
+	containerPidsChartTmpl = collectorapi.Chart{
+		ID:       "container_%s_pids",
+		Title:    "Docker container processes",
+		Units:    "pids",
+		Fam:      "pids",
+		Ctx:      "docker.container_pids",
+		Priority: prioContainerPids,
+		Dims: collectorapi.Dims{
+			{ID: "container_%s_pids_current", Name: "active"},
+		},
+	}
+)
+
 func (c *Collector) addContainerCharts(name, image string) {
 	charts := containerChartsTmpl.Copy()
 
 	if !c.CollectContainerSize {
 		_ = charts.Remove(containerWritableLayerSizeChartTmpl.ID)
 	}
+	if c.CollectEvents {
+		if err := charts.Add(*containerEventsChartsTmpl.Copy()...); err != nil {
+			c.Warning(err)
+		}
+	}
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, name)
+		chart.Labels = []collectorapi.Label{
+			{Key: "container_name", Value: name},
+			{Key: "image", Value: image},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, name)
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) addContainerStatsCharts(name, image string) {
+	charts := containerStatsChartsTmpl.Copy()
 
 	for _, chart := range *charts {
 		chart.ID = fmt.Sprintf(chart.ID, name)
@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	typesContainer "github.com/moby/moby/api/types/container"
 	docker "github.com/moby/moby/client"
@@ -19,6 +20,9 @@ func (c *Collector) collect() (map[string]int64, error) {
 		}
 		c.client = client
 	}
+	if c.events != nil && c.eventsCancel == nil {
+		c.startEventsWatcher()
+	}
 
 	mx := make(map[string]int64)
 
@@ -31,6 +35,12 @@ func (c *Collector) collect() (map[string]int64, error) {
 	if err := c.collectContainers(mx); err != nil {
 		return nil, err
 	}
+	if c.events != nil {
+		c.events.collect(mx, c.containers, time.Now())
+	}
+	if c.CollectStats {
+		c.collectContainersStats(mx)
+	}
 
 	return mx, nil
 }
@@ -121,6 +131,7 @@ func (c *Collector) collectContainers(mx map[string]int64) error {
 	}
 
 	seen := make(map[string]bool)
+	running := make(map[string]runningContainer)
 
 	for _, s := range containerHealthStatuses {
 		mx["containers_health_status_"+string(s)] = 0
@@ -158,6 +169,9 @@ func (c *Collector) collectContainers(mx map[string]int64) error {
 			}
 
 			seen[name] = true
+			if cntr.State == "running" {
+				running[name] = runningContainer{id: cntr.ID, image: cntr.Image}
+			}
 
 			if !c.containers[name] {
 				c.containers[name] = true
@@ -188,9 +202,11 @@ func (c *Collector) collectContainers(mx map[string]int64) error {
 	for name := range c.containers {
 		if !seen[name] {
 			delete(c.containers, name)
+			delete(c.statsContainers, name)
 			c.removeContainerCharts(name)
 		}
 	}
+	c.runningContainers = running
 
 	return nil
 }
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	typesContainer "github.com/moby/moby/api/types/container"
+	docker "github.com/moby/moby/client"
+)
+
+const precision = 100
+
+// collectContainersStats queries the Docker stats API for every running container.
+// It provides resource usage metrics when the Agent has no access to the host cgroups
+// (e.g. it runs in a container and monitors a remote Docker daemon).
+func (c *Collector) collectContainersStats(mx map[string]int64) {
+	type result struct {
+		name  string
+		image string
+		stats *typesContainer.StatsResponse
+		err   error
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results []result
+		sem     = make(chan struct{}, max(c.StatsConcurrency, 1))
+	)
+
+	for name, cntr := range c.runningContainers {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			stats, err := c.queryContainerStats(cntr.id)
+
+			mu.Lock()
+			results = append(results, result{name: name, image: cntr.image, stats: stats, err: err})
+			mu.Unlock()
+		})
+	}
+	wg.Wait()
+
+	for _, res := range results {
+		if res.err != nil {
+			c.Debugf("container '%s' stats: %v", res.name, res.err)
+			continue
+		}
+		if !c.statsContainers[res.name] {
+			c.statsContainers[res.name] = true
+			c.addContainerStatsCharts(res.name, res.image)
+		}
+		writeContainerStats(mx, fmt.Sprintf("container_%s_", res.name), res.stats)
+	}
+}
+
+func (c *Collector) queryContainerStats(id string) (*typesContainer.StatsResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout.Duration())
+	defer cancel()
+
+	// a single sample: CPU usage is calculated from the cumulative counters between collections
+	res, err := c.client.ContainerStats(ctx, id, docker.ContainerStatsOptions{Stream: false})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	var stats typesContainer.StatsResponse
+	if err := json.NewDecoder(res.Body).Decode(&stats); err != nil {
+		return nil, fmt.Errorf("decode stats: %v", err)
+	}
+
+	return &stats, nil
+}
+
+func writeContainerStats(mx map[string]int64, px string, stats *typesContainer.StatsResponse) {
+	cpu := stats.CPUStats
+	mx[px+"cpu_usage_user"] = int64(cpu.CPUUsage.UsageInUsermode)
+	mx[px+"cpu_usage_system"] = int64(cpu.CPUUsage.UsageInKernelmode)
+	mx[px+"cpu_throttled_time"] = int64(cpu.ThrottlingData.ThrottledTime)
+
+	mem := stats.MemoryStats
+	used := mem.Usage
+	// the same calculation as "docker stats": page cache that can be reclaimed is not counted as used
+	if v, ok := mem.Stats["total_inactive_file"]; ok && v < used { // cgroup v1
+		used -= v
+	} else if v, ok := mem.Stats["inactive_file"]; ok && v < used { // cgroup v2
+		used -= v
+	}
+	mx[px+"mem_used"] = int64(used)
+	mx[px+"mem_limit"] = int64(mem.Limit)
+	mx[px+"mem_utilization"] = 0
+	if mem.Limit > 0 {
+		mx[px+"mem_utilization"] = int64(float64(used) * 100 * precision / float64(mem.Limit))
+	}
+
+	var net typesContainer.NetworkStats
+	for _, v := range stats.Networks {
+		net.RxBytes += v.RxBytes
+		net.TxBytes += v.TxBytes
+		net.RxPackets += v.RxPackets
+		net.TxPackets += v.TxPackets
+		net.RxErrors += v.RxErrors
+		net.TxErrors += v.TxErrors
+		net.RxDropped += v.RxDropped
+		net.TxDropped += v.TxDropped
+	}
+	mx[px+"net_rx_bytes"] = int64(net.RxBytes)
+	mx[px+"net_tx_bytes"] = int64(net.TxBytes)
+	mx[px+"net_rx_packets"] = int64(net.RxPackets)
+	mx[px+"net_tx_packets"] = int64(net.TxPackets)
+	mx[px+"net_rx_errors"] = int64(net.RxErrors)
+	mx[px+"net_tx_errors"] = int64(net.TxErrors)
+	mx[px+"net_rx_dropped"] = int64(net.RxDropped)
+	mx[px+"net_tx_dropped"] = int64(net.TxDropped)
+
+	var read, write uint64
+	for _, e := range stats.BlkioStats.IoServiceBytesRecursive {
+		// "Read"/"Write" on cgroup v1, "read"/"write" on cgroup v2
+		switch strings.ToLower(e.Op) {
+		case "read":
+			read += e.Value
+		case "write":
+			write += e.Value
+		}
+	}
+	mx[px+"blkio_read_bytes"] = int64(read)
+	mx[px+"blkio_write_bytes"] = int64(write)
+
+	mx[px+"pids_current"] = int64(stats.PidsStats.Current)
+}
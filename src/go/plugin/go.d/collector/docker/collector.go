@@ -6,6 +6,7 @@ import (
 	"context"
 	_ "embed"
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/netdata/netdata/go/plugins/pkg/confopt"
@@ -44,14 +45,20 @@ func New() *Collector {
 			Timeout:              confopt.Duration(time.Second * 2),
 			ContainerSelector:    "*",
 			CollectContainerSize: false,
+			CollectEvents:        false,
+			RestartLoopThreshold: 3,
+			RestartLoopWindow:    confopt.Duration(time.Minute * 5),
+			CollectStats:         false,
+			StatsConcurrency:     10,
 		},
 
 		charts: summaryCharts.Copy(),
 		newClient: func(cfg Config) (dockerClient, error) {
 			return docker.New(docker.WithHost(cfg.Address))
 		},
-		cntrSr:     matcher.TRUE(),
-		containers: make(map[string]bool),
+		cntrSr:          matcher.TRUE(),
+		containers:      make(map[string]bool),
+		statsContainers: make(map[string]bool),
 	}
 	c.funcRouter = dockerfunc.NewRouter(funcDepsAdapter{collector: c})
 	return c
@@ -65,6 +72,11 @@ type Config struct {
 	Timeout              confopt.Duration `yaml:"timeout,omitempty" json:"timeout"`
 	ContainerSelector    string           `yaml:"container_selector,omitempty" json:"container_selector"`
 	CollectContainerSize bool             `yaml:"collect_container_size" json:"collect_container_size"`
+	CollectEvents        bool             `yaml:"collect_events" json:"collect_events"`
+	RestartLoopThreshold int              `yaml:"restart_loop_threshold,omitempty" json:"restart_loop_threshold"`
+	RestartLoopWindow    confopt.Duration `yaml:"restart_loop_window,omitempty" json:"restart_loop_window"`
+	CollectStats         bool             `yaml:"collect_container_stats" json:"collect_container_stats"`
+	StatsConcurrency     int              `yaml:"container_stats_concurrency,omitempty" json:"container_stats_concurrency"`
 }
 
 type (
@@ -81,11 +93,24 @@ type (
 
 		containers map[string]bool
 		cntrSr     matcher.Matcher
+
+		runningContainers map[string]runningContainer // by name, for the stats API
+		statsContainers   map[string]bool
+
+		events       *containerEvents
+		eventsCancel context.CancelFunc
+		eventsWg     sync.WaitGroup
+	}
+	runningContainer struct {
+		id    string
+		image string
 	}
 	dockerClient interface {
 		Info(context.Context, docker.InfoOptions) (docker.SystemInfoResult, error)
 		ImageList(context.Context, docker.ImageListOptions) (docker.ImageListResult, error)
 		ContainerList(context.Context, docker.ContainerListOptions) (docker.ContainerListResult, error)
+		ContainerStats(context.Context, string, docker.ContainerStatsOptions) (docker.ContainerStatsResult, error)
+		Events(context.Context, docker.EventsListOptions) docker.EventsResult
 		Close() error
 	}
 )
@@ -109,6 +134,12 @@ func (c *Collector) Init(context.Context) error {
 	if c.funcRouter == nil {
 		c.funcRouter = dockerfunc.NewRouter(funcDepsAdapter{collector: c})
 	}
+	if c.CollectEvents {
+		c.events = newContainerEvents(c.RestartLoopThreshold, c.RestartLoopWindow.Duration())
+		if err := c.charts.Add(*containersEventsCharts.Copy()...); err != nil {
+			c.Warning(err)
+		}
+	}
 
 	return nil
 }
@@ -145,6 +176,7 @@ func (c *Collector) Cleanup(ctx context.Context) {
 	if c.funcRouter != nil {
 		c.funcRouter.Cleanup(ctx)
 	}
+	c.stopEventsWatcher()
 	if c.client == nil {
 		return
 	}
@@ -3,14 +3,20 @@
 package docker
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
+	"maps"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/collecttest"
 
 	typesContainer "github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/events"
 	typesImage "github.com/moby/moby/api/types/image"
 	typesSystem "github.com/moby/moby/api/types/system"
 	docker "github.com/moby/moby/client"
@@ -688,6 +694,165 @@ func TestCollector_Collect(t *testing.T) {
 	}
 }
 
+func TestCollector_Collect_Events(t *testing.T) {
+	m := &mockClient{events: make(chan events.Message)}
+	collr := New()
+	collr.CollectEvents = true
+	collr.RestartLoopThreshold = 2
+	collr.newClient = prepareNewClientFunc(m)
+	require.NoError(t, collr.Init(context.Background()))
+	defer collr.Cleanup(context.Background())
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+	assert.Equal(t, int64(0), mx["containers_events_die"])
+	assert.Equal(t, int64(0), mx["container_container5_crash_looping"])
+
+	now := time.Now()
+	send := func(name string, action events.Action, attrs map[string]string) {
+		now = now.Add(time.Second)
+		a := map[string]string{"name": name}
+		maps.Copy(a, attrs)
+		m.events <- events.Message{
+			Type:     events.ContainerEventType,
+			Action:   action,
+			Actor:    events.Actor{ID: name + "-id", Attributes: a},
+			TimeNano: now.UnixNano(),
+		}
+	}
+
+	// container5 crashes twice and is restarted by its restart policy
+	for range 2 {
+		send("container5", events.ActionOOM, nil)
+		send("container5", events.ActionDie, map[string]string{"exitCode": "137"})
+		send("container5", events.ActionStart, nil)
+	}
+	send("container2", events.ActionHealthStatusUnhealthy, nil)
+	send("container2", events.ActionHealthStatusUnhealthy, nil)
+	send("container2", events.ActionHealthStatusHealthy, nil)
+	send("container3", events.ActionDie, map[string]string{"exitCode": "0"})
+	// the last event is not processed until the next one is received (unbuffered channel)
+	send("container3", events.ActionExecStart, nil)
+
+	mx = collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	assert.Equal(t, int64(2), mx["containers_events_oom_kill"])
+	assert.Equal(t, int64(3), mx["containers_events_die"])
+	assert.Equal(t, int64(2), mx["containers_events_die_nonzero"])
+	assert.Equal(t, int64(2), mx["containers_events_restart"])
+	assert.Equal(t, int64(1), mx["containers_health_transitions_unhealthy"])
+	assert.Equal(t, int64(1), mx["containers_health_transitions_healthy"])
+	assert.Equal(t, int64(1), mx["containers_crash_looping"])
+
+	assert.Equal(t, int64(2), mx["container_container5_events_oom_kill"])
+	assert.Equal(t, int64(2), mx["container_container5_events_die"])
+	assert.Equal(t, int64(2), mx["container_container5_events_restart"])
+	assert.Equal(t, int64(137), mx["container_container5_last_exit_code"])
+	assert.Equal(t, int64(2), mx["container_container5_restarts_in_window"])
+	assert.Equal(t, int64(1), mx["container_container5_crash_looping"])
+
+	assert.Equal(t, int64(1), mx["container_container3_events_die"])
+	assert.Equal(t, int64(0), mx["container_container3_events_restart"])
+	assert.Equal(t, int64(0), mx["container_container3_crash_looping"])
+
+	collecttest.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+
+	ev := funcDepsAdapter{collector: collr}.ContainerEvents()
+	require.Contains(t, ev, "container5")
+	assert.True(t, ev["container5"].CrashLooping)
+	assert.Equal(t, "start", ev["container5"].LastEvent)
+	assert.Equal(t, "die (exit 0)", ev["container3"].LastEvent)
+}
+
+func TestCollector_Collect_ContainerStats(t *testing.T) {
+	tests := map[string]struct {
+		client    *mockClient
+		wantStats bool
+	}{
+		"success": {
+			client:    &mockClient{},
+			wantStats: true,
+		},
+		"stats API error": {
+			client:    &mockClient{errOnContainerStats: true},
+			wantStats: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collr := New()
+			collr.CollectStats = true
+			collr.StatsConcurrency = 2
+			collr.newClient = prepareNewClientFunc(test.client)
+			require.NoError(t, collr.Init(context.Background()))
+			defer collr.Cleanup(context.Background())
+
+			mx := collr.Collect(context.Background())
+			require.NotNil(t, mx)
+
+			// running containers
+			for _, name := range []string{"container2", "container3", "container5"} {
+				px := "container_" + name + "_"
+				if !test.wantStats {
+					assert.NotContains(t, mx, px+"cpu_usage_user")
+					continue
+				}
+				assert.Equal(t, int64(2_000_000_000), mx[px+"cpu_usage_user"])
+				assert.Equal(t, int64(1_000_000_000), mx[px+"cpu_usage_system"])
+				assert.Equal(t, int64(5_000_000), mx[px+"cpu_throttled_time"])
+				assert.Equal(t, int64(256<<20), mx[px+"mem_used"])
+				assert.Equal(t, int64(1<<30), mx[px+"mem_limit"])
+				assert.Equal(t, int64(2500), mx[px+"mem_utilization"])
+				assert.Equal(t, int64(1500), mx[px+"net_rx_bytes"])
+				assert.Equal(t, int64(2500), mx[px+"net_tx_bytes"])
+				assert.Equal(t, int64(15), mx[px+"net_rx_packets"])
+				assert.Equal(t, int64(25), mx[px+"net_tx_packets"])
+				assert.Equal(t, int64(1), mx[px+"net_rx_errors"])
+				assert.Equal(t, int64(2), mx[px+"net_tx_dropped"])
+				assert.Equal(t, int64(8192), mx[px+"blkio_read_bytes"])
+				assert.Equal(t, int64(8192), mx[px+"blkio_write_bytes"])
+				assert.Equal(t, int64(7), mx[px+"pids_current"])
+				assert.True(t, collr.Charts().Has("container_"+name+"_cpu_usage"))
+			}
+			// not running
+			assert.NotContains(t, mx, "container_container1_cpu_usage_user")
+
+			collecttest.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+		})
+	}
+}
+
+func TestContainerEvents_RestartLoopWindow(t *testing.T) {
+	e := newContainerEvents(2, time.Minute)
+	now := time.Now()
+
+	for i := range 2 {
+		ts := now.Add(time.Duration(i) * time.Second)
+		for _, action := range []events.Action{events.ActionDie, events.ActionStart} {
+			e.process(events.Message{
+				Type:     events.ContainerEventType,
+				Action:   action,
+				Actor:    events.Actor{Attributes: map[string]string{"name": "app", "exitCode": "1"}},
+				TimeNano: ts.UnixNano(),
+			})
+		}
+	}
+
+	st := e.containers["app"]
+	require.NotNil(t, st)
+	assert.True(t, e.crashLooping(st, now.Add(time.Second*10)))
+	assert.False(t, e.crashLooping(st, now.Add(time.Minute*2)))
+
+	e.process(events.Message{
+		Type:   events.ContainerEventType,
+		Action: events.ActionDestroy,
+		Actor:  events.Actor{Attributes: map[string]string{"name": "app"}},
+	})
+	assert.NotContains(t, e.containers, "app")
+}
+
 func prepareCaseSuccess() *Collector {
 	collr := New()
 	collr.CollectContainerSize = true
@@ -734,10 +899,56 @@ func prepareNewClientFunc(m *mockClient) func(_ Config) (dockerClient, error) {
 }
 
 type mockClient struct {
-	errOnInfo          bool
-	errOnImageList     bool
-	errOnContainerList bool
-	closeCalled        bool
+	errOnInfo           bool
+	errOnImageList      bool
+	errOnContainerList  bool
+	errOnContainerStats bool
+	closeCalled         bool
+	events              chan events.Message
+}
+
+func (m *mockClient) ContainerStats(_ context.Context, _ string, _ docker.ContainerStatsOptions) (docker.ContainerStatsResult, error) {
+	if m.errOnContainerStats {
+		return docker.ContainerStatsResult{}, errors.New("mockClient.ContainerStats() error")
+	}
+
+	stats := typesContainer.StatsResponse{
+		CPUStats: typesContainer.CPUStats{
+			CPUUsage: typesContainer.CPUUsage{
+				TotalUsage:        3_000_000_000,
+				UsageInUsermode:   2_000_000_000,
+				UsageInKernelmode: 1_000_000_000,
+			},
+			ThrottlingData: typesContainer.ThrottlingData{ThrottledTime: 5_000_000},
+		},
+		MemoryStats: typesContainer.MemoryStats{
+			Usage: 300 << 20,
+			Limit: 1 << 30,
+			Stats: map[string]uint64{"inactive_file": 44 << 20},
+		},
+		Networks: map[string]typesContainer.NetworkStats{
+			"eth0": {RxBytes: 1000, TxBytes: 2000, RxPackets: 10, TxPackets: 20, RxErrors: 1, TxDropped: 2},
+			"eth1": {RxBytes: 500, TxBytes: 500, RxPackets: 5, TxPackets: 5},
+		},
+		BlkioStats: typesContainer.BlkioStats{
+			IoServiceBytesRecursive: []typesContainer.BlkioStatEntry{
+				{Major: 8, Op: "read", Value: 4096},
+				{Major: 8, Op: "write", Value: 8192},
+				{Major: 9, Op: "Read", Value: 4096},
+			},
+		},
+		PidsStats: typesContainer.PidsStats{Current: 7},
+	}
+
+	bs, err := json.Marshal(stats)
+	if err != nil {
+		return docker.ContainerStatsResult{}, err
+	}
+	return docker.ContainerStatsResult{Body: io.NopCloser(bytes.NewReader(bs))}, nil
+}
+
+func (m *mockClient) Events(_ context.Context, _ docker.EventsListOptions) docker.EventsResult {
+	return docker.EventsResult{Messages: m.events}
 }
 
 func (m *mockClient) Info(_ context.Context, _ docker.InfoOptions) (docker.SystemInfoResult, error) {
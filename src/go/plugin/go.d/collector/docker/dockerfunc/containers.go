@@ -20,7 +20,8 @@ import (
 
 const (
 	containersMethodID   = "container-ls"
-	containersMethodHelp = "List Docker containers (equivalent to docker ps -a)."
+	containersMethodHelp = "List Docker containers (equivalent to docker ps -a). " +
+		"Restarts, OOM kills and the last lifecycle event are available when the collector's 'collect_events' option is enabled."
 )
 
 const (
@@ -34,6 +35,12 @@ const (
 	colNames
 	colContainerIDFull
 	colCreatedUnix
+	colRestarts
+	colOOMKills
+	colLastExitCode
+	colLastEvent
+	colLastEventTime
+	colCrashLoop
 )
 
 const (
@@ -47,6 +54,12 @@ const (
 	containersColNames       = "names"
 	containersColIDFull      = "container_id_full"
 	containersColCreatedUnix = "created_unix"
+	containersColRestarts    = "restarts"
+	containersColOOMKills    = "oom_kills"
+	containersColLastExit    = "last_exit_code"
+	containersColLastEvent   = "last_event"
+	containersColLastEventAt = "last_event_time"
+	containersColCrashLoop   = "crash_loop"
 )
 
 func containersFunctionConfig() funcapi.FunctionConfig {
@@ -97,10 +110,12 @@ func (f *funcContainers) Handle(ctx context.Context, method string, params funca
 
 	sortContainers(containers)
 
+	events := f.router.deps.ContainerEvents()
+
 	now := time.Now()
 	rows := make([][]any, 0, len(containers))
 	for _, cntr := range containers {
-		rows = append(rows, buildContainerRow(cntr, now))
+		rows = append(rows, buildContainerRow(cntr, events, now))
 	}
 
 	return &funcapi.FunctionResponse{
@@ -123,8 +138,8 @@ func sortContainers(containers []typesContainer.Summary) {
 	})
 }
 
-func buildContainerRow(cntr typesContainer.Summary, now time.Time) []any {
-	row := make([]any, colCreatedUnix+1)
+func buildContainerRow(cntr typesContainer.Summary, events map[string]ContainerEvents, now time.Time) []any {
+	row := make([]any, colCrashLoop+1)
 	row[colContainerID] = shortContainerID(cntr.ID)
 	row[colImage] = cntr.Image
 	row[colCommand] = strings.TrimSpace(cntr.Command)
@@ -135,6 +150,23 @@ func buildContainerRow(cntr typesContainer.Summary, now time.Time) []any {
 	row[colNames] = formatContainerNames(cntr.Names)
 	row[colContainerIDFull] = cntr.ID
 	row[colCreatedUnix] = cntr.Created
+
+	if ev, ok := events[primaryContainerName(cntr.Names)]; ok {
+		row[colRestarts] = ev.Restarts
+		row[colOOMKills] = ev.OOMKills
+		if ev.LastExitCode != nil {
+			row[colLastExitCode] = *ev.LastExitCode
+		}
+		row[colLastEvent] = ev.LastEvent
+		if !ev.LastEventAt.IsZero() {
+			row[colLastEventTime] = ev.LastEventAt.UnixMilli()
+		}
+		if ev.CrashLooping {
+			row[colCrashLoop] = "crash looping"
+		} else {
+			row[colCrashLoop] = "ok"
+		}
+	}
 	return row
 }
 
@@ -267,7 +299,86 @@ func buildContainerColumns() map[string]any {
 			Visible:       false,
 			ValueOptions:  funcapi.ValueOptions{Transform: funcapi.FieldTransformNumber},
 		}.BuildColumn(),
+		containersColRestarts: funcapi.Column{
+			Index:         colRestarts,
+			Name:          "RESTARTS",
+			Type:          funcapi.FieldTypeInteger,
+			Visualization: funcapi.FieldVisualValue,
+			Sort:          funcapi.FieldSortDescending,
+			Sortable:      true,
+			Summary:       funcapi.FieldSummarySum,
+			Filter:        funcapi.FieldFilterRange,
+			Visible:       true,
+			ValueOptions:  funcapi.ValueOptions{Transform: funcapi.FieldTransformNumber},
+		}.BuildColumn(),
+		containersColOOMKills: funcapi.Column{
+			Index:         colOOMKills,
+			Name:          "OOM KILLS",
+			Type:          funcapi.FieldTypeInteger,
+			Visualization: funcapi.FieldVisualValue,
+			Sort:          funcapi.FieldSortDescending,
+			Sortable:      true,
+			Summary:       funcapi.FieldSummarySum,
+			Filter:        funcapi.FieldFilterRange,
+			Visible:       false,
+			ValueOptions:  funcapi.ValueOptions{Transform: funcapi.FieldTransformNumber},
+		}.BuildColumn(),
+		containersColLastExit: funcapi.Column{
+			Index:         colLastExitCode,
+			Name:          "Last Exit Code",
+			Type:          funcapi.FieldTypeInteger,
+			Visualization: funcapi.FieldVisualValue,
+			Sort:          funcapi.FieldSortDescending,
+			Sortable:      true,
+			Summary:       funcapi.FieldSummaryCount,
+			Filter:        funcapi.FieldFilterMultiselect,
+			Visible:       false,
+			ValueOptions:  funcapi.ValueOptions{Transform: funcapi.FieldTransformNumber},
+		}.BuildColumn(),
+		containersColLastEvent: funcapi.Column{
+			Index:         colLastEvent,
+			Name:          "LAST EVENT",
+			Type:          funcapi.FieldTypeString,
+			Visualization: funcapi.FieldVisualValue,
+			Sort:          funcapi.FieldSortAscending,
+			Sortable:      true,
+			Summary:       funcapi.FieldSummaryCount,
+			Filter:        funcapi.FieldFilterMultiselect,
+			Visible:       true,
+			ValueOptions:  funcapi.ValueOptions{Transform: funcapi.FieldTransformText},
+		}.BuildColumn(),
+		containersColLastEventAt: funcapi.Column{
+			Index:         colLastEventTime,
+			Name:          "Last Event Time",
+			Type:          funcapi.FieldTypeTimestamp,
+			Visualization: funcapi.FieldVisualValue,
+			Sort:          funcapi.FieldSortDescending,
+			Sortable:      true,
+			Summary:       funcapi.FieldSummaryMax,
+			Filter:        funcapi.FieldFilterRange,
+			Visible:       false,
+			ValueOptions:  funcapi.ValueOptions{Transform: funcapi.FieldTransformDatetime},
+		}.BuildColumn(),
+		containersColCrashLoop: funcapi.Column{
+			Index:         colCrashLoop,
+			Name:          "RESTART LOOP",
+			Type:          funcapi.FieldTypeString,
+			Visualization: funcapi.FieldVisualPill,
+			Sort:          funcapi.FieldSortAscending,
+			Sortable:      true,
+			Summary:       funcapi.FieldSummaryCount,
+			Filter:        funcapi.FieldFilterMultiselect,
+			Visible:       true,
+			ValueOptions:  funcapi.ValueOptions{Transform: funcapi.FieldTransformText},
+		}.BuildColumn(),
+	}
+}
+
+func primaryContainerName(names []string) string {
+	if len(names) == 0 {
+		return ""
 	}
+	return strings.TrimPrefix(strings.TrimSpace(names[0]), "/")
 }
 
 func shortContainerID(id string) string {
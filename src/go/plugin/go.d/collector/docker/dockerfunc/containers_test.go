@@ -64,6 +64,16 @@ func TestFuncContainers_HandleSuccess(t *testing.T) {
 				Names:   []string{"/alma8"},
 			},
 		}},
+		events: map[string]ContainerEvents{
+			"alma8": {
+				Restarts:     4,
+				OOMKills:     1,
+				LastExitCode: new(int64(137)),
+				LastEvent:    "die (exit 137)",
+				LastEventAt:  now,
+				CrashLooping: true,
+			},
+		},
 	})
 
 	resp := r.Handle(context.Background(), containersMethodID, nil)
@@ -86,6 +96,12 @@ func TestFuncContainers_HandleSuccess(t *testing.T) {
 		containersColNames,
 		containersColIDFull,
 		containersColCreatedUnix,
+		containersColRestarts,
+		containersColOOMKills,
+		containersColLastExit,
+		containersColLastEvent,
+		containersColLastEventAt,
+		containersColCrashLoop,
 	} {
 		_, ok := columns[key]
 		assert.True(t, ok, "missing column %s", key)
@@ -103,6 +119,12 @@ func TestFuncContainers_HandleSuccess(t *testing.T) {
 	assert.Equal(t, "sleep infinity", data[0][colCommand])
 	assert.Equal(t, "Exited", data[0][colStatus])
 	assert.Equal(t, "exited", data[0][colState])
+	assert.Equal(t, int64(4), data[0][colRestarts])
+	assert.Equal(t, int64(1), data[0][colOOMKills])
+	assert.Equal(t, int64(137), data[0][colLastExitCode])
+	assert.Equal(t, "die (exit 137)", data[0][colLastEvent])
+	assert.Equal(t, now.UnixMilli(), data[0][colLastEventTime])
+	assert.Equal(t, "crash looping", data[0][colCrashLoop])
 
 	// second row includes formatted ports and truncated command
 	assert.Equal(t, "postgres-dev", data[1][colNames])
@@ -111,6 +133,9 @@ func TestFuncContainers_HandleSuccess(t *testing.T) {
 	assert.Equal(t, "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", data[1][colContainerIDFull])
 	assert.Equal(t, "docker-entrypoint.sh postgres", data[1][colCommand])
 	assert.Equal(t, "exited", data[1][colState])
+	// no events seen
+	assert.Nil(t, data[1][colRestarts])
+	assert.Nil(t, data[1][colCrashLoop])
 }
 
 func TestFormatHelpers(t *testing.T) {
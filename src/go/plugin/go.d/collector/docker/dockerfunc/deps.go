@@ -4,6 +4,7 @@ package dockerfunc
 
 import (
 	"context"
+	"time"
 
 	docker "github.com/moby/moby/client"
 )
@@ -14,7 +15,19 @@ type DockerClient interface {
 	ImageList(context.Context, docker.ImageListOptions) (docker.ImageListResult, error)
 }
 
+// ContainerEvents summarizes the lifecycle events seen for a container since the collector started.
+type ContainerEvents struct {
+	Restarts     int64
+	OOMKills     int64
+	LastExitCode *int64
+	LastEvent    string
+	LastEventAt  time.Time
+	CrashLooping bool
+}
+
 // Deps provides runtime dependencies needed by Docker function handlers.
 type Deps interface {
 	DockerClient() (DockerClient, error)
+	// ContainerEvents returns the events summaries by container name, or nil if events are not collected.
+	ContainerEvents() map[string]ContainerEvents
 }
@@ -16,6 +16,11 @@ import (
 type mockDeps struct {
 	client DockerClient
 	err    error
+	events map[string]ContainerEvents
+}
+
+func (m mockDeps) ContainerEvents() map[string]ContainerEvents {
+	return m.events
 }
 
 func (m mockDeps) DockerClient() (DockerClient, error) {
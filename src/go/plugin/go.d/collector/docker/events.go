@@ -0,0 +1,304 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/docker/dockerfunc"
+
+	"github.com/moby/moby/api/types/events"
+	docker "github.com/moby/moby/client"
+)
+
+const eventsReconnectInterval = 5 * time.Second
+
+var containerHealthTransitions = []string{"healthy", "unhealthy", "starting"}
+
+// containerEvents keeps the lifecycle events received from the Docker events stream.
+// Events are processed as they arrive, so crashes between two data collections are not missed.
+type containerEvents struct {
+	restartLoopThreshold int
+	restartLoopWindow    time.Duration
+
+	mu         sync.Mutex
+	containers map[string]*containerEventStats // by container name
+	total      containerEventCounters
+}
+
+type (
+	containerEventCounters struct {
+		oomKills          int64
+		dies              int64
+		diesNonZero       int64
+		restarts          int64
+		healthTransitions map[string]int64
+	}
+	containerEventStats struct {
+		containerEventCounters
+
+		lastExitCode int64
+		hasExitCode  bool
+		lastHealth   string
+		lastEvent    string
+		lastEventAt  time.Time
+		diedAt       time.Time   // set by "die", cleared by "start"
+		restartTimes []time.Time // within the restart loop window
+	}
+)
+
+func newContainerEvents(threshold int, window time.Duration) *containerEvents {
+	return &containerEvents{
+		restartLoopThreshold: threshold,
+		restartLoopWindow:    window,
+		containers:           make(map[string]*containerEventStats),
+		total:                containerEventCounters{healthTransitions: make(map[string]int64)},
+	}
+}
+
+func (e *containerEvents) process(msg events.Message) {
+	if msg.Type != events.ContainerEventType {
+		return
+	}
+	name := strings.TrimPrefix(msg.Actor.Attributes["name"], "/")
+	if name == "" {
+		return
+	}
+
+	ts := eventTime(msg)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if msg.Action == events.ActionDestroy {
+		delete(e.containers, name)
+		return
+	}
+
+	st, ok := e.containers[name]
+	if !ok {
+		st = &containerEventStats{containerEventCounters: containerEventCounters{healthTransitions: make(map[string]int64)}}
+		e.containers[name] = st
+	}
+
+	action := string(msg.Action)
+
+	switch {
+	case msg.Action == events.ActionOOM:
+		st.oomKills++
+		e.total.oomKills++
+	case msg.Action == events.ActionDie:
+		st.dies++
+		e.total.dies++
+		if code, err := strconv.ParseInt(msg.Actor.Attributes["exitCode"], 10, 64); err == nil {
+			st.lastExitCode, st.hasExitCode = code, true
+			if code != 0 {
+				st.diesNonZero++
+				e.total.diesNonZero++
+			}
+			action = fmt.Sprintf("die (exit %d)", code)
+		}
+		st.diedAt = ts
+	case msg.Action == events.ActionStart:
+		// Restart policies and "docker restart" both result in "die" followed by "start".
+		if !st.diedAt.IsZero() {
+			st.restarts++
+			e.total.restarts++
+			st.restartTimes = append(st.restartTimes, ts)
+		}
+		st.diedAt = time.Time{}
+	case strings.HasPrefix(action, string(events.ActionHealthStatus)):
+		status := strings.TrimSpace(strings.TrimPrefix(action, string(events.ActionHealthStatus)+":"))
+		if status != st.lastHealth && status != "running" && status != "" {
+			st.lastHealth = status
+			st.healthTransitions[status]++
+			e.total.healthTransitions[status]++
+		}
+	default:
+		// exec, attach, resize, etc. are too noisy to be kept as the last event
+		if !isLifecycleAction(msg.Action) {
+			return
+		}
+	}
+
+	st.lastEvent = action
+	st.lastEventAt = ts
+}
+
+// crashLooping reports whether the container was restarted at least restartLoopThreshold times
+// within the restart loop window.
+func (e *containerEvents) crashLooping(st *containerEventStats, now time.Time) bool {
+	e.pruneRestarts(st, now)
+	return e.restartLoopThreshold > 0 && len(st.restartTimes) >= e.restartLoopThreshold
+}
+
+func (e *containerEvents) pruneRestarts(st *containerEventStats, now time.Time) {
+	i := 0
+	for i < len(st.restartTimes) && now.Sub(st.restartTimes[i]) > e.restartLoopWindow {
+		i++
+	}
+	st.restartTimes = st.restartTimes[i:]
+}
+
+func (e *containerEvents) collect(mx map[string]int64, containers map[string]bool, now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	mx["containers_events_oom_kill"] = e.total.oomKills
+	mx["containers_events_die"] = e.total.dies
+	mx["containers_events_die_nonzero"] = e.total.diesNonZero
+	mx["containers_events_restart"] = e.total.restarts
+	for _, s := range containerHealthTransitions {
+		mx["containers_health_transitions_"+s] = e.total.healthTransitions[s]
+	}
+	mx["containers_crash_looping"] = 0
+
+	for name := range containers {
+		px := fmt.Sprintf("container_%s_", name)
+
+		st, ok := e.containers[name]
+		if !ok {
+			st = &containerEventStats{}
+		}
+		looping := e.crashLooping(st, now)
+
+		mx[px+"events_oom_kill"] = st.oomKills
+		mx[px+"events_die"] = st.dies
+		mx[px+"events_restart"] = st.restarts
+		for _, s := range containerHealthTransitions {
+			mx[px+"health_transitions_"+s] = st.healthTransitions[s]
+		}
+		mx[px+"last_exit_code"] = st.lastExitCode
+		mx[px+"restarts_in_window"] = int64(len(st.restartTimes))
+		mx[px+"crash_looping"] = boolToInt(looping)
+		if looping {
+			mx["containers_crash_looping"]++
+		}
+	}
+}
+
+// summaries returns the events summary of every container, for the containers function.
+func (e *containerEvents) summaries(now time.Time) map[string]dockerfunc.ContainerEvents {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	out := make(map[string]dockerfunc.ContainerEvents, len(e.containers))
+	for name, st := range e.containers {
+		v := dockerfunc.ContainerEvents{
+			Restarts:     st.restarts,
+			OOMKills:     st.oomKills,
+			LastEvent:    st.lastEvent,
+			LastEventAt:  st.lastEventAt,
+			CrashLooping: e.crashLooping(st, now),
+		}
+		if st.hasExitCode {
+			v.LastExitCode = &st.lastExitCode
+		}
+		out[name] = v
+	}
+	return out
+}
+
+func isLifecycleAction(a events.Action) bool {
+	switch a {
+	case events.ActionCreate, events.ActionStop, events.ActionKill, events.ActionPause, events.ActionUnPause,
+		events.ActionRestart, events.ActionRename, events.ActionUpdate:
+		return true
+	}
+	return false
+}
+
+func eventTime(msg events.Message) time.Time {
+	if msg.TimeNano > 0 {
+		return time.Unix(0, msg.TimeNano)
+	}
+	if msg.Time > 0 {
+		return time.Unix(msg.Time, 0)
+	}
+	return time.Now()
+}
+
+func (c *Collector) startEventsWatcher() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.eventsCancel = cancel
+
+	c.eventsWg.Go(func() { c.runEventsWatcher(ctx, c.client) })
+}
+
+func (c *Collector) stopEventsWatcher() {
+	if c.eventsCancel == nil {
+		return
+	}
+	c.eventsCancel()
+	c.eventsWg.Wait()
+	c.eventsCancel = nil
+}
+
+func (c *Collector) runEventsWatcher(ctx context.Context, client dockerClient) {
+	var since string
+	var lastNano int64
+	var warned bool
+
+	for {
+		res := client.Events(ctx, docker.EventsListOptions{
+			Since:   since,
+			Filters: make(docker.Filters).Add("type", string(events.ContainerEventType)),
+		})
+
+		err := func() error {
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case msg, ok := <-res.Messages:
+					if !ok {
+						return io.EOF
+					}
+					// after a reconnect, events at the "since" timestamp are delivered again
+					if msg.TimeNano != 0 && msg.TimeNano <= lastNano {
+						continue
+					}
+					lastNano = max(lastNano, msg.TimeNano)
+					warned = false
+					c.events.process(msg)
+				case err, ok := <-res.Err:
+					if !ok {
+						return io.EOF
+					}
+					return err
+				}
+			}
+		}()
+
+		if ctx.Err() != nil {
+			return
+		}
+		if !warned && !errors.Is(err, io.EOF) {
+			c.Warningf("docker events stream: %v (reconnecting every %s)", err, eventsReconnectInterval)
+			warned = true
+		}
+		if lastNano > 0 {
+			since = fmt.Sprintf("%d.%09d", lastNano/int64(time.Second), lastNano%int64(time.Second))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(eventsReconnectInterval):
+		}
+	}
+}
+
+func boolToInt(v bool) int64 {
+	if v {
+		return 1
+	}
+	return 0
+}
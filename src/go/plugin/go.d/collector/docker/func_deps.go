@@ -4,6 +4,7 @@ package docker
 
 import (
 	"errors"
+	"time"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/docker/dockerfunc"
 )
@@ -20,3 +21,10 @@ func (a funcDepsAdapter) DockerClient() (dockerfunc.DockerClient, error) {
 	}
 	return a.collector.client, nil
 }
+
+func (a funcDepsAdapter) ContainerEvents() map[string]dockerfunc.ContainerEvents {
+	if a.collector.events == nil {
+		return nil
+	}
+	return a.collector.events.summaries(time.Now())
+}
@@ -13,11 +13,15 @@ const (
 	prioFileExistenceStatus = collectorapi.Priority + iota
 	prioFileModificationTimeAgo
 	prioFileSize
+	prioFileIntegrityStatus
 
 	prioDirExistenceStatus
 	prioDirModificationTimeAgo
 	prioDirSize
 	prioDirFilesCount
+
+	prioIntegrityFiles
+	prioIntegrityChanges
 )
 
 var (
@@ -56,8 +60,49 @@ var (
 			{ID: "file_%s_size_bytes", Name: "size"},
 		},
 	}
+	fileIntegrityStatusChartTmpl = collectorapi.Chart{
+		ID:       "file_%s_integrity_status",
+		Title:    "File integrity changes since the baseline",
+		Units:    "status",
+		Fam:      "file integrity",
+		Ctx:      "filecheck.file_integrity_status",
+		Priority: prioFileIntegrityStatus,
+		Dims: collectorapi.Dims{
+			{ID: "file_%s_integrity_content_changed", Name: "content"},
+			{ID: "file_%s_integrity_owner_changed", Name: "owner"},
+			{ID: "file_%s_integrity_mode_changed", Name: "mode"},
+			{ID: "file_%s_integrity_xattrs_changed", Name: "xattrs"},
+		},
+	}
 )
 
+var integrityFilesChart = collectorapi.Chart{
+	ID:       "integrity_files",
+	Title:    "Monitored files integrity",
+	Units:    "files",
+	Fam:      "integrity",
+	Ctx:      "filecheck.integrity_files",
+	Priority: prioIntegrityFiles,
+	Type:     collectorapi.Stacked,
+	Dims: collectorapi.Dims{
+		{ID: "integrity_files_unchanged", Name: "unchanged"},
+		{ID: "integrity_files_changed", Name: "changed"},
+		{ID: "integrity_files_missing", Name: "missing"},
+	},
+}
+
+var integrityChangesChart = collectorapi.Chart{
+	ID:       "integrity_changes",
+	Title:    "Detected file integrity changes",
+	Units:    "changes/s",
+	Fam:      "integrity",
+	Ctx:      "filecheck.integrity_changes",
+	Priority: prioIntegrityChanges,
+	Dims: collectorapi.Dims{
+		{ID: "integrity_changes_detected", Name: "detected", Algo: collectorapi.Incremental},
+	},
+}
+
 var (
 	dirExistenceStatusChartTmpl = collectorapi.Chart{
 		ID:       "dir_%s_existence_status",
@@ -128,6 +173,11 @@ func (c *Collector) updateFileCharts(infos []*statInfo) {
 				fileModificationTimeAgoChartTmpl.Copy(),
 				fileSizeChartTmpl.Copy(),
 			)
+			if c.integrity != nil {
+				c.addFileCharts(info.path,
+					fileIntegrityStatusChartTmpl.Copy(),
+				)
+			}
 
 		} else if sf.hasOtherCharts && info.fi == nil {
 			sf.hasOtherCharts = false
@@ -184,6 +234,12 @@ func (c *Collector) updateDirCharts(infos []*statInfo) {
 	}
 }
 
+func (c *Collector) addIntegrityCharts() {
+	if err := c.Charts().Add(integrityFilesChart.Copy(), integrityChangesChart.Copy()); err != nil {
+		c.Warning(err)
+	}
+}
+
 func (c *Collector) addFileCharts(filePath string, chartsTmpl ...*collectorapi.Chart) {
 	cs := append(collectorapi.Charts{}, chartsTmpl...)
 	charts := cs.Copy()
@@ -14,6 +14,9 @@ func (c *Collector) collectFiles(mx map[string]int64) {
 	if c.isTimeToDiscoverFiles(now) {
 		c.lastDiscFilesTime = now
 		c.curFiles = c.discoverFiles()
+		if c.integrity != nil && c.Integrity.Realtime {
+			c.updateIntegrityWatcher()
+		}
 	}
 
 	var infos []*statInfo
@@ -26,6 +29,10 @@ func (c *Collector) collectFiles(mx map[string]int64) {
 		c.collectFile(mx, si, now)
 	}
 
+	if c.integrity != nil {
+		c.collectFilesIntegrity(mx, now)
+	}
+
 	c.updateFileCharts(infos)
 }
 
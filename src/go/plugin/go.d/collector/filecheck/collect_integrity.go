@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package filecheck
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+func (c *Collector) collectFilesIntegrity(mx map[string]int64, now time.Time) {
+	var changed, unchanged, missing int64
+
+	paths := slices.Clone(c.curFiles)
+	monitored := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		monitored[path] = true
+	}
+	// Discovery returns only existing glob matches, baseline files that matched a glob are checked too,
+	// so that their removal is reported instead of their baseline being silently dropped.
+	for _, path := range c.integrity.baselinePaths() {
+		if !monitored[path] && c.matchesFilesInclude(path) {
+			monitored[path] = true
+			paths = append(paths, path)
+		}
+	}
+
+	for _, path := range paths {
+
+		c.integrity.check(path, now)
+
+		f, ok := c.integrity.status(path)
+		if !ok {
+			continue
+		}
+
+		if !f.exists {
+			if _, inBaseline := c.integrity.baselineAttrs(path); inBaseline {
+				missing++
+			}
+			continue
+		}
+
+		if f.changes.any() {
+			changed++
+		} else {
+			unchanged++
+		}
+
+		px := fmt.Sprintf("file_%s_", path)
+		mx[px+"integrity_content_changed"] = boolToInt(f.changes.content)
+		mx[px+"integrity_owner_changed"] = boolToInt(f.changes.owner)
+		mx[px+"integrity_mode_changed"] = boolToInt(f.changes.mode)
+		mx[px+"integrity_xattrs_changed"] = boolToInt(f.changes.xattrs)
+	}
+
+	c.integrity.forget(func(path string) bool { return monitored[path] })
+	c.integrity.saveBaseline()
+
+	mx["integrity_files_unchanged"] = unchanged
+	mx["integrity_files_changed"] = changed
+	mx["integrity_files_missing"] = missing
+	mx["integrity_changes_detected"] = c.integrity.changesDetectedTotal()
+}
+
+// matchesFilesInclude reports whether a glob in 'files->include' matches the path and 'files->exclude' does not.
+func (c *Collector) matchesFilesInclude(path string) bool {
+	if c.filesFilter.MatchString(path) {
+		return false
+	}
+	return slices.ContainsFunc(c.Files.Include, func(pattern string) bool {
+		ok, _ := filepath.Match(pattern, path)
+		return hasMeta(pattern) && ok
+	})
+}
+
+func (c *Collector) updateIntegrityWatcher() {
+	if c.integrityWatcher == nil {
+		w, err := newIntegrityWatcher(c.integrity, c.Logger)
+		if err != nil {
+			c.Warningf("integrity: real-time detection is disabled: %v", err)
+			c.Integrity.Realtime = false
+			return
+		}
+		c.integrityWatcher = w
+	}
+	c.integrityWatcher.setFiles(c.curFiles)
+}
+
+func boolToInt(v bool) int64 {
+	if v {
+		return 1
+	}
+	return 0
+}
@@ -22,22 +22,32 @@ func init() {
 		Defaults: collectorapi.Defaults{
 			UpdateEvery: 10,
 		},
-		Create: func() collectorapi.CollectorV1 { return New() },
-		Config: func() any { return &Config{} },
+		Create:          func() collectorapi.CollectorV1 { return New() },
+		Config:          func() any { return &Config{} },
+		SharedFunctions: filecheckMethods,
+		MethodHandler:   filecheckFunctionHandler,
 	})
 }
 
 func New() *Collector {
-	return &Collector{
+	c := &Collector{
 		Config: Config{
 			DiscoveryEvery: confopt.Duration(time.Minute * 1),
 			Files:          filesConfig{},
 			Dirs:           dirsConfig{CollectDirSize: false},
+			Integrity: integrityConfig{
+				Enabled:         false,
+				HashContent:     true,
+				MaxHashFileSize: defaultMaxHashFileSize,
+				Realtime:        true,
+			},
 		},
 		charts:    &collectorapi.Charts{},
 		seenFiles: newSeenItems(),
 		seenDirs:  newSeenItems(),
 	}
+	c.funcRouter = newFuncRouter(c)
+	return c
 }
 
 type (
@@ -46,6 +56,7 @@ type (
 		DiscoveryEvery confopt.Duration `yaml:"discovery_every,omitempty" json:"discovery_every"`
 		Files          filesConfig      `yaml:"files" json:"files"`
 		Dirs           dirsConfig       `yaml:"dirs" json:"dirs"`
+		Integrity      integrityConfig  `yaml:"integrity,omitempty" json:"integrity"`
 	}
 	filesConfig struct {
 		Include []string `yaml:"include" json:"include"`
@@ -56,6 +67,13 @@ type (
 		Exclude        []string `yaml:"exclude,omitempty" json:"exclude"`
 		CollectDirSize bool     `yaml:"collect_dir_size" json:"collect_dir_size"`
 	}
+	integrityConfig struct {
+		Enabled         bool   `yaml:"enabled" json:"enabled"`
+		HashContent     bool   `yaml:"hash_content" json:"hash_content"`
+		MaxHashFileSize int64  `yaml:"max_hash_file_size,omitempty" json:"max_hash_file_size"`
+		BaselineFile    string `yaml:"baseline_file,omitempty" json:"baseline_file"`
+		Realtime        bool   `yaml:"realtime" json:"realtime"`
+	}
 )
 
 type Collector struct {
@@ -73,6 +91,11 @@ type Collector struct {
 	lastDiscDirsTime time.Time
 	curDirs          []string
 	seenDirs         *seenItems
+
+	integrity        *integrityMonitor
+	integrityWatcher *integrityWatcher
+
+	funcRouter *funcRouter
 }
 
 func (c *Collector) Configuration() any {
@@ -97,6 +120,15 @@ func (c *Collector) Init(context.Context) error {
 	}
 	c.dirsFilter = df
 
+	if c.Integrity.Enabled {
+		m, err := c.initIntegrityMonitor()
+		if err != nil {
+			return fmt.Errorf("integrity monitor initialization: %v", err)
+		}
+		c.integrity = m
+		c.addIntegrityCharts()
+	}
+
 	c.Debugf("monitored files: %v", c.Files.Include)
 	c.Debugf("monitored dirs: %v", c.Dirs.Include)
 
@@ -124,4 +156,15 @@ func (c *Collector) Collect(context.Context) map[string]int64 {
 	return mx
 }
 
-func (c *Collector) Cleanup(context.Context) {}
+func (c *Collector) Cleanup(ctx context.Context) {
+	if c.integrityWatcher != nil {
+		c.integrityWatcher.stop()
+		c.integrityWatcher = nil
+	}
+	if c.integrity != nil {
+		c.integrity.saveBaseline()
+	}
+	if c.funcRouter != nil {
+		c.funcRouter.Cleanup(ctx)
+	}
+}
@@ -5,9 +5,12 @@ package filecheck
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/netdata/netdata/go/plugins/pkg/funcapi"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/collecttest"
 
 	"github.com/stretchr/testify/assert"
@@ -81,6 +84,15 @@ func TestCollector_Init(t *testing.T) {
 				},
 			},
 		},
+		"integrity without files->include": {
+			wantFail: true,
+			config: Config{
+				Dirs: dirsConfig{
+					Include: []string{"/path/to/dir1"},
+				},
+				Integrity: integrityConfig{Enabled: true},
+			},
+		},
 		"only dirs->include": {
 			wantFail: false,
 			config: Config{
@@ -253,6 +265,208 @@ func TestCollector_Collect(t *testing.T) {
 	}
 }
 
+func TestCollector_Collect_Integrity(t *testing.T) {
+	dir := t.TempDir()
+	file1 := filepath.Join(dir, "file1.conf")
+	file2 := filepath.Join(dir, "file2.conf")
+	baseline := filepath.Join(dir, "baseline.json")
+
+	require.NoError(t, os.WriteFile(file1, []byte("key = value\n"), 0644))
+	require.NoError(t, os.WriteFile(file2, []byte("key = value\n"), 0644))
+
+	newCollector := func() *Collector {
+		collr := New()
+		collr.Config.Files.Include = []string{file1, file2}
+		collr.Config.Integrity.Enabled = true
+		collr.Config.Integrity.Realtime = false
+		collr.Config.Integrity.BaselineFile = baseline
+		require.NoError(t, collr.Init(context.Background()))
+		return collr
+	}
+
+	collr := newCollector()
+
+	mx := collr.Collect(context.Background())
+	assert.Equal(t, int64(2), mx["integrity_files_unchanged"])
+	assert.Equal(t, int64(0), mx["integrity_files_changed"])
+	assert.Equal(t, int64(0), mx["integrity_files_missing"])
+	collecttest.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+	collr.Cleanup(context.Background())
+
+	require.FileExists(t, baseline)
+
+	// the baseline survives restarts
+	require.NoError(t, os.WriteFile(file1, []byte("key = other value\n"), 0644))
+	require.NoError(t, os.Chmod(file1, 0600))
+	require.NoError(t, os.Remove(file2))
+
+	collr = newCollector()
+	defer collr.Cleanup(context.Background())
+
+	mx = collr.Collect(context.Background())
+	assert.Equal(t, int64(0), mx["integrity_files_unchanged"])
+	assert.Equal(t, int64(1), mx["integrity_files_changed"])
+	assert.Equal(t, int64(1), mx["integrity_files_missing"])
+	assert.Equal(t, int64(2), mx["integrity_changes_detected"])
+
+	px := "file_" + file1 + "_"
+	assert.Equal(t, int64(1), mx[px+"integrity_content_changed"])
+	assert.Equal(t, int64(1), mx[px+"integrity_mode_changed"])
+	assert.Equal(t, int64(0), mx[px+"integrity_owner_changed"])
+	assert.Equal(t, int64(0), mx[px+"integrity_xattrs_changed"])
+
+	resp := collr.funcRouter.Handle(context.Background(), integrityChangesMethodID, funcapi.ResolvedParams{})
+	require.NotNil(t, resp)
+	require.Equal(t, 200, resp.Status)
+	require.Len(t, resp.Data, 2)
+
+	rows := resp.Data.([][]any)
+	assert.Equal(t, file1, rows[0][0])
+	assert.Equal(t, "changed", rows[0][1])
+	assert.Equal(t, "content, mode", rows[0][2])
+	assert.Equal(t, int64(1), rows[0][3])
+	assert.Equal(t, file2, rows[1][0])
+	assert.Equal(t, "missing", rows[1][1])
+
+	// restoring the file clears the change, but it is still reported as reverted
+	require.NoError(t, os.WriteFile(file1, []byte("key = value\n"), 0644))
+	require.NoError(t, os.Chmod(file1, 0644))
+
+	mx = collr.Collect(context.Background())
+	assert.Equal(t, int64(0), mx[px+"integrity_content_changed"])
+	assert.Equal(t, int64(0), mx[px+"integrity_mode_changed"])
+	assert.Equal(t, int64(2), mx["integrity_changes_detected"])
+
+	resp = collr.funcRouter.Handle(context.Background(), integrityChangesMethodID, funcapi.ResolvedParams{})
+	require.Equal(t, 200, resp.Status)
+	rows = resp.Data.([][]any)
+	require.Len(t, rows, 2)
+	assert.Equal(t, file1, rows[0][0])
+	assert.Equal(t, "reverted", rows[0][1])
+	assert.Equal(t, "content, mode", rows[0][2])
+	assert.Equal(t, int64(1), rows[0][3])
+	assert.NotNil(t, rows[0][4], "the time of the reverted change is kept")
+}
+
+func TestCollector_Collect_IntegrityGlobMissing(t *testing.T) {
+	dir := t.TempDir()
+	file1 := filepath.Join(dir, "file1.conf")
+	file2 := filepath.Join(dir, "file2.conf")
+
+	require.NoError(t, os.WriteFile(file1, []byte("key = value\n"), 0644))
+	require.NoError(t, os.WriteFile(file2, []byte("key = value\n"), 0644))
+
+	collr := New()
+	collr.Config.Files.Include = []string{filepath.Join(dir, "*.conf")}
+	collr.Config.Integrity.Enabled = true
+	collr.Config.Integrity.Realtime = false
+	collr.Config.Integrity.BaselineFile = filepath.Join(dir, "baseline.json")
+	require.NoError(t, collr.Init(context.Background()))
+	defer collr.Cleanup(context.Background())
+
+	collect := func() map[string]int64 {
+		collr.lastDiscFilesTime = time.Time{} // rediscover
+		return collr.Collect(context.Background())
+	}
+
+	mx := collect()
+	assert.Equal(t, int64(2), mx["integrity_files_unchanged"])
+
+	// the removed file no longer matches the glob, but it is in the baseline
+	require.NoError(t, os.Remove(file2))
+
+	mx = collect()
+	assert.Equal(t, int64(1), mx["integrity_files_unchanged"])
+	assert.Equal(t, int64(1), mx["integrity_files_missing"])
+
+	changes := collr.integrity.changedFiles()
+	require.Len(t, changes, 1)
+	assert.Equal(t, file2, changes[0].path)
+	assert.Equal(t, "missing", changes[0].status)
+
+	// the restored file is reported as reverted, with the removal as the last change
+	require.NoError(t, os.WriteFile(file2, []byte("key = value\n"), 0644))
+
+	mx = collect()
+	assert.Equal(t, int64(2), mx["integrity_files_unchanged"])
+	assert.Equal(t, int64(0), mx["integrity_files_missing"])
+
+	changes = collr.integrity.changedFiles()
+	require.Len(t, changes, 1)
+	assert.Equal(t, "reverted", changes[0].status)
+	assert.Equal(t, "missing", changes[0].changes.String())
+	assert.False(t, changes[0].detectedAt.IsZero())
+}
+
+func TestCollector_Collect_IntegrityRealtime(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.conf")
+
+	require.NoError(t, os.WriteFile(file, []byte("key = value\n"), 0644))
+
+	collr := New()
+	collr.Config.Files.Include = []string{file}
+	collr.Config.Integrity.Enabled = true
+	collr.Config.Integrity.BaselineFile = filepath.Join(dir, "baseline.json")
+	require.NoError(t, collr.Init(context.Background()))
+	defer collr.Cleanup(context.Background())
+
+	_ = collr.Collect(context.Background())
+	require.NotNil(t, collr.integrityWatcher)
+	require.Empty(t, collr.integrity.changedFiles())
+
+	require.NoError(t, os.WriteFile(file, []byte("key = other value\n"), 0644))
+
+	// detected without a data collection
+	assert.Eventually(t, func() bool {
+		return len(collr.integrity.changedFiles()) == 1
+	}, time.Second*5, time.Millisecond*50)
+
+	require.NoError(t, os.WriteFile(file, []byte("key = value\n"), 0644))
+
+	assert.Eventually(t, func() bool {
+		changes := collr.integrity.changedFiles()
+		return len(changes) == 1 && changes[0].status == "reverted"
+	}, time.Second*5, time.Millisecond*50)
+}
+
+func TestIntegrityWatcher_ScheduleCoalescesEvents(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file.conf")
+	require.NoError(t, os.WriteFile(file, []byte("key = value\n"), 0644))
+
+	w, err := newIntegrityWatcher(newIntegrityMonitor(integrityConfig{HashContent: true}, ""), nil)
+	require.NoError(t, err)
+	defer w.stop()
+
+	w.schedule(file)
+	w.setFiles([]string{file})
+	for range 10 {
+		w.schedule(file)
+	}
+
+	w.mu.Lock()
+	assert.Len(t, w.pending, 1, "events of one write are checked once")
+	w.mu.Unlock()
+
+	assert.Eventually(t, func() bool {
+		_, ok := w.monitor.status(file)
+		return ok
+	}, time.Second*5, time.Millisecond*10)
+
+	w.mu.Lock()
+	assert.Empty(t, w.pending)
+	w.mu.Unlock()
+}
+
+func TestCollector_IntegrityChangesFunction_Disabled(t *testing.T) {
+	collr := prepareFilecheckFiles()
+	require.NoError(t, collr.Init(context.Background()))
+
+	resp := collr.funcRouter.Handle(context.Background(), integrityChangesMethodID, funcapi.ResolvedParams{})
+	require.NotNil(t, resp)
+	assert.Equal(t, 503, resp.Status)
+}
+
 func prepareFilecheckFiles() *Collector {
 	collr := New()
 	collr.Config.Files.Include = []string{
@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package filecheck
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/pkg/funcapi"
+)
+
+const integrityChangesMethodID = "integrity-changes"
+
+const integrityChangesHelp = "Monitored files that differ from their integrity baseline (content, owner, permissions or extended attributes) " +
+	"or that were removed. Files that differed since the collector started but match the baseline again are listed as reverted. " +
+	"The baseline is recorded the first time a file is seen."
+
+func integrityChangesFunctionConfig() funcapi.FunctionConfig {
+	return funcapi.FunctionConfig{
+		ID:          integrityChangesMethodID,
+		Name:        "File Integrity Changes",
+		UpdateEvery: 10,
+		Help:        integrityChangesHelp,
+	}
+}
+
+// Compile-time interface check.
+var _ funcapi.MethodHandler = (*funcIntegrityChanges)(nil)
+
+// funcIntegrityChanges handles the "integrity-changes" function.
+type funcIntegrityChanges struct {
+	router *funcRouter
+}
+
+func newFuncIntegrityChanges(r *funcRouter) *funcIntegrityChanges {
+	return &funcIntegrityChanges{router: r}
+}
+
+// MethodParams implements funcapi.MethodHandler.
+func (f *funcIntegrityChanges) MethodParams(_ context.Context, method string) ([]funcapi.ParamConfig, error) {
+	if method != integrityChangesMethodID {
+		return nil, fmt.Errorf("unknown method: %s", method)
+	}
+	return nil, nil
+}
+
+// Handle implements funcapi.MethodHandler.
+func (f *funcIntegrityChanges) Handle(_ context.Context, method string, _ funcapi.ResolvedParams) *funcapi.FunctionResponse {
+	if method != integrityChangesMethodID {
+		return funcapi.NotFoundResponse(method)
+	}
+
+	c := f.router.collector
+	if c.integrity == nil {
+		return funcapi.UnavailableResponse("file integrity monitoring is disabled, enable it with 'integrity->enabled'")
+	}
+
+	changes := c.integrity.changedFiles()
+
+	cs := integrityColumnSet(integrityColumns)
+	data := make([][]any, 0, len(changes))
+	for _, ch := range changes {
+		row := make([]any, len(integrityColumns))
+		for i, col := range integrityColumns {
+			row[i] = col.Value(ch)
+		}
+		data = append(data, row)
+	}
+
+	return &funcapi.FunctionResponse{
+		Status:            200,
+		Help:              integrityChangesHelp,
+		Columns:           cs.BuildColumns(),
+		Data:              data,
+		DefaultSortColumn: "Detected",
+	}
+}
+
+// Cleanup implements funcapi.MethodHandler.
+func (f *funcIntegrityChanges) Cleanup(context.Context) {}
+
+type integrityColumn struct {
+	funcapi.ColumnMeta
+	Value func(integrityChange) any
+}
+
+func integrityColumnSet(cols []integrityColumn) funcapi.ColumnSet[integrityColumn] {
+	return funcapi.Columns(cols, func(c integrityColumn) funcapi.ColumnMeta { return c.ColumnMeta })
+}
+
+var integrityColumns = []integrityColumn{
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Path", Tooltip: "File path", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true, Sticky: true, UniqueKey: true}, Value: func(ch integrityChange) any { return ch.path }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Status", Tooltip: "Changed, missing or reverted", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true, Visualization: funcapi.FieldVisualPill}, Value: func(ch integrityChange) any { return ch.status }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Changes", Tooltip: "Changed attributes, or missing if the file was removed", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true}, Value: func(ch integrityChange) any { return ch.changes.String() }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Times Changed", Tooltip: "Number of times a difference from the baseline was detected since the collector started", Type: funcapi.FieldTypeInteger, Visible: true, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummarySum, Filter: funcapi.FieldFilterRange, Sortable: true}, Value: func(ch integrityChange) any { return ch.count }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Detected", Tooltip: "When the difference from the baseline was first detected (for reverted files, the last difference)", Type: funcapi.FieldTypeTimestamp, Visible: true, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummaryMax, Filter: funcapi.FieldFilterRange, Sortable: true, Transform: funcapi.FieldTransformDatetime}, Value: func(ch integrityChange) any { return timeMilliCell(ch.detectedAt) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Baseline SHA256", Tooltip: "Content hash in the baseline", Type: funcapi.FieldTypeString, Visible: false, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterNone, Sortable: false}, Value: func(ch integrityChange) any { return ch.old.SHA256 }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Current SHA256", Tooltip: "Current content hash", Type: funcapi.FieldTypeString, Visible: false, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterNone, Sortable: false}, Value: func(ch integrityChange) any { return ch.new.SHA256 }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Size", Tooltip: "Baseline → current size", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterNone, Sortable: false}, Value: func(ch integrityChange) any {
+		return attrDiff(ch, func(a fileAttrs) string { return fmt.Sprintf("%d", a.Size) })
+	}},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Mode", Tooltip: "Baseline → current permissions", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterNone, Sortable: false}, Value: func(ch integrityChange) any {
+		return attrDiff(ch, func(a fileAttrs) string { return fs.FileMode(a.Mode).String() })
+	}},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Owner", Tooltip: "Baseline → current uid:gid", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterNone, Sortable: false}, Value: func(ch integrityChange) any {
+		return attrDiff(ch, func(a fileAttrs) string { return fmt.Sprintf("%d:%d", a.UID, a.GID) })
+	}},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Modified", Tooltip: "Baseline → current modification time", Type: funcapi.FieldTypeString, Visible: false, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterNone, Sortable: false}, Value: func(ch integrityChange) any {
+		return attrDiff(ch, func(a fileAttrs) string { return time.Unix(0, a.MTime).UTC().Format(time.RFC3339) })
+	}},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Xattrs", Tooltip: "Extended attributes added, removed or modified since the baseline", Type: funcapi.FieldTypeString, Visible: false, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterNone, Sortable: false}, Value: func(ch integrityChange) any { return xattrsDiff(ch.old.Xattrs, ch.new.Xattrs) }},
+}
+
+// attrDiff formats an attribute as "baseline → current", or only the baseline value if it did not change.
+func attrDiff(ch integrityChange, format func(fileAttrs) string) string {
+	old := format(ch.old)
+	if ch.status == "missing" {
+		return old
+	}
+	if cur := format(ch.new); cur != old {
+		return old + " → " + cur
+	}
+	return old
+}
+
+func xattrsDiff(old, cur map[string]string) string {
+	var parts []string
+	for name, v := range cur {
+		if ov, ok := old[name]; !ok {
+			parts = append(parts, "+"+name)
+		} else if ov != v {
+			parts = append(parts, "~"+name)
+		}
+	}
+	for name := range old {
+		if _, ok := cur[name]; !ok {
+			parts = append(parts, "-"+name)
+		}
+	}
+	slices.Sort(parts)
+	return strings.Join(parts, ", ")
+}
+
+func timeMilliCell(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UnixMilli()
+}
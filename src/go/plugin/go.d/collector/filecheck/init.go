@@ -12,6 +12,9 @@ func (c *Collector) validateConfig() error {
 	if len(c.Files.Include) == 0 && len(c.Dirs.Include) == 0 {
 		return errors.New("both 'files->include' and 'dirs->include' are empty")
 	}
+	if c.Integrity.Enabled && len(c.Files.Include) == 0 {
+		return errors.New("'integrity' is enabled but 'files->include' is empty")
+	}
 	return nil
 }
 
@@ -23,6 +26,25 @@ func (c *Collector) initDirsFilter() (matcher.Matcher, error) {
 	return newFilter(c.Dirs.Exclude)
 }
 
+func (c *Collector) initIntegrityMonitor() (*integrityMonitor, error) {
+	path := c.Integrity.BaselineFile
+	if path == "" {
+		path = defaultBaselinePath(c.Files.Include)
+	}
+	if path == "" {
+		c.Warning("integrity: Netdata lib dir is not set, the baseline is kept in memory only")
+	} else {
+		c.Debugf("integrity: baseline file '%s'", path)
+	}
+
+	m := newIntegrityMonitor(c.Integrity, path)
+	if err := m.loadBaseline(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
 func newFilter(patterns []string) (matcher.Matcher, error) {
 	filter := matcher.FALSE()
 
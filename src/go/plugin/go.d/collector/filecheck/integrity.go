@@ -0,0 +1,381 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package filecheck
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/framework/filepersister"
+)
+
+const defaultMaxHashFileSize = 100 << 20 // 100 MiB
+
+// fileAttrs is the set of file attributes tracked by integrity monitoring.
+type fileAttrs struct {
+	Size   int64             `json:"size"`
+	Mode   uint32            `json:"mode"`
+	UID    int64             `json:"uid"`
+	GID    int64             `json:"gid"`
+	MTime  int64             `json:"mtime"`
+	SHA256 string            `json:"sha256,omitempty"`
+	Xattrs map[string]string `json:"xattrs,omitempty"` // xattr name => sha256 of the value
+
+	// not persisted, used to avoid rehashing unchanged files
+	ctime int64
+	inode uint64
+}
+
+type integrityChanges struct {
+	missing bool
+	content bool
+	owner   bool
+	mode    bool
+	xattrs  bool
+}
+
+func (ch integrityChanges) any() bool {
+	return ch.missing || ch.content || ch.owner || ch.mode || ch.xattrs
+}
+
+func (ch integrityChanges) String() string {
+	var parts []string
+	if ch.missing {
+		parts = append(parts, "missing")
+	}
+	if ch.content {
+		parts = append(parts, "content")
+	}
+	if ch.owner {
+		parts = append(parts, "owner")
+	}
+	if ch.mode {
+		parts = append(parts, "mode")
+	}
+	if ch.xattrs {
+		parts = append(parts, "xattrs")
+	}
+	return strings.Join(parts, ", ")
+}
+
+func compareFileAttrs(base, cur fileAttrs) integrityChanges {
+	var ch integrityChanges
+
+	if base.SHA256 != "" && cur.SHA256 != "" {
+		ch.content = base.SHA256 != cur.SHA256
+	} else {
+		// content was not hashed (disabled or the file is too large): fall back to size and mtime
+		ch.content = base.Size != cur.Size || base.MTime != cur.MTime
+	}
+	ch.owner = base.UID != cur.UID || base.GID != cur.GID
+	ch.mode = base.Mode != cur.Mode
+	ch.xattrs = !maps.Equal(base.Xattrs, cur.Xattrs)
+
+	return ch
+}
+
+type (
+	// integrityMonitor compares the monitored files against a persisted baseline.
+	// It is used by the collection loop, the real-time watcher and the function handler.
+	integrityMonitor struct {
+		hashContent     bool
+		maxHashFileSize int64
+		baselinePath    string
+
+		mu              sync.Mutex
+		baseline        map[string]fileAttrs
+		baselineDirty   bool
+		files           map[string]*integrityFile
+		changesDetected int64 // differences from the baseline detected since start, in all files
+	}
+	integrityFile struct {
+		exists     bool
+		attrs      fileAttrs
+		changes    integrityChanges
+		detectedAt time.Time // when the current difference from the baseline was first detected
+		checkedAt  time.Time
+
+		// Kept after the file matches the baseline again, so that reverted changes are still reported.
+		changeCount  int64
+		lastChange   integrityChanges
+		lastChangeAt time.Time
+	}
+)
+
+func newIntegrityMonitor(cfg integrityConfig, baselinePath string) *integrityMonitor {
+	maxSize := cfg.MaxHashFileSize
+	if maxSize <= 0 {
+		maxSize = defaultMaxHashFileSize
+	}
+	return &integrityMonitor{
+		hashContent:     cfg.HashContent,
+		maxHashFileSize: maxSize,
+		baselinePath:    baselinePath,
+		baseline:        make(map[string]fileAttrs),
+		files:           make(map[string]*integrityFile),
+	}
+}
+
+// loadBaseline reads the persisted baseline. A missing file is not an error: the baseline is created
+// from the first observation of every monitored file.
+func (m *integrityMonitor) loadBaseline() error {
+	if m.baselinePath == "" {
+		return nil
+	}
+
+	bs, err := os.ReadFile(m.baselinePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var state integrityBaselineState
+	if err := json.Unmarshal(bs, &state); err != nil {
+		return fmt.Errorf("parse baseline '%s': %v", m.baselinePath, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for path, attrs := range state.Files {
+		m.baseline[path] = attrs
+	}
+
+	return nil
+}
+
+func (m *integrityMonitor) saveBaseline() {
+	m.mu.Lock()
+	if !m.baselineDirty {
+		m.mu.Unlock()
+		return
+	}
+	m.baselineDirty = false
+	state := integrityBaselineState{Version: 1, Files: maps.Clone(m.baseline)}
+	m.mu.Unlock()
+
+	filepersister.Save(m.baselinePath, state)
+}
+
+// check reads the current attributes of the file and compares them with the baseline.
+func (m *integrityMonitor) check(path string, now time.Time) {
+	fi, err := os.Lstat(path)
+
+	m.mu.Lock()
+	prev := m.files[path]
+	m.mu.Unlock()
+
+	if err != nil || !fi.Mode().IsRegular() {
+		m.update(path, nil, now)
+		return
+	}
+
+	attrs := statFileAttrs(fi)
+	attrs.Xattrs = readXattrs(path)
+
+	if m.hashContent && fi.Size() <= m.maxHashFileSize {
+		if prev != nil && prev.exists && prev.attrs.SHA256 != "" && sameFileVersion(prev.attrs, attrs) {
+			attrs.SHA256 = prev.attrs.SHA256
+		} else if sum, err := hashFile(path); err == nil {
+			attrs.SHA256 = sum
+		}
+	}
+
+	m.update(path, &attrs, now)
+}
+
+func (m *integrityMonitor) update(path string, attrs *fileAttrs, now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, ok := m.files[path]
+	if !ok {
+		f = &integrityFile{}
+		m.files[path] = f
+	}
+	f.checkedAt = now
+
+	if attrs == nil {
+		f.exists = false
+		f.changes = integrityChanges{missing: true}
+		if _, ok := m.baseline[path]; ok && f.detectedAt.IsZero() {
+			f.detectedAt = now
+			m.recordChangeLocked(f, now)
+		}
+		return
+	}
+
+	f.exists = true
+	f.attrs = *attrs
+
+	base, ok := m.baseline[path]
+	if !ok {
+		m.baseline[path] = *attrs
+		m.baselineDirty = true
+		base = *attrs
+	}
+
+	f.changes = compareFileAttrs(base, *attrs)
+	switch {
+	case !f.changes.any():
+		f.detectedAt = time.Time{}
+	case f.detectedAt.IsZero():
+		f.detectedAt = now
+		m.recordChangeLocked(f, now)
+	default:
+		f.lastChange = f.changes
+	}
+}
+
+// recordChangeLocked counts a new difference of the file from the baseline.
+func (m *integrityMonitor) recordChangeLocked(f *integrityFile, now time.Time) {
+	m.changesDetected++
+	f.changeCount++
+	f.lastChange = f.changes
+	f.lastChangeAt = now
+}
+
+func (m *integrityMonitor) changesDetectedTotal() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.changesDetected
+}
+
+// forget drops the runtime state of files that are no longer monitored; their baseline is kept.
+func (m *integrityMonitor) forget(keep func(path string) bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	maps.DeleteFunc(m.files, func(path string, _ *integrityFile) bool { return !keep(path) })
+}
+
+func (m *integrityMonitor) status(path string) (integrityFile, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, ok := m.files[path]
+	if !ok {
+		return integrityFile{}, false
+	}
+	return *f, true
+}
+
+// baselinePaths returns the paths of all files in the baseline, sorted.
+func (m *integrityMonitor) baselinePaths() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Sorted(maps.Keys(m.baseline))
+}
+
+func (m *integrityMonitor) baselineAttrs(path string) (fileAttrs, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	attrs, ok := m.baseline[path]
+	return attrs, ok
+}
+
+type integrityChange struct {
+	path       string
+	status     string // "changed", "missing" or "reverted"
+	changes    integrityChanges
+	old        fileAttrs
+	new        fileAttrs
+	detectedAt time.Time
+	count      int64
+}
+
+// changedFiles returns the monitored files that differ from the baseline, or did since the start
+// and were reverted, sorted by path.
+func (m *integrityMonitor) changedFiles() []integrityChange {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var out []integrityChange
+	for _, path := range slices.Sorted(maps.Keys(m.files)) {
+		f := m.files[path]
+		base, ok := m.baseline[path]
+		if !ok {
+			continue
+		}
+		switch {
+		case !f.exists:
+			out = append(out, integrityChange{path: path, status: "missing", old: base, detectedAt: f.detectedAt, count: f.changeCount})
+		case f.changes.any():
+			out = append(out, integrityChange{path: path, status: "changed", changes: f.changes, old: base, new: f.attrs, detectedAt: f.detectedAt, count: f.changeCount})
+		case f.changeCount > 0:
+			// the last change is reported, the current attributes match the baseline
+			out = append(out, integrityChange{path: path, status: "reverted", changes: f.lastChange, old: base, new: f.attrs, detectedAt: f.lastChangeAt, count: f.changeCount})
+		}
+	}
+	return out
+}
+
+type integrityBaselineState struct {
+	Version int                  `json:"version"`
+	Files   map[string]fileAttrs `json:"files"`
+}
+
+func (s integrityBaselineState) Bytes() ([]byte, error) {
+	return json.MarshalIndent(s, "", " ")
+}
+
+// sameFileVersion reports whether the file was not modified since its attributes were read
+// (the content hash can be reused).
+func sameFileVersion(a, b fileAttrs) bool {
+	return a.Size == b.Size && a.MTime == b.MTime && a.ctime == b.ctime && a.inode == b.inode
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashBytes(bs []byte) string {
+	sum := sha256.Sum256(bs)
+	return hex.EncodeToString(sum[:])
+}
+
+func statFileAttrs(fi fs.FileInfo) fileAttrs {
+	attrs := fileAttrs{
+		Size:  fi.Size(),
+		Mode:  uint32(fi.Mode()),
+		UID:   -1,
+		GID:   -1,
+		MTime: fi.ModTime().UnixNano(),
+	}
+	fillOwnerAttrs(&attrs, fi)
+	return attrs
+}
+
+// defaultBaselinePath returns the baseline file path in the Netdata lib dir. The name is derived from
+// the include patterns so that jobs monitoring different files do not share a baseline.
+func defaultBaselinePath(include []string) string {
+	patterns := slices.Clone(include)
+	slices.Sort(patterns)
+	id := hashBytes([]byte(strings.Join(patterns, "\x00")))[:16]
+	return filepersister.StatePath("filecheck-integrity-" + id + ".json")
+}
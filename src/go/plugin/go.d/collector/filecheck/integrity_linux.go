@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build linux
+
+package filecheck
+
+import (
+	"io/fs"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+func fillOwnerAttrs(attrs *fileAttrs, fi fs.FileInfo) {
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return
+	}
+	attrs.UID = int64(st.Uid)
+	attrs.GID = int64(st.Gid)
+	attrs.inode = st.Ino
+	attrs.ctime = st.Ctim.Nano()
+}
+
+// readXattrs returns the extended attributes of the file (not following symlinks) as name => sha256 of the value.
+func readXattrs(path string) map[string]string {
+	size, err := unix.Llistxattr(path, nil)
+	if err != nil || size <= 0 {
+		return nil
+	}
+	buf := make([]byte, size)
+	if size, err = unix.Llistxattr(path, buf); err != nil {
+		return nil
+	}
+
+	xattrs := make(map[string]string)
+	for _, name := range strings.Split(string(buf[:size]), "\x00") {
+		if name == "" {
+			continue
+		}
+		vsize, err := unix.Lgetxattr(path, name, nil)
+		if err != nil {
+			continue
+		}
+		val := make([]byte, max(vsize, 0))
+		if vsize > 0 {
+			if vsize, err = unix.Lgetxattr(path, name, val); err != nil {
+				continue
+			}
+		}
+		xattrs[name] = hashBytes(val[:vsize])
+	}
+	if len(xattrs) == 0 {
+		return nil
+	}
+	return xattrs
+}
@@ -0,0 +1,12 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build !unix
+
+package filecheck
+
+import "io/fs"
+
+// File ownership and extended attributes are not tracked on this platform.
+func fillOwnerAttrs(*fileAttrs, fs.FileInfo) {}
+
+func readXattrs(string) map[string]string { return nil }
@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build unix && !linux
+
+package filecheck
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+func fillOwnerAttrs(attrs *fileAttrs, fi fs.FileInfo) {
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return
+	}
+	attrs.UID = int64(st.Uid)
+	attrs.GID = int64(st.Gid)
+	attrs.inode = uint64(st.Ino)
+}
+
+// readXattrs is only implemented on Linux.
+func readXattrs(string) map[string]string { return nil }
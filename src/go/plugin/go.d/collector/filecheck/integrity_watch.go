@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package filecheck
+
+import (
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/netdata/netdata/go/plugins/logger"
+)
+
+// integrityWatchDelay is how long a file is re-checked after the first event of a change,
+// so that a write reported as many events is hashed once.
+const integrityWatchDelay = 100 * time.Millisecond
+
+// integrityWatcher re-checks monitored files as soon as the kernel reports a change (inotify on Linux),
+// so that changes are detected between collections, including short-lived ones.
+// Parent directories are watched instead of the files themselves to survive atomic replacements (rename over).
+type integrityWatcher struct {
+	*logger.Logger
+
+	monitor *integrityMonitor
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+
+	mu      sync.Mutex
+	files   map[string]bool
+	dirs    map[string]bool
+	pending map[string]*time.Timer // scheduled re-checks, per path
+}
+
+func newIntegrityWatcher(monitor *integrityMonitor, l *logger.Logger) (*integrityWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &integrityWatcher{
+		Logger:  l,
+		monitor: monitor,
+		watcher: watcher,
+		done:    make(chan struct{}),
+		files:   make(map[string]bool),
+		dirs:    make(map[string]bool),
+		pending: make(map[string]*time.Timer),
+	}
+
+	go w.run()
+
+	return w, nil
+}
+
+func (w *integrityWatcher) run() {
+	defer close(w.done)
+
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			w.schedule(filepath.Clean(event.Name))
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			w.Warningf("integrity watcher: %v", err)
+		}
+	}
+}
+
+// schedule re-checks a monitored file after integrityWatchDelay. Events received in the meantime are
+// covered by the scheduled check; the timer is not extended, so a file written continuously is still checked.
+func (w *integrityWatcher) schedule(path string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.files[path] || w.pending[path] != nil {
+		return
+	}
+	w.pending[path] = time.AfterFunc(integrityWatchDelay, func() {
+		w.mu.Lock()
+		delete(w.pending, path)
+		w.mu.Unlock()
+
+		w.monitor.check(path, time.Now())
+	})
+}
+
+// setFiles updates the set of watched files after a discovery.
+func (w *integrityWatcher) setFiles(paths []string) {
+	files := make(map[string]bool, len(paths))
+	dirs := make(map[string]bool)
+	for _, p := range paths {
+		p = filepath.Clean(p)
+		files[p] = true
+		dirs[filepath.Dir(p)] = true
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.files = files
+
+	for dir := range w.dirs {
+		if !dirs[dir] {
+			_ = w.watcher.Remove(dir)
+			delete(w.dirs, dir)
+		}
+	}
+	for dir := range dirs {
+		if w.dirs[dir] {
+			continue
+		}
+		if err := w.watcher.Add(dir); err != nil {
+			// the directory may not exist yet, retried after the next discovery
+			w.Debugf("integrity watcher: watch '%s': %v", dir, err)
+			continue
+		}
+		w.dirs[dir] = true
+	}
+}
+
+func (w *integrityWatcher) stop() {
+	_ = w.watcher.Close()
+	<-w.done
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for path, timer := range w.pending {
+		timer.Stop()
+		delete(w.pending, path)
+	}
+}
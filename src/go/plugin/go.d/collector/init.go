@@ -30,6 +30,7 @@ import (
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/coredns"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/couchbase"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/couchdb"
+	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/cron"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/dcgm"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/dmcache"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/dnsdist"
@@ -59,6 +60,7 @@ import (
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/intelgpu"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/ipfs"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/isc_dhcpd"
+	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/jolokia"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/k8s_apiserver"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/k8s_kubelet"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/k8s_kubeproxy"
@@ -120,6 +122,7 @@ import (
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/squidlog"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/storcli"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/supervisord"
+	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/systemdjournal"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/systemdunits"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/tengine"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/testrandom"
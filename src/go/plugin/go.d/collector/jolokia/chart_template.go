@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package jolokia
+
+import (
+	"fmt"
+
+	"github.com/netdata/netdata/go/plugins/plugin/framework/charttpl"
+)
+
+// chartExpireAfterCycles is how many successful cycles an autogen chart or
+// dimension survives without its series being seen (e.g. a removed memory pool).
+const chartExpireAfterCycles = 10
+
+// buildChartTemplate returns the per-job chart template: the "jolokia" context
+// namespace with autogen enabled, so custom MBean metrics are charted one chart
+// per metric, plus the curated groups of the selected profiles.
+func buildChartTemplate(profiles []profile) (string, error) {
+	spec := charttpl.Spec{
+		Version:          charttpl.VersionV1,
+		ContextNamespace: "jolokia",
+		Engine: &charttpl.Engine{
+			Autogen: &charttpl.EngineAutogen{
+				Enabled:                  true,
+				ExpireAfterSuccessCycles: chartExpireAfterCycles,
+			},
+		},
+		Groups: []charttpl.Group{{Family: customMetricsFamily}},
+	}
+	for _, p := range profiles {
+		spec.Groups = append(spec.Groups, p.Template.Clone())
+	}
+
+	raw, err := spec.MarshalTemplate()
+	if err != nil {
+		return "", fmt.Errorf("build jolokia chart template: %w", err)
+	}
+	return raw, nil
+}
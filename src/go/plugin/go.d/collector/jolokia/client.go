@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package jolokia
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/netdata/netdata/go/plugins/pkg/web"
+)
+
+// Jolokia bulk requests are a JSON array POSTed to the agent URL; the response
+// is an array of the same length, one entry per request, in order.
+// https://jolokia.org/reference/html/manual/jolokia_protocol.html
+
+type jolokiaRequest struct {
+	Type      string         `json:"type"`
+	MBean     string         `json:"mbean"`
+	Attribute []string       `json:"attribute,omitempty"`
+	Config    map[string]any `json:"config,omitempty"`
+}
+
+type jolokiaResponse struct {
+	Status int             `json:"status"`
+	Value  json.RawMessage `json:"value"`
+	Error  string          `json:"error"`
+}
+
+func (c *Collector) doBulk(reqs []jolokiaRequest) ([]jolokiaResponse, error) {
+	body, err := json.Marshal(reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := c.RequestConfig.Copy()
+	cfg.Method = http.MethodPost
+	cfg.Body = string(body)
+	req, err := web.NewHTTPRequest(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var resps []jolokiaResponse
+	if err := web.DoHTTP(c.httpClient).RequestJSON(req, &resps); err != nil {
+		return nil, err
+	}
+	if len(resps) != len(reqs) {
+		return nil, fmt.Errorf("jolokia returned %d responses for %d requests", len(resps), len(reqs))
+	}
+	return resps, nil
+}
+
+// search returns, for every pattern, whether at least one MBean matches it.
+func (c *Collector) search(patterns []string) ([]bool, error) {
+	reqs := make([]jolokiaRequest, 0, len(patterns))
+	for _, p := range patterns {
+		reqs = append(reqs, jolokiaRequest{Type: "search", MBean: p})
+	}
+	resps, err := c.doBulk(reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make([]bool, len(resps))
+	for i, resp := range resps {
+		if resp.Status != http.StatusOK {
+			c.Debugf("search '%s': status %d: %s", patterns[i], resp.Status, resp.Error)
+			continue
+		}
+		var names []string
+		if err := json.Unmarshal(resp.Value, &names); err != nil {
+			return nil, fmt.Errorf("search '%s': %v", patterns[i], err)
+		}
+		found[i] = len(names) > 0
+	}
+	return found, nil
+}
+
+// read reads the attributes of every query and returns the values keyed by the
+// full object name of each matching MBean, then by attribute name.
+func (c *Collector) read(queries []mbeanQuery) ([]map[string]map[string]any, error) {
+	reqs := make([]jolokiaRequest, 0, len(queries))
+	for _, q := range queries {
+		reqs = append(reqs, jolokiaRequest{
+			Type:      "read",
+			MBean:     q.mbean,
+			Attribute: q.readAttrs,
+			// Skip unreadable attributes instead of failing the whole read.
+			Config: map[string]any{"ignoreErrors": true},
+		})
+	}
+	resps, err := c.doBulk(reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]map[string]map[string]any, len(resps))
+	for i, resp := range resps {
+		q := queries[i]
+		if resp.Status != http.StatusOK {
+			c.Debugf("read '%s': status %d: %s", q.mbean, resp.Status, resp.Error)
+			continue
+		}
+		if q.pattern {
+			var v map[string]map[string]any
+			if err := json.Unmarshal(resp.Value, &v); err != nil {
+				return nil, fmt.Errorf("read '%s': %v", q.mbean, err)
+			}
+			out[i] = v
+		} else {
+			var v map[string]any
+			if err := json.Unmarshal(resp.Value, &v); err != nil {
+				return nil, fmt.Errorf("read '%s': %v", q.mbean, err)
+			}
+			out[i] = map[string]map[string]any{q.mbean: v}
+		}
+	}
+	return out, nil
+}
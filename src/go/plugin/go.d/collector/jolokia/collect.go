@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package jolokia
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/pkg/metrix"
+)
+
+const customMetricsFamily = "Custom"
+
+// check selects the profiles, verifies that the agent returns at least one
+// metric for them and builds the per-job chart template.
+func (c *Collector) check() error {
+	profiles, err := c.selectProfiles()
+	if err != nil {
+		return err
+	}
+
+	rt := &jolokiaRuntime{profiles: profiles}
+	for _, p := range profiles {
+		queries, err := newMBeanQueries(p.MBeans)
+		if err != nil {
+			return fmt.Errorf("profile '%s': %v", p.Name, err)
+		}
+		rt.queries = append(rt.queries, queries...)
+	}
+	rt.queries = append(rt.queries, c.customQueries...)
+	if len(rt.queries) == 0 {
+		return errors.New("no profile matches the agent MBeans and no custom 'mbeans' are configured")
+	}
+
+	values, err := c.read(rt.queries)
+	if err != nil {
+		return err
+	}
+	if n := len(buildSeries(rt.queries, values)); n == 0 {
+		return fmt.Errorf("'%s' returned no values for the configured MBean attributes", c.URL)
+	}
+
+	rt.chartTemplate, err = buildChartTemplate(profiles)
+	if err != nil {
+		return err
+	}
+	for _, p := range profiles {
+		c.Debugf("using profile '%s'", p.Name)
+	}
+	c.runtime = rt
+	return nil
+}
+
+func (c *Collector) selectProfiles() ([]profile, error) {
+	catalog, err := c.loadProfileCatalog()
+	if err != nil {
+		return nil, fmt.Errorf("load profiles: %v", err)
+	}
+	if len(c.Profiles) > 0 {
+		return catalog.resolve(c.Profiles)
+	}
+
+	all := catalog.all()
+	if len(all) == 0 {
+		return nil, nil
+	}
+	patterns := make([]string, 0, len(all))
+	for _, p := range all {
+		patterns = append(patterns, p.Match)
+	}
+	found, err := c.search(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []profile
+	for i, p := range all {
+		if found[i] {
+			selected = append(selected, p)
+		}
+	}
+	return selected, nil
+}
+
+func (c *Collector) collect() error {
+	rt := c.runtime
+	if rt == nil {
+		return errors.New("jolokia collector runtime is unavailable: successful Check is required before Collect")
+	}
+
+	values, err := c.read(rt.queries)
+	if err != nil {
+		return err
+	}
+
+	sm := c.store.Write().SnapshotMeter("")
+	for _, s := range buildSeries(rt.queries, values) {
+		meter := sm.WithLabels(s.labels...)
+		opts := []metrix.InstrumentOption{metrix.WithFloat(true)}
+		if s.custom {
+			opts = append(opts, metrix.WithChartFamily(customMetricsFamily))
+		}
+		if s.counter {
+			meter.Counter(s.name, opts...).ObserveTotal(s.value)
+		} else {
+			meter.Gauge(s.name, opts...).Observe(s.value)
+		}
+	}
+	return nil
+}
+
+type series struct {
+	name    string
+	labels  []metrix.Label
+	counter bool
+	custom  bool
+	value   float64
+}
+
+// buildSeries turns read results into series. values[i] holds the result of
+// queries[i], keyed by object name and attribute.
+func buildSeries(queries []mbeanQuery, values []map[string]map[string]any) []series {
+	var out []series
+	for i, q := range queries {
+		for name, attrs := range values[i] {
+			on, err := parseObjectName(name)
+			if err != nil {
+				continue
+			}
+			labels := make([]metrix.Label, 0, len(q.labels))
+			for _, lbl := range q.labels {
+				if v, ok := on.propertyValue(lbl.key); ok {
+					labels = append(labels, metrix.Label{Key: lbl.name, Value: v})
+				}
+			}
+			for _, attr := range q.attributes {
+				raw, ok := attrs[attr.Attribute]
+				if !ok {
+					continue
+				}
+				v, ok := attributeValue(raw, attr.Path)
+				if !ok {
+					continue
+				}
+				out = append(out, series{
+					name:    attr.Metric,
+					labels:  labels,
+					counter: attr.Type == metricTypeCounter,
+					custom:  q.custom,
+					value:   v,
+				})
+			}
+		}
+	}
+	return out
+}
+
+// attributeValue walks path ('/'-separated keys or list indexes) into a
+// CompositeData, Map or array attribute value and converts the leaf to a number.
+func attributeValue(v any, path string) (float64, bool) {
+	if path != "" {
+		for _, seg := range strings.Split(path, "/") {
+			switch node := v.(type) {
+			case map[string]any:
+				next, ok := node[seg]
+				if !ok {
+					return 0, false
+				}
+				v = next
+			case []any:
+				idx, err := strconv.Atoi(seg)
+				if err != nil || idx < 0 || idx >= len(node) {
+					return 0, false
+				}
+				v = node[idx]
+			default:
+				return 0, false
+			}
+		}
+	}
+
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
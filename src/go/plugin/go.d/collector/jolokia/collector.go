@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package jolokia
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/pkg/confopt"
+	"github.com/netdata/netdata/go/plugins/pkg/metrix"
+	"github.com/netdata/netdata/go/plugins/pkg/web"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+)
+
+//go:embed "config_schema.json"
+var configSchema string
+
+func init() {
+	collectorapi.Register("jolokia", collectorapi.Creator{
+		JobConfigSchema: configSchema,
+		Defaults: collectorapi.Defaults{
+			UpdateEvery: 5,
+		},
+		CreateV2: func() collectorapi.CollectorV2 { return New() },
+		Config:   func() any { return &Config{} },
+	})
+}
+
+func New() *Collector {
+	return &Collector{
+		Config: Config{
+			HTTPConfig: web.HTTPConfig{
+				RequestConfig: web.RequestConfig{
+					URL: "http://127.0.0.1:8778/jolokia",
+				},
+				ClientConfig: web.ClientConfig{
+					Timeout: confopt.Duration(time.Second * 5),
+				},
+			},
+		},
+		store:              metrix.NewCollectorStore(),
+		loadProfileCatalog: loadDefaultProfileCatalog,
+	}
+}
+
+type Config struct {
+	Vnode              string `yaml:"vnode,omitempty" json:"vnode"`
+	UpdateEvery        int    `yaml:"update_every,omitempty" json:"update_every"`
+	AutoDetectionRetry int    `yaml:"autodetection_retry,omitempty" json:"autodetection_retry"`
+	web.HTTPConfig     `yaml:",inline" json:""`
+	// Profiles lists the profiles to use by name. When empty, every profile whose
+	// match pattern finds an MBean is used.
+	Profiles []string `yaml:"profiles,omitempty" json:"profiles"`
+	// MBeans are custom MBean mappings collected in addition to the profiles.
+	MBeans []MBeanConfig `yaml:"mbeans,omitempty" json:"mbeans"`
+}
+
+type Collector struct {
+	collectorapi.Base
+	Config `yaml:",inline" json:""`
+
+	httpClient *http.Client
+	store      metrix.CollectorStore
+
+	customQueries []mbeanQuery
+	runtime       *jolokiaRuntime
+
+	// loadProfileCatalog resolves the profile catalog; a field so tests inject a fake.
+	loadProfileCatalog func() (profileCatalog, error)
+}
+
+// jolokiaRuntime is built by Check: the selected profiles, the read queries and
+// the chart template derived from them.
+type jolokiaRuntime struct {
+	profiles      []profile
+	queries       []mbeanQuery
+	chartTemplate string
+}
+
+func (c *Collector) Configuration() any {
+	return c.Config
+}
+
+func (c *Collector) Init(context.Context) error {
+	if err := c.validateConfig(); err != nil {
+		return fmt.Errorf("config validation: %v", err)
+	}
+
+	queries, err := newMBeanQueries(c.MBeans)
+	if err != nil {
+		return fmt.Errorf("config validation: %v", err)
+	}
+	for i := range queries {
+		queries[i].custom = true
+	}
+	c.customQueries = queries
+
+	httpClient, err := web.NewHTTPClient(c.ClientConfig)
+	if err != nil {
+		return fmt.Errorf("init HTTP client: %v", err)
+	}
+	c.httpClient = httpClient
+
+	c.Debugf("using URL %s", c.URL)
+	c.Debugf("using timeout: %s", c.Timeout)
+
+	return nil
+}
+
+func (c *Collector) Check(context.Context) error {
+	return c.check()
+}
+
+func (c *Collector) Collect(context.Context) error {
+	return c.collect()
+}
+
+func (c *Collector) Cleanup(context.Context) {
+	c.runtime = nil
+	if c.httpClient != nil {
+		c.httpClient.CloseIdleConnections()
+	}
+}
+
+func (c *Collector) MetricStore() metrix.CollectorStore {
+	return c.store
+}
+
+func (c *Collector) ChartTemplateYAML() string {
+	if c.runtime == nil {
+		return ""
+	}
+	return c.runtime.chartTemplate
+}
@@ -0,0 +1,437 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package jolokia
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netdata/netdata/go/plugins/pkg/metrix"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/chartengine"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/charttpl"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/collecttest"
+)
+
+var (
+	dataConfigJSON, _ = os.ReadFile("testdata/config.json")
+	dataConfigYAML, _ = os.ReadFile("testdata/config.yaml")
+
+	dataJVMMBeans, _ = os.ReadFile("testdata/jvm.json")
+)
+
+func Test_testDataIsValid(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"dataConfigJSON": dataConfigJSON,
+		"dataConfigYAML": dataConfigYAML,
+		"dataJVMMBeans":  dataJVMMBeans,
+	} {
+		require.NotNil(t, data, name)
+	}
+}
+
+func TestCollector_ConfigurationSerialize(t *testing.T) {
+	collecttest.TestConfigurationSerialize(t, &Collector{}, dataConfigJSON, dataConfigYAML)
+}
+
+func TestCollector_Interfaces(t *testing.T) {
+	assert.Implements(t, (*collectorapi.CollectorV2)(nil), New())
+}
+
+func TestCollector_Init(t *testing.T) {
+	tests := map[string]struct {
+		config   func(*Config)
+		wantFail bool
+	}{
+		"success with default config": {
+			config: func(*Config) {},
+		},
+		"success with custom mbeans": {
+			config: func(cfg *Config) {
+				cfg.MBeans = []MBeanConfig{hikariMBean()}
+			},
+		},
+		"fail when URL not set": {
+			wantFail: true,
+			config:   func(cfg *Config) { cfg.URL = "" },
+		},
+		"fail on empty profile name": {
+			wantFail: true,
+			config:   func(cfg *Config) { cfg.Profiles = []string{" "} },
+		},
+		"fail on invalid mbean": {
+			wantFail: true,
+			config: func(cfg *Config) {
+				m := hikariMBean()
+				m.MBean = "com.zaxxer.hikari"
+				cfg.MBeans = []MBeanConfig{m}
+			},
+		},
+		"fail on mbean without attributes": {
+			wantFail: true,
+			config: func(cfg *Config) {
+				m := hikariMBean()
+				m.Attributes = nil
+				cfg.MBeans = []MBeanConfig{m}
+			},
+		},
+		"fail on invalid metric name": {
+			wantFail: true,
+			config: func(cfg *Config) {
+				m := hikariMBean()
+				m.Attributes[0].Metric = "hikari.active"
+				cfg.MBeans = []MBeanConfig{m}
+			},
+		},
+		"fail on unknown metric type": {
+			wantFail: true,
+			config: func(cfg *Config) {
+				m := hikariMBean()
+				m.Attributes[0].Type = "histogram"
+				cfg.MBeans = []MBeanConfig{m}
+			},
+		},
+		"fail on label from missing key property": {
+			wantFail: true,
+			config: func(cfg *Config) {
+				m := hikariMBean()
+				m.Labels = map[string]string{"pool": "name"}
+				cfg.MBeans = []MBeanConfig{m}
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collr := New()
+			test.config(&collr.Config)
+
+			if test.wantFail {
+				assert.Error(t, collr.Init(context.Background()))
+			} else {
+				assert.NoError(t, collr.Init(context.Background()))
+			}
+		})
+	}
+}
+
+func TestCollector_Check(t *testing.T) {
+	tests := map[string]struct {
+		prepare      func(t *testing.T) (*Collector, func())
+		wantProfiles []string
+		wantFail     bool
+	}{
+		"auto-selects matching profiles": {
+			prepare:      caseJVM,
+			wantProfiles: []string{"jvm_gc", "jvm_memory", "jvm_threads"},
+		},
+		"explicit profiles": {
+			prepare: func(t *testing.T) (*Collector, func()) {
+				collr, cleanup := caseJVM(t)
+				collr.Profiles = []string{"jvm_threads"}
+				return collr, cleanup
+			},
+			wantProfiles: []string{"jvm_threads"},
+		},
+		"custom mbeans only": {
+			prepare: func(t *testing.T) (*Collector, func()) {
+				collr, cleanup := prepareCaseMBeans(t, `{"com.zaxxer.hikari:type=Pool (main)": {"ActiveConnections": 3}}`)
+				collr.MBeans = []MBeanConfig{hikariMBean()}
+				return collr, cleanup
+			},
+		},
+		"fail on unknown profile": {
+			wantFail: true,
+			prepare: func(t *testing.T) (*Collector, func()) {
+				collr, cleanup := caseJVM(t)
+				collr.Profiles = []string{"tomcat"}
+				return collr, cleanup
+			},
+		},
+		"fail when nothing matches": {
+			wantFail: true,
+			prepare: func(t *testing.T) (*Collector, func()) {
+				return prepareCaseMBeans(t, `{}`)
+			},
+		},
+		"fail on invalid response": {
+			wantFail: true,
+			prepare: func(t *testing.T) (*Collector, func()) {
+				srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					_, _ = w.Write([]byte("hello and\n goodbye"))
+				}))
+				collr := New()
+				collr.URL = srv.URL
+				return collr, srv.Close
+			},
+		},
+		"fail on connection refused": {
+			wantFail: true,
+			prepare: func(t *testing.T) (*Collector, func()) {
+				collr := New()
+				collr.URL = "http://127.0.0.1:65001/jolokia"
+				return collr, func() {}
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collr, cleanup := test.prepare(t)
+			defer cleanup()
+
+			require.NoError(t, collr.Init(context.Background()))
+
+			if test.wantFail {
+				assert.Error(t, collr.Check(context.Background()))
+				assert.Empty(t, collr.ChartTemplateYAML())
+				return
+			}
+			require.NoError(t, collr.Check(context.Background()))
+
+			var names []string
+			for _, p := range collr.runtime.profiles {
+				names = append(names, p.Name)
+			}
+			assert.Equal(t, test.wantProfiles, names)
+		})
+	}
+}
+
+func TestCollector_Collect(t *testing.T) {
+	collr, cleanup := caseJVM(t)
+	defer cleanup()
+	collr.MBeans = []MBeanConfig{hikariMBean()}
+
+	require.NoError(t, collr.Init(context.Background()))
+	require.NoError(t, collr.Check(context.Background()))
+
+	cc := mustCycleController(t, collr.MetricStore())
+	cc.BeginCycle()
+	require.NoError(t, collr.Collect(context.Background()))
+	cc.CommitCycleSuccess()
+
+	r := collr.MetricStore().Read(metrix.ReadRaw())
+
+	assertValue(t, r, "jvm_memory_heap_used", nil, 82837504)
+	assertValue(t, r, "jvm_memory_heap_committed", nil, 268435456)
+	assertValue(t, r, "jvm_memory_heap_max", nil, 4217372672)
+	assertValue(t, r, "jvm_memory_nonheap_used", nil, 56873456)
+	assertValue(t, r, "jvm_memory_objects_pending_finalization", nil, 0)
+	assertValue(t, r, "jvm_memory_pool_used", metrix.Labels{"pool": "G1 Eden Space"}, 50331648)
+	assertValue(t, r, "jvm_memory_pool_max", metrix.Labels{"pool": "G1 Old Gen"}, 4217372672)
+
+	assertValue(t, r, "jvm_gc_collections_total", metrix.Labels{"gc": "G1 Young Generation"}, 12)
+	assertValue(t, r, "jvm_gc_collection_time_ms_total", metrix.Labels{"gc": "G1 Young Generation"}, 85)
+	assertValue(t, r, "jvm_gc_collections_total", metrix.Labels{"gc": "G1 Old Generation"}, 0)
+
+	assertValue(t, r, "jvm_threads_live", nil, 27)
+	assertValue(t, r, "jvm_threads_daemon", nil, 21)
+	assertValue(t, r, "jvm_threads_peak", nil, 29)
+	assertValue(t, r, "jvm_threads_started_total", nil, 43)
+
+	assertValue(t, r, "hikari_connections_active", metrix.Labels{"type": "Pool (main)"}, 3)
+	assertValue(t, r, "hikari_connections_idle", metrix.Labels{"type": "Pool (main)"}, 7)
+
+	collecttest.AssertChartCoverage(t, collr, collecttest.ChartCoverageExpectation{})
+}
+
+func TestCollector_CollectRequiresCheck(t *testing.T) {
+	collr := New()
+	require.NoError(t, collr.Init(context.Background()))
+
+	assert.Error(t, collr.Collect(context.Background()))
+}
+
+func TestCollector_ChartTemplateYAML(t *testing.T) {
+	collr, cleanup := caseJVM(t)
+	defer cleanup()
+
+	assert.Empty(t, collr.ChartTemplateYAML())
+
+	require.NoError(t, collr.Init(context.Background()))
+	require.NoError(t, collr.Check(context.Background()))
+
+	templateYAML := collr.ChartTemplateYAML()
+	collecttest.AssertChartTemplateSchema(t, templateYAML)
+
+	spec, err := charttpl.DecodeYAML([]byte(templateYAML))
+	require.NoError(t, err)
+	require.NoError(t, spec.Validate())
+
+	_, err = chartengine.Compile(spec, 1)
+	require.NoError(t, err)
+}
+
+func TestCollector_Cleanup(t *testing.T) {
+	collr := New()
+	assert.NotPanics(t, func() { collr.Cleanup(context.Background()) })
+
+	collr, cleanup := caseJVM(t)
+	defer cleanup()
+
+	require.NoError(t, collr.Init(context.Background()))
+	require.NoError(t, collr.Check(context.Background()))
+	assert.NotPanics(t, func() { collr.Cleanup(context.Background()) })
+	assert.Empty(t, collr.ChartTemplateYAML())
+}
+
+func TestAttributeValue(t *testing.T) {
+	tests := map[string]struct {
+		value  any
+		path   string
+		want   float64
+		wantOK bool
+	}{
+		"number":            {value: 12.5, want: 12.5, wantOK: true},
+		"true":              {value: true, want: 1, wantOK: true},
+		"false":             {value: false, want: 0, wantOK: true},
+		"numeric string":    {value: "42", want: 42, wantOK: true},
+		"composite field":   {value: map[string]any{"used": 10.0}, path: "used", want: 10, wantOK: true},
+		"nested with index": {value: map[string]any{"a": []any{1.0, 2.0}}, path: "a/1", want: 2, wantOK: true},
+		"missing field":     {value: map[string]any{"used": 10.0}, path: "max"},
+		"bad index":         {value: []any{1.0}, path: "3"},
+		"text":              {value: "RUNNING"},
+		"composite no path": {value: map[string]any{"used": 10.0}},
+		"null":              {value: nil},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := attributeValue(test.value, test.path)
+			assert.Equal(t, test.wantOK, ok)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func hikariMBean() MBeanConfig {
+	return MBeanConfig{
+		MBean: "com.zaxxer.hikari:type=Pool (*)",
+		Attributes: []AttributeConfig{
+			{Attribute: "ActiveConnections", Metric: "hikari_connections_active"},
+			{Attribute: "IdleConnections", Metric: "hikari_connections_idle"},
+		},
+	}
+}
+
+func caseJVM(t *testing.T) (*Collector, func()) {
+	return prepareCaseMBeans(t, string(dataJVMMBeans))
+}
+
+// prepareCaseMBeans starts a fake Jolokia agent serving search and read
+// requests over the given MBeans (object name -> attribute -> value).
+func prepareCaseMBeans(t *testing.T, mbeansJSON string) (*Collector, func()) {
+	t.Helper()
+	var mbeans map[string]map[string]any
+	require.NoError(t, json.Unmarshal([]byte(mbeansJSON), &mbeans))
+
+	srv := httptest.NewServer(newFakeJolokia(mbeans))
+	collr := New()
+	collr.URL = srv.URL + "/jolokia"
+	return collr, srv.Close
+}
+
+func newFakeJolokia(mbeans map[string]map[string]any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/jolokia" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var reqs []jolokiaRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		resps := make([]map[string]any, 0, len(reqs))
+		for _, req := range reqs {
+			var names []string
+			for name := range mbeans {
+				if fakeMatchObjectName(req.MBean, name) {
+					names = append(names, name)
+				}
+			}
+
+			switch {
+			case req.Type == "search":
+				if names == nil {
+					names = []string{}
+				}
+				resps = append(resps, map[string]any{"status": 200, "value": names})
+			case len(names) == 0:
+				resps = append(resps, map[string]any{
+					"status":     404,
+					"error_type": "javax.management.InstanceNotFoundException",
+					"error":      "javax.management.InstanceNotFoundException : " + req.MBean,
+				})
+			default:
+				byName := make(map[string]any)
+				for _, name := range names {
+					attrs := make(map[string]any)
+					for _, attr := range req.Attribute {
+						if v, ok := mbeans[name][attr]; ok {
+							attrs[attr] = v
+						}
+					}
+					byName[name] = attrs
+				}
+				var value any = byName
+				if req.MBean == names[0] {
+					value = byName[names[0]]
+				}
+				resps = append(resps, map[string]any{"status": 200, "value": value})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(resps)
+	}
+}
+
+func fakeMatchObjectName(pattern, name string) bool {
+	if pattern == name {
+		return true
+	}
+	p, err := parseObjectName(pattern)
+	if err != nil {
+		return false
+	}
+	on, err := parseObjectName(name)
+	if err != nil {
+		return false
+	}
+	if ok, _ := path.Match(p.domain, on.domain); !ok {
+		return false
+	}
+	if !p.wildcardList && len(p.props) != len(on.props) {
+		return false
+	}
+	for _, prop := range p.props {
+		v, found := on.propertyValue(prop.key)
+		if ok, _ := path.Match(prop.value, v); !found || !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func mustCycleController(t *testing.T, store metrix.CollectorStore) metrix.CycleController {
+	t.Helper()
+	managed, ok := metrix.AsCycleManagedStore(store)
+	require.True(t, ok, "store does not expose cycle control")
+	return managed.CycleController()
+}
+
+func assertValue(t *testing.T, r metrix.Reader, name string, labels metrix.Labels, want float64) {
+	t.Helper()
+	got, ok := r.Value(name, labels)
+	require.Truef(t, ok, "expected metric %s labels=%v", name, labels)
+	assert.InDeltaf(t, want, got, 1e-9, "unexpected value for %s labels=%v: got %v want %v", name, labels, got, want)
+}
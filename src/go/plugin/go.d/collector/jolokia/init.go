@@ -0,0 +1,21 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package jolokia
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+func (c *Collector) validateConfig() error {
+	if c.URL == "" {
+		return errors.New("'url' can not be empty")
+	}
+	for i, name := range c.Profiles {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("profiles[%d]: name can not be empty", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package jolokia
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+const (
+	metricTypeGauge   = "gauge"
+	metricTypeCounter = "counter"
+)
+
+var reMetricName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// MBeanConfig maps the attributes of the MBeans matching an ObjectName pattern
+// to metrics. It is shared by job configuration and profiles.
+type MBeanConfig struct {
+	// MBean is an ObjectName or ObjectName pattern (e.g. java.lang:type=MemoryPool,name=*).
+	MBean string `yaml:"mbean" json:"mbean"`
+	// Labels maps a label name to the ObjectName key property it takes its value from.
+	// When unset, every key property with a wildcard value becomes a label of the same name.
+	Labels map[string]string `yaml:"labels,omitempty" json:"labels"`
+	// Attributes lists the attributes to read and the metrics they become.
+	Attributes []AttributeConfig `yaml:"attributes" json:"attributes"`
+}
+
+// AttributeConfig maps one MBean attribute, or one field of a composite
+// attribute, to a metric.
+type AttributeConfig struct {
+	Attribute string `yaml:"attribute" json:"attribute"`
+	// Path selects a field of a CompositeData or Map attribute, segments separated by '/'.
+	Path   string `yaml:"path,omitempty" json:"path"`
+	Metric string `yaml:"metric" json:"metric"`
+	// Type is 'gauge' (default) or 'counter'.
+	Type string `yaml:"type,omitempty" json:"type"`
+}
+
+func (m MBeanConfig) validate() error {
+	if err := validateObjectNamePattern(m.MBean); err != nil {
+		return fmt.Errorf("'mbean': %v", err)
+	}
+	on, _ := parseObjectName(m.MBean)
+	for label, key := range m.Labels {
+		if !reMetricName.MatchString(label) {
+			return fmt.Errorf("mbean '%s': invalid label name '%s'", m.MBean, label)
+		}
+		if _, ok := on.propertyValue(key); !ok && !on.wildcardList {
+			return fmt.Errorf("mbean '%s': label '%s': key property '%s' is not part of the object name", m.MBean, label, key)
+		}
+	}
+	if len(m.Attributes) == 0 {
+		return fmt.Errorf("mbean '%s': 'attributes' must not be empty", m.MBean)
+	}
+	for i, attr := range m.Attributes {
+		if err := attr.validate(); err != nil {
+			return fmt.Errorf("mbean '%s': attributes[%d]: %v", m.MBean, i, err)
+		}
+	}
+	return nil
+}
+
+func (a AttributeConfig) validate() error {
+	if strings.TrimSpace(a.Attribute) == "" {
+		return errors.New("'attribute' must not be empty")
+	}
+	if !reMetricName.MatchString(a.Metric) {
+		return fmt.Errorf("invalid 'metric' name '%s'", a.Metric)
+	}
+	switch a.Type {
+	case "", metricTypeGauge, metricTypeCounter:
+	default:
+		return fmt.Errorf("'type' must be '%s' or '%s', got '%s'", metricTypeGauge, metricTypeCounter, a.Type)
+	}
+	return nil
+}
+
+// mbeanQuery is a validated MBeanConfig ready to be sent as a Jolokia read request.
+type mbeanQuery struct {
+	mbean      string
+	pattern    bool
+	labels     []mbeanLabel // sorted by name
+	attributes []AttributeConfig
+	// readAttrs are the distinct attribute names, in first-seen order.
+	readAttrs []string
+	// custom is set for job-configured mappings, which are charted by autogen.
+	custom bool
+}
+
+type mbeanLabel struct {
+	name string
+	key  string
+}
+
+func newMBeanQuery(cfg MBeanConfig) (mbeanQuery, error) {
+	if err := cfg.validate(); err != nil {
+		return mbeanQuery{}, err
+	}
+	on, _ := parseObjectName(cfg.MBean)
+
+	q := mbeanQuery{
+		mbean:      cfg.MBean,
+		pattern:    on.isPattern(),
+		attributes: slices.Clone(cfg.Attributes),
+	}
+	if len(cfg.Labels) > 0 {
+		for name, key := range cfg.Labels {
+			q.labels = append(q.labels, mbeanLabel{name: name, key: key})
+		}
+	} else {
+		for _, key := range on.wildcardKeys() {
+			q.labels = append(q.labels, mbeanLabel{name: key, key: key})
+		}
+	}
+	sort.Slice(q.labels, func(i, j int) bool { return q.labels[i].name < q.labels[j].name })
+
+	for _, attr := range cfg.Attributes {
+		if !slices.Contains(q.readAttrs, attr.Attribute) {
+			q.readAttrs = append(q.readAttrs, attr.Attribute)
+		}
+	}
+	return q, nil
+}
+
+func newMBeanQueries(cfgs []MBeanConfig) ([]mbeanQuery, error) {
+	queries := make([]mbeanQuery, 0, len(cfgs))
+	for i, cfg := range cfgs {
+		q, err := newMBeanQuery(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("mbeans[%d]: %v", i, err)
+		}
+		queries = append(queries, q)
+	}
+	return queries, nil
+}
@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package jolokia
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// objectName is a parsed JMX ObjectName (domain:key=value[,key=value...]).
+// Values keep their original form; quoted values are unquoted by propertyValue.
+type objectName struct {
+	domain string
+	props  []objectNameProp
+	// wildcardList is set when the property list ends with the ",*" pattern.
+	wildcardList bool
+}
+
+type objectNameProp struct {
+	key   string
+	value string
+}
+
+func parseObjectName(s string) (objectName, error) {
+	domain, list, ok := strings.Cut(s, ":")
+	if !ok {
+		return objectName{}, fmt.Errorf("object name '%s': missing ':' after the domain", s)
+	}
+	if list == "" {
+		return objectName{}, fmt.Errorf("object name '%s': empty key property list", s)
+	}
+
+	on := objectName{domain: domain}
+	for _, part := range splitObjectNameProps(list) {
+		if part == "*" {
+			on.wildcardList = true
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || key == "" || value == "" {
+			return objectName{}, fmt.Errorf("object name '%s': invalid key property '%s'", s, part)
+		}
+		on.props = append(on.props, objectNameProp{key: key, value: value})
+	}
+	if len(on.props) == 0 && !on.wildcardList {
+		return objectName{}, fmt.Errorf("object name '%s': no key properties", s)
+	}
+	return on, nil
+}
+
+// splitObjectNameProps splits a key property list on commas outside quoted values.
+func splitObjectNameProps(list string) []string {
+	var parts []string
+	start, quoted := 0, false
+	for i := 0; i < len(list); i++ {
+		switch list[i] {
+		case '\\':
+			if quoted {
+				i++
+			}
+		case '"':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				parts = append(parts, list[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, list[start:])
+}
+
+func (on objectName) isPattern() bool {
+	if on.wildcardList || hasWildcard(on.domain) {
+		return true
+	}
+	for _, p := range on.props {
+		if isValuePattern(p.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// wildcardKeys returns the keys whose values are patterns, in the order they appear.
+func (on objectName) wildcardKeys() []string {
+	var keys []string
+	for _, p := range on.props {
+		if isValuePattern(p.value) {
+			keys = append(keys, p.key)
+		}
+	}
+	return keys
+}
+
+func (on objectName) propertyValue(key string) (string, bool) {
+	for _, p := range on.props {
+		if p.key == key {
+			return unquoteObjectNameValue(p.value), true
+		}
+	}
+	return "", false
+}
+
+func isValuePattern(v string) bool {
+	return !strings.HasPrefix(v, `"`) && hasWildcard(v)
+}
+
+func hasWildcard(s string) bool {
+	return strings.ContainsAny(s, "*?")
+}
+
+func unquoteObjectNameValue(v string) string {
+	if len(v) < 2 || v[0] != '"' || v[len(v)-1] != '"' {
+		return v
+	}
+	v = v[1 : len(v)-1]
+	if !strings.Contains(v, `\`) {
+		return v
+	}
+	var sb strings.Builder
+	for i := 0; i < len(v); i++ {
+		if v[i] == '\\' && i+1 < len(v) {
+			i++
+			if v[i] == 'n' {
+				sb.WriteByte('\n')
+				continue
+			}
+		}
+		sb.WriteByte(v[i])
+	}
+	return sb.String()
+}
+
+func validateObjectNamePattern(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return errors.New("must not be empty")
+	}
+	_, err := parseObjectName(s)
+	return err
+}
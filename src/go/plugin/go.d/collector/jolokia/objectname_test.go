@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package jolokia
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseObjectName(t *testing.T) {
+	tests := map[string]struct {
+		input         string
+		wantPattern   bool
+		wantWildcards []string
+		wantValues    map[string]string
+		wantErr       bool
+	}{
+		"plain name": {
+			input:      "java.lang:type=Memory",
+			wantValues: map[string]string{"type": "Memory"},
+		},
+		"value with spaces": {
+			input:      "java.lang:name=G1 Old Gen,type=MemoryPool",
+			wantValues: map[string]string{"name": "G1 Old Gen", "type": "MemoryPool"},
+		},
+		"quoted value with comma": {
+			input:      `Catalina:type=GlobalRequestProcessor,name="http-nio-8080,x"`,
+			wantValues: map[string]string{"name": "http-nio-8080,x"},
+		},
+		"value wildcard": {
+			input:         "java.lang:type=GarbageCollector,name=*",
+			wantPattern:   true,
+			wantWildcards: []string{"name"},
+		},
+		"partial value wildcard": {
+			input:         "com.zaxxer.hikari:type=Pool (*)",
+			wantPattern:   true,
+			wantWildcards: []string{"type"},
+		},
+		"property list wildcard": {
+			input:       "java.lang:type=GarbageCollector,*",
+			wantPattern: true,
+			wantValues:  map[string]string{"type": "GarbageCollector"},
+		},
+		"domain wildcard": {
+			input:       "*:type=Memory",
+			wantPattern: true,
+		},
+		"quoted wildcard is literal": {
+			input:      `app:name="a*b"`,
+			wantValues: map[string]string{"name": "a*b"},
+		},
+		"missing domain separator": {
+			input:   "java.lang",
+			wantErr: true,
+		},
+		"empty property list": {
+			input:   "java.lang:",
+			wantErr: true,
+		},
+		"property without value": {
+			input:   "java.lang:type",
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			on, err := parseObjectName(test.input)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, test.wantPattern, on.isPattern())
+			assert.Equal(t, test.wantWildcards, on.wildcardKeys())
+			for k, want := range test.wantValues {
+				got, ok := on.propertyValue(k)
+				assert.Truef(t, ok, "key '%s'", k)
+				assert.Equalf(t, want, got, "key '%s'", k)
+			}
+		})
+	}
+}
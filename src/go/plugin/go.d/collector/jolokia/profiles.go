@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package jolokia
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/netdata/netdata/go/plugins/logger"
+	"github.com/netdata/netdata/go/plugins/pkg/executable"
+	"github.com/netdata/netdata/go/plugins/pkg/pluginconfig"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/charttpl"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/profilecatalog"
+)
+
+const profilesDirName = "jolokia.profiles"
+
+var profilesLog = logger.New().With("component", "jolokia/profiles")
+
+// profile maps the MBeans of one application or subsystem to metrics and
+// curated charts. Identity is the file basename. Match is an ObjectName pattern:
+// with automatic selection a profile is used when a Jolokia search for it finds
+// at least one MBean.
+type profile struct {
+	Name     string
+	Match    string
+	MBeans   []MBeanConfig
+	Template charttpl.Group
+}
+
+type profileDocument struct {
+	Match    string          `yaml:"match"`
+	MBeans   []MBeanConfig   `yaml:"mbeans"`
+	Template *charttpl.Group `yaml:"template"`
+}
+
+type profileCatalog struct {
+	core profilecatalog.Catalog[profile]
+}
+
+var defaultProfileCatalog = profilecatalog.NewCached(loadProfilesFromDefaultDirs)
+
+func loadDefaultProfileCatalog() (profileCatalog, error) { return defaultProfileCatalog.Get() }
+
+func loadProfilesFromDefaultDirs() (profileCatalog, error) {
+	return loadProfilesFromDirs(defaultProfileDirSpecs())
+}
+
+// loadProfilesFromDirs builds the catalog from the given directories. User
+// profiles override stock profiles of the same name; invalid user profiles are
+// skipped with a warning, invalid stock profiles are fatal.
+func loadProfilesFromDirs(specs []profilecatalog.DirSpec) (profileCatalog, error) {
+	core, err := profilecatalog.Load(specs, profilecatalog.Options[profile]{
+		Decode: func(ctx profilecatalog.FileContext, data []byte) (profile, error) {
+			return decodeProfile(data, ctx.BaseName)
+		},
+		NormalizeKey: normalizeProfileName,
+		Log:          profilesLog,
+	})
+	if err != nil {
+		return profileCatalog{}, err
+	}
+	return profileCatalog{core: core}, nil
+}
+
+func normalizeProfileName(v string) string { return strings.ToLower(strings.TrimSpace(v)) }
+
+func decodeProfile(data []byte, name string) (profile, error) {
+	var doc profileDocument
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&doc); err != nil {
+		return profile{}, fmt.Errorf("unmarshal profile '%s': %w", name, err)
+	}
+
+	if err := validateObjectNamePattern(doc.Match); err != nil {
+		return profile{}, fmt.Errorf("profile '%s': 'match': %v", name, err)
+	}
+	if len(doc.MBeans) == 0 {
+		return profile{}, fmt.Errorf("profile '%s': 'mbeans' must not be empty", name)
+	}
+	if _, err := newMBeanQueries(doc.MBeans); err != nil {
+		return profile{}, fmt.Errorf("profile '%s': %v", name, err)
+	}
+	if doc.Template == nil {
+		return profile{}, fmt.Errorf("profile '%s': 'template' is required", name)
+	}
+	spec := charttpl.Spec{
+		Version: charttpl.VersionV1,
+		Groups:  []charttpl.Group{*doc.Template},
+	}
+	if err := spec.Validate(); err != nil {
+		return profile{}, fmt.Errorf("profile '%s': 'template': %w", name, err)
+	}
+
+	return profile{
+		Name:     name,
+		Match:    doc.Match,
+		MBeans:   doc.MBeans,
+		Template: *doc.Template,
+	}, nil
+}
+
+func (c profileCatalog) all() []profile {
+	named := c.core.InOrder()
+	out := make([]profile, 0, len(named))
+	for _, n := range named {
+		out = append(out, n.Profile.clone())
+	}
+	return out
+}
+
+// resolve returns the named profiles in the requested order.
+func (c profileCatalog) resolve(names []string) ([]profile, error) {
+	out := make([]profile, 0, len(names))
+	for _, name := range names {
+		p, ok := c.core.Get(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown profile '%s'", name)
+		}
+		out = append(out, p.clone())
+	}
+	return out, nil
+}
+
+func (p profile) clone() profile {
+	out := p
+	out.MBeans = make([]MBeanConfig, 0, len(p.MBeans))
+	for _, m := range p.MBeans {
+		m.Attributes = slices.Clone(m.Attributes)
+		m.Labels = maps.Clone(m.Labels)
+		out.MBeans = append(out.MBeans, m)
+	}
+	out.Template = p.Template.Clone()
+	return out
+}
+
+func defaultProfileDirSpecs() []profilecatalog.DirSpec {
+	if executable.Name == "test" {
+		if dir := profilesDirFromThisFile(); dir != "" {
+			return []profilecatalog.DirSpec{{Path: dir, IsStock: true}}
+		}
+		return nil
+	}
+
+	if dir := filepath.Join(executable.Directory, "../config/go.d", profilesDirName, "default"); profilecatalog.DirExists(dir) {
+		return []profilecatalog.DirSpec{{Path: dir, IsStock: true}}
+	}
+
+	specs := make([]profilecatalog.DirSpec, 0, len(pluginconfig.CollectorsUserDirs())+1)
+	for _, dir := range pluginconfig.CollectorsUserDirs() {
+		specs = append(specs, profilecatalog.DirSpec{
+			Path:    filepath.Join(dir, profilesDirName),
+			IsStock: false,
+		})
+	}
+	specs = append(specs, profilecatalog.DirSpec{
+		Path:    filepath.Join(pluginconfig.CollectorsStockDir(), profilesDirName, "default"),
+		IsStock: true,
+	})
+
+	return specs
+}
+
+func profilesDirFromThisFile() string {
+	_, thisFile, _, ok := runtime.Caller(0)
+	if !ok {
+		return ""
+	}
+
+	// This file sits at plugin/go.d/collector/jolokia; two levels up is
+	// plugin/go.d, under which config/go.d/<profilesDirName>/default lives.
+	candidate := filepath.Join(filepath.Dir(thisFile), "..", "..", "config", "go.d", profilesDirName, "default")
+	if !profilecatalog.DirExists(candidate) {
+		return ""
+	}
+
+	abs, _ := filepath.Abs(candidate)
+	return abs
+}
@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package jolokia
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/profilecatalog"
+)
+
+func TestStockProfiles(t *testing.T) {
+	catalog, err := loadProfilesFromDefaultDirs()
+	require.NoError(t, err)
+
+	var names []string
+	for _, p := range catalog.all() {
+		names = append(names, p.Name)
+		_, err := buildChartTemplate([]profile{p})
+		assert.NoErrorf(t, err, "profile '%s'", p.Name)
+	}
+	assert.Subset(t, names, []string{"jvm_memory", "jvm_gc", "jvm_threads"})
+}
+
+func TestDecodeProfile(t *testing.T) {
+	const validTemplate = `
+template:
+  family: App
+  metrics:
+    - app_requests_total
+  charts:
+    - title: Requests
+      context: requests
+      units: requests/s
+      dimensions:
+        - selector: app_requests_total
+          name: requests
+`
+	tests := map[string]struct {
+		input   string
+		wantErr bool
+	}{
+		"valid": {
+			input: `
+match: 'app:type=Server'
+mbeans:
+  - mbean: 'app:type=Server'
+    attributes:
+      - attribute: Requests
+        metric: app_requests_total
+        type: counter
+` + validTemplate,
+		},
+		"unknown field": {
+			wantErr: true,
+			input: `
+match: 'app:type=Server'
+app: server
+mbeans:
+  - mbean: 'app:type=Server'
+    attributes:
+      - attribute: Requests
+        metric: app_requests_total
+` + validTemplate,
+		},
+		"invalid match": {
+			wantErr: true,
+			input: `
+match: 'app'
+mbeans:
+  - mbean: 'app:type=Server'
+    attributes:
+      - attribute: Requests
+        metric: app_requests_total
+` + validTemplate,
+		},
+		"no mbeans": {
+			wantErr: true,
+			input:   "match: 'app:type=Server'\n" + validTemplate,
+		},
+		"invalid attribute": {
+			wantErr: true,
+			input: `
+match: 'app:type=Server'
+mbeans:
+  - mbean: 'app:type=Server'
+    attributes:
+      - attribute: Requests
+` + validTemplate,
+		},
+		"no template": {
+			wantErr: true,
+			input: `
+match: 'app:type=Server'
+mbeans:
+  - mbean: 'app:type=Server'
+    attributes:
+      - attribute: Requests
+        metric: app_requests_total
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := decodeProfile([]byte(test.input), "app")
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLoadProfilesFromDirs_UserOverridesStock(t *testing.T) {
+	stock, user := t.TempDir(), t.TempDir()
+	const profileFmt = `
+match: '%s'
+mbeans:
+  - mbean: 'app:type=Server'
+    attributes:
+      - attribute: Requests
+        metric: app_requests_total
+template:
+  family: App
+  metrics:
+    - app_requests_total
+  charts:
+    - title: Requests
+      context: requests
+      units: requests
+      dimensions:
+        - selector: app_requests_total
+          name: requests
+`
+	writeProfile := func(dir, name, match string) {
+		data := []byte(fmt.Sprintf(profileFmt, match))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name+".yaml"), data, 0o644))
+	}
+	writeProfile(stock, "app", "app:type=Server")
+	writeProfile(user, "app", "app:type=Server,*")
+	require.NoError(t, os.WriteFile(filepath.Join(user, "broken.yaml"), []byte("match: ["), 0o644))
+
+	catalog, err := loadProfilesFromDirs([]profilecatalog.DirSpec{
+		{Path: user},
+		{Path: stock, IsStock: true},
+	})
+	require.NoError(t, err)
+
+	profiles, err := catalog.resolve([]string{"APP"})
+	require.NoError(t, err)
+	require.Len(t, profiles, 1)
+	assert.Equal(t, "app:type=Server,*", profiles[0].Match)
+
+	_, err = catalog.resolve([]string{"broken"})
+	assert.Error(t, err)
+}
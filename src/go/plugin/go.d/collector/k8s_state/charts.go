@@ -90,6 +90,51 @@ const (
 	labelKeyQoSClass       = labelKeyPrefix + "qos_class"
 	labelKeyDeploymentName = labelKeyPrefix + "deployment_name"
 	labelKeyCronJobName    = labelKeyPrefix + "cronjob_name"
+
+	labelKeyStatefulSetName       = labelKeyPrefix + "statefulset_name"
+	labelKeyDaemonSetName         = labelKeyPrefix + "daemonset_name"
+	labelKeyPVCName               = labelKeyPrefix + "pvc_name"
+	labelKeyStorageClass          = labelKeyPrefix + "storage_class"
+	labelKeyHPAName               = labelKeyPrefix + "hpa_name"
+	labelKeyHPATargetKind         = labelKeyPrefix + "hpa_target_kind"
+	labelKeyHPATargetName         = labelKeyPrefix + "hpa_target_name"
+	labelKeyResourceQuotaName     = labelKeyPrefix + "resourcequota_name"
+	labelKeyResourceQuotaResource = labelKeyPrefix + "resourcequota_resource"
+)
+
+const (
+	prioStatefulSetReplicas = 50550 + iota
+	prioStatefulSetAge
+)
+
+const (
+	prioDaemonSetPodsScheduling = 50600 + iota
+	prioDaemonSetPodsAvailability
+	prioDaemonSetAge
+)
+
+const (
+	prioPVCPhase = 50800 + iota
+	prioPVCStorage
+	prioPVCAge
+)
+
+const (
+	prioHPAReplicas = 50850 + iota
+	prioHPAConditions
+	prioHPAAge
+)
+
+const (
+	prioResourceQuotaUtilization = 50900 + iota
+	prioResourceQuotaUsage
+)
+
+const (
+	prioEvents = 50950 + iota
+	prioEventsWarningByReason
+	prioEventsWarningByKind
+	prioEventsWarningByNamespace
 )
 
 var baseCharts = collectorapi.Charts{
@@ -158,6 +203,34 @@ var cronJobChartsTmpl = collectorapi.Charts{
 	cronJobAgeChartTmpl.Copy(),
 }
 
+var statefulSetChartsTmpl = collectorapi.Charts{
+	statefulSetReplicasChartTmpl.Copy(),
+	statefulSetAgeChartTmpl.Copy(),
+}
+
+var daemonSetChartsTmpl = collectorapi.Charts{
+	daemonSetPodsSchedulingChartTmpl.Copy(),
+	daemonSetPodsAvailabilityChartTmpl.Copy(),
+	daemonSetAgeChartTmpl.Copy(),
+}
+
+var pvcChartsTmpl = collectorapi.Charts{
+	pvcPhaseChartTmpl.Copy(),
+	pvcStorageChartTmpl.Copy(),
+	pvcAgeChartTmpl.Copy(),
+}
+
+var hpaChartsTmpl = collectorapi.Charts{
+	hpaReplicasChartTmpl.Copy(),
+	hpaConditionsChartTmpl.Copy(),
+	hpaAgeChartTmpl.Copy(),
+}
+
+var resourceQuotaChartsTmpl = collectorapi.Charts{
+	resourceQuotaUtilizationChartTmpl.Copy(),
+	resourceQuotaUsageChartTmpl.Copy(),
+}
+
 var (
 	// CPU resource
 	nodeAllocatableCPURequestsUtilChartTmpl = collectorapi.Chart{
@@ -984,6 +1057,329 @@ func (c *Collector) removeCronJobCharts(st *cronJobState) {
 	c.removeCharts(prefix)
 }
 
+var (
+	statefulSetReplicasChartTmpl = collectorapi.Chart{
+		IDSep:    true,
+		ID:       "statefulset_%s.replicas",
+		Title:    "StatefulSet Replicas",
+		Units:    "replicas",
+		Fam:      "statefulset replicas",
+		Ctx:      "k8s_state.statefulset_replicas",
+		Priority: prioStatefulSetReplicas,
+		Dims: collectorapi.Dims{
+			{ID: "sts_%s_desired_replicas", Name: "desired"},
+			{ID: "sts_%s_current_replicas", Name: "current"},
+			{ID: "sts_%s_updated_replicas", Name: "updated"},
+			{ID: "sts_%s_ready_replicas", Name: "ready"},
+			{ID: "sts_%s_available_replicas", Name: "available"},
+		},
+	}
+	statefulSetAgeChartTmpl = collectorapi.Chart{
+		IDSep:    true,
+		ID:       "statefulset_%s.age",
+		Title:    "StatefulSet Age",
+		Units:    "seconds",
+		Fam:      "statefulset age",
+		Ctx:      "k8s_state.statefulset_age",
+		Priority: prioStatefulSetAge,
+		Dims: collectorapi.Dims{
+			{ID: "sts_%s_age", Name: "age"},
+		},
+	}
+)
+
+func (c *Collector) addStatefulSetCharts(st *statefulSetState) {
+	charts := statefulSetChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, replaceDots(st.id()))
+		chart.Labels = []collectorapi.Label{
+			{Key: labelKeyClusterID, Value: c.kubeClusterID, Source: collectorapi.LabelSourceK8s},
+			{Key: labelKeyClusterName, Value: c.kubeClusterName, Source: collectorapi.LabelSourceK8s},
+			{Key: labelKeyStatefulSetName, Value: st.name, Source: collectorapi.LabelSourceK8s},
+			{Key: labelKeyNamespace, Value: st.namespace, Source: collectorapi.LabelSourceK8s},
+		}
+		for _, d := range chart.Dims {
+			d.ID = fmt.Sprintf(d.ID, st.id())
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) removeStatefulSetCharts(st *statefulSetState) {
+	prefix := fmt.Sprintf("statefulset_%s", replaceDots(st.id()))
+	c.removeCharts(prefix)
+}
+
+var (
+	daemonSetPodsSchedulingChartTmpl = collectorapi.Chart{
+		IDSep:    true,
+		ID:       "daemonset_%s.pods_scheduling",
+		Title:    "DaemonSet Pods Scheduling",
+		Units:    "pods",
+		Fam:      "daemonset pods",
+		Ctx:      "k8s_state.daemonset_pods_scheduling",
+		Priority: prioDaemonSetPodsScheduling,
+		Dims: collectorapi.Dims{
+			{ID: "ds_%s_desired_scheduled", Name: "desired"},
+			{ID: "ds_%s_current_scheduled", Name: "current"},
+			{ID: "ds_%s_updated_scheduled", Name: "updated"},
+			{ID: "ds_%s_misscheduled", Name: "misscheduled"},
+		},
+	}
+	daemonSetPodsAvailabilityChartTmpl = collectorapi.Chart{
+		IDSep:    true,
+		ID:       "daemonset_%s.pods_availability",
+		Title:    "DaemonSet Pods Availability",
+		Units:    "pods",
+		Fam:      "daemonset pods",
+		Ctx:      "k8s_state.daemonset_pods_availability",
+		Priority: prioDaemonSetPodsAvailability,
+		Dims: collectorapi.Dims{
+			{ID: "ds_%s_ready", Name: "ready"},
+			{ID: "ds_%s_available", Name: "available"},
+			{ID: "ds_%s_unavailable", Name: "unavailable"},
+		},
+	}
+	daemonSetAgeChartTmpl = collectorapi.Chart{
+		IDSep:    true,
+		ID:       "daemonset_%s.age",
+		Title:    "DaemonSet Age",
+		Units:    "seconds",
+		Fam:      "daemonset age",
+		Ctx:      "k8s_state.daemonset_age",
+		Priority: prioDaemonSetAge,
+		Dims: collectorapi.Dims{
+			{ID: "ds_%s_age", Name: "age"},
+		},
+	}
+)
+
+func (c *Collector) addDaemonSetCharts(st *daemonSetState) {
+	charts := daemonSetChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, replaceDots(st.id()))
+		chart.Labels = []collectorapi.Label{
+			{Key: labelKeyClusterID, Value: c.kubeClusterID, Source: collectorapi.LabelSourceK8s},
+			{Key: labelKeyClusterName, Value: c.kubeClusterName, Source: collectorapi.LabelSourceK8s},
+			{Key: labelKeyDaemonSetName, Value: st.name, Source: collectorapi.LabelSourceK8s},
+			{Key: labelKeyNamespace, Value: st.namespace, Source: collectorapi.LabelSourceK8s},
+		}
+		for _, d := range chart.Dims {
+			d.ID = fmt.Sprintf(d.ID, st.id())
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) removeDaemonSetCharts(st *daemonSetState) {
+	prefix := fmt.Sprintf("daemonset_%s", replaceDots(st.id()))
+	c.removeCharts(prefix)
+}
+
+var (
+	pvcPhaseChartTmpl = collectorapi.Chart{
+		IDSep:    true,
+		ID:       "pvc_%s.phase",
+		Title:    "PersistentVolumeClaim Phase",
+		Units:    "state",
+		Fam:      "pvc phase",
+		Ctx:      "k8s_state.pvc_phase",
+		Priority: prioPVCPhase,
+		Dims: collectorapi.Dims{
+			{ID: "pvc_%s_phase_pending", Name: "pending"},
+			{ID: "pvc_%s_phase_bound", Name: "bound"},
+			{ID: "pvc_%s_phase_lost", Name: "lost"},
+		},
+	}
+	pvcStorageChartTmpl = collectorapi.Chart{
+		IDSep:    true,
+		ID:       "pvc_%s.storage",
+		Title:    "PersistentVolumeClaim Storage",
+		Units:    "bytes",
+		Fam:      "pvc storage",
+		Ctx:      "k8s_state.pvc_storage",
+		Priority: prioPVCStorage,
+		Dims: collectorapi.Dims{
+			{ID: "pvc_%s_storage_requested", Name: "requested"},
+			{ID: "pvc_%s_storage_capacity", Name: "capacity"},
+		},
+	}
+	pvcAgeChartTmpl = collectorapi.Chart{
+		IDSep:    true,
+		ID:       "pvc_%s.age",
+		Title:    "PersistentVolumeClaim Age",
+		Units:    "seconds",
+		Fam:      "pvc age",
+		Ctx:      "k8s_state.pvc_age",
+		Priority: prioPVCAge,
+		Dims: collectorapi.Dims{
+			{ID: "pvc_%s_age", Name: "age"},
+		},
+	}
+)
+
+func (c *Collector) addPVCCharts(st *pvcState) {
+	charts := pvcChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, replaceDots(st.id()))
+		chart.Labels = []collectorapi.Label{
+			{Key: labelKeyClusterID, Value: c.kubeClusterID, Source: collectorapi.LabelSourceK8s},
+			{Key: labelKeyClusterName, Value: c.kubeClusterName, Source: collectorapi.LabelSourceK8s},
+			{Key: labelKeyPVCName, Value: st.name, Source: collectorapi.LabelSourceK8s},
+			{Key: labelKeyNamespace, Value: st.namespace, Source: collectorapi.LabelSourceK8s},
+			{Key: labelKeyStorageClass, Value: st.storageClass, Source: collectorapi.LabelSourceK8s},
+		}
+		for _, d := range chart.Dims {
+			d.ID = fmt.Sprintf(d.ID, st.id())
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) removePVCCharts(st *pvcState) {
+	prefix := fmt.Sprintf("pvc_%s", replaceDots(st.id()))
+	c.removeCharts(prefix)
+}
+
+var (
+	hpaReplicasChartTmpl = collectorapi.Chart{
+		IDSep:    true,
+		ID:       "hpa_%s.replicas",
+		Title:    "HorizontalPodAutoscaler Replicas",
+		Units:    "replicas",
+		Fam:      "hpa replicas",
+		Ctx:      "k8s_state.hpa_replicas",
+		Priority: prioHPAReplicas,
+		Dims: collectorapi.Dims{
+			{ID: "hpa_%s_min_replicas", Name: "min"},
+			{ID: "hpa_%s_max_replicas", Name: "max"},
+			{ID: "hpa_%s_current_replicas", Name: "current"},
+			{ID: "hpa_%s_desired_replicas", Name: "desired"},
+		},
+	}
+	hpaConditionsChartTmpl = collectorapi.Chart{
+		IDSep:    true,
+		ID:       "hpa_%s.conditions",
+		Title:    "HorizontalPodAutoscaler Conditions",
+		Units:    "status",
+		Fam:      "hpa conditions",
+		Ctx:      "k8s_state.hpa_conditions",
+		Priority: prioHPAConditions,
+		Dims: collectorapi.Dims{
+			{ID: "hpa_%s_condition_able_to_scale", Name: "able_to_scale"},
+			{ID: "hpa_%s_condition_scaling_active", Name: "scaling_active"},
+			{ID: "hpa_%s_condition_scaling_limited", Name: "scaling_limited"},
+		},
+	}
+	hpaAgeChartTmpl = collectorapi.Chart{
+		IDSep:    true,
+		ID:       "hpa_%s.age",
+		Title:    "HorizontalPodAutoscaler Age",
+		Units:    "seconds",
+		Fam:      "hpa age",
+		Ctx:      "k8s_state.hpa_age",
+		Priority: prioHPAAge,
+		Dims: collectorapi.Dims{
+			{ID: "hpa_%s_age", Name: "age"},
+		},
+	}
+)
+
+func (c *Collector) addHPACharts(st *hpaState) {
+	charts := hpaChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, replaceDots(st.id()))
+		chart.Labels = []collectorapi.Label{
+			{Key: labelKeyClusterID, Value: c.kubeClusterID, Source: collectorapi.LabelSourceK8s},
+			{Key: labelKeyClusterName, Value: c.kubeClusterName, Source: collectorapi.LabelSourceK8s},
+			{Key: labelKeyHPAName, Value: st.name, Source: collectorapi.LabelSourceK8s},
+			{Key: labelKeyNamespace, Value: st.namespace, Source: collectorapi.LabelSourceK8s},
+			{Key: labelKeyHPATargetKind, Value: st.targetKind, Source: collectorapi.LabelSourceK8s},
+			{Key: labelKeyHPATargetName, Value: st.targetName, Source: collectorapi.LabelSourceK8s},
+		}
+		for _, d := range chart.Dims {
+			d.ID = fmt.Sprintf(d.ID, st.id())
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) removeHPACharts(st *hpaState) {
+	prefix := fmt.Sprintf("hpa_%s", replaceDots(st.id()))
+	c.removeCharts(prefix)
+}
+
+var (
+	resourceQuotaUtilizationChartTmpl = collectorapi.Chart{
+		IDSep:    true,
+		ID:       "resourcequota_%s_%s.utilization",
+		Title:    "ResourceQuota Utilization",
+		Units:    "%",
+		Fam:      "resourcequota",
+		Ctx:      "k8s_state.resourcequota_utilization",
+		Priority: prioResourceQuotaUtilization,
+		Dims: collectorapi.Dims{
+			{ID: "quota_%s_%s_utilization", Name: "used", Div: precision},
+		},
+	}
+	resourceQuotaUsageChartTmpl = collectorapi.Chart{
+		IDSep:    true,
+		ID:       "resourcequota_%s_%s.usage",
+		Title:    "ResourceQuota Usage",
+		Units:    "value",
+		Fam:      "resourcequota",
+		Ctx:      "k8s_state.resourcequota_usage",
+		Priority: prioResourceQuotaUsage,
+		Dims: collectorapi.Dims{
+			{ID: "quota_%s_%s_used", Name: "used", Div: precision},
+			{ID: "quota_%s_%s_hard", Name: "hard", Div: precision},
+		},
+	}
+)
+
+func (c *Collector) addResourceQuotaCharts(st *resourceQuotaState, rs *resourceQuotaResourceState) {
+	charts := resourceQuotaChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, replaceDots(st.id()), replaceDots(rs.name))
+		chart.Labels = []collectorapi.Label{
+			{Key: labelKeyClusterID, Value: c.kubeClusterID, Source: collectorapi.LabelSourceK8s},
+			{Key: labelKeyClusterName, Value: c.kubeClusterName, Source: collectorapi.LabelSourceK8s},
+			{Key: labelKeyResourceQuotaName, Value: st.name, Source: collectorapi.LabelSourceK8s},
+			{Key: labelKeyNamespace, Value: st.namespace, Source: collectorapi.LabelSourceK8s},
+			{Key: labelKeyResourceQuotaResource, Value: rs.name, Source: collectorapi.LabelSourceK8s},
+		}
+		for _, d := range chart.Dims {
+			d.ID = fmt.Sprintf(d.ID, st.id(), rs.name)
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) removeResourceQuotaCharts(st *resourceQuotaState, rs *resourceQuotaResourceState) {
+	prefix := fmt.Sprintf("resourcequota_%s_%s.", replaceDots(st.id()), replaceDots(rs.name))
+	c.removeCharts(prefix)
+}
+
 func (c *Collector) removeCharts(prefix string) {
 	for _, c := range *c.Charts() {
 		if strings.HasPrefix(c.ID, prefix) {
@@ -993,6 +1389,83 @@ func (c *Collector) removeCharts(prefix string) {
 	}
 }
 
+var eventChartsTmpl = collectorapi.Charts{
+	eventsChartTmpl.Copy(),
+	eventsWarningByReasonChartTmpl.Copy(),
+	eventsWarningByKindChartTmpl.Copy(),
+	eventsWarningByNamespaceChartTmpl.Copy(),
+}
+
+var (
+	eventsChartTmpl = collectorapi.Chart{
+		ID:       "events",
+		Title:    "Events",
+		Units:    "events/s",
+		Fam:      "events",
+		Ctx:      "k8s_state.events",
+		Type:     collectorapi.Stacked,
+		Priority: prioEvents,
+		Dims: collectorapi.Dims{
+			{ID: "events_normal", Name: "normal", Algo: collectorapi.Incremental},
+			{ID: "events_warning", Name: "warning", Algo: collectorapi.Incremental},
+		},
+	}
+	eventsWarningByReasonChartTmpl = collectorapi.Chart{
+		ID:       "events_warning_by_reason",
+		Title:    "Warning events by reason",
+		Units:    "events/s",
+		Fam:      "events",
+		Ctx:      "k8s_state.events_warning_by_reason",
+		Type:     collectorapi.Stacked,
+		Priority: prioEventsWarningByReason,
+	}
+	eventsWarningByKindChartTmpl = collectorapi.Chart{
+		ID:       "events_warning_by_kind",
+		Title:    "Warning events by object kind",
+		Units:    "events/s",
+		Fam:      "events",
+		Ctx:      "k8s_state.events_warning_by_kind",
+		Type:     collectorapi.Stacked,
+		Priority: prioEventsWarningByKind,
+	}
+	eventsWarningByNamespaceChartTmpl = collectorapi.Chart{
+		ID:       "events_warning_by_namespace",
+		Title:    "Warning events by namespace",
+		Units:    "events/s",
+		Fam:      "events",
+		Ctx:      "k8s_state.events_warning_by_namespace",
+		Type:     collectorapi.Stacked,
+		Priority: prioEventsWarningByNamespace,
+	}
+)
+
+func (c *Collector) addEventCharts() {
+	charts := eventChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.Labels = []collectorapi.Label{
+			{Key: labelKeyClusterID, Value: c.kubeClusterID, Source: collectorapi.LabelSourceK8s},
+			{Key: labelKeyClusterName, Value: c.kubeClusterName, Source: collectorapi.LabelSourceK8s},
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) addEventDim(chartID, dimID, name string) {
+	chart := c.Charts().Get(chartID)
+	if chart == nil || chart.HasDim(dimID) {
+		return
+	}
+	if err := chart.AddDim(&collectorapi.Dim{ID: dimID, Name: name, Algo: collectorapi.Incremental}); err != nil {
+		c.Warning(err)
+		return
+	}
+	chart.MarkNotCreated()
+}
+
 var discoveryStatusChart = collectorapi.Chart{
 	ID:       "discovery_discoverers_state",
 	Title:    "Running discoverers state",
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 
@@ -110,6 +111,12 @@ func (c *Collector) collectKubeState(mx map[string]int64) {
 	c.collectNodesState(mx)
 	c.collectDeploymentState(mx)
 	c.collectCronJobState(mx)
+	c.collectStatefulSetState(mx)
+	c.collectDaemonSetState(mx)
+	c.collectPVCState(mx)
+	c.collectHPAState(mx)
+	c.collectResourceQuotaState(mx)
+	c.collectEventsState(mx)
 }
 
 func (c *Collector) collectPodsState(mx map[string]int64) {
@@ -447,6 +454,193 @@ func (c *Collector) collectCronJobState(mx map[string]int64) {
 	})
 }
 
+func (c *Collector) collectStatefulSetState(mx map[string]int64) {
+	now := time.Now()
+
+	maps.DeleteFunc(c.state.statefulSets, func(s string, st *statefulSetState) bool {
+		if st.deleted {
+			c.removeStatefulSetCharts(st)
+			return true
+		}
+
+		if st.new {
+			st.new = false
+			c.addStatefulSetCharts(st)
+		}
+
+		px := fmt.Sprintf("sts_%s_", st.id())
+
+		mx[px+"age"] = int64(now.Sub(st.creationTime).Seconds())
+		mx[px+"desired_replicas"] = st.replicas
+		mx[px+"current_replicas"] = st.currentReplicas
+		mx[px+"updated_replicas"] = st.updatedReplicas
+		mx[px+"ready_replicas"] = st.readyReplicas
+		mx[px+"available_replicas"] = st.availReplicas
+
+		return false
+	})
+}
+
+func (c *Collector) collectDaemonSetState(mx map[string]int64) {
+	now := time.Now()
+
+	maps.DeleteFunc(c.state.daemonSets, func(s string, st *daemonSetState) bool {
+		if st.deleted {
+			c.removeDaemonSetCharts(st)
+			return true
+		}
+
+		if st.new {
+			st.new = false
+			c.addDaemonSetCharts(st)
+		}
+
+		px := fmt.Sprintf("ds_%s_", st.id())
+
+		mx[px+"age"] = int64(now.Sub(st.creationTime).Seconds())
+		mx[px+"desired_scheduled"] = st.desiredScheduled
+		mx[px+"current_scheduled"] = st.currentScheduled
+		mx[px+"updated_scheduled"] = st.updatedScheduled
+		mx[px+"misscheduled"] = st.misscheduled
+		mx[px+"ready"] = st.ready
+		mx[px+"available"] = st.available
+		mx[px+"unavailable"] = st.unavailable
+
+		return false
+	})
+}
+
+func (c *Collector) collectPVCState(mx map[string]int64) {
+	now := time.Now()
+
+	maps.DeleteFunc(c.state.pvcs, func(s string, st *pvcState) bool {
+		if st.deleted {
+			c.removePVCCharts(st)
+			return true
+		}
+
+		if st.new {
+			st.new = false
+			c.addPVCCharts(st)
+		}
+
+		px := fmt.Sprintf("pvc_%s_", st.id())
+
+		mx[px+"age"] = int64(now.Sub(st.creationTime).Seconds())
+		mx[px+"phase_pending"] = oldmetrix.Bool(st.phase == corev1.ClaimPending)
+		mx[px+"phase_bound"] = oldmetrix.Bool(st.phase == corev1.ClaimBound)
+		mx[px+"phase_lost"] = oldmetrix.Bool(st.phase == corev1.ClaimLost)
+		mx[px+"storage_requested"] = st.requestedStorage
+		mx[px+"storage_capacity"] = st.capacityStorage
+
+		return false
+	})
+}
+
+func (c *Collector) collectHPAState(mx map[string]int64) {
+	now := time.Now()
+
+	maps.DeleteFunc(c.state.hpas, func(s string, st *hpaState) bool {
+		if st.deleted {
+			c.removeHPACharts(st)
+			return true
+		}
+
+		if st.new {
+			st.new = false
+			c.addHPACharts(st)
+		}
+
+		px := fmt.Sprintf("hpa_%s_", st.id())
+
+		mx[px+"age"] = int64(now.Sub(st.creationTime).Seconds())
+		mx[px+"min_replicas"] = st.minReplicas
+		mx[px+"max_replicas"] = st.maxReplicas
+		mx[px+"current_replicas"] = st.currentReplicas
+		mx[px+"desired_replicas"] = st.desiredReplicas
+
+		mx[px+"condition_able_to_scale"] = 0
+		mx[px+"condition_scaling_active"] = 0
+		mx[px+"condition_scaling_limited"] = 0
+
+		for _, cond := range st.conditions {
+			v := condStatusToInt(cond.Status)
+			switch cond.Type {
+			case autoscalingv2.AbleToScale:
+				mx[px+"condition_able_to_scale"] = v
+			case autoscalingv2.ScalingActive:
+				mx[px+"condition_scaling_active"] = v
+			case autoscalingv2.ScalingLimited:
+				mx[px+"condition_scaling_limited"] = v
+			}
+		}
+
+		return false
+	})
+}
+
+func (c *Collector) collectResourceQuotaState(mx map[string]int64) {
+	maps.DeleteFunc(c.state.resourceQuotas, func(s string, st *resourceQuotaState) bool {
+		if st.deleted {
+			for _, rs := range st.resources {
+				c.removeResourceQuotaCharts(st, rs)
+			}
+			return true
+		}
+
+		maps.DeleteFunc(st.resources, func(name string, rs *resourceQuotaResourceState) bool {
+			if rs.deleted {
+				c.removeResourceQuotaCharts(st, rs)
+				return true
+			}
+
+			if rs.new {
+				rs.new = false
+				c.addResourceQuotaCharts(st, rs)
+			}
+
+			px := fmt.Sprintf("quota_%s_%s_", st.id(), rs.name)
+
+			mx[px+"used"] = rs.used
+			mx[px+"hard"] = rs.hard
+			mx[px+"utilization"] = calcPercentage(rs.used, rs.hard)
+
+			return false
+		})
+
+		return false
+	})
+}
+
+func (c *Collector) collectEventsState(mx map[string]int64) {
+	es := c.state.events
+	if !es.seen {
+		return
+	}
+
+	if !es.chartsAdded {
+		es.chartsAdded = true
+		c.addEventCharts()
+	}
+
+	es.pruneRecent(time.Now())
+
+	mx["events_normal"] = es.normal
+	mx["events_warning"] = es.warning
+
+	c.collectEventCounters(mx, eventsWarningByReasonChartTmpl.ID, "events_warning_reason_", es.warningByReason)
+	c.collectEventCounters(mx, eventsWarningByKindChartTmpl.ID, "events_warning_kind_", es.warningByKind)
+	c.collectEventCounters(mx, eventsWarningByNamespaceChartTmpl.ID, "events_warning_namespace_", es.warningByNamespace)
+}
+
+func (c *Collector) collectEventCounters(mx map[string]int64, chartID, prefix string, counters map[string]int64) {
+	for _, key := range slices.Sorted(maps.Keys(counters)) {
+		id := prefix + key
+		mx[id] = counters[key]
+		c.addEventDim(chartID, id, key)
+	}
+}
+
 func condStatusToInt(cs corev1.ConditionStatus) int64 {
 	return oldmetrix.Bool(cs == corev1.ConditionTrue)
 }
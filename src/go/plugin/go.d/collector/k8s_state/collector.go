@@ -24,13 +24,15 @@ func init() {
 		Defaults: collectorapi.Defaults{
 			Disabled: true,
 		},
-		Create: func() collectorapi.CollectorV1 { return New() },
-		Config: func() any { return &Config{} },
+		Create:          func() collectorapi.CollectorV1 { return New() },
+		Config:          func() any { return &Config{} },
+		SharedFunctions: k8sStateMethods,
+		MethodHandler:   k8sStateFunctionHandler,
 	})
 }
 
 func New() *Collector {
-	return &Collector{
+	c := &Collector{
 		initDelay:     time.Second * 10,
 		newKubeClient: newKubeClient,
 		charts:        baseCharts.Copy(),
@@ -38,6 +40,9 @@ func New() *Collector {
 		wg:            &sync.WaitGroup{},
 		state:         newKubeState(),
 	}
+	c.funcRouter = newFuncRouter(c)
+
+	return c
 }
 
 type Config struct {
@@ -64,6 +69,8 @@ type Collector struct {
 	kubeClusterName string
 
 	state *kubeState
+
+	funcRouter *funcRouter
 }
 
 func (c *Collector) Configuration() any {
@@ -115,7 +122,11 @@ func (c *Collector) Collect(context.Context) map[string]int64 {
 	return ms
 }
 
-func (c *Collector) Cleanup(context.Context) {
+func (c *Collector) Cleanup(ctx context.Context) {
+	if c.funcRouter != nil {
+		c.funcRouter.Cleanup(ctx)
+	}
+
 	if c.ctxCancel == nil {
 		return
 	}
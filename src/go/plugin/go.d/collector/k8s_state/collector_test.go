@@ -20,13 +20,19 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apiresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 var (
@@ -589,6 +595,143 @@ func TestCollector_Collect(t *testing.T) {
 				}
 			},
 		},
+		"StatefulSets, DaemonSets, PVCs, HPAs and ResourceQuotas": {
+			create: func(t *testing.T) testCase {
+				sts := newStatefulSet("sts01")
+				ds := newDaemonSet("ds01")
+				pvc := newPVC("pvc01")
+				hpa := newHPA("hpa01")
+				quota := newResourceQuota("quota01")
+
+				client := fake.NewClientset(
+					sts,
+					ds,
+					pvc,
+					hpa,
+					quota,
+				)
+
+				step1 := func(t *testing.T, collr *Collector) {
+					mx := collr.Collect(context.Background())
+					expected := map[string]int64{
+						"discovery_node_discoverer_state":                1,
+						"discovery_pod_discoverer_state":                 1,
+						"ds_default_ds01_age":                            10,
+						"ds_default_ds01_available":                      2,
+						"ds_default_ds01_current_scheduled":              3,
+						"ds_default_ds01_desired_scheduled":              3,
+						"ds_default_ds01_misscheduled":                   0,
+						"ds_default_ds01_ready":                          2,
+						"ds_default_ds01_unavailable":                    1,
+						"ds_default_ds01_updated_scheduled":              3,
+						"hpa_default_hpa01_age":                          10,
+						"hpa_default_hpa01_condition_able_to_scale":      1,
+						"hpa_default_hpa01_condition_scaling_active":     1,
+						"hpa_default_hpa01_condition_scaling_limited":    1,
+						"hpa_default_hpa01_current_replicas":             5,
+						"hpa_default_hpa01_desired_replicas":             5,
+						"hpa_default_hpa01_max_replicas":                 5,
+						"hpa_default_hpa01_min_replicas":                 1,
+						"pvc_default_pvc01_age":                          10,
+						"pvc_default_pvc01_phase_bound":                  1,
+						"pvc_default_pvc01_phase_lost":                   0,
+						"pvc_default_pvc01_phase_pending":                0,
+						"pvc_default_pvc01_storage_capacity":             2147483648,
+						"pvc_default_pvc01_storage_requested":            1073741824,
+						"quota_default_quota01_pods_hard":                10000,
+						"quota_default_quota01_pods_used":                5000,
+						"quota_default_quota01_pods_utilization":         50000,
+						"quota_default_quota01_requests.cpu_hard":        2000,
+						"quota_default_quota01_requests.cpu_used":        1500,
+						"quota_default_quota01_requests.cpu_utilization": 75000,
+						"sts_default_sts01_age":                          10,
+						"sts_default_sts01_available_replicas":           2,
+						"sts_default_sts01_current_replicas":             3,
+						"sts_default_sts01_desired_replicas":             3,
+						"sts_default_sts01_ready_replicas":               2,
+						"sts_default_sts01_updated_replicas":             3,
+					}
+
+					copyIfSuffix(expected, mx, "age")
+
+					assert.Equal(t, expected, mx)
+					assert.Equal(t,
+						len(statefulSetChartsTmpl)+
+							len(daemonSetChartsTmpl)+
+							len(pvcChartsTmpl)+
+							len(hpaChartsTmpl)+
+							len(resourceQuotaChartsTmpl)*len(quota.Status.Hard)+
+							len(baseCharts),
+						len(*collr.Charts()),
+					)
+					collecttest.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+				}
+
+				return testCase{
+					client: client,
+					steps:  []testCaseStep{step1},
+				}
+			},
+		},
+		"Events": {
+			create: func(t *testing.T) testCase {
+				ctx := context.Background()
+				seeded := newCoreEvent("event01", corev1.EventTypeWarning, "FailedScheduling", "Pod", "pod01")
+				client := fake.NewClientset(
+					seeded,
+					newEventsV1Event(seeded),
+				)
+
+				step1 := func(t *testing.T, collr *Collector) {
+					mx := collr.Collect(context.Background())
+					expected := map[string]int64{
+						"discovery_node_discoverer_state": 1,
+						"discovery_pod_discoverer_state":  1,
+						"events_normal":                   0,
+						"events_warning":                  0,
+					}
+
+					assert.Equal(t, expected, mx)
+					assert.Equal(t, len(eventChartsTmpl)+len(baseCharts), len(*collr.Charts()))
+
+					updated := seeded.DeepCopy()
+					updated.Count = 3
+					updated.LastTimestamp = metav1.Time{Time: time.Now()}
+					_, _ = client.CoreV1().Events(updated.Namespace).Update(ctx, updated, metav1.UpdateOptions{})
+
+					created := newCoreEvent("event02", corev1.EventTypeWarning, "BackOff", "Pod", "pod02")
+					_, _ = client.CoreV1().Events(created.Namespace).Create(ctx, created, metav1.CreateOptions{})
+
+					normal := newCoreEvent("event03", corev1.EventTypeNormal, "Pulled", "Pod", "pod02")
+					_, _ = client.CoreV1().Events(normal.Namespace).Create(ctx, normal, metav1.CreateOptions{})
+				}
+
+				step2 := func(t *testing.T, collr *Collector) {
+					mx := collr.Collect(context.Background())
+					expected := map[string]int64{
+						"discovery_node_discoverer_state":        1,
+						"discovery_pod_discoverer_state":         1,
+						"events_normal":                          1,
+						"events_warning":                         3,
+						"events_warning_kind_Pod":                3,
+						"events_warning_namespace_default":       3,
+						"events_warning_reason_BackOff":          1,
+						"events_warning_reason_FailedScheduling": 2,
+					}
+
+					assert.Equal(t, expected, mx)
+					collecttest.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+
+					assert.Len(t, collr.recentEvents(false), 3)
+					assert.Len(t, collr.recentEvents(true), 2)
+				}
+
+				return testCase{
+					client: client,
+					steps:  []testCaseStep{step1, step2},
+				}
+			},
+		},
 		"delete a Pod in runtime": {
 			create: func(t *testing.T) testCase {
 				ctx := context.Background()
@@ -951,6 +1094,31 @@ func TestCollector_Collect(t *testing.T) {
 	}
 }
 
+func TestCollector_Collect_OptionalResourceForbidden(t *testing.T) {
+	client := fake.NewClientset(newNode("node01"), newStatefulSet("sts01"))
+	client.PrependReactor("list", "statefulsets", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "statefulsets"}, "", errors.New("rbac"))
+	})
+
+	collr := New()
+	collr.initDelay = time.Second
+	collr.newKubeClient = func() (kubernetes.Interface, error) { return client, nil }
+
+	require.NoError(t, collr.Init(context.Background()))
+	require.NoError(t, collr.Check(context.Background()))
+	defer collr.Cleanup(context.Background())
+
+	_ = collr.Collect(context.Background())
+	time.Sleep(collr.initDelay)
+
+	mx := collr.Collect(context.Background())
+
+	assert.Contains(t, mx, "node_node01_age", "a forbidden optional resource must not block the other metrics")
+	for k := range mx {
+		assert.Falsef(t, strings.HasPrefix(k, "sts_"), "metric '%s'", k)
+	}
+}
+
 func newNode(name string) *corev1.Node {
 	return &corev1.Node{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1090,6 +1258,157 @@ func newDeployment(name string) *appsv1.Deployment {
 	}
 }
 
+func newStatefulSet(name string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         corev1.NamespaceDefault,
+			UID:               types.UID(name),
+			CreationTimestamp: metav1.Time{Time: time.Now()},
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: new(int32(3)),
+		},
+		Status: appsv1.StatefulSetStatus{
+			CurrentReplicas:   3,
+			UpdatedReplicas:   3,
+			ReadyReplicas:     2,
+			AvailableReplicas: 2,
+		},
+	}
+}
+
+func newDaemonSet(name string) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         corev1.NamespaceDefault,
+			UID:               types.UID(name),
+			CreationTimestamp: metav1.Time{Time: time.Now()},
+		},
+		Status: appsv1.DaemonSetStatus{
+			DesiredNumberScheduled: 3,
+			CurrentNumberScheduled: 3,
+			UpdatedNumberScheduled: 3,
+			NumberReady:            2,
+			NumberAvailable:        2,
+			NumberUnavailable:      1,
+		},
+	}
+}
+
+func newPVC(name string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         corev1.NamespaceDefault,
+			UID:               types.UID(name),
+			CreationTimestamp: metav1.Time{Time: time.Now()},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: new("standard"),
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: mustQuantity("1Gi"),
+				},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Phase: corev1.ClaimBound,
+			Capacity: corev1.ResourceList{
+				corev1.ResourceStorage: mustQuantity("2Gi"),
+			},
+		},
+	}
+}
+
+func newHPA(name string) *autoscalingv2.HorizontalPodAutoscaler {
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         corev1.NamespaceDefault,
+			UID:               types.UID(name),
+			CreationTimestamp: metav1.Time{Time: time.Now()},
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				Kind: "Deployment",
+				Name: "deploy01",
+			},
+			MaxReplicas: 5,
+		},
+		Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+			CurrentReplicas: 5,
+			DesiredReplicas: 5,
+			Conditions: []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{Type: autoscalingv2.AbleToScale, Status: corev1.ConditionTrue},
+				{Type: autoscalingv2.ScalingActive, Status: corev1.ConditionTrue},
+				{Type: autoscalingv2.ScalingLimited, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+}
+
+func newResourceQuota(name string) *corev1.ResourceQuota {
+	return &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         corev1.NamespaceDefault,
+			UID:               types.UID(name),
+			CreationTimestamp: metav1.Time{Time: time.Now()},
+		},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{
+				corev1.ResourceRequestsCPU: mustQuantity("2"),
+				corev1.ResourcePods:        mustQuantity("10"),
+			},
+			Used: corev1.ResourceList{
+				corev1.ResourceRequestsCPU: mustQuantity("1500m"),
+				corev1.ResourcePods:        mustQuantity("5"),
+			},
+		},
+	}
+}
+
+func newCoreEvent(name, typ, reason, objKind, objName string) *corev1.Event {
+	now := time.Now()
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         corev1.NamespaceDefault,
+			UID:               types.UID(name),
+			CreationTimestamp: metav1.Time{Time: now},
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      objKind,
+			Name:      objName,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Type:           typ,
+		Reason:         reason,
+		Message:        reason + " " + objName,
+		Source:         corev1.EventSource{Component: "kubelet"},
+		Count:          1,
+		FirstTimestamp: metav1.Time{Time: now},
+		LastTimestamp:  metav1.Time{Time: now},
+	}
+}
+
+// newEventsV1Event returns the events.k8s.io/v1 view of the same Event object.
+func newEventsV1Event(ev *corev1.Event) *eventsv1.Event {
+	return &eventsv1.Event{
+		ObjectMeta:               ev.ObjectMeta,
+		Regarding:                ev.InvolvedObject,
+		Type:                     ev.Type,
+		Reason:                   ev.Reason,
+		Note:                     ev.Message,
+		DeprecatedSource:         ev.Source,
+		DeprecatedCount:          ev.Count,
+		DeprecatedFirstTimestamp: ev.FirstTimestamp,
+		DeprecatedLastTimestamp:  ev.LastTimestamp,
+	}
+}
+
 func prepareCronJob(name string) *batchv1.CronJob {
 	return &batchv1.CronJob{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package k8s_state
+
+import (
+	"context"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/netdata/netdata/go/plugins/logger"
+)
+
+func newDaemonSetDiscoverer(si cache.SharedInformer, l *logger.Logger) *daemonSetDiscoverer {
+	if si == nil {
+		panic("nil daemonset shared informer")
+	}
+
+	queue := workqueue.NewTypedWithConfig(workqueue.TypedQueueConfig[string]{Name: "daemonset"})
+
+	_, _ = si.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj any) { enqueue(queue, obj) },
+		UpdateFunc: func(_, obj any) { enqueue(queue, obj) },
+		DeleteFunc: func(obj any) { enqueue(queue, obj) },
+	})
+
+	return &daemonSetDiscoverer{
+		Logger:   l,
+		informer: si,
+		queue:    queue,
+		readyCh:  make(chan struct{}),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+type dsResource struct {
+	src string
+	val any
+}
+
+func (r dsResource) source() string         { return r.src }
+func (r dsResource) kind() kubeResourceKind { return kubeResourceDaemonSet }
+func (r dsResource) value() any             { return r.val }
+
+type daemonSetDiscoverer struct {
+	*logger.Logger
+	informer cache.SharedInformer
+	queue    *workqueue.Typed[string]
+	readyCh  chan struct{}
+	stopCh   chan struct{}
+}
+
+func (d *daemonSetDiscoverer) run(ctx context.Context, in chan<- resource) {
+	d.Info("daemonset_discoverer is started")
+	defer func() { close(d.stopCh); d.Info("daemonset_discoverer is stopped") }()
+
+	defer d.queue.ShutDown()
+
+	go d.informer.Run(ctx.Done())
+
+	// DaemonSets are optional (missing RBAC permissions must not block the other discoverers),
+	// their charts are added once the informer syncs.
+	close(d.readyCh)
+
+	if !cache.WaitForCacheSync(ctx.Done(), d.informer.HasSynced) {
+		return
+	}
+
+	go d.runDiscover(ctx, in)
+
+	<-ctx.Done()
+}
+
+func (d *daemonSetDiscoverer) runDiscover(ctx context.Context, in chan<- resource) {
+	for {
+		key, shutdown := d.queue.Get()
+		if shutdown {
+			return
+		}
+
+		func() {
+			defer d.queue.Done(key)
+
+			ns, name, err := cache.SplitMetaNamespaceKey(key)
+			if err != nil {
+				return
+			}
+
+			item, exists, err := d.informer.GetStore().GetByKey(key)
+			if err != nil {
+				return
+			}
+
+			r := &dsResource{src: daemonSetSource(ns, name)}
+			if exists {
+				r.val = item
+			}
+			send(ctx, in, r)
+		}()
+	}
+}
+
+func daemonSetSource(namespace, name string) string {
+	return "k8s/ds/" + namespace + "/" + name
+}
+
+func (d *daemonSetDiscoverer) ready() bool   { return isChanClosed(d.readyCh) }
+func (d *daemonSetDiscoverer) stopped() bool { return isChanClosed(d.stopCh) }
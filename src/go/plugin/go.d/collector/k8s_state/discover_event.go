@@ -0,0 +1,321 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package k8s_state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/logger"
+
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	eventListPageSize  = 500
+	eventRetryInterval = 30 * time.Second
+)
+
+// Events are not watched through a SharedInformer: its store would keep every Event in the cluster
+// (they live for an hour by default) in memory. Instead, each API is listed once to seed the state and
+// then watched directly; the bounded eventsState is the only place Events are kept.
+func newEventDiscoverer(client kubernetes.Interface, l *logger.Logger) *eventDiscoverer {
+	if client == nil {
+		panic("nil event client")
+	}
+
+	return &eventDiscoverer{
+		Logger: l,
+		apis: []eventAPI{
+			newCoreV1EventAPI(client),
+			newEventsV1EventAPI(client),
+		},
+		readyCh: make(chan struct{}),
+		stopCh:  make(chan struct{}),
+	}
+}
+
+type eventResource struct {
+	src string
+	val any
+}
+
+func (r eventResource) source() string         { return r.src }
+func (r eventResource) kind() kubeResourceKind { return kubeResourceEvent }
+func (r eventResource) value() any             { return r.val }
+
+// eventAPI abstracts the core/v1 and events.k8s.io/v1 Events APIs.
+// Both expose the same underlying objects, so the state deduplicates by UID and count.
+type eventAPI struct {
+	name  string
+	list  func(ctx context.Context, opts metav1.ListOptions) ([]*kubeEvent, string, string, error)
+	watch func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	conv  func(obj any) *kubeEvent
+}
+
+type eventDiscoverer struct {
+	*logger.Logger
+	apis    []eventAPI
+	readyCh chan struct{}
+	stopCh  chan struct{}
+}
+
+func (d *eventDiscoverer) run(ctx context.Context, in chan<- resource) {
+	d.Info("event_discoverer is started")
+	defer func() { close(d.stopCh); d.Info("event_discoverer is stopped") }()
+
+	var wg sync.WaitGroup
+	for _, api := range d.apis {
+		wg.Go(func() { d.runAPI(ctx, in, api) })
+	}
+
+	// Events are optional (missing RBAC permissions or API groups must not block the other discoverers).
+	close(d.readyCh)
+
+	wg.Wait()
+	<-ctx.Done()
+}
+
+func (d *eventDiscoverer) runAPI(ctx context.Context, in chan<- resource, api eventAPI) {
+	var warned bool
+
+	for {
+		rv, err := d.listAPI(ctx, in, api)
+		if err == nil {
+			warned = false
+			// keep watching from the last seen resource version until the watch expires or fails
+			for err == nil && ctx.Err() == nil {
+				rv, err = d.watchAPI(ctx, in, api, rv)
+				if err == nil {
+					select {
+					case <-ctx.Done():
+					case <-time.After(time.Second):
+					}
+				}
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if errors.Is(err, errEventWatchExpired) {
+			d.Debugf("event_discoverer: %s events: %v, relisting", api.name, err)
+			continue
+		}
+		if !warned {
+			d.Warningf("event_discoverer: %s events: %v (retrying every %s)", api.name, err, eventRetryInterval)
+			warned = true
+		} else {
+			d.Debugf("event_discoverer: %s events: %v", api.name, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(eventRetryInterval):
+		}
+	}
+}
+
+// listAPI seeds the state with existing Events page by page and returns the list resource version.
+// Seeded Events fill the recent events table but are not counted in the event rates.
+func (d *eventDiscoverer) listAPI(ctx context.Context, in chan<- resource, api eventAPI) (string, error) {
+	opts := metav1.ListOptions{Limit: eventListPageSize}
+
+	for {
+		events, rv, next, err := api.list(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		for _, ev := range events {
+			ev.seed = true
+			send(ctx, in, &eventResource{src: eventSource(ev.key()), val: ev})
+		}
+		if next == "" {
+			return rv, nil
+		}
+		opts.Continue = next
+	}
+}
+
+var errEventWatchExpired = errors.New("watch expired")
+
+// watchAPI streams Events until the server closes the watch. It returns the last seen resource version.
+func (d *eventDiscoverer) watchAPI(ctx context.Context, in chan<- resource, api eventAPI, rv string) (string, error) {
+	w, err := api.watch(ctx, metav1.ListOptions{ResourceVersion: rv, AllowWatchBookmarks: true})
+	if err != nil {
+		return rv, err
+	}
+	defer w.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return rv, nil
+		case e, ok := <-w.ResultChan():
+			if !ok {
+				return rv, nil
+			}
+			switch e.Type {
+			case watch.Added, watch.Modified:
+				if ev := api.conv(e.Object); ev != nil {
+					rv = firstNotEmpty(ev.resourceVersion, rv)
+					send(ctx, in, &eventResource{src: eventSource(ev.key()), val: ev})
+				}
+			case watch.Bookmark:
+				if m, err := meta.Accessor(e.Object); err == nil {
+					rv = firstNotEmpty(m.GetResourceVersion(), rv)
+				}
+			case watch.Error:
+				return rv, fmt.Errorf("%w: %v", errEventWatchExpired, apierrors.FromObject(e.Object))
+			}
+		}
+	}
+}
+
+func eventSource(key string) string {
+	return "k8s/event/" + key
+}
+
+func (d *eventDiscoverer) ready() bool   { return isChanClosed(d.readyCh) }
+func (d *eventDiscoverer) stopped() bool { return isChanClosed(d.stopCh) }
+
+func newCoreV1EventAPI(client kubernetes.Interface) eventAPI {
+	events := client.CoreV1().Events(corev1.NamespaceAll)
+
+	return eventAPI{
+		name: "core/v1",
+		list: func(ctx context.Context, opts metav1.ListOptions) ([]*kubeEvent, string, string, error) {
+			list, err := events.List(ctx, opts)
+			if err != nil {
+				return nil, "", "", err
+			}
+			out := make([]*kubeEvent, 0, len(list.Items))
+			for i := range list.Items {
+				out = append(out, coreV1ToKubeEvent(&list.Items[i]))
+			}
+			return out, list.ResourceVersion, list.Continue, nil
+		},
+		watch: func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+			return events.Watch(ctx, opts)
+		},
+		conv: func(obj any) *kubeEvent {
+			if ev, ok := obj.(*corev1.Event); ok {
+				return coreV1ToKubeEvent(ev)
+			}
+			return nil
+		},
+	}
+}
+
+func newEventsV1EventAPI(client kubernetes.Interface) eventAPI {
+	events := client.EventsV1().Events(corev1.NamespaceAll)
+
+	return eventAPI{
+		name: "events.k8s.io/v1",
+		list: func(ctx context.Context, opts metav1.ListOptions) ([]*kubeEvent, string, string, error) {
+			list, err := events.List(ctx, opts)
+			if err != nil {
+				return nil, "", "", err
+			}
+			out := make([]*kubeEvent, 0, len(list.Items))
+			for i := range list.Items {
+				out = append(out, eventsV1ToKubeEvent(&list.Items[i]))
+			}
+			return out, list.ResourceVersion, list.Continue, nil
+		},
+		watch: func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+			return events.Watch(ctx, opts)
+		},
+		conv: func(obj any) *kubeEvent {
+			if ev, ok := obj.(*eventsv1.Event); ok {
+				return eventsV1ToKubeEvent(ev)
+			}
+			return nil
+		},
+	}
+}
+
+func coreV1ToKubeEvent(ev *corev1.Event) *kubeEvent {
+	ke := &kubeEvent{
+		uid:             string(ev.UID),
+		resourceVersion: ev.ResourceVersion,
+		name:            ev.Name,
+		namespace:       ev.Namespace,
+		eventType:       ev.Type,
+		reason:          ev.Reason,
+		message:         ev.Message,
+		objKind:         ev.InvolvedObject.Kind,
+		objName:         ev.InvolvedObject.Name,
+		objNamespace:    ev.InvolvedObject.Namespace,
+		source:          firstNotEmpty(ev.ReportingController, ev.Source.Component),
+		count:           int64(ev.Count),
+		firstSeen:       ev.FirstTimestamp.Time,
+		lastSeen:        ev.LastTimestamp.Time,
+	}
+	if ev.Series != nil {
+		ke.count = int64(ev.Series.Count)
+		ke.lastSeen = ev.Series.LastObservedTime.Time
+	}
+	if ke.firstSeen.IsZero() {
+		ke.firstSeen = firstNotZeroTime(ev.EventTime.Time, ev.CreationTimestamp.Time)
+	}
+	if ke.lastSeen.IsZero() {
+		ke.lastSeen = ke.firstSeen
+	}
+	ke.count = max(ke.count, 1)
+	return ke
+}
+
+func eventsV1ToKubeEvent(ev *eventsv1.Event) *kubeEvent {
+	ke := &kubeEvent{
+		uid:             string(ev.UID),
+		resourceVersion: ev.ResourceVersion,
+		name:            ev.Name,
+		namespace:       ev.Namespace,
+		eventType:       ev.Type,
+		reason:          ev.Reason,
+		message:         ev.Note,
+		objKind:         ev.Regarding.Kind,
+		objName:         ev.Regarding.Name,
+		objNamespace:    ev.Regarding.Namespace,
+		source:          firstNotEmpty(ev.ReportingController, ev.DeprecatedSource.Component),
+		count:           int64(ev.DeprecatedCount),
+		firstSeen:       firstNotZeroTime(ev.DeprecatedFirstTimestamp.Time, ev.EventTime.Time, ev.CreationTimestamp.Time),
+		lastSeen:        ev.DeprecatedLastTimestamp.Time,
+	}
+	if ev.Series != nil {
+		ke.count = int64(ev.Series.Count)
+		ke.lastSeen = ev.Series.LastObservedTime.Time
+	}
+	if ke.lastSeen.IsZero() {
+		ke.lastSeen = ke.firstSeen
+	}
+	ke.count = max(ke.count, 1)
+	return ke
+}
+
+func firstNotEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func firstNotZeroTime(values ...time.Time) time.Time {
+	for _, v := range values {
+		if !v.IsZero() {
+			return v
+		}
+	}
+	return time.Time{}
+}
@@ -6,13 +6,16 @@ import (
 	"context"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/netdata/netdata/go/plugins/logger"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
@@ -167,15 +170,87 @@ func (d *kubeDiscovery) setupDiscoverers(ctx context.Context) []discoverer {
 		},
 	}, d.client)
 
+	sts := d.client.AppsV1().StatefulSets(corev1.NamespaceAll)
+	stsWatcher := cache.ToListWatcherWithWatchListSemantics(&cache.ListWatch{
+		ListWithContextFunc: func(_ context.Context, options metav1.ListOptions) (runtime.Object, error) {
+			return sts.List(ctx, options)
+		},
+		WatchFuncWithContext: func(_ context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			return sts.Watch(ctx, options)
+		},
+	}, d.client)
+
+	ds := d.client.AppsV1().DaemonSets(corev1.NamespaceAll)
+	dsWatcher := cache.ToListWatcherWithWatchListSemantics(&cache.ListWatch{
+		ListWithContextFunc: func(_ context.Context, options metav1.ListOptions) (runtime.Object, error) {
+			return ds.List(ctx, options)
+		},
+		WatchFuncWithContext: func(_ context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			return ds.Watch(ctx, options)
+		},
+	}, d.client)
+
+	pvc := d.client.CoreV1().PersistentVolumeClaims(corev1.NamespaceAll)
+	pvcWatcher := cache.ToListWatcherWithWatchListSemantics(&cache.ListWatch{
+		ListWithContextFunc: func(_ context.Context, options metav1.ListOptions) (runtime.Object, error) {
+			return pvc.List(ctx, options)
+		},
+		WatchFuncWithContext: func(_ context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			return pvc.Watch(ctx, options)
+		},
+	}, d.client)
+
+	hpa := d.client.AutoscalingV2().HorizontalPodAutoscalers(corev1.NamespaceAll)
+	hpaWatcher := cache.ToListWatcherWithWatchListSemantics(&cache.ListWatch{
+		ListWithContextFunc: func(_ context.Context, options metav1.ListOptions) (runtime.Object, error) {
+			return hpa.List(ctx, options)
+		},
+		WatchFuncWithContext: func(_ context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			return hpa.Watch(ctx, options)
+		},
+	}, d.client)
+
+	quota := d.client.CoreV1().ResourceQuotas(corev1.NamespaceAll)
+	quotaWatcher := cache.ToListWatcherWithWatchListSemantics(&cache.ListWatch{
+		ListWithContextFunc: func(_ context.Context, options metav1.ListOptions) (runtime.Object, error) {
+			return quota.List(ctx, options)
+		},
+		WatchFuncWithContext: func(_ context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			return quota.Watch(ctx, options)
+		},
+	}, d.client)
+
 	return []discoverer{
 		newNodeDiscoverer(cache.NewSharedInformer(nodeWatcher, &corev1.Node{}, resyncPeriod), d.Logger),
 		newPodDiscoverer(cache.NewSharedInformer(podWatcher, &corev1.Pod{}, resyncPeriod), d.Logger),
 		newDeploymentDiscoverer(cache.NewSharedInformer(deployWatcher, &appsv1.Deployment{}, resyncPeriod), d.Logger),
 		newCronJobDiscoverer(cache.NewSharedInformer(cjWatcher, &batchv1.CronJob{}, resyncPeriod), d.Logger),
 		newJobDiscoverer(cache.NewSharedInformer(jobsWatcher, &batchv1.Job{}, resyncPeriod), d.Logger),
+		newStatefulSetDiscoverer(d.optionalInformer(stsWatcher, &appsv1.StatefulSet{}, "statefulsets"), d.Logger),
+		newDaemonSetDiscoverer(d.optionalInformer(dsWatcher, &appsv1.DaemonSet{}, "daemonsets"), d.Logger),
+		newPVCDiscoverer(d.optionalInformer(pvcWatcher, &corev1.PersistentVolumeClaim{}, "persistentvolumeclaims"), d.Logger),
+		newHPADiscoverer(d.optionalInformer(hpaWatcher, &autoscalingv2.HorizontalPodAutoscaler{}, "horizontalpodautoscalers"), d.Logger),
+		newResourceQuotaDiscoverer(d.optionalInformer(quotaWatcher, &corev1.ResourceQuota{}, "resourcequotas"), d.Logger),
+		newEventDiscoverer(d.client, d.Logger),
 	}
 }
 
+// optionalInformer returns an informer for a resource that the Netdata ServiceAccount may not be allowed
+// to list or watch. The informer keeps retrying in the background; a denied request is logged once.
+func (d *kubeDiscovery) optionalInformer(lw cache.ListerWatcher, obj runtime.Object, resource string) cache.SharedInformer {
+	si := cache.NewSharedInformer(lw, obj, resyncPeriod)
+
+	var warned atomic.Bool
+	_ = si.SetWatchErrorHandlerWithContext(func(ctx context.Context, r *cache.Reflector, err error) {
+		if apierrors.IsForbidden(err) && !warned.Swap(true) {
+			d.Warningf("not allowed to list/watch %s, their metrics are not collected (check the ClusterRole): %v", resource, err)
+		}
+		cache.DefaultWatchErrorHandler(ctx, r, err)
+	})
+
+	return si
+}
+
 func enqueue(queue *workqueue.Typed[string], obj any) {
 	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
 	if err != nil {
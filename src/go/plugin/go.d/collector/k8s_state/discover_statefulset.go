@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package k8s_state
+
+import (
+	"context"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/netdata/netdata/go/plugins/logger"
+)
+
+func newStatefulSetDiscoverer(si cache.SharedInformer, l *logger.Logger) *statefulSetDiscoverer {
+	if si == nil {
+		panic("nil statefulset shared informer")
+	}
+
+	queue := workqueue.NewTypedWithConfig(workqueue.TypedQueueConfig[string]{Name: "statefulset"})
+
+	_, _ = si.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj any) { enqueue(queue, obj) },
+		UpdateFunc: func(_, obj any) { enqueue(queue, obj) },
+		DeleteFunc: func(obj any) { enqueue(queue, obj) },
+	})
+
+	return &statefulSetDiscoverer{
+		Logger:   l,
+		informer: si,
+		queue:    queue,
+		readyCh:  make(chan struct{}),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+type stsResource struct {
+	src string
+	val any
+}
+
+func (r stsResource) source() string         { return r.src }
+func (r stsResource) kind() kubeResourceKind { return kubeResourceStatefulSet }
+func (r stsResource) value() any             { return r.val }
+
+type statefulSetDiscoverer struct {
+	*logger.Logger
+	informer cache.SharedInformer
+	queue    *workqueue.Typed[string]
+	readyCh  chan struct{}
+	stopCh   chan struct{}
+}
+
+func (d *statefulSetDiscoverer) run(ctx context.Context, in chan<- resource) {
+	d.Info("statefulset_discoverer is started")
+	defer func() { close(d.stopCh); d.Info("statefulset_discoverer is stopped") }()
+
+	defer d.queue.ShutDown()
+
+	go d.informer.Run(ctx.Done())
+
+	// StatefulSets are optional (missing RBAC permissions must not block the other discoverers),
+	// their charts are added once the informer syncs.
+	close(d.readyCh)
+
+	if !cache.WaitForCacheSync(ctx.Done(), d.informer.HasSynced) {
+		return
+	}
+
+	go d.runDiscover(ctx, in)
+
+	<-ctx.Done()
+}
+
+func (d *statefulSetDiscoverer) runDiscover(ctx context.Context, in chan<- resource) {
+	for {
+		key, shutdown := d.queue.Get()
+		if shutdown {
+			return
+		}
+
+		func() {
+			defer d.queue.Done(key)
+
+			ns, name, err := cache.SplitMetaNamespaceKey(key)
+			if err != nil {
+				return
+			}
+
+			item, exists, err := d.informer.GetStore().GetByKey(key)
+			if err != nil {
+				return
+			}
+
+			r := &stsResource{src: statefulSetSource(ns, name)}
+			if exists {
+				r.val = item
+			}
+			send(ctx, in, r)
+		}()
+	}
+}
+
+func statefulSetSource(namespace, name string) string {
+	return "k8s/sts/" + namespace + "/" + name
+}
+
+func (d *statefulSetDiscoverer) ready() bool   { return isChanClosed(d.readyCh) }
+func (d *statefulSetDiscoverer) stopped() bool { return isChanClosed(d.stopCh) }
@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package k8s_state
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/netdata/netdata/go/plugins/pkg/funcapi"
+)
+
+const eventsMethodID = "events"
+
+const eventsHelp = "Recently seen Kubernetes Events (core/v1 and events.k8s.io/v1), newest first. " +
+	"At most 1000 Events from the last hour are kept."
+
+const (
+	eventsParamType        = "type"
+	eventsParamTypeWarning = "warning"
+	eventsParamTypeAll     = "all"
+)
+
+func eventsFunctionConfig() funcapi.FunctionConfig {
+	return funcapi.FunctionConfig{
+		ID:             eventsMethodID,
+		Name:           "Events",
+		UpdateEvery:    10,
+		Help:           eventsHelp,
+		RequiredParams: []funcapi.ParamConfig{eventsTypeParam()},
+	}
+}
+
+func eventsTypeParam() funcapi.ParamConfig {
+	return funcapi.ParamConfig{
+		ID:        eventsParamType,
+		Name:      "Event type",
+		Help:      "Show only Warning events or all events",
+		Selection: funcapi.ParamSelect,
+		Options: []funcapi.ParamOption{
+			{ID: eventsParamTypeWarning, Name: "Warning", Default: true},
+			{ID: eventsParamTypeAll, Name: "All"},
+		},
+	}
+}
+
+// Compile-time interface check.
+var _ funcapi.MethodHandler = (*funcEvents)(nil)
+
+// funcEvents handles the "events" function for Kubernetes state.
+type funcEvents struct {
+	router *funcRouter
+}
+
+func newFuncEvents(r *funcRouter) *funcEvents {
+	return &funcEvents{router: r}
+}
+
+// MethodParams implements funcapi.MethodHandler.
+func (f *funcEvents) MethodParams(_ context.Context, method string) ([]funcapi.ParamConfig, error) {
+	if method != eventsMethodID {
+		return nil, fmt.Errorf("unknown method: %s", method)
+	}
+	return []funcapi.ParamConfig{eventsTypeParam()}, nil
+}
+
+// Handle implements funcapi.MethodHandler.
+func (f *funcEvents) Handle(_ context.Context, method string, params funcapi.ResolvedParams) *funcapi.FunctionResponse {
+	if method != eventsMethodID {
+		return funcapi.NotFoundResponse(method)
+	}
+
+	c := f.router.collector
+	if c.state == nil {
+		return funcapi.UnavailableResponse("collector is still initializing, please retry in a few seconds")
+	}
+
+	warningOnly := params.GetOne(eventsParamType) != eventsParamTypeAll
+	events := c.recentEvents(warningOnly)
+
+	cs := eventsColumnSet(eventsColumns)
+	data := make([][]any, 0, len(events))
+	for _, ev := range events {
+		row := make([]any, len(eventsColumns))
+		for i, col := range eventsColumns {
+			row[i] = col.Value(ev)
+		}
+		data = append(data, row)
+	}
+
+	return &funcapi.FunctionResponse{
+		Status:            200,
+		Help:              eventsHelp,
+		Columns:           cs.BuildColumns(),
+		Data:              data,
+		DefaultSortColumn: "Last Seen",
+	}
+}
+
+// Cleanup implements funcapi.MethodHandler.
+func (f *funcEvents) Cleanup(context.Context) {}
+
+// recentEvents returns a copy of the recently seen Events, newest first.
+func (c *Collector) recentEvents(warningOnly bool) []kubeEvent {
+	c.state.Lock()
+	defer c.state.Unlock()
+
+	events := make([]kubeEvent, 0, len(c.state.events.recent))
+	for _, ev := range c.state.events.recent {
+		if warningOnly && ev.eventType != corev1.EventTypeWarning {
+			continue
+		}
+		events = append(events, *ev)
+	}
+
+	slices.SortFunc(events, func(a, b kubeEvent) int {
+		if v := b.lastSeen.Compare(a.lastSeen); v != 0 {
+			return v
+		}
+		return strings.Compare(a.key(), b.key())
+	})
+
+	return events
+}
+
+type eventsColumn struct {
+	funcapi.ColumnMeta
+	Value func(kubeEvent) any
+}
+
+func eventsColumnSet(cols []eventsColumn) funcapi.ColumnSet[eventsColumn] {
+	return funcapi.Columns(cols, func(c eventsColumn) funcapi.ColumnMeta { return c.ColumnMeta })
+}
+
+var eventsColumns = []eventsColumn{
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Last Seen", Tooltip: "Time of the last occurrence", Type: funcapi.FieldTypeTimestamp, Visible: true, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummaryMax, Filter: funcapi.FieldFilterRange, Sortable: true, Sticky: true, Transform: funcapi.FieldTransformDatetime}, Value: func(e kubeEvent) any { return timeMilliCell(e.lastSeen) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Type", Tooltip: "Event type", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true, Visualization: funcapi.FieldVisualPill}, Value: func(e kubeEvent) any { return e.eventType }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Reason", Tooltip: "Short machine-readable reason (e.g. BackOff, FailedScheduling, OOMKilling)", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true}, Value: func(e kubeEvent) any { return e.reason }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Object", Tooltip: "Involved object as kind/name", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true}, Value: func(e kubeEvent) any { return e.objKind + "/" + e.objName }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Kind", Tooltip: "Involved object kind", Type: funcapi.FieldTypeString, Visible: false, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true}, Value: func(e kubeEvent) any { return e.objKind }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Name", Tooltip: "Involved object name", Type: funcapi.FieldTypeString, Visible: false, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true}, Value: func(e kubeEvent) any { return e.objName }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Namespace", Tooltip: "Involved object namespace", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true}, Value: func(e kubeEvent) any { return firstNotEmpty(e.objNamespace, e.namespace) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Message", Tooltip: "Human-readable description", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterNone, Sortable: false, FullWidth: true, Wrap: true}, Value: func(e kubeEvent) any { return e.message }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Count", Tooltip: "Number of occurrences", Type: funcapi.FieldTypeInteger, Units: "events", Visible: true, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummarySum, Filter: funcapi.FieldFilterRange, Sortable: true}, Value: func(e kubeEvent) any { return e.count }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "First Seen", Tooltip: "Time of the first occurrence", Type: funcapi.FieldTypeTimestamp, Visible: false, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummaryMin, Filter: funcapi.FieldFilterRange, Sortable: true, Transform: funcapi.FieldTransformDatetime}, Value: func(e kubeEvent) any { return timeMilliCell(e.firstSeen) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Source", Tooltip: "Reporting component", Type: funcapi.FieldTypeString, Visible: false, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true}, Value: func(e kubeEvent) any { return e.source }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "UID", Tooltip: "Event UID", Type: funcapi.FieldTypeString, Visible: false, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterNone, Sortable: false, UniqueKey: true}, Value: func(e kubeEvent) any { return e.key() }},
+}
+
+func timeMilliCell(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UnixMilli()
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package k8s_state
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/netdata/netdata/go/plugins/pkg/funcapi"
+)
+
+func TestFuncEvents_Handle(t *testing.T) {
+	now := time.Now()
+
+	tests := map[string]struct {
+		params   funcapi.ResolvedParams
+		wantRows []string // reasons, newest first
+	}{
+		"warning only by default": {
+			params:   funcapi.ResolvedParams{},
+			wantRows: []string{"BackOff", "FailedMount"},
+		},
+		"all events": {
+			params:   funcapi.ResolvedParams{eventsParamType: {IDs: []string{eventsParamTypeAll}}},
+			wantRows: []string{"Pulled", "BackOff", "FailedMount"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collr := New()
+			es := collr.state.events
+			es.recent["1"] = &kubeEvent{uid: "1", eventType: corev1.EventTypeWarning, reason: "FailedMount", objKind: "Pod", objName: "pod01", count: 1, lastSeen: now.Add(-time.Minute)}
+			es.recent["2"] = &kubeEvent{uid: "2", eventType: corev1.EventTypeWarning, reason: "BackOff", objKind: "Pod", objName: "pod02", count: 5, lastSeen: now.Add(-time.Second * 10)}
+			es.recent["3"] = &kubeEvent{uid: "3", eventType: corev1.EventTypeNormal, reason: "Pulled", objKind: "Pod", objName: "pod02", count: 1, lastSeen: now}
+
+			resp := collr.funcRouter.Handle(context.Background(), eventsMethodID, test.params)
+			require.NotNil(t, resp)
+			require.Equal(t, 200, resp.Status)
+			assert.Len(t, resp.Columns, len(eventsColumns))
+
+			rows, ok := resp.Data.([][]any)
+			require.True(t, ok)
+
+			var reasons []string
+			for _, row := range rows {
+				require.Len(t, row, len(eventsColumns))
+				reasons = append(reasons, row[2].(string))
+			}
+			assert.Equal(t, test.wantRows, reasons)
+		})
+	}
+}
+
+func TestEventsState_observe(t *testing.T) {
+	now := time.Now()
+	es := newEventsState()
+
+	assert.Equal(t, int64(0), es.observe(&kubeEvent{seed: true, uid: "1", count: 2, lastSeen: now}), "seeded event")
+	assert.Equal(t, int64(0), es.observe(&kubeEvent{uid: "1", count: 2, lastSeen: now}), "same event from the other API")
+	assert.Equal(t, int64(3), es.observe(&kubeEvent{uid: "1", count: 5, lastSeen: now.Add(time.Second)}), "count increased")
+	assert.Equal(t, int64(1), es.observe(&kubeEvent{uid: "2", count: 1, lastSeen: now}), "new event")
+
+	for i := range maxRecentEvents + 10 {
+		es.observe(&kubeEvent{uid: string(rune('a' + i)), count: 1, lastSeen: now.Add(time.Duration(i) * time.Millisecond)})
+	}
+	assert.Len(t, es.recent, maxRecentEvents)
+
+	es.pruneRecent(now.Add(recentEventsMaxAge + time.Minute))
+	assert.Empty(t, es.recent)
+}
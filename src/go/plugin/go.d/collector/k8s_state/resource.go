@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -24,6 +25,12 @@ const (
 	kubeResourceDeployment
 	kubeResourceCronJob
 	kubeResourceJob
+	kubeResourceStatefulSet
+	kubeResourceDaemonSet
+	kubeResourcePVC
+	kubeResourceHPA
+	kubeResourceResourceQuota
+	kubeResourceEvent
 )
 
 func toNode(i any) (*corev1.Node, error) {
@@ -80,3 +87,58 @@ func toJob(i any) (*batchv1.Job, error) {
 		return nil, fmt.Errorf("unexpected type: %T (expected %T or %T)", v, &batchv1.Job{}, resource(nil))
 	}
 }
+
+func toStatefulSet(i any) (*appsv1.StatefulSet, error) {
+	switch v := i.(type) {
+	case *appsv1.StatefulSet:
+		return v, nil
+	case resource:
+		return toStatefulSet(v.value())
+	default:
+		return nil, fmt.Errorf("unexpected type: %T (expected %T or %T)", v, &appsv1.StatefulSet{}, resource(nil))
+	}
+}
+
+func toDaemonSet(i any) (*appsv1.DaemonSet, error) {
+	switch v := i.(type) {
+	case *appsv1.DaemonSet:
+		return v, nil
+	case resource:
+		return toDaemonSet(v.value())
+	default:
+		return nil, fmt.Errorf("unexpected type: %T (expected %T or %T)", v, &appsv1.DaemonSet{}, resource(nil))
+	}
+}
+
+func toPVC(i any) (*corev1.PersistentVolumeClaim, error) {
+	switch v := i.(type) {
+	case *corev1.PersistentVolumeClaim:
+		return v, nil
+	case resource:
+		return toPVC(v.value())
+	default:
+		return nil, fmt.Errorf("unexpected type: %T (expected %T or %T)", v, &corev1.PersistentVolumeClaim{}, resource(nil))
+	}
+}
+
+func toHPA(i any) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	switch v := i.(type) {
+	case *autoscalingv2.HorizontalPodAutoscaler:
+		return v, nil
+	case resource:
+		return toHPA(v.value())
+	default:
+		return nil, fmt.Errorf("unexpected type: %T (expected %T or %T)", v, &autoscalingv2.HorizontalPodAutoscaler{}, resource(nil))
+	}
+}
+
+func toResourceQuota(i any) (*corev1.ResourceQuota, error) {
+	switch v := i.(type) {
+	case *corev1.ResourceQuota:
+		return v, nil
+	case resource:
+		return toResourceQuota(v.value())
+	default:
+		return nil, fmt.Errorf("unexpected type: %T (expected %T or %T)", v, &corev1.ResourceQuota{}, resource(nil))
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -19,6 +20,14 @@ func newKubeState() *kubeState {
 		deployments: make(map[string]*deploymentState),
 		cronJobs:    make(map[string]*cronJobState),
 		jobs:        make(map[string]*jobState),
+
+		statefulSets:   make(map[string]*statefulSetState),
+		daemonSets:     make(map[string]*daemonSetState),
+		pvcs:           make(map[string]*pvcState),
+		hpas:           make(map[string]*hpaState),
+		resourceQuotas: make(map[string]*resourceQuotaState),
+
+		events: newEventsState(),
 	}
 }
 
@@ -62,6 +71,37 @@ func newJobState() *jobState {
 	}
 }
 
+func newStatefulSetState() *statefulSetState {
+	return &statefulSetState{
+		new: true,
+	}
+}
+
+func newDaemonSetState() *daemonSetState {
+	return &daemonSetState{
+		new: true,
+	}
+}
+
+func newPVCState() *pvcState {
+	return &pvcState{
+		new: true,
+	}
+}
+
+func newHPAState() *hpaState {
+	return &hpaState{
+		new: true,
+	}
+}
+
+func newResourceQuotaState() *resourceQuotaState {
+	return &resourceQuotaState{
+		new:       true,
+		resources: make(map[string]*resourceQuotaResourceState),
+	}
+}
+
 type kubeState struct {
 	*sync.Mutex
 	nodes       map[string]*nodeState
@@ -69,6 +109,14 @@ type kubeState struct {
 	deployments map[string]*deploymentState
 	cronJobs    map[string]*cronJobState
 	jobs        map[string]*jobState
+
+	statefulSets   map[string]*statefulSetState
+	daemonSets     map[string]*daemonSetState
+	pvcs           map[string]*pvcState
+	hpas           map[string]*hpaState
+	resourceQuotas map[string]*resourceQuotaState
+
+	events *eventsState
 }
 
 type (
@@ -231,3 +279,162 @@ type jobState struct {
 	completionTime *time.Time
 	active         int32
 }
+
+type statefulSetState struct {
+	new     bool
+	deleted bool
+
+	uid          string
+	name         string
+	namespace    string
+	creationTime time.Time
+
+	replicas        int64 // desired
+	currentReplicas int64
+	updatedReplicas int64
+	readyReplicas   int64
+	availReplicas   int64
+}
+
+func (ss statefulSetState) id() string { return ss.namespace + "_" + ss.name }
+
+type daemonSetState struct {
+	new     bool
+	deleted bool
+
+	uid          string
+	name         string
+	namespace    string
+	creationTime time.Time
+
+	desiredScheduled int64
+	currentScheduled int64
+	updatedScheduled int64
+	misscheduled     int64
+	ready            int64
+	available        int64
+	unavailable      int64
+}
+
+func (ds daemonSetState) id() string { return ds.namespace + "_" + ds.name }
+
+type pvcState struct {
+	new     bool
+	deleted bool
+
+	uid          string
+	name         string
+	namespace    string
+	storageClass string
+	creationTime time.Time
+
+	phase            corev1.PersistentVolumeClaimPhase
+	requestedStorage int64
+	capacityStorage  int64
+}
+
+func (ps pvcState) id() string { return ps.namespace + "_" + ps.name }
+
+type hpaState struct {
+	new     bool
+	deleted bool
+
+	uid          string
+	name         string
+	namespace    string
+	targetKind   string
+	targetName   string
+	creationTime time.Time
+
+	minReplicas     int64
+	maxReplicas     int64
+	currentReplicas int64
+	desiredReplicas int64
+
+	conditions []autoscalingv2.HorizontalPodAutoscalerCondition
+}
+
+func (hs hpaState) id() string { return hs.namespace + "_" + hs.name }
+
+type resourceQuotaState struct {
+	new     bool
+	deleted bool
+
+	uid       string
+	name      string
+	namespace string
+
+	// keyed by the quota resource name, e.g. "requests.cpu" or "pods"
+	resources map[string]*resourceQuotaResourceState
+}
+
+func (qs resourceQuotaState) id() string { return qs.namespace + "_" + qs.name }
+
+type resourceQuotaResourceState struct {
+	new     bool
+	deleted bool
+
+	name string
+	hard int64 // in milli-units
+	used int64 // in milli-units
+}
+
+const (
+	maxRecentEvents    = 1000
+	recentEventsMaxAge = time.Hour
+	maxEventDimensions = 50
+)
+
+func newEventsState() *eventsState {
+	return &eventsState{
+		recent:             make(map[string]*kubeEvent),
+		warningByReason:    make(map[string]int64),
+		warningByKind:      make(map[string]int64),
+		warningByNamespace: make(map[string]int64),
+	}
+}
+
+// eventsState holds Event counters and a bounded set of recently seen Events.
+type eventsState struct {
+	seen        bool
+	chartsAdded bool
+
+	// keyed by the Event UID, at most maxRecentEvents entries
+	recent map[string]*kubeEvent
+
+	normal  int64
+	warning int64
+
+	// at most maxEventDimensions keys each, the rest is accounted as "other"
+	warningByReason    map[string]int64
+	warningByKind      map[string]int64
+	warningByNamespace map[string]int64
+}
+
+// kubeEvent is a compact, API-version independent representation of a Kubernetes Event.
+type kubeEvent struct {
+	// seed marks Events read by the initial list: they are shown but not counted as new occurrences.
+	seed bool
+
+	uid             string
+	resourceVersion string
+	name            string
+	namespace       string
+	eventType       string
+	reason          string
+	message         string
+	objKind         string
+	objName         string
+	objNamespace    string
+	source          string
+	count           int64
+	firstSeen       time.Time
+	lastSeen        time.Time
+}
+
+func (e kubeEvent) key() string {
+	if e.uid != "" {
+		return e.uid
+	}
+	return e.namespace + "/" + e.name
+}
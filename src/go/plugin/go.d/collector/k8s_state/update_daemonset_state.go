@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package k8s_state
+
+func (c *Collector) updateDaemonSetState(r resource) {
+	if r.value() == nil {
+		if st, ok := c.state.daemonSets[r.source()]; ok {
+			st.deleted = true
+		}
+		return
+	}
+
+	ds, err := toDaemonSet(r)
+	if err != nil {
+		c.Warning(err)
+		return
+	}
+
+	st, ok := c.state.daemonSets[r.source()]
+	if !ok {
+		st = newDaemonSetState()
+		c.state.daemonSets[r.source()] = st
+
+		st.uid = string(ds.UID)
+		st.name = ds.Name
+		st.namespace = ds.Namespace
+		st.creationTime = ds.CreationTimestamp.Time
+	}
+
+	st.desiredScheduled = int64(ds.Status.DesiredNumberScheduled)
+	st.currentScheduled = int64(ds.Status.CurrentNumberScheduled)
+	st.updatedScheduled = int64(ds.Status.UpdatedNumberScheduled)
+	st.misscheduled = int64(ds.Status.NumberMisscheduled)
+	st.ready = int64(ds.Status.NumberReady)
+	st.available = int64(ds.Status.NumberAvailable)
+	st.unavailable = int64(ds.Status.NumberUnavailable)
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package k8s_state
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func (c *Collector) updateEventState(r resource) {
+	ev, ok := r.value().(*kubeEvent)
+	if !ok {
+		c.Warning(fmt.Errorf("unexpected type: %T (expected %T)", r.value(), &kubeEvent{}))
+		return
+	}
+
+	es := c.state.events
+	es.seen = true
+
+	delta := es.observe(ev)
+	if delta == 0 {
+		return
+	}
+
+	switch ev.eventType {
+	case corev1.EventTypeWarning:
+		es.warning += delta
+		incEventCounter(es.warningByReason, ev.reason, delta)
+		incEventCounter(es.warningByKind, ev.objKind, delta)
+		incEventCounter(es.warningByNamespace, ev.objNamespace, delta)
+	default:
+		es.normal += delta
+	}
+}
+
+// observe stores the Event and returns the number of new occurrences it represents.
+// The same Event is delivered by both Events APIs and by relists; those copies carry no new occurrences.
+func (es *eventsState) observe(ev *kubeEvent) int64 {
+	key := ev.key()
+
+	var delta int64 = 1
+	if prev, ok := es.recent[key]; ok {
+		if ev.count <= prev.count && !ev.lastSeen.After(prev.lastSeen) {
+			return 0
+		}
+		delta = max(ev.count-prev.count, 1)
+	}
+
+	es.recent[key] = ev
+	if len(es.recent) > maxRecentEvents {
+		es.evictOldest()
+	}
+
+	if ev.seed {
+		return 0
+	}
+	return delta
+}
+
+func (es *eventsState) evictOldest() {
+	var oldestKey string
+	var oldest time.Time
+	for k, ev := range es.recent {
+		if oldestKey == "" || ev.lastSeen.Before(oldest) {
+			oldestKey, oldest = k, ev.lastSeen
+		}
+	}
+	delete(es.recent, oldestKey)
+}
+
+func (es *eventsState) pruneRecent(now time.Time) {
+	for k, ev := range es.recent {
+		if now.Sub(ev.lastSeen) > recentEventsMaxAge {
+			delete(es.recent, k)
+		}
+	}
+}
+
+func incEventCounter(counters map[string]int64, key string, delta int64) {
+	if key == "" {
+		key = "unknown"
+	}
+	if _, ok := counters[key]; !ok && len(counters) >= maxEventDimensions {
+		key = "other"
+	}
+	counters[key] += delta
+}
@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package k8s_state
+
+func (c *Collector) updateHPAState(r resource) {
+	if r.value() == nil {
+		if st, ok := c.state.hpas[r.source()]; ok {
+			st.deleted = true
+		}
+		return
+	}
+
+	hpa, err := toHPA(r)
+	if err != nil {
+		c.Warning(err)
+		return
+	}
+
+	st, ok := c.state.hpas[r.source()]
+	if !ok {
+		st = newHPAState()
+		c.state.hpas[r.source()] = st
+
+		st.uid = string(hpa.UID)
+		st.name = hpa.Name
+		st.namespace = hpa.Namespace
+		st.creationTime = hpa.CreationTimestamp.Time
+		st.targetKind = hpa.Spec.ScaleTargetRef.Kind
+		st.targetName = hpa.Spec.ScaleTargetRef.Name
+	}
+
+	// spec.minReplicas defaults to 1 when unset
+	st.minReplicas = 1
+	if hpa.Spec.MinReplicas != nil {
+		st.minReplicas = int64(*hpa.Spec.MinReplicas)
+	}
+	st.maxReplicas = int64(hpa.Spec.MaxReplicas)
+	st.currentReplicas = int64(hpa.Status.CurrentReplicas)
+	st.desiredReplicas = int64(hpa.Status.DesiredReplicas)
+	st.conditions = hpa.Status.Conditions
+}
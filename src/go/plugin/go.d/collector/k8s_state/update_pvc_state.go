@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package k8s_state
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+func (c *Collector) updatePVCState(r resource) {
+	if r.value() == nil {
+		if st, ok := c.state.pvcs[r.source()]; ok {
+			st.deleted = true
+		}
+		return
+	}
+
+	pvc, err := toPVC(r)
+	if err != nil {
+		c.Warning(err)
+		return
+	}
+
+	st, ok := c.state.pvcs[r.source()]
+	if !ok {
+		st = newPVCState()
+		c.state.pvcs[r.source()] = st
+
+		st.uid = string(pvc.UID)
+		st.name = pvc.Name
+		st.namespace = pvc.Namespace
+		st.creationTime = pvc.CreationTimestamp.Time
+		if pvc.Spec.StorageClassName != nil {
+			st.storageClass = *pvc.Spec.StorageClassName
+		}
+	}
+
+	st.phase = pvc.Status.Phase
+	st.requestedStorage = 0
+	if v, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+		st.requestedStorage = v.Value()
+	}
+	st.capacityStorage = 0
+	if v, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+		st.capacityStorage = v.Value()
+	}
+}
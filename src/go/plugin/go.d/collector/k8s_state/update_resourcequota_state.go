@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package k8s_state
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+func (c *Collector) updateResourceQuotaState(r resource) {
+	if r.value() == nil {
+		if st, ok := c.state.resourceQuotas[r.source()]; ok {
+			st.deleted = true
+		}
+		return
+	}
+
+	quota, err := toResourceQuota(r)
+	if err != nil {
+		c.Warning(err)
+		return
+	}
+
+	st, ok := c.state.resourceQuotas[r.source()]
+	if !ok {
+		st = newResourceQuotaState()
+		c.state.resourceQuotas[r.source()] = st
+
+		st.uid = string(quota.UID)
+		st.name = quota.Name
+		st.namespace = quota.Namespace
+	}
+
+	for name, rs := range st.resources {
+		if _, ok := quota.Status.Hard[corev1.ResourceName(name)]; !ok {
+			rs.deleted = true
+		}
+	}
+
+	for name, hard := range quota.Status.Hard {
+		rs, ok := st.resources[string(name)]
+		if !ok {
+			rs = &resourceQuotaResourceState{new: true, name: string(name)}
+			st.resources[string(name)] = rs
+		}
+		rs.deleted = false
+		rs.hard = hard.MilliValue()
+		rs.used = 0
+		if used, ok := quota.Status.Used[name]; ok {
+			rs.used = used.MilliValue()
+		}
+	}
+}
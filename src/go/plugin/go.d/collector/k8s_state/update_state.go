@@ -22,6 +22,18 @@ func (c *Collector) runUpdateState(in <-chan resource) {
 				c.updateCronJobState(res)
 			case kubeResourceJob:
 				c.updateJobState(res)
+			case kubeResourceStatefulSet:
+				c.updateStatefulSetState(res)
+			case kubeResourceDaemonSet:
+				c.updateDaemonSetState(res)
+			case kubeResourcePVC:
+				c.updatePVCState(res)
+			case kubeResourceHPA:
+				c.updateHPAState(res)
+			case kubeResourceResourceQuota:
+				c.updateResourceQuotaState(res)
+			case kubeResourceEvent:
+				c.updateEventState(res)
 			}
 			c.state.Unlock()
 		}
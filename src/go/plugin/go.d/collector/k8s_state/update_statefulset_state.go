@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package k8s_state
+
+func (c *Collector) updateStatefulSetState(r resource) {
+	if r.value() == nil {
+		if st, ok := c.state.statefulSets[r.source()]; ok {
+			st.deleted = true
+		}
+		return
+	}
+
+	sts, err := toStatefulSet(r)
+	if err != nil {
+		c.Warning(err)
+		return
+	}
+
+	st, ok := c.state.statefulSets[r.source()]
+	if !ok {
+		st = newStatefulSetState()
+		c.state.statefulSets[r.source()] = st
+
+		st.uid = string(sts.UID)
+		st.name = sts.Name
+		st.namespace = sts.Namespace
+		st.creationTime = sts.CreationTimestamp.Time
+	}
+
+	// spec.replicas defaults to 1 when unset
+	st.replicas = 1
+	if sts.Spec.Replicas != nil {
+		st.replicas = int64(*sts.Spec.Replicas)
+	}
+	st.currentReplicas = int64(sts.Status.CurrentReplicas)
+	st.updatedReplicas = int64(sts.Status.UpdatedReplicas)
+	st.readyReplicas = int64(sts.Status.ReadyReplicas)
+	st.availReplicas = int64(sts.Status.AvailableReplicas)
+}
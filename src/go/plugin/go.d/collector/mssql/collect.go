@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/cloudauth/sqladapter"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/sqlquery"
 )
 
 // noLatencySentinel is the value SQL Server returns when no latency data is available
@@ -123,7 +124,7 @@ func (c *Collector) openConnection() (*sql.DB, error) {
 		return nil, err
 	}
 
-	db, err := sql.Open(driverName, dsn)
+	db, err := sqlquery.Open(driverName, dsn)
 	if err != nil {
 		return nil, fmt.Errorf("error opening connection: %v", err)
 	}
@@ -4,7 +4,6 @@ package mysql
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"fmt"
 	"strconv"
@@ -121,7 +120,7 @@ func (c *Collector) openConnection(ctx context.Context) error {
 		ctx = context.Background()
 	}
 
-	db, err := sql.Open("mysql", c.DSN)
+	db, err := sqlquery.Open("mysql", c.DSN)
 	if err != nil {
 		return fmt.Errorf("error on opening a connection with the mysql database [%s]: %v", c.safeDSN, err)
 	}
@@ -11,6 +11,9 @@ import (
 
 const (
 	_ = 2050 + iota // right after Disks section
+	prioDeviceHealthScore
+	prioDeviceHealthRisk
+	prioDeviceDefectsGrowth
 	prioDeviceEstimatedEndurancePerc
 	prioDeviceAvailableSparePerc
 	prioDeviceCompositeTemperature
@@ -30,6 +33,9 @@ const (
 )
 
 var deviceChartsTmpl = collectorapi.Charts{
+	deviceHealthScoreChartTmpl.Copy(),
+	deviceHealthRiskChartTmpl.Copy(),
+	deviceDefectsGrowthChartTmpl.Copy(),
 	deviceEstimatedEndurancePercChartTmpl.Copy(),
 	deviceAvailableSparePercChartTmpl.Copy(),
 	deviceCompositeTemperatureChartTmpl.Copy(),
@@ -48,6 +54,41 @@ var deviceChartsTmpl = collectorapi.Charts{
 	deviceThmTemp2TimeChartTmpl.Copy(),
 }
 
+var deviceHealthScoreChartTmpl = collectorapi.Chart{
+	ID:       "device_%s_health_score",
+	Title:    "Health score",
+	Units:    "score",
+	Fam:      "health",
+	Ctx:      "nvme.device_health_score",
+	Priority: prioDeviceHealthScore,
+	Dims: collectorapi.Dims{
+		{ID: "device_%s_health_score", Name: "score"},
+	},
+}
+var deviceHealthRiskChartTmpl = collectorapi.Chart{
+	ID:       "device_%s_health_risk",
+	Title:    "Failure risk",
+	Units:    "status",
+	Fam:      "health",
+	Ctx:      "nvme.device_health_risk",
+	Priority: prioDeviceHealthRisk,
+	Dims: collectorapi.Dims{
+		{ID: "device_%s_health_risk_low", Name: "low"},
+		{ID: "device_%s_health_risk_medium", Name: "medium"},
+		{ID: "device_%s_health_risk_high", Name: "high"},
+	},
+}
+var deviceDefectsGrowthChartTmpl = collectorapi.Chart{
+	ID:       "device_%s_defects_growth",
+	Title:    "Media errors growth",
+	Units:    "errors/day",
+	Fam:      "health",
+	Ctx:      "nvme.device_defects_growth",
+	Priority: prioDeviceDefectsGrowth,
+	Dims: collectorapi.Dims{
+		{ID: "device_%s_defects_growth", Name: "growth", Div: 1000},
+	},
+}
 var deviceEstimatedEndurancePercChartTmpl = collectorapi.Chart{
 	ID:       "device_%s_estimated_endurance_perc",
 	Title:    "Estimated endurance",
@@ -76,6 +76,8 @@ func (c *Collector) collectNVMeDevice(mx map[string]int64, devicePath string) er
 	mx["device_"+dev+"_critical_warning_volatile_mem_backup_failed"] = oldmetrix.Bool(parseValue(stats.CriticalWarningValue)&(1<<4) != 0)
 	mx["device_"+dev+"_critical_warning_persistent_memory_read_only"] = oldmetrix.Bool(parseValue(stats.CriticalWarningValue)&(1<<5) != 0)
 
+	c.collectDeviceHealth(mx, devicePath, stats)
+
 	return nil
 }
 
@@ -96,6 +98,7 @@ func (c *Collector) listNVMeDevices() error {
 		}
 
 		seen[path] = true
+		c.deviceInfo[path] = deviceInfo{model: dev.ModelNumber, serial: dev.SerialNumber}
 		if !c.devicePaths[path] {
 			c.devicePaths[path] = true
 			c.addDeviceCharts(path, dev.ModelNumber)
@@ -105,6 +108,8 @@ func (c *Collector) listNVMeDevices() error {
 	for path := range c.devicePaths {
 		if !seen[path] {
 			delete(c.devicePaths, path)
+			delete(c.deviceInfo, path)
+			c.removeDeviceHealth(path)
 			c.removeDeviceCharts(path)
 		}
 	}
@@ -7,23 +7,34 @@ import (
 	_ "embed"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/netdata/netdata/go/plugins/pkg/confopt"
 	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/diskhealth"
 )
 
 //go:embed "config_schema.json"
 var configSchema string
 
 func init() {
+	// Media error history is shared by all jobs, so that jobs polling the same
+	// devices do not overwrite each other's history file.
+	history := diskhealth.NewHistory(healthHistoryStatePath)
 	collectorapi.Register("nvme", collectorapi.Creator{
 		JobConfigSchema: configSchema,
 		Defaults: collectorapi.Defaults{
 			UpdateEvery: 10,
 		},
-		Create: func() collectorapi.CollectorV1 { return New() },
-		Config: func() any { return &Config{} },
+		Create: func() collectorapi.CollectorV1 {
+			c := New()
+			c.healthHistory = history
+			return c
+		},
+		Config:          func() any { return &Config{} },
+		SharedFunctions: nvmeMethods,
+		MethodHandler:   nvmeFunctionHandler,
 	})
 }
 
@@ -34,8 +45,12 @@ func New() *Collector {
 		},
 
 		charts:           &collectorapi.Charts{},
+		now:              time.Now,
 		devicePaths:      make(map[string]bool),
+		deviceInfo:       make(map[string]deviceInfo),
 		listDevicesEvery: time.Minute * 10,
+		healthHistory:    diskhealth.NewHistory(nil),
+		health:           make(map[string]*deviceHealth),
 	}
 
 }
@@ -51,12 +66,20 @@ type Collector struct {
 
 	charts *collectorapi.Charts
 
+	funcRouter *funcRouter
+
+	now  func() time.Time
 	exec nvmeCli
 
 	devicePaths      map[string]bool
+	deviceInfo       map[string]deviceInfo
 	listDevicesTime  time.Time
 	listDevicesEvery time.Duration
 	forceListDevices bool
+
+	healthHistory *diskhealth.History
+	healthMu      sync.RWMutex
+	health        map[string]*deviceHealth
 }
 
 func (c *Collector) Configuration() any {
@@ -70,6 +93,8 @@ func (c *Collector) Init(context.Context) error {
 	}
 	c.exec = nvmeExec
 
+	c.funcRouter = newFuncRouter(c)
+
 	return nil
 }
 
@@ -100,4 +125,9 @@ func (c *Collector) Collect(context.Context) map[string]int64 {
 	return mx
 }
 
-func (c *Collector) Cleanup(context.Context) {}
+func (c *Collector) Cleanup(ctx context.Context) {
+	if c.funcRouter != nil {
+		c.funcRouter.Cleanup(ctx)
+	}
+	c.healthHistory.Save()
+}
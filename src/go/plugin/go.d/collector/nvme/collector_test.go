@@ -8,8 +8,10 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/collecttest"
 
@@ -146,6 +148,11 @@ func TestCollector_Collect(t *testing.T) {
 						"device_nvme0_critical_warning_volatile_mem_backup_failed":  0,
 						"device_nvme0_data_units_read":                              91155062272000,
 						"device_nvme0_data_units_written":                           987485941760000,
+						"device_nvme0_defects_growth":                               0,
+						"device_nvme0_health_risk_high":                             0,
+						"device_nvme0_health_risk_low":                              1,
+						"device_nvme0_health_risk_medium":                           0,
+						"device_nvme0_health_score":                                 100,
 						"device_nvme0_host_read_commands":                           5808178366,
 						"device_nvme0_host_write_commands":                          24273507789,
 						"device_nvme0_media_errors":                                 0,
@@ -171,6 +178,11 @@ func TestCollector_Collect(t *testing.T) {
 						"device_nvme1_critical_warning_volatile_mem_backup_failed":  0,
 						"device_nvme1_data_units_read":                              91155062272000,
 						"device_nvme1_data_units_written":                           987485941760000,
+						"device_nvme1_defects_growth":                               0,
+						"device_nvme1_health_risk_high":                             0,
+						"device_nvme1_health_risk_low":                              1,
+						"device_nvme1_health_risk_medium":                           0,
+						"device_nvme1_health_score":                                 100,
 						"device_nvme1_host_read_commands":                           5808178366,
 						"device_nvme1_host_write_commands":                          24273507789,
 						"device_nvme1_media_errors":                                 0,
@@ -196,6 +208,11 @@ func TestCollector_Collect(t *testing.T) {
 						"device_nvme2_critical_warning_volatile_mem_backup_failed":  0,
 						"device_nvme2_data_units_read":                              91155062272000,
 						"device_nvme2_data_units_written":                           987485941760000,
+						"device_nvme2_defects_growth":                               0,
+						"device_nvme2_health_risk_high":                             0,
+						"device_nvme2_health_risk_low":                              1,
+						"device_nvme2_health_risk_medium":                           0,
+						"device_nvme2_health_score":                                 100,
 						"device_nvme2_host_read_commands":                           5808178366,
 						"device_nvme2_host_write_commands":                          24273507789,
 						"device_nvme2_media_errors":                                 0,
@@ -234,6 +251,11 @@ func TestCollector_Collect(t *testing.T) {
 						"device_nvme0_critical_warning_volatile_mem_backup_failed":  0,
 						"device_nvme0_data_units_read":                              5068041216000,
 						"device_nvme0_data_units_written":                           69712734208000,
+						"device_nvme0_defects_growth":                               0,
+						"device_nvme0_health_risk_high":                             0,
+						"device_nvme0_health_risk_low":                              1,
+						"device_nvme0_health_risk_medium":                           0,
+						"device_nvme0_health_score":                                 100,
 						"device_nvme0_host_read_commands":                           313528805,
 						"device_nvme0_host_write_commands":                          1928062610,
 						"device_nvme0_media_errors":                                 0,
@@ -259,6 +281,11 @@ func TestCollector_Collect(t *testing.T) {
 						"device_nvme1_critical_warning_volatile_mem_backup_failed":  0,
 						"device_nvme1_data_units_read":                              5068041216000,
 						"device_nvme1_data_units_written":                           69712734208000,
+						"device_nvme1_defects_growth":                               0,
+						"device_nvme1_health_risk_high":                             0,
+						"device_nvme1_health_risk_low":                              1,
+						"device_nvme1_health_risk_medium":                           0,
+						"device_nvme1_health_score":                                 100,
 						"device_nvme1_host_read_commands":                           313528805,
 						"device_nvme1_host_write_commands":                          1928062610,
 						"device_nvme1_media_errors":                                 0,
@@ -297,6 +324,11 @@ func TestCollector_Collect(t *testing.T) {
 						"device_nvme0_critical_warning_volatile_mem_backup_failed":  0,
 						"device_nvme0_data_units_read":                              5068041216000,
 						"device_nvme0_data_units_written":                           69712734208000,
+						"device_nvme0_defects_growth":                               0,
+						"device_nvme0_health_risk_high":                             0,
+						"device_nvme0_health_risk_low":                              1,
+						"device_nvme0_health_risk_medium":                           0,
+						"device_nvme0_health_score":                                 100,
 						"device_nvme0_host_read_commands":                           313528805,
 						"device_nvme0_host_write_commands":                          1928062610,
 						"device_nvme0_media_errors":                                 0,
@@ -322,6 +354,11 @@ func TestCollector_Collect(t *testing.T) {
 						"device_nvme1_critical_warning_volatile_mem_backup_failed":  0,
 						"device_nvme1_data_units_read":                              5068041216000,
 						"device_nvme1_data_units_written":                           69712734208000,
+						"device_nvme1_defects_growth":                               0,
+						"device_nvme1_health_risk_high":                             0,
+						"device_nvme1_health_risk_low":                              1,
+						"device_nvme1_health_risk_medium":                           0,
+						"device_nvme1_health_score":                                 100,
 						"device_nvme1_host_read_commands":                           313528805,
 						"device_nvme1_host_write_commands":                          1928062610,
 						"device_nvme1_media_errors":                                 0,
@@ -360,6 +397,11 @@ func TestCollector_Collect(t *testing.T) {
 						"device_nvme0_critical_warning_volatile_mem_backup_failed":  0,
 						"device_nvme0_data_units_read":                              5068041216000,
 						"device_nvme0_data_units_written":                           69712734208000,
+						"device_nvme0_defects_growth":                               0,
+						"device_nvme0_health_risk_high":                             0,
+						"device_nvme0_health_risk_low":                              1,
+						"device_nvme0_health_risk_medium":                           0,
+						"device_nvme0_health_score":                                 100,
 						"device_nvme0_host_read_commands":                           313528805,
 						"device_nvme0_host_write_commands":                          1928062610,
 						"device_nvme0_media_errors":                                 0,
@@ -385,6 +427,11 @@ func TestCollector_Collect(t *testing.T) {
 						"device_nvme1_critical_warning_volatile_mem_backup_failed":  0,
 						"device_nvme1_data_units_read":                              5068041216000,
 						"device_nvme1_data_units_written":                           69712734208000,
+						"device_nvme1_defects_growth":                               0,
+						"device_nvme1_health_risk_high":                             0,
+						"device_nvme1_health_risk_low":                              1,
+						"device_nvme1_health_risk_medium":                           0,
+						"device_nvme1_health_score":                                 100,
 						"device_nvme1_host_read_commands":                           313528805,
 						"device_nvme1_host_write_commands":                          1928062610,
 						"device_nvme1_media_errors":                                 0,
@@ -451,6 +498,72 @@ func TestCollector_Collect(t *testing.T) {
 	}
 }
 
+func TestCollector_DiskHealth(t *testing.T) {
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+	collr := New()
+	collr.now = func() time.Time { return now }
+	collr.funcRouter = newFuncRouter(collr)
+	defer collr.Cleanup(context.Background())
+
+	resp := collr.funcRouter.Handle(context.Background(), diskHealthMethodID, nil)
+	assert.Equal(t, 503, resp.Status)
+
+	collr.exec = &mockNVMeCLIExec{
+		dataList:     dataVer23NVMeListJson,
+		dataSmartLog: setSmartLogMediaErrors(t, dataVer23NVMeSmartLogJson, 4),
+	}
+	mx := collr.Collect(context.Background())
+	collecttest.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+
+	assert.Equal(t, int64(80), mx["device_nvme0_health_score"])
+	assert.Equal(t, int64(1), mx["device_nvme0_health_risk_low"])
+	assert.Equal(t, int64(0), mx["device_nvme0_defects_growth"])
+
+	now = now.Add(2 * time.Hour)
+	collr.exec = &mockNVMeCLIExec{
+		dataList:     dataVer23NVMeListJson,
+		dataSmartLog: setSmartLogMediaErrors(t, dataVer23NVMeSmartLogJson, 10),
+	}
+	mx = collr.Collect(context.Background())
+
+	assert.Equal(t, int64(35), mx["device_nvme0_health_score"])
+	assert.Equal(t, int64(1), mx["device_nvme0_health_risk_high"])
+	assert.Equal(t, int64(6000), mx["device_nvme0_defects_growth"])
+
+	resp = collr.funcRouter.Handle(context.Background(), diskHealthMethodID, nil)
+	require.Equal(t, 200, resp.Status)
+	data, ok := resp.Data.([][]any)
+	require.True(t, ok)
+	require.Len(t, data, 2)
+
+	rows := make(map[any]map[string]any)
+	for _, values := range data {
+		row := make(map[string]any)
+		for i, col := range diskHealthColumns {
+			row[col.Name] = values[i]
+		}
+		rows[row["Device"]] = row
+	}
+	row := rows["nvme0"]
+	require.NotNil(t, row)
+	assert.Equal(t, "XXX00YYY", row["Serial"])
+	assert.Equal(t, int64(35), row["Score"])
+	assert.Equal(t, "high", row["Risk"])
+	assert.Equal(t, "10 media errors; 6 new defects in 2h (6.00/day)", row["Risk Factors"])
+	assert.Equal(t, int64(10), row["Media Errors"])
+	assert.Equal(t, int64(2), row["Endurance Used"])
+}
+
+func setSmartLogMediaErrors(t *testing.T, data []byte, n int) []byte {
+	var v map[string]any
+	require.NoError(t, json.Unmarshal(data, &v))
+	v["media_errors"] = json.Number(strconv.Itoa(n))
+	bs, err := json.Marshal(v)
+	require.NoError(t, err)
+	return bs
+}
+
 func prepareCaseVer211OK(collr *Collector) {
 	collr.exec = &mockNVMeCLIExec{
 		dataList:     dataVer211NVMeListJson,
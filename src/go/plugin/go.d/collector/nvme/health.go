@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package nvme
+
+import (
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/framework/filepersister"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/diskhealth"
+)
+
+type deviceInfo struct {
+	model  string
+	serial string
+}
+
+type deviceHealth struct {
+	device     string
+	model      string
+	serial     string
+	attrs      diskhealth.Attributes
+	trend      diskhealth.Trend
+	assessment diskhealth.Assessment
+	updated    time.Time
+}
+
+func healthHistoryStatePath() string {
+	return filepersister.StatePath("god-nvme-disk-health.json")
+}
+
+func (c *Collector) collectDeviceHealth(mx map[string]int64, devicePath string, stats *nvmeDeviceSmartLog) {
+	dev := extractDeviceFromPath(devicePath)
+	info := c.deviceInfo[devicePath]
+	attrs := healthAttributes(stats)
+
+	now := c.now()
+	defects, _ := attrs.Defects()
+	trend := c.healthHistory.Observe(healthHistoryKey(dev, info), now, defects)
+	a := diskhealth.Assess(attrs, trend)
+
+	px := "device_" + dev + "_"
+	mx[px+"health_score"] = a.Score
+	for _, r := range diskhealth.Risks {
+		mx[px+"health_risk_"+string(r)] = 0
+	}
+	mx[px+"health_risk_"+string(a.Risk)] = 1
+	mx[px+"defects_growth"] = int64(trend.GrowthPerDay * 1000)
+
+	c.healthMu.Lock()
+	c.health[devicePath] = &deviceHealth{
+		device:     dev,
+		model:      info.model,
+		serial:     info.serial,
+		attrs:      attrs,
+		trend:      trend,
+		assessment: a,
+		updated:    now,
+	}
+	c.healthMu.Unlock()
+}
+
+func (c *Collector) removeDeviceHealth(devicePath string) {
+	c.healthMu.Lock()
+	delete(c.health, devicePath)
+	c.healthMu.Unlock()
+}
+
+// healthHistoryKey identifies the controller in the persisted defect history. The serial number
+// follows the controller across device renames (nvme0 becoming nvme1 after a reboot).
+func healthHistoryKey(dev string, info deviceInfo) string {
+	if info.serial != "" {
+		return info.model + "/" + info.serial
+	}
+	return dev
+}
+
+func healthAttributes(stats *nvmeDeviceSmartLog) diskhealth.Attributes {
+	return diskhealth.Attributes{
+		CriticalWarning:         parseValue(stats.CriticalWarningValue),
+		MediaErrors:             new(parseValue(stats.MediaErrors)),
+		PercentageUsed:          new(parseValue(stats.PercentUsed)),
+		AvailableSpare:          new(parseValue(stats.AvailSpare)),
+		AvailableSpareThreshold: new(parseValue(stats.SpareThresh)),
+	}
+}
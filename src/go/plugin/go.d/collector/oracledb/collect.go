@@ -7,6 +7,8 @@ import (
 	"database/sql"
 	"fmt"
 	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/sqlquery"
 )
 
 const precision = 1000
@@ -70,7 +72,7 @@ func (c *Collector) doQuery(query string, assign func(column, value string, line
 }
 
 func (c *Collector) openConnection() error {
-	db, err := sql.Open("oracle", c.DSN)
+	db, err := sqlquery.Open("oracle", c.DSN)
 	if err != nil {
 		return fmt.Errorf("error on sql open: %v", err)
 	}
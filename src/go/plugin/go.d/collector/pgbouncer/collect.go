@@ -268,9 +268,9 @@ func (c *Collector) openConnection() error {
 
 	cfg.DefaultQueryExecMode = pgx.QueryExecModeSimpleProtocol
 
-	db := stdlib.OpenDB(*cfg, stdlib.OptionShouldPing(func(_ context.Context, _ stdlib.ShouldPingParams) bool {
+	db := sqlquery.OpenDB(stdlib.GetConnector(*cfg, stdlib.OptionShouldPing(func(_ context.Context, _ stdlib.ShouldPingParams) bool {
 		return false
-	}))
+	})))
 
 	db.SetMaxOpenConns(1)
 	db.SetMaxIdleConns(1)
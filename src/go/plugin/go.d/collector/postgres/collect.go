@@ -12,6 +12,7 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/sqlquery"
 )
 
 const (
@@ -144,7 +145,7 @@ func (c *Collector) openPrimaryConnection() (*sql.DB, error) {
 		return c.openAzureADConnection(cfg, "Postgres database")
 	}
 
-	db, err := sql.Open("pgx", c.DSN)
+	db, err := sqlquery.Open("pgx", c.DSN)
 	if err != nil {
 		return nil, fmt.Errorf("error on opening a connection with the Postgres database [%s]: %v", c.DSN, err)
 	}
@@ -179,7 +180,7 @@ func (c *Collector) openSecondaryConnection(dbname string) (*sql.DB, string, err
 
 	connStr := stdlib.RegisterConnConfig(cfg)
 
-	db, err := sql.Open("pgx", connStr)
+	db, err := sqlquery.Open("pgx", connStr)
 	if err != nil {
 		stdlib.UnregisterConnConfig(connStr)
 		return nil, "", fmt.Errorf("error on opening a secondary connection with the Postgres database [%s]: %v", dbname, err)
@@ -206,7 +207,7 @@ func (c *Collector) openAzureADConnection(cfg *pgx.ConnConfig, target string) (*
 		return nil, fmt.Errorf("cloud auth token provider is not initialized for %s", target)
 	}
 
-	db := stdlib.OpenDB(*cfg, stdlib.OptionBeforeConnect(c.azureADBeforeConnect))
+	db := sqlquery.OpenDB(stdlib.GetConnector(*cfg, stdlib.OptionBeforeConnect(c.azureADBeforeConnect)))
 
 	db.SetMaxOpenConns(1)
 	db.SetMaxIdleConns(1)
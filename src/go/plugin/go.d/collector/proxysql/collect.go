@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/oldmetrix"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/sqlquery"
 )
 
 const (
@@ -244,7 +245,7 @@ func (c *Collector) updateCharts() {
 }
 
 func (c *Collector) openConnection() error {
-	db, err := sql.Open("mysql", c.DSN)
+	db, err := sqlquery.Open("mysql", c.DSN)
 	if err != nil {
 		return fmt.Errorf("error on opening a connection with the proxysql instance [%s]: %v", c.DSN, err)
 	}
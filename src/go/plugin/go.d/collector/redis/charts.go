@@ -2,7 +2,11 @@
 
 package redis
 
-import "github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+import (
+	"fmt"
+
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+)
 
 const (
 	prioConnections = collectorapi.Priority + iota
@@ -37,6 +41,17 @@ const (
 	prioKeys
 	prioExpiresKeys
 
+	prioKeyPatternKeys
+	prioKeyPatternScanStatus
+	prioKeyPatternLength
+	prioKeyPatternMemoryUsage
+
+	prioStreamLength
+	prioStreamConsumerGroups
+	prioStreamConsumerGroupPending
+	prioStreamConsumerGroupLag
+	prioStreamConsumerGroupConsumers
+
 	prioUptime
 )
 
@@ -381,3 +396,176 @@ var (
 		},
 	}
 )
+
+var keyPatternChartsTmpl = collectorapi.Charts{
+	keyPatternKeysChartTmpl.Copy(),
+	keyPatternScanStatusChartTmpl.Copy(),
+	keyPatternLengthChartTmpl.Copy(),
+	keyPatternMemoryUsageChartTmpl.Copy(),
+}
+
+var (
+	keyPatternKeysChartTmpl = collectorapi.Chart{
+		ID:       "key_pattern_%s_keys",
+		Title:    "Keys matching the pattern",
+		Units:    "keys",
+		Fam:      "key patterns",
+		Ctx:      "redis.key_pattern_keys",
+		Priority: prioKeyPatternKeys,
+		Dims: collectorapi.Dims{
+			{ID: "key_pattern_%s_keys", Name: "keys"},
+		},
+	}
+	keyPatternScanStatusChartTmpl = collectorapi.Chart{
+		ID:       "key_pattern_%s_scan_status",
+		Title:    "Key pattern scan status",
+		Units:    "status",
+		Fam:      "key patterns",
+		Ctx:      "redis.key_pattern_scan_status",
+		Priority: prioKeyPatternScanStatus,
+		Dims: collectorapi.Dims{
+			{ID: "key_pattern_%s_scan_complete", Name: "complete"},
+			{ID: "key_pattern_%s_scan_truncated", Name: "truncated"},
+		},
+	}
+	keyPatternLengthChartTmpl = collectorapi.Chart{
+		ID:       "key_pattern_%s_length",
+		Title:    "Total length of keys matching the pattern",
+		Units:    "elements",
+		Fam:      "key patterns",
+		Ctx:      "redis.key_pattern_length",
+		Priority: prioKeyPatternLength,
+		Dims: collectorapi.Dims{
+			{ID: "key_pattern_%s_length", Name: "length"},
+		},
+	}
+	keyPatternMemoryUsageChartTmpl = collectorapi.Chart{
+		ID:       "key_pattern_%s_memory_usage",
+		Title:    "Memory used by keys matching the pattern",
+		Units:    "bytes",
+		Fam:      "key patterns",
+		Ctx:      "redis.key_pattern_memory_usage",
+		Type:     collectorapi.Area,
+		Priority: prioKeyPatternMemoryUsage,
+		Dims: collectorapi.Dims{
+			{ID: "key_pattern_%s_memory_usage", Name: "used"},
+		},
+	}
+)
+
+var streamChartsTmpl = collectorapi.Charts{
+	streamLengthChartTmpl.Copy(),
+	streamConsumerGroupsChartTmpl.Copy(),
+}
+
+var (
+	streamLengthChartTmpl = collectorapi.Chart{
+		ID:       "stream_%s_length",
+		Title:    "Stream length",
+		Units:    "entries",
+		Fam:      "streams",
+		Ctx:      "redis.stream_length",
+		Priority: prioStreamLength,
+		Dims: collectorapi.Dims{
+			{ID: "stream_%s_length", Name: "length"},
+		},
+	}
+	streamConsumerGroupsChartTmpl = collectorapi.Chart{
+		ID:       "stream_%s_consumer_groups",
+		Title:    "Stream consumer groups",
+		Units:    "groups",
+		Fam:      "streams",
+		Ctx:      "redis.stream_consumer_groups",
+		Priority: prioStreamConsumerGroups,
+		Dims: collectorapi.Dims{
+			{ID: "stream_%s_consumer_groups", Name: "groups"},
+		},
+	}
+)
+
+var streamGroupChartsTmpl = collectorapi.Charts{
+	streamConsumerGroupPendingChartTmpl.Copy(),
+	streamConsumerGroupLagChartTmpl.Copy(),
+	streamConsumerGroupConsumersChartTmpl.Copy(),
+}
+
+var (
+	streamConsumerGroupPendingChartTmpl = collectorapi.Chart{
+		ID:       "consumer_group_%s_pending",
+		Title:    "Consumer group pending entries",
+		Units:    "entries",
+		Fam:      "streams",
+		Ctx:      "redis.stream_consumer_group_pending",
+		Priority: prioStreamConsumerGroupPending,
+		Dims: collectorapi.Dims{
+			{ID: "consumer_group_%s_pending", Name: "pending"},
+		},
+	}
+	streamConsumerGroupLagChartTmpl = collectorapi.Chart{
+		ID:       "consumer_group_%s_lag",
+		Title:    "Consumer group lag",
+		Units:    "entries",
+		Fam:      "streams",
+		Ctx:      "redis.stream_consumer_group_lag",
+		Priority: prioStreamConsumerGroupLag,
+		Dims: collectorapi.Dims{
+			{ID: "consumer_group_%s_lag", Name: "lag"},
+		},
+	}
+	streamConsumerGroupConsumersChartTmpl = collectorapi.Chart{
+		ID:       "consumer_group_%s_consumers",
+		Title:    "Consumer group consumers",
+		Units:    "consumers",
+		Fam:      "streams",
+		Ctx:      "redis.stream_consumer_group_consumers",
+		Priority: prioStreamConsumerGroupConsumers,
+		Dims: collectorapi.Dims{
+			{ID: "consumer_group_%s_consumers", Name: "consumers"},
+		},
+	}
+)
+
+func (c *Collector) addKeyPatternCharts(p KeyPatternConfig) {
+	c.addChartsFromTmpl(keyPatternChartsTmpl, p.Name, []collectorapi.Label{
+		{Key: "key_pattern", Value: p.Name},
+		{Key: "match", Value: p.Match},
+	})
+}
+
+func (c *Collector) addStreamCharts(key string) {
+	c.addChartsFromTmpl(streamChartsTmpl, streamID(key), []collectorapi.Label{
+		{Key: "stream", Value: key},
+	})
+}
+
+func (c *Collector) addStreamGroupCharts(sg streamGroup) {
+	c.addChartsFromTmpl(streamGroupChartsTmpl, sg.id(), []collectorapi.Label{
+		{Key: "stream", Value: sg.stream},
+		{Key: "consumer_group", Value: sg.group},
+	})
+}
+
+func (c *Collector) addChartsFromTmpl(tmpl collectorapi.Charts, id string, labels []collectorapi.Label) {
+	charts := tmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, id)
+		chart.Labels = labels
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, id)
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) removeCharts(tmpl collectorapi.Charts, id string) {
+	for _, t := range tmpl {
+		if chart := c.Charts().Get(fmt.Sprintf(t.ID, id)); chart != nil {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
@@ -37,6 +37,7 @@ func (c *Collector) collect() (map[string]int64, error) {
 	mx := make(map[string]int64)
 	c.collectInfo(mx, info)
 	c.collectPingLatency(mx)
+	c.collectKeyStats(mx)
 
 	return mx, nil
 }
@@ -0,0 +1,332 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type keyInfo struct {
+	key    string
+	typ    string
+	length int64
+	memory int64
+}
+
+type streamGroup struct {
+	stream string
+	group  string
+}
+
+// collectKeyStats scans the configured key patterns every KeyStats.UpdateEvery
+// seconds and reports the result of the last scan in between.
+func (c *Collector) collectKeyStats(mx map[string]int64) {
+	if len(c.KeyStats.Patterns) == 0 {
+		return
+	}
+
+	every := time.Duration(max(c.KeyStats.UpdateEvery, 1)) * time.Second
+	if c.keyStats == nil || time.Since(c.lastKeyScan) >= every {
+		c.lastKeyScan = time.Now()
+		prev := c.keyStats
+		c.keyStats = make(map[string]int64)
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.keyStatsTimeout())
+		if err := c.scanKeyPatterns(ctx, c.keyStats, prev); err != nil {
+			c.Warningf("key stats: %v", err)
+		}
+		cancel()
+	}
+
+	maps.Copy(mx, c.keyStats)
+}
+
+// scanKeyPatterns writes the metrics of a new scan to mx. Values of patterns and streams the scan did not reach
+// (it was stopped by the timeout) are copied from prev, the metrics of the previous scan.
+func (c *Collector) scanKeyPatterns(ctx context.Context, mx, prev map[string]int64) error {
+	seenStreams := make(map[string]bool)
+	seenGroups := make(map[streamGroup]bool)
+	listedGroups := make(map[string]bool) // streams whose consumer groups were listed
+	allComplete := true
+
+	var errs []error
+	for _, p := range c.KeyStats.Patterns {
+		px := "key_pattern_" + p.Name + "_"
+
+		if ctx.Err() != nil {
+			// not scanned: a zero would look like the keys are gone
+			allComplete = false
+			if _, ok := prev[px+"keys"]; ok {
+				for _, k := range []string{"keys", "length", "memory_usage"} {
+					mx[px+k] = prev[px+k]
+				}
+				mx[px+"scan_complete"] = 0
+				mx[px+"scan_truncated"] = 1
+			}
+			continue
+		}
+
+		// A scan cut short by the timeout still reports the keys found so far,
+		// flagged as truncated like a scan stopped by MaxScans or MaxKeys.
+		keys, complete, err := c.scanKeys(ctx, p.Match, p.Type, c.KeyStats.MaxKeys, c.KeyStats.MaxScans)
+		if err != nil && ctx.Err() == nil {
+			errs = append(errs, err)
+			allComplete = false
+			continue
+		}
+		if !c.keyPatterns[p.Name] {
+			c.keyPatterns[p.Name] = true
+			c.addKeyPatternCharts(p)
+		}
+
+		mx[px+"keys"] = int64(len(keys))
+		mx[px+"length"] = 0
+		mx[px+"memory_usage"] = 0
+
+		for _, key := range keys {
+			ki, err := c.inspectKey(ctx, key, p.Type)
+			if err != nil {
+				if ctx.Err() != nil {
+					complete = false
+					break
+				}
+				c.Debugf("key stats: inspect key '%s': %v", key, err)
+				continue
+			}
+			mx[px+"length"] += ki.length
+			mx[px+"memory_usage"] += ki.memory
+
+			if ki.typ == "stream" && !seenStreams[key] {
+				seenStreams[key] = true
+				if err := c.collectStream(ctx, mx, ki, seenGroups); err != nil {
+					c.Debugf("key stats: stream '%s': %v", key, err)
+				} else {
+					listedGroups[key] = true
+				}
+			}
+		}
+
+		mx[px+"scan_complete"] = boolToInt(complete)
+		mx[px+"scan_truncated"] = boolToInt(!complete)
+		allComplete = allComplete && complete
+	}
+	if ctx.Err() != nil {
+		errs = append(errs, fmt.Errorf("scan stopped after %s, results are truncated", c.keyStatsTimeout()))
+	}
+
+	for key := range seenStreams {
+		if !c.streams[key] {
+			c.streams[key] = true
+			c.addStreamCharts(key)
+		}
+	}
+	for sg := range seenGroups {
+		if !c.streamGroups[sg] {
+			c.streamGroups[sg] = true
+			c.addStreamGroupCharts(sg)
+		}
+	}
+
+	// A stream that was not reached may still exist, only a complete scan proves that it is gone.
+	for key := range c.streams {
+		switch {
+		case seenStreams[key]:
+		case allComplete:
+			delete(c.streams, key)
+			c.removeCharts(streamChartsTmpl, streamID(key))
+		default:
+			copyMetrics(mx, prev, streamChartsTmpl, streamID(key))
+		}
+	}
+	for sg := range c.streamGroups {
+		switch {
+		case seenGroups[sg]:
+		case allComplete || listedGroups[sg.stream]:
+			// the group is gone, or XINFO GROUPS of its stream no longer lists it
+			delete(c.streamGroups, sg)
+			c.removeCharts(streamGroupChartsTmpl, sg.id())
+		default:
+			copyMetrics(mx, prev, streamGroupChartsTmpl, sg.id())
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// copyMetrics copies the values of the dimensions of the charts created from tmpl for id.
+func copyMetrics(dst, src map[string]int64, tmpl collectorapi.Charts, id string) {
+	for _, chart := range tmpl {
+		for _, dim := range chart.Dims {
+			k := fmt.Sprintf(dim.ID, id)
+			if v, ok := src[k]; ok {
+				dst[k] = v
+			}
+		}
+	}
+}
+
+func (c *Collector) collectStream(ctx context.Context, mx map[string]int64, ki keyInfo, seen map[streamGroup]bool) error {
+	id := streamID(ki.key)
+	mx["stream_"+id+"_length"] = ki.length
+
+	groups, err := c.rdb.XInfoGroups(ctx, ki.key).Result()
+	if err != nil {
+		return err
+	}
+	mx["stream_"+id+"_consumer_groups"] = int64(len(groups))
+
+	for _, g := range groups {
+		sg := streamGroup{stream: ki.key, group: g.Name}
+		seen[sg] = true
+
+		px := "consumer_group_" + sg.id() + "_"
+		mx[px+"pending"] = g.Pending
+		mx[px+"consumers"] = g.Consumers
+		// Lag is reported by Redis 7.0+ and is -1 when it cannot be determined.
+		if g.Lag >= 0 {
+			mx[px+"lag"] = g.Lag
+		}
+	}
+	return nil
+}
+
+// scanKeys returns up to limit distinct keys matching the pattern, using at
+// most maxScans SCAN calls (0 means no limit). SCAN walks the keyspace
+// incrementally, so large databases do not block the server. complete reports
+// whether the whole keyspace was walked.
+func (c *Collector) scanKeys(ctx context.Context, match, typ string, limit, maxScans int) (keys []string, complete bool, err error) {
+	count := int64(max(c.KeyStats.ScanCount, 1))
+	seen := make(map[string]bool)
+	var cursor uint64
+
+	for scans := 1; ; scans++ {
+		var cmd *redis.ScanCmd
+		if typ == "" {
+			cmd = c.rdb.Scan(ctx, cursor, match, count)
+		} else {
+			cmd = c.rdb.ScanType(ctx, cursor, match, count, typ)
+		}
+		batch, next, err := cmd.Result()
+		if err != nil {
+			return keys, false, err
+		}
+		for _, key := range batch {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			keys = append(keys, key)
+			if limit > 0 && len(keys) >= limit {
+				return keys, next == 0, nil
+			}
+		}
+		if next == 0 {
+			return keys, true, nil
+		}
+		if maxScans > 0 && scans >= maxScans {
+			return keys, false, nil
+		}
+		cursor = next
+	}
+}
+
+var errKeyGone = errors.New("key no longer exists")
+
+func (c *Collector) inspectKey(ctx context.Context, key, typ string) (keyInfo, error) {
+	ki := keyInfo{key: key, typ: typ}
+
+	if ki.typ == "" {
+		v, err := c.rdb.Type(ctx, key).Result()
+		if err != nil {
+			return ki, err
+		}
+		ki.typ = v
+	}
+
+	var cmd *redis.IntCmd
+	switch ki.typ {
+	case "none":
+		return ki, errKeyGone
+	case "string":
+		cmd = c.rdb.StrLen(ctx, key)
+	case "list":
+		cmd = c.rdb.LLen(ctx, key)
+	case "hash":
+		cmd = c.rdb.HLen(ctx, key)
+	case "set":
+		cmd = c.rdb.SCard(ctx, key)
+	case "zset":
+		cmd = c.rdb.ZCard(ctx, key)
+	case "stream":
+		cmd = c.rdb.XLen(ctx, key)
+	}
+	if cmd != nil {
+		v, err := cmd.Result()
+		if err != nil {
+			return ki, err
+		}
+		ki.length = v
+	}
+
+	// SAMPLES 0 would walk every nested value, so only positive counts are sent;
+	// the server default is 5. MEMORY USAGE can be disabled by managed services,
+	// the length is still reported then.
+	var samples []int
+	if c.KeyStats.MemorySamples > 0 {
+		samples = append(samples, c.KeyStats.MemorySamples)
+	}
+	if v, err := c.rdb.MemoryUsage(ctx, key, samples...).Result(); err == nil {
+		ki.memory = v
+	} else if errors.Is(err, redis.Nil) {
+		return ki, errKeyGone
+	}
+
+	return ki, nil
+}
+
+var idReplacer = strings.NewReplacer(" ", "_", ".", "_", "\t", "_", "\n", "_")
+
+// streamID returns the chart ID part of a stream. Names that are changed to be valid in an ID
+// get a hash suffix, so that "a.b" and "a_b" do not share charts.
+func streamID(key string) string {
+	id := idReplacer.Replace(key)
+	if id != key {
+		id += "_" + nameHash(key)
+	}
+	return id
+}
+
+// id returns the chart ID part of a consumer group. The stream and group names are joined with "_",
+// a hash suffix is added when that is ambiguous or a name was changed.
+func (sg streamGroup) id() string {
+	stream, group := idReplacer.Replace(sg.stream), idReplacer.Replace(sg.group)
+	id := stream + "_" + group
+	if stream != sg.stream || group != sg.group || strings.Contains(stream+group, "_") {
+		id += "_" + nameHash(sg.stream+"\x00"+sg.group)
+	}
+	return id
+}
+
+func nameHash(s string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(s))
+	return strconv.FormatUint(uint64(h.Sum32()), 16)
+}
+
+func boolToInt(v bool) int64 {
+	if v {
+		return 1
+	}
+	return 0
+}
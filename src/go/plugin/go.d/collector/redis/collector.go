@@ -44,10 +44,21 @@ func New() *Collector {
 			Address:     "redis://@localhost:6379",
 			Timeout:     confopt.Duration(time.Second),
 			PingSamples: 5,
+			KeyStats: KeyStatsConfig{
+				UpdateEvery:   60,
+				ScanCount:     100,
+				MaxScans:      100,
+				MaxKeys:       1000,
+				MemorySamples: 5,
+			},
 			Functions: FunctionsConfig{
 				TopQueries: TopQueriesConfig{
 					Limit: 500,
 				},
+				LargestKeys: LargestKeysConfig{
+					Limit:   100,
+					MaxKeys: 10000,
+				},
 			},
 		},
 
@@ -56,6 +67,9 @@ func New() *Collector {
 		pingSummary:            oldmetrix.NewSummary(),
 		collectedCommands:      make(map[string]bool),
 		collectedDbs:           make(map[string]bool),
+		keyPatterns:            make(map[string]bool),
+		streams:                make(map[string]bool),
+		streamGroups:           make(map[streamGroup]bool),
 	}
 	c.funcRouter = newFuncRouter(c)
 	return c
@@ -71,11 +85,39 @@ type Config struct {
 	Password           string           `yaml:"password,omitempty" json:"password"`
 	tlscfg.TLSConfig   `yaml:",inline" json:""`
 	PingSamples        int             `yaml:"ping_samples" json:"ping_samples"`
+	KeyStats           KeyStatsConfig  `yaml:"key_stats,omitempty" json:"key_stats"`
 	Functions          FunctionsConfig `yaml:"functions,omitempty" json:"functions"`
 }
 
+// KeyStatsConfig configures key pattern monitoring. Keys are found with SCAN,
+// so the server is never blocked, and the work per scan is bounded by Timeout,
+// MaxScans and MaxKeys.
+type KeyStatsConfig struct {
+	UpdateEvery   int                `yaml:"update_every,omitempty" json:"update_every"`
+	Timeout       confopt.Duration   `yaml:"timeout,omitempty" json:"timeout"`
+	ScanCount     int                `yaml:"scan_count,omitempty" json:"scan_count"`
+	MaxScans      int                `yaml:"max_scans,omitempty" json:"max_scans"`
+	MaxKeys       int                `yaml:"max_keys,omitempty" json:"max_keys"`
+	MemorySamples int                `yaml:"memory_samples,omitempty" json:"memory_samples"`
+	Patterns      []KeyPatternConfig `yaml:"patterns,omitempty" json:"patterns"`
+}
+
+type KeyPatternConfig struct {
+	Name  string `yaml:"name" json:"name"`
+	Match string `yaml:"match" json:"match"`
+	Type  string `yaml:"type,omitempty" json:"type"`
+}
+
 type FunctionsConfig struct {
-	TopQueries TopQueriesConfig `yaml:"top_queries,omitempty" json:"top_queries"`
+	TopQueries  TopQueriesConfig  `yaml:"top_queries,omitempty" json:"top_queries"`
+	LargestKeys LargestKeysConfig `yaml:"largest_keys,omitempty" json:"largest_keys"`
+}
+
+type LargestKeysConfig struct {
+	Disabled bool             `yaml:"disabled" json:"disabled"`
+	Timeout  confopt.Duration `yaml:"timeout,omitempty" json:"timeout"`
+	Limit    int              `yaml:"limit,omitempty" json:"limit"`
+	MaxKeys  int              `yaml:"max_keys,omitempty" json:"max_keys"`
 }
 
 type TopQueriesConfig struct {
@@ -84,6 +126,13 @@ type TopQueriesConfig struct {
 	Limit    int              `yaml:"limit,omitempty" json:"limit"`
 }
 
+func (c Config) keyStatsTimeout() time.Duration {
+	if c.KeyStats.Timeout == 0 {
+		return c.Timeout.Duration()
+	}
+	return c.KeyStats.Timeout.Duration()
+}
+
 func (c Config) topQueriesTimeout() time.Duration {
 	if c.Functions.TopQueries.Timeout == 0 {
 		return c.Timeout.Duration()
@@ -98,6 +147,27 @@ func (c Config) topQueriesLimit() int {
 	return c.Functions.TopQueries.Limit
 }
 
+func (c Config) largestKeysTimeout() time.Duration {
+	if c.Functions.LargestKeys.Timeout == 0 {
+		return max(c.Timeout.Duration(), 10*time.Second)
+	}
+	return c.Functions.LargestKeys.Timeout.Duration()
+}
+
+func (c Config) largestKeysLimit() int {
+	if c.Functions.LargestKeys.Limit <= 0 {
+		return 100
+	}
+	return c.Functions.LargestKeys.Limit
+}
+
+func (c Config) largestKeysMaxKeys() int {
+	if c.Functions.LargestKeys.MaxKeys <= 0 {
+		return 10000
+	}
+	return c.Functions.LargestKeys.MaxKeys
+}
+
 type (
 	Collector struct {
 		collectorapi.Base
@@ -116,11 +186,28 @@ type (
 		pingSummary       oldmetrix.Summary
 		collectedCommands map[string]bool
 		collectedDbs      map[string]bool
+
+		lastKeyScan  time.Time
+		keyStats     map[string]int64 // metrics of the last key scan, reported until the next one
+		keyPatterns  map[string]bool
+		streams      map[string]bool
+		streamGroups map[streamGroup]bool
 	}
 	redisClient interface {
 		Info(ctx context.Context, section ...string) *redis.StringCmd
 		Ping(context.Context) *redis.StatusCmd
 		SlowLogGet(ctx context.Context, num int64) *redis.SlowLogCmd
+		Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd
+		ScanType(ctx context.Context, cursor uint64, match string, count int64, keyType string) *redis.ScanCmd
+		Type(ctx context.Context, key string) *redis.StatusCmd
+		MemoryUsage(ctx context.Context, key string, samples ...int) *redis.IntCmd
+		StrLen(ctx context.Context, key string) *redis.IntCmd
+		LLen(ctx context.Context, key string) *redis.IntCmd
+		HLen(ctx context.Context, key string) *redis.IntCmd
+		SCard(ctx context.Context, key string) *redis.IntCmd
+		ZCard(ctx context.Context, key string) *redis.IntCmd
+		XLen(ctx context.Context, stream string) *redis.IntCmd
+		XInfoGroups(ctx context.Context, stream string) *redis.XInfoGroupsCmd
 		Close() error
 	}
 )
@@ -5,9 +5,13 @@ package redis
 import (
 	"context"
 	"errors"
+	"maps"
 	"os"
+	"path"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
@@ -56,6 +60,30 @@ func TestCollector_Init(t *testing.T) {
 			wantFail: true,
 			config:   Config{Address: "127.0.0.1:6379"},
 		},
+		"fails on key pattern without 'match'": {
+			wantFail: true,
+			config: Config{
+				Address:  "redis://127.0.0.1:6379",
+				KeyStats: KeyStatsConfig{Patterns: []KeyPatternConfig{{Name: "queues"}}},
+			},
+		},
+		"fails on duplicate key pattern names": {
+			wantFail: true,
+			config: Config{
+				Address: "redis://127.0.0.1:6379",
+				KeyStats: KeyStatsConfig{Patterns: []KeyPatternConfig{
+					{Name: "queues", Match: "queue:*"},
+					{Name: "queues", Match: "jobs:*"},
+				}},
+			},
+		},
+		"fails on unknown key pattern type": {
+			wantFail: true,
+			config: Config{
+				Address:  "redis://127.0.0.1:6379",
+				KeyStats: KeyStatsConfig{Patterns: []KeyPatternConfig{{Name: "queues", Match: "queue:*", Type: "queue"}}},
+			},
+		},
 		"fails on invalid TLSCA": {
 			wantFail: true,
 			config: Config{
@@ -312,6 +340,181 @@ func TestCollector_Collect(t *testing.T) {
 	}
 }
 
+func TestCollector_Collect_KeyStats(t *testing.T) {
+	collr := New()
+	collr.KeyStats.Patterns = []KeyPatternConfig{
+		{Name: "queues", Match: "queue:*"},
+		{Name: "events", Match: "events:*", Type: "stream"},
+	}
+	require.NoError(t, collr.Init(context.Background()))
+	m := &mockRedisClient{
+		result: dataVer609InfoAll,
+		keys: map[string]mockRedisKey{
+			"queue:mail": {typ: "list", length: 10, memory: 1000},
+			"queue:jobs": {typ: "hash", length: 5, memory: 500},
+			"events:orders": {typ: "stream", length: 100, memory: 4096, groups: []redis.XInfoGroup{
+				{Name: "billing", Consumers: 2, Pending: 3, Lag: 7},
+				{Name: "shipping", Consumers: 1, Pending: 0, Lag: 0},
+			}},
+			"session:1": {typ: "string", length: 64, memory: 128},
+		},
+	}
+	collr.rdb = m
+
+	mx := collr.Collect(context.Background())
+
+	want := map[string]int64{
+		"key_pattern_queues_keys":                         2,
+		"key_pattern_queues_scan_complete":                1,
+		"key_pattern_queues_scan_truncated":               0,
+		"key_pattern_queues_length":                       15,
+		"key_pattern_queues_memory_usage":                 1500,
+		"key_pattern_events_keys":                         1,
+		"key_pattern_events_length":                       100,
+		"key_pattern_events_memory_usage":                 4096,
+		"stream_events:orders_length":                     100,
+		"stream_events:orders_consumer_groups":            2,
+		"consumer_group_events:orders_billing_pending":    3,
+		"consumer_group_events:orders_billing_lag":        7,
+		"consumer_group_events:orders_billing_consumers":  2,
+		"consumer_group_events:orders_shipping_pending":   0,
+		"consumer_group_events:orders_shipping_lag":       0,
+		"consumer_group_events:orders_shipping_consumers": 1,
+	}
+	for k, v := range want {
+		assert.Equalf(t, v, mx[k], "metric '%s'", k)
+	}
+	collecttest.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+
+	scans := m.scans
+	delete(m.keys, "events:orders")
+	mx = collr.Collect(context.Background())
+	assert.Equal(t, scans, m.scans, "keys are scanned once per key_stats.update_every")
+	assert.Equal(t, int64(100), mx["stream_events:orders_length"], "last scan is reported until the next one")
+
+	collr.lastKeyScan = time.Time{}
+	mx = collr.Collect(context.Background())
+	assert.Equal(t, int64(0), mx["key_pattern_events_keys"])
+	assert.NotContains(t, mx, "stream_events:orders_length")
+	for _, id := range []string{"stream_events:orders_length", "consumer_group_events:orders_billing_lag"} {
+		chart := collr.Charts().Get(id)
+		require.NotNilf(t, chart, "chart '%s'", id)
+		assert.Truef(t, chart.Obsolete, "chart '%s' is not removed", id)
+	}
+}
+
+func TestCollector_Collect_KeyStatsTruncated(t *testing.T) {
+	keys := map[string]mockRedisKey{
+		"queue:1": {typ: "list", length: 1, memory: 10},
+		"queue:2": {typ: "list", length: 2, memory: 20},
+		"queue:3": {typ: "list", length: 3, memory: 30},
+		"queue:4": {typ: "list", length: 4, memory: 40},
+	}
+
+	tests := map[string]struct {
+		maxScans      int
+		maxKeys       int
+		wantKeys      int64
+		wantScans     int
+		wantTruncated int64
+	}{
+		"whole keyspace": {
+			wantKeys:      4,
+			wantScans:     2,
+			wantTruncated: 0,
+		},
+		"stopped by max_scans": {
+			maxScans:      1,
+			wantKeys:      3,
+			wantScans:     1,
+			wantTruncated: 1,
+		},
+		"stopped by max_keys": {
+			maxKeys:       2,
+			wantKeys:      2,
+			wantScans:     1,
+			wantTruncated: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collr := New()
+			collr.KeyStats.MaxScans = test.maxScans
+			collr.KeyStats.MaxKeys = test.maxKeys
+			collr.KeyStats.Patterns = []KeyPatternConfig{{Name: "queues", Match: "queue:*"}}
+			require.NoError(t, collr.Init(context.Background()))
+			m := &mockRedisClient{result: dataVer609InfoAll, keys: keys, scanPage: 3}
+			collr.rdb = m
+
+			mx := collr.Collect(context.Background())
+
+			assert.Equal(t, test.wantKeys, mx["key_pattern_queues_keys"])
+			assert.Equal(t, test.wantScans, m.scans)
+			assert.Equal(t, test.wantTruncated, mx["key_pattern_queues_scan_truncated"])
+			assert.Equal(t, 1-test.wantTruncated, mx["key_pattern_queues_scan_complete"])
+		})
+	}
+}
+
+func TestCollector_Collect_KeyStatsTimeout(t *testing.T) {
+	collr := New()
+	collr.KeyStats.Patterns = []KeyPatternConfig{
+		{Name: "queues", Match: "queue:*"},
+		{Name: "events", Match: "events:*", Type: "stream"},
+	}
+	require.NoError(t, collr.Init(context.Background()))
+	collr.rdb = &mockRedisClient{
+		result: dataVer609InfoAll,
+		keys: map[string]mockRedisKey{
+			"queue:mail": {typ: "list", length: 10, memory: 1000},
+			"events:orders": {typ: "stream", length: 100, memory: 4096, groups: []redis.XInfoGroup{
+				{Name: "billing", Consumers: 2, Pending: 3, Lag: 7},
+			}},
+		},
+	}
+
+	prev := collr.Collect(context.Background())
+	require.Equal(t, int64(1), prev["key_pattern_events_keys"])
+
+	// the timeout expired before the patterns were scanned
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mx := make(map[string]int64)
+	assert.Error(t, collr.scanKeyPatterns(ctx, mx, prev))
+
+	assert.Equal(t, int64(1), mx["key_pattern_events_keys"], "the previous value is kept")
+	assert.Equal(t, int64(1), mx["key_pattern_events_scan_truncated"])
+	assert.Equal(t, int64(100), mx["stream_events:orders_length"])
+	assert.Equal(t, int64(7), mx["consumer_group_events:orders_billing_lag"])
+	for _, id := range []string{"stream_events:orders_length", "consumer_group_events:orders_billing_lag"} {
+		chart := collr.Charts().Get(id)
+		require.NotNilf(t, chart, "chart '%s'", id)
+		assert.Falsef(t, chart.Obsolete, "chart '%s' is removed after a truncated scan", id)
+	}
+
+	// a pattern that was never scanned is not reported as zero keys
+	mx = make(map[string]int64)
+	assert.Error(t, collr.scanKeyPatterns(ctx, mx, nil))
+	assert.NotContains(t, mx, "key_pattern_queues_keys")
+}
+
+func Test_streamIDs(t *testing.T) {
+	assert.Equal(t, "events:orders", streamID("events:orders"))
+	assert.NotEqual(t, streamID("a.b"), streamID("a_b"))
+	assert.NotEqual(t, streamID("a b"), streamID("a.b"))
+
+	assert.Equal(t, "events:orders_billing", streamGroup{stream: "events:orders", group: "billing"}.id())
+	assert.NotEqual(t,
+		streamGroup{stream: "x", group: "y_z"}.id(),
+		streamGroup{stream: "x_y", group: "z"}.id(),
+	)
+	assert.NotEqual(t,
+		streamGroup{stream: "x", group: "y.z"}.id(),
+		streamGroup{stream: "x", group: "y_z"}.id(),
+	)
+}
+
 func prepareRedisV609(t *testing.T) *Collector {
 	collr := New()
 	require.NoError(t, collr.Init(context.Background()))
@@ -379,9 +582,19 @@ func copyTimeRelatedMetrics(dst, src map[string]int64) {
 type mockRedisClient struct {
 	errOnInfo   bool
 	result      []byte
+	keys        map[string]mockRedisKey
+	scanPage    int
+	scans       int
 	calledClose bool
 }
 
+type mockRedisKey struct {
+	typ    string
+	length int64
+	memory int64
+	groups []redis.XInfoGroup
+}
+
 func (m *mockRedisClient) Info(_ context.Context, _ ...string) (cmd *redis.StringCmd) {
 	if m.errOnInfo {
 		cmd = redis.NewStringResult("", errors.New("error on Info"))
@@ -401,6 +614,66 @@ func (m *mockRedisClient) SlowLogGet(ctx context.Context, num int64) *redis.Slow
 	return cmd
 }
 
+func (m *mockRedisClient) Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd {
+	return m.ScanType(ctx, cursor, match, count, "")
+}
+
+// ScanType walks the sorted keys scanPage keys at a time (all at once when
+// scanPage is 0), using the position of the next page as the cursor.
+func (m *mockRedisClient) ScanType(_ context.Context, cursor uint64, match string, _ int64, keyType string) *redis.ScanCmd {
+	m.scans++
+	all := slices.Sorted(maps.Keys(m.keys))
+	page := m.scanPage
+	if page == 0 {
+		page = len(all)
+	}
+
+	end := min(int(cursor)+page, len(all))
+	var keys []string
+	for _, key := range all[cursor:end] {
+		v := m.keys[key]
+		if ok, _ := path.Match(match, key); ok && (keyType == "" || keyType == v.typ) {
+			keys = append(keys, key)
+		}
+	}
+	var next uint64
+	if end < len(all) {
+		next = uint64(end)
+	}
+	return redis.NewScanCmdResult(keys, next, nil)
+}
+
+func (m *mockRedisClient) Type(_ context.Context, key string) *redis.StatusCmd {
+	if v, ok := m.keys[key]; ok {
+		return redis.NewStatusResult(v.typ, nil)
+	}
+	return redis.NewStatusResult("none", nil)
+}
+
+func (m *mockRedisClient) MemoryUsage(_ context.Context, key string, _ ...int) *redis.IntCmd {
+	if v, ok := m.keys[key]; ok {
+		return redis.NewIntResult(v.memory, nil)
+	}
+	return redis.NewIntResult(0, redis.Nil)
+}
+
+func (m *mockRedisClient) length(key string) *redis.IntCmd {
+	return redis.NewIntResult(m.keys[key].length, nil)
+}
+
+func (m *mockRedisClient) StrLen(_ context.Context, key string) *redis.IntCmd { return m.length(key) }
+func (m *mockRedisClient) LLen(_ context.Context, key string) *redis.IntCmd   { return m.length(key) }
+func (m *mockRedisClient) HLen(_ context.Context, key string) *redis.IntCmd   { return m.length(key) }
+func (m *mockRedisClient) SCard(_ context.Context, key string) *redis.IntCmd  { return m.length(key) }
+func (m *mockRedisClient) ZCard(_ context.Context, key string) *redis.IntCmd  { return m.length(key) }
+func (m *mockRedisClient) XLen(_ context.Context, key string) *redis.IntCmd   { return m.length(key) }
+
+func (m *mockRedisClient) XInfoGroups(ctx context.Context, stream string) *redis.XInfoGroupsCmd {
+	cmd := redis.NewXInfoGroupsCmd(ctx, stream)
+	cmd.SetVal(m.keys[stream].groups)
+	return cmd
+}
+
 func (m *mockRedisClient) Close() error {
 	m.calledClose = true
 	return nil
@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/netdata/netdata/go/plugins/pkg/funcapi"
+)
+
+const largestKeysMethodID = "largest-keys"
+
+const largestKeysHelp = "Largest keys found by a bounded SCAN, measured with MEMORY USAGE. WARNING: Key names may contain application data (potential PII)."
+
+func largestKeysFunctionConfig() funcapi.FunctionConfig {
+	return funcapi.FunctionConfig{
+		ID:             largestKeysMethodID,
+		Name:           "Largest Keys",
+		UpdateEvery:    60,
+		Help:           largestKeysHelp,
+		RequireCloud:   true,
+		RequiredParams: []funcapi.ParamConfig{funcapi.BuildSortParam(largestKeysColumns)},
+	}
+}
+
+var largestKeysColumns = []redisColumn{
+	{ColumnMeta: funcapi.ColumnMeta{Name: "key", Tooltip: "Key", Type: funcapi.FieldTypeString, Visible: true, Sortable: true, Filter: funcapi.FieldFilterMultiselect, Visualization: funcapi.FieldVisualValue, Transform: funcapi.FieldTransformText, UniqueKey: true, Sticky: true, FullWidth: true, Wrap: true, Sort: funcapi.FieldSortAscending}, sortOpt: true, sortLbl: "Largest keys by Key"},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "type", Tooltip: "Type", Type: funcapi.FieldTypeString, Visible: true, Sortable: true, Filter: funcapi.FieldFilterMultiselect, Visualization: funcapi.FieldVisualValue, Transform: funcapi.FieldTransformText, Sort: funcapi.FieldSortAscending, GroupBy: &funcapi.GroupByOptions{IsDefault: true}}},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "length", Tooltip: "Length", Type: funcapi.FieldTypeInteger, Visible: true, Sortable: true, Filter: funcapi.FieldFilterRange, Visualization: funcapi.FieldVisualBar, Transform: funcapi.FieldTransformNumber, Units: "elements", Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummarySum, Chart: &funcapi.ChartOptions{Group: "Length", Title: "Length"}}, sortOpt: true, sortLbl: "Largest keys by Length"},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "memory", Tooltip: "Memory Usage", Type: funcapi.FieldTypeInteger, Visible: true, Sortable: true, Filter: funcapi.FieldFilterRange, Visualization: funcapi.FieldVisualBar, Transform: funcapi.FieldTransformNumber, Units: "bytes", Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummarySum, Chart: &funcapi.ChartOptions{Group: "Memory", Title: "Memory Usage", IsDefault: true}}, sortOpt: true, sortLbl: "Largest keys by Memory Usage", defaultSort: true},
+}
+
+// Compile-time interface check.
+var _ funcapi.MethodHandler = (*funcLargestKeys)(nil)
+
+// funcLargestKeys handles the "largest-keys" function for Redis.
+type funcLargestKeys struct {
+	router *funcRouter
+}
+
+func newFuncLargestKeys(r *funcRouter) *funcLargestKeys {
+	return &funcLargestKeys{router: r}
+}
+
+// MethodParams implements funcapi.MethodHandler.
+func (f *funcLargestKeys) MethodParams(_ context.Context, method string) ([]funcapi.ParamConfig, error) {
+	if method != largestKeysMethodID {
+		return nil, fmt.Errorf("unknown method: %s", method)
+	}
+	if f.router.collector.Functions.LargestKeys.Disabled {
+		return nil, fmt.Errorf("largest-keys function disabled in configuration")
+	}
+	return []funcapi.ParamConfig{funcapi.BuildSortParam(largestKeysColumns)}, nil
+}
+
+// Handle implements funcapi.MethodHandler.
+func (f *funcLargestKeys) Handle(ctx context.Context, method string, params funcapi.ResolvedParams) *funcapi.FunctionResponse {
+	if method != largestKeysMethodID {
+		return funcapi.NotFoundResponse(method)
+	}
+	if f.router.collector.Functions.LargestKeys.Disabled {
+		return funcapi.UnavailableResponse("largest-keys function has been disabled in configuration")
+	}
+
+	if f.router.collector.rdb == nil {
+		return funcapi.UnavailableResponse("collector is still initializing, please retry in a few seconds")
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, f.router.collector.largestKeysTimeout())
+	defer cancel()
+	return f.collectLargestKeys(queryCtx, params.Column("__sort"))
+}
+
+// Cleanup implements funcapi.MethodHandler.
+func (f *funcLargestKeys) Cleanup(context.Context) {}
+
+func (f *funcLargestKeys) collectLargestKeys(ctx context.Context, sortColumn string) *funcapi.FunctionResponse {
+	c := f.router.collector
+
+	keys, complete, err := c.scanKeys(ctx, "*", "", c.largestKeysMaxKeys(), 0)
+	if err != nil && len(keys) == 0 {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return &funcapi.FunctionResponse{Status: 504, Message: "key scan timed out"}
+		}
+		return &funcapi.FunctionResponse{Status: 500, Message: fmt.Sprintf("key scan failed: %v", err)}
+	}
+
+	var message string
+	if !complete {
+		message = fmt.Sprintf("Only the first %d keys returned by SCAN were examined.", len(keys))
+	}
+
+	infos := make([]keyInfo, 0, len(keys))
+	for _, key := range keys {
+		ki, err := c.inspectKey(ctx, key, "")
+		if err != nil {
+			if ctx.Err() != nil {
+				message = fmt.Sprintf("Timed out after examining %d of %d keys, results are partial.", len(infos), len(keys))
+				break
+			}
+			continue
+		}
+		infos = append(infos, ki)
+	}
+
+	sortLargestKeys(infos, mapLargestKeysSortColumn(sortColumn))
+	if limit := c.largestKeysLimit(); len(infos) > limit {
+		infos = infos[:limit]
+	}
+
+	data := make([][]any, 0, len(infos))
+	for _, ki := range infos {
+		row := make([]any, len(largestKeysColumns))
+		for i, col := range largestKeysColumns {
+			switch col.Name {
+			case "key":
+				row[i] = ki.key
+			case "type":
+				row[i] = ki.typ
+			case "length":
+				row[i] = ki.length
+			case "memory":
+				row[i] = ki.memory
+			}
+		}
+		data = append(data, row)
+	}
+
+	if len(data) == 0 && message == "" {
+		message = "No keys found."
+	}
+
+	cs := redisColumnSet(largestKeysColumns)
+	return &funcapi.FunctionResponse{
+		Status:            200,
+		Message:           message,
+		Help:              largestKeysHelp,
+		Columns:           cs.BuildColumns(),
+		Data:              data,
+		DefaultSortColumn: "memory",
+		RequiredParams:    []funcapi.ParamConfig{funcapi.BuildSortParam(largestKeysColumns)},
+		ChartingConfig:    cs.BuildCharting(),
+	}
+}
+
+func mapLargestKeysSortColumn(col string) string {
+	switch col {
+	case "memory", "length", "key":
+		return col
+	default:
+		return "memory"
+	}
+}
+
+func sortLargestKeys(infos []keyInfo, sortColumn string) {
+	switch sortColumn {
+	case "key":
+		sort.Slice(infos, func(i, j int) bool { return infos[i].key < infos[j].key })
+	case "length":
+		sort.Slice(infos, func(i, j int) bool { return infos[i].length > infos[j].length })
+	default:
+		sort.Slice(infos, func(i, j int) bool { return infos[i].memory > infos[j].memory })
+	}
+}
@@ -23,6 +23,7 @@ func newFuncRouter(c *Collector) *funcRouter {
 		handlers:  make(map[string]funcapi.MethodHandler),
 	}
 	r.handlers[topQueriesMethodID] = newFuncTopQueries(r)
+	r.handlers[largestKeysMethodID] = newFuncLargestKeys(r)
 	return r
 }
 
@@ -52,6 +53,7 @@ func (r *funcRouter) Cleanup(ctx context.Context) {
 func redisMethods() []funcapi.FunctionConfig {
 	return []funcapi.FunctionConfig{
 		topQueriesFunctionConfig(),
+		largestKeysFunctionConfig(),
 	}
 }
 
@@ -3,17 +3,20 @@
 package redis
 
 import (
+	"context"
 	"testing"
 
 	"github.com/netdata/netdata/go/plugins/pkg/funcapi"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRedisMethods(t *testing.T) {
 	methods := redisMethods()
 
 	require := assert.New(t)
-	require.Len(methods, 1)
+	require.Len(methods, 2)
+	require.Equal("largest-keys", methods[1].ID)
 	require.Equal("top-queries", methods[0].ID)
 	require.Equal("Top Queries", methods[0].Name)
 	require.NotEmpty(methods[0].RequiredParams)
@@ -68,3 +71,34 @@ func TestMapRedisSortColumn(t *testing.T) {
 		})
 	}
 }
+
+func TestFuncLargestKeys_Handle(t *testing.T) {
+	collr := New()
+	collr.Functions.LargestKeys.Limit = 2
+	collr.rdb = &mockRedisClient{keys: map[string]mockRedisKey{
+		"queue:mail": {typ: "list", length: 10, memory: 1000},
+		"queue:jobs": {typ: "hash", length: 500, memory: 500},
+		"session:1":  {typ: "string", length: 64, memory: 128},
+	}}
+
+	tests := map[string]struct {
+		sort     string
+		wantKeys []string
+	}{
+		"default sort by memory": {sort: "", wantKeys: []string{"queue:mail", "queue:jobs"}},
+		"sort by length":         {sort: "length", wantKeys: []string{"queue:jobs", "session:1"}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := collr.funcRouter.Handle(context.Background(), largestKeysMethodID, funcapi.ResolvedParams{"__sort": {Options: []funcapi.ParamOption{{ID: test.sort}}}})
+			require.Equal(t, 200, resp.Status, resp.Message)
+
+			var keys []string
+			for _, row := range resp.Data.([][]any) {
+				keys = append(keys, row[0].(string))
+			}
+			assert.Equal(t, test.wantKeys, keys)
+		})
+	}
+}
@@ -4,6 +4,8 @@ package redis
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/redis/go-redis/v9"
 
@@ -15,6 +17,30 @@ func (c *Collector) validateConfig() error {
 	if c.Address == "" {
 		return errors.New("'address' not set")
 	}
+	return validateKeyPatterns(c.KeyStats.Patterns)
+}
+
+var keyTypes = map[string]bool{
+	"string": true, "list": true, "hash": true, "set": true, "zset": true, "stream": true,
+}
+
+func validateKeyPatterns(patterns []KeyPatternConfig) error {
+	seen := make(map[string]bool)
+	for i, p := range patterns {
+		switch {
+		case p.Name == "":
+			return fmt.Errorf("'key_stats.patterns[%d]': 'name' not set", i)
+		case strings.ContainsAny(p.Name, " \t."):
+			return fmt.Errorf("'key_stats.patterns[%d]': 'name' must not contain spaces or dots", i)
+		case seen[p.Name]:
+			return fmt.Errorf("'key_stats.patterns[%d]': duplicate name '%s'", i, p.Name)
+		case p.Match == "":
+			return fmt.Errorf("'key_stats.patterns[%d]': 'match' not set", i)
+		case p.Type != "" && !keyTypes[p.Type]:
+			return fmt.Errorf("'key_stats.patterns[%d]': unknown type '%s'", i, p.Type)
+		}
+		seen[p.Name] = true
+	}
 	return nil
 }
 
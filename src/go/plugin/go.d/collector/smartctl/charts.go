@@ -10,7 +10,10 @@ import (
 )
 
 const (
-	prioDeviceSmartStatus = collectorapi.Priority + iota
+	prioDeviceHealthScore = collectorapi.Priority + iota
+	prioDeviceHealthRisk
+	prioDeviceDefectsGrowth
+	prioDeviceSmartStatus
 	prioDeviceAtaSmartErrorLogCount
 	prioDevicePowerOnTime
 	prioDeviceTemperature
@@ -96,6 +99,53 @@ var (
 	}
 )
 
+var deviceHealthChartsTmpl = collectorapi.Charts{
+	deviceHealthScoreChartTmpl.Copy(),
+	deviceHealthRiskChartTmpl.Copy(),
+	deviceDefectsGrowthChartTmpl.Copy(),
+}
+
+var (
+	deviceHealthScoreChartTmpl = collectorapi.Chart{
+		ID:       "device_%s_type_%s_health_score",
+		Title:    "Device health score",
+		Units:    "score",
+		Fam:      "health",
+		Ctx:      "smartctl.device_health_score",
+		Type:     collectorapi.Line,
+		Priority: prioDeviceHealthScore,
+		Dims: collectorapi.Dims{
+			{ID: "device_%s_type_%s_health_score", Name: "score"},
+		},
+	}
+	deviceHealthRiskChartTmpl = collectorapi.Chart{
+		ID:       "device_%s_type_%s_health_risk",
+		Title:    "Device failure risk",
+		Units:    "status",
+		Fam:      "health",
+		Ctx:      "smartctl.device_health_risk",
+		Type:     collectorapi.Line,
+		Priority: prioDeviceHealthRisk,
+		Dims: collectorapi.Dims{
+			{ID: "device_%s_type_%s_health_risk_low", Name: "low"},
+			{ID: "device_%s_type_%s_health_risk_medium", Name: "medium"},
+			{ID: "device_%s_type_%s_health_risk_high", Name: "high"},
+		},
+	}
+	deviceDefectsGrowthChartTmpl = collectorapi.Chart{
+		ID:       "device_%s_type_%s_defects_growth",
+		Title:    "Device defects growth",
+		Units:    "defects/day",
+		Fam:      "health",
+		Ctx:      "smartctl.device_defects_growth",
+		Type:     collectorapi.Line,
+		Priority: prioDeviceDefectsGrowth,
+		Dims: collectorapi.Dims{
+			{ID: "device_%s_type_%s_defects_growth", Name: "growth", Div: 1000},
+		},
+	}
+)
+
 var deviceScsiErrorLogChartsTmpl = collectorapi.Charts{
 	deviceScsiReadErrorsChartTmpl.Copy(),
 	deviceScsiWriteErrorsChartTmpl.Copy(),
@@ -193,6 +243,11 @@ func (c *Collector) addDeviceCharts(dev *smartDevice, id deviceIdentity, smartAt
 			return nil, err
 		}
 	}
+	if cs := c.newDeviceHealthCharts(dev, id); cs != nil && len(*cs) > 0 {
+		if err := charts.Add(*cs...); err != nil {
+			return nil, err
+		}
+	}
 
 	candidate := append(collectorapi.Charts(nil), (*c.Charts())...)
 	if err := candidate.Add(charts...); err != nil {
@@ -307,6 +362,29 @@ func (c *Collector) newDeviceScsiErrorLogCharts(dev *smartDevice, id deviceIdent
 	return charts
 }
 
+func (c *Collector) newDeviceHealthCharts(dev *smartDevice, id deviceIdentity) *collectorapi.Charts {
+	attrs := dev.healthAttributes()
+	if !attrs.Known() {
+		return nil
+	}
+
+	charts := deviceHealthChartsTmpl.Copy()
+
+	if _, ok := attrs.Defects(); !ok {
+		_ = charts.Remove(deviceDefectsGrowthChartTmpl.ID)
+	}
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, id.name, id.typ)
+		chart.Labels = deviceChartLabels(dev)
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, id.name, id.typ)
+		}
+	}
+
+	return charts
+}
+
 func deviceChartLabels(dev *smartDevice) []collectorapi.Label {
 	return []collectorapi.Label{
 		{Key: "device_name", Value: dev.deviceName()},
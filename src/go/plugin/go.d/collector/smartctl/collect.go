@@ -33,6 +33,7 @@ func (c *Collector) collect() (map[string]int64, error) {
 					delete(c.attachedDevices, k)
 					removeDeviceCharts(attached.charts)
 				}
+				c.removeDeviceHealth(k)
 			}
 		}
 
@@ -161,6 +162,7 @@ func (c *Collector) processDeviceResult(mx map[string]int64, result deviceInfoRe
 	}
 
 	c.collectSmartDevice(mx, dev, id, attached.smartAttrs)
+	c.collectDeviceHealth(mx, key, dev, id)
 
 	return nil
 }
@@ -215,11 +217,7 @@ func (c *Collector) collectSmartDevice(mx map[string]int64, dev *smartDevice, id
 				mx[px+"normalized"] = v
 			}
 
-			rs := strings.TrimSpace(attr.rawString())
-			if i := strings.IndexByte(rs, ' '); i != -1 {
-				rs = rs[:i]
-			}
-			if v, err := strconv.ParseInt(rs, 10, 64); err == nil {
+			if v, ok := attr.decodedRaw(); ok {
 				mx[px+"decoded"] = v
 			}
 		}
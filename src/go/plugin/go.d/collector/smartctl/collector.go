@@ -7,24 +7,35 @@ import (
 	_ "embed"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/netdata/netdata/go/plugins/pkg/confopt"
 	"github.com/netdata/netdata/go/plugins/pkg/matcher"
 	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/diskhealth"
 )
 
 //go:embed "config_schema.json"
 var configSchema string
 
 func init() {
+	// Defect history is shared by all jobs, so that jobs polling the same disks
+	// do not overwrite each other's history file.
+	history := diskhealth.NewHistory(healthHistoryStatePath)
 	collectorapi.Register("smartctl", collectorapi.Creator{
 		JobConfigSchema: configSchema,
 		Defaults: collectorapi.Defaults{
 			UpdateEvery: 10,
 		},
-		Create: func() collectorapi.CollectorV1 { return New() },
-		Config: func() any { return &Config{} },
+		Create: func() collectorapi.CollectorV1 {
+			c := New()
+			c.healthHistory = history
+			return c
+		},
+		Config:          func() any { return &Config{} },
+		SharedFunctions: smartctlMethods,
+		MethodHandler:   smartctlFunctionHandler,
 	})
 }
 
@@ -39,9 +50,12 @@ func New() *Collector {
 			ConcurrentScans:  0, // Default to sequential
 		},
 		charts:          &collectorapi.Charts{},
+		now:             time.Now,
 		forceScan:       true,
 		deviceSr:        matcher.TRUE(),
 		attachedDevices: make(map[string]attachedDevice),
+		healthHistory:   diskhealth.NewHistory(nil),
+		health:          make(map[string]*deviceHealth),
 	}
 }
 
@@ -68,6 +82,9 @@ type Collector struct {
 
 	charts *collectorapi.Charts
 
+	funcRouter *funcRouter
+
+	now  func() time.Time
 	exec smartctlCli
 
 	deviceSr matcher.Matcher
@@ -81,6 +98,10 @@ type Collector struct {
 
 	attachedDevices map[string]attachedDevice
 	mx              map[string]int64
+
+	healthHistory *diskhealth.History
+	healthMu      sync.RWMutex
+	health        map[string]*deviceHealth
 }
 
 type attachedDevice struct {
@@ -110,6 +131,8 @@ func (c *Collector) Init(context.Context) error {
 	}
 	c.exec = smartctlExec
 
+	c.funcRouter = newFuncRouter(c)
+
 	return nil
 }
 
@@ -143,4 +166,9 @@ func (c *Collector) Collect(context.Context) map[string]int64 {
 	return mx
 }
 
-func (c *Collector) Cleanup(context.Context) {}
+func (c *Collector) Cleanup(ctx context.Context) {
+	if c.funcRouter != nil {
+		c.funcRouter.Cleanup(ctx)
+	}
+	c.healthHistory.Save()
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/netdata/netdata/go/plugins/pkg/confopt"
 	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/collecttest"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/diskhealth"
 )
 
 var (
@@ -210,6 +211,11 @@ func wantMetricsTypeSata() map[string]int64 {
 		"device_sda_type_sat_attr_throughput_performance_normalized":  148,
 		"device_sda_type_sat_attr_udma_crc_error_count_decoded":       0,
 		"device_sda_type_sat_attr_udma_crc_error_count_normalized":    100,
+		"device_sda_type_sat_defects_growth":                          0,
+		"device_sda_type_sat_health_risk_high":                        0,
+		"device_sda_type_sat_health_risk_low":                         1,
+		"device_sda_type_sat_health_risk_medium":                      0,
+		"device_sda_type_sat_health_score":                            100,
 		"device_sda_type_sat_power_cycle_count":                       12,
 		"device_sda_type_sat_power_on_time":                           29678400,
 		"device_sda_type_sat_smart_status_failed":                     0,
@@ -240,6 +246,11 @@ func wantMetricsTypeSata() map[string]int64 {
 		"device_sdc_type_sat_attr_total_lbas_written_normalized":      253,
 		"device_sdc_type_sat_attr_udma_crc_error_count_decoded":       0,
 		"device_sdc_type_sat_attr_udma_crc_error_count_normalized":    100,
+		"device_sdc_type_sat_defects_growth":                          0,
+		"device_sdc_type_sat_health_risk_high":                        0,
+		"device_sdc_type_sat_health_risk_low":                         1,
+		"device_sdc_type_sat_health_risk_medium":                      0,
+		"device_sdc_type_sat_health_score":                            100,
 		"device_sdc_type_sat_power_cycle_count":                       13,
 		"device_sdc_type_sat_power_on_time":                           29678400,
 		"device_sdc_type_sat_smart_status_failed":                     0,
@@ -257,7 +268,7 @@ func TestCollector_Collect(t *testing.T) {
 	}{
 		"success type sata devices": {
 			prepareMock: prepareMockOkTypeSata,
-			wantCharts:  68,
+			wantCharts:  74,
 			wantMetrics: wantMetricsTypeSata(),
 		},
 		"success type sata devices concurrent": {
@@ -267,13 +278,18 @@ func TestCollector_Collect(t *testing.T) {
 				cfg.ConcurrentScans = 2
 				return cfg
 			},
-			wantCharts:  68,
+			wantCharts:  74,
 			wantMetrics: wantMetricsTypeSata(),
 		},
 		"success type nvme devices": {
 			prepareMock: prepareMockOkTypeNvme,
-			wantCharts:  4,
+			wantCharts:  7,
 			wantMetrics: map[string]int64{
+				"device_nvme0_type_nvme_defects_growth":      0,
+				"device_nvme0_type_nvme_health_risk_high":    0,
+				"device_nvme0_type_nvme_health_risk_low":     1,
+				"device_nvme0_type_nvme_health_risk_medium":  0,
+				"device_nvme0_type_nvme_health_score":        100,
 				"device_nvme0_type_nvme_power_cycle_count":   2,
 				"device_nvme0_type_nvme_power_on_time":       11206800,
 				"device_nvme0_type_nvme_smart_status_failed": 0,
@@ -290,13 +306,23 @@ func TestCollector_Collect(t *testing.T) {
 				}
 				return cfg
 			},
-			wantCharts: 8,
+			wantCharts: 14,
 			wantMetrics: map[string]int64{
+				"device_nvme0_type_nvme_defects_growth":      0,
+				"device_nvme0_type_nvme_health_risk_high":    0,
+				"device_nvme0_type_nvme_health_risk_low":     1,
+				"device_nvme0_type_nvme_health_risk_medium":  0,
+				"device_nvme0_type_nvme_health_score":        100,
 				"device_nvme0_type_nvme_power_cycle_count":   2,
 				"device_nvme0_type_nvme_power_on_time":       11206800,
 				"device_nvme0_type_nvme_smart_status_failed": 0,
 				"device_nvme0_type_nvme_smart_status_passed": 1,
 				"device_nvme0_type_nvme_temperature":         39,
+				"device_nvme1_type_nvme_defects_growth":      0,
+				"device_nvme1_type_nvme_health_risk_high":    0,
+				"device_nvme1_type_nvme_health_risk_low":     1,
+				"device_nvme1_type_nvme_health_risk_medium":  0,
+				"device_nvme1_type_nvme_health_score":        100,
 				"device_nvme1_type_nvme_power_cycle_count":   5,
 				"device_nvme1_type_nvme_power_on_time":       17038800,
 				"device_nvme1_type_nvme_smart_status_failed": 0,
@@ -306,8 +332,13 @@ func TestCollector_Collect(t *testing.T) {
 		},
 		"success type scsi devices": {
 			prepareMock: prepareMockOkTypeScsi,
-			wantCharts:  7,
+			wantCharts:  10,
 			wantMetrics: map[string]int64{
+				"device_sda_type_scsi_defects_growth":                                 0,
+				"device_sda_type_scsi_health_risk_high":                               0,
+				"device_sda_type_scsi_health_risk_low":                                1,
+				"device_sda_type_scsi_health_risk_medium":                             0,
+				"device_sda_type_scsi_health_score":                                   100,
 				"device_sda_type_scsi_power_cycle_count":                              4,
 				"device_sda_type_scsi_power_on_time":                                  5908920,
 				"device_sda_type_scsi_scsi_error_log_read_total_errors_corrected":     647736,
@@ -323,7 +354,7 @@ func TestCollector_Collect(t *testing.T) {
 		},
 		"success type sata devices non-fatal exit status": {
 			prepareMock: prepareMockOkTypeSataNonFatalExitStatus,
-			wantCharts:  68,
+			wantCharts:  74,
 			wantMetrics: wantMetricsTypeSata(),
 		},
 		"error on scan": {
@@ -377,8 +408,8 @@ func TestCollector_CollectDeviceNameWithWhitespace(t *testing.T) {
 
 	mx := collr.Collect(context.Background())
 
-	require.Len(t, *collr.Charts(), 4)
-	require.Len(t, mx, 5)
+	require.Len(t, *collr.Charts(), 7)
+	require.Len(t, mx, 10)
 	collecttest.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
 
 	for _, chart := range *collr.Charts() {
@@ -409,8 +440,8 @@ func TestCollector_NormalizedDeviceIdentityDoesNotAlias(t *testing.T) {
 		}
 
 		mx := collr.Collect(context.Background())
-		require.Len(t, *collr.Charts(), 8)
-		require.Len(t, mx, 10)
+		require.Len(t, *collr.Charts(), 14)
+		require.Len(t, mx, 20)
 		collecttest.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
 
 		ids := make(map[string]string)
@@ -484,9 +515,9 @@ func TestCollector_AddedDeviceForcesDevicePoll(t *testing.T) {
 	}
 
 	first := collr.Collect(context.Background())
-	require.Len(t, first, 5)
+	require.Len(t, first, 10)
 	second := collr.Collect(context.Background())
-	require.Len(t, second, 10)
+	require.Len(t, second, 20)
 	assert.Equal(t, map[string]int{"/dev/nvme0": 2, "/dev/nvme1": 1}, devicePolls)
 }
 
@@ -518,8 +549,8 @@ func TestCollector_RemovesChartsUsingAttachedResponseIdentity(t *testing.T) {
 	}
 
 	first := collr.Collect(context.Background())
-	require.Len(t, *collr.Charts(), 4)
-	require.Len(t, first, 5)
+	require.Len(t, *collr.Charts(), 7)
+	require.Len(t, first, 10)
 
 	second := collr.Collect(context.Background())
 	require.Empty(t, second)
@@ -690,11 +721,11 @@ func TestCollector_RemovesOnlyChartsOwnedByDisappearedDevice(t *testing.T) {
 	}
 
 	first := collr.Collect(context.Background())
-	require.Len(t, *collr.Charts(), 8)
-	require.Len(t, first, 10)
+	require.Len(t, *collr.Charts(), 14)
+	require.Len(t, first, 20)
 
 	second := collr.Collect(context.Background())
-	require.Len(t, second, 5)
+	require.Len(t, second, 10)
 
 	var removed, active int
 	for _, chart := range *collr.Charts() {
@@ -709,8 +740,8 @@ func TestCollector_RemovesOnlyChartsOwnedByDisappearedDevice(t *testing.T) {
 			t.Errorf("unexpected device label on chart %q", chart.ID)
 		}
 	}
-	assert.Equal(t, 4, removed)
-	assert.Equal(t, 4, active)
+	assert.Equal(t, 7, removed)
+	assert.Equal(t, 7, active)
 }
 
 func TestCollector_ReplacesChangedResponseIdentity(t *testing.T) {
@@ -736,12 +767,12 @@ func TestCollector_ReplacesChangedResponseIdentity(t *testing.T) {
 	}
 
 	first := collr.Collect(context.Background())
-	require.Len(t, first, 5)
-	require.Len(t, *collr.Charts(), 4)
+	require.Len(t, first, 10)
+	require.Len(t, *collr.Charts(), 7)
 
 	second := collr.Collect(context.Background())
-	require.Len(t, second, 5)
-	require.Len(t, *collr.Charts(), 8)
+	require.Len(t, second, 10)
+	require.Len(t, *collr.Charts(), 14)
 
 	var removed, active int
 	for _, chart := range *collr.Charts() {
@@ -758,8 +789,8 @@ func TestCollector_ReplacesChangedResponseIdentity(t *testing.T) {
 			t.Errorf("unexpected device label on chart %q", chart.ID)
 		}
 	}
-	assert.Equal(t, 4, removed)
-	assert.Equal(t, 4, active)
+	assert.Equal(t, 7, removed)
+	assert.Equal(t, 7, active)
 	assert.Equal(t, newDeviceIdentity(secondName, "nvme"), collr.attachedDevices[scanName+"|nvme"].id)
 }
 
@@ -777,8 +808,8 @@ func TestCollector_AttachmentCollisionDoesNotRecordOrphanState(t *testing.T) {
 			}
 
 			mx := collr.Collect(context.Background())
-			require.Len(t, *collr.Charts(), 4)
-			require.Len(t, mx, 5)
+			require.Len(t, *collr.Charts(), 7)
+			require.Len(t, mx, 10)
 			assert.Len(t, collr.attachedDevices, 1)
 			collecttest.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
 		})
@@ -806,7 +837,7 @@ func TestCollector_DisambiguatesConflictingAttributeNames(t *testing.T) {
 
 			mx := collr.Collect(context.Background())
 			require.NotEmpty(t, mx)
-			require.Len(t, *collr.Charts(), 39)
+			require.Len(t, *collr.Charts(), 42)
 			require.Len(t, collr.attachedDevices, 1)
 			collecttest.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
 
@@ -923,6 +954,124 @@ func deviceScanData(t *testing.T, deviceType, protocol string, deviceNames ...st
 	return data
 }
 
+func TestCollector_DiskHealth(t *testing.T) {
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	reallocated := "24"
+
+	collr := New()
+	require.NoError(t, collr.Init(context.Background()))
+	defer collr.Cleanup(context.Background())
+
+	collr.now = func() time.Time { return now }
+	collr.PollDevicesEvery = confopt.Duration(time.Nanosecond)
+	collr.exec = &mockSmartctlCliExec{
+		scanData: deviceScanData(t, "sat", "ATA", "/dev/sda"),
+		deviceDataFunc: func(string, string, string) ([]byte, error) {
+			return setSmartAttributesRaw(t, dataTypeSataDeviceHDDSda, map[string]string{
+				ataAttrReallocatedSectors: reallocated,
+				ataAttrPendingSectors:     "16",
+			}), nil
+		},
+	}
+
+	resp := collr.funcRouter.Handle(context.Background(), diskHealthMethodID, nil)
+	assert.Equal(t, 503, resp.Status)
+
+	mx := collr.Collect(context.Background())
+	collecttest.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+
+	px := "device_sda_type_sat_"
+	assert.Equal(t, int64(45), mx[px+"health_score"])
+	assert.Equal(t, int64(1), mx[px+"health_risk_high"])
+	assert.Equal(t, int64(0), mx[px+"defects_growth"])
+
+	now = now.Add(2 * time.Hour)
+	reallocated = "30"
+	mx = collr.Collect(context.Background())
+
+	assert.Equal(t, int64(15), mx[px+"health_score"])
+	assert.Equal(t, int64(6000), mx[px+"defects_growth"])
+
+	resp = collr.funcRouter.Handle(context.Background(), diskHealthMethodID, nil)
+	require.Equal(t, 200, resp.Status)
+	data, ok := resp.Data.([][]any)
+	require.True(t, ok)
+	require.Len(t, data, 1)
+
+	row := make(map[string]any)
+	for i, col := range diskHealthColumns {
+		row[col.Name] = data[0][i]
+	}
+	assert.Equal(t, "sda (sat)", row["Device"])
+	assert.Equal(t, int64(15), row["Score"])
+	assert.Equal(t, "high", row["Risk"])
+	assert.Equal(t, "16 pending sectors; 6 new defects in 2h (6.00/day); 30 reallocated sectors", row["Risk Factors"])
+	assert.Equal(t, int64(30), row["Reallocated"])
+	assert.Nil(t, row["Media Errors"])
+}
+
+func TestSmartDevice_healthAttributes(t *testing.T) {
+	tests := map[string]struct {
+		data        []byte
+		wantDefects int64
+		wantCheck   func(t *testing.T, a diskhealth.Attributes)
+	}{
+		"sata": {
+			data: dataTypeSataDeviceSSDSdc,
+			wantCheck: func(t *testing.T, a diskhealth.Attributes) {
+				assert.Equal(t, new(int64(0)), a.ReallocatedSectors)
+				assert.Equal(t, new(int64(0)), a.ReportedUncorrect)
+				assert.Equal(t, new(int64(0)), a.CRCErrors)
+				assert.Nil(t, a.PendingSectors)
+				assert.Nil(t, a.MediaErrors)
+			},
+		},
+		"scsi": {
+			data: dataTypeScsiDeviceSda,
+			wantCheck: func(t *testing.T, a diskhealth.Attributes) {
+				assert.Equal(t, new(int64(0)), a.ReallocatedSectors)
+				assert.Equal(t, new(int64(0)), a.ReportedUncorrect)
+				assert.Equal(t, new(true), a.SmartStatusPassed)
+			},
+		},
+		"nvme": {
+			data: dataTypeNvmeDeviceNvme0,
+			wantCheck: func(t *testing.T, a diskhealth.Attributes) {
+				assert.Equal(t, new(int64(0)), a.MediaErrors)
+				assert.Equal(t, new(int64(0)), a.PercentageUsed)
+				assert.Equal(t, new(int64(100)), a.AvailableSpare)
+				assert.Equal(t, new(int64(5)), a.AvailableSpareThreshold)
+				assert.Nil(t, a.ReallocatedSectors)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res := gjson.ParseBytes(test.data)
+			attrs := newSmartDevice(&res).healthAttributes()
+
+			require.True(t, attrs.Known())
+			test.wantCheck(t, attrs)
+		})
+	}
+}
+
+func setSmartAttributesRaw(t *testing.T, data []byte, raw map[string]string) []byte {
+	t.Helper()
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(data, &payload))
+	for _, v := range payload["ata_smart_attributes"].(map[string]any)["table"].([]any) {
+		attr := v.(map[string]any)
+		if s, ok := raw[fmt.Sprint(attr["id"])]; ok {
+			attr["raw"].(map[string]any)["string"] = s
+		}
+	}
+	result, err := json.Marshal(payload)
+	require.NoError(t, err)
+	return result
+}
+
 func replaceFixtureValue(t *testing.T, data []byte, old, new string) []byte {
 	t.Helper()
 	require.Positive(t, bytes.Count(data, []byte(old)), "fixture does not contain %q", old)
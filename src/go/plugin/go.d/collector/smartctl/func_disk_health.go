@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package smartctl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/pkg/funcapi"
+)
+
+const diskHealthMethodID = "disk-health"
+
+const diskHealthHelp = "Disks ranked by failure risk. The health score (0-100) combines failure-predictive SMART attributes " +
+	"(reallocated, pending and uncorrectable sectors, CRC errors, NVMe media errors, endurance used, available spare) " +
+	"with the growth of defects over the last 7 days."
+
+func diskHealthFunctionConfig() funcapi.FunctionConfig {
+	return funcapi.FunctionConfig{
+		ID:          diskHealthMethodID,
+		Name:        "Disk Health",
+		UpdateEvery: 10,
+		Help:        diskHealthHelp,
+	}
+}
+
+// Compile-time interface check.
+var _ funcapi.MethodHandler = (*funcDiskHealth)(nil)
+
+// funcDiskHealth handles the "disk-health" function.
+type funcDiskHealth struct {
+	router *funcRouter
+}
+
+func newFuncDiskHealth(r *funcRouter) *funcDiskHealth {
+	return &funcDiskHealth{router: r}
+}
+
+// MethodParams implements funcapi.MethodHandler.
+func (f *funcDiskHealth) MethodParams(_ context.Context, method string) ([]funcapi.ParamConfig, error) {
+	if method != diskHealthMethodID {
+		return nil, fmt.Errorf("unknown method: %s", method)
+	}
+	return nil, nil
+}
+
+// Handle implements funcapi.MethodHandler.
+func (f *funcDiskHealth) Handle(_ context.Context, method string, _ funcapi.ResolvedParams) *funcapi.FunctionResponse {
+	if method != diskHealthMethodID {
+		return funcapi.NotFoundResponse(method)
+	}
+
+	c := f.router.collector
+
+	c.healthMu.RLock()
+	disks := make([]deviceHealth, 0, len(c.health))
+	for _, h := range c.health {
+		disks = append(disks, *h)
+	}
+	c.healthMu.RUnlock()
+
+	if len(disks) == 0 {
+		return funcapi.UnavailableResponse("no disk health data has been collected yet, please retry later")
+	}
+
+	cs := diskHealthColumnSet(diskHealthColumns)
+	data := make([][]any, 0, len(disks))
+	for _, d := range disks {
+		row := make([]any, len(diskHealthColumns))
+		for i, col := range diskHealthColumns {
+			row[i] = col.Value(d)
+		}
+		data = append(data, row)
+	}
+
+	return &funcapi.FunctionResponse{
+		Status:            200,
+		Help:              diskHealthHelp,
+		Columns:           cs.BuildColumns(),
+		Data:              data,
+		DefaultSortColumn: "Score",
+	}
+}
+
+// Cleanup implements funcapi.MethodHandler.
+func (f *funcDiskHealth) Cleanup(context.Context) {}
+
+type diskHealthColumn struct {
+	funcapi.ColumnMeta
+	Value func(deviceHealth) any
+}
+
+func diskHealthColumnSet(cols []diskHealthColumn) funcapi.ColumnSet[diskHealthColumn] {
+	return funcapi.Columns(cols, func(c diskHealthColumn) funcapi.ColumnMeta { return c.ColumnMeta })
+}
+
+var diskHealthColumns = []diskHealthColumn{
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Device", Tooltip: "Device", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true, Sticky: true, UniqueKey: true}, Value: func(d deviceHealth) any { return d.name + " (" + d.typ + ")" }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Model", Tooltip: "Model", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true}, Value: func(d deviceHealth) any { return d.model }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Serial", Tooltip: "Serial number", Type: funcapi.FieldTypeString, Visible: false, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true}, Value: func(d deviceHealth) any { return d.serial }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Score", Tooltip: "Health score, 100 means no known risk factors", Type: funcapi.FieldTypeInteger, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryMin, Filter: funcapi.FieldFilterRange, Sortable: true}, Value: func(d deviceHealth) any { return d.assessment.Score }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Risk", Tooltip: "Failure risk", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true, Visualization: funcapi.FieldVisualPill}, Value: func(d deviceHealth) any { return string(d.assessment.Risk) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Risk Factors", Tooltip: "Risk factors, most severe first", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterNone, Sortable: false, FullWidth: true, Wrap: true}, Value: func(d deviceHealth) any { return strings.Join(d.assessment.Reasons, "; ") }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Reallocated", Tooltip: "Reallocated sectors (SCSI: grown defect list)", Type: funcapi.FieldTypeInteger, Visible: true, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummarySum, Filter: funcapi.FieldFilterRange, Sortable: true}, Value: func(d deviceHealth) any { return intCell(d.attrs.ReallocatedSectors) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Pending", Tooltip: "Current pending sectors", Type: funcapi.FieldTypeInteger, Visible: true, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummarySum, Filter: funcapi.FieldFilterRange, Sortable: true}, Value: func(d deviceHealth) any { return intCell(d.attrs.PendingSectors) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Offline Uncorrectable", Tooltip: "Offline uncorrectable sectors", Type: funcapi.FieldTypeInteger, Visible: false, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummarySum, Filter: funcapi.FieldFilterRange, Sortable: true}, Value: func(d deviceHealth) any { return intCell(d.attrs.OfflineUncorrectable) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Reported Uncorrectable", Tooltip: "Uncorrectable errors reported to the host", Type: funcapi.FieldTypeInteger, Visible: false, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummarySum, Filter: funcapi.FieldFilterRange, Sortable: true}, Value: func(d deviceHealth) any { return intCell(d.attrs.ReportedUncorrect) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "CRC Errors", Tooltip: "Interface CRC errors", Type: funcapi.FieldTypeInteger, Visible: false, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummarySum, Filter: funcapi.FieldFilterRange, Sortable: true}, Value: func(d deviceHealth) any { return intCell(d.attrs.CRCErrors) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Media Errors", Tooltip: "NVMe media and data integrity errors", Type: funcapi.FieldTypeInteger, Visible: true, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummarySum, Filter: funcapi.FieldFilterRange, Sortable: true}, Value: func(d deviceHealth) any { return intCell(d.attrs.MediaErrors) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Endurance Used", Tooltip: "NVMe percentage of rated endurance used", Type: funcapi.FieldTypeInteger, Units: "%", Visible: true, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummaryMax, Filter: funcapi.FieldFilterRange, Sortable: true}, Value: func(d deviceHealth) any { return intCell(d.attrs.PercentageUsed) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Available Spare", Tooltip: "NVMe available spare capacity", Type: funcapi.FieldTypeInteger, Units: "%", Visible: false, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryMin, Filter: funcapi.FieldFilterRange, Sortable: true}, Value: func(d deviceHealth) any { return intCell(d.attrs.AvailableSpare) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "New Defects", Tooltip: "Defects (reallocated and pending sectors, media errors) added within the last 7 days", Type: funcapi.FieldTypeInteger, Visible: true, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummarySum, Filter: funcapi.FieldFilterRange, Sortable: true}, Value: func(d deviceHealth) any { return d.trend.NewDefects }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Defects Growth", Tooltip: "Average number of new defects per day within the last 7 days", Type: funcapi.FieldTypeFloat, Units: "defects/day", DecimalPoints: 2, Visible: true, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummaryMax, Filter: funcapi.FieldFilterRange, Sortable: true, Transform: funcapi.FieldTransformNumber}, Value: func(d deviceHealth) any { return d.trend.GrowthPerDay }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Updated", Tooltip: "When the disk was last polled", Type: funcapi.FieldTypeTimestamp, Visible: false, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummaryMax, Filter: funcapi.FieldFilterRange, Sortable: true, Transform: funcapi.FieldTransformDatetime}, Value: func(d deviceHealth) any { return d.updated.UnixMilli() }},
+}
+
+func intCell(v *int64) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
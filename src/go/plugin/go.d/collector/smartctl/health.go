@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package smartctl
+
+import (
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/framework/filepersister"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/diskhealth"
+)
+
+// ATA SMART attributes used for failure prediction.
+const (
+	ataAttrReallocatedSectors   = "5"
+	ataAttrReportedUncorrect    = "187"
+	ataAttrPendingSectors       = "197"
+	ataAttrOfflineUncorrectable = "198"
+	ataAttrCRCErrors            = "199"
+)
+
+type deviceHealth struct {
+	name       string
+	typ        string
+	model      string
+	serial     string
+	attrs      diskhealth.Attributes
+	trend      diskhealth.Trend
+	assessment diskhealth.Assessment
+	updated    time.Time
+}
+
+func healthHistoryStatePath() string {
+	return filepersister.StatePath("god-smartctl-disk-health.json")
+}
+
+func (c *Collector) collectDeviceHealth(mx map[string]int64, key string, dev *smartDevice, id deviceIdentity) {
+	attrs := dev.healthAttributes()
+	if !attrs.Known() {
+		return
+	}
+
+	now := c.now()
+	var trend diskhealth.Trend
+	if defects, ok := attrs.Defects(); ok {
+		trend = c.healthHistory.Observe(dev.healthHistoryKey(), now, defects)
+		mx[id.prefix+"defects_growth"] = int64(trend.GrowthPerDay * 1000)
+	}
+	a := diskhealth.Assess(attrs, trend)
+
+	mx[id.prefix+"health_score"] = a.Score
+	for _, r := range diskhealth.Risks {
+		mx[id.prefix+"health_risk_"+string(r)] = 0
+	}
+	mx[id.prefix+"health_risk_"+string(a.Risk)] = 1
+
+	c.healthMu.Lock()
+	c.health[key] = &deviceHealth{
+		name:       dev.deviceName(),
+		typ:        dev.deviceType(),
+		model:      dev.modelName(),
+		serial:     dev.serialNumber(),
+		attrs:      attrs,
+		trend:      trend,
+		assessment: a,
+		updated:    now,
+	}
+	c.healthMu.Unlock()
+}
+
+func (c *Collector) removeDeviceHealth(key string) {
+	c.healthMu.Lock()
+	delete(c.health, key)
+	c.healthMu.Unlock()
+}
+
+// healthHistoryKey identifies the disk in the persisted defect history. The serial number
+// follows the disk across device renames (/dev/sda becoming /dev/sdb after a reboot).
+func (d *smartDevice) healthHistoryKey() string {
+	if sn := d.serialNumber(); sn != "" {
+		return d.modelName() + "/" + sn
+	}
+	return d.deviceName() + "/" + d.deviceType()
+}
+
+func (d *smartDevice) healthAttributes() diskhealth.Attributes {
+	var attrs diskhealth.Attributes
+
+	if v, ok := d.smartStatusPassed(); ok {
+		attrs.SmartStatusPassed = &v
+	}
+
+	if table, ok := d.ataSmartAttributeTable(); ok {
+		for _, attr := range table {
+			v, ok := attr.decodedRaw()
+			if !ok {
+				continue
+			}
+			switch attr.id() {
+			case ataAttrReallocatedSectors:
+				attrs.ReallocatedSectors = &v
+			case ataAttrReportedUncorrect:
+				attrs.ReportedUncorrect = &v
+			case ataAttrPendingSectors:
+				attrs.PendingSectors = &v
+			case ataAttrOfflineUncorrectable:
+				attrs.OfflineUncorrectable = &v
+			case ataAttrCRCErrors:
+				attrs.CRCErrors = &v
+			}
+		}
+	}
+
+	if v := d.data.Get("scsi_grown_defect_list"); v.Exists() {
+		attrs.ReallocatedSectors = new(v.Int())
+	}
+	if log := d.data.Get("scsi_error_counter_log"); log.Exists() {
+		var n int64
+		for _, op := range []string{"read", "write", "verify"} {
+			n += log.Get(op + ".total_uncorrected_errors").Int()
+		}
+		attrs.ReportedUncorrect = &n
+	}
+
+	if log := d.data.Get("nvme_smart_health_information_log"); log.Exists() {
+		attrs.CriticalWarning = log.Get("critical_warning").Int()
+		for path, dst := range map[string]**int64{
+			"media_errors":              &attrs.MediaErrors,
+			"percentage_used":           &attrs.PercentageUsed,
+			"available_spare":           &attrs.AvailableSpare,
+			"available_spare_threshold": &attrs.AvailableSpareThreshold,
+		} {
+			if v := log.Get(path); v.Exists() {
+				*dst = new(v.Int())
+			}
+		}
+	}
+
+	return attrs
+}
@@ -3,6 +3,7 @@
 package smartctl
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/tidwall/gjson"
@@ -113,3 +114,14 @@ func (a *smartAttribute) value() string {
 func (a *smartAttribute) rawString() string {
 	return a.data.Get("raw.string").String()
 }
+
+// decodedRaw returns the first number of the raw value as decoded by smartctl
+// (e.g. "37 (Min/Max 21/45)" => 37).
+func (a *smartAttribute) decodedRaw() (int64, bool) {
+	rs := strings.TrimSpace(a.rawString())
+	if i := strings.IndexByte(rs, ' '); i != -1 {
+		rs = rs[:i]
+	}
+	v, err := strconv.ParseInt(rs, 10, 64)
+	return v, err == nil
+}
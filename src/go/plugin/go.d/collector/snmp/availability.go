@@ -0,0 +1,353 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/framework/filepersister"
+)
+
+// Availability history is kept in hourly buckets for the longest reporting
+// window and persisted under the Netdata lib dir, so SLA figures survive agent
+// restarts. Time the collector was not running is neither up nor down: it is
+// simply not observed.
+
+const (
+	availabilityStateFileName = "god-snmp-availability.json"
+	availabilityBucketSize    = time.Hour
+	availabilityRetention     = 30 * 24 * time.Hour
+	availabilitySaveInterval  = 5 * time.Minute
+	// A poll gap longer than this many update intervals means the job (or the
+	// agent) was not running; only one interval of it is accounted.
+	availabilityMaxGapIntervals = 3
+	// Boot time estimates jitter by the poll latency and uptime rounding; a
+	// boot time moving forward by less than this is not a reboot.
+	availabilityBootTolerance = 60 * time.Second
+	// sysUpTime is TimeTicks (hundredths of a second) and wraps after ~497 days.
+	sysUpTimeWrapSeconds = (1<<32 - 1) / 100
+)
+
+type availabilityWindow struct {
+	name     string
+	duration time.Duration
+}
+
+var availabilityWindows = []availabilityWindow{
+	{name: "1d", duration: 24 * time.Hour},
+	{name: "7d", duration: 7 * 24 * time.Hour},
+	{name: "30d", duration: availabilityRetention},
+}
+
+func availabilityStatePath() string {
+	return filepersister.StatePath(availabilityStateFileName)
+}
+
+type (
+	// availabilityRegistry holds the availability history of every device
+	// polled by the SNMP jobs of one plugin process.
+	availabilityRegistry struct {
+		mu      sync.Mutex
+		state   filepersister.State
+		devices map[string]*deviceAvailability
+	}
+	deviceAvailability struct {
+		Hostname string `json:"hostname"`
+		Port     int    `json:"port"`
+		SysName  string `json:"sys_name,omitempty"`
+		Vendor   string `json:"vendor,omitempty"`
+		Model    string `json:"model,omitempty"`
+
+		Up         bool  `json:"up"`
+		StateSince int64 `json:"state_since"`
+		LastPoll   int64 `json:"last_poll"`
+		LastUptime int64 `json:"last_uptime,omitempty"`
+		BootTime   int64 `json:"boot_time,omitempty"`
+
+		Buckets []availabilityBucket `json:"buckets"`
+		Reboots []int64              `json:"reboots,omitempty"`
+		Outages []int64              `json:"outages,omitempty"`
+
+		active bool
+	}
+	availabilityBucket struct {
+		Start    int64 `json:"start"`
+		Up       int64 `json:"up"`
+		Observed int64 `json:"observed"`
+	}
+	availabilityDeviceInfo struct {
+		Hostname string
+		Port     int
+		SysName  string
+		Vendor   string
+		Model    string
+	}
+	availabilityObservation struct {
+		now       time.Time
+		interval  time.Duration
+		snmpUp    bool
+		pingUp    bool
+		uptime    int64 // seconds
+		hasUptime bool
+	}
+	availabilityReport struct {
+		availabilityDeviceInfo
+		Up         bool
+		Monitored  bool
+		StateSince int64
+		LastPoll   int64
+		BootTime   int64
+		Uptime     int64
+		Rebooted   bool
+		Windows    []availabilityWindowStats
+	}
+	availabilityWindowStats struct {
+		Up       int64
+		Observed int64
+		Reboots  int
+		Outages  int
+	}
+)
+
+func newAvailabilityRegistry(statePath func() string) *availabilityRegistry {
+	return &availabilityRegistry{
+		state:   filepersister.State{Path: statePath, SaveInterval: availabilitySaveInterval},
+		devices: make(map[string]*deviceAvailability),
+	}
+}
+
+// observe accounts one poll of a device and returns its updated report.
+func (r *availabilityRegistry) observe(key string, info availabilityDeviceInfo, obs availabilityObservation) availabilityReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ensureLoadedLocked(obs.now)
+
+	dev, ok := r.devices[key]
+	if !ok {
+		dev = &deviceAvailability{}
+		r.devices[key] = dev
+	}
+	dev.Hostname, dev.Port = info.Hostname, info.Port
+	if info.SysName != "" {
+		dev.SysName = info.SysName
+	}
+	if info.Vendor != "" {
+		dev.Vendor = info.Vendor
+	}
+	if info.Model != "" {
+		dev.Model = info.Model
+	}
+	dev.active = true
+
+	rebooted := dev.observe(obs)
+	dev.prune(obs.now)
+
+	if r.state.SaveDue(obs.now) {
+		r.saveLocked(obs.now)
+	}
+
+	report := dev.report(obs.now)
+	report.Rebooted = rebooted
+	return report
+}
+
+// release marks the device as no longer polled and persists its history.
+func (r *availabilityRegistry) release(key string, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if dev, ok := r.devices[key]; ok {
+		dev.active = false
+		r.saveLocked(now)
+	}
+}
+
+func (r *availabilityRegistry) reports(now time.Time) []availabilityReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ensureLoadedLocked(now)
+
+	reports := make([]availabilityReport, 0, len(r.devices))
+	for _, dev := range r.devices {
+		dev.prune(now)
+		if len(dev.Buckets) == 0 {
+			continue
+		}
+		reports = append(reports, dev.report(now))
+	}
+	slices.SortFunc(reports, func(a, b availabilityReport) int {
+		if c := strings.Compare(a.Hostname, b.Hostname); c != 0 {
+			return c
+		}
+		return a.Port - b.Port
+	})
+	return reports
+}
+
+func (d *deviceAvailability) observe(obs availabilityObservation) (rebooted bool) {
+	now := obs.now.Unix()
+	interval := max(int64(obs.interval/time.Second), 1)
+
+	elapsed := interval
+	gap := int64(0)
+	if d.LastPoll > 0 {
+		gap = now - d.LastPoll
+		if gap > 0 && gap <= interval*availabilityMaxGapIntervals {
+			elapsed = gap
+		}
+	}
+
+	reachable := obs.snmpUp || obs.pingUp
+	booted := false
+
+	if obs.hasUptime {
+		bootTime := now - obs.uptime
+		wrapped := uptimeWrapped(d.LastUptime, obs.uptime, gap)
+		if d.BootTime > 0 && bootTime-d.BootTime > int64(availabilityBootTolerance/time.Second) && !wrapped {
+			d.Reboots = append(d.Reboots, bootTime)
+			rebooted = true
+		}
+		booted = !wrapped && obs.uptime < elapsed
+		d.BootTime = bootTime
+		d.LastUptime = obs.uptime
+	}
+
+	var up int64
+	if reachable {
+		up = elapsed
+		if booted {
+			// The device booted during this interval and was down before that.
+			up = obs.uptime
+		}
+	}
+
+	switch {
+	case d.LastPoll == 0:
+		d.Up, d.StateSince = reachable, now
+	case d.Up && !reachable:
+		d.Outages = append(d.Outages, now)
+		d.Up, d.StateSince = false, now
+	case !d.Up && reachable:
+		d.Up, d.StateSince = true, now
+	}
+	d.LastPoll = now
+
+	d.addToBucket(now, up, elapsed)
+	return rebooted
+}
+
+func uptimeWrapped(prev, cur, gap int64) bool {
+	tolerance := int64(availabilityBootTolerance / time.Second)
+	return prev+gap >= sysUpTimeWrapSeconds-tolerance && cur <= gap+tolerance
+}
+
+func (d *deviceAvailability) addToBucket(now, up, observed int64) {
+	start := time.Unix(now, 0).Truncate(availabilityBucketSize).Unix()
+	if n := len(d.Buckets); n > 0 && d.Buckets[n-1].Start == start {
+		d.Buckets[n-1].Up += up
+		d.Buckets[n-1].Observed += observed
+		return
+	}
+	d.Buckets = append(d.Buckets, availabilityBucket{Start: start, Up: up, Observed: observed})
+}
+
+func (d *deviceAvailability) prune(now time.Time) {
+	cutoff := now.Add(-availabilityRetention).Unix()
+	bucketCutoff := now.Add(-availabilityRetention - availabilityBucketSize).Unix()
+
+	d.Buckets = slices.DeleteFunc(d.Buckets, func(b availabilityBucket) bool { return b.Start < bucketCutoff })
+	d.Reboots = slices.DeleteFunc(d.Reboots, func(ts int64) bool { return ts < cutoff })
+	d.Outages = slices.DeleteFunc(d.Outages, func(ts int64) bool { return ts < cutoff })
+}
+
+func (d *deviceAvailability) report(now time.Time) availabilityReport {
+	rep := availabilityReport{
+		availabilityDeviceInfo: availabilityDeviceInfo{
+			Hostname: d.Hostname,
+			Port:     d.Port,
+			SysName:  d.SysName,
+			Vendor:   d.Vendor,
+			Model:    d.Model,
+		},
+		Up:         d.Up,
+		Monitored:  d.active,
+		StateSince: d.StateSince,
+		LastPoll:   d.LastPoll,
+		BootTime:   d.BootTime,
+		Windows:    make([]availabilityWindowStats, len(availabilityWindows)),
+	}
+	if d.BootTime > 0 && d.LastUptime > 0 {
+		rep.Uptime = d.LastUptime
+	}
+
+	for i, w := range availabilityWindows {
+		// Buckets are hourly, so windows are aligned to whole hours.
+		from := now.Add(-w.duration).Truncate(availabilityBucketSize).Unix()
+		stats := &rep.Windows[i]
+		for _, b := range d.Buckets {
+			if b.Start >= from {
+				stats.Up += b.Up
+				stats.Observed += b.Observed
+			}
+		}
+		stats.Reboots = countSince(d.Reboots, now.Add(-w.duration).Unix())
+		stats.Outages = countSince(d.Outages, now.Add(-w.duration).Unix())
+	}
+	return rep
+}
+
+func countSince(timestamps []int64, from int64) int {
+	var n int
+	for _, ts := range timestamps {
+		if ts >= from {
+			n++
+		}
+	}
+	return n
+}
+
+// percentage returns availability in thousandths of a percent.
+func (s availabilityWindowStats) percentage() (int64, bool) {
+	if s.Observed <= 0 {
+		return 0, false
+	}
+	return s.Up * 100_000 / s.Observed, true
+}
+
+func (r *availabilityRegistry) ensureLoadedLocked(now time.Time) {
+	var state availabilityState
+	if !r.state.Load(&state) {
+		return
+	}
+	for key, dev := range state.Devices {
+		if dev == nil {
+			continue
+		}
+		if _, ok := r.devices[key]; ok {
+			continue
+		}
+		dev.prune(now)
+		if len(dev.Buckets) == 0 {
+			continue
+		}
+		r.devices[key] = dev
+	}
+}
+
+func (r *availabilityRegistry) saveLocked(now time.Time) {
+	r.state.Save(now, availabilityState{Devices: r.devices})
+}
+
+type availabilityState struct {
+	Devices map[string]*deviceAvailability `json:"devices"`
+}
+
+func (s availabilityState) Bytes() ([]byte, error) {
+	return json.Marshal(s)
+}
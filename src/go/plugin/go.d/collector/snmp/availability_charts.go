@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp
+
+import "github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+
+const (
+	prioAvailability = prioLicenseState + 1 + iota
+	prioAvailabilityReachability
+	prioAvailabilityUptime
+	prioAvailabilityReboots
+)
+
+const (
+	metricIDAvailabilityPrefix      = "snmp_device_availability_"
+	metricIDReachabilitySNMP        = "snmp_device_reachability_snmp"
+	metricIDReachabilityPing        = "snmp_device_reachability_ping"
+	metricIDUptime                  = "snmp_device_uptime"
+	metricIDRebootsPrefix           = "snmp_device_reboots_"
+	availabilityPercentageDivisor   = 1000
+	availabilityComponentLabelValue = "availability"
+)
+
+var (
+	availabilityChart = collectorapi.Chart{
+		ID:       "snmp_device_availability",
+		Title:    "Device availability",
+		Units:    "percentage",
+		Fam:      "Availability/SLA",
+		Ctx:      "snmp.device_availability",
+		Priority: prioAvailability,
+		SkipGaps: true,
+		Dims: collectorapi.Dims{
+			{ID: metricIDAvailabilityPrefix + "1d", Name: "1d", Div: availabilityPercentageDivisor},
+			{ID: metricIDAvailabilityPrefix + "7d", Name: "7d", Div: availabilityPercentageDivisor},
+			{ID: metricIDAvailabilityPrefix + "30d", Name: "30d", Div: availabilityPercentageDivisor},
+		},
+	}
+	availabilityReachabilityChart = collectorapi.Chart{
+		ID:       "snmp_device_reachability",
+		Title:    "Device reachability",
+		Units:    "status",
+		Fam:      "Availability/Reachability",
+		Ctx:      "snmp.device_reachability",
+		Priority: prioAvailabilityReachability,
+	}
+	availabilityUptimeChart = collectorapi.Chart{
+		ID:       "snmp_device_uptime",
+		Title:    "Device uptime",
+		Units:    "seconds",
+		Fam:      "Availability/Uptime",
+		Ctx:      "snmp.device_uptime",
+		Priority: prioAvailabilityUptime,
+		Dims: collectorapi.Dims{
+			{ID: metricIDUptime, Name: "uptime"},
+		},
+	}
+	availabilityRebootsChart = collectorapi.Chart{
+		ID:       "snmp_device_reboots",
+		Title:    "Device reboots",
+		Units:    "reboots",
+		Fam:      "Availability/Reboots",
+		Ctx:      "snmp.device_reboots",
+		Priority: prioAvailabilityReboots,
+		Dims: collectorapi.Dims{
+			{ID: metricIDRebootsPrefix + "1d", Name: "1d"},
+			{ID: metricIDRebootsPrefix + "7d", Name: "7d"},
+			{ID: metricIDRebootsPrefix + "30d", Name: "30d"},
+		},
+	}
+)
+
+func (c *Collector) addAvailabilityCharts() {
+	charts := collectorapi.Charts{availabilityChart.Copy()}
+
+	reachability := availabilityReachabilityChart.Copy()
+	if !c.PingOnly {
+		reachability.Dims = append(reachability.Dims, &collectorapi.Dim{ID: metricIDReachabilitySNMP, Name: "snmp"})
+		charts = append(charts, availabilityUptimeChart.Copy(), availabilityRebootsChart.Copy())
+	}
+	if c.PingOnly || c.Ping.Enabled {
+		reachability.Dims = append(reachability.Dims, &collectorapi.Dim{ID: metricIDReachabilityPing, Name: "ping"})
+	}
+	charts = append(charts, reachability)
+
+	labels := c.chartBaseLabels()
+	labels["component"] = availabilityComponentLabelValue
+
+	for _, chart := range charts {
+		for k, v := range labels {
+			chart.Labels = append(chart.Labels, collectorapi.Label{Key: k, Value: v})
+		}
+	}
+
+	if err := c.Charts().Add(charts...); err != nil {
+		c.Warningf("failed to add availability charts: %v", err)
+	}
+}
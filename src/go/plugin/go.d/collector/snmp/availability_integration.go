@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp
+
+import (
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/pkg/funcapi"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/snmputils"
+)
+
+func snmpAgentMethods() []funcapi.FunctionConfig {
+	return []funcapi.FunctionConfig{availabilityFunctionConfig()}
+}
+
+// newMethodHandler routes agent Functions, dispatched without a job, to the
+// process-wide availability registry and everything else to the job router.
+func newMethodHandler(registry *availabilityRegistry) func(collectorapi.RuntimeJob) funcapi.MethodHandler {
+	return func(job collectorapi.RuntimeJob) funcapi.MethodHandler {
+		if job == nil {
+			return newFuncAvailability(registry)
+		}
+		return snmpFunctionHandler(job)
+	}
+}
+
+func (c *Collector) availabilityKey() string {
+	return net.JoinHostPort(c.Hostname, strconv.Itoa(c.Options.Port))
+}
+
+func (c *Collector) availabilityDeviceInfo() availabilityDeviceInfo {
+	info := availabilityDeviceInfo{
+		Hostname: c.Hostname,
+		Port:     c.Options.Port,
+	}
+	if si := c.sysInfo; si != nil {
+		info.SysName = si.Name
+		info.Vendor = firstVendor(si.Vendor, si.Organization)
+		info.Model = si.Model
+	}
+	return info
+}
+
+// pollSysUptime doubles as the SNMP reachability probe: a device that answers
+// the uptime GET is reachable over SNMP even when it has no matching profile.
+func (c *Collector) pollSysUptime(obs *availabilityObservation) {
+	v, err := snmputils.GetSysUptime(c.snmpClient)
+	if err != nil {
+		c.Debugf("availability: sysUpTime: %v", err)
+		return
+	}
+	obs.snmpUp = true
+	obs.uptime, obs.hasUptime = v, v > 0
+}
+
+func (c *Collector) collectAvailability(mx map[string]int64, obs availabilityObservation) {
+	if c.availability == nil || !c.Availability.Enabled {
+		return
+	}
+
+	obs.now = time.Now()
+	obs.interval = time.Duration(max(c.UpdateEvery, 1)) * time.Second
+
+	rep := c.availability.observe(c.availabilityKey(), c.availabilityDeviceInfo(), obs)
+	if rep.Rebooted {
+		c.Infof("device %s rebooted at %s", c.Hostname, time.Unix(rep.BootTime, 0).UTC().Format(time.RFC3339))
+	}
+	if !obs.snmpUp && !obs.pingUp {
+		// Like every other metric, availability has a gap while the device is
+		// unreachable, so vnode staleness keeps working; the downtime is still
+		// accounted and shows up in the percentages once the device is back.
+		return
+	}
+
+	for i, w := range availabilityWindows {
+		if pct, ok := rep.Windows[i].percentage(); ok {
+			mx[metricIDAvailabilityPrefix+w.name] = pct
+		}
+		if !c.PingOnly {
+			mx[metricIDRebootsPrefix+w.name] = int64(rep.Windows[i].Reboots)
+		}
+	}
+	if !c.PingOnly {
+		mx[metricIDReachabilitySNMP] = boolToInt(obs.snmpUp)
+		if obs.hasUptime {
+			mx[metricIDUptime] = obs.uptime
+		}
+	}
+	if c.PingOnly || c.Ping.Enabled {
+		mx[metricIDReachabilityPing] = boolToInt(obs.pingUp)
+	}
+}
+
+func (c *Collector) releaseAvailability() {
+	if c.availability == nil || !c.Availability.Enabled {
+		return
+	}
+	c.availability.release(c.availabilityKey(), time.Now())
+}
+
+func boolToInt(v bool) int64 {
+	if v {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,238 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gosnmp/gosnmp"
+	snmpmock "github.com/gosnmp/gosnmp/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netdata/netdata/go/plugins/logger"
+	"github.com/netdata/netdata/go/plugins/pkg/funcapi"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp/ddsnmp"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp/ddsnmp/ddsnmpcollector"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/pinger"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/snmputils"
+)
+
+var testAvailabilityDevice = availabilityDeviceInfo{Hostname: "192.0.2.1", Port: 161, SysName: "core-sw1"}
+
+func TestAvailabilityRegistry_AccountsUpAndDownTime(t *testing.T) {
+	r := newAvailabilityRegistry(nil)
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	interval := 10 * time.Second
+
+	var rep availabilityReport
+	for i := range 10 {
+		rep = r.observe("dev", testAvailabilityDevice, availabilityObservation{
+			now:      start.Add(time.Duration(i) * interval),
+			interval: interval,
+			snmpUp:   i < 6,
+		})
+	}
+
+	assert.False(t, rep.Up)
+	assert.True(t, rep.Monitored)
+	assert.Equal(t, start.Add(6*interval).Unix(), rep.StateSince)
+	assert.Equal(t, availabilityWindowStats{Up: 60, Observed: 100, Outages: 1}, rep.Windows[0])
+	pct, ok := rep.Windows[0].percentage()
+	require.True(t, ok)
+	assert.Equal(t, int64(60_000), pct)
+}
+
+func TestAvailabilityRegistry_PingKeepsDeviceUpWhenSNMPFails(t *testing.T) {
+	r := newAvailabilityRegistry(nil)
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	rep := r.observe("dev", testAvailabilityDevice, availabilityObservation{now: now, interval: time.Second, pingUp: true})
+
+	assert.True(t, rep.Up)
+	assert.Equal(t, int64(1), rep.Windows[0].Up)
+}
+
+func TestAvailabilityRegistry_DoesNotAccountCollectorGaps(t *testing.T) {
+	r := newAvailabilityRegistry(nil)
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	interval := 10 * time.Second
+
+	r.observe("dev", testAvailabilityDevice, availabilityObservation{now: start, interval: interval, snmpUp: true})
+	rep := r.observe("dev", testAvailabilityDevice, availabilityObservation{now: start.Add(2 * time.Hour), interval: interval})
+
+	assert.Equal(t, int64(20), rep.Windows[0].Observed)
+	assert.Equal(t, int64(10), rep.Windows[0].Up)
+}
+
+func TestAvailabilityRegistry_DetectsReboots(t *testing.T) {
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	interval := 60 * time.Second
+
+	tests := map[string]struct {
+		uptimes      []int64
+		wantReboots  int
+		wantUp       int64
+		wantObserved int64
+	}{
+		"steady uptime": {
+			uptimes:      []int64{1000, 1060, 1120},
+			wantReboots:  0,
+			wantUp:       180,
+			wantObserved: 180,
+		},
+		"reboot between polls counts boot time as down": {
+			uptimes:      []int64{1000, 1060, 20},
+			wantReboots:  1,
+			wantUp:       140,
+			wantObserved: 180,
+		},
+		"sysUpTime wrap is not a reboot": {
+			uptimes:      []int64{sysUpTimeWrapSeconds - 70, sysUpTimeWrapSeconds - 10, 50},
+			wantReboots:  0,
+			wantUp:       180,
+			wantObserved: 180,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := newAvailabilityRegistry(nil)
+			var rep availabilityReport
+			var rebooted bool
+			for i, uptime := range test.uptimes {
+				rep = r.observe("dev", testAvailabilityDevice, availabilityObservation{
+					now:       start.Add(time.Duration(i) * interval),
+					interval:  interval,
+					snmpUp:    true,
+					uptime:    uptime,
+					hasUptime: true,
+				})
+				rebooted = rebooted || rep.Rebooted
+			}
+
+			assert.Equal(t, test.wantReboots, rep.Windows[2].Reboots)
+			assert.Equal(t, test.wantReboots > 0, rebooted)
+			assert.Equal(t, test.wantUp, rep.Windows[0].Up)
+			assert.Equal(t, test.wantObserved, rep.Windows[0].Observed)
+		})
+	}
+}
+
+func TestAvailabilityRegistry_WindowsAndRetention(t *testing.T) {
+	r := newAvailabilityRegistry(nil)
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+
+	r.devices["dev"] = &deviceAvailability{
+		Hostname: "192.0.2.1",
+		Port:     161,
+		LastPoll: now.Unix(),
+		Up:       true,
+		Buckets: []availabilityBucket{
+			{Start: now.Add(-40 * 24 * time.Hour).Unix(), Up: 0, Observed: 3600},
+			{Start: now.Add(-10 * 24 * time.Hour).Unix(), Up: 1800, Observed: 3600},
+			{Start: now.Add(-3 * 24 * time.Hour).Unix(), Up: 3000, Observed: 3600},
+			{Start: now.Add(-time.Hour).Unix(), Up: 3600, Observed: 3600},
+		},
+		Reboots: []int64{now.Add(-35 * 24 * time.Hour).Unix(), now.Add(-2 * time.Hour).Unix()},
+	}
+
+	reports := r.reports(now)
+	require.Len(t, reports, 1)
+	assert.Equal(t, []availabilityWindowStats{
+		{Up: 3600, Observed: 3600, Reboots: 1},
+		{Up: 6600, Observed: 7200, Reboots: 1},
+		{Up: 8400, Observed: 10800, Reboots: 1},
+	}, reports[0].Windows)
+	assert.False(t, reports[0].Monitored)
+}
+
+func TestAvailabilityRegistry_PersistsAcrossRestarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), availabilityStateFileName)
+	statePath := func() string { return path }
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	r := newAvailabilityRegistry(statePath)
+	r.observe("dev", testAvailabilityDevice, availabilityObservation{
+		now: now, interval: time.Minute, snmpUp: true, uptime: 5000, hasUptime: true,
+	})
+	r.release("dev", now)
+
+	restarted := newAvailabilityRegistry(statePath)
+	rep := restarted.observe("dev", testAvailabilityDevice, availabilityObservation{
+		now: now.Add(time.Minute), interval: time.Minute, snmpUp: true, uptime: 30, hasUptime: true,
+	})
+
+	assert.True(t, rep.Rebooted)
+	assert.Equal(t, "core-sw1", rep.SysName)
+	assert.Equal(t, availabilityWindowStats{Up: 90, Observed: 120, Reboots: 1}, rep.Windows[0])
+}
+
+func TestCollector_CollectAvailability(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSNMP := snmpmock.NewMockHandler(ctrl)
+	setMockClientInitExpect(mockSNMP)
+	setMockClientSysInfoExpect(mockSNMP)
+	mockSNMP.EXPECT().Get(gomock.Any()).Return(&gosnmp.SnmpPacket{
+		Variables: []gosnmp.SnmpPDU{
+			{Name: "." + snmputils.OidSysUpTime, Type: gosnmp.TimeTicks, Value: uint32(360000)},
+		},
+	}, nil).Times(1)
+
+	collr := newTestSNMPCollector()
+	collr.Config = prepareV2Config()
+	collr.CreateVnode = false
+	collr.Ping.Enabled = true
+	collr.Availability.Enabled = true
+	collr.snmpProfiles = []*ddsnmp.Profile{{}}
+	collr.newDdSnmpColl = func(ddsnmpcollector.Config) ddCollector { return &mockDdSnmpCollector{} }
+	collr.newSnmpClient = func() gosnmp.Handler { return mockSNMP }
+	collr.newPinger = func(cfg pinger.Config, log *logger.Logger) (pinger.Client, error) {
+		return &mockPingClient{sample: pingSuccessSample(collr.Hostname)}, nil
+	}
+
+	require.NoError(t, collr.Init(context.Background()))
+	_ = collr.Check(context.Background())
+	mx := collr.Collect(context.Background())
+
+	assert.Equal(t, int64(100_000), mx["snmp_device_availability_1d"])
+	assert.Equal(t, int64(100_000), mx["snmp_device_availability_30d"])
+	assert.Equal(t, int64(0), mx["snmp_device_reboots_30d"])
+	assert.Equal(t, int64(3600), mx["snmp_device_uptime"])
+	assert.Equal(t, int64(1), mx["snmp_device_reachability_snmp"])
+	assert.Equal(t, int64(1), mx["snmp_device_reachability_ping"])
+
+	for _, id := range []string{"snmp_device_availability", "snmp_device_reachability", "snmp_device_uptime", "snmp_device_reboots"} {
+		chart := collr.Charts().Get(id)
+		require.NotNilf(t, chart, "chart %s", id)
+		for _, dim := range chart.Dims {
+			assert.Containsf(t, mx, dim.ID, "chart %s dim %s", id, dim.ID)
+		}
+	}
+
+	resp := newFuncAvailability(collr.availability).Handle(context.Background(), availabilityMethodID, funcapi.ResolvedParams{})
+	require.Equal(t, 200, resp.Status)
+	rows, ok := resp.Data.([][]any)
+	require.True(t, ok)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "192.0.2.1:161", rows[0][0])
+	assert.Equal(t, "up", rows[0][4])
+
+	mockSNMP.EXPECT().Close().Return(nil).AnyTimes()
+	collr.Cleanup(context.Background())
+	resp = newFuncAvailability(collr.availability).Handle(context.Background(), availabilityMethodID, funcapi.ResolvedParams{})
+	rows, ok = resp.Data.([][]any)
+	require.True(t, ok)
+	assert.Equal(t, "not monitored", rows[0][4])
+}
+
+func TestFuncAvailability_UnavailableWithoutData(t *testing.T) {
+	resp := newFuncAvailability(newAvailabilityRegistry(nil)).Handle(context.Background(), availabilityMethodID, funcapi.ResolvedParams{})
+	assert.Equal(t, 503, resp.Status)
+}
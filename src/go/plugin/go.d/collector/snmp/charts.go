@@ -19,7 +19,7 @@ const (
 )
 
 const (
-	prioInternalStatsTimings = prioLicenseState + 1 + iota
+	prioInternalStatsTimings = prioIfacePercentileUtilization + 1 + iota
 	prioInternalStatsSnmpOps
 	prioInternalStatsMetrics
 	prioInternalStatsTableCache
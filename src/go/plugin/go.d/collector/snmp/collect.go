@@ -43,21 +43,31 @@ func (c *Collector) collectPingOnly(ctx context.Context) (map[string]int64, erro
 		return nil, err
 	}
 
+	c.collectAvailability(mx, availabilityObservation{pingUp: len(mx) > 0})
+
 	return mx, nil
 }
 
 func (c *Collector) collectDeviceMetrics(ctx context.Context) (map[string]int64, error) {
 	var (
-		snmpMx map[string]int64
-		pingMx map[string]int64
+		snmpMx  map[string]int64
+		snmpErr error
+		pingMx  map[string]int64
+		obs     availabilityObservation
 	)
 
 	g, groupCtx := errgroup.WithContext(ctx)
 
+	// An SNMP failure must not cancel the ping probe: ping still tells whether
+	// the device is reachable for availability accounting.
 	g.Go(func() error {
 		m := make(map[string]int64)
 		if err := c.collectSNMP(m); err != nil {
-			return err
+			snmpErr = err
+			return nil
+		}
+		if c.Availability.Enabled {
+			c.pollSysUptime(&obs)
 		}
 		snmpMx = m
 		return nil
@@ -82,10 +92,17 @@ func (c *Collector) collectDeviceMetrics(ctx context.Context) (map[string]int64,
 		return nil, err
 	}
 
+	obs.pingUp = len(pingMx) > 0
+	if snmpErr != nil {
+		c.collectAvailability(make(map[string]int64), obs)
+		return nil, snmpErr
+	}
+
 	mx := make(map[string]int64, len(snmpMx)+len(pingMx))
 
 	maps.Copy(mx, snmpMx)
 	maps.Copy(mx, pingMx)
+	c.collectAvailability(mx, obs)
 
 	return mx, nil
 }
@@ -139,6 +156,9 @@ func (c *Collector) ensureInitialized() error {
 	if c.PingOnly || c.Ping.Enabled {
 		c.addPingCharts()
 	}
+	if c.Availability.Enabled {
+		c.addAvailabilityCharts()
+	}
 
 	c.registerDeviceState(si, nil)
 
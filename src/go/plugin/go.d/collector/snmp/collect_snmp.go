@@ -35,6 +35,7 @@ func (c *Collector) collectSNMP(mx map[string]int64) error {
 	c.collectProfileStats(mx, pms)
 
 	c.finalizeIfaceCache()
+	c.collectIfacePercentiles(mx)
 	c.finalizeProfileMetrics()
 
 	return nil
@@ -32,15 +32,23 @@ func newCreator(store *ddsnmp.DeviceStore) collectorapi.Creator {
 	if store == nil {
 		panic("snmp Register requires a non-nil device store")
 	}
+	// Availability history is shared by all jobs, so the agent-wide
+	// availability Function can report every polled device.
+	availability := newAvailabilityRegistry(availabilityStatePath)
 	return collectorapi.Creator{
 		JobConfigSchema: configSchema,
 		Defaults: collectorapi.Defaults{
 			UpdateEvery: 10,
 		},
-		Create:          func() collectorapi.CollectorV1 { return New(store) },
+		Create: func() collectorapi.CollectorV1 {
+			c := New(store)
+			c.availability = availability
+			return c
+		},
 		Config:          func() any { return &Config{} },
 		SharedFunctions: snmpMethods,
-		MethodHandler:   snmpFunctionHandler,
+		AgentFunctions:  snmpAgentMethods,
+		MethodHandler:   newMethodHandler(availability),
 	}
 }
 
@@ -76,13 +84,22 @@ func New(store *ddsnmp.DeviceStore) *Collector {
 					Network:    "ip",
 				},
 			},
+			Availability: AvailabilityConfig{
+				Enabled: true,
+			},
+			Percentiles: PercentilesConfig{
+				BillingPeriod: percentileMonthly,
+				BillingDay:    1,
+			},
 		},
 
-		charts:            &collectorapi.Charts{},
-		seenScalarMetrics: make(map[string]bool),
-		seenTableMetrics:  make(map[string]bool),
-		seenProfiles:      make(map[string]bool),
-		deviceStore:       store,
+		charts:               &collectorapi.Charts{},
+		seenScalarMetrics:    make(map[string]bool),
+		seenTableMetrics:     make(map[string]bool),
+		seenProfiles:         make(map[string]bool),
+		seenPercentileIfaces: make(map[string]bool),
+		deviceStore:          store,
+		availability:         newAvailabilityRegistry(nil),
 
 		ifaceCache: newIfaceCache(),
 		licensing:  newLicensingIntegration(),
@@ -107,16 +124,19 @@ type (
 
 		vnode *vnodes.VirtualNode
 
-		charts            *collectorapi.Charts
-		seenScalarMetrics map[string]bool
-		seenTableMetrics  map[string]bool
-		seenProfiles      map[string]bool
-		deviceStore       *ddsnmp.DeviceStore
+		charts               *collectorapi.Charts
+		seenScalarMetrics    map[string]bool
+		seenTableMetrics     map[string]bool
+		seenProfiles         map[string]bool
+		seenPercentileIfaces map[string]bool
+		deviceStore          *ddsnmp.DeviceStore
+		availability         *availabilityRegistry
 
-		ifaceCache *ifaceCache // interface metrics cache for functions
-		licensing  *licensingIntegration
-		bgp        *bgpIntegration // BGP metric normalization and function state
-		funcRouter *funcRouter     // function router for method handlers
+		ifaceCache  *ifaceCache       // interface metrics cache for functions
+		percentiles *ifacePercentiles // interface traffic percentiles for billing
+		licensing   *licensingIntegration
+		bgp         *bgpIntegration // BGP metric normalization and function state
+		funcRouter  *funcRouter     // function router for method handlers
 
 		pingClient pinger.Client
 		newPinger  func(pinger.Config, *logger.Logger) (pinger.Client, error)
@@ -154,6 +174,14 @@ func (c *Collector) Init(context.Context) error {
 		return fmt.Errorf("failed to initialize SNMP client: %v", err)
 	}
 
+	if c.Percentiles.Enabled && !c.PingOnly {
+		p, err := newIfacePercentiles(c.Percentiles, percentileStatePath(c.Hostname, c.Options.Port))
+		if err != nil {
+			return fmt.Errorf("failed to initialize interface percentiles: %v", err)
+		}
+		c.percentiles = p
+	}
+
 	if c.PingOnly || c.Ping.Enabled {
 		pr, err := c.initPinger()
 		if err != nil {
@@ -211,6 +239,10 @@ func (c *Collector) Cleanup(ctx context.Context) {
 	if c.deviceStore != nil {
 		c.deviceStore.Unregister(c.deviceStoreKey())
 	}
+	c.releaseAvailability()
+	if c.percentiles != nil {
+		c.percentiles.save()
+	}
 	if c.snmpClient != nil {
 		_ = c.snmpClient.Close()
 	}
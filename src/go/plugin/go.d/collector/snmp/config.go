@@ -24,6 +24,23 @@ type (
 
 		PingOnly bool       `yaml:"ping_only,omitempty" json:"ping_only"`
 		Ping     PingConfig `yaml:"ping,omitempty" json:"ping"`
+
+		Availability AvailabilityConfig `yaml:"availability,omitempty" json:"availability"`
+		Percentiles  PercentilesConfig  `yaml:"percentiles,omitempty" json:"percentiles"`
+	}
+
+	AvailabilityConfig struct {
+		Enabled bool `yaml:"enabled" json:"enabled"`
+	}
+
+	PercentilesConfig struct {
+		Enabled bool `yaml:"enabled" json:"enabled"`
+		// Interfaces is a simple patterns selector of interface names; empty selects all.
+		Interfaces string `yaml:"interfaces,omitempty" json:"interfaces"`
+		// BillingPeriod is "monthly" (calendar month starting on BillingDay, UTC)
+		// or a rolling window duration such as "7d".
+		BillingPeriod string `yaml:"billing_period,omitempty" json:"billing_period"`
+		BillingDay    int    `yaml:"billing_day,omitempty" json:"billing_day"`
 	}
 
 	PingConfig struct {
@@ -26,6 +26,9 @@ const (
 	KindArpEntry             TopologyKind = "arp_entry"
 	KindArpLegacyEntry       TopologyKind = "arp_legacy_entry"
 	KindOSPFNeighbor         TopologyKind = "ospf_neighbor"
+	KindISISAdjacency        TopologyKind = "isis_adjacency"
+	KindVRRPGroup            TopologyKind = "vrrp_group"
+	KindHSRPGroup            TopologyKind = "hsrp_group"
 )
 
 var validTopologyKinds = map[TopologyKind]struct{}{
@@ -48,6 +51,9 @@ var validTopologyKinds = map[TopologyKind]struct{}{
 	KindArpEntry:             {},
 	KindArpLegacyEntry:       {},
 	KindOSPFNeighbor:         {},
+	KindISISAdjacency:        {},
+	KindVRRPGroup:            {},
+	KindHSRPGroup:            {},
 }
 
 func IsValidTopologyKind(kind TopologyKind) bool {
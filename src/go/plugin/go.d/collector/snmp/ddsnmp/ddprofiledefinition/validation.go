@@ -41,6 +41,7 @@ var validMetadataResources = map[string]map[string]bool{
 		"lldp_loc_sys_cap_enabled":    true,
 		"bridge_base_address":         true,
 		"ospf_router_id":              true,
+		"isis_system_id":              true,
 	},
 	"interface": {
 		"name":         true,
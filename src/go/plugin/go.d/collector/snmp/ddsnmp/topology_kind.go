@@ -26,4 +26,7 @@ const (
 	KindArpEntry             = ddprofiledefinition.KindArpEntry
 	KindArpLegacyEntry       = ddprofiledefinition.KindArpLegacyEntry
 	KindOSPFNeighbor         = ddprofiledefinition.KindOSPFNeighbor
+	KindISISAdjacency        = ddprofiledefinition.KindISISAdjacency
+	KindVRRPGroup            = ddprofiledefinition.KindVRRPGroup
+	KindHSRPGroup            = ddprofiledefinition.KindHSRPGroup
 )
@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/pkg/funcapi"
+)
+
+const availabilityMethodID = "availability"
+
+func availabilityFunctionConfig() funcapi.FunctionConfig {
+	return funcapi.FunctionConfig{
+		ID:          availabilityMethodID,
+		Name:        "Availability",
+		UpdateEvery: 10,
+		Help:        "Reachability, reboots and availability SLA over the last day, week and month for every polled SNMP device",
+		// Availability is parameterless: it always reports every device.
+		RequiredParams: []funcapi.ParamConfig{},
+	}
+}
+
+// Compile-time interface check.
+var _ funcapi.MethodHandler = (*funcAvailability)(nil)
+
+type funcAvailability struct {
+	registry *availabilityRegistry
+}
+
+func newFuncAvailability(registry *availabilityRegistry) *funcAvailability {
+	return &funcAvailability{registry: registry}
+}
+
+func (f *funcAvailability) MethodParams(_ context.Context, method string) ([]funcapi.ParamConfig, error) {
+	if method != availabilityMethodID {
+		return nil, nil
+	}
+	return []funcapi.ParamConfig{}, nil
+}
+
+func (f *funcAvailability) Cleanup(_ context.Context) {}
+
+func (f *funcAvailability) Handle(_ context.Context, method string, _ funcapi.ResolvedParams) *funcapi.FunctionResponse {
+	if method != availabilityMethodID {
+		return funcapi.NotFoundResponse(method)
+	}
+	if f.registry == nil {
+		return funcapi.UnavailableResponse("availability data not available yet, please retry after data collection")
+	}
+
+	now := time.Now()
+	reports := f.registry.reports(now)
+	if len(reports) == 0 {
+		return funcapi.UnavailableResponse("availability data not available yet, please retry after data collection")
+	}
+
+	cs := availabilityColumnSet(availabilityAllColumns)
+	data := make([][]any, 0, len(reports))
+	for _, rep := range reports {
+		data = append(data, buildAvailabilityFunctionRow(rep))
+	}
+
+	return &funcapi.FunctionResponse{
+		Status:            200,
+		Help:              "Reachability, reboots and availability SLA over the last day, week and month for every polled SNMP device",
+		Columns:           buildAvailabilityColumns(cs),
+		Data:              data,
+		DefaultSortColumn: "Availability 30d",
+	}
+}
+
+type availabilityColumn struct {
+	funcapi.ColumnMeta
+	Value func(availabilityReport) any
+}
+
+func availabilityColumnSet(cols []availabilityColumn) funcapi.ColumnSet[availabilityColumn] {
+	return funcapi.Columns(cols, func(c availabilityColumn) funcapi.ColumnMeta { return c.ColumnMeta })
+}
+
+var availabilityAllColumns = []availabilityColumn{
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Device", Tooltip: "Polled device address", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sticky: true, Sortable: true, UniqueKey: true}, Value: func(r availabilityReport) any {
+		return net.JoinHostPort(r.Hostname, strconv.Itoa(r.Port))
+	}},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Name", Tooltip: "sysName", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true}, Value: func(r availabilityReport) any { return emptyToNil(r.SysName) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Vendor", Tooltip: "Device vendor", Type: funcapi.FieldTypeString, Visible: false, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true}, Value: func(r availabilityReport) any { return emptyToNil(r.Vendor) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Model", Tooltip: "Device model", Type: funcapi.FieldTypeString, Visible: false, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true}, Value: func(r availabilityReport) any { return emptyToNil(r.Model) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Status", Tooltip: "Reachability at the last poll", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true, Visualization: funcapi.FieldVisualPill}, Value: func(r availabilityReport) any { return availabilityStatus(r) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Status Since", Tooltip: "Time of the last up/down transition", Type: funcapi.FieldTypeTimestamp, Visible: false, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummaryMax, Filter: funcapi.FieldFilterRange, Sortable: true, Transform: funcapi.FieldTransformDatetime}, Value: func(r availabilityReport) any { return unixMilliCell(r.StateSince) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Uptime", Tooltip: "Device uptime reported over SNMP at the last poll", Type: funcapi.FieldTypeDuration, Units: "milliseconds", Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryMin, Filter: funcapi.FieldFilterRange, Sortable: true, Transform: funcapi.FieldTransformDuration}, Value: func(r availabilityReport) any {
+		if r.Uptime <= 0 {
+			return nil
+		}
+		return r.Uptime * 1000
+	}},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Last Boot", Tooltip: "Boot time derived from the device uptime", Type: funcapi.FieldTypeTimestamp, Visible: false, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummaryMax, Filter: funcapi.FieldFilterRange, Sortable: true, Transform: funcapi.FieldTransformDatetime}, Value: func(r availabilityReport) any { return unixMilliCell(r.BootTime) }},
+	availabilityPercentColumn("Availability 1d", "Availability over the last 24 hours", 0, true),
+	availabilityPercentColumn("Availability 7d", "Availability over the last 7 days", 1, true),
+	availabilityPercentColumn("Availability 30d", "Availability over the last 30 days", 2, true),
+	availabilityDowntimeColumn("Downtime 1d", "Unreachable time over the last 24 hours", 0, false),
+	availabilityDowntimeColumn("Downtime 7d", "Unreachable time over the last 7 days", 1, false),
+	availabilityDowntimeColumn("Downtime 30d", "Unreachable time over the last 30 days", 2, true),
+	availabilityCountColumn("Reboots 1d", "Reboots detected over the last 24 hours", "reboots", 0, false, func(s availabilityWindowStats) int { return s.Reboots }),
+	availabilityCountColumn("Reboots 30d", "Reboots detected over the last 30 days", "reboots", 2, true, func(s availabilityWindowStats) int { return s.Reboots }),
+	availabilityCountColumn("Outages 1d", "Up to down transitions over the last 24 hours", "outages", 0, false, func(s availabilityWindowStats) int { return s.Outages }),
+	availabilityCountColumn("Outages 30d", "Up to down transitions over the last 30 days", "outages", 2, true, func(s availabilityWindowStats) int { return s.Outages }),
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Observed 30d", Tooltip: "Time the device was polled over the last 30 days; periods without polling are excluded from availability", Type: funcapi.FieldTypeDuration, Units: "milliseconds", Visible: false, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryMin, Filter: funcapi.FieldFilterRange, Sortable: true, Transform: funcapi.FieldTransformDuration}, Value: func(r availabilityReport) any { return r.Windows[2].Observed * 1000 }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Last Poll", Tooltip: "Time of the last poll", Type: funcapi.FieldTypeTimestamp, Visible: false, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummaryMax, Filter: funcapi.FieldFilterRange, Sortable: true, Transform: funcapi.FieldTransformDatetime}, Value: func(r availabilityReport) any { return unixMilliCell(r.LastPoll) }},
+}
+
+func availabilityPercentColumn(name, tooltip string, window int, visible bool) availabilityColumn {
+	return availabilityColumn{
+		ColumnMeta: funcapi.ColumnMeta{Name: name, Tooltip: tooltip, Type: funcapi.FieldTypeFloat, Units: "percentage", Visible: visible, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryMin, Filter: funcapi.FieldFilterRange, Sortable: true, Visualization: funcapi.FieldVisualBar, Transform: funcapi.FieldTransformNumber, DecimalPoints: 3},
+		Value: func(r availabilityReport) any {
+			pct, ok := r.Windows[window].percentage()
+			if !ok {
+				return nil
+			}
+			return float64(pct) / availabilityPercentageDivisor
+		},
+	}
+}
+
+func availabilityDowntimeColumn(name, tooltip string, window int, visible bool) availabilityColumn {
+	return availabilityColumn{
+		ColumnMeta: funcapi.ColumnMeta{Name: name, Tooltip: tooltip, Type: funcapi.FieldTypeDuration, Units: "milliseconds", Visible: visible, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummaryMax, Filter: funcapi.FieldFilterRange, Sortable: true, Transform: funcapi.FieldTransformDuration},
+		Value: func(r availabilityReport) any {
+			s := r.Windows[window]
+			if s.Observed <= 0 {
+				return nil
+			}
+			return (s.Observed - s.Up) * 1000
+		},
+	}
+}
+
+func availabilityCountColumn(name, tooltip, units string, window int, visible bool, value func(availabilityWindowStats) int) availabilityColumn {
+	return availabilityColumn{
+		ColumnMeta: funcapi.ColumnMeta{Name: name, Tooltip: tooltip, Type: funcapi.FieldTypeInteger, Units: units, Visible: visible, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummarySum, Filter: funcapi.FieldFilterRange, Sortable: true},
+		Value:      func(r availabilityReport) any { return value(r.Windows[window]) },
+	}
+}
+
+func buildAvailabilityColumns(cs funcapi.ColumnSet[availabilityColumn]) map[string]any {
+	columns := cs.BuildColumns()
+	rowOptions := funcapi.Column{
+		Index:         cs.Len(),
+		Name:          "rowOptions",
+		Type:          funcapi.FieldTypeNone,
+		Visualization: funcapi.FieldVisualRowOptions,
+		Sort:          funcapi.FieldSortAscending,
+		Sortable:      false,
+		Sticky:        false,
+		Summary:       funcapi.FieldSummaryCount,
+		Filter:        funcapi.FieldFilterNone,
+		Visible:       false,
+		Dummy:         true,
+		ValueOptions: funcapi.ValueOptions{
+			Transform:     funcapi.FieldTransformNone,
+			DecimalPoints: 0,
+			DefaultValue:  nil,
+		},
+	}
+	columns["rowOptions"] = rowOptions.BuildColumn()
+	return columns
+}
+
+func buildAvailabilityFunctionRow(rep availabilityReport) []any {
+	data := make([]any, len(availabilityAllColumns)+1)
+	for i, col := range availabilityAllColumns {
+		data[i] = col.Value(rep)
+	}
+	data[len(availabilityAllColumns)] = nil
+	return data
+}
+
+func availabilityStatus(rep availabilityReport) string {
+	switch {
+	case !rep.Monitored:
+		return "not monitored"
+	case rep.Up:
+		return "up"
+	default:
+		return "down"
+	}
+}
+
+func unixMilliCell(ts int64) any {
+	if ts <= 0 {
+		return nil
+	}
+	return time.Unix(ts, 0).UnixMilli()
+}
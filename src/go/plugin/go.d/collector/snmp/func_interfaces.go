@@ -148,7 +148,7 @@ func (f *funcInterfaces) buildRow(entry *ifaceEntry) []any {
 	}
 	if isIfaceDown(entry) {
 		for i, col := range snmpAllColumns {
-			if col.Type == funcapi.FieldTypeFloat {
+			if col.Type == funcapi.FieldTypeFloat && !col.Historical {
 				row[i] = nil
 			}
 		}
@@ -271,6 +271,7 @@ type snmpColumn struct {
 	funcapi.ColumnMeta
 	Value       func(*ifaceEntry) any // Extracts value from entry
 	DefaultSort bool                  // Is default sort column
+	Historical  bool                  // Not a current rate: kept for interfaces that are down
 }
 
 func snmpColumnSet(cols []snmpColumn) funcapi.ColumnSet[snmpColumn] {
@@ -301,4 +302,51 @@ var snmpAllColumns = []snmpColumn{
 	{ColumnMeta: funcapi.ColumnMeta{Name: "Discards Out", Tooltip: "Discards Out", Type: funcapi.FieldTypeFloat, Units: "packets/s", Visualization: funcapi.FieldVisualBar, Visible: true, Transform: funcapi.FieldTransformNumber, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummarySum, Filter: funcapi.FieldFilterRange, Sortable: true}, Value: func(e *ifaceEntry) any { return ptrToAny(e.rates.discardsOut) }},
 	{ColumnMeta: funcapi.ColumnMeta{Name: "Multicast In", Tooltip: "Multicast In", Type: funcapi.FieldTypeFloat, Units: "packets/s", Visualization: funcapi.FieldVisualBar, Visible: false, Transform: funcapi.FieldTransformNumber, DecimalPoints: 2, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummarySum, Filter: funcapi.FieldFilterRange, Sortable: true, Chart: &funcapi.ChartOptions{Group: "MulticastPackets", Title: "Multicast Packets"}}, Value: func(e *ifaceEntry) any { return ptrToAny(e.rates.mcastPktsIn) }},
 	{ColumnMeta: funcapi.ColumnMeta{Name: "Multicast Out", Tooltip: "Multicast Out", Type: funcapi.FieldTypeFloat, Units: "packets/s", Visualization: funcapi.FieldVisualBar, Visible: false, Transform: funcapi.FieldTransformNumber, DecimalPoints: 2, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummarySum, Filter: funcapi.FieldFilterRange, Sortable: true, Chart: &funcapi.ChartOptions{Group: "MulticastPackets"}}, Value: func(e *ifaceEntry) any { return ptrToAny(e.rates.mcastPktsOut) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Speed", Tooltip: "Interface speed (ifHighSpeed)", Type: funcapi.FieldTypeFloat, Units: "bit/s", Visible: false, Transform: funcapi.FieldTransformNumber, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummarySum, Filter: funcapi.FieldFilterRange, Sortable: true}, Value: func(e *ifaceEntry) any {
+		if e.speed <= 0 {
+			return nil
+		}
+		return float64(e.speed)
+	}, Historical: true},
+	ifacePercentileColumn("95th In", "95th percentile of 5-minute inbound traffic samples in the billing period", "bit/s", func(e *ifaceEntry, st *ifacePercentileStats) any { return float64(st.P95In) }),
+	ifacePercentileColumn("95th Out", "95th percentile of 5-minute outbound traffic samples in the billing period", "bit/s", func(e *ifaceEntry, st *ifacePercentileStats) any { return float64(st.P95Out) }),
+	ifacePercentileColumn("99th In", "99th percentile of 5-minute inbound traffic samples in the billing period", "bit/s", func(e *ifaceEntry, st *ifacePercentileStats) any { return float64(st.P99In) }),
+	ifacePercentileColumn("99th Out", "99th percentile of 5-minute outbound traffic samples in the billing period", "bit/s", func(e *ifaceEntry, st *ifacePercentileStats) any { return float64(st.P99Out) }),
+	ifacePercentileColumn("95th Util In", "Inbound 95th percentile relative to the interface speed", "percentage", func(e *ifaceEntry, st *ifacePercentileStats) any { return utilizationCell(st.P95In, e.speed) }),
+	ifacePercentileColumn("95th Util Out", "Outbound 95th percentile relative to the interface speed", "percentage", func(e *ifaceEntry, st *ifacePercentileStats) any { return utilizationCell(st.P95Out, e.speed) }),
+	ifacePercentileColumn("Prev 95th In", "Inbound 95th percentile of the previous billing period", "bit/s", func(_ *ifaceEntry, st *ifacePercentileStats) any {
+		if st.Previous == nil {
+			return nil
+		}
+		return float64(st.Previous.P95In)
+	}),
+	ifacePercentileColumn("Prev 95th Out", "Outbound 95th percentile of the previous billing period", "bit/s", func(_ *ifaceEntry, st *ifacePercentileStats) any {
+		if st.Previous == nil {
+			return nil
+		}
+		return float64(st.Previous.P95Out)
+	}),
+}
+
+// ifacePercentileColumn builds a billing column; it is empty until the first
+// 5-minute sample of the interface is complete.
+func ifacePercentileColumn(name, tooltip, units string, value func(*ifaceEntry, *ifacePercentileStats) any) snmpColumn {
+	return snmpColumn{
+		ColumnMeta: funcapi.ColumnMeta{Name: name, Tooltip: tooltip, Type: funcapi.FieldTypeFloat, Units: units, Visualization: funcapi.FieldVisualBar, Visible: false, Transform: funcapi.FieldTransformNumber, DecimalPoints: 2, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummaryMax, Filter: funcapi.FieldFilterRange, Sortable: true},
+		Value: func(e *ifaceEntry) any {
+			if e.percentiles == nil || e.percentiles.Samples == 0 {
+				return nil
+			}
+			return value(e, e.percentiles)
+		},
+		Historical: true,
+	}
+}
+
+func utilizationCell(rate uint64, speed int64) any {
+	v, ok := utilization(rate, speed)
+	if !ok {
+		return nil
+	}
+	return float64(v) / 1000
 }
@@ -20,6 +20,7 @@ var ifaceMetricNames = map[string]bool{
 	"ifDiscards":         true,
 	"ifAdminStatus":      true,
 	"ifOperStatus":       true,
+	"ifHighSpeed":        true,
 }
 
 // Tag keys used to identify interfaces.
@@ -59,6 +60,12 @@ type ifaceEntry struct {
 	// Computed rates (per-second, nil if not yet calculable)
 	rates ifaceRates
 
+	// Interface speed in bit/s (from ifHighSpeed, 0 if unknown)
+	speed int64
+
+	// Traffic percentiles over the billing period (nil if not tracked)
+	percentiles *ifacePercentileStats
+
 	// Tracking
 	updated bool // true if seen in current collection cycle
 }
@@ -202,6 +209,8 @@ func (c *Collector) updateIfaceCacheEntry(m ddsnmp.Metric) {
 		entry.adminStatus = extractStatus(m.MultiValue)
 	case "ifOperStatus":
 		entry.operStatus = extractStatus(m.MultiValue)
+	case "ifHighSpeed":
+		entry.speed = m.Value
 	}
 
 	entry.updated = true
@@ -239,6 +248,13 @@ func (c *Collector) finalizeIfaceCache() {
 			entry.rates.errorsOut = calcRate(entry.counters.errorsOut, entry.prevCounters.errorsOut, elapsed)
 			entry.rates.discardsIn = calcRate(entry.counters.discardsIn, entry.prevCounters.discardsIn, elapsed)
 			entry.rates.discardsOut = calcRate(entry.counters.discardsOut, entry.prevCounters.discardsOut, elapsed)
+
+			// A counter reset (e.g. device reboot) would show up as a wrap and
+			// put a bogus sample in the billing data.
+			reset := entry.counters.trafficIn < entry.prevCounters.trafficIn || entry.counters.trafficOut < entry.prevCounters.trafficOut
+			if c.percentiles != nil && !reset && entry.rates.trafficIn != nil && entry.rates.trafficOut != nil {
+				entry.percentiles = c.percentiles.add(name, now, elapsed, *entry.rates.trafficIn, *entry.rates.trafficOut)
+			}
 		}
 
 		entry.prevCounters = entry.counters
@@ -0,0 +1,311 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/pkg/confopt"
+	"github.com/netdata/netdata/go/plugins/pkg/matcher"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/filepersister"
+)
+
+// Interface percentiles follow the usual burstable billing method: traffic is
+// averaged into 5-minute samples, and the 95th percentile of the samples in the
+// billing period is the billed rate. Samples are persisted under the Netdata
+// lib dir, so a restart does not reset the billing period.
+
+const (
+	percentileSampleInterval  = 5 * time.Minute
+	percentileStateFilePrefix = "god-snmp-percentiles-"
+	percentileMonthly         = "monthly"
+	percentileMinWindow       = time.Hour
+	percentileMaxWindow       = 92 * 24 * time.Hour
+)
+
+type (
+	// ifacePercentiles keeps the 5-minute traffic samples of one device's
+	// interfaces for the current billing period.
+	ifacePercentiles struct {
+		state  filepersister.State
+		period billingPeriod
+		filter matcher.Matcher
+
+		dirty  bool
+		ifaces map[string]*ifaceSamples
+	}
+	ifaceSamples struct {
+		PeriodStart int64         `json:"period_start"`
+		Samples     []ifaceSample `json:"samples"`
+		Previous    *ifaceBilling `json:"previous,omitempty"`
+
+		// The 5-minute slot being accumulated: bits transferred and seconds covered.
+		Slot     int64   `json:"slot"`
+		SlotIn   float64 `json:"slot_in"`
+		SlotOut  float64 `json:"slot_out"`
+		SlotSecs float64 `json:"slot_secs"`
+
+		stats *ifacePercentileStats
+	}
+	// ifaceSample is the average rate (bit/s) over one 5-minute slot.
+	ifaceSample struct {
+		Start int64  `json:"t"`
+		In    uint64 `json:"i"`
+		Out   uint64 `json:"o"`
+	}
+	// ifaceBilling is the final 95th percentile of a closed billing period.
+	ifaceBilling struct {
+		PeriodStart int64  `json:"period_start"`
+		PeriodEnd   int64  `json:"period_end"`
+		Samples     int    `json:"samples"`
+		P95In       uint64 `json:"p95_in"`
+		P95Out      uint64 `json:"p95_out"`
+	}
+	ifacePercentileStats struct {
+		PeriodStart int64
+		Samples     int
+		P95In       uint64
+		P95Out      uint64
+		P99In       uint64
+		P99Out      uint64
+		Previous    *ifaceBilling
+	}
+	billingPeriod struct {
+		day    int           // monthly periods start on this day of month
+		window time.Duration // rolling window; zero for monthly periods
+	}
+)
+
+func parseBillingPeriod(cfg PercentilesConfig) (billingPeriod, error) {
+	if cfg.BillingPeriod == "" || cfg.BillingPeriod == percentileMonthly {
+		day := cfg.BillingDay
+		if day == 0 {
+			day = 1
+		}
+		if day < 1 || day > 28 {
+			return billingPeriod{}, fmt.Errorf("billing_day must be between 1 and 28, got %d", cfg.BillingDay)
+		}
+		return billingPeriod{day: day}, nil
+	}
+
+	window, err := confopt.ParseDuration(cfg.BillingPeriod)
+	if err != nil {
+		return billingPeriod{}, fmt.Errorf("invalid billing_period '%s': expected '%s' or a duration: %v", cfg.BillingPeriod, percentileMonthly, err)
+	}
+	if window < percentileMinWindow || window > percentileMaxWindow {
+		return billingPeriod{}, fmt.Errorf("billing_period must be between %s and %s, got '%s'", percentileMinWindow, percentileMaxWindow, cfg.BillingPeriod)
+	}
+	return billingPeriod{window: window}, nil
+}
+
+// start returns the start of the billing period that contains now.
+func (p billingPeriod) start(now time.Time) int64 {
+	if p.window > 0 {
+		return now.Add(-p.window).Unix()
+	}
+	now = now.UTC()
+	start := time.Date(now.Year(), now.Month(), p.day, 0, 0, 0, 0, time.UTC)
+	if now.Before(start) {
+		start = start.AddDate(0, -1, 0)
+	}
+	return start.Unix()
+}
+
+func (p billingPeriod) rolling() bool {
+	return p.window > 0
+}
+
+func newIfacePercentiles(cfg PercentilesConfig, statePath string) (*ifacePercentiles, error) {
+	period, err := parseBillingPeriod(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := matcher.TRUE()
+	if cfg.Interfaces != "" {
+		if filter, err = matcher.NewSimplePatternsMatcher(cfg.Interfaces); err != nil {
+			return nil, fmt.Errorf("invalid interfaces selector '%s': %v", cfg.Interfaces, err)
+		}
+	}
+
+	return &ifacePercentiles{
+		state:  filepersister.State{Path: func() string { return statePath }},
+		period: period,
+		filter: filter,
+		ifaces: make(map[string]*ifaceSamples),
+	}, nil
+}
+
+func percentileStatePath(hostname string, port int) string {
+	name := fmt.Sprintf("%s%s-%d.json", percentileStateFilePrefix, hostname, port)
+	return filepersister.StatePath(strings.NewReplacer("/", "_", ":", "_", "\\", "_").Replace(name))
+}
+
+// add accounts the interface traffic rates (bit/s) measured over the last
+// elapsed period and returns the current percentile stats of the interface.
+func (p *ifacePercentiles) add(name string, now time.Time, elapsed time.Duration, in, out float64) *ifacePercentileStats {
+	if !p.filter.MatchString(name) {
+		return nil
+	}
+	p.ensureLoaded(now)
+
+	s, ok := p.ifaces[name]
+	if !ok {
+		s = &ifaceSamples{PeriodStart: p.period.start(now)}
+		p.ifaces[name] = s
+	}
+
+	slot := now.Truncate(percentileSampleInterval).Unix()
+	if s.SlotSecs > 0 && s.Slot != slot {
+		s.closeSlot()
+		p.dirty = true
+	}
+	if p.advance(s, now) {
+		p.dirty = true
+	}
+
+	secs := elapsed.Seconds()
+	if secs > 0 && secs <= percentileSampleInterval.Seconds() {
+		s.Slot = slot
+		s.SlotIn += in * secs
+		s.SlotOut += out * secs
+		s.SlotSecs += secs
+	}
+
+	if s.stats == nil {
+		s.stats = s.computeStats()
+	}
+	return s.stats
+}
+
+// flush persists the samples if a slot closed since the last save.
+func (p *ifacePercentiles) flush() {
+	if p.dirty {
+		p.save()
+	}
+}
+
+func (p *ifacePercentiles) save() {
+	if !p.state.Loaded() {
+		return
+	}
+	p.dirty = false
+	p.state.Save(time.Now(), percentileState{Interfaces: p.ifaces})
+}
+
+// advance moves the interface samples to the billing period containing now.
+func (p *ifacePercentiles) advance(s *ifaceSamples, now time.Time) bool {
+	start := p.period.start(now)
+
+	if p.period.rolling() {
+		n := len(s.Samples)
+		s.Samples = slices.DeleteFunc(s.Samples, func(v ifaceSample) bool { return v.Start < start })
+		s.PeriodStart = start
+		if n != len(s.Samples) {
+			s.stats = nil
+			return true
+		}
+		return false
+	}
+
+	if start <= s.PeriodStart {
+		return false
+	}
+	if len(s.Samples) > 0 {
+		closed := s.Samples[:0:0]
+		for _, v := range s.Samples {
+			if v.Start >= s.PeriodStart && v.Start < start {
+				closed = append(closed, v)
+			}
+		}
+		if len(closed) > 0 {
+			in, out := samplePercentile(closed, 95)
+			s.Previous = &ifaceBilling{
+				PeriodStart: s.PeriodStart,
+				PeriodEnd:   start,
+				Samples:     len(closed),
+				P95In:       in,
+				P95Out:      out,
+			}
+		}
+	}
+	s.Samples = slices.DeleteFunc(s.Samples, func(v ifaceSample) bool { return v.Start < start })
+	s.PeriodStart = start
+	s.stats = nil
+	return true
+}
+
+func (s *ifaceSamples) closeSlot() {
+	s.Samples = append(s.Samples, ifaceSample{
+		Start: s.Slot,
+		In:    uint64(s.SlotIn / s.SlotSecs),
+		Out:   uint64(s.SlotOut / s.SlotSecs),
+	})
+	s.SlotIn, s.SlotOut, s.SlotSecs = 0, 0, 0
+	s.stats = nil
+}
+
+func (s *ifaceSamples) computeStats() *ifacePercentileStats {
+	stats := &ifacePercentileStats{
+		PeriodStart: s.PeriodStart,
+		Samples:     len(s.Samples),
+		Previous:    s.Previous,
+	}
+	if len(s.Samples) > 0 {
+		stats.P95In, stats.P95Out = samplePercentile(s.Samples, 95)
+		stats.P99In, stats.P99Out = samplePercentile(s.Samples, 99)
+	}
+	return stats
+}
+
+// samplePercentile returns the nearest-rank percentile of the in and out
+// rates: the top (100-pct)% of samples are discarded and the highest
+// remaining sample is the result.
+func samplePercentile(samples []ifaceSample, pct float64) (in, out uint64) {
+	ins := make([]uint64, len(samples))
+	outs := make([]uint64, len(samples))
+	for i, v := range samples {
+		ins[i], outs[i] = v.In, v.Out
+	}
+	slices.Sort(ins)
+	slices.Sort(outs)
+
+	idx := int(math.Ceil(pct/100*float64(len(samples)))) - 1
+	idx = max(0, min(idx, len(samples)-1))
+	return ins[idx], outs[idx]
+}
+
+// utilization returns rate as thousandths of a percent of the interface speed.
+func utilization(rate uint64, speed int64) (int64, bool) {
+	if speed <= 0 {
+		return 0, false
+	}
+	return int64(float64(rate) * 100_000 / float64(speed)), true
+}
+
+func (p *ifacePercentiles) ensureLoaded(now time.Time) {
+	var state percentileState
+	if !p.state.Load(&state) {
+		return
+	}
+	for name, s := range state.Interfaces {
+		if s == nil || !p.filter.MatchString(name) {
+			continue
+		}
+		p.advance(s, now)
+		p.ifaces[name] = s
+	}
+}
+
+type percentileState struct {
+	Interfaces map[string]*ifaceSamples `json:"interfaces"`
+}
+
+func (s percentileState) Bytes() ([]byte, error) {
+	return json.Marshal(s)
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp
+
+import (
+	"fmt"
+
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+)
+
+const (
+	prioIfacePercentileTraffic = prioAvailabilityReboots + 1 + iota
+	prioIfacePercentileUtilization
+)
+
+const percentilesComponentLabelValue = "percentiles"
+
+var (
+	ifacePercentileTrafficChartTmpl = collectorapi.Chart{
+		ID:       "snmp_interface_%s_traffic_percentile",
+		Title:    "Interface traffic percentiles over the billing period",
+		Units:    "bit/s",
+		Fam:      "Network/Interface/Traffic/Percentiles",
+		Ctx:      "snmp.interface_traffic_percentile",
+		Priority: prioIfacePercentileTraffic,
+		Dims: collectorapi.Dims{
+			{ID: "snmp_interface_%s_p95_in", Name: "p95_in"},
+			{ID: "snmp_interface_%s_p95_out", Name: "p95_out", Mul: -1},
+			{ID: "snmp_interface_%s_p99_in", Name: "p99_in"},
+			{ID: "snmp_interface_%s_p99_out", Name: "p99_out", Mul: -1},
+		},
+	}
+	ifacePercentileUtilizationChartTmpl = collectorapi.Chart{
+		ID:       "snmp_interface_%s_utilization_percentile",
+		Title:    "Interface 95th percentile utilization over the billing period",
+		Units:    "percentage",
+		Fam:      "Network/Interface/Traffic/Percentiles",
+		Ctx:      "snmp.interface_utilization_percentile",
+		Priority: prioIfacePercentileUtilization,
+		Dims: collectorapi.Dims{
+			{ID: "snmp_interface_%s_p95_in_utilization", Name: "p95_in", Div: 1000},
+			{ID: "snmp_interface_%s_p95_out_utilization", Name: "p95_out", Div: 1000},
+		},
+	}
+)
+
+func (c *Collector) addIfacePercentileCharts(entry *ifaceEntry) {
+	id := cleanMetricName.Replace(entry.name)
+
+	charts := collectorapi.Charts{
+		ifacePercentileTrafficChartTmpl.Copy(),
+		ifacePercentileUtilizationChartTmpl.Copy(),
+	}
+
+	labels := c.chartBaseLabels()
+	labels["interface"] = entry.name
+	labels["component"] = percentilesComponentLabelValue
+	if entry.ifType != "" {
+		labels["if_type"] = entry.ifType
+	}
+	if entry.ifTypeGroup != "" {
+		labels["if_type_group"] = entry.ifTypeGroup
+	}
+
+	for _, chart := range charts {
+		chart.ID = fmt.Sprintf(chart.ID, id)
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, id)
+		}
+		for k, v := range labels {
+			chart.Labels = append(chart.Labels, collectorapi.Label{Key: k, Value: v})
+		}
+	}
+
+	if err := c.Charts().Add(charts...); err != nil {
+		c.Warningf("failed to add interface percentile charts: %v", err)
+	}
+}
+
+func (c *Collector) removeIfacePercentileCharts(name string) {
+	id := cleanMetricName.Replace(name)
+	for _, tmpl := range []string{ifacePercentileTrafficChartTmpl.ID, ifacePercentileUtilizationChartTmpl.ID} {
+		if chart := c.Charts().Get(fmt.Sprintf(tmpl, id)); chart != nil {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
+
+// collectIfacePercentiles writes the percentile metrics of the interfaces seen
+// in this collection cycle and persists the samples once a 5-minute slot closes.
+func (c *Collector) collectIfacePercentiles(mx map[string]int64) {
+	if c.percentiles == nil || c.ifaceCache == nil {
+		return
+	}
+	defer c.percentiles.flush()
+
+	c.ifaceCache.mu.RLock()
+	defer c.ifaceCache.mu.RUnlock()
+
+	seen := make(map[string]bool)
+
+	for name, entry := range c.ifaceCache.interfaces {
+		st := entry.percentiles
+		if st == nil || st.Samples == 0 {
+			continue
+		}
+
+		seen[name] = true
+		if !c.seenPercentileIfaces[name] {
+			c.seenPercentileIfaces[name] = true
+			c.addIfacePercentileCharts(entry)
+		}
+
+		px := "snmp_interface_" + cleanMetricName.Replace(name) + "_"
+		mx[px+"p95_in"] = int64(st.P95In)
+		mx[px+"p95_out"] = int64(st.P95Out)
+		mx[px+"p99_in"] = int64(st.P99In)
+		mx[px+"p99_out"] = int64(st.P99Out)
+		if v, ok := utilization(st.P95In, entry.speed); ok {
+			mx[px+"p95_in_utilization"] = v
+		}
+		if v, ok := utilization(st.P95Out, entry.speed); ok {
+			mx[px+"p95_out_utilization"] = v
+		}
+	}
+
+	for name := range c.seenPercentileIfaces {
+		if !seen[name] {
+			delete(c.seenPercentileIfaces, name)
+			c.removeIfacePercentileCharts(name)
+		}
+	}
+}
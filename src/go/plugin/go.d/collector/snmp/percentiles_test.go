@@ -0,0 +1,205 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/snmputils"
+)
+
+func TestParseBillingPeriod(t *testing.T) {
+	tests := map[string]struct {
+		cfg     PercentilesConfig
+		want    billingPeriod
+		wantErr bool
+	}{
+		"default is monthly from the 1st": {cfg: PercentilesConfig{}, want: billingPeriod{day: 1}},
+		"monthly with billing day":        {cfg: PercentilesConfig{BillingPeriod: "monthly", BillingDay: 15}, want: billingPeriod{day: 15}},
+		"rolling window":                  {cfg: PercentilesConfig{BillingPeriod: "7d"}, want: billingPeriod{window: 7 * 24 * time.Hour}},
+		"billing day out of range":        {cfg: PercentilesConfig{BillingDay: 31}, wantErr: true},
+		"window too short":                {cfg: PercentilesConfig{BillingPeriod: "5m"}, wantErr: true},
+		"invalid period":                  {cfg: PercentilesConfig{BillingPeriod: "quarterly"}, wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseBillingPeriod(test.cfg)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestBillingPeriod_Start(t *testing.T) {
+	p := billingPeriod{day: 15}
+
+	assert.Equal(t, time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC).Unix(), p.start(time.Date(2026, 3, 20, 8, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC).Unix(), p.start(time.Date(2026, 3, 14, 8, 0, 0, 0, time.UTC)))
+}
+
+func TestSamplePercentile(t *testing.T) {
+	samples := make([]ifaceSample, 0, 100)
+	for i := 100; i >= 1; i-- {
+		samples = append(samples, ifaceSample{In: uint64(i), Out: uint64(i * 10)})
+	}
+
+	in, out := samplePercentile(samples, 95)
+	assert.Equal(t, uint64(95), in)
+	assert.Equal(t, uint64(950), out)
+
+	in, out = samplePercentile(samples, 99)
+	assert.Equal(t, uint64(99), in)
+	assert.Equal(t, uint64(990), out)
+
+	in, out = samplePercentile(samples[99:], 95)
+	assert.Equal(t, uint64(1), in)
+	assert.Equal(t, uint64(10), out)
+}
+
+func TestIfacePercentiles_SamplesAndPercentiles(t *testing.T) {
+	p, err := newIfacePercentiles(PercentilesConfig{}, "")
+	require.NoError(t, err)
+
+	start := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	step := 10 * time.Second
+
+	var st *ifacePercentileStats
+	// Three full 5-minute slots at 100, 300 and 200 Mbit/s in, half of that out.
+	for i, rate := range []float64{100e6, 300e6, 200e6} {
+		for j := range 30 {
+			now := start.Add(time.Duration(i)*percentileSampleInterval + time.Duration(j+1)*step - time.Second)
+			st = p.add("eth0", now, step, rate, rate/2)
+		}
+	}
+	// The first poll of the next slot closes the third one.
+	st = p.add("eth0", start.Add(3*percentileSampleInterval+step), step, 0, 0)
+
+	require.NotNil(t, st)
+	assert.Equal(t, 3, st.Samples)
+	assert.Equal(t, uint64(300e6), st.P95In)
+	assert.Equal(t, uint64(150e6), st.P95Out)
+	assert.Equal(t, uint64(300e6), st.P99In)
+	assert.Nil(t, st.Previous)
+	assert.True(t, p.dirty)
+}
+
+func TestIfacePercentiles_InterfacesSelector(t *testing.T) {
+	p, err := newIfacePercentiles(PercentilesConfig{Interfaces: "Gi* !*"}, "")
+	require.NoError(t, err)
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	assert.Nil(t, p.add("eth0", now, time.Second, 1, 1))
+	assert.NotNil(t, p.add("Gi0/1", now, time.Second, 1, 1))
+}
+
+func TestIfacePercentiles_MonthlyRolloverKeepsPreviousPeriod(t *testing.T) {
+	p, err := newIfacePercentiles(PercentilesConfig{}, "")
+	require.NoError(t, err)
+
+	endOfMarch := time.Date(2026, 3, 31, 23, 50, 0, 0, time.UTC)
+	p.add("eth0", endOfMarch, time.Minute, 400e6, 100e6)
+	st := p.add("eth0", endOfMarch.Add(5*time.Minute), time.Minute, 400e6, 100e6)
+	require.Equal(t, 1, st.Samples)
+
+	st = p.add("eth0", endOfMarch.Add(12*time.Minute), time.Minute, 10e6, 10e6)
+
+	assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC).Unix(), st.PeriodStart)
+	assert.Equal(t, 0, st.Samples)
+	require.NotNil(t, st.Previous)
+	assert.Equal(t, 2, st.Previous.Samples)
+	assert.Equal(t, uint64(400e6), st.Previous.P95In)
+	assert.Equal(t, uint64(100e6), st.Previous.P95Out)
+}
+
+func TestIfacePercentiles_RollingWindowDropsOldSamples(t *testing.T) {
+	p, err := newIfacePercentiles(PercentilesConfig{BillingPeriod: "1h"}, "")
+	require.NoError(t, err)
+
+	start := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	p.add("eth0", start, time.Minute, 900e6, 900e6)
+	p.add("eth0", start.Add(5*time.Minute), time.Minute, 100e6, 100e6)
+	st := p.add("eth0", start.Add(10*time.Minute), time.Minute, 100e6, 100e6)
+	assert.Equal(t, uint64(900e6), st.P95In)
+
+	st = p.add("eth0", start.Add(70*time.Minute), time.Minute, 100e6, 100e6)
+	assert.Equal(t, 1, st.Samples)
+	assert.Equal(t, uint64(100e6), st.P95In)
+}
+
+func TestIfacePercentiles_PersistsAcrossRestarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "percentiles.json")
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	p, err := newIfacePercentiles(PercentilesConfig{}, path)
+	require.NoError(t, err)
+	p.add("eth0", now, time.Minute, 200e6, 50e6)
+	p.add("eth0", now.Add(5*time.Minute), time.Minute, 200e6, 50e6)
+	p.flush()
+	assert.False(t, p.dirty)
+
+	restarted, err := newIfacePercentiles(PercentilesConfig{}, path)
+	require.NoError(t, err)
+	st := restarted.add("eth0", now.Add(10*time.Minute), time.Minute, 100e6, 100e6)
+
+	assert.Equal(t, 2, st.Samples)
+	assert.Equal(t, uint64(200e6), st.P95In)
+	assert.Equal(t, uint64(50e6), st.P95Out)
+}
+
+func TestCollector_CollectIfacePercentiles(t *testing.T) {
+	collr := newTestSNMPCollector()
+	collr.Hostname = "192.0.2.1"
+	collr.sysInfo = &snmputils.SysInfo{Name: "edge-rtr1"}
+
+	p, err := newIfacePercentiles(PercentilesConfig{}, "")
+	require.NoError(t, err)
+	collr.percentiles = p
+
+	collr.ifaceCache.interfaces["Gi0/1"] = &ifaceEntry{
+		name:  "Gi0/1",
+		speed: 1e9,
+		percentiles: &ifacePercentileStats{
+			Samples: 10,
+			P95In:   250e6,
+			P95Out:  100e6,
+			P99In:   400e6,
+			P99Out:  200e6,
+		},
+	}
+	collr.ifaceCache.interfaces["Gi0/2"] = &ifaceEntry{name: "Gi0/2"}
+
+	mx := make(map[string]int64)
+	collr.collectIfacePercentiles(mx)
+
+	assert.Equal(t, map[string]int64{
+		"snmp_interface_Gi0/1_p95_in":              250e6,
+		"snmp_interface_Gi0/1_p95_out":             100e6,
+		"snmp_interface_Gi0/1_p99_in":              400e6,
+		"snmp_interface_Gi0/1_p99_out":             200e6,
+		"snmp_interface_Gi0/1_p95_in_utilization":  25_000,
+		"snmp_interface_Gi0/1_p95_out_utilization": 10_000,
+	}, mx)
+
+	for _, id := range []string{"snmp_interface_Gi0/1_traffic_percentile", "snmp_interface_Gi0/1_utilization_percentile"} {
+		chart := collr.Charts().Get(id)
+		require.NotNilf(t, chart, "chart %s", id)
+		for _, dim := range chart.Dims {
+			assert.Containsf(t, mx, dim.ID, "chart %s dim %s", id, dim.ID)
+		}
+	}
+
+	delete(collr.ifaceCache.interfaces, "Gi0/1")
+	collr.collectIfacePercentiles(make(map[string]int64))
+	assert.True(t, collr.Charts().Get("snmp_interface_Gi0/1_traffic_percentile").Obsolete)
+}
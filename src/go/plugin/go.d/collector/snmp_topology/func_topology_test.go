@@ -386,7 +386,7 @@ func TestSNMPTopologyToV1_BuildsTypedActorDetailTables(t *testing.T) {
 	require.NotNil(t, deviceType.Presentation.Modal.Labels)
 	require.NotNil(t, deviceType.Presentation.Modal.Labels.Identification)
 	assert.Equal(t, "management_ip", deviceType.Presentation.Modal.Labels.Identification.Fields[1].Key)
-	require.Len(t, deviceType.Presentation.Modal.Sections, 6)
+	require.Len(t, deviceType.Presentation.Modal.Sections, 8)
 	assert.Equal(t, "ports", deviceType.Presentation.Modal.Sections[0].ID)
 	assert.Equal(t, "if_index", deviceType.Presentation.Modal.Sections[0].Columns[0].ID)
 	assert.Equal(t, "Port ID", deviceType.Presentation.Modal.Sections[0].Columns[0].Label)
@@ -405,9 +405,13 @@ func TestSNMPTopologyToV1_BuildsTypedActorDetailTables(t *testing.T) {
 	assert.Equal(t, "ospf_neighbors", deviceType.Presentation.Modal.Sections[4].ID)
 	assert.Equal(t, "actor_table", deviceType.Presentation.Modal.Sections[4].Source.Kind)
 	assert.Equal(t, "actor_ospf_neighbors", deviceType.Presentation.Modal.Sections[4].Source.Table)
-	assert.Equal(t, "bgp_peers", deviceType.Presentation.Modal.Sections[5].ID)
-	assert.Equal(t, "actor_table", deviceType.Presentation.Modal.Sections[5].Source.Kind)
-	assert.Equal(t, "actor_bgp_peers", deviceType.Presentation.Modal.Sections[5].Source.Table)
+	assert.Equal(t, "isis_adjacencies", deviceType.Presentation.Modal.Sections[5].ID)
+	assert.Equal(t, "actor_isis_adjacencies", deviceType.Presentation.Modal.Sections[5].Source.Table)
+	assert.Equal(t, "redundancy_groups", deviceType.Presentation.Modal.Sections[6].ID)
+	assert.Equal(t, "actor_redundancy_groups", deviceType.Presentation.Modal.Sections[6].Source.Table)
+	assert.Equal(t, "bgp_peers", deviceType.Presentation.Modal.Sections[7].ID)
+	assert.Equal(t, "actor_table", deviceType.Presentation.Modal.Sections[7].Source.Kind)
+	assert.Equal(t, "actor_bgp_peers", deviceType.Presentation.Modal.Sections[7].Source.Table)
 
 	endpointType := payload.Types.ActorTypes["endpoint"]
 	require.NotNil(t, endpointType.Presentation)
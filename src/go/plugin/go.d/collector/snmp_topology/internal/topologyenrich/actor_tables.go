@@ -33,3 +33,33 @@ func attachTopologyBGPPeerRows(data *topologymodel.Data, rowsByActor map[topolog
 		actor.Detail.BGP = rows
 	}
 }
+
+func attachTopologyISISAdjacencyRows(data *topologymodel.Data, rowsByActor map[topologymodel.ActorHandle][]topologymodel.ISISAdjacencyDetailRow) {
+	if data == nil || len(rowsByActor) == 0 {
+		return
+	}
+	for i := range data.Actors {
+		actor := &data.Actors[i]
+		rows := rowsByActor[actor.ActorHandle]
+		if len(rows) == 0 {
+			continue
+		}
+		sortTopologyISISAdjacencyDetailRows(rows)
+		actor.Detail.ISIS = rows
+	}
+}
+
+func attachTopologyRedundancyGroupRows(data *topologymodel.Data, rowsByActor map[topologymodel.ActorHandle][]topologymodel.RedundancyGroupDetailRow) {
+	if data == nil || len(rowsByActor) == 0 {
+		return
+	}
+	for i := range data.Actors {
+		actor := &data.Actors[i]
+		rows := rowsByActor[actor.ActorHandle]
+		if len(rows) == 0 {
+			continue
+		}
+		sortTopologyRedundancyGroupDetailRows(rows)
+		actor.Detail.Redundancy = rows
+	}
+}
@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package topologyenrich
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_topology/internal/topologymodel"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_topology/internal/topologyutil"
+
+	"github.com/netdata/netdata/go/plugins/pkg/topology/graph"
+)
+
+const topologyISISSource = "isis_mib"
+
+func ApplyISISAdjacency(data *topologymodel.Data, aggregate topologymodel.ObservationAggregate) topologymodel.ISISEnrichmentStats {
+	resolver := newTopologyL3ActorResolverProvider(data, aggregate.Snapshots)
+	return applyISISAdjacencyWithResolver(data, aggregate, resolver)
+}
+
+func applyISISAdjacencyWithResolver(
+	data *topologymodel.Data,
+	aggregate topologymodel.ObservationAggregate,
+	resolver *topologyL3ActorResolverProvider,
+) topologymodel.ISISEnrichmentStats {
+	var stats topologymodel.ISISEnrichmentStats
+	if data == nil || len(aggregate.ISISAdjacencies) == 0 {
+		return finishTopologyISISAdjacencyEnrichment(data, stats)
+	}
+
+	actorResolver := resolver.resolve()
+	seen := existingTopologyISISLinkKeys(data.Links)
+	adjacencyRowsByActor := make(map[topologymodel.ActorHandle][]topologymodel.ISISAdjacencyDetailRow)
+
+	for _, row := range aggregate.ISISAdjacencies {
+		stats.ObservedRows++
+		localRef, localOK := actorResolver.resolveDeviceID(row.DeviceID)
+		remoteRef, remoteOK := actorResolver.resolveISISSystemID(row.NeighborSystemID)
+		if localOK {
+			modalRow := topologyISISAdjacencyActorRow(row)
+			if remoteOK {
+				modalRow.RemoteActorHandle = remoteRef.actorHandle
+			}
+			adjacencyRowsByActor[localRef.actorHandle] = append(adjacencyRowsByActor[localRef.actorHandle], modalRow)
+			stats.AttachedAdjacencyRows++
+		}
+
+		if !isISISAdjacencyUp(row) {
+			stats.SuppressedNonUpState++
+			continue
+		}
+		if !localOK {
+			stats.SuppressedUnresolvedLocal++
+			continue
+		}
+		if !remoteOK {
+			stats.SuppressedUnresolvedNeighbor++
+			continue
+		}
+		if localRef.actorHandle == remoteRef.actorHandle {
+			stats.SuppressedSelfActor++
+			continue
+		}
+
+		link := topologyISISAdjacencyLink(row, localRef, remoteRef)
+		key := topologyISISLinkKey(link)
+		if _, exists := seen[key]; exists {
+			stats.SuppressedDuplicateLink++
+			continue
+		}
+		seen[key] = struct{}{}
+		seen[key.reversed()] = struct{}{}
+		data.Links = append(data.Links, link)
+		stats.EmittedLinks++
+	}
+
+	attachTopologyISISAdjacencyRows(data, adjacencyRowsByActor)
+	sort.Slice(data.Links, func(i, j int) bool {
+		return topologymodel.LinkSortKey(data.Links[i]) < topologymodel.LinkSortKey(data.Links[j])
+	})
+	return finishTopologyISISAdjacencyEnrichment(data, stats)
+}
+
+func finishTopologyISISAdjacencyEnrichment(data *topologymodel.Data, stats topologymodel.ISISEnrichmentStats) topologymodel.ISISEnrichmentStats {
+	recordTopologyISISEnrichmentStats(data, stats)
+	topologymodel.RecomputeLinkStats(data)
+	return stats
+}
+
+func isISISAdjacencyUp(row topologymodel.ISISAdjacency) bool {
+	return topologyutil.NormalizeISISAdjacencyState(row.State) == "up"
+}
+
+func topologyISISAdjacencyLink(row topologymodel.ISISAdjacency, srcRef, dstRef topologyL3ActorRef) topologymodel.Link {
+	return topologymodel.Link{
+		Layer:          "3",
+		Protocol:       topologymodel.ISISAdjacencyLinkType,
+		LinkType:       topologymodel.ISISAdjacencyLinkType,
+		Direction:      "observed",
+		State:          "up",
+		SrcActorHandle: srcRef.actorHandle,
+		DstActorHandle: dstRef.actorHandle,
+		Src: topologymodel.LinkEndpoint{
+			Match:   srcRef.endpointMatch,
+			IfIndex: topologyutil.ParseIndex(row.IfIndex),
+			IfName:  strings.TrimSpace(row.IfName),
+		},
+		Dst: topologymodel.LinkEndpoint{
+			Match: dstRef.endpointMatch,
+		},
+		Inference: &graph.LinkInference{
+			Inference:      "isis_up_adjacency",
+			AttachmentMode: "logical_l3_isis",
+		},
+		Detail: topologymodel.LinkDetail{
+			ISIS: &topologymodel.ISISAdjacencyLinkDetail{
+				Source:           topologyISISSource,
+				LocalSystemID:    topologyutil.NormalizeISISSystemID(row.LocalSystemID),
+				NeighborSystemID: topologyutil.NormalizeISISSystemID(row.NeighborSystemID),
+				Level:            strings.TrimSpace(row.Level),
+			},
+		},
+	}
+}
+
+func topologyISISAdjacencyActorRow(row topologymodel.ISISAdjacency) topologymodel.ISISAdjacencyDetailRow {
+	return topologymodel.ISISAdjacencyDetailRow{
+		LocalSystemID:    topologyutil.NormalizeISISSystemID(row.LocalSystemID),
+		NeighborSystemID: topologyutil.NormalizeISISSystemID(row.NeighborSystemID),
+		State:            topologyutil.NormalizeISISAdjacencyState(row.State),
+		Level:            strings.TrimSpace(row.Level),
+		NeighborType:     strings.TrimSpace(row.NeighborType),
+		IfIndex:          strings.TrimSpace(row.IfIndex),
+		IfName:           strings.TrimSpace(row.IfName),
+		Source:           topologyISISSource,
+	}
+}
+
+func sortTopologyISISAdjacencyDetailRows(rows []topologymodel.ISISAdjacencyDetailRow) {
+	sort.Slice(rows, func(i, j int) bool {
+		return topologyISISAdjacencyActorRowSortKey(rows[i]) < topologyISISAdjacencyActorRowSortKey(rows[j])
+	})
+}
+
+func topologyISISAdjacencyActorRowSortKey(row topologymodel.ISISAdjacencyDetailRow) string {
+	return strings.Join([]string{
+		row.NeighborSystemID,
+		row.Level,
+		row.IfName,
+		row.IfIndex,
+		row.State,
+	}, "\x00")
+}
+
+// An IS-IS adjacency is reported by both routers; both views collapse into
+// one link per router pair and level, whichever side is seen first.
+type topologyISISLinkKeyValue struct {
+	srcActor topologymodel.ActorHandle
+	dstActor topologymodel.ActorHandle
+	level    string
+}
+
+func (k topologyISISLinkKeyValue) reversed() topologyISISLinkKeyValue {
+	k.srcActor, k.dstActor = k.dstActor, k.srcActor
+	return k
+}
+
+func topologyISISLinkKey(link topologymodel.Link) topologyISISLinkKeyValue {
+	return topologyISISLinkKeyValue{
+		srcActor: link.SrcActorHandle,
+		dstActor: link.DstActorHandle,
+		level:    topologymodel.LinkISISLevel(link),
+	}
+}
+
+func existingTopologyISISLinkKeys(links []topologymodel.Link) map[topologyISISLinkKeyValue]struct{} {
+	seen := make(map[topologyISISLinkKeyValue]struct{})
+	for _, link := range links {
+		if strings.EqualFold(strings.TrimSpace(topologyutil.FirstNonEmptyString(link.LinkType, link.Protocol)), topologymodel.ISISAdjacencyLinkType) {
+			key := topologyISISLinkKey(link)
+			seen[key] = struct{}{}
+			seen[key.reversed()] = struct{}{}
+		}
+	}
+	return seen
+}
+
+func recordTopologyISISEnrichmentStats(data *topologymodel.Data, stats topologymodel.ISISEnrichmentStats) {
+	if data == nil {
+		return
+	}
+	data.Stats.ISIS = stats
+	data.Stats.HasISIS = true
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package topologyenrich
+
+import (
+	"testing"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_topology/internal/topologymodel"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyTopologyISISAdjacencyEnrichmentEmitsUpAdjacency(t *testing.T) {
+	data := topologymodel.Data{
+		Actors: []topologymodel.Actor{
+			topologyISISManagedActorForTest("router-a", "device-a", "1921.6800.1001", "198.51.100.1"),
+			topologyISISManagedActorForTest("router-b", "device-b", "1921.6800.1002", "198.51.100.2"),
+		},
+	}
+	aggregate := topologymodel.ObservationAggregate{
+		ISISAdjacencies: []topologymodel.ISISAdjacency{
+			isisAdjacencyForTest("device-a", "1921.6800.1001", "1921.6800.1002", "up"),
+			isisAdjacencyForTest("device-b", "1921.6800.1002", "1921.6800.1001", "up"),
+		},
+	}
+	handles := assignTopologyEnrichTestHandles(t, &data)
+
+	stats := ApplyISISAdjacency(&data, aggregate)
+
+	require.Equal(t, 2, stats.ObservedRows)
+	require.Equal(t, 1, stats.EmittedLinks)
+	require.Equal(t, 1, stats.SuppressedDuplicateLink)
+	require.Len(t, data.Links, 1)
+	link := data.Links[0]
+	require.Equal(t, topologymodel.ISISAdjacencyLinkType, link.LinkType)
+	require.Equal(t, "up", link.State)
+	require.Equal(t, "1921.6800.1001", topologymodel.LinkISISLocalSystemID(link))
+	require.Equal(t, "1921.6800.1002", topologymodel.LinkISISNeighborSystemID(link))
+	require.Equal(t, "level2", topologymodel.LinkISISLevel(link))
+	require.Equal(t, 1, topologyStatsToV1ForTest(t, data.Stats)["isis_adjacency_emitted_links"])
+	require.Equal(t, 1, topologyStatsToV1ForTest(t, data.Stats)["isis_adjacency_visible_links"])
+	require.Len(t, data.Actors[0].Detail.ISIS, 1)
+	require.Equal(t, handles["router-b"], data.Actors[0].Detail.ISIS[0].RemoteActorHandle)
+}
+
+func TestApplyTopologyISISAdjacencyEnrichmentKeepsSuppressedRowsAsDetail(t *testing.T) {
+	tests := map[string]struct {
+		actors         []topologymodel.Actor
+		row            topologymodel.ISISAdjacency
+		wantNonUp      int
+		wantUnresolved int
+		wantSelf       int
+	}{
+		"initializing adjacency": {
+			actors: []topologymodel.Actor{
+				topologyISISManagedActorForTest("router-a", "device-a", "1921.6800.1001"),
+				topologyISISManagedActorForTest("router-b", "device-b", "1921.6800.1002"),
+			},
+			row:       isisAdjacencyForTest("device-a", "1921.6800.1001", "1921.6800.1002", "initializing"),
+			wantNonUp: 1,
+		},
+		"unknown neighbor": {
+			actors: []topologymodel.Actor{
+				topologyISISManagedActorForTest("router-a", "device-a", "1921.6800.1001"),
+			},
+			row:            isisAdjacencyForTest("device-a", "1921.6800.1001", "1921.6800.1009", "up"),
+			wantUnresolved: 1,
+		},
+		"self neighbor": {
+			actors: []topologymodel.Actor{
+				topologyISISManagedActorForTest("router-a", "device-a", "1921.6800.1001"),
+			},
+			row:      isisAdjacencyForTest("device-a", "1921.6800.1001", "1921.6800.1001", "up"),
+			wantSelf: 1,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			data := topologymodel.Data{Actors: tc.actors}
+			assignTopologyEnrichTestHandles(t, &data)
+
+			stats := ApplyISISAdjacency(&data, topologymodel.ObservationAggregate{
+				ISISAdjacencies: []topologymodel.ISISAdjacency{tc.row},
+			})
+
+			require.Empty(t, data.Links)
+			require.Equal(t, tc.wantNonUp, stats.SuppressedNonUpState)
+			require.Equal(t, tc.wantUnresolved, stats.SuppressedUnresolvedNeighbor)
+			require.Equal(t, tc.wantSelf, stats.SuppressedSelfActor)
+			require.Len(t, data.Actors[0].Detail.ISIS, 1)
+		})
+	}
+}
+
+func topologyISISManagedActorForTest(actorID, deviceID, systemID string, ips ...string) topologymodel.Actor {
+	actor := topologyL3ManagedActorForTest(actorID, map[string]any{"device_id": deviceID}, ips...)
+	actor.Labels = map[string]string{topologymodel.LabelISISSystemID: systemID}
+	return actor
+}
+
+func isisAdjacencyForTest(deviceID, localSystemID, neighborSystemID, state string) topologymodel.ISISAdjacency {
+	return topologymodel.ISISAdjacency{
+		DeviceID:         deviceID,
+		LocalSystemID:    localSystemID,
+		NeighborSystemID: neighborSystemID,
+		State:            state,
+		Level:            "level2",
+		NeighborType:     "l2IntermediateSystem",
+		IfIndex:          "2",
+		IfName:           "Gi0/2",
+	}
+}
@@ -27,6 +27,7 @@ type topologyL3ActorResolver struct {
 	byDeviceID map[string]topologyL3ActorRef
 	byIP       map[string]topologyL3ActorRef
 	byRouterID map[string]topologyL3ActorRef
+	byISISID   map[string]topologyL3ActorRef
 }
 
 type topologyL3ActorResolverProvider struct {
@@ -57,6 +58,7 @@ func newTopologyL3ActorResolver(data *topologymodel.Data, snapshots []topologymo
 		byDeviceID: make(map[string]topologyL3ActorRef),
 		byIP:       make(map[string]topologyL3ActorRef),
 		byRouterID: make(map[string]topologyL3ActorRef),
+		byISISID:   make(map[string]topologyL3ActorRef),
 	}
 	if data == nil || len(data.Actors) == 0 {
 		return resolver
@@ -89,6 +91,7 @@ func newTopologyL3ActorResolver(data *topologymodel.Data, snapshots []topologymo
 		for _, routerID := range topologyL3ActorRouterIDs(actor) {
 			resolver.addUniqueRouterID(routerID, ref)
 		}
+		resolver.addUniqueISISSystemID(actor.Labels[topologymodel.LabelISISSystemID], ref)
 	}
 
 	matches := make([]int, 0, 1)
@@ -102,6 +105,7 @@ func newTopologyL3ActorResolver(data *topologymodel.Data, snapshots []topologymo
 			ref := managedActors[actorIndex]
 			resolver.addUniqueDeviceID(deviceID, ref)
 			resolver.addUniqueRouterID(snapshot.LocalDevice.OSPFRouterID, ref)
+			resolver.addUniqueISISSystemID(snapshot.LocalDevice.Labels[topologymodel.LabelISISSystemID], ref)
 		}
 	}
 
@@ -142,6 +146,13 @@ func (r topologyL3ActorResolver) resolveRouterID(routerID string) (topologyL3Act
 	return topologyL3ActorRef{}, false
 }
 
+func (r topologyL3ActorResolver) resolveISISSystemID(systemID string) (topologyL3ActorRef, bool) {
+	if ref, ok := r.byISISID[topologyutil.NormalizeISISSystemID(systemID)]; ok && ref.valid() {
+		return ref, true
+	}
+	return topologyL3ActorRef{}, false
+}
+
 func (r topologyL3ActorResolver) resolveIPAddress(ip string) (topologyL3ActorRef, bool) {
 	if ref, ok := r.byIP[topologyutil.NormalizeIPAddress(ip)]; ok && ref.valid() {
 		return ref, true
@@ -201,6 +212,21 @@ func (r topologyL3ActorResolver) addUniqueRouterID(routerID string, ref topology
 	}
 }
 
+func (r topologyL3ActorResolver) addUniqueISISSystemID(systemID string, ref topologyL3ActorRef) {
+	systemID = topologyutil.NormalizeISISSystemID(systemID)
+	if systemID == "" || !ref.valid() {
+		return
+	}
+	existing, ok := r.byISISID[systemID]
+	if !ok {
+		r.byISISID[systemID] = ref
+		return
+	}
+	if existing.valid() && existing.actorHandle != ref.actorHandle {
+		r.byISISID[systemID] = topologyL3ActorRef{}
+	}
+}
+
 func topologyL3ActorLexicalOrder(actors []topologymodel.Actor) map[topologymodel.ActorHandle]int {
 	type entry struct {
 		handle  topologymodel.ActorHandle
@@ -9,6 +9,8 @@ func ApplyLayer3(data *topologymodel.Data, aggregate topologymodel.ObservationAg
 	resolver := newTopologyL3ActorResolverProvider(data, aggregate.Snapshots)
 	applyL3SubnetWithResolver(data, aggregate, resolver)
 	applyOSPFAdjacencyWithResolver(data, aggregate, resolver)
-	// BGP extends IP identity after L3 and OSPF have consumed the shared base resolver.
+	applyISISAdjacencyWithResolver(data, aggregate, resolver)
+	applyRedundancyGroupsWithResolver(data, aggregate, resolver)
+	// BGP extends IP identity after L3, OSPF, IS-IS and redundancy groups have consumed the shared base resolver.
 	applyBGPAdjacencyWithResolver(data, aggregate, resolver)
 }
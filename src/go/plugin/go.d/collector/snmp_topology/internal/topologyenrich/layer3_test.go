@@ -43,6 +43,8 @@ func TestApplyLayer3MatchesStandaloneEnrichmentSequence(t *testing.T) {
 	assignTopologyEnrichTestHandles(t, &want)
 	ApplyL3Subnet(&want, aggregate)
 	ApplyOSPFAdjacency(&want, aggregate)
+	ApplyISISAdjacency(&want, aggregate)
+	ApplyRedundancyGroups(&want, aggregate)
 	ApplyBGPAdjacency(&want, aggregate)
 
 	got := newData()
@@ -0,0 +1,286 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package topologyenrich
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"sort"
+	"strings"
+
+	topologyengine "github.com/netdata/netdata/go/plugins/pkg/l2topology"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_topology/internal/topologymodel"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_topology/internal/topologyutil"
+
+	"github.com/netdata/netdata/go/plugins/pkg/topology/graph"
+)
+
+// First-hop redundancy groups (VRRP, HSRP) are rendered as a virtual router
+// actor that owns the virtual IP, with a membership link from every router
+// that takes part in the group. The virtual IP is kept out of the actor match
+// so the virtual router never collapses into whichever device answers for it.
+
+func ApplyRedundancyGroups(data *topologymodel.Data, aggregate topologymodel.ObservationAggregate) topologymodel.RedundancyEnrichmentStats {
+	resolver := newTopologyL3ActorResolverProvider(data, aggregate.Snapshots)
+	return applyRedundancyGroupsWithResolver(data, aggregate, resolver)
+}
+
+type topologyRedundancyGroup struct {
+	key      string
+	protocol string
+	groupID  string
+	vip      string
+	rows     []topologymodel.RedundancyGroup
+}
+
+func applyRedundancyGroupsWithResolver(
+	data *topologymodel.Data,
+	aggregate topologymodel.ObservationAggregate,
+	resolver *topologyL3ActorResolverProvider,
+) topologymodel.RedundancyEnrichmentStats {
+	var stats topologymodel.RedundancyEnrichmentStats
+	if data == nil || len(aggregate.RedundancyGroups) == 0 {
+		return finishTopologyRedundancyEnrichment(data, stats)
+	}
+
+	actorResolver := resolver.resolve()
+	producerScopeID := strings.TrimSpace(aggregate.ProducerScopeID)
+	rowsByActor := make(map[topologymodel.ActorHandle][]topologymodel.RedundancyGroupDetailRow)
+
+	groups := make(map[string]*topologyRedundancyGroup)
+	for _, row := range aggregate.RedundancyGroups {
+		stats.ObservedRows++
+		vip := topologyutil.NormalizeNonUnspecifiedIPAddress(row.VirtualIP)
+		if vip == "" {
+			stats.SuppressedNoVirtualIP++
+			if ref, ok := actorResolver.resolveDeviceID(row.DeviceID); ok {
+				rowsByActor[ref.actorHandle] = append(rowsByActor[ref.actorHandle], topologyRedundancyGroupActorRow(row))
+				stats.AttachedGroupRows++
+			}
+			continue
+		}
+		protocol := strings.ToLower(strings.TrimSpace(row.Protocol))
+		groupID := strings.TrimSpace(row.GroupID)
+		key := topologyutil.JoinKeyParts(protocol, groupID, vip)
+		group := groups[key]
+		if group == nil {
+			group = &topologyRedundancyGroup{key: key, protocol: protocol, groupID: groupID, vip: vip}
+			groups[key] = group
+		}
+		group.rows = append(group.rows, row)
+	}
+
+	actorSeen := existingTopologyActorRefs(data.Actors)
+	linkSeen := existingTopologyRedundancyLinkKeys(data.Links)
+	for _, key := range topologyutil.SortedMapKeys(groups) {
+		group := groups[key]
+
+		vrHandle := topologymodel.ActorHandle{}
+		vrActorID := ""
+		vrExists := false
+		if producerScopeID == "" {
+			stats.SuppressedNoProducerScope++
+		} else {
+			vrActorID = topologyRedundancyVirtualRouterActorID(producerScopeID, group)
+			vrHandle, vrExists = actorSeen[vrActorID]
+		}
+		vrEmitted := false
+
+		for _, row := range group.rows {
+			ref, ok := actorResolver.resolveDeviceID(row.DeviceID)
+			if !ok {
+				stats.SuppressedUnresolvedLocal++
+				continue
+			}
+			memberRow := topologyRedundancyGroupActorRow(row)
+			if vrActorID == "" {
+				rowsByActor[ref.actorHandle] = append(rowsByActor[ref.actorHandle], memberRow)
+				stats.AttachedGroupRows++
+				continue
+			}
+
+			if !vrExists && !vrEmitted {
+				vrHandle = data.NextActorHandle()
+				vrActor := topologyRedundancyVirtualRouterActor(vrActorID, group)
+				vrActor.ActorHandle = vrHandle
+				data.Actors = append(data.Actors, vrActor)
+				actorSeen[vrActorID] = vrHandle
+				vrEmitted = true
+				stats.EmittedVirtualRouters++
+			}
+
+			memberRow.RemoteActorHandle = vrHandle
+			rowsByActor[ref.actorHandle] = append(rowsByActor[ref.actorHandle], memberRow)
+			vrRow := topologyRedundancyGroupActorRow(row)
+			vrRow.RemoteActorHandle = ref.actorHandle
+			rowsByActor[vrHandle] = append(rowsByActor[vrHandle], vrRow)
+			stats.AttachedGroupRows++
+
+			link := topologyRedundancyMembershipLink(row, group, ref, vrHandle)
+			linkKey := topologyRedundancyLinkKey(link)
+			if _, exists := linkSeen[linkKey]; exists {
+				stats.SuppressedDuplicateLink++
+				continue
+			}
+			linkSeen[linkKey] = struct{}{}
+			data.Links = append(data.Links, link)
+			stats.EmittedMembershipLinks++
+		}
+	}
+
+	attachTopologyRedundancyGroupRows(data, rowsByActor)
+	sort.Slice(data.Actors, func(i, j int) bool {
+		return strings.TrimSpace(data.Actors[i].ActorID) < strings.TrimSpace(data.Actors[j].ActorID)
+	})
+	sort.Slice(data.Links, func(i, j int) bool {
+		return topologymodel.LinkSortKey(data.Links[i]) < topologymodel.LinkSortKey(data.Links[j])
+	})
+	return finishTopologyRedundancyEnrichment(data, stats)
+}
+
+func finishTopologyRedundancyEnrichment(data *topologymodel.Data, stats topologymodel.RedundancyEnrichmentStats) topologymodel.RedundancyEnrichmentStats {
+	recordTopologyRedundancyEnrichmentStats(data, stats)
+	topologymodel.RecomputeLinkStats(data)
+	return stats
+}
+
+func topologyRedundancySource(protocol string) string {
+	switch protocol {
+	case "hsrp":
+		return "cisco_hsrp_mib"
+	default:
+		return "vrrp_mib"
+	}
+}
+
+func topologyRedundancyVirtualRouterActorID(producerScopeID string, group *topologyRedundancyGroup) string {
+	sum := sha1.Sum([]byte(topologyutil.JoinKeyParts(
+		strings.TrimSpace(producerScopeID),
+		group.protocol,
+		group.groupID,
+		group.vip,
+	)))
+	return "virtual_router:" + hex.EncodeToString(sum[:8])
+}
+
+func topologyRedundancyVirtualRouterActor(actorID string, group *topologyRedundancyGroup) topologymodel.Actor {
+	return topologymodel.Actor{
+		ActorID:   actorID,
+		ActorType: topologymodel.VirtualRouterActorType,
+		Layer:     "3",
+		Source:    topologyRedundancySource(group.protocol),
+		Labels: map[string]string{
+			"protocol":   group.protocol,
+			"group_id":   group.groupID,
+			"virtual_ip": group.vip,
+		},
+		Detail: topologymodel.ActorDetail{
+			L2: topologyengine.ProjectionActorDetail{
+				DisplayName: strings.ToUpper(group.protocol) + " " + group.groupID + " (" + group.vip + ")",
+			},
+		},
+	}
+}
+
+func topologyRedundancyMembershipLink(
+	row topologymodel.RedundancyGroup,
+	group *topologyRedundancyGroup,
+	memberRef topologyL3ActorRef,
+	vrHandle topologymodel.ActorHandle,
+) topologymodel.Link {
+	return topologymodel.Link{
+		Layer:          "3",
+		Protocol:       topologymodel.RedundancyMembershipLinkType,
+		LinkType:       topologymodel.RedundancyMembershipLinkType,
+		Direction:      "observed",
+		State:          strings.TrimSpace(row.Role),
+		SrcActorHandle: memberRef.actorHandle,
+		DstActorHandle: vrHandle,
+		Src: topologymodel.LinkEndpoint{
+			Match:   memberRef.endpointMatch,
+			IfIndex: topologyutil.ParseIndex(row.IfIndex),
+			IfName:  strings.TrimSpace(row.IfName),
+		},
+		Dst: topologymodel.LinkEndpoint{},
+		Inference: &graph.LinkInference{
+			Inference:      "first_hop_redundancy_membership",
+			AttachmentMode: "logical_l3_" + group.protocol,
+		},
+		Detail: topologymodel.LinkDetail{
+			Redundancy: &topologymodel.RedundancyMembershipLinkDetail{
+				Source:    topologyRedundancySource(group.protocol),
+				Protocol:  group.protocol,
+				GroupID:   group.groupID,
+				VirtualIP: group.vip,
+				State:     strings.TrimSpace(row.State),
+				Role:      strings.TrimSpace(row.Role),
+				Priority:  strings.TrimSpace(row.Priority),
+			},
+		},
+	}
+}
+
+func topologyRedundancyGroupActorRow(row topologymodel.RedundancyGroup) topologymodel.RedundancyGroupDetailRow {
+	protocol := strings.ToLower(strings.TrimSpace(row.Protocol))
+	return topologymodel.RedundancyGroupDetailRow{
+		Protocol:  protocol,
+		GroupID:   strings.TrimSpace(row.GroupID),
+		VirtualIP: topologyutil.NormalizeNonUnspecifiedIPAddress(row.VirtualIP),
+		State:     strings.TrimSpace(row.State),
+		Role:      strings.TrimSpace(row.Role),
+		Priority:  strings.TrimSpace(row.Priority),
+		MasterIP:  topologyutil.NormalizeNonUnspecifiedIPAddress(row.MasterIP),
+		IfIndex:   strings.TrimSpace(row.IfIndex),
+		IfName:    strings.TrimSpace(row.IfName),
+		Source:    topologyRedundancySource(protocol),
+	}
+}
+
+func sortTopologyRedundancyGroupDetailRows(rows []topologymodel.RedundancyGroupDetailRow) {
+	sort.Slice(rows, func(i, j int) bool {
+		return topologyRedundancyGroupActorRowSortKey(rows[i]) < topologyRedundancyGroupActorRowSortKey(rows[j])
+	})
+}
+
+func topologyRedundancyGroupActorRowSortKey(row topologymodel.RedundancyGroupDetailRow) string {
+	return strings.Join([]string{
+		row.Protocol,
+		row.GroupID,
+		row.VirtualIP,
+		row.IfName,
+		row.IfIndex,
+		row.Role,
+	}, "\x00")
+}
+
+type topologyRedundancyLinkKeyValue struct {
+	member  topologymodel.ActorHandle
+	vr      topologymodel.ActorHandle
+	ifIndex int
+}
+
+func topologyRedundancyLinkKey(link topologymodel.Link) topologyRedundancyLinkKeyValue {
+	return topologyRedundancyLinkKeyValue{
+		member:  link.SrcActorHandle,
+		vr:      link.DstActorHandle,
+		ifIndex: link.Src.IfIndex,
+	}
+}
+
+func existingTopologyRedundancyLinkKeys(links []topologymodel.Link) map[topologyRedundancyLinkKeyValue]struct{} {
+	seen := make(map[topologyRedundancyLinkKeyValue]struct{})
+	for _, link := range links {
+		if strings.EqualFold(strings.TrimSpace(topologyutil.FirstNonEmptyString(link.LinkType, link.Protocol)), topologymodel.RedundancyMembershipLinkType) {
+			seen[topologyRedundancyLinkKey(link)] = struct{}{}
+		}
+	}
+	return seen
+}
+
+func recordTopologyRedundancyEnrichmentStats(data *topologymodel.Data, stats topologymodel.RedundancyEnrichmentStats) {
+	if data == nil {
+		return
+	}
+	data.Stats.Redundancy = stats
+	data.Stats.HasRedundancy = true
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package topologyenrich
+
+import (
+	"testing"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_topology/internal/topologymodel"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyTopologyRedundancyGroupsEmitsSharedVirtualRouter(t *testing.T) {
+	data := topologymodel.Data{
+		Actors: []topologymodel.Actor{
+			topologyL3ManagedActorForTest("router-a", map[string]any{"device_id": "device-a"}, "198.51.100.2"),
+			topologyL3ManagedActorForTest("router-b", map[string]any{"device_id": "device-b"}, "198.51.100.3"),
+		},
+	}
+	aggregate := topologymodel.ObservationAggregate{
+		ProducerScopeID: "scope-1",
+		RedundancyGroups: []topologymodel.RedundancyGroup{
+			redundancyGroupForTest("device-a", "vrrp", "10", "198.51.100.1", "master"),
+			redundancyGroupForTest("device-b", "vrrp", "10", "198.51.100.1", "backup"),
+		},
+	}
+	handles := assignTopologyEnrichTestHandles(t, &data)
+
+	stats := ApplyRedundancyGroups(&data, aggregate)
+
+	require.Equal(t, 2, stats.ObservedRows)
+	require.Equal(t, 1, stats.EmittedVirtualRouters)
+	require.Equal(t, 2, stats.EmittedMembershipLinks)
+	require.Len(t, data.Actors, 3)
+	require.Len(t, data.Links, 2)
+
+	var vr topologymodel.Actor
+	for _, actor := range data.Actors {
+		if actor.ActorType == topologymodel.VirtualRouterActorType {
+			vr = actor
+		}
+	}
+	require.NotEmpty(t, vr.ActorID)
+	require.Empty(t, vr.Match.IPAddresses)
+	require.Equal(t, "198.51.100.1", vr.Labels["virtual_ip"])
+	require.Len(t, vr.Detail.Redundancy, 2)
+
+	roles := make(map[topologymodel.ActorHandle]string)
+	for _, link := range data.Links {
+		require.Equal(t, topologymodel.RedundancyMembershipLinkType, link.LinkType)
+		require.Equal(t, vr.ActorHandle, link.DstActorHandle)
+		require.Equal(t, "10", topologymodel.LinkRedundancyGroupID(link))
+		roles[link.SrcActorHandle] = topologymodel.LinkRedundancyRole(link)
+	}
+	require.Equal(t, "master", roles[handles["router-a"]])
+	require.Equal(t, "backup", roles[handles["router-b"]])
+	require.Equal(t, 2, topologyStatsToV1ForTest(t, data.Stats)["redundancy_membership_visible_links"])
+}
+
+func TestApplyTopologyRedundancyGroupsKeepsIncompleteRowsAsDetail(t *testing.T) {
+	tests := map[string]struct {
+		aggregate           topologymodel.ObservationAggregate
+		wantNoVirtualIP     int
+		wantNoProducerScope int
+	}{
+		"missing virtual ip": {
+			aggregate: topologymodel.ObservationAggregate{
+				ProducerScopeID:  "scope-1",
+				RedundancyGroups: []topologymodel.RedundancyGroup{redundancyGroupForTest("device-a", "hsrp", "1", "", "active")},
+			},
+			wantNoVirtualIP: 1,
+		},
+		"missing producer scope": {
+			aggregate: topologymodel.ObservationAggregate{
+				RedundancyGroups: []topologymodel.RedundancyGroup{redundancyGroupForTest("device-a", "hsrp", "1", "198.51.100.1", "active")},
+			},
+			wantNoProducerScope: 1,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			data := topologymodel.Data{
+				Actors: []topologymodel.Actor{
+					topologyL3ManagedActorForTest("router-a", map[string]any{"device_id": "device-a"}, "198.51.100.2"),
+				},
+			}
+			assignTopologyEnrichTestHandles(t, &data)
+
+			stats := ApplyRedundancyGroups(&data, tc.aggregate)
+
+			require.Empty(t, data.Links)
+			require.Len(t, data.Actors, 1)
+			require.Equal(t, tc.wantNoVirtualIP, stats.SuppressedNoVirtualIP)
+			require.Equal(t, tc.wantNoProducerScope, stats.SuppressedNoProducerScope)
+			require.Len(t, data.Actors[0].Detail.Redundancy, 1)
+		})
+	}
+}
+
+func redundancyGroupForTest(deviceID, protocol, groupID, vip, state string) topologymodel.RedundancyGroup {
+	return topologymodel.RedundancyGroup{
+		DeviceID:  deviceID,
+		Protocol:  protocol,
+		GroupID:   groupID,
+		VirtualIP: vip,
+		State:     state,
+		Role:      state,
+		Priority:  "100",
+		IfIndex:   "3",
+		IfName:    "Vlan10",
+	}
+}
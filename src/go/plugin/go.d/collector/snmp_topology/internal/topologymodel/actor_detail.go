@@ -11,7 +11,10 @@ import (
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_topology/internal/topologyutil"
 )
 
-const LabelOSPFRouterID = "ospf_router_id"
+const (
+	LabelOSPFRouterID = "ospf_router_id"
+	LabelISISSystemID = "isis_system_id"
+)
 
 func ActorDetailDisplayName(actor Actor) string {
 	return topologyutil.FirstNonEmptyString(
@@ -108,3 +108,73 @@ func LinkBGPSource(link Link) string {
 	}
 	return strings.TrimSpace(link.Detail.BGP.Source)
 }
+
+func LinkISISLocalSystemID(link Link) string {
+	if link.Detail.ISIS == nil {
+		return ""
+	}
+	return strings.TrimSpace(link.Detail.ISIS.LocalSystemID)
+}
+
+func LinkISISNeighborSystemID(link Link) string {
+	if link.Detail.ISIS == nil {
+		return ""
+	}
+	return strings.TrimSpace(link.Detail.ISIS.NeighborSystemID)
+}
+
+func LinkISISLevel(link Link) string {
+	if link.Detail.ISIS == nil {
+		return ""
+	}
+	return strings.TrimSpace(link.Detail.ISIS.Level)
+}
+
+func LinkISISSource(link Link) string {
+	if link.Detail.ISIS == nil {
+		return ""
+	}
+	return strings.TrimSpace(link.Detail.ISIS.Source)
+}
+
+func LinkRedundancyProtocol(link Link) string {
+	if link.Detail.Redundancy == nil {
+		return ""
+	}
+	return strings.TrimSpace(link.Detail.Redundancy.Protocol)
+}
+
+func LinkRedundancyGroupID(link Link) string {
+	if link.Detail.Redundancy == nil {
+		return ""
+	}
+	return strings.TrimSpace(link.Detail.Redundancy.GroupID)
+}
+
+func LinkRedundancyVirtualIP(link Link) string {
+	if link.Detail.Redundancy == nil {
+		return ""
+	}
+	return strings.TrimSpace(link.Detail.Redundancy.VirtualIP)
+}
+
+func LinkRedundancyRole(link Link) string {
+	if link.Detail.Redundancy == nil {
+		return ""
+	}
+	return strings.TrimSpace(link.Detail.Redundancy.Role)
+}
+
+func LinkRedundancyPriority(link Link) string {
+	if link.Detail.Redundancy == nil {
+		return ""
+	}
+	return strings.TrimSpace(link.Detail.Redundancy.Priority)
+}
+
+func LinkRedundancySource(link Link) string {
+	if link.Detail.Redundancy == nil {
+		return ""
+	}
+	return strings.TrimSpace(link.Detail.Redundancy.Source)
+}
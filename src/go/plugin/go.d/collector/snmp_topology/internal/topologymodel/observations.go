@@ -12,25 +12,29 @@ import (
 )
 
 type ObservationSnapshot struct {
-	L2Observations []topologyengine.L2Observation
-	L3Interfaces   []L3Interface
-	OSPFNeighbors  []OSPFNeighbor
-	BGPPeers       []BGPPeer
-	LocalDevice    Device
-	LocalDeviceID  string
-	AgentID        string
-	CollectedAt    time.Time
+	L2Observations   []topologyengine.L2Observation
+	L3Interfaces     []L3Interface
+	OSPFNeighbors    []OSPFNeighbor
+	ISISAdjacencies  []ISISAdjacency
+	RedundancyGroups []RedundancyGroup
+	BGPPeers         []BGPPeer
+	LocalDevice      Device
+	LocalDeviceID    string
+	AgentID          string
+	CollectedAt      time.Time
 }
 
 type ObservationAggregate struct {
-	Snapshots       []ObservationSnapshot
-	L2Observations  []topologyengine.L2Observation
-	L3Interfaces    []L3Interface
-	OSPFNeighbors   []OSPFNeighbor
-	BGPPeers        []BGPPeer
-	AgentID         string
-	ProducerScopeID string
-	CollectedAt     time.Time
+	Snapshots        []ObservationSnapshot
+	L2Observations   []topologyengine.L2Observation
+	L3Interfaces     []L3Interface
+	OSPFNeighbors    []OSPFNeighbor
+	ISISAdjacencies  []ISISAdjacency
+	RedundancyGroups []RedundancyGroup
+	BGPPeers         []BGPPeer
+	AgentID          string
+	ProducerScopeID  string
+	CollectedAt      time.Time
 }
 
 type L3Interface struct {
@@ -56,6 +60,32 @@ type OSPFNeighbor struct {
 	Prefix           int
 }
 
+type ISISAdjacency struct {
+	DeviceID         string
+	LocalSystemID    string
+	NeighborSystemID string
+	State            string
+	Level            string
+	NeighborType     string
+	IfIndex          string
+	IfName           string
+}
+
+// RedundancyGroup is one device's view of a first-hop redundancy group
+// (VRRP virtual router or HSRP group) on one interface.
+type RedundancyGroup struct {
+	DeviceID  string
+	Protocol  string
+	GroupID   string
+	VirtualIP string
+	State     string
+	Role      string
+	Priority  string
+	MasterIP  string
+	IfIndex   string
+	IfName    string
+}
+
 type BGPPeer struct {
 	DeviceID              string
 	RoutingInstance       string
@@ -86,6 +116,34 @@ type OSPFNeighborDetailRow struct {
 	Source            string
 }
 
+type ISISAdjacencyDetailRow struct {
+	RemoteActorHandle ActorHandle
+	LocalSystemID     string
+	NeighborSystemID  string
+	State             string
+	Level             string
+	NeighborType      string
+	IfIndex           string
+	IfName            string
+	Source            string
+}
+
+// RedundancyGroupDetailRow is attached to both the member device and the
+// virtual router actor; RemoteActorHandle points to the other side.
+type RedundancyGroupDetailRow struct {
+	RemoteActorHandle ActorHandle
+	Protocol          string
+	GroupID           string
+	VirtualIP         string
+	State             string
+	Role              string
+	Priority          string
+	MasterIP          string
+	IfIndex           string
+	IfName            string
+	Source            string
+}
+
 type BGPPeerDetailRow struct {
 	RemoteActorHandle     ActorHandle
 	RoutingInstance       string
@@ -10,20 +10,24 @@ import (
 )
 
 type Stats struct {
-	L2          topologyengine.ProjectionStats
-	HasL2       bool
-	Shape       ShapeStats
-	HasShape    bool
-	Focus       FocusStats
-	HasFocus    bool
-	L3          L3EnrichmentStats
-	HasL3       bool
-	OSPF        OSPFEnrichmentStats
-	HasOSPF     bool
-	BGP         BGPEnrichmentStats
-	HasBGP      bool
-	Recomputed  RecomputedStats
-	HasComputed bool
+	L2            topologyengine.ProjectionStats
+	HasL2         bool
+	Shape         ShapeStats
+	HasShape      bool
+	Focus         FocusStats
+	HasFocus      bool
+	L3            L3EnrichmentStats
+	HasL3         bool
+	OSPF          OSPFEnrichmentStats
+	HasOSPF       bool
+	ISIS          ISISEnrichmentStats
+	HasISIS       bool
+	Redundancy    RedundancyEnrichmentStats
+	HasRedundancy bool
+	BGP           BGPEnrichmentStats
+	HasBGP        bool
+	Recomputed    RecomputedStats
+	HasComputed   bool
 }
 
 type ShapeStats struct {
@@ -48,13 +52,15 @@ type FocusDepth struct {
 }
 
 type RecomputedStats struct {
-	ActorsTotal                    int
-	LinksTotal                     int
-	LinksProbable                  int
-	L3SubnetVisibleLinks           int
-	L3SubnetMembershipVisibleLinks int
-	OSPFAdjacencyVisibleLinks      int
-	BGPAdjacencyVisibleLinks       int
+	ActorsTotal                      int
+	LinksTotal                       int
+	LinksProbable                    int
+	L3SubnetVisibleLinks             int
+	L3SubnetMembershipVisibleLinks   int
+	OSPFAdjacencyVisibleLinks        int
+	ISISAdjacencyVisibleLinks        int
+	RedundancyMembershipVisibleLinks int
+	BGPAdjacencyVisibleLinks         int
 }
 
 type L3EnrichmentStats struct {
@@ -97,6 +103,28 @@ type OSPFEnrichmentStats struct {
 	SuppressedDuplicateLink      int
 }
 
+type ISISEnrichmentStats struct {
+	ObservedRows                 int
+	EmittedLinks                 int
+	AttachedAdjacencyRows        int
+	SuppressedNonUpState         int
+	SuppressedUnresolvedLocal    int
+	SuppressedUnresolvedNeighbor int
+	SuppressedSelfActor          int
+	SuppressedDuplicateLink      int
+}
+
+type RedundancyEnrichmentStats struct {
+	ObservedRows              int
+	EmittedVirtualRouters     int
+	EmittedMembershipLinks    int
+	AttachedGroupRows         int
+	SuppressedNoVirtualIP     int
+	SuppressedUnresolvedLocal int
+	SuppressedNoProducerScope int
+	SuppressedDuplicateLink   int
+}
+
 type BGPEnrichmentStats struct {
 	ObservedRows                 int
 	EmittedLinks                 int
@@ -128,6 +156,8 @@ func RecomputeLinkStats(data *Data) {
 	data.Stats.HasComputed = true
 	RecomputeL3VisibleLinkStats(data)
 	RecomputeOSPFVisibleLinkStats(data)
+	RecomputeISISVisibleLinkStats(data)
+	RecomputeRedundancyVisibleLinkStats(data)
 	RecomputeBGPVisibleLinkStats(data)
 }
 
@@ -162,6 +192,30 @@ func RecomputeOSPFVisibleLinkStats(data *Data) {
 	data.Stats.Recomputed.OSPFAdjacencyVisibleLinks = count
 }
 
+func RecomputeISISVisibleLinkStats(data *Data) {
+	if data == nil || !data.Stats.HasISIS {
+		return
+	}
+	data.Stats.Recomputed.ISISAdjacencyVisibleLinks = countLinksOfType(data.Links, ISISAdjacencyLinkType)
+}
+
+func RecomputeRedundancyVisibleLinkStats(data *Data) {
+	if data == nil || !data.Stats.HasRedundancy {
+		return
+	}
+	data.Stats.Recomputed.RedundancyMembershipVisibleLinks = countLinksOfType(data.Links, RedundancyMembershipLinkType)
+}
+
+func countLinksOfType(links []Link, linkType string) int {
+	count := 0
+	for _, link := range links {
+		if strings.EqualFold(strings.TrimSpace(topologyutil.FirstNonEmptyString(link.LinkType, link.Protocol)), linkType) {
+			count++
+		}
+	}
+	return count
+}
+
 func RecomputeBGPVisibleLinkStats(data *Data) {
 	if data == nil || !data.Stats.HasBGP {
 		return
@@ -12,11 +12,14 @@ import (
 const SchemaVersion = "2.0"
 
 const (
-	L3SubnetSegmentActorType   = "l3_subnet_segment"
-	L3SubnetLinkType           = "l3_subnet"
-	L3SubnetMembershipLinkType = "l3_subnet_membership"
-	OSPFAdjacencyLinkType      = "ospf_adjacency"
-	BGPAdjacencyLinkType       = "bgp_adjacency"
+	L3SubnetSegmentActorType     = "l3_subnet_segment"
+	VirtualRouterActorType       = "virtual_router"
+	L3SubnetLinkType             = "l3_subnet"
+	L3SubnetMembershipLinkType   = "l3_subnet_membership"
+	OSPFAdjacencyLinkType        = "ospf_adjacency"
+	ISISAdjacencyLinkType        = "isis_adjacency"
+	RedundancyMembershipLinkType = "redundancy_membership"
+	BGPAdjacencyLinkType         = "bgp_adjacency"
 )
 
 const (
@@ -50,6 +53,8 @@ type LinkDetail struct {
 	L3Subnet           *L3SubnetLinkDetail
 	L3SubnetMembership *L3SubnetMembershipLinkDetail
 	OSPF               *OSPFAdjacencyLinkDetail
+	ISIS               *ISISAdjacencyLinkDetail
+	Redundancy         *RedundancyMembershipLinkDetail
 	BGP                *BGPAdjacencyLinkDetail
 }
 
@@ -92,6 +97,23 @@ type OSPFAdjacencyLinkDetail struct {
 	Prefix           int
 }
 
+type ISISAdjacencyLinkDetail struct {
+	Source           string
+	LocalSystemID    string
+	NeighborSystemID string
+	Level            string
+}
+
+type RedundancyMembershipLinkDetail struct {
+	Source    string
+	Protocol  string
+	GroupID   string
+	VirtualIP string
+	State     string
+	Role      string
+	Priority  string
+}
+
 type BGPAdjacencyLinkDetail struct {
 	Source          string
 	RoutingInstance string
@@ -117,10 +139,12 @@ type Actor struct {
 }
 
 type ActorDetail struct {
-	L2   topologyengine.ProjectionActorDetail
-	SNMP SNMPActorDetail
-	OSPF []OSPFNeighborDetailRow
-	BGP  []BGPPeerDetailRow
+	L2         topologyengine.ProjectionActorDetail
+	SNMP       SNMPActorDetail
+	OSPF       []OSPFNeighborDetailRow
+	ISIS       []ISISAdjacencyDetailRow
+	Redundancy []RedundancyGroupDetailRow
+	BGP        []BGPPeerDetailRow
 }
 
 type SNMPActorDetail struct {
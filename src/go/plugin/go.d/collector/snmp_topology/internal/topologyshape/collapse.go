@@ -181,6 +181,8 @@ func mergeTopologyActorDetail(dst, src topologymodel.ActorDetail) topologymodel.
 	dst.L2 = mergeTopologyProjectionActorDetail(dst.L2, src.L2)
 	dst.SNMP = mergeTopologySNMPActorDetail(dst.SNMP, src.SNMP)
 	dst.OSPF = append(dst.OSPF, src.OSPF...)
+	dst.ISIS = append(dst.ISIS, src.ISIS...)
+	dst.Redundancy = append(dst.Redundancy, src.Redundancy...)
 	dst.BGP = append(dst.BGP, src.BGP...)
 	return dst
 }
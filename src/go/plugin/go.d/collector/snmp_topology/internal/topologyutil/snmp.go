@@ -279,3 +279,47 @@ func ParseIndex(value string) int {
 	}
 	return v
 }
+
+// NormalizeISISSystemID renders a 6-byte IS-IS system ID in the dotted
+// notation used by router CLIs (e.g. 1921.6800.1001).
+func NormalizeISISSystemID(value string) string {
+	id := NormalizeHexIdentifier(value)
+	if len(id) != 12 {
+		return id
+	}
+	return id[0:4] + "." + id[4:8] + "." + id[8:12]
+}
+
+func NormalizeISISAdjacencyState(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ""
+	}
+	switch strings.ToLower(value) {
+	case "1", "down":
+		return "down"
+	case "2", "initializing", "init":
+		return "initializing"
+	case "3", "up":
+		return "up"
+	case "4", "failed":
+		return "failed"
+	default:
+		return value
+	}
+}
+
+// RedundancyGroupRole maps a VRRP or HSRP group state to the role of the
+// router in the group: "master" forwards traffic for the virtual IP, "backup"
+// is ready to take over. Other states are returned as is.
+func RedundancyGroupRole(state string) string {
+	state = strings.TrimSpace(state)
+	switch strings.ToLower(state) {
+	case "master", "active":
+		return "master"
+	case "backup", "standby":
+		return "backup"
+	default:
+		return strings.ToLower(state)
+	}
+}
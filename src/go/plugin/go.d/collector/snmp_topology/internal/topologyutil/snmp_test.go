@@ -104,3 +104,43 @@ func TestNormalizeOSPFNeighborState(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeISISSystemID(t *testing.T) {
+	tests := map[string]struct {
+		in   string
+		want string
+	}{
+		"plain hex":      {in: "192168001001", want: "1921.6800.1001"},
+		"prefixed hex":   {in: "0x192168001001", want: "1921.6800.1001"},
+		"separated hex":  {in: "19:21:68:00:10:01", want: "1921.6800.1001"},
+		"already dotted": {in: "1921.6800.1001", want: "1921.6800.1001"},
+		"empty":          {in: " ", want: ""},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, NormalizeISISSystemID(tc.in))
+		})
+	}
+}
+
+func TestRedundancyGroupRole(t *testing.T) {
+	tests := map[string]struct {
+		in   string
+		want string
+	}{
+		"vrrp master":   {in: "master", want: "master"},
+		"vrrp backup":   {in: "backup", want: "backup"},
+		"hsrp active":   {in: "active", want: "master"},
+		"hsrp standby":  {in: "standby", want: "backup"},
+		"hsrp listen":   {in: "listen", want: "listen"},
+		"vrrp init":     {in: " Initialize ", want: "initialize"},
+		"missing state": {in: "", want: ""},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, RedundancyGroupRole(tc.in))
+		})
+	}
+}
@@ -69,6 +69,12 @@ func buildSNMPTopologyV1ActorDetails(
 		case "ospf_neighbors":
 			tableID = "actor_ospf_neighbors"
 			table = buildSNMPTopologyV1OSPFNeighborsTable(rows, actorIndex, stringsDict)
+		case "isis_adjacencies":
+			tableID = "actor_isis_adjacencies"
+			table = buildSNMPTopologyV1ISISAdjacenciesTable(rows, actorIndex, stringsDict)
+		case "redundancy_groups":
+			tableID = "actor_redundancy_groups"
+			table = buildSNMPTopologyV1RedundancyGroupsTable(rows, actorIndex, stringsDict)
 		case "bgp_peers":
 			tableID = "actor_bgp_peers"
 			table = buildSNMPTopologyV1BGPPeersTable(rows, actorIndex, stringsDict)
@@ -84,6 +90,10 @@ func buildSNMPTopologyV1ActorDetails(
 			tableTypes[tableID] = snmpTopologyV1ActorPortsTableType()
 		case "actor_ospf_neighbors":
 			tableTypes[tableID] = snmpTopologyV1OSPFNeighborsTableType()
+		case "actor_isis_adjacencies":
+			tableTypes[tableID] = snmpTopologyV1ISISAdjacenciesTableType()
+		case "actor_redundancy_groups":
+			tableTypes[tableID] = snmpTopologyV1RedundancyGroupsTableType()
 		case "actor_bgp_peers":
 			tableTypes[tableID] = snmpTopologyV1BGPPeersTableType()
 		}
@@ -148,6 +148,20 @@ func collectSNMPTopologyV1ActorTableRows(actors []topologymodel.Actor) map[strin
 				values:            snmpTopologyV1OSPFNeighborValues(row),
 			})
 		}
+		for _, row := range actor.Detail.ISIS {
+			tables["isis_adjacencies"] = append(tables["isis_adjacencies"], topologyV1DynamicRow{
+				actorRef:          actorIndex,
+				remoteActorHandle: row.RemoteActorHandle,
+				values:            snmpTopologyV1ISISAdjacencyValues(row),
+			})
+		}
+		for _, row := range actor.Detail.Redundancy {
+			tables["redundancy_groups"] = append(tables["redundancy_groups"], topologyV1DynamicRow{
+				actorRef:          actorIndex,
+				remoteActorHandle: row.RemoteActorHandle,
+				values:            snmpTopologyV1RedundancyGroupValues(row),
+			})
+		}
 		for _, row := range actor.Detail.BGP {
 			tables["bgp_peers"] = append(tables["bgp_peers"], topologyV1DynamicRow{
 				actorRef:          actorIndex,
@@ -217,6 +217,8 @@ func snmpTopologyV1ActorType(actorType string) string {
 		return snmpTopologyV1ActorSegment
 	case snmpTopologyV1ActorL3SubnetSegment:
 		return snmpTopologyV1ActorL3SubnetSegment
+	case snmpTopologyV1ActorVirtualRouter:
+		return snmpTopologyV1ActorVirtualRouter
 	default:
 		return "custom"
 	}
@@ -228,7 +230,7 @@ func snmpTopologyV1DisplayName(actor topologymodel.Actor) string {
 
 func snmpTopologyV1ActorLayer(actor topologymodel.Actor) string {
 	switch snmpTopologyV1ActorType(actor.ActorType) {
-	case snmpTopologyV1ActorEndpoint, snmpTopologyV1ActorSegment, snmpTopologyV1ActorL3SubnetSegment:
+	case snmpTopologyV1ActorEndpoint, snmpTopologyV1ActorSegment, snmpTopologyV1ActorL3SubnetSegment, snmpTopologyV1ActorVirtualRouter:
 		return "network"
 	default:
 		if topologyengine.IsDeviceActorType(actor.ActorType) {
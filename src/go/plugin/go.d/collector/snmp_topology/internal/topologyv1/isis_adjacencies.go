@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package topologyv1
+
+import (
+	topologyapi "github.com/netdata/netdata/go/plugins/pkg/topology/v1"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_topology/internal/topologymodel"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_topology/internal/topologyutil"
+)
+
+func buildSNMPTopologyV1ISISAdjacenciesTable(
+	rows []topologyV1DynamicRow,
+	actorIndex topologyV1ActorIndex,
+	stringsDict *topologyapi.StringDictionary,
+) topologyapi.Table {
+	actorRefs := make([]any, len(rows))
+	remoteActors := make([]any, len(rows))
+	localSystemIDs := make([]any, len(rows))
+	neighborSystemIDs := make([]any, len(rows))
+	states := make([]any, len(rows))
+	levels := make([]any, len(rows))
+	neighborTypes := make([]any, len(rows))
+	ifIndexes := make([]any, len(rows))
+	ifNames := make([]any, len(rows))
+	sources := make([]any, len(rows))
+
+	for i, row := range rows {
+		actorRefs[i] = row.actorRef
+		remoteActors[i] = nullableActorRef(actorIndex, row.remoteActorHandle)
+		localSystemIDs[i] = nullableStringRef(stringsDict, topologyutil.NormalizeISISSystemID(topologyV1ScalarLabelValue(row.values["local_system_id"])))
+		neighborSystemIDs[i] = nullableStringRef(stringsDict, topologyutil.NormalizeISISSystemID(topologyV1ScalarLabelValue(row.values["neighbor_system_id"])))
+		states[i] = nullableStringRef(stringsDict, topologyutil.NormalizeISISAdjacencyState(topologyV1ScalarLabelValue(row.values["state"])))
+		levels[i] = nullableStringRef(stringsDict, topologyV1ScalarLabelValue(row.values["level"]))
+		neighborTypes[i] = nullableStringRef(stringsDict, topologyV1ScalarLabelValue(row.values["neighbor_type"]))
+		ifIndexes[i] = nullableStringRef(stringsDict, topologyV1ScalarLabelValue(row.values["if_index"]))
+		ifNames[i] = nullableStringRef(stringsDict, topologyV1ScalarLabelValue(row.values["if_name"]))
+		sources[i] = nullableStringRef(stringsDict, topologyutil.FirstNonEmptyString(topologyV1ScalarLabelValue(row.values["source"]), "isis_mib"))
+	}
+
+	return topologyapi.MustTable(len(rows), snmpTopologyV1ISISAdjacenciesColumns(), []topologyapi.ColumnEncoding{
+		topologyapi.Values(actorRefs...),
+		topologyapi.Values(remoteActors...),
+		topologyapi.Values(localSystemIDs...),
+		topologyapi.Values(neighborSystemIDs...),
+		topologyapi.Values(states...),
+		topologyapi.Values(levels...),
+		topologyapi.Values(neighborTypes...),
+		topologyapi.Values(ifIndexes...),
+		topologyapi.Values(ifNames...),
+		topologyapi.Values(sources...),
+	})
+}
+
+func snmpTopologyV1ISISAdjacencyValues(row topologymodel.ISISAdjacencyDetailRow) map[string]any {
+	return pruneNilAttributes(map[string]any{
+		"local_system_id":    row.LocalSystemID,
+		"neighbor_system_id": row.NeighborSystemID,
+		"state":              row.State,
+		"level":              row.Level,
+		"neighbor_type":      row.NeighborType,
+		"if_index":           row.IfIndex,
+		"if_name":            row.IfName,
+		"source":             topologyutil.FirstNonEmptyString(row.Source, "isis_mib"),
+	})
+}
@@ -110,6 +110,20 @@ func buildSNMPTopologyV1Links(
 			evidenceRows.remoteASes = append(evidenceRows.remoteASes, nullableStringRef(stringsDict, topologymodel.LinkBGPRemoteAS(link)))
 			evidenceRows.sources = append(evidenceRows.sources, nullableStringRef(stringsDict, topologymodel.LinkBGPSource(link)))
 		}
+		if linkType == snmpTopologyV1LinkISIS {
+			evidenceRows.srcSystemIDs = append(evidenceRows.srcSystemIDs, nullableStringRef(stringsDict, topologymodel.LinkISISLocalSystemID(link)))
+			evidenceRows.dstSystemIDs = append(evidenceRows.dstSystemIDs, nullableStringRef(stringsDict, topologymodel.LinkISISNeighborSystemID(link)))
+			evidenceRows.levels = append(evidenceRows.levels, nullableStringRef(stringsDict, topologymodel.LinkISISLevel(link)))
+			evidenceRows.sources = append(evidenceRows.sources, nullableStringRef(stringsDict, topologymodel.LinkISISSource(link)))
+		}
+		if linkType == snmpTopologyV1LinkRedundancy {
+			evidenceRows.groupProtocols = append(evidenceRows.groupProtocols, nullableStringRef(stringsDict, topologymodel.LinkRedundancyProtocol(link)))
+			evidenceRows.groupIDs = append(evidenceRows.groupIDs, nullableStringRef(stringsDict, topologymodel.LinkRedundancyGroupID(link)))
+			evidenceRows.virtualIPs = append(evidenceRows.virtualIPs, nullableStringRef(stringsDict, topologymodel.LinkRedundancyVirtualIP(link)))
+			evidenceRows.roles = append(evidenceRows.roles, nullableStringRef(stringsDict, topologymodel.LinkRedundancyRole(link)))
+			evidenceRows.priorities = append(evidenceRows.priorities, nullableStringRef(stringsDict, topologymodel.LinkRedundancyPriority(link)))
+			evidenceRows.sources = append(evidenceRows.sources, nullableStringRef(stringsDict, topologymodel.LinkRedundancySource(link)))
+		}
 	}
 
 	linkTable := topologyapi.MustTable(len(links),
@@ -244,6 +258,14 @@ type snmpTopologyV1EvidenceRows struct {
 	remoteASes       []any
 	localIPs         []any
 	neighborIPs      []any
+	srcSystemIDs     []any
+	dstSystemIDs     []any
+	levels           []any
+	groupProtocols   []any
+	groupIDs         []any
+	virtualIPs       []any
+	roles            []any
+	priorities       []any
 }
 
 func (rows *snmpTopologyV1EvidenceRows) table(linkType string) topologyapi.Table {
@@ -302,6 +324,24 @@ func (rows *snmpTopologyV1EvidenceRows) columnEncodingsForType(linkType string)
 			topologyapi.Values(rows.sources...),
 		)
 	}
+	if linkType == snmpTopologyV1LinkISIS {
+		encodings = append(encodings,
+			topologyapi.Values(rows.srcSystemIDs...),
+			topologyapi.Values(rows.dstSystemIDs...),
+			topologyapi.Values(rows.levels...),
+			topologyapi.Values(rows.sources...),
+		)
+	}
+	if linkType == snmpTopologyV1LinkRedundancy {
+		encodings = append(encodings,
+			topologyapi.Values(rows.groupProtocols...),
+			topologyapi.Values(rows.groupIDs...),
+			topologyapi.Values(rows.virtualIPs...),
+			topologyapi.Values(rows.roles...),
+			topologyapi.Values(rows.priorities...),
+			topologyapi.Values(rows.sources...),
+		)
+	}
 	if linkType == snmpTopologyV1LinkL3SubnetMembership {
 		encodings = append(encodings,
 			topologyapi.Values(rows.memberActors...),
@@ -349,6 +389,26 @@ func snmpTopologyV1EvidenceColumnsForType(linkType string) []topologyapi.Column
 		}
 		return snmpTopologyV1EvidenceColumnsWithExtras(columns, extras)
 	}
+	if linkType == snmpTopologyV1LinkISIS {
+		extras := []topologyapi.Column{
+			topologyapi.NewColumn("src_system_id", "string_ref", topologyapi.WithDictionary("strings"), topologyapi.WithNullable()),
+			topologyapi.NewColumn("dst_system_id", "string_ref", topologyapi.WithDictionary("strings"), topologyapi.WithNullable()),
+			topologyapi.NewColumn("level", "string_ref", topologyapi.WithDictionary("strings"), topologyapi.WithNullable()),
+			topologyapi.NewColumn("source", "string_ref", topologyapi.WithDictionary("strings"), topologyapi.WithNullable()),
+		}
+		return snmpTopologyV1EvidenceColumnsWithExtras(columns, extras)
+	}
+	if linkType == snmpTopologyV1LinkRedundancy {
+		extras := []topologyapi.Column{
+			topologyapi.NewColumn("group_protocol", "string_ref", topologyapi.WithDictionary("strings"), topologyapi.WithNullable()),
+			topologyapi.NewColumn("group_id", "string_ref", topologyapi.WithDictionary("strings"), topologyapi.WithNullable()),
+			topologyapi.NewColumn("virtual_ip", "string_ref", topologyapi.WithDictionary("strings"), topologyapi.WithNullable()),
+			topologyapi.NewColumn("role", "string_ref", topologyapi.WithDictionary("strings"), topologyapi.WithNullable()),
+			topologyapi.NewColumn("priority", "string_ref", topologyapi.WithDictionary("strings"), topologyapi.WithNullable()),
+			topologyapi.NewColumn("source", "string_ref", topologyapi.WithDictionary("strings"), topologyapi.WithNullable()),
+		}
+		return snmpTopologyV1EvidenceColumnsWithExtras(columns, extras)
+	}
 	if linkType == snmpTopologyV1LinkL3SubnetMembership {
 		extras := []topologyapi.Column{
 			topologyapi.NewColumn("member_actor", "actor_ref", topologyapi.WithRole("reference")),
@@ -527,6 +587,10 @@ func snmpTopologyV1LinkType(link topologymodel.Link) string {
 		return snmpTopologyV1LinkL3SubnetMembership
 	case snmpTopologyV1LinkOSPF:
 		return snmpTopologyV1LinkOSPF
+	case snmpTopologyV1LinkISIS:
+		return snmpTopologyV1LinkISIS
+	case snmpTopologyV1LinkRedundancy:
+		return snmpTopologyV1LinkRedundancy
 	case snmpTopologyV1LinkBGP:
 		return snmpTopologyV1LinkBGP
 	default:
@@ -536,7 +600,8 @@ func snmpTopologyV1LinkType(link topologymodel.Link) string {
 
 func snmpTopologyV1LinkIsLogicalL3(link topologymodel.Link) bool {
 	switch snmpTopologyV1LinkType(link) {
-	case snmpTopologyV1LinkL3Subnet, snmpTopologyV1LinkL3SubnetMembership, snmpTopologyV1LinkOSPF, snmpTopologyV1LinkBGP:
+	case snmpTopologyV1LinkL3Subnet, snmpTopologyV1LinkL3SubnetMembership, snmpTopologyV1LinkOSPF,
+		snmpTopologyV1LinkISIS, snmpTopologyV1LinkRedundancy, snmpTopologyV1LinkBGP:
 		return true
 	default:
 		return false
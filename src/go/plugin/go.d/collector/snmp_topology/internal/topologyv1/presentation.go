@@ -29,6 +29,7 @@ var (
 		"hostname",
 		"dns_name",
 		topologymodel.LabelOSPFRouterID,
+		topologymodel.LabelISISSystemID,
 		"capabilities",
 		"capabilities_supported",
 		"capabilities_enabled",
@@ -189,6 +190,27 @@ func snmpTopologyV1ActorTypes() map[string]topologyapi.ActorType {
 			Modal: snmpTopologyV1L3SubnetSegmentModal(),
 		},
 	}
+	types[snmpTopologyV1ActorVirtualRouter] = topologyapi.ActorType{
+		Layer:             "network",
+		Identity:          []string{"id"},
+		MergeIdentity:     []string{"id"},
+		AggregationScopes: []string{"network"},
+		Search:            &topologyapi.ActorSearchPolicy{Enabled: new(false)},
+		Presentation: &topologyapi.ActorPresentation{
+			Label:     "Virtual router",
+			Role:      "actor",
+			Icon:      "router",
+			ColorSlot: "warning",
+			Size:      &topologyapi.ActorSizePresentation{Mode: "fixed", Scale: "compact"},
+			LabelPolicy: &topologyapi.LabelPolicy{
+				Columns:   []string{"display_name"},
+				Fallback:  "type_label",
+				MaxLength: 80,
+				Array:     "reject",
+			},
+			Modal: snmpTopologyV1VirtualRouterModal(),
+		},
+	}
 	types["custom"] = topologyapi.ActorType{
 		Layer:             "custom",
 		Identity:          []string{"id"},
@@ -236,7 +258,9 @@ func snmpTopologyV1DeviceModal() *topologyapi.ModalPresentation {
 			snmpTopologyV1L3SubnetSection(3),
 			snmpTopologyV1L3SubnetMembershipSection(4),
 			snmpTopologyV1OSPFNeighborsSection(5),
-			snmpTopologyV1BGPPeersSection(6),
+			snmpTopologyV1ISISAdjacenciesSection(6),
+			snmpTopologyV1RedundancyGroupsSection(7),
+			snmpTopologyV1BGPPeersSection(8),
 		},
 	}
 }
@@ -258,6 +282,15 @@ func snmpTopologyV1L3SubnetSegmentModal() *topologyapi.ModalPresentation {
 	}
 }
 
+func snmpTopologyV1VirtualRouterModal() *topologyapi.ModalPresentation {
+	return &topologyapi.ModalPresentation{
+		MiniTopology: &topologyapi.ModalMiniTopologyPresentation{Depth: 1},
+		Sections: []topologyapi.ModalSection{
+			snmpTopologyV1RedundancyGroupsSection(1),
+		},
+	}
+}
+
 func snmpTopologyV1DeviceModalLabels() *topologyapi.ModalLabelsPresentation {
 	return &topologyapi.ModalLabelsPresentation{
 		Table: "actor_labels",
@@ -268,6 +301,7 @@ func snmpTopologyV1DeviceModalLabels() *topologyapi.ModalLabelsPresentation {
 				{Key: "vendor", Label: "Vendor", MaxValues: 1},
 				{Key: "model", Label: "Model", MaxValues: 1},
 				{Key: topologymodel.LabelOSPFRouterID, Label: "OSPF Router ID", MaxValues: 1},
+				{Key: topologymodel.LabelISISSystemID, Label: "IS-IS System ID", MaxValues: 1},
 				{Key: "ports_total", Label: "Ports", MaxValues: 1},
 				{Key: "lldp_neighbor_count", Label: "LLDP", MaxValues: 1},
 				{Key: "cdp_neighbor_count", Label: "CDP", MaxValues: 1},
@@ -476,6 +510,44 @@ func snmpTopologyV1OSPFNeighborsSection(order int) topologyapi.ModalSection {
 	}
 }
 
+func snmpTopologyV1ISISAdjacenciesSection(order int) topologyapi.ModalSection {
+	return topologyapi.ModalSection{
+		ID:    "isis_adjacencies",
+		Label: "IS-IS Adjacencies",
+		Order: order,
+		Source: topologyapi.ModalSource{
+			Kind:  "actor_table",
+			Table: "actor_isis_adjacencies",
+		},
+		OwnerFilter: &topologyapi.ModalOwnerFilter{
+			Mode:        "actor_column",
+			ActorColumn: "actor",
+		},
+		Columns:    snmpTopologyV1ISISAdjacencyModalColumns(),
+		Sort:       &topologyapi.ModalSort{Column: "neighbor_system_id", Direction: "asc"},
+		EmptyLabel: "No IS-IS adjacencies",
+	}
+}
+
+func snmpTopologyV1RedundancyGroupsSection(order int) topologyapi.ModalSection {
+	return topologyapi.ModalSection{
+		ID:    "redundancy_groups",
+		Label: "Redundancy Groups",
+		Order: order,
+		Source: topologyapi.ModalSource{
+			Kind:  "actor_table",
+			Table: "actor_redundancy_groups",
+		},
+		OwnerFilter: &topologyapi.ModalOwnerFilter{
+			Mode:        "actor_column",
+			ActorColumn: "actor",
+		},
+		Columns:    snmpTopologyV1RedundancyGroupModalColumns(),
+		Sort:       &topologyapi.ModalSort{Column: "group_id", Direction: "asc"},
+		EmptyLabel: "No redundancy groups",
+	}
+}
+
 func snmpTopologyV1BGPPeersSection(order int) topologyapi.ModalSection {
 	return topologyapi.ModalSection{
 		ID:    "bgp_peers",
@@ -594,6 +666,8 @@ func snmpTopologyV1LinkTypeSpecs() []snmpTopologyV1LinkTypeSpec {
 		{id: snmpTopologyV1LinkL3Subnet, label: "L3 subnet", colorSlot: "info", lineStyle: "dashed", width: "normal", semanticRole: "normal"},
 		{id: snmpTopologyV1LinkL3SubnetMembership, label: "L3 subnet membership", colorSlot: "info", lineStyle: "dashed", width: "normal", semanticRole: "normal"},
 		{id: snmpTopologyV1LinkOSPF, label: "OSPF adjacency", colorSlot: "purple", lineStyle: "dashed", width: "normal", semanticRole: "control"},
+		{id: snmpTopologyV1LinkISIS, label: "IS-IS adjacency", colorSlot: "teal", lineStyle: "dashed", width: "normal", semanticRole: "control"},
+		{id: snmpTopologyV1LinkRedundancy, label: "Redundancy group membership", colorSlot: "warning", lineStyle: "dotted", width: "normal", semanticRole: "control"},
 		{id: snmpTopologyV1LinkBGP, label: "BGP adjacency", colorSlot: "accent", lineStyle: "dashed", width: "normal", semanticRole: "control"},
 		{id: snmpTopologyV1LinkSNMP, label: "SNMP", colorSlot: "primary", lineStyle: "solid", width: "normal", semanticRole: "normal"},
 		{id: snmpTopologyV1LinkProbable, label: "Probable", colorSlot: "dim", lineStyle: "solid", width: "normal", semanticRole: "normal"},
@@ -672,6 +746,21 @@ func snmpTopologyV1EvidenceMatchColumnsForType(linkType string) []string {
 			"dst_ip",
 		}
 	}
+	if linkType == snmpTopologyV1LinkISIS {
+		return []string{
+			"src_actor",
+			"dst_actor",
+			"level",
+		}
+	}
+	if linkType == snmpTopologyV1LinkRedundancy {
+		return []string{
+			"src_actor",
+			"dst_actor",
+			"group_id",
+			"virtual_ip",
+		}
+	}
 	if linkType == snmpTopologyV1LinkBGP {
 		return []string{
 			"src_actor",
@@ -716,6 +805,7 @@ func snmpTopologyV1Presentation() *topologyapi.Presentation {
 				{Type: "endpoint", Label: "Inferred endpoint"},
 				{Type: "segment", Label: "Network segment"},
 				{Type: snmpTopologyV1ActorL3SubnetSegment, Label: "L3 subnet"},
+				{Type: snmpTopologyV1ActorVirtualRouter, Label: "Virtual router"},
 			},
 			Links: []topologyapi.LegendEntry{
 				{Type: snmpTopologyV1LinkLLDP, Label: "LLDP"},
@@ -725,6 +815,8 @@ func snmpTopologyV1Presentation() *topologyapi.Presentation {
 				{Type: snmpTopologyV1LinkL3Subnet, Label: "L3 subnet"},
 				{Type: snmpTopologyV1LinkL3SubnetMembership, Label: "L3 subnet membership"},
 				{Type: snmpTopologyV1LinkOSPF, Label: "OSPF adjacency"},
+				{Type: snmpTopologyV1LinkISIS, Label: "IS-IS adjacency"},
+				{Type: snmpTopologyV1LinkRedundancy, Label: "Redundancy group"},
 				{Type: snmpTopologyV1LinkBGP, Label: "BGP adjacency"},
 				{Type: snmpTopologyV1LinkProbable, Label: "Probable"},
 			},
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package topologyv1
+
+import (
+	topologyapi "github.com/netdata/netdata/go/plugins/pkg/topology/v1"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_topology/internal/topologymodel"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_topology/internal/topologyutil"
+)
+
+func buildSNMPTopologyV1RedundancyGroupsTable(
+	rows []topologyV1DynamicRow,
+	actorIndex topologyV1ActorIndex,
+	stringsDict *topologyapi.StringDictionary,
+) topologyapi.Table {
+	actorRefs := make([]any, len(rows))
+	remoteActors := make([]any, len(rows))
+	protocols := make([]any, len(rows))
+	groupIDs := make([]any, len(rows))
+	virtualIPs := make([]any, len(rows))
+	states := make([]any, len(rows))
+	roles := make([]any, len(rows))
+	priorities := make([]any, len(rows))
+	masterIPs := make([]any, len(rows))
+	ifIndexes := make([]any, len(rows))
+	ifNames := make([]any, len(rows))
+	sources := make([]any, len(rows))
+
+	for i, row := range rows {
+		actorRefs[i] = row.actorRef
+		remoteActors[i] = nullableActorRef(actorIndex, row.remoteActorHandle)
+		protocols[i] = nullableStringRef(stringsDict, topologyV1ScalarLabelValue(row.values["protocol"]))
+		groupIDs[i] = nullableStringRef(stringsDict, topologyV1ScalarLabelValue(row.values["group_id"]))
+		virtualIPs[i] = nullableStringRef(stringsDict, topologyutil.NormalizeNonUnspecifiedIPAddress(topologyV1ScalarLabelValue(row.values["virtual_ip"])))
+		states[i] = nullableStringRef(stringsDict, topologyV1ScalarLabelValue(row.values["state"]))
+		roles[i] = nullableStringRef(stringsDict, topologyV1ScalarLabelValue(row.values["role"]))
+		priorities[i] = nullableStringRef(stringsDict, topologyV1ScalarLabelValue(row.values["priority"]))
+		masterIPs[i] = nullableStringRef(stringsDict, topologyutil.NormalizeNonUnspecifiedIPAddress(topologyV1ScalarLabelValue(row.values["master_ip"])))
+		ifIndexes[i] = nullableStringRef(stringsDict, topologyV1ScalarLabelValue(row.values["if_index"]))
+		ifNames[i] = nullableStringRef(stringsDict, topologyV1ScalarLabelValue(row.values["if_name"]))
+		sources[i] = nullableStringRef(stringsDict, topologyV1ScalarLabelValue(row.values["source"]))
+	}
+
+	return topologyapi.MustTable(len(rows), snmpTopologyV1RedundancyGroupsColumns(), []topologyapi.ColumnEncoding{
+		topologyapi.Values(actorRefs...),
+		topologyapi.Values(remoteActors...),
+		topologyapi.Values(protocols...),
+		topologyapi.Values(groupIDs...),
+		topologyapi.Values(virtualIPs...),
+		topologyapi.Values(states...),
+		topologyapi.Values(roles...),
+		topologyapi.Values(priorities...),
+		topologyapi.Values(masterIPs...),
+		topologyapi.Values(ifIndexes...),
+		topologyapi.Values(ifNames...),
+		topologyapi.Values(sources...),
+	})
+}
+
+func snmpTopologyV1RedundancyGroupValues(row topologymodel.RedundancyGroupDetailRow) map[string]any {
+	return pruneNilAttributes(map[string]any{
+		"protocol":   row.Protocol,
+		"group_id":   row.GroupID,
+		"virtual_ip": row.VirtualIP,
+		"state":      row.State,
+		"role":       row.Role,
+		"priority":   row.Priority,
+		"master_ip":  row.MasterIP,
+		"if_index":   row.IfIndex,
+		"if_name":    row.IfName,
+		"source":     row.Source,
+	})
+}
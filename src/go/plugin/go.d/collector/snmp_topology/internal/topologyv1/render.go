@@ -18,6 +18,7 @@ const (
 	snmpTopologyV1ActorEndpoint        = "endpoint"
 	snmpTopologyV1ActorSegment         = "segment"
 	snmpTopologyV1ActorL3SubnetSegment = topologymodel.L3SubnetSegmentActorType
+	snmpTopologyV1ActorVirtualRouter   = topologymodel.VirtualRouterActorType
 
 	snmpTopologyV1LinkObservation        = "l2_observation"
 	snmpTopologyV1LinkLLDP               = "lldp"
@@ -31,6 +32,8 @@ const (
 	snmpTopologyV1LinkL3Subnet           = topologymodel.L3SubnetLinkType
 	snmpTopologyV1LinkL3SubnetMembership = topologymodel.L3SubnetMembershipLinkType
 	snmpTopologyV1LinkOSPF               = topologymodel.OSPFAdjacencyLinkType
+	snmpTopologyV1LinkISIS               = topologymodel.ISISAdjacencyLinkType
+	snmpTopologyV1LinkRedundancy         = topologymodel.RedundancyMembershipLinkType
 	snmpTopologyV1LinkBGP                = topologymodel.BGPAdjacencyLinkType
 )
 
@@ -64,6 +67,12 @@ func Render(data topologymodel.Data) (topologyapi.Data, error) {
 	if _, ok := tableTypes["actor_ospf_neighbors"]; !ok {
 		tableTypes["actor_ospf_neighbors"] = snmpTopologyV1OSPFNeighborsTableType()
 	}
+	if _, ok := tableTypes["actor_isis_adjacencies"]; !ok {
+		tableTypes["actor_isis_adjacencies"] = snmpTopologyV1ISISAdjacenciesTableType()
+	}
+	if _, ok := tableTypes["actor_redundancy_groups"]; !ok {
+		tableTypes["actor_redundancy_groups"] = snmpTopologyV1RedundancyGroupsTableType()
+	}
 	if _, ok := tableTypes["actor_bgp_peers"]; !ok {
 		tableTypes["actor_bgp_peers"] = snmpTopologyV1BGPPeersTableType()
 	}
@@ -125,6 +134,9 @@ func Render(data topologymodel.Data) (topologyapi.Data, error) {
 				"l3_subnet",
 				"l3_subnet_membership",
 				"ospf",
+				"isis",
+				"vrrp",
+				"hsrp",
 				"bgp",
 			},
 		},
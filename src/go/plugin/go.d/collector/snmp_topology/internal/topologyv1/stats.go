@@ -8,7 +8,7 @@ import (
 )
 
 func topologyStatsToV1(stats topologymodel.Stats) map[string]any {
-	if !stats.HasL2 && !stats.HasShape && !stats.HasFocus && !stats.HasL3 && !stats.HasOSPF && !stats.HasBGP && !stats.HasComputed {
+	if !stats.HasL2 && !stats.HasShape && !stats.HasFocus && !stats.HasL3 && !stats.HasOSPF && !stats.HasISIS && !stats.HasRedundancy && !stats.HasBGP && !stats.HasComputed {
 		return nil
 	}
 
@@ -28,6 +28,12 @@ func topologyStatsToV1(stats topologymodel.Stats) map[string]any {
 	if stats.HasOSPF {
 		addTopologyOSPFStats(out, stats)
 	}
+	if stats.HasISIS {
+		addTopologyISISStats(out, stats)
+	}
+	if stats.HasRedundancy {
+		addTopologyRedundancyStats(out, stats)
+	}
 	if stats.HasBGP {
 		addTopologyBGPStats(out, stats)
 	}
@@ -166,6 +172,40 @@ func addTopologyOSPFStats(out map[string]any, stats topologymodel.Stats) {
 	}
 }
 
+func addTopologyISISStats(out map[string]any, stats topologymodel.Stats) {
+	isis := stats.ISIS
+
+	out["isis_adjacency_rows"] = isis.ObservedRows
+	out["isis_adjacency_detail_rows"] = isis.AttachedAdjacencyRows
+	out["isis_adjacency_emitted_links"] = isis.EmittedLinks
+	out["isis_adjacency_suppressed_non_up_state"] = isis.SuppressedNonUpState
+	out["isis_adjacency_suppressed_unresolved_local"] = isis.SuppressedUnresolvedLocal
+	out["isis_adjacency_suppressed_unresolved_neighbor"] = isis.SuppressedUnresolvedNeighbor
+	out["isis_adjacency_suppressed_self_actor"] = isis.SuppressedSelfActor
+	out["isis_adjacency_suppressed_duplicate_link"] = isis.SuppressedDuplicateLink
+	out["isis_adjacency_visible_links"] = isis.EmittedLinks
+	if stats.HasComputed {
+		out["isis_adjacency_visible_links"] = stats.Recomputed.ISISAdjacencyVisibleLinks
+	}
+}
+
+func addTopologyRedundancyStats(out map[string]any, stats topologymodel.Stats) {
+	redundancy := stats.Redundancy
+
+	out["redundancy_group_rows"] = redundancy.ObservedRows
+	out["redundancy_group_detail_rows"] = redundancy.AttachedGroupRows
+	out["redundancy_virtual_routers_emitted"] = redundancy.EmittedVirtualRouters
+	out["redundancy_membership_emitted_links"] = redundancy.EmittedMembershipLinks
+	out["redundancy_group_suppressed_no_virtual_ip"] = redundancy.SuppressedNoVirtualIP
+	out["redundancy_group_suppressed_unresolved_local"] = redundancy.SuppressedUnresolvedLocal
+	out["redundancy_group_suppressed_no_producer_scope"] = redundancy.SuppressedNoProducerScope
+	out["redundancy_membership_suppressed_duplicate_link"] = redundancy.SuppressedDuplicateLink
+	out["redundancy_membership_visible_links"] = redundancy.EmittedMembershipLinks
+	if stats.HasComputed {
+		out["redundancy_membership_visible_links"] = stats.Recomputed.RedundancyMembershipVisibleLinks
+	}
+}
+
 func addTopologyBGPStats(out map[string]any, stats topologymodel.Stats) {
 	bgp := stats.BGP
 
@@ -126,6 +126,34 @@ func snmpTopologyV1OSPFNeighborsTableType() topologyapi.TableType {
 	}
 }
 
+func snmpTopologyV1ISISAdjacenciesTableType() topologyapi.TableType {
+	return topologyapi.TableType{
+		Role:        "actor_detail",
+		Owner:       "actor",
+		Aggregation: "append",
+		Columns:     snmpTopologyV1ISISAdjacenciesColumns(),
+		Presentation: &topologyapi.TableTypePresentation{
+			Label:   "IS-IS Adjacencies",
+			Order:   5,
+			Columns: snmpTopologyV1ISISAdjacencyModalColumns(),
+		},
+	}
+}
+
+func snmpTopologyV1RedundancyGroupsTableType() topologyapi.TableType {
+	return topologyapi.TableType{
+		Role:        "actor_detail",
+		Owner:       "actor",
+		Aggregation: "append",
+		Columns:     snmpTopologyV1RedundancyGroupsColumns(),
+		Presentation: &topologyapi.TableTypePresentation{
+			Label:   "Redundancy Groups",
+			Order:   6,
+			Columns: snmpTopologyV1RedundancyGroupModalColumns(),
+		},
+	}
+}
+
 func snmpTopologyV1BGPPeersTableType() topologyapi.TableType {
 	return topologyapi.TableType{
 		Role:        "actor_detail",
@@ -134,7 +162,7 @@ func snmpTopologyV1BGPPeersTableType() topologyapi.TableType {
 		Columns:     snmpTopologyV1BGPPeersColumns(),
 		Presentation: &topologyapi.TableTypePresentation{
 			Label:   "BGP Peers",
-			Order:   5,
+			Order:   7,
 			Columns: snmpTopologyV1BGPPeerModalColumns(),
 		},
 	}
@@ -175,6 +203,36 @@ func snmpTopologyV1OSPFNeighborModalColumns() []topologyapi.ModalColumn {
 	}
 }
 
+func snmpTopologyV1ISISAdjacencyModalColumns() []topologyapi.ModalColumn {
+	return []topologyapi.ModalColumn{
+		modalDirectColumn("neighbor_system_id", "Neighbor System ID", "neighbor_system_id", "text"),
+		modalDirectColumn("level", "Level", "level", "badge"),
+		modalDirectColumn("state", "State", "state", "badge"),
+		modalActorRefColumn("remote_actor", "Remote Actor", "remote_actor"),
+		modalDirectColumn("if_name", "Interface", "if_name", "text"),
+		modalDirectColumnWithVisibility("local_system_id", "Local System ID", "local_system_id", "text", "expanded"),
+		modalDirectColumnWithVisibility("neighbor_type", "Neighbor Type", "neighbor_type", "badge", "expanded"),
+		modalDirectColumnWithVisibility("if_index", "IfIndex", "if_index", "text", "expanded"),
+		modalDirectColumnWithVisibility("source", "Source", "source", "badge", "expanded"),
+	}
+}
+
+func snmpTopologyV1RedundancyGroupModalColumns() []topologyapi.ModalColumn {
+	return []topologyapi.ModalColumn{
+		modalDirectColumn("protocol", "Protocol", "protocol", "badge"),
+		modalDirectColumn("group_id", "Group", "group_id", "text"),
+		modalDirectColumn("virtual_ip", "Virtual IP", "virtual_ip", "text"),
+		modalDirectColumn("role", "Role", "role", "badge"),
+		modalDirectColumn("priority", "Priority", "priority", "text"),
+		modalActorRefColumn("remote_actor", "Remote Actor", "remote_actor"),
+		modalDirectColumn("if_name", "Interface", "if_name", "text"),
+		modalDirectColumnWithVisibility("state", "State", "state", "badge", "expanded"),
+		modalDirectColumnWithVisibility("master_ip", "Master IP", "master_ip", "text", "expanded"),
+		modalDirectColumnWithVisibility("if_index", "IfIndex", "if_index", "text", "expanded"),
+		modalDirectColumnWithVisibility("source", "Source", "source", "badge", "expanded"),
+	}
+}
+
 func snmpTopologyV1ActorPortsColumns() []topologyapi.Column {
 	return []topologyapi.Column{
 		topologyapi.NewColumn("actor", "actor_ref", topologyapi.WithRole("reference")),
@@ -247,6 +305,38 @@ func snmpTopologyV1OSPFNeighborsColumns() []topologyapi.Column {
 	}
 }
 
+func snmpTopologyV1ISISAdjacenciesColumns() []topologyapi.Column {
+	return []topologyapi.Column{
+		topologyapi.NewColumn("actor", "actor_ref", topologyapi.WithRole("reference")),
+		topologyapi.NewColumn("remote_actor", "actor_ref", topologyapi.WithNullable(), topologyapi.WithRole("reference")),
+		topologyapi.NewColumn("local_system_id", "string_ref", topologyapi.WithNullable(), topologyapi.WithDictionary("strings")),
+		topologyapi.NewColumn("neighbor_system_id", "string_ref", topologyapi.WithNullable(), topologyapi.WithDictionary("strings")),
+		topologyapi.NewColumn("state", "string_ref", topologyapi.WithNullable(), topologyapi.WithDictionary("strings")),
+		topologyapi.NewColumn("level", "string_ref", topologyapi.WithNullable(), topologyapi.WithDictionary("strings")),
+		topologyapi.NewColumn("neighbor_type", "string_ref", topologyapi.WithNullable(), topologyapi.WithDictionary("strings")),
+		topologyapi.NewColumn("if_index", "string_ref", topologyapi.WithNullable(), topologyapi.WithDictionary("strings")),
+		topologyapi.NewColumn("if_name", "string_ref", topologyapi.WithNullable(), topologyapi.WithDictionary("strings")),
+		topologyapi.NewColumn("source", "string_ref", topologyapi.WithNullable(), topologyapi.WithDictionary("strings")),
+	}
+}
+
+func snmpTopologyV1RedundancyGroupsColumns() []topologyapi.Column {
+	return []topologyapi.Column{
+		topologyapi.NewColumn("actor", "actor_ref", topologyapi.WithRole("reference")),
+		topologyapi.NewColumn("remote_actor", "actor_ref", topologyapi.WithNullable(), topologyapi.WithRole("reference")),
+		topologyapi.NewColumn("protocol", "string_ref", topologyapi.WithNullable(), topologyapi.WithDictionary("strings")),
+		topologyapi.NewColumn("group_id", "string_ref", topologyapi.WithNullable(), topologyapi.WithDictionary("strings")),
+		topologyapi.NewColumn("virtual_ip", "string_ref", topologyapi.WithNullable(), topologyapi.WithDictionary("strings")),
+		topologyapi.NewColumn("state", "string_ref", topologyapi.WithNullable(), topologyapi.WithDictionary("strings")),
+		topologyapi.NewColumn("role", "string_ref", topologyapi.WithNullable(), topologyapi.WithDictionary("strings")),
+		topologyapi.NewColumn("priority", "string_ref", topologyapi.WithNullable(), topologyapi.WithDictionary("strings")),
+		topologyapi.NewColumn("master_ip", "string_ref", topologyapi.WithNullable(), topologyapi.WithDictionary("strings")),
+		topologyapi.NewColumn("if_index", "string_ref", topologyapi.WithNullable(), topologyapi.WithDictionary("strings")),
+		topologyapi.NewColumn("if_name", "string_ref", topologyapi.WithNullable(), topologyapi.WithDictionary("strings")),
+		topologyapi.NewColumn("source", "string_ref", topologyapi.WithNullable(), topologyapi.WithDictionary("strings")),
+	}
+}
+
 func snmpTopologyV1BGPPeersColumns() []topologyapi.Column {
 	return []topologyapi.Column{
 		topologyapi.NewColumn("actor", "actor_ref", topologyapi.WithRole("reference")),
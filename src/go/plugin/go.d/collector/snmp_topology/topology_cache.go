@@ -47,6 +47,8 @@ type topologyCache struct {
 	stpPorts            map[string]*stpPortEntry
 	arpEntries          map[string]*arpEntry
 	ospfNeighborsByKey  map[string]topologymodel.OSPFNeighbor
+	isisAdjByKey        map[string]topologymodel.ISISAdjacency
+	redundancyGroups    map[string]topologymodel.RedundancyGroup
 	bgpPeersByKey       map[string]topologymodel.BGPPeer
 }
 
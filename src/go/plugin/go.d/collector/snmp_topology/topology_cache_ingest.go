@@ -41,6 +41,7 @@ func (c *topologyCache) updateTopologyProfileTags(pms []*ddsnmp.ProfileMetrics)
 			c.applyLLDPLocalDeviceProfileTags(tags)
 			c.applyBridgeProfileTags(tags)
 			c.applyOSPFProfileTags(tags)
+			c.applyISISProfileTags(tags)
 		}
 	}
 }
@@ -27,6 +27,8 @@ func newTopologyCache() *topologyCache {
 		stpPorts:           make(map[string]*stpPortEntry),
 		arpEntries:         make(map[string]*arpEntry),
 		ospfNeighborsByKey: make(map[string]topologymodel.OSPFNeighbor),
+		isisAdjByKey:       make(map[string]topologymodel.ISISAdjacency),
+		redundancyGroups:   make(map[string]topologymodel.RedundancyGroup),
 		bgpPeersByKey:      make(map[string]topologymodel.BGPPeer),
 	}
 }
@@ -62,6 +64,8 @@ func (c *topologyCache) replaceWith(src *topologyCache) {
 	c.stpPorts = src.stpPorts
 	c.arpEntries = src.arpEntries
 	c.ospfNeighborsByKey = src.ospfNeighborsByKey
+	c.isisAdjByKey = src.isisAdjByKey
+	c.redundancyGroups = src.redundancyGroups
 	c.bgpPeersByKey = src.bgpPeersByKey
 }
 
@@ -57,6 +57,16 @@ func (c *topologyCache) applyOSPFProfileTags(tags map[string]string) {
 	}
 }
 
+func (c *topologyCache) applyISISProfileTags(tags map[string]string) {
+	if c == nil || len(tags) == 0 {
+		return
+	}
+	if v := topologyutil.NormalizeISISSystemID(tags[tagISISSystemID]); v != "" {
+		c.localDevice.Labels = ensureLabels(c.localDevice.Labels)
+		c.localDevice.Labels[tagISISSystemID] = v
+	}
+}
+
 func (c *topologyCache) applyAuthoritativeBridgeIdentity(mac string) {
 	mac = topologyutil.NormalizeMAC(mac)
 	if mac == "" || mac == "00:00:00:00:00:00" {
@@ -124,6 +124,27 @@ const (
 	tagOSPFNeighborRouterID         = "ospf_neighbor_router_id"
 	tagOSPFNeighborState            = "ospf_neighbor_state"
 
+	tagISISSystemID               = topologymodel.LabelISISSystemID
+	tagISISAdjacencyNeighborSysID = "isis_adjacency_neighbor_sys_id"
+	tagISISAdjacencyState         = "isis_adjacency_state"
+	tagISISAdjacencyLevel         = "isis_adjacency_level"
+	tagISISAdjacencyNeighborType  = "isis_adjacency_neighbor_type"
+	tagISISAdjacencyIfIndex       = "isis_adjacency_if_index"
+
+	tagVRRPIfIndex   = "vrrp_if_index"
+	tagVRRPVrID      = "vrrp_vr_id"
+	tagVRRPVirtualIP = "vrrp_virtual_ip"
+	tagVRRPState     = "vrrp_state"
+	tagVRRPPriority  = "vrrp_priority"
+	tagVRRPMasterIP  = "vrrp_master_ip"
+
+	tagHSRPIfIndex      = "hsrp_if_index"
+	tagHSRPGroupNumber  = "hsrp_group_number"
+	tagHSRPState        = "hsrp_state"
+	tagHSRPPriority     = "hsrp_priority"
+	tagHSRPVirtualIP    = "hsrp_virtual_ip"
+	tagHSRPActiveRouter = "hsrp_active_router"
+
 	// Internal collector tags used when ingesting additional VLAN-context snapshots.
 	tagTopologyContextVLANID   = "_topology_context_vlan_id"
 	tagTopologyContextVLANName = "_topology_context_vlan_name"
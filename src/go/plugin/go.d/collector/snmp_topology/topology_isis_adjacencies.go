@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmptopology
+
+import (
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_topology/internal/topologymodel"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_topology/internal/topologyutil"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp/ddsnmp"
+)
+
+func init() {
+	registerTopologyMetricHandler(ddsnmp.KindISISAdjacency, (*topologyCache).updateISISAdjacency)
+}
+
+func (c *topologyCache) updateISISAdjacency(tags map[string]string) {
+	neighborSystemID := topologyutil.NormalizeISISSystemID(tags[tagISISAdjacencyNeighborSysID])
+	if neighborSystemID == "" {
+		return
+	}
+
+	row := topologymodel.ISISAdjacency{
+		NeighborSystemID: neighborSystemID,
+		State:            topologyutil.NormalizeISISAdjacencyState(tags[tagISISAdjacencyState]),
+		Level:            strings.TrimSpace(tags[tagISISAdjacencyLevel]),
+		NeighborType:     strings.TrimSpace(tags[tagISISAdjacencyNeighborType]),
+		IfIndex:          strings.TrimSpace(tags[tagISISAdjacencyIfIndex]),
+	}
+
+	if c.isisAdjByKey == nil {
+		c.isisAdjByKey = make(map[string]topologymodel.ISISAdjacency)
+	}
+	c.isisAdjByKey[topologyISISAdjacencyCacheKey(row)] = row
+}
+
+func (c *topologyCache) snapshotISISAdjacencies(localDeviceID string) []topologymodel.ISISAdjacency {
+	if c == nil || len(c.isisAdjByKey) == 0 {
+		return nil
+	}
+	localDeviceID = strings.TrimSpace(localDeviceID)
+	if localDeviceID == "" {
+		return nil
+	}
+
+	localSystemID := topologyutil.NormalizeISISSystemID(c.localDevice.Labels[tagISISSystemID])
+	keys := topologyutil.SortedMapKeys(c.isisAdjByKey)
+	rows := make([]topologymodel.ISISAdjacency, 0, len(keys))
+	for _, key := range keys {
+		row := c.isisAdjByKey[key]
+		row.DeviceID = localDeviceID
+		row.LocalSystemID = localSystemID
+		if row.IfIndex != "" {
+			row.IfName = strings.TrimSpace(c.ifNamesByIndex[row.IfIndex])
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func topologyISISAdjacencyCacheKey(row topologymodel.ISISAdjacency) string {
+	return topologyutil.JoinKeyParts(row.NeighborSystemID, row.IfIndex, row.Level)
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmptopology
+
+import (
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_topology/internal/topologymodel"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_topology/internal/topologyutil"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp/ddsnmp"
+)
+
+const (
+	topologyRedundancyProtocolVRRP = "vrrp"
+	topologyRedundancyProtocolHSRP = "hsrp"
+)
+
+func init() {
+	registerTopologyMetricHandler(ddsnmp.KindVRRPGroup, (*topologyCache).updateVRRPGroup)
+	registerTopologyMetricHandler(ddsnmp.KindHSRPGroup, (*topologyCache).updateHSRPGroup)
+}
+
+func (c *topologyCache) updateVRRPGroup(tags map[string]string) {
+	c.addRedundancyGroup(topologymodel.RedundancyGroup{
+		Protocol:  topologyRedundancyProtocolVRRP,
+		GroupID:   strings.TrimSpace(tags[tagVRRPVrID]),
+		VirtualIP: topologyutil.NormalizeNonUnspecifiedIPAddress(tags[tagVRRPVirtualIP]),
+		State:     strings.TrimSpace(tags[tagVRRPState]),
+		Priority:  strings.TrimSpace(tags[tagVRRPPriority]),
+		MasterIP:  topologyutil.NormalizeNonUnspecifiedIPAddress(tags[tagVRRPMasterIP]),
+		IfIndex:   strings.TrimSpace(tags[tagVRRPIfIndex]),
+	})
+}
+
+func (c *topologyCache) updateHSRPGroup(tags map[string]string) {
+	c.addRedundancyGroup(topologymodel.RedundancyGroup{
+		Protocol:  topologyRedundancyProtocolHSRP,
+		GroupID:   strings.TrimSpace(tags[tagHSRPGroupNumber]),
+		VirtualIP: topologyutil.NormalizeNonUnspecifiedIPAddress(tags[tagHSRPVirtualIP]),
+		State:     strings.TrimSpace(tags[tagHSRPState]),
+		Priority:  strings.TrimSpace(tags[tagHSRPPriority]),
+		MasterIP:  topologyutil.NormalizeNonUnspecifiedIPAddress(tags[tagHSRPActiveRouter]),
+		IfIndex:   strings.TrimSpace(tags[tagHSRPIfIndex]),
+	})
+}
+
+func (c *topologyCache) addRedundancyGroup(row topologymodel.RedundancyGroup) {
+	if row.GroupID == "" {
+		return
+	}
+	row.Role = topologyutil.RedundancyGroupRole(row.State)
+
+	if c.redundancyGroups == nil {
+		c.redundancyGroups = make(map[string]topologymodel.RedundancyGroup)
+	}
+	c.redundancyGroups[topologyRedundancyGroupCacheKey(row)] = row
+}
+
+func (c *topologyCache) snapshotRedundancyGroups(localDeviceID string) []topologymodel.RedundancyGroup {
+	if c == nil || len(c.redundancyGroups) == 0 {
+		return nil
+	}
+	localDeviceID = strings.TrimSpace(localDeviceID)
+	if localDeviceID == "" {
+		return nil
+	}
+
+	keys := topologyutil.SortedMapKeys(c.redundancyGroups)
+	rows := make([]topologymodel.RedundancyGroup, 0, len(keys))
+	for _, key := range keys {
+		row := c.redundancyGroups[key]
+		row.DeviceID = localDeviceID
+		if row.IfIndex != "" {
+			row.IfName = strings.TrimSpace(c.ifNamesByIndex[row.IfIndex])
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func topologyRedundancyGroupCacheKey(row topologymodel.RedundancyGroup) string {
+	return topologyutil.JoinKeyParts(row.Protocol, row.IfIndex, row.GroupID, row.VirtualIP)
+}
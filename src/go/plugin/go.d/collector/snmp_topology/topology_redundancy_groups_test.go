@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmptopology
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopologyCache_RedundancyGroupsNormalizeVRRPAndHSRPRoles(t *testing.T) {
+	cache := newTopologyCache()
+	cache.ifNamesByIndex = map[string]string{"3": "Vlan10"}
+
+	cache.updateVRRPGroup(map[string]string{
+		tagVRRPIfIndex:   "3",
+		tagVRRPVrID:      "10",
+		tagVRRPVirtualIP: "C6336401",
+		tagVRRPState:     "master",
+		tagVRRPPriority:  "120",
+	})
+	cache.updateHSRPGroup(map[string]string{
+		tagHSRPIfIndex:      "3",
+		tagHSRPGroupNumber:  "1",
+		tagHSRPVirtualIP:    "198.51.100.254",
+		tagHSRPState:        "standby",
+		tagHSRPActiveRouter: "198.51.100.2",
+	})
+	cache.updateHSRPGroup(map[string]string{tagHSRPState: "active"})
+
+	rows := cache.snapshotRedundancyGroups("device-a")
+
+	require.Len(t, rows, 2)
+	require.Equal(t, topologyRedundancyProtocolHSRP, rows[0].Protocol)
+	require.Equal(t, "backup", rows[0].Role)
+	require.Equal(t, "198.51.100.2", rows[0].MasterIP)
+	require.Equal(t, topologyRedundancyProtocolVRRP, rows[1].Protocol)
+	require.Equal(t, "master", rows[1].Role)
+	require.Equal(t, "198.51.100.1", rows[1].VirtualIP)
+	require.Equal(t, "Vlan10", rows[1].IfName)
+	require.Equal(t, "device-a", rows[1].DeviceID)
+}
+
+func TestTopologyCache_ISISAdjacencyUsesLocalSystemIDLabel(t *testing.T) {
+	cache := newTopologyCache()
+	cache.localDevice.Labels = map[string]string{tagISISSystemID: "1921.6800.1001"}
+
+	cache.updateISISAdjacency(map[string]string{
+		tagISISAdjacencyNeighborSysID: "192168001002",
+		tagISISAdjacencyState:         "3",
+		tagISISAdjacencyLevel:         "level2",
+		tagISISAdjacencyIfIndex:       "2",
+	})
+	cache.updateISISAdjacency(map[string]string{tagISISAdjacencyState: "3"})
+
+	rows := cache.snapshotISISAdjacencies("device-a")
+
+	require.Len(t, rows, 1)
+	require.Equal(t, "1921.6800.1001", rows[0].LocalSystemID)
+	require.Equal(t, "1921.6800.1002", rows[0].NeighborSystemID)
+	require.Equal(t, "up", rows[0].State)
+}
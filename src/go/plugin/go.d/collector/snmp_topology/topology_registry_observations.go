@@ -78,25 +78,33 @@ func aggregateTopologyObservationSnapshots(snapshots []topologymodel.Observation
 	totalObservations := 0
 	totalL3Interfaces := 0
 	totalOSPFNeighbors := 0
+	totalISISAdjacencies := 0
+	totalRedundancyGroups := 0
 	totalBGPPeers := 0
 	for _, snapshot := range snapshots {
 		totalObservations += len(snapshot.L2Observations)
 		totalL3Interfaces += len(snapshot.L3Interfaces)
 		totalOSPFNeighbors += len(snapshot.OSPFNeighbors)
+		totalISISAdjacencies += len(snapshot.ISISAdjacencies)
+		totalRedundancyGroups += len(snapshot.RedundancyGroups)
 		totalBGPPeers += len(snapshot.BGPPeers)
 	}
 
 	aggregate := topologymodel.ObservationAggregate{
-		Snapshots:      snapshots,
-		L2Observations: make([]topologyengine.L2Observation, 0, totalObservations),
-		L3Interfaces:   make([]topologymodel.L3Interface, 0, totalL3Interfaces),
-		OSPFNeighbors:  make([]topologymodel.OSPFNeighbor, 0, totalOSPFNeighbors),
-		BGPPeers:       make([]topologymodel.BGPPeer, 0, totalBGPPeers),
+		Snapshots:        snapshots,
+		L2Observations:   make([]topologyengine.L2Observation, 0, totalObservations),
+		L3Interfaces:     make([]topologymodel.L3Interface, 0, totalL3Interfaces),
+		OSPFNeighbors:    make([]topologymodel.OSPFNeighbor, 0, totalOSPFNeighbors),
+		ISISAdjacencies:  make([]topologymodel.ISISAdjacency, 0, totalISISAdjacencies),
+		RedundancyGroups: make([]topologymodel.RedundancyGroup, 0, totalRedundancyGroups),
+		BGPPeers:         make([]topologymodel.BGPPeer, 0, totalBGPPeers),
 	}
 	for _, snapshot := range snapshots {
 		aggregate.L2Observations = append(aggregate.L2Observations, snapshot.L2Observations...)
 		aggregate.L3Interfaces = append(aggregate.L3Interfaces, snapshot.L3Interfaces...)
 		aggregate.OSPFNeighbors = append(aggregate.OSPFNeighbors, snapshot.OSPFNeighbors...)
+		aggregate.ISISAdjacencies = append(aggregate.ISISAdjacencies, snapshot.ISISAdjacencies...)
+		aggregate.RedundancyGroups = append(aggregate.RedundancyGroups, snapshot.RedundancyGroups...)
 		aggregate.BGPPeers = append(aggregate.BGPPeers, snapshot.BGPPeers...)
 		if aggregate.AgentID == "" && snapshot.AgentID != "" {
 			aggregate.AgentID = snapshot.AgentID
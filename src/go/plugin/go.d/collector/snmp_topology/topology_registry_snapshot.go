@@ -45,13 +45,15 @@ func (c *topologyCache) buildObservationSnapshotLocked() (topologymodel.Observat
 	}
 
 	return topologymodel.ObservationSnapshot{
-		L2Observations: []topologyengine.L2Observation{localObservation},
-		L3Interfaces:   c.snapshotL3Interfaces(localObservation.DeviceID),
-		OSPFNeighbors:  c.snapshotOSPFNeighbors(localObservation.DeviceID),
-		BGPPeers:       c.snapshotBGPPeers(localObservation.DeviceID),
-		LocalDevice:    local,
-		LocalDeviceID:  localObservation.DeviceID,
-		AgentID:        strings.TrimSpace(c.agentID),
-		CollectedAt:    c.lastUpdate,
+		L2Observations:   []topologyengine.L2Observation{localObservation},
+		L3Interfaces:     c.snapshotL3Interfaces(localObservation.DeviceID),
+		OSPFNeighbors:    c.snapshotOSPFNeighbors(localObservation.DeviceID),
+		ISISAdjacencies:  c.snapshotISISAdjacencies(localObservation.DeviceID),
+		RedundancyGroups: c.snapshotRedundancyGroups(localObservation.DeviceID),
+		BGPPeers:         c.snapshotBGPPeers(localObservation.DeviceID),
+		LocalDevice:      local,
+		LocalDeviceID:    localObservation.DeviceID,
+		AgentID:          strings.TrimSpace(c.agentID),
+		CollectedAt:      c.lastUpdate,
 	}, true
 }
@@ -136,6 +136,24 @@ func TestSNMPTopologyToV1_RealPipelineStatsCensus(t *testing.T) {
 		"ospf_adjacency_suppressed_self_actor",
 		"ospf_adjacency_suppressed_duplicate_link",
 		"ospf_adjacency_visible_links",
+		"isis_adjacency_rows",
+		"isis_adjacency_detail_rows",
+		"isis_adjacency_emitted_links",
+		"isis_adjacency_suppressed_non_up_state",
+		"isis_adjacency_suppressed_unresolved_local",
+		"isis_adjacency_suppressed_unresolved_neighbor",
+		"isis_adjacency_suppressed_self_actor",
+		"isis_adjacency_suppressed_duplicate_link",
+		"isis_adjacency_visible_links",
+		"redundancy_group_rows",
+		"redundancy_group_detail_rows",
+		"redundancy_virtual_routers_emitted",
+		"redundancy_membership_emitted_links",
+		"redundancy_group_suppressed_no_virtual_ip",
+		"redundancy_group_suppressed_unresolved_local",
+		"redundancy_group_suppressed_no_producer_scope",
+		"redundancy_membership_suppressed_duplicate_link",
+		"redundancy_membership_visible_links",
 		"bgp_peer_rows",
 		"bgp_peer_detail_rows",
 		"bgp_adjacency_emitted_links",
@@ -209,6 +227,10 @@ func TestSNMPTopologyToV1_RealPipelineStatsCensusNoProtocolDataEmitsZeroProtocol
 	require.Equal(t, 0, payload.Stats["ospf_neighbor_rows"])
 	require.Equal(t, 0, payload.Stats["ospf_adjacency_emitted_links"])
 	require.Equal(t, 0, payload.Stats["ospf_adjacency_visible_links"])
+	require.Equal(t, 0, payload.Stats["isis_adjacency_rows"])
+	require.Equal(t, 0, payload.Stats["isis_adjacency_visible_links"])
+	require.Equal(t, 0, payload.Stats["redundancy_group_rows"])
+	require.Equal(t, 0, payload.Stats["redundancy_membership_visible_links"])
 	require.Equal(t, 0, payload.Stats["bgp_peer_rows"])
 	require.Equal(t, 0, payload.Stats["bgp_adjacency_emitted_links"])
 	require.Equal(t, 0, payload.Stats["bgp_adjacency_visible_links"])
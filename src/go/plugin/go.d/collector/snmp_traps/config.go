@@ -2,6 +2,10 @@
 
 package snmp_traps
 
+import (
+	"github.com/netdata/netdata/go/plugins/pkg/tlscfg"
+)
+
 type EndpointConfig struct {
 	Protocol string `yaml:"protocol" json:"protocol"`
 	Address  string `yaml:"address" json:"address"`
@@ -48,6 +52,37 @@ type OTLPConfig struct {
 	QueueCapacity  int               `yaml:"queue_capacity,omitempty" json:"queue_capacity"`
 }
 
+type ForwardConfig struct {
+	Enabled      bool                       `yaml:"enabled" json:"enabled"`
+	Destinations []ForwardDestinationConfig `yaml:"destinations,omitempty" json:"destinations"`
+}
+
+type ForwardDestinationConfig struct {
+	Address       string        `yaml:"address" json:"address"`
+	Version       string        `yaml:"version,omitempty" json:"version"`
+	Community     string        `yaml:"community,omitempty" json:"community"`
+	USMUser       USMUserConfig `yaml:"usm_user,omitempty" json:"usm_user"`
+	Inform        bool          `yaml:"inform,omitempty" json:"inform"`
+	Timeout       string        `yaml:"timeout,omitempty" json:"timeout"`
+	Retries       int           `yaml:"retries,omitempty" json:"retries"`
+	QueueCapacity int           `yaml:"queue_capacity,omitempty" json:"queue_capacity"`
+	Categories    []string      `yaml:"categories,omitempty" json:"categories"`
+	Severities    []string      `yaml:"severities,omitempty" json:"severities"`
+}
+
+type SyslogConfig struct {
+	Enabled          bool     `yaml:"enabled" json:"enabled"`
+	Endpoint         string   `yaml:"endpoint,omitempty" json:"endpoint"`
+	Facility         string   `yaml:"facility,omitempty" json:"facility"`
+	AppName          string   `yaml:"app_name,omitempty" json:"app_name"`
+	SDID             string   `yaml:"sd_id,omitempty" json:"sd_id"`
+	Timeout          string   `yaml:"timeout,omitempty" json:"timeout"`
+	QueueCapacity    int      `yaml:"queue_capacity,omitempty" json:"queue_capacity"`
+	Categories       []string `yaml:"categories,omitempty" json:"categories"`
+	Severities       []string `yaml:"severities,omitempty" json:"severities"`
+	tlscfg.TLSConfig `yaml:",inline" json:""`
+}
+
 type JournalBackendConfig struct {
 	Enabled *bool `yaml:"enabled,omitempty" json:"enabled"`
 }
@@ -91,6 +126,8 @@ type Config struct {
 	Dedup              DedupConfig          `yaml:"dedup,omitempty" json:"dedup"`
 	Journal            JournalBackendConfig `yaml:"journal,omitempty" json:"journal"`
 	OTLP               OTLPConfig           `yaml:"otlp,omitempty" json:"otlp"`
+	Forward            ForwardConfig        `yaml:"forward,omitempty" json:"forward"`
+	Syslog             SyslogConfig         `yaml:"syslog,omitempty" json:"syslog"`
 	Retention          jsonRetentionConfig  `yaml:"retention,omitempty" json:"retention"`
 	Overrides          []OverrideConfig     `yaml:"overrides,omitempty" json:"overrides"`
 	ProfileMetrics     ProfileMetricsConfig `yaml:"profile_metrics,omitempty" json:"profile_metrics"`
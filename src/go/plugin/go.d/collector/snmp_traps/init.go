@@ -13,8 +13,10 @@ import (
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/dedup"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/jobruntime"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/model"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/output/forward"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/output/journal"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/output/otlp"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/output/syslog"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/profilemetrics"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/receiver"
 )
@@ -113,6 +115,30 @@ func validateConfig(c Config) (validatedConfig, error) {
 	if !journalEnabled && !c.OTLP.Enabled {
 		return validated, errors.New("at least one SNMP trap output backend must be enabled: journal.enabled or otlp.enabled")
 	}
+	forwardPolicies, err := validateForward(c.Forward)
+	if err != nil {
+		return validated, err
+	}
+	var syslogPolicy syslog.Policy
+	if c.Syslog.Enabled {
+		if err := validateRelayFilter("syslog", c.Syslog.Categories, c.Syslog.Severities); err != nil {
+			return validated, err
+		}
+		syslogPolicy, err = syslog.Normalize(syslog.Config{
+			Endpoint:      c.Syslog.Endpoint,
+			Facility:      c.Syslog.Facility,
+			AppName:       c.Syslog.AppName,
+			SDID:          c.Syslog.SDID,
+			Timeout:       c.Syslog.Timeout,
+			QueueCapacity: c.Syslog.QueueCapacity,
+			TLS:           c.Syslog.TLSConfig,
+			Categories:    c.Syslog.Categories,
+			Severities:    c.Syslog.Severities,
+		})
+		if err != nil {
+			return validated, err
+		}
+	}
 
 	if err := validateOverrides(c.Overrides); err != nil {
 		return validated, err
@@ -165,6 +191,9 @@ func validateConfig(c Config) (validatedConfig, error) {
 		Journal:               retention.Config(),
 		OTLPEnabled:           c.OTLP.Enabled,
 		OTLP:                  otlpPolicy,
+		Forward:               forwardPolicies,
+		SyslogEnabled:         c.Syslog.Enabled,
+		Syslog:                syslogPolicy,
 		Dedup:                 dedupPolicy,
 		ProfileMetrics:        profileMetrics,
 		ReverseDNSEnabled:     c.ReverseDNS.Enabled,
@@ -246,6 +275,60 @@ func validateOverrides(overrides []OverrideConfig) error {
 	return nil
 }
 
+func validateForward(cfg ForwardConfig) ([]forward.Policy, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if len(cfg.Destinations) == 0 {
+		return nil, errors.New("forward.destinations: at least one destination is required when forward.enabled is true")
+	}
+	policies := make([]forward.Policy, 0, len(cfg.Destinations))
+	for i, dest := range cfg.Destinations {
+		name := fmt.Sprintf("forward.destinations[%d]", i)
+		if err := validateRelayFilter(name, dest.Categories, dest.Severities); err != nil {
+			return nil, err
+		}
+		policy, err := forward.Normalize(name, forward.Config{
+			Address:   dest.Address,
+			Version:   dest.Version,
+			Community: dest.Community,
+			USMUser: forward.USMUser{
+				Username:  dest.USMUser.Username,
+				EngineID:  dest.USMUser.EngineID,
+				AuthProto: dest.USMUser.AuthProto,
+				AuthKey:   dest.USMUser.AuthKey,
+				PrivProto: dest.USMUser.PrivProto,
+				PrivKey:   dest.USMUser.PrivKey,
+			},
+			Inform:        dest.Inform,
+			Timeout:       dest.Timeout,
+			Retries:       dest.Retries,
+			QueueCapacity: dest.QueueCapacity,
+			Categories:    dest.Categories,
+			Severities:    dest.Severities,
+		})
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+func validateRelayFilter(name string, categories, severities []string) error {
+	for _, category := range categories {
+		if !catalog.ValidCategory(category) {
+			return fmt.Errorf("%s.categories: invalid category %q", name, category)
+		}
+	}
+	for _, severity := range severities {
+		if !catalog.ValidSeverity(severity) {
+			return fmt.Errorf("%s.severities: invalid severity %q", name, severity)
+		}
+	}
+	return nil
+}
+
 func validateConfigLabelKey(key string) error {
 	if !labelKeyRE.MatchString(key) {
 		return fmt.Errorf("does not match ^[a-z][a-z0-9_]*$")
@@ -344,6 +344,7 @@ func TestConfigSchemaDynCfgListFieldsHaveSafeDefaults(t *testing.T) {
 		{name: "allowlist.source_cidrs", path: []string{"jsonSchema", "properties", "allowlist", "properties", "source_cidrs"}, wantDefault: []any{"0.0.0.0/0", "::/0"}},
 		{name: "source.trusted_relays", path: []string{"jsonSchema", "properties", "source", "properties", "trusted_relays"}},
 		{name: "dedup.key_varbinds", path: []string{"jsonSchema", "properties", "dedup", "properties", "key_varbinds"}},
+		{name: "forward.destinations", path: []string{"jsonSchema", "properties", "forward", "properties", "destinations"}},
 		{name: "overrides", path: []string{"jsonSchema", "properties", "overrides"}},
 		{name: "profile_metrics.include", path: []string{"jsonSchema", "properties", "profile_metrics", "properties", "include"}},
 	} {
@@ -395,6 +396,8 @@ func TestConfigSchemaDynCfgObjectFieldsHaveSafeDefaults(t *testing.T) {
 		{name: "dedup", path: []string{"jsonSchema", "properties", "dedup"}},
 		{name: "journal", path: []string{"jsonSchema", "properties", "journal"}},
 		{name: "otlp", path: []string{"jsonSchema", "properties", "otlp"}},
+		{name: "forward", path: []string{"jsonSchema", "properties", "forward"}},
+		{name: "syslog", path: []string{"jsonSchema", "properties", "syslog"}},
 		{name: "retention", path: []string{"jsonSchema", "properties", "retention"}},
 		{name: "overrides.labels", path: []string{"jsonSchema", "properties", "overrides", "items", "properties", "labels"}},
 		{name: "profile_metrics", path: []string{"jsonSchema", "properties", "profile_metrics"}},
@@ -505,7 +508,7 @@ func TestConfigSchemaDynCfgTabsRenderAllTopLevelFieldsOnce(t *testing.T) {
 			"dynamic_engine_id_max_pairs",
 		}},
 		{title: "Filtering", fields: []string{"allowlist", "source", "rate_limit", "dedup"}},
-		{title: "Outputs", fields: []string{"journal", "otlp"}},
+		{title: "Outputs", fields: []string{"journal", "otlp", "forward", "syslog"}},
 		{title: "Storage", fields: []string{"retention"}},
 		{title: "Enrichment", fields: []string{"reverse_dns", "overrides"}},
 		{title: "Metrics", fields: []string{"profile_metrics"}},
@@ -826,6 +829,91 @@ func TestCollectorInit_NoOutputBackendIsCodedError(t *testing.T) {
 	assert.Nil(t, c.job)
 }
 
+func TestValidateConfigRelayOutputs(t *testing.T) {
+	base := func() Config {
+		return Config{
+			Name:   "local",
+			Listen: ListenConfig{Endpoints: []EndpointConfig{{Protocol: "udp", Address: "127.0.0.1", Port: 162}}},
+		}
+	}
+
+	tests := map[string]struct {
+		mutate  func(*Config)
+		wantErr string
+	}{
+		"forward disabled ignores destinations": {
+			mutate: func(c *Config) {
+				c.Forward.Destinations = []ForwardDestinationConfig{{Address: ""}}
+			},
+		},
+		"forward v2c destination": {
+			mutate: func(c *Config) {
+				c.Forward = ForwardConfig{Enabled: true, Destinations: []ForwardDestinationConfig{{
+					Address:    "192.0.2.50",
+					Categories: []string{"security"},
+					Severities: []string{"crit"},
+				}}}
+			},
+		},
+		"forward requires destinations": {
+			mutate: func(c *Config) {
+				c.Forward.Enabled = true
+			},
+			wantErr: "forward.destinations: at least one destination",
+		},
+		"forward invalid category": {
+			mutate: func(c *Config) {
+				c.Forward = ForwardConfig{Enabled: true, Destinations: []ForwardDestinationConfig{{
+					Address:    "192.0.2.50",
+					Categories: []string{"outage"},
+				}}}
+			},
+			wantErr: `forward.destinations[0].categories: invalid category "outage"`,
+		},
+		"forward v3 trap requires engine id": {
+			mutate: func(c *Config) {
+				c.Forward = ForwardConfig{Enabled: true, Destinations: []ForwardDestinationConfig{{
+					Address: "192.0.2.50:1162",
+					Version: "v3",
+					USMUser: USMUserConfig{Username: "relay", AuthProto: "sha256", AuthKey: "authpass1"},
+				}}}
+			},
+			wantErr: "forward.destinations[0].usm_user: engine_id is required",
+		},
+		"syslog tls endpoint": {
+			mutate: func(c *Config) {
+				c.Syslog = SyslogConfig{Enabled: true, Endpoint: "tls://syslog.example.com:6514", Severities: []string{"err"}}
+			},
+		},
+		"syslog invalid facility": {
+			mutate: func(c *Config) {
+				c.Syslog = SyslogConfig{Enabled: true, Facility: "local9"}
+			},
+			wantErr: "syslog.facility",
+		},
+		"syslog invalid severity": {
+			mutate: func(c *Config) {
+				c.Syslog = SyslogConfig{Enabled: true, Severities: []string{"fatal"}}
+			},
+			wantErr: `syslog.severities: invalid severity "fatal"`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := base()
+			tc.mutate(&cfg)
+			err := cfg.Validate()
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
+
 func TestCollectorInit_MissingNetdataLogRootIsRetryableCodedError(t *testing.T) {
 	manager := setMinimalProfileDir(t)
 	root := filepath.Join(t.TempDir(), "missing")
@@ -13,6 +13,8 @@ import (
 const (
 	listenerReadErrorLogEvery     = time.Hour
 	listenerReadErrorLogKeyPrefix = "snmp_traps:listener_read_failed:"
+	relayErrorLogEvery            = 10 * time.Minute
+	relayErrorLogKeyPrefix        = "snmp_traps:relay_failed:"
 )
 
 func (j *Job) attachTelemetry(bindEvents []receiver.Event) *telemetry.Job {
@@ -27,6 +29,18 @@ func (j *Job) handleOutputOutcome(jobTelemetry *telemetry.Job, outcome output.Ou
 	if outcome.Backend == output.BackendJournal && outcome.Err != nil {
 		j.deps.Log.warningf("SNMP trap journal writer stopped for job %q: %v", j.policy.jobName, outcome.Err)
 	}
+	if (outcome.Backend == output.BackendForward || outcome.Backend == output.BackendSyslog) && outcome.Err != nil {
+		relay := "forward"
+		if outcome.Backend == output.BackendSyslog {
+			relay = "syslog"
+		}
+		j.deps.Log.warnLimited(
+			relayErrorLogKeyPrefix+relay+":"+j.policy.jobName,
+			relayErrorLogEvery,
+			"SNMP trap %s relay failed for job %q: %v",
+			relay, j.policy.jobName, outcome.Err,
+		)
+	}
 	if jobTelemetry == nil || outcome.FailedEntries == 0 {
 		return
 	}
@@ -35,6 +49,10 @@ func (j *Job) handleOutputOutcome(jobTelemetry *telemetry.Job, outcome output.Ou
 		jobTelemetry.AddError(writeFailureJournal, outcome.FailedEntries)
 	case output.BackendOTLP:
 		jobTelemetry.AddError(writeFailureOTLP, outcome.FailedEntries)
+	case output.BackendForward:
+		jobTelemetry.AddError(relayFailureForward, outcome.FailedEntries)
+	case output.BackendSyslog:
+		jobTelemetry.AddError(relayFailureSyslog, outcome.FailedEntries)
 	}
 	if outcome.Authoritative {
 		jobTelemetry.PipelineWriteFailed(outcome.FailedEntries)
@@ -14,6 +14,7 @@ import (
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/dedup"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/hostidentity"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/output"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/output/syslog"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/profilemetrics"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/receiver"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/telemetry"
@@ -22,6 +23,8 @@ import (
 const (
 	writeFailureJournal = telemetry.ErrorJournalWriteFailed
 	writeFailureOTLP    = telemetry.ErrorOTLPExportFailed
+	relayFailureForward = telemetry.ErrorForwardFailed
+	relayFailureSyslog  = telemetry.ErrorSyslogFailed
 )
 
 type Job struct {
@@ -153,6 +156,19 @@ func (j *Job) Start(ctx context.Context, onCommit func()) error {
 		}
 	}
 
+	if len(j.policy.forward) > 0 || j.policy.syslogEnabled {
+		var syslogPolicy *syslog.Policy
+		if j.policy.syslogEnabled {
+			syslogPolicy = &j.policy.syslog
+		}
+		if err := prepared.prepareRelays(j.policy.forward, syslogPolicy, reportOutput); err != nil {
+			jobTelemetry.Detach()
+			recv.RollbackPreparedState()
+			cleanupPreflight()
+			return startupError(err)
+		}
+	}
+
 	writer, writeFailureDim := prepared.coordinator(reportOutput)
 	deduper := newDeduper(j.policy.jobName, j.policy.dedup, idx, writer, jobTelemetry, writeFailureDim, func() int64 {
 		return j.monotonicUsecWith(prepared.journalHost)
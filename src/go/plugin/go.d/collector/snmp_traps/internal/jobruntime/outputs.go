@@ -7,17 +7,27 @@ import (
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/hostidentity"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/output"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/output/forward"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/output/journal"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/output/otlp"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/output/syslog"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/telemetry"
 )
 
 type preparedOutputs struct {
 	journal     *journal.Writer
 	otlp        *otlp.Writer
+	relays      []preparedRelay
 	journalHost hostidentity.Provider
 }
 
+// preparedRelay is a forwarding or syslog output. Relays are never
+// authoritative; they only receive what the primary output is also given.
+type preparedRelay struct {
+	writer  *output.QueueWriter
+	backend output.Backend
+}
+
 func (j *Job) prepareOutputs(report output.OutcomeReporter) (*preparedOutputs, error) {
 	prepared := &preparedOutputs{}
 	if !j.policy.journalEnabled {
@@ -55,23 +65,51 @@ func (p *preparedOutputs) prepareOTLP(ctx context.Context, jobName string, polic
 	return nil
 }
 
+func (p *preparedOutputs) prepareRelays(forwards []forward.Policy, syslogPolicy *syslog.Policy, report output.OutcomeReporter) error {
+	for _, policy := range forwards {
+		writer, err := forward.Prepare(policy, forward.Options{Report: report})
+		if err != nil {
+			return err
+		}
+		p.relays = append(p.relays, preparedRelay{writer: writer, backend: output.BackendForward})
+	}
+	if syslogPolicy != nil {
+		writer, err := syslog.Prepare(*syslogPolicy, syslog.Options{Report: report})
+		if err != nil {
+			return err
+		}
+		p.relays = append(p.relays, preparedRelay{writer: writer, backend: output.BackendSyslog})
+	}
+	return nil
+}
+
 func (p *preparedOutputs) coordinator(report output.OutcomeReporter) (output.Writer, telemetry.ErrorKind) {
 	var primary output.Writer
 	if p.journal != nil {
 		primary = p.journal
 	}
-	var secondary output.Writer
+	var secondaries []output.Secondary
 	if p.otlp != nil {
-		secondary = p.otlp
+		secondaries = append(secondaries, output.Secondary{Writer: p.otlp, Backend: output.BackendOTLP})
+	}
+	for _, relay := range p.relays {
+		secondaries = append(secondaries, output.Secondary{Writer: relay.writer, Backend: relay.backend})
 	}
 	writeFailure := writeFailureJournal
 	if primary == nil {
 		writeFailure = writeFailureOTLP
 	}
-	return output.NewCoordinator(primary, secondary, output.BackendOTLP, report), writeFailure
+	return output.NewCoordinator(primary, secondaries, report), writeFailure
 }
 
 func (p *preparedOutputs) start() error {
+	// Relays cannot fail a commit, so they start first and are already
+	// draining by the time the authoritative backends accept entries.
+	for _, relay := range p.relays {
+		if err := relay.writer.Start(); err != nil {
+			return err
+		}
+	}
 	var otlpStarter, journalStarter outputStarter
 	if p.otlp != nil {
 		otlpStarter = p.otlp
@@ -110,4 +148,7 @@ func (p *preparedOutputs) close() {
 	if p.otlp != nil {
 		_ = p.otlp.Close()
 	}
+	for _, relay := range p.relays {
+		_ = relay.writer.Close()
+	}
 }
@@ -4,10 +4,13 @@ package jobruntime
 
 import (
 	"maps"
+	"slices"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/dedup"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/output/forward"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/output/journal"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/output/otlp"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/output/syslog"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/profilemetrics"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/receiver"
 )
@@ -26,6 +29,9 @@ type PolicyConfig struct {
 	Journal               journal.Config
 	OTLPEnabled           bool
 	OTLP                  otlp.Policy
+	Forward               []forward.Policy
+	SyslogEnabled         bool
+	Syslog                syslog.Policy
 	Dedup                 dedup.Policy
 	ProfileMetrics        profilemetrics.Policy
 	ReverseDNSEnabled     bool
@@ -41,6 +47,9 @@ type Policy struct {
 	journal               journal.Config
 	otlpEnabled           bool
 	otlp                  otlp.Policy
+	forward               []forward.Policy
+	syslogEnabled         bool
+	syslog                syslog.Policy
 	dedup                 dedup.Policy
 	profileMetrics        profilemetrics.Policy
 	reverseDNSEnabled     bool
@@ -65,6 +74,9 @@ func NewPolicy(cfg PolicyConfig) Policy {
 		journal:               cfg.Journal,
 		otlpEnabled:           cfg.OTLPEnabled,
 		otlp:                  cfg.OTLP,
+		forward:               slices.Clone(cfg.Forward),
+		syslogEnabled:         cfg.SyslogEnabled,
+		syslog:                cfg.Syslog,
 		dedup:                 cfg.Dedup,
 		profileMetrics:        cfg.ProfileMetrics,
 		reverseDNSEnabled:     cfg.ReverseDNSEnabled,
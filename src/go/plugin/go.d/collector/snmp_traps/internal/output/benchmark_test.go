@@ -15,7 +15,7 @@ func (discardWriter) Flush() error                 { return nil }
 func (discardWriter) Close() error                 { return nil }
 
 func BenchmarkCoordinatorWrite(b *testing.B) {
-	writer := NewCoordinator(discardWriter{}, discardWriter{}, BackendOTLP, nil)
+	writer := NewCoordinator(discardWriter{}, []Secondary{{Writer: discardWriter{}, Backend: BackendOTLP}}, nil)
 	entry := &model.TrapEntry{}
 	b.ReportAllocs()
 	b.ResetTimer()
@@ -8,51 +8,64 @@ import (
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/model"
 )
 
+// Secondary is a non-authoritative output fed alongside the primary writer.
+type Secondary struct {
+	Writer  Writer
+	Backend Backend
+}
+
 type coordinator struct {
-	primary          Writer
-	secondary        Writer
-	secondaryBackend Backend
-	report           OutcomeReporter
+	primary     Writer
+	secondaries []Secondary
+	report      OutcomeReporter
 }
 
-func NewCoordinator(primary, secondary Writer, secondaryBackend Backend, report OutcomeReporter) Writer {
+func NewCoordinator(primary Writer, secondaries []Secondary, report OutcomeReporter) Writer {
 	if primary == nil {
-		return secondary
+		if len(secondaries) == 0 {
+			return nil
+		}
+		primary, secondaries = secondaries[0].Writer, secondaries[1:]
 	}
-	if secondary == nil {
+	if len(secondaries) == 0 {
 		return primary
 	}
 	return &coordinator{
-		primary:          primary,
-		secondary:        secondary,
-		secondaryBackend: secondaryBackend,
-		report:           report,
+		primary:     primary,
+		secondaries: secondaries,
+		report:      report,
 	}
 }
 
 func (w *coordinator) Write(entry *model.TrapEntry) error {
 	primaryErr := w.primary.Write(entry)
-	if err := w.secondary.Write(entry); err != nil {
-		w.report.Report(Outcome{
-			Backend:       w.secondaryBackend,
-			Stage:         StageEnqueue,
-			FailedEntries: 1,
-			Err:           err,
-		})
+	for _, secondary := range w.secondaries {
+		if err := secondary.Writer.Write(entry); err != nil {
+			w.report.Report(Outcome{
+				Backend:       secondary.Backend,
+				Stage:         StageEnqueue,
+				FailedEntries: 1,
+				Err:           err,
+			})
+		}
 	}
 	return primaryErr
 }
 
 func (w *coordinator) Flush() error {
-	primaryErr := w.primary.Flush()
-	secondaryErr := w.secondary.Flush()
-	return errors.Join(primaryErr, secondaryErr)
+	errs := []error{w.primary.Flush()}
+	for _, secondary := range w.secondaries {
+		errs = append(errs, secondary.Writer.Flush())
+	}
+	return errors.Join(errs...)
 }
 
 func (w *coordinator) Close() error {
-	primaryErr := w.primary.Close()
-	secondaryErr := w.secondary.Close()
-	return errors.Join(primaryErr, secondaryErr)
+	errs := []error{w.primary.Close()}
+	for _, secondary := range w.secondaries {
+		errs = append(errs, secondary.Writer.Close())
+	}
+	return errors.Join(errs...)
 }
 
 func (w *coordinator) BinaryEncodedFields() uint64 {
@@ -70,7 +70,7 @@ func TestCoordinatorSecondaryFailureDoesNotFailPrimaryWrite(t *testing.T) {
 	primary := &mockWriter{}
 	secondary := &mockWriter{err: ErrQueueFull}
 	var outcomes []Outcome
-	writer := NewCoordinator(primary, secondary, BackendOTLP, func(outcome Outcome) {
+	writer := NewCoordinator(primary, []Secondary{{Writer: secondary, Backend: BackendOTLP}}, func(outcome Outcome) {
 		outcomes = append(outcomes, outcome)
 	})
 
@@ -92,7 +92,7 @@ func TestCoordinatorBothWriteFailuresReturnOnlyPrimaryError(t *testing.T) {
 	primary := &mockWriter{err: primaryErr}
 	secondary := &mockWriter{err: ErrQueueFull}
 	var outcomes []Outcome
-	writer := NewCoordinator(primary, secondary, BackendOTLP, func(outcome Outcome) {
+	writer := NewCoordinator(primary, []Secondary{{Writer: secondary, Backend: BackendOTLP}}, func(outcome Outcome) {
 		outcomes = append(outcomes, outcome)
 	})
 
@@ -108,7 +108,7 @@ func TestCoordinatorPrimaryFailureStillAttemptsSecondaryWrite(t *testing.T) {
 	primaryErr := errors.New("primary failed")
 	primary := &mockWriter{err: primaryErr}
 	secondary := &mockWriter{}
-	writer := NewCoordinator(primary, secondary, BackendOTLP, nil)
+	writer := NewCoordinator(primary, []Secondary{{Writer: secondary, Backend: BackendOTLP}}, nil)
 
 	entry := testCoordinatorEntry()
 	err := writer.Write(entry)
@@ -123,7 +123,7 @@ func TestCoordinatorFlushAndClosePreserveOrderAndJoinErrors(t *testing.T) {
 	var calls []string
 	primary := &mockWriter{name: "primary", calls: &calls, err: primaryErr}
 	secondary := &mockWriter{name: "secondary", calls: &calls, err: secondaryErr}
-	writer := NewCoordinator(primary, secondary, BackendOTLP, nil)
+	writer := NewCoordinator(primary, []Secondary{{Writer: secondary, Backend: BackendOTLP}}, nil)
 
 	flushErr := writer.Flush()
 	require.ErrorIs(t, flushErr, primaryErr)
@@ -145,7 +145,7 @@ func TestCoordinatorFlushAndClosePreserveOrderAndJoinErrors(t *testing.T) {
 func TestCoordinatorForwardsBinaryEncodedFieldsFromPrimary(t *testing.T) {
 	primary := &mockWriter{binaryEncodedFields: 7}
 	secondary := &mockWriter{}
-	writer := NewCoordinator(primary, secondary, BackendOTLP, nil)
+	writer := NewCoordinator(primary, []Secondary{{Writer: secondary, Backend: BackendOTLP}}, nil)
 
 	binaryEncoded, ok := writer.(BinaryFieldCounter)
 	require.True(t, ok)
@@ -154,8 +154,38 @@ func TestCoordinatorForwardsBinaryEncodedFieldsFromPrimary(t *testing.T) {
 
 func TestCoordinatorReturnsSingleWriterDirectly(t *testing.T) {
 	writer := &mockWriter{}
-	assert.Same(t, writer, NewCoordinator(writer, nil, BackendOTLP, nil))
-	assert.Same(t, writer, NewCoordinator(nil, writer, BackendOTLP, nil))
+	assert.Same(t, writer, NewCoordinator(writer, nil, nil))
+	assert.Same(t, writer, NewCoordinator(nil, []Secondary{{Writer: writer, Backend: BackendOTLP}}, nil))
+}
+
+func TestCoordinatorFansOutToEverySecondary(t *testing.T) {
+	primary := &mockWriter{}
+	otlp := &mockWriter{}
+	forward := &mockWriter{err: ErrQueueFull}
+	syslog := &mockWriter{}
+	var outcomes []Outcome
+	writer := NewCoordinator(primary, []Secondary{
+		{Writer: otlp, Backend: BackendOTLP},
+		{Writer: forward, Backend: BackendForward},
+		{Writer: syslog, Backend: BackendSyslog},
+	}, func(outcome Outcome) {
+		outcomes = append(outcomes, outcome)
+	})
+
+	entry := testCoordinatorEntry()
+	require.NoError(t, writer.Write(entry))
+	assert.Equal(t, []*model.TrapEntry{entry}, primary.entries)
+	assert.Equal(t, []*model.TrapEntry{entry}, otlp.entries)
+	assert.Empty(t, forward.entries)
+	assert.Equal(t, []*model.TrapEntry{entry}, syslog.entries)
+	require.Len(t, outcomes, 1)
+	assert.Equal(t, BackendForward, outcomes[0].Backend)
+
+	require.ErrorIs(t, writer.Close(), ErrQueueFull)
+	assert.True(t, primary.closed)
+	assert.True(t, otlp.closed)
+	assert.True(t, forward.closed)
+	assert.True(t, syslog.closed)
 }
 
 func testCoordinatorEntry() *model.TrapEntry {
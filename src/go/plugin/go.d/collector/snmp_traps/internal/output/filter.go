@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package output
+
+import (
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/model"
+)
+
+// Filter selects the entries a relay output accepts. Empty category and
+// severity sets match everything; entries without a category or severity are
+// matched as "unknown" and "notice", the same defaults the OTLP output uses.
+type Filter struct {
+	trapsOnly  bool
+	categories map[model.Category]bool
+	severities map[model.Severity]bool
+}
+
+func NewFilter(trapsOnly bool, categories, severities []string) Filter {
+	filter := Filter{trapsOnly: trapsOnly}
+	if len(categories) > 0 {
+		filter.categories = make(map[model.Category]bool, len(categories))
+		for _, category := range categories {
+			filter.categories[model.Category(category)] = true
+		}
+	}
+	if len(severities) > 0 {
+		filter.severities = make(map[model.Severity]bool, len(severities))
+		for _, severity := range severities {
+			filter.severities[model.Severity(severity)] = true
+		}
+	}
+	return filter
+}
+
+func (f Filter) Match(entry *model.TrapEntry) bool {
+	if entry == nil {
+		return false
+	}
+	if f.trapsOnly && entry.ReportType != "" && entry.ReportType != model.ReportTypeTrap {
+		return false
+	}
+	if f.categories != nil {
+		category := entry.Category
+		if category == "" {
+			category = "unknown"
+		}
+		if !f.categories[category] {
+			return false
+		}
+	}
+	if f.severities != nil {
+		severity := entry.Severity
+		if severity == "" {
+			severity = "notice"
+		}
+		if !f.severities[severity] {
+			return false
+		}
+	}
+	return true
+}
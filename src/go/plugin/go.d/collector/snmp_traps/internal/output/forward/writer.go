@@ -0,0 +1,413 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package forward re-emits received traps as SNMPv2c or SNMPv3 notifications
+// to downstream trap receivers.
+package forward
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/model"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/output"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/receiver"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/snmputils"
+)
+
+const (
+	defaultForwardPort          = 162
+	defaultForwardCommunity     = "public"
+	defaultForwardTimeout       = 5 * time.Second
+	defaultForwardRetries       = 2
+	defaultForwardQueueCapacity = 10000
+)
+
+var errNilEntry = errors.New("nil trap entry")
+
+type USMUser struct {
+	Username  string
+	EngineID  string
+	AuthProto string
+	AuthKey   string
+	PrivProto string
+	PrivKey   string
+}
+
+type Config struct {
+	Address       string
+	Version       string
+	Community     string
+	USMUser       USMUser
+	Inform        bool
+	Timeout       string
+	Retries       int
+	QueueCapacity int
+	Categories    []string
+	Severities    []string
+}
+
+// Policy is the normalized settings of one forwarding destination.
+type Policy struct {
+	host          string
+	port          uint16
+	version       gosnmp.SnmpVersion
+	community     string
+	msgFlags      gosnmp.SnmpV3MsgFlags
+	usm           usmPolicy
+	inform        bool
+	timeout       time.Duration
+	retries       int
+	queueCapacity int
+	filter        output.Filter
+}
+
+// Normalize validates one destination. name prefixes returned errors, e.g.
+// "forward.destinations[0]".
+func Normalize(name string, cfg Config) (Policy, error) {
+	host, port, err := parseForwardAddress(cfg.Address)
+	if err != nil {
+		return Policy{}, fmt.Errorf("%s.address: %w", name, err)
+	}
+	policy := Policy{
+		host:   host,
+		port:   port,
+		inform: cfg.Inform,
+		filter: output.NewFilter(true, cfg.Categories, cfg.Severities),
+	}
+
+	switch strings.ToLower(strings.TrimSpace(cfg.Version)) {
+	case "", "2c", "v2c":
+		policy.version = gosnmp.Version2c
+		policy.community = cfg.Community
+		if policy.community == "" {
+			policy.community = defaultForwardCommunity
+		}
+	case "3", "v3":
+		policy.version = gosnmp.Version3
+		if err := normalizeForwardUSMUser(&policy, cfg.USMUser); err != nil {
+			return Policy{}, fmt.Errorf("%s.usm_user: %w", name, err)
+		}
+	default:
+		return Policy{}, fmt.Errorf("%s.version: unsupported SNMP version %q (must be v2c or v3)", name, cfg.Version)
+	}
+
+	policy.timeout = defaultForwardTimeout
+	if raw := strings.TrimSpace(cfg.Timeout); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return Policy{}, fmt.Errorf("%s.timeout: %w", name, err)
+		}
+		if d <= 0 {
+			return Policy{}, fmt.Errorf("%s.timeout must be positive", name)
+		}
+		policy.timeout = d
+	}
+
+	policy.retries = cfg.Retries
+	if policy.retries == 0 {
+		policy.retries = defaultForwardRetries
+	}
+	if policy.retries < 0 {
+		return Policy{}, fmt.Errorf("%s.retries must be positive, got %d", name, cfg.Retries)
+	}
+
+	policy.queueCapacity = cfg.QueueCapacity
+	if policy.queueCapacity == 0 {
+		policy.queueCapacity = defaultForwardQueueCapacity
+	}
+	if policy.queueCapacity < 0 {
+		return Policy{}, fmt.Errorf("%s.queue_capacity must be positive, got %d", name, cfg.QueueCapacity)
+	}
+	return policy, nil
+}
+
+func normalizeForwardUSMUser(policy *Policy, user USMUser) error {
+	if err := receiver.ValidateUSMCredentials(receiver.USMUser(user)); err != nil {
+		return err
+	}
+	var engineID []byte
+	if user.EngineID != "" {
+		id, err := receiver.ParseEngineID(user.EngineID)
+		if err != nil {
+			return fmt.Errorf("engine_id: %w", err)
+		}
+		engineID = id
+	} else if !policy.inform {
+		// A trap sender is the authoritative engine, so there is nothing to
+		// discover; informs learn the receiver's engine ID on first use.
+		return errors.New("engine_id is required when forwarding SNMPv3 traps")
+	}
+
+	authProto := snmputils.ParseSNMPv3AuthProtocol(strings.ToLower(user.AuthProto))
+	privProto := snmputils.ParseSNMPv3PrivProtocol(strings.ToLower(user.PrivProto))
+	switch {
+	case authProto != gosnmp.NoAuth && privProto != gosnmp.NoPriv:
+		policy.msgFlags = gosnmp.AuthPriv
+	case authProto != gosnmp.NoAuth:
+		policy.msgFlags = gosnmp.AuthNoPriv
+	default:
+		policy.msgFlags = gosnmp.NoAuthNoPriv
+	}
+	policy.usm = usmPolicy{
+		username:  user.Username,
+		engineID:  string(engineID),
+		authProto: authProto,
+		authKey:   user.AuthKey,
+		privProto: privProto,
+		privKey:   user.PrivKey,
+	}
+	return nil
+}
+
+type usmPolicy struct {
+	username  string
+	engineID  string
+	authProto gosnmp.SnmpV3AuthProtocol
+	authKey   string
+	privProto gosnmp.SnmpV3PrivProtocol
+	privKey   string
+}
+
+// securityParameters returns fresh USM state; gosnmp mutates it while
+// discovering engines and localizing keys, so it must not outlive one writer.
+func (u usmPolicy) securityParameters() *gosnmp.UsmSecurityParameters {
+	return &gosnmp.UsmSecurityParameters{
+		UserName:                 u.username,
+		AuthenticationProtocol:   u.authProto,
+		AuthenticationPassphrase: u.authKey,
+		PrivacyProtocol:          u.privProto,
+		PrivacyPassphrase:        u.privKey,
+		AuthoritativeEngineID:    u.engineID,
+	}
+}
+
+func parseForwardAddress(raw string) (string, uint16, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", 0, errors.New("address is required")
+	}
+	host, portStr, err := net.SplitHostPort(raw)
+	if err != nil {
+		// Bare hosts, including bare IPv6 literals, use the standard trap port.
+		host = strings.Trim(raw, "[]")
+		if strings.ContainsAny(host, "/?#[] ") {
+			return "", 0, fmt.Errorf("invalid address %q", raw)
+		}
+		return host, defaultForwardPort, nil
+	}
+	if host == "" {
+		return "", 0, errors.New("address must include host")
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil || port == 0 {
+		return "", 0, fmt.Errorf("invalid port %q", portStr)
+	}
+	return host, uint16(port), nil
+}
+
+// Target returns the destination as host:port for logs.
+func (p Policy) Target() string {
+	return net.JoinHostPort(p.host, strconv.Itoa(int(p.port)))
+}
+
+type Options struct {
+	Report output.OutcomeReporter
+}
+
+// Prepare opens the destination socket. Forwarding is never authoritative:
+// failures are reported against output.BackendForward and never fail the
+// pipeline commit.
+func Prepare(policy Policy, opts Options) (*output.QueueWriter, error) {
+	client := &gosnmp.GoSNMP{
+		Target:    policy.host,
+		Port:      policy.port,
+		Transport: "udp",
+		Version:   policy.version,
+		Community: policy.community,
+		Timeout:   policy.timeout,
+		Retries:   policy.retries,
+	}
+	if policy.version == gosnmp.Version3 {
+		client.SecurityModel = gosnmp.UserSecurityModel
+		client.MsgFlags = policy.msgFlags
+		client.SecurityParameters = policy.usm.securityParameters()
+	}
+	if err := client.Connect(); err != nil {
+		return nil, fmt.Errorf("SNMP trap forward to %s: %w", policy.Target(), err)
+	}
+
+	return output.NewQueueWriter(&sender{client: client, inform: policy.inform}, output.QueueOptions{
+		Backend:  output.BackendForward,
+		Capacity: policy.queueCapacity,
+		Filter:   policy.filter,
+		Report:   opts.Report,
+	}), nil
+}
+
+type sender struct {
+	client *gosnmp.GoSNMP
+	inform bool
+}
+
+func (s *sender) Send(entry *model.TrapEntry) error {
+	if entry == nil {
+		return errNilEntry
+	}
+	_, err := s.client.SendTrap(gosnmp.SnmpTrap{
+		Variables: BuildPDUs(entry),
+		IsInform:  s.inform,
+	})
+	return err
+}
+
+func (s *sender) Close() error {
+	if s.client.Conn == nil {
+		return nil
+	}
+	return s.client.Conn.Close()
+}
+
+// BuildPDUs rebuilds the notification varbind list of entry: sysUpTime.0 and
+// snmpTrapOID.0 first, as RFC 3416 requires, followed by the received
+// varbinds. The community carried by SNMPv1 translations is never relayed,
+// and varbinds whose value cannot be re-encoded are dropped.
+func BuildPDUs(entry *model.TrapEntry) []gosnmp.SnmpPDU {
+	pdus := make([]gosnmp.SnmpPDU, 0, len(entry.Varbinds)+2)
+
+	var upTime *gosnmp.SnmpPDU
+	for _, vb := range entry.Varbinds {
+		if model.NormalizeOID(vb.OID) != model.SysUpTimeOID {
+			continue
+		}
+		if pdu, ok := toPDU(vb); ok && pdu.Type == gosnmp.TimeTicks {
+			upTime = &pdu
+		}
+		break
+	}
+	if upTime == nil {
+		upTime = &gosnmp.SnmpPDU{Name: "." + model.SysUpTimeOID, Type: gosnmp.TimeTicks, Value: uint32(0)}
+	}
+	pdus = append(pdus,
+		*upTime,
+		gosnmp.SnmpPDU{Name: "." + model.SNMPTrapOID, Type: gosnmp.ObjectIdentifier, Value: "." + model.NormalizeOID(entry.TrapOID)},
+	)
+
+	for _, vb := range entry.Varbinds {
+		oid := model.NormalizeOID(vb.OID)
+		if oid == model.SysUpTimeOID || oid == model.SNMPTrapOID || model.IsSensitiveVarbind(vb) {
+			continue
+		}
+		if pdu, ok := toPDU(vb); ok {
+			pdus = append(pdus, pdu)
+		}
+	}
+	return pdus
+}
+
+func toPDU(vb model.VarbindValue) (gosnmp.SnmpPDU, bool) {
+	pdu := gosnmp.SnmpPDU{Name: "." + model.NormalizeOID(vb.OID)}
+	switch string(vb.Type) {
+	case gosnmp.Integer.String():
+		v, ok := toInt64(vb.Value)
+		if !ok || v < math.MinInt32 || v > math.MaxInt32 {
+			return pdu, false
+		}
+		pdu.Type, pdu.Value = gosnmp.Integer, int(v)
+	case gosnmp.Counter32.String(), gosnmp.Gauge32.String(), gosnmp.TimeTicks.String(), gosnmp.Uinteger32.String():
+		v, ok := toUint64(vb.Value)
+		if !ok || v > math.MaxUint32 {
+			return pdu, false
+		}
+		pdu.Type, pdu.Value = asn1Types[string(vb.Type)], uint32(v)
+	case gosnmp.Counter64.String():
+		v, ok := toUint64(vb.Value)
+		if !ok {
+			return pdu, false
+		}
+		pdu.Type, pdu.Value = gosnmp.Counter64, v
+	case gosnmp.OctetString.String(), gosnmp.BitString.String(), gosnmp.Opaque.String():
+		switch v := vb.Value.(type) {
+		case []byte:
+			pdu.Value = v
+		case string:
+			pdu.Value = v
+		default:
+			return pdu, false
+		}
+		pdu.Type = asn1Types[string(vb.Type)]
+	case gosnmp.ObjectIdentifier.String():
+		v, ok := vb.Value.(string)
+		if !ok || !model.IsNumericOID(model.NormalizeOID(v)) {
+			return pdu, false
+		}
+		pdu.Type, pdu.Value = gosnmp.ObjectIdentifier, "."+model.NormalizeOID(v)
+	case gosnmp.IPAddress.String():
+		v, ok := vb.Value.(string)
+		if !ok || net.ParseIP(v).To4() == nil {
+			return pdu, false
+		}
+		pdu.Type, pdu.Value = gosnmp.IPAddress, v
+	case gosnmp.OpaqueFloat.String():
+		v, ok := vb.Value.(float64)
+		if !ok {
+			return pdu, false
+		}
+		pdu.Type, pdu.Value = gosnmp.OpaqueFloat, float32(v)
+	case gosnmp.OpaqueDouble.String():
+		v, ok := vb.Value.(float64)
+		if !ok {
+			return pdu, false
+		}
+		pdu.Type, pdu.Value = gosnmp.OpaqueDouble, v
+	case gosnmp.Null.String(), gosnmp.NoSuchObject.String(), gosnmp.NoSuchInstance.String(), gosnmp.EndOfMibView.String():
+		pdu.Type, pdu.Value = gosnmp.Null, nil
+	default:
+		return pdu, false
+	}
+	return pdu, true
+}
+
+var asn1Types = map[string]gosnmp.Asn1BER{
+	gosnmp.Counter32.String():   gosnmp.Counter32,
+	gosnmp.Gauge32.String():     gosnmp.Gauge32,
+	gosnmp.TimeTicks.String():   gosnmp.TimeTicks,
+	gosnmp.Uinteger32.String():  gosnmp.Uinteger32,
+	gosnmp.OctetString.String(): gosnmp.OctetString,
+	gosnmp.BitString.String():   gosnmp.BitString,
+	gosnmp.Opaque.String():      gosnmp.Opaque,
+}
+
+func toInt64(value any) (int64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return v, true
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
+
+func toUint64(value any) (uint64, bool) {
+	switch v := value.(type) {
+	case uint64:
+		return v, true
+	case int64:
+		if v < 0 {
+			return 0, false
+		}
+		return uint64(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forward
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/model"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]struct {
+		cfg     Config
+		wantErr string
+		check   func(t *testing.T, policy Policy)
+	}{
+		"v2c defaults": {
+			cfg: Config{Address: "192.0.2.10"},
+			check: func(t *testing.T, policy Policy) {
+				assert.Equal(t, "192.0.2.10:162", policy.Target())
+				assert.Equal(t, gosnmp.Version2c, policy.version)
+				assert.Equal(t, "public", policy.community)
+				assert.Equal(t, 5*time.Second, policy.timeout)
+				assert.Equal(t, 2, policy.retries)
+				assert.Equal(t, 10000, policy.queueCapacity)
+			},
+		},
+		"bare ipv6 host": {
+			cfg: Config{Address: "2001:db8::1"},
+			check: func(t *testing.T, policy Policy) {
+				assert.Equal(t, "[2001:db8::1]:162", policy.Target())
+			},
+		},
+		"v3 trap with engine id": {
+			cfg: Config{
+				Address: "collector.example:1162",
+				Version: "v3",
+				USMUser: USMUser{
+					Username:  "relay",
+					EngineID:  "80001f8880e9630000d61ff449",
+					AuthProto: "sha",
+					AuthKey:   "authpass1",
+					PrivProto: "aes",
+					PrivKey:   "privpass1",
+				},
+			},
+			check: func(t *testing.T, policy Policy) {
+				assert.Equal(t, gosnmp.Version3, policy.version)
+				assert.Equal(t, gosnmp.AuthPriv, policy.msgFlags)
+				assert.Equal(t, "relay", policy.usm.username)
+				assert.NotEmpty(t, policy.usm.engineID)
+			},
+		},
+		"v3 inform without engine id": {
+			cfg: Config{
+				Address: "collector.example",
+				Version: "v3",
+				Inform:  true,
+				USMUser: USMUser{Username: "relay", AuthProto: "sha", AuthKey: "authpass1"},
+			},
+			check: func(t *testing.T, policy Policy) {
+				assert.Equal(t, gosnmp.AuthNoPriv, policy.msgFlags)
+				assert.Empty(t, policy.usm.engineID)
+			},
+		},
+		"v3 trap without engine id": {
+			cfg:     Config{Address: "collector.example", Version: "v3", USMUser: USMUser{Username: "relay"}},
+			wantErr: "forward.destinations[0].usm_user: engine_id is required when forwarding SNMPv3 traps",
+		},
+		"v1 rejected": {
+			cfg:     Config{Address: "collector.example", Version: "v1"},
+			wantErr: `forward.destinations[0].version: unsupported SNMP version "v1"`,
+		},
+		"missing address": {
+			cfg:     Config{},
+			wantErr: "forward.destinations[0].address: address is required",
+		},
+		"invalid port": {
+			cfg:     Config{Address: "collector.example:0"},
+			wantErr: `forward.destinations[0].address: invalid port "0"`,
+		},
+		"invalid timeout": {
+			cfg:     Config{Address: "collector.example", Timeout: "-1s"},
+			wantErr: "forward.destinations[0].timeout must be positive",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			policy, err := Normalize("forward.destinations[0]", test.cfg)
+			if test.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			test.check(t, policy)
+		})
+	}
+}
+
+func TestBuildPDUs(t *testing.T) {
+	entry := &model.TrapEntry{
+		TrapOID: "1.3.6.1.6.3.1.1.5.3",
+		Varbinds: []model.VarbindValue{
+			{OID: "1.3.6.1.2.1.2.2.1.1.3", Type: model.ASN1Type(gosnmp.Integer.String()), Value: int64(3)},
+			{OID: ".1.3.6.1.2.1.1.3.0", Type: model.ASN1Type(gosnmp.TimeTicks.String()), Value: uint64(4200)},
+			{OID: "1.3.6.1.6.3.18.1.4.0", Name: "snmpTrapCommunity.0", Type: model.ASN1Type(gosnmp.OctetString.String()), Value: "secret"},
+			{OID: "1.3.6.1.2.1.2.2.1.2.3", Type: model.ASN1Type(gosnmp.OctetString.String()), Value: "eth0"},
+			{OID: "1.3.6.1.2.1.31.1.1.1.6.3", Type: model.ASN1Type(gosnmp.Counter64.String()), Value: uint64(1 << 40)},
+			{OID: "1.3.6.1.4.1.9.9.1", Type: model.ASN1Type(gosnmp.Integer.String()), Value: int64(1 << 40)},
+			{OID: "1.3.6.1.4.1.9.9.2", Type: model.ASN1Type(gosnmp.IPAddress.String()), Value: "not-an-ip"},
+		},
+	}
+
+	assert.Equal(t, []gosnmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(4200)},
+		{Name: ".1.3.6.1.6.3.1.1.4.1.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.6.3.1.1.5.3"},
+		{Name: ".1.3.6.1.2.1.2.2.1.1.3", Type: gosnmp.Integer, Value: 3},
+		{Name: ".1.3.6.1.2.1.2.2.1.2.3", Type: gosnmp.OctetString, Value: "eth0"},
+		{Name: ".1.3.6.1.2.1.31.1.1.1.6.3", Type: gosnmp.Counter64, Value: uint64(1 << 40)},
+	}, BuildPDUs(entry))
+}
+
+func TestBuildPDUsDefaultsSysUpTime(t *testing.T) {
+	pdus := BuildPDUs(&model.TrapEntry{TrapOID: "1.3.6.1.6.3.1.1.5.1"})
+
+	require.Len(t, pdus, 2)
+	assert.Equal(t, gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(0)}, pdus[0])
+}
+
+func TestPrepareSendsV2cTrap(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	policy, err := Normalize("forward.destinations[0]", Config{
+		Address:   conn.LocalAddr().String(),
+		Community: "relay",
+	})
+	require.NoError(t, err)
+
+	writer, err := Prepare(policy, Options{})
+	require.NoError(t, err)
+	require.NoError(t, writer.Start())
+	defer func() { _ = writer.Close() }()
+
+	require.NoError(t, writer.Write(&model.TrapEntry{
+		ReportType: model.ReportTypeTrap,
+		TrapOID:    "1.3.6.1.6.3.1.1.5.3",
+		Varbinds: []model.VarbindValue{
+			{OID: "1.3.6.1.2.1.2.2.1.1.3", Type: model.ASN1Type(gosnmp.Integer.String()), Value: int64(3)},
+		},
+	}))
+	require.NoError(t, writer.Flush())
+
+	buf := make([]byte, 4096)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+
+	packet, err := (&gosnmp.GoSNMP{}).SnmpDecodePacket(buf[:n])
+	require.NoError(t, err)
+	assert.Equal(t, gosnmp.Version2c, packet.Version)
+	assert.Equal(t, "relay", packet.Community)
+	assert.Equal(t, gosnmp.SNMPv2Trap, packet.PDUType)
+	require.Len(t, packet.Variables, 3)
+	assert.Equal(t, ".1.3.6.1.6.3.1.1.5.3", packet.Variables[1].Value)
+	assert.Equal(t, 3, packet.Variables[2].Value)
+}
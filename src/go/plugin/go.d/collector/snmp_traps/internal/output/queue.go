@@ -0,0 +1,242 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package output
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/model"
+)
+
+// Sender delivers one entry to a relay target. Send is only called from the
+// queue worker goroutine; Close is called once, after the worker has exited.
+type Sender interface {
+	Send(entry *model.TrapEntry) error
+	Close() error
+}
+
+type QueueOptions struct {
+	Backend  Backend
+	Capacity int
+	Filter   Filter
+	Report   OutcomeReporter
+}
+
+// QueueWriter decouples a relay Sender from the trap pipeline: Write only
+// enqueues, so a slow or unreachable target sheds entries with ErrQueueFull
+// instead of stalling the authoritative output.
+type QueueWriter struct {
+	sender  Sender
+	backend Backend
+	filter  Filter
+	report  OutcomeReporter
+
+	queue   chan *model.TrapEntry
+	flushCh chan chan error
+	closeCh chan chan error
+	doneCh  chan struct{}
+
+	mu       sync.Mutex
+	started  bool
+	closed   bool
+	lastErr  error
+	lastErrM sync.Mutex
+}
+
+var _ Writer = (*QueueWriter)(nil)
+
+func NewQueueWriter(sender Sender, opts QueueOptions) *QueueWriter {
+	return &QueueWriter{
+		sender:  sender,
+		backend: opts.Backend,
+		filter:  opts.Filter,
+		report:  opts.Report,
+		queue:   make(chan *model.TrapEntry, opts.Capacity),
+		flushCh: make(chan chan error),
+		closeCh: make(chan chan error),
+		doneCh:  make(chan struct{}),
+	}
+}
+
+func (w *QueueWriter) Start() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return ErrClosed
+	}
+	if w.started {
+		return nil
+	}
+	w.started = true
+	go w.worker()
+	return nil
+}
+
+// Write enqueues entry when it passes the writer filter. Entries rejected by
+// the filter are not failures and return nil.
+func (w *QueueWriter) Write(entry *model.TrapEntry) error {
+	if !w.filter.Match(entry) {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return ErrClosed
+	}
+	if !w.started {
+		return ErrNotStarted
+	}
+	select {
+	case w.queue <- entry:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+func (w *QueueWriter) Flush() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return ErrClosed
+	}
+	if !w.started {
+		w.mu.Unlock()
+		return ErrNotStarted
+	}
+
+	replyCh := make(chan error, 1)
+	select {
+	case w.flushCh <- replyCh:
+		w.mu.Unlock()
+	case <-w.doneCh:
+		w.mu.Unlock()
+		return ErrClosed
+	}
+	return <-replyCh
+}
+
+func (w *QueueWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return w.getLastErr()
+	}
+	w.closed = true
+	if !w.started {
+		w.mu.Unlock()
+		err := w.sender.Close()
+		w.setLastErr(err)
+		return err
+	}
+
+	replyCh := make(chan error, 1)
+	select {
+	case w.closeCh <- replyCh:
+		w.mu.Unlock()
+	case <-w.doneCh:
+		w.mu.Unlock()
+		return w.getLastErr()
+	}
+	err := <-replyCh
+	<-w.doneCh
+	if closeErr := w.sender.Close(); err == nil {
+		err = closeErr
+	}
+	w.setLastErr(err)
+	return err
+}
+
+func (w *QueueWriter) worker() {
+	var activeReplyCh chan error
+
+	defer func() {
+		if v := recover(); v != nil {
+			err := fmt.Errorf("SNMP trap relay writer panic: %v", v)
+			w.setLastErr(err)
+			w.mu.Lock()
+			w.closed = true
+			w.mu.Unlock()
+			w.reportPending(err)
+			_ = w.sender.Close()
+			if activeReplyCh != nil {
+				activeReplyCh <- err
+			}
+		}
+		close(w.doneCh)
+	}()
+
+	for {
+		select {
+		case entry := <-w.queue:
+			_ = w.send(entry)
+
+		case replyCh := <-w.flushCh:
+			activeReplyCh = replyCh
+			replyCh <- w.drainQueue()
+			activeReplyCh = nil
+
+		case replyCh := <-w.closeCh:
+			activeReplyCh = replyCh
+			replyCh <- w.drainQueue()
+			activeReplyCh = nil
+			return
+		}
+	}
+}
+
+func (w *QueueWriter) drainQueue() error {
+	var firstErr error
+	for {
+		select {
+		case entry := <-w.queue:
+			if err := w.send(entry); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		default:
+			return firstErr
+		}
+	}
+}
+
+func (w *QueueWriter) send(entry *model.TrapEntry) error {
+	err := w.sender.Send(entry)
+	if err != nil {
+		w.report.Report(Outcome{
+			Backend:       w.backend,
+			Stage:         StageExport,
+			FailedEntries: 1,
+			Err:           err,
+		})
+	}
+	return err
+}
+
+func (w *QueueWriter) reportPending(err error) {
+	// The entry being sent when the worker panicked is lost as well.
+	pending := uint64(len(w.queue)) + 1
+	w.report.Report(Outcome{
+		Backend:       w.backend,
+		Stage:         StageWorker,
+		FailedEntries: pending,
+		Err:           err,
+	})
+}
+
+func (w *QueueWriter) setLastErr(err error) {
+	if err == nil {
+		return
+	}
+	w.lastErrM.Lock()
+	if w.lastErr == nil {
+		w.lastErr = err
+	}
+	w.lastErrM.Unlock()
+}
+
+func (w *QueueWriter) getLastErr() error {
+	w.lastErrM.Lock()
+	defer w.lastErrM.Unlock()
+	return w.lastErr
+}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package output
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSender struct {
+	mu      sync.Mutex
+	entries []*model.TrapEntry
+	err     error
+	block   chan struct{}
+	closed  int
+}
+
+func (s *mockSender) Send(entry *model.TrapEntry) error {
+	if s.block != nil {
+		<-s.block
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *mockSender) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed++
+	return nil
+}
+
+func TestQueueWriterDeliversOnFlushAndClose(t *testing.T) {
+	sender := &mockSender{}
+	writer := NewQueueWriter(sender, QueueOptions{Backend: BackendSyslog, Capacity: 8})
+	require.ErrorIs(t, writer.Write(testCoordinatorEntry()), ErrNotStarted)
+	require.NoError(t, writer.Start())
+
+	first, second := testCoordinatorEntry(), testCoordinatorEntry()
+	require.NoError(t, writer.Write(first))
+	require.NoError(t, writer.Flush())
+	require.NoError(t, writer.Write(second))
+	require.NoError(t, writer.Close())
+
+	assert.Equal(t, []*model.TrapEntry{first, second}, sender.entries)
+	assert.Equal(t, 1, sender.closed)
+	assert.ErrorIs(t, writer.Write(first), ErrClosed)
+}
+
+func TestQueueWriterReportsSendFailures(t *testing.T) {
+	sendErr := errors.New("unreachable")
+	var outcomes []Outcome
+	writer := NewQueueWriter(&mockSender{err: sendErr}, QueueOptions{
+		Backend:  BackendForward,
+		Capacity: 8,
+		Report: func(outcome Outcome) {
+			outcomes = append(outcomes, outcome)
+		},
+	})
+	require.NoError(t, writer.Start())
+	require.NoError(t, writer.Write(testCoordinatorEntry()))
+
+	// The worker may send before Flush drains the queue, so the failure is
+	// only guaranteed to surface through the reporter.
+	_ = writer.Flush()
+	require.Len(t, outcomes, 1)
+	assert.Equal(t, Outcome{
+		Backend:       BackendForward,
+		Stage:         StageExport,
+		FailedEntries: 1,
+		Err:           sendErr,
+	}, outcomes[0])
+	require.NoError(t, writer.Close())
+}
+
+func TestQueueWriterShedsWhenFull(t *testing.T) {
+	sender := &mockSender{block: make(chan struct{})}
+	writer := NewQueueWriter(sender, QueueOptions{Backend: BackendForward, Capacity: 1})
+	require.NoError(t, writer.Start())
+
+	// The worker holds the first entry in Send; the second fills the queue.
+	require.NoError(t, writer.Write(testCoordinatorEntry()))
+	require.Eventually(t, func() bool { return len(writer.queue) == 0 }, time.Second, 5*time.Millisecond)
+	require.NoError(t, writer.Write(testCoordinatorEntry()))
+	assert.ErrorIs(t, writer.Write(testCoordinatorEntry()), ErrQueueFull)
+
+	close(sender.block)
+	require.NoError(t, writer.Close())
+	assert.Len(t, sender.entries, 2)
+}
+
+func TestQueueWriterSkipsFilteredEntries(t *testing.T) {
+	sender := &mockSender{}
+	writer := NewQueueWriter(sender, QueueOptions{
+		Backend:  BackendForward,
+		Capacity: 8,
+		Filter:   NewFilter(true, []string{"security"}, []string{"crit", "alert"}),
+	})
+	require.NoError(t, writer.Start())
+
+	match := &model.TrapEntry{ReportType: model.ReportTypeTrap, Category: "security", Severity: "crit"}
+	for _, entry := range []*model.TrapEntry{
+		match,
+		{ReportType: model.ReportTypeTrap, Category: "security", Severity: "info"},
+		{ReportType: model.ReportTypeTrap, Category: "state_change", Severity: "crit"},
+		{ReportType: model.ReportTypeDedupSummary, Category: "security", Severity: "crit"},
+	} {
+		require.NoError(t, writer.Write(entry))
+	}
+	require.NoError(t, writer.Close())
+	assert.Equal(t, []*model.TrapEntry{match}, sender.entries)
+}
+
+func TestFilterDefaults(t *testing.T) {
+	assert.True(t, NewFilter(false, nil, nil).Match(&model.TrapEntry{ReportType: model.ReportTypeDecodeError}))
+	assert.False(t, NewFilter(true, nil, nil).Match(&model.TrapEntry{ReportType: model.ReportTypeDecodeError}))
+	assert.True(t, NewFilter(true, []string{"unknown"}, []string{"notice"}).Match(&model.TrapEntry{}))
+	assert.False(t, NewFilter(false, nil, nil).Match(nil))
+}
@@ -0,0 +1,398 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package syslog relays trap entries to a syslog collector as RFC 5424
+// messages over UDP, TCP or TLS.
+package syslog
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/pkg/tlscfg"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/model"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/output"
+)
+
+const (
+	defaultSyslogEndpoint      = "udp://127.0.0.1:514"
+	defaultSyslogFacility      = "local0"
+	defaultSyslogAppName       = "netdata-snmptrap"
+	defaultSyslogSDID          = "snmptrap@32473"
+	defaultSyslogTimeout       = 5 * time.Second
+	defaultSyslogQueueCapacity = 10000
+	maxSyslogAppNameLen        = 48
+	maxSyslogHostnameLen       = 255
+	maxSyslogMsgIDLen          = 32
+)
+
+var errNilEntry = errors.New("nil trap entry")
+
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5,
+	"lpr": 6, "news": 7, "uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19,
+	"local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+var syslogSeverities = map[model.Severity]int{
+	"emerg": 0, "alert": 1, "crit": 2, "err": 3,
+	"warning": 4, "notice": 5, "info": 6, "debug": 7,
+}
+
+type Config struct {
+	Endpoint      string
+	Facility      string
+	AppName       string
+	SDID          string
+	Timeout       string
+	QueueCapacity int
+	TLS           tlscfg.TLSConfig
+	Categories    []string
+	Severities    []string
+}
+
+type Policy struct {
+	network       string
+	address       string
+	facility      int
+	appName       string
+	sdID          string
+	timeout       time.Duration
+	queueCapacity int
+	tls           tlscfg.TLSConfig
+	filter        output.Filter
+}
+
+func Normalize(cfg Config) (Policy, error) {
+	network, address, err := parseSyslogEndpoint(cfg.Endpoint)
+	if err != nil {
+		return Policy{}, fmt.Errorf("syslog.endpoint: %w", err)
+	}
+
+	facilityName := strings.ToLower(strings.TrimSpace(cfg.Facility))
+	if facilityName == "" {
+		facilityName = defaultSyslogFacility
+	}
+	facility, ok := syslogFacilities[facilityName]
+	if !ok {
+		return Policy{}, fmt.Errorf("syslog.facility: unknown facility %q", cfg.Facility)
+	}
+
+	appName := cfg.AppName
+	if appName == "" {
+		appName = defaultSyslogAppName
+	}
+	if !isPrintASCII(appName) || len(appName) > maxSyslogAppNameLen {
+		return Policy{}, fmt.Errorf("syslog.app_name must be 1-%d printable ASCII characters without spaces", maxSyslogAppNameLen)
+	}
+
+	sdID := cfg.SDID
+	if sdID == "" {
+		sdID = defaultSyslogSDID
+	}
+	if err := validateSDID(sdID); err != nil {
+		return Policy{}, fmt.Errorf("syslog.sd_id: %w", err)
+	}
+
+	timeout := defaultSyslogTimeout
+	if raw := strings.TrimSpace(cfg.Timeout); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return Policy{}, fmt.Errorf("syslog.timeout: %w", err)
+		}
+		if d <= 0 {
+			return Policy{}, errors.New("syslog.timeout must be positive")
+		}
+		timeout = d
+	}
+
+	queueCapacity := cfg.QueueCapacity
+	if queueCapacity == 0 {
+		queueCapacity = defaultSyslogQueueCapacity
+	}
+	if queueCapacity < 0 {
+		return Policy{}, fmt.Errorf("syslog.queue_capacity must be positive, got %d", cfg.QueueCapacity)
+	}
+
+	return Policy{
+		network:       network,
+		address:       address,
+		facility:      facility,
+		appName:       appName,
+		sdID:          sdID,
+		timeout:       timeout,
+		queueCapacity: queueCapacity,
+		tls:           cfg.TLS,
+		filter:        output.NewFilter(false, cfg.Categories, cfg.Severities),
+	}, nil
+}
+
+// Target returns the endpoint as network://host:port for logs.
+func (p Policy) Target() string { return p.network + "://" + p.address }
+
+func parseSyslogEndpoint(raw string) (string, string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		raw = defaultSyslogEndpoint
+	}
+	network, address := "udp", raw
+	if strings.Contains(raw, "://") {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return "", "", err
+		}
+		switch u.Scheme {
+		case "udp", "tcp", "tls":
+			network = u.Scheme
+		default:
+			return "", "", fmt.Errorf("unsupported scheme %q (must be udp, tcp or tls)", u.Scheme)
+		}
+		if u.Path != "" || u.RawQuery != "" || u.Fragment != "" {
+			return "", "", errors.New("path, query and fragment are not supported")
+		}
+		address = u.Host
+	}
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", "", fmt.Errorf("endpoint must be host:port: %w", err)
+	}
+	if host == "" || port == "" {
+		return "", "", errors.New("endpoint must include host and port")
+	}
+	return network, address, nil
+}
+
+func validateSDID(id string) error {
+	if id == "" || len(id) > 32 {
+		return errors.New("must be 1-32 characters")
+	}
+	if !isPrintASCII(id) || strings.ContainsAny(id, `="]`) {
+		return errors.New(`must be printable ASCII without spaces, '=', ']' or '"'`)
+	}
+	if !strings.Contains(id, "@") {
+		return errors.New("must be name@<private enterprise number>")
+	}
+	return nil
+}
+
+type Options struct {
+	Report output.OutcomeReporter
+}
+
+// Prepare validates the TLS settings and returns a non-authoritative writer.
+// The connection is established lazily by the worker and re-established after
+// stream write failures, so a syslog collector restart does not stop relaying.
+func Prepare(policy Policy, opts Options) (*output.QueueWriter, error) {
+	s := &sender{policy: policy}
+	if policy.network == "tls" {
+		tlsConfig, err := tlscfg.NewTLSConfig(policy.tls)
+		if err != nil {
+			return nil, fmt.Errorf("syslog TLS config: %w", err)
+		}
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		}
+		tlsConfig.MinVersion = tls.VersionTLS12
+		if tlsConfig.ServerName == "" {
+			host, _, _ := net.SplitHostPort(policy.address)
+			tlsConfig.ServerName = host
+		}
+		s.tlsConfig = tlsConfig
+	}
+	return output.NewQueueWriter(s, output.QueueOptions{
+		Backend:  output.BackendSyslog,
+		Capacity: policy.queueCapacity,
+		Filter:   policy.filter,
+		Report:   opts.Report,
+	}), nil
+}
+
+type sender struct {
+	policy    Policy
+	tlsConfig *tls.Config
+	conn      net.Conn
+}
+
+func (s *sender) Send(entry *model.TrapEntry) error {
+	if entry == nil {
+		return errNilEntry
+	}
+	msg := FormatMessage(entry, s.policy.facility, s.policy.appName, s.policy.sdID)
+	if s.policy.network != "udp" {
+		// RFC 6587 / RFC 5425 octet-counting framing.
+		msg = strconv.Itoa(len(msg)) + " " + msg
+	}
+	if s.conn == nil {
+		if err := s.dial(); err != nil {
+			return err
+		}
+	}
+	_ = s.conn.SetWriteDeadline(time.Now().Add(s.policy.timeout))
+	if _, err := s.conn.Write([]byte(msg)); err != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+		return err
+	}
+	return nil
+}
+
+func (s *sender) dial() error {
+	dialer := &net.Dialer{Timeout: s.policy.timeout}
+	var conn net.Conn
+	var err error
+	switch s.policy.network {
+	case "tls":
+		conn, err = tls.DialWithDialer(dialer, "tcp", s.policy.address, s.tlsConfig)
+	default:
+		conn, err = dialer.Dial(s.policy.network, s.policy.address)
+	}
+	if err != nil {
+		return fmt.Errorf("syslog connect to %s: %w", s.policy.Target(), err)
+	}
+	s.conn = conn
+	return nil
+}
+
+func (s *sender) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// FormatMessage renders entry as an RFC 5424 message without transport
+// framing. The trap identity goes into one structured data element so
+// collectors can index it without parsing the free-form message.
+func FormatMessage(entry *model.TrapEntry, facility int, appName, sdID string) string {
+	severity, ok := syslogSeverities[entry.Severity]
+	if !ok {
+		severity = syslogSeverities["notice"]
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<")
+	sb.WriteString(strconv.Itoa(facility*8 + severity))
+	sb.WriteString(">1 ")
+	if entry.ReceivedRealtimeUsec > 0 {
+		sb.WriteString(time.UnixMicro(entry.ReceivedRealtimeUsec).UTC().Format("2006-01-02T15:04:05.000000Z"))
+	} else {
+		sb.WriteString("-")
+	}
+	sb.WriteString(" ")
+	sb.WriteString(headerField(syslogHostname(entry), maxSyslogHostnameLen))
+	sb.WriteString(" ")
+	sb.WriteString(headerField(appName, maxSyslogAppNameLen))
+	sb.WriteString(" - ")
+	sb.WriteString(headerField(syslogMsgID(entry), maxSyslogMsgIDLen))
+	sb.WriteString(" ")
+	writeStructuredData(&sb, entry, sdID)
+	if entry.Message != "" {
+		sb.WriteString(" ")
+		sb.WriteString(entry.Message)
+	}
+	return sb.String()
+}
+
+func syslogHostname(entry *model.TrapEntry) string {
+	if entry.DeviceHostname != "" {
+		return entry.DeviceHostname
+	}
+	if entry.SourceIP != "" {
+		return entry.SourceIP
+	}
+	if host, _, err := net.SplitHostPort(entry.SourceUDPPeer); err == nil {
+		return host
+	}
+	return ""
+}
+
+func syslogMsgID(entry *model.TrapEntry) string {
+	switch entry.ReportType {
+	case model.ReportTypeDedupSummary, model.ReportTypeDecodeError:
+		return string(entry.ReportType)
+	}
+	if entry.Category == "" {
+		return "unknown"
+	}
+	return string(entry.Category)
+}
+
+func writeStructuredData(sb *strings.Builder, entry *model.TrapEntry, sdID string) {
+	params := [][2]string{
+		{"oid", entry.TrapOID},
+		{"name", entry.TrapName},
+		{"category", string(entry.Category)},
+		{"severity", string(entry.Severity)},
+		{"source", entry.SourceIP},
+		{"version", string(entry.SnmpVersion)},
+		{"pdu", string(entry.PduType)},
+		{"job", entry.JobName},
+	}
+	if entry.SummaryCounts != nil {
+		params = append(params, [2]string{"suppressed", strconv.FormatInt(entry.SummaryCounts.TotalSuppressed, 10)})
+	}
+	if entry.DecodeError != nil {
+		params = append(params, [2]string{"decode_error", entry.DecodeError.Kind})
+	}
+
+	sb.WriteString("[")
+	sb.WriteString(sdID)
+	for _, param := range params {
+		if param[1] == "" {
+			continue
+		}
+		sb.WriteString(" ")
+		sb.WriteString(param[0])
+		sb.WriteString(`="`)
+		writeParamValue(sb, param[1])
+		sb.WriteString(`"`)
+	}
+	sb.WriteString("]")
+}
+
+func writeParamValue(sb *strings.Builder, value string) {
+	for _, r := range value {
+		if r == '"' || r == '\\' || r == ']' {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+}
+
+// headerField makes value a valid RFC 5424 header field: printable ASCII, no
+// spaces, bounded length, and NILVALUE when empty.
+func headerField(value string, maxLen int) string {
+	var sb strings.Builder
+	for i := 0; i < len(value) && sb.Len() < maxLen; i++ {
+		c := value[i]
+		if c >= 33 && c <= 126 {
+			sb.WriteByte(c)
+		} else {
+			sb.WriteByte('_')
+		}
+	}
+	if sb.Len() == 0 {
+		return "-"
+	}
+	return sb.String()
+}
+
+func isPrintASCII(value string) bool {
+	if value == "" {
+		return false
+	}
+	for i := 0; i < len(value); i++ {
+		if value[i] < 33 || value[i] > 126 {
+			return false
+		}
+	}
+	return true
+}
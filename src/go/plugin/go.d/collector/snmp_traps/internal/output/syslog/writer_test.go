@@ -0,0 +1,217 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package syslog
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/snmp_traps/internal/model"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]struct {
+		cfg     Config
+		wantErr string
+		check   func(t *testing.T, policy Policy)
+	}{
+		"defaults": {
+			cfg: Config{},
+			check: func(t *testing.T, policy Policy) {
+				assert.Equal(t, "udp://127.0.0.1:514", policy.Target())
+				assert.Equal(t, 16, policy.facility)
+				assert.Equal(t, "netdata-snmptrap", policy.appName)
+				assert.Equal(t, "snmptrap@32473", policy.sdID)
+				assert.Equal(t, 5*time.Second, policy.timeout)
+				assert.Equal(t, 10000, policy.queueCapacity)
+			},
+		},
+		"bare host:port is udp": {
+			cfg: Config{Endpoint: "syslog.example:1514", Facility: "LOCAL5"},
+			check: func(t *testing.T, policy Policy) {
+				assert.Equal(t, "udp://syslog.example:1514", policy.Target())
+				assert.Equal(t, 21, policy.facility)
+			},
+		},
+		"tls endpoint": {
+			cfg: Config{Endpoint: "tls://syslog.example:6514"},
+			check: func(t *testing.T, policy Policy) {
+				assert.Equal(t, "tls", policy.network)
+			},
+		},
+		"unsupported scheme": {
+			cfg:     Config{Endpoint: "http://syslog.example:514"},
+			wantErr: `syslog.endpoint: unsupported scheme "http"`,
+		},
+		"missing port": {
+			cfg:     Config{Endpoint: "tcp://syslog.example"},
+			wantErr: "syslog.endpoint: endpoint must be host:port",
+		},
+		"unknown facility": {
+			cfg:     Config{Facility: "local9"},
+			wantErr: `syslog.facility: unknown facility "local9"`,
+		},
+		"app name with spaces": {
+			cfg:     Config{AppName: "snmp traps"},
+			wantErr: "syslog.app_name must be",
+		},
+		"sd id without enterprise number": {
+			cfg:     Config{SDID: "snmptrap"},
+			wantErr: "syslog.sd_id: must be name@<private enterprise number>",
+		},
+		"invalid timeout": {
+			cfg:     Config{Timeout: "0s"},
+			wantErr: "syslog.timeout must be positive",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			policy, err := Normalize(test.cfg)
+			if test.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			test.check(t, policy)
+		})
+	}
+}
+
+func TestFormatMessage(t *testing.T) {
+	tests := map[string]struct {
+		entry *model.TrapEntry
+		want  string
+	}{
+		"trap": {
+			entry: &model.TrapEntry{
+				JobName:              "local",
+				ReportType:           model.ReportTypeTrap,
+				ReceivedRealtimeUsec: time.Date(2026, 3, 1, 12, 30, 45, 123456000, time.UTC).UnixMicro(),
+				TrapOID:              "1.3.6.1.6.3.1.1.5.3",
+				TrapName:             "IF-MIB::linkDown",
+				Category:             "state_change",
+				Severity:             "warning",
+				Message:              "Interface eth0 went down",
+				SourceIP:             "192.0.2.10",
+				DeviceHostname:       "core-sw1",
+				SnmpVersion:          "v2c",
+				PduType:              "trap",
+			},
+			want: `<132>1 2026-03-01T12:30:45.123456Z core-sw1 netdata-snmptrap - state_change ` +
+				`[snmptrap@32473 oid="1.3.6.1.6.3.1.1.5.3" name="IF-MIB::linkDown" category="state_change" ` +
+				`severity="warning" source="192.0.2.10" version="v2c" pdu="trap" job="local"] Interface eth0 went down`,
+		},
+		"escapes structured data and falls back to peer host": {
+			entry: &model.TrapEntry{
+				ReportType:    model.ReportTypeTrap,
+				TrapName:      `vendor "x"]\`,
+				SourceUDPPeer: "198.51.100.7:40000",
+			},
+			want: `<133>1 - 198.51.100.7 netdata-snmptrap - unknown [snmptrap@32473 name="vendor \"x\"\]\\"]`,
+		},
+		"dedup summary": {
+			entry: &model.TrapEntry{
+				ReportType:    model.ReportTypeDedupSummary,
+				Severity:      "info",
+				SummaryCounts: &model.DedupSummary{TotalSuppressed: 12},
+			},
+			want: `<134>1 - - netdata-snmptrap - ` + string(model.ReportTypeDedupSummary) +
+				` [snmptrap@32473 severity="info" suppressed="12"]`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, FormatMessage(test.entry, 16, "netdata-snmptrap", "snmptrap@32473"))
+		})
+	}
+}
+
+func TestPrepareSendsUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	policy, err := Normalize(Config{Endpoint: "udp://" + conn.LocalAddr().String()})
+	require.NoError(t, err)
+	writer, err := Prepare(policy, Options{})
+	require.NoError(t, err)
+	require.NoError(t, writer.Start())
+	defer func() { _ = writer.Close() }()
+
+	require.NoError(t, writer.Write(testEntry()))
+	require.NoError(t, writer.Flush())
+
+	buf := make([]byte, 4096)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Equal(t, FormatMessage(testEntry(), 16, policy.appName, policy.sdID), string(buf[:n]))
+}
+
+func TestPrepareSendsTCPWithOctetCounting(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = ln.Close() }()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		r := bufio.NewReader(conn)
+		length, err := r.ReadString(' ')
+		if err != nil {
+			return
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(length))
+		if err != nil {
+			return
+		}
+		msg := make([]byte, n)
+		if _, err := io.ReadFull(r, msg); err != nil {
+			return
+		}
+		received <- string(msg)
+	}()
+
+	policy, err := Normalize(Config{Endpoint: "tcp://" + ln.Addr().String(), Facility: "daemon"})
+	require.NoError(t, err)
+	writer, err := Prepare(policy, Options{})
+	require.NoError(t, err)
+	require.NoError(t, writer.Start())
+	defer func() { _ = writer.Close() }()
+
+	require.NoError(t, writer.Write(testEntry()))
+	require.NoError(t, writer.Flush())
+
+	select {
+	case msg := <-received:
+		assert.Equal(t, FormatMessage(testEntry(), 3, policy.appName, policy.sdID), msg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("syslog message was not received")
+	}
+}
+
+func testEntry() *model.TrapEntry {
+	return &model.TrapEntry{
+		ReportType: model.ReportTypeTrap,
+		TrapOID:    "1.3.6.1.6.3.1.1.5.3",
+		Category:   "state_change",
+		Severity:   "warning",
+		SourceIP:   "192.0.2.10",
+		Message:    "Interface eth0 went down",
+	}
+}
@@ -34,6 +34,8 @@ type Backend uint8
 const (
 	BackendJournal Backend = iota + 1
 	BackendOTLP
+	BackendForward
+	BackendSyslog
 )
 
 type Stage uint8
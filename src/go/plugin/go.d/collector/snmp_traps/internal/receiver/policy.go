@@ -226,38 +226,50 @@ func ValidateUSMUsers(users []USMUser, dynamic bool) error {
 		}
 		seen[key] = true
 
-		authProto := strings.ToLower(user.AuthProto)
-		if authProto == "" {
-			authProto = "none"
+		if err := ValidateUSMCredentials(user); err != nil {
+			return fmt.Errorf("usm_users[%d]: %w", i, err)
 		}
-		if !validAuthProtos[authProto] {
-			return fmt.Errorf("usm_users[%d]: invalid auth_proto %q (must be one of: none, md5, sha, sha224, sha256, sha384, sha512)", i, user.AuthProto)
-		}
-		privProto := strings.ToLower(user.PrivProto)
-		if privProto == "" {
-			privProto = "none"
-		}
-		if !validPrivProtos[privProto] {
-			return fmt.Errorf("usm_users[%d]: invalid priv_proto %q (must be one of: none, des, aes, aes192, aes256, aes192c, aes256c)", i, user.PrivProto)
+	}
+	return nil
+}
+
+// ValidateUSMCredentials checks the security level settings of one USM user.
+// It does not look at the engine ID, whose requirements depend on the caller.
+func ValidateUSMCredentials(user USMUser) error {
+	if user.Username == "" {
+		return errors.New("username is required")
+	}
+	authProto := strings.ToLower(user.AuthProto)
+	if authProto == "" {
+		authProto = "none"
+	}
+	if !validAuthProtos[authProto] {
+		return fmt.Errorf("invalid auth_proto %q (must be one of: none, md5, sha, sha224, sha256, sha384, sha512)", user.AuthProto)
+	}
+	privProto := strings.ToLower(user.PrivProto)
+	if privProto == "" {
+		privProto = "none"
+	}
+	if !validPrivProtos[privProto] {
+		return fmt.Errorf("invalid priv_proto %q (must be one of: none, des, aes, aes192, aes256, aes192c, aes256c)", user.PrivProto)
+	}
+	if authProto == "none" && privProto != "none" {
+		return fmt.Errorf("priv_proto %q requires auth_proto (noAuthNoPriv only supports none/none)", privProto)
+	}
+	if authProto != "none" {
+		if user.AuthKey == "" {
+			return fmt.Errorf("auth_key is required when auth_proto is %q", authProto)
 		}
-		if authProto == "none" && privProto != "none" {
-			return fmt.Errorf("usm_users[%d]: priv_proto %q requires auth_proto (noAuthNoPriv only supports none/none)", i, privProto)
+		if len(user.AuthKey) < minSNMPv3PassphraseLen {
+			return fmt.Errorf("auth_key must be at least %d characters", minSNMPv3PassphraseLen)
 		}
-		if authProto != "none" {
-			if user.AuthKey == "" {
-				return fmt.Errorf("usm_users[%d]: auth_key is required when auth_proto is %q", i, authProto)
-			}
-			if len(user.AuthKey) < minSNMPv3PassphraseLen {
-				return fmt.Errorf("usm_users[%d]: auth_key must be at least %d characters", i, minSNMPv3PassphraseLen)
-			}
+	}
+	if privProto != "none" {
+		if user.PrivKey == "" {
+			return fmt.Errorf("priv_key is required when priv_proto is %q", privProto)
 		}
-		if privProto != "none" {
-			if user.PrivKey == "" {
-				return fmt.Errorf("usm_users[%d]: priv_key is required when priv_proto is %q", i, privProto)
-			}
-			if len(user.PrivKey) < minSNMPv3PassphraseLen {
-				return fmt.Errorf("usm_users[%d]: priv_key must be at least %d characters", i, minSNMPv3PassphraseLen)
-			}
+		if len(user.PrivKey) < minSNMPv3PassphraseLen {
+			return fmt.Errorf("priv_key must be at least %d characters", minSNMPv3PassphraseLen)
 		}
 	}
 	return nil
@@ -353,6 +365,11 @@ func ValidateV3Requirements(versions []string, users []USMUser, dynamic bool, wh
 	return nil
 }
 
+// ParseEngineID decodes a hex SNMP engine ID and checks its RFC 3411 bounds.
+func ParseEngineID(id string) ([]byte, error) {
+	return parseEngineIDHex(id)
+}
+
 func parseEngineIDHex(id string) ([]byte, error) {
 	id = strings.TrimSpace(id)
 	if id == "" {
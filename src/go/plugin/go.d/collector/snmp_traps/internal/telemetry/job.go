@@ -26,6 +26,8 @@ const (
 	ErrorProfileLoadFailed      ErrorKind = "profile_load_failed"
 	ErrorJournalWriteFailed     ErrorKind = "journal_write_failed"
 	ErrorOTLPExportFailed       ErrorKind = "otlp_export_failed"
+	ErrorForwardFailed          ErrorKind = "forward_failed"
+	ErrorSyslogFailed           ErrorKind = "syslog_failed"
 	ErrorListenerReadFailed     ErrorKind = "listener_read_failed"
 	ErrorListenerBufferDegraded ErrorKind = "listener_buffer_degraded"
 )
@@ -45,6 +47,8 @@ type trapErrors struct {
 	profileLoadFailed      atomic.Uint64
 	journalWriteFailed     atomic.Uint64
 	otlpExportFailed       atomic.Uint64
+	forwardFailed          atomic.Uint64
+	syslogFailed           atomic.Uint64
 	listenerReadFailed     atomic.Uint64
 	listenerBufferDegraded atomic.Uint64
 }
@@ -183,6 +187,10 @@ func (j *Job) AddError(kind ErrorKind, n uint64) {
 		j.errors.journalWriteFailed.Add(n)
 	case ErrorOTLPExportFailed:
 		j.errors.otlpExportFailed.Add(n)
+	case ErrorForwardFailed:
+		j.errors.forwardFailed.Add(n)
+	case ErrorSyslogFailed:
+		j.errors.syslogFailed.Add(n)
 	case ErrorListenerReadFailed:
 		j.errors.listenerReadFailed.Add(n)
 	case ErrorListenerBufferDegraded:
@@ -277,6 +285,8 @@ func collectErrors(store metrix.CollectorStore, jobName string, j *Job) {
 	meter.Counter("snmp_trap_errors_profile_load_failed").ObserveTotal(float64(j.errors.profileLoadFailed.Load()))
 	meter.Counter("snmp_trap_errors_journal_write_failed").ObserveTotal(float64(j.errors.journalWriteFailed.Load()))
 	meter.Counter("snmp_trap_errors_otlp_export_failed").ObserveTotal(float64(j.errors.otlpExportFailed.Load()))
+	meter.Counter("snmp_trap_errors_forward_failed").ObserveTotal(float64(j.errors.forwardFailed.Load()))
+	meter.Counter("snmp_trap_errors_syslog_failed").ObserveTotal(float64(j.errors.syslogFailed.Load()))
 	meter.Counter("snmp_trap_errors_listener_read_failed").ObserveTotal(float64(j.errors.listenerReadFailed.Load()))
 	meter.Counter("snmp_trap_errors_listener_buffer_degraded").ObserveTotal(float64(j.errors.listenerBufferDegraded.Load()))
 }
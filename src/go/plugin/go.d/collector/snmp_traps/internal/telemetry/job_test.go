@@ -96,6 +96,8 @@ func TestJobCollectsExactRetainedMetricSet(t *testing.T) {
 		"snmp_trap_errors_profile_load_failed",
 		"snmp_trap_errors_journal_write_failed",
 		"snmp_trap_errors_otlp_export_failed",
+		"snmp_trap_errors_forward_failed",
+		"snmp_trap_errors_syslog_failed",
 		"snmp_trap_errors_listener_read_failed",
 		"snmp_trap_errors_listener_buffer_degraded",
 		"snmp_trap_dedup_suppressed",
@@ -16,6 +16,7 @@ import (
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/cloudauth/sqladapter"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/oldmetrix"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/sqlquery"
 )
 
 func (c *Collector) collect(ctx context.Context) (map[string]int64, error) {
@@ -175,7 +176,7 @@ func (c *Collector) openConnection(ctx context.Context) error {
 		return err
 	}
 
-	db, err := sql.Open(driverName, dsn)
+	db, err := sqlquery.Open(driverName, dsn)
 	if err != nil {
 		return fmt.Errorf("open %s: %w (dsn=%s)", driverName, err, redactDSN(dsn))
 	}
@@ -227,7 +228,7 @@ func (c *Collector) openPostgresAzureADConnection(ctx context.Context) error {
 		return fmt.Errorf("parse pgx DSN: %w", err)
 	}
 
-	db := stdlib.OpenDB(*cfg, stdlib.OptionBeforeConnect(c.azureADBeforeConnect))
+	db := sqlquery.OpenDB(stdlib.GetConnector(*cfg, stdlib.OptionBeforeConnect(c.azureADBeforeConnect)))
 	db.SetConnMaxLifetime(10 * time.Minute)
 
 	pingCtx := ctx
@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build linux
+
+package systemdjournal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+)
+
+const (
+	prioMessages = collectorapi.Priority + iota
+	prioPatternMatches
+	prioUnitMessages
+	prioUnitPatternMatches
+)
+
+var baseCharts = collectorapi.Charts{
+	messagesChart.Copy(),
+}
+
+var (
+	messagesChart = collectorapi.Chart{
+		ID:       "messages",
+		Title:    "Journal Messages by Priority",
+		Units:    "messages/s",
+		Fam:      "messages",
+		Ctx:      "systemdjournal.messages",
+		Type:     collectorapi.Stacked,
+		Priority: prioMessages,
+		Dims:     prioDims("prio_"),
+	}
+	patternMatchesChart = collectorapi.Chart{
+		ID:       "pattern_matches",
+		Title:    "Journal Messages Matching Patterns",
+		Units:    "messages/s",
+		Fam:      "patterns",
+		Ctx:      "systemdjournal.pattern_matches",
+		Priority: prioPatternMatches,
+	}
+)
+
+var (
+	unitMessagesChartTmpl = collectorapi.Chart{
+		ID:       "unit_%s_messages",
+		Title:    "Unit Journal Messages by Priority",
+		Units:    "messages/s",
+		Fam:      "units",
+		Ctx:      "systemdjournal.unit_messages",
+		Type:     collectorapi.Stacked,
+		Priority: prioUnitMessages,
+	}
+	unitPatternMatchesChartTmpl = collectorapi.Chart{
+		ID:       "unit_%s_pattern_matches",
+		Title:    "Unit Journal Messages Matching Patterns",
+		Units:    "messages/s",
+		Fam:      "units",
+		Ctx:      "systemdjournal.unit_pattern_matches",
+		Priority: prioUnitPatternMatches,
+	}
+)
+
+func prioDims(px string) collectorapi.Dims {
+	var dims collectorapi.Dims
+	for _, name := range priorities {
+		dims = append(dims, &collectorapi.Dim{ID: px + name, Name: name, Algo: collectorapi.Incremental})
+	}
+	return dims
+}
+
+func (c *Collector) addPatternMatchesChart() {
+	chart := patternMatchesChart.Copy()
+	for _, p := range c.patterns {
+		chart.Dims = append(chart.Dims, &collectorapi.Dim{ID: "match_" + p.name, Name: p.name, Algo: collectorapi.Incremental})
+	}
+	if err := c.Charts().Add(chart); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) addUnitCharts(unit string) {
+	px := "unit_" + unit + "_"
+
+	charts := collectorapi.Charts{
+		unitMessagesChartTmpl.Copy(),
+	}
+	chart := charts[0]
+	chart.Dims = prioDims(px + "prio_")
+
+	var patternDims collectorapi.Dims
+	for _, p := range c.patterns {
+		if p.unitSr.MatchString(unit) {
+			patternDims = append(patternDims, &collectorapi.Dim{ID: px + "match_" + p.name, Name: p.name, Algo: collectorapi.Incremental})
+		}
+	}
+	if len(patternDims) > 0 {
+		chart := unitPatternMatchesChartTmpl.Copy()
+		chart.Dims = patternDims
+		charts = append(charts, chart)
+	}
+
+	id := cleanUnitName(unit)
+	for _, chart := range charts {
+		chart.ID = fmt.Sprintf(chart.ID, id)
+		chart.Labels = []collectorapi.Label{
+			{Key: "unit_name", Value: unit},
+		}
+	}
+
+	if err := c.Charts().Add(charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+func cleanUnitName(unit string) string {
+	return strings.NewReplacer(".", "_", " ", "_").Replace(unit)
+}
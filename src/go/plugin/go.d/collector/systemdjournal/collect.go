@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build linux
+
+package systemdjournal
+
+import (
+	"errors"
+)
+
+func (c *Collector) collect() (map[string]int64, error) {
+	s := c.stats
+	s.mu.Lock()
+
+	if !s.started {
+		err := s.err
+		s.mu.Unlock()
+		if err == nil {
+			err = errors.New("journal reader is not running")
+		}
+		return nil, err
+	}
+
+	mx := make(map[string]int64)
+
+	for i, name := range priorities {
+		mx["prio_"+name] = s.prio[i]
+	}
+	for _, p := range c.patterns {
+		mx["match_"+p.name] = s.matches[p.name]
+	}
+
+	var newUnits []string
+	for unit, us := range s.units {
+		if !c.seenUnits[unit] {
+			newUnits = append(newUnits, unit)
+		}
+		px := "unit_" + unit + "_"
+		for i, name := range priorities {
+			mx[px+"prio_"+name] = us.prio[i]
+		}
+		for _, p := range c.patterns {
+			if p.unitSr.MatchString(unit) {
+				mx[px+"match_"+p.name] = us.matches[p.name]
+			}
+		}
+	}
+
+	s.mu.Unlock()
+
+	for _, unit := range newUnits {
+		c.seenUnits[unit] = true
+		c.addUnitCharts(unit)
+	}
+
+	c.saveCursor()
+
+	return mx, nil
+}
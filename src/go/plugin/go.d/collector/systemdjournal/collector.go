@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build linux
+
+package systemdjournal
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/pkg/matcher"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
+)
+
+//go:embed "config_schema.json"
+var configSchema string
+
+func init() {
+	collectorapi.Register("systemdjournal", collectorapi.Creator{
+		JobConfigSchema: configSchema,
+		Defaults: collectorapi.Defaults{
+			UpdateEvery: 10,
+			Disabled:    true,
+		},
+		Create: func() collectorapi.CollectorV1 { return New() },
+		Config: func() any { return &Config{} },
+	})
+}
+
+func New() *Collector {
+	return &Collector{
+		Config: Config{
+			Include:  []string{"*.service"},
+			MaxUnits: 200,
+		},
+		charts:    baseCharts.Copy(),
+		newReader: newJournalctlReader,
+		stats:     newJournalStats(),
+		seenUnits: make(map[string]bool),
+	}
+}
+
+type (
+	Config struct {
+		UpdateEvery    int             `yaml:"update_every,omitempty" json:"update_every"`
+		JournalctlPath string          `yaml:"journalctl_path,omitempty" json:"journalctl_path"`
+		Directory      string          `yaml:"directory,omitempty" json:"directory"`
+		Include        []string        `yaml:"include,omitempty" json:"include"`
+		MaxUnits       int             `yaml:"max_units,omitempty" json:"max_units"`
+		Patterns       []patternConfig `yaml:"patterns,omitempty" json:"patterns"`
+		CursorFile     string          `yaml:"cursor_file,omitempty" json:"cursor_file"`
+	}
+	patternConfig struct {
+		Name  string   `yaml:"name" json:"name"`
+		Regex string   `yaml:"regex" json:"regex"`
+		Units []string `yaml:"units,omitempty" json:"units"`
+	}
+)
+
+type Collector struct {
+	collectorapi.Base
+	Config `yaml:",inline" json:""`
+
+	charts *collectorapi.Charts
+
+	newReader func(cfg readerConfig) journalReader
+
+	unitSr     matcher.Matcher
+	patterns   []*pattern
+	cursorPath string
+
+	stats *journalStats
+
+	readerCancel context.CancelFunc
+	readerWg     sync.WaitGroup
+
+	seenUnits map[string]bool
+}
+
+func (c *Collector) Configuration() any {
+	return c.Config
+}
+
+func (c *Collector) Init(context.Context) error {
+	if err := c.validateConfig(); err != nil {
+		return fmt.Errorf("config validation: %v", err)
+	}
+
+	sr, err := c.initUnitSelector()
+	if err != nil {
+		return fmt.Errorf("init unit selector: %v", err)
+	}
+	c.unitSr = sr
+
+	patterns, err := c.initPatterns()
+	if err != nil {
+		return fmt.Errorf("init patterns: %v", err)
+	}
+	c.patterns = patterns
+	if len(c.patterns) > 0 {
+		c.addPatternMatchesChart()
+	}
+
+	c.cursorPath = c.initCursorPath()
+	c.stats.cursor = readCursor(c.cursorPath)
+
+	c.Debugf("units: patterns '%v', max %d", c.Include, c.MaxUnits)
+	c.Debugf("cursor file: '%s'", c.cursorPath)
+
+	return nil
+}
+
+var readerCheckTimeout = time.Second * 2
+
+func (c *Collector) Check(context.Context) error {
+	if c.readerCancel == nil {
+		c.startReader()
+	}
+
+	// journalctl failures (missing binary, no permissions) are reported by the reader shortly after it starts
+	deadline := time.Now().Add(readerCheckTimeout)
+	for time.Now().Before(deadline) {
+		if err := c.stats.readerError(); err != nil {
+			return err
+		}
+		if c.stats.readerStarted() {
+			return nil
+		}
+		time.Sleep(time.Millisecond * 100)
+	}
+
+	if err := c.stats.readerError(); err != nil {
+		return err
+	}
+	return errors.New("journal reader has not started")
+}
+
+func (c *Collector) Charts() *collectorapi.Charts {
+	return c.charts
+}
+
+func (c *Collector) Collect(context.Context) map[string]int64 {
+	mx, err := c.collect()
+	if err != nil {
+		c.Error(err)
+	}
+
+	if len(mx) == 0 {
+		return nil
+	}
+	return mx
+}
+
+func (c *Collector) Cleanup(context.Context) {
+	c.stopReader()
+	c.saveCursor()
+}
@@ -0,0 +1,418 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build linux
+
+package systemdjournal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/collecttest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	dataConfigJSON, _ = os.ReadFile("testdata/config.json")
+	dataConfigYAML, _ = os.ReadFile("testdata/config.yaml")
+
+	dataJournal, _ = os.ReadFile("testdata/journal.json")
+)
+
+func Test_testDataIsValid(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"dataConfigJSON": dataConfigJSON,
+		"dataConfigYAML": dataConfigYAML,
+		"dataJournal":    dataJournal,
+	} {
+		require.NotNil(t, data, name)
+	}
+}
+
+func TestCollector_ConfigurationSerialize(t *testing.T) {
+	collecttest.TestConfigurationSerialize(t, &Collector{}, dataConfigJSON, dataConfigYAML)
+}
+
+func TestCollector_Init(t *testing.T) {
+	tests := map[string]struct {
+		config   Config
+		wantFail bool
+	}{
+		"success on default config": {
+			config: New().Config,
+		},
+		"success with patterns": {
+			config: Config{
+				Include:  []string{"*"},
+				Patterns: []patternConfig{{Name: "oom", Regex: "(?i)out of memory", Units: []string{"*.service"}}},
+			},
+		},
+		"fails when 'include' option not set": {
+			wantFail: true,
+			config:   Config{Include: []string{}},
+		},
+		"fails on invalid regex": {
+			wantFail: true,
+			config: Config{
+				Include:  []string{"*"},
+				Patterns: []patternConfig{{Name: "bad", Regex: "("}},
+			},
+		},
+		"fails on duplicate pattern name": {
+			wantFail: true,
+			config: Config{
+				Include: []string{"*"},
+				Patterns: []patternConfig{
+					{Name: "p", Regex: "a"},
+					{Name: "p", Regex: "b"},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collr := New()
+			collr.Config = test.config
+
+			if test.wantFail {
+				assert.Error(t, collr.Init(context.Background()))
+			} else {
+				assert.NoError(t, collr.Init(context.Background()))
+			}
+		})
+	}
+}
+
+func TestCollector_Charts(t *testing.T) {
+	assert.NotNil(t, New().Charts())
+}
+
+func TestCollector_Cleanup(t *testing.T) {
+	assert.NotPanics(t, func() { New().Cleanup(context.Background()) })
+}
+
+func TestCollector_Check(t *testing.T) {
+	tests := map[string]struct {
+		reader   *mockReader
+		wantFail bool
+	}{
+		"success when the reader starts": {
+			reader: &mockReader{},
+		},
+		"fail when the reader fails": {
+			reader:   &mockReader{err: errors.New("mock error")},
+			wantFail: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collr := prepareCollector(t, test.reader)
+			defer collr.Cleanup(context.Background())
+
+			if test.wantFail {
+				assert.Error(t, collr.Check(context.Background()))
+			} else {
+				assert.NoError(t, collr.Check(context.Background()))
+			}
+		})
+	}
+}
+
+func TestCollector_Collect(t *testing.T) {
+	reader := &mockReader{lines: bytes.Split(bytes.TrimSpace(dataJournal), []byte("\n"))}
+	collr := prepareCollector(t, reader)
+	defer collr.Cleanup(context.Background())
+
+	require.NoError(t, collr.Check(context.Background()))
+	reader.waitFed(t)
+
+	mx := collr.Collect(context.Background())
+
+	expected := map[string]int64{
+		"prio_emerg":   0,
+		"prio_alert":   0,
+		"prio_crit":    0,
+		"prio_err":     3,
+		"prio_warning": 1,
+		"prio_notice":  1,
+		"prio_info":    2,
+		"prio_debug":   0,
+
+		"match_failed": 3,
+		"match_oom":    1,
+
+		"unit_nginx.service_prio_emerg":   0,
+		"unit_nginx.service_prio_alert":   0,
+		"unit_nginx.service_prio_crit":    0,
+		"unit_nginx.service_prio_err":     2,
+		"unit_nginx.service_prio_warning": 0,
+		"unit_nginx.service_prio_notice":  0,
+		"unit_nginx.service_prio_info":    1,
+		"unit_nginx.service_prio_debug":   0,
+		"unit_nginx.service_match_failed": 1,
+		"unit_nginx.service_match_oom":    0,
+
+		"unit_sshd.service_prio_emerg":   0,
+		"unit_sshd.service_prio_alert":   0,
+		"unit_sshd.service_prio_crit":    0,
+		"unit_sshd.service_prio_err":     0,
+		"unit_sshd.service_prio_warning": 0,
+		"unit_sshd.service_prio_notice":  1,
+		"unit_sshd.service_prio_info":    0,
+		"unit_sshd.service_prio_debug":   0,
+		"unit_sshd.service_match_failed": 1,
+		"unit_sshd.service_match_oom":    0,
+
+		"unit_backup.service_prio_emerg":   0,
+		"unit_backup.service_prio_alert":   0,
+		"unit_backup.service_prio_crit":    0,
+		"unit_backup.service_prio_err":     1,
+		"unit_backup.service_prio_warning": 0,
+		"unit_backup.service_prio_notice":  0,
+		"unit_backup.service_prio_info":    0,
+		"unit_backup.service_prio_debug":   0,
+		"unit_backup.service_match_failed": 1,
+		"unit_backup.service_match_oom":    0,
+	}
+
+	assert.Equal(t, expected, mx)
+	collecttest.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+
+	assert.True(t, collr.Charts().Has("unit_nginx_service_messages"))
+	assert.True(t, collr.Charts().Has("unit_nginx_service_pattern_matches"))
+	assert.False(t, collr.Charts().Has("unit_session-1_scope_messages"))
+
+	collr.Cleanup(context.Background())
+	bs, err := os.ReadFile(collr.CursorFile)
+	require.NoError(t, err)
+	assert.Equal(t, "s=1;i=7", string(bs))
+}
+
+func TestCollector_Collect_MaxUnits(t *testing.T) {
+	reader := &mockReader{lines: bytes.Split(bytes.TrimSpace(dataJournal), []byte("\n"))}
+	collr := prepareCollector(t, reader)
+	collr.MaxUnits = 1
+	defer collr.Cleanup(context.Background())
+
+	require.NoError(t, collr.Check(context.Background()))
+	reader.waitFed(t)
+
+	mx := collr.Collect(context.Background())
+
+	assert.Equal(t, int64(3), mx["prio_err"])
+	assert.Equal(t, int64(2), mx["unit_nginx.service_prio_err"])
+	assert.NotContains(t, mx, "unit_sshd.service_prio_notice")
+}
+
+func TestCollector_Init_ResumesFromCursor(t *testing.T) {
+	cursorFile := filepath.Join(t.TempDir(), "cursor")
+	require.NoError(t, os.WriteFile(cursorFile, []byte("s=1;i=42\n"), 0644))
+
+	reader := &mockReader{}
+	collr := New()
+	collr.CursorFile = cursorFile
+	collr.newReader = func(cfg readerConfig) journalReader {
+		reader.setConfig(cfg)
+		return reader
+	}
+	require.NoError(t, collr.Init(context.Background()))
+	defer collr.Cleanup(context.Background())
+
+	require.NoError(t, collr.Check(context.Background()))
+	assert.Equal(t, "s=1;i=42", reader.config().cursor)
+}
+
+func Test_readLines(t *testing.T) {
+	oversize := strings.Repeat("x", maxEntrySize+10)
+
+	tests := map[string]struct {
+		input       string
+		wantEntries []string
+		wantSkipped []int
+	}{
+		"entries": {
+			input:       "a\nb\n",
+			wantEntries: []string{"a", "b"},
+		},
+		"no trailing newline": {
+			input:       "a\nb",
+			wantEntries: []string{"a", "b"},
+		},
+		"empty lines": {
+			input:       "a\n\nb\n",
+			wantEntries: []string{"a", "b"},
+		},
+		"oversize entry": {
+			input:       "a\n" + oversize + "\nb\n",
+			wantEntries: []string{"a", "b"},
+			wantSkipped: []int{len(oversize) + 1},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var entries []string
+			var skipped []int
+
+			err := readLines(strings.NewReader(test.input),
+				func(line []byte) { entries = append(entries, string(line)) },
+				func(size int) { skipped = append(skipped, size) },
+			)
+
+			require.NoError(t, err)
+			assert.Equal(t, test.wantEntries, entries)
+			assert.Equal(t, test.wantSkipped, skipped)
+		})
+	}
+}
+
+func TestJournalctlReader_Run_SkipsOversizeEntry(t *testing.T) {
+	dir := t.TempDir()
+	argsFile := filepath.Join(dir, "args")
+	script := filepath.Join(dir, "journalctl")
+	content := fmt.Sprintf(`#!/bin/sh
+echo "$@" > %s
+head -c %d /dev/zero | tr '\0' x
+echo
+echo '{"MESSAGE":"ok"}'
+exec sleep 60
+`, argsFile, maxEntrySize+1)
+	require.NoError(t, os.WriteFile(script, []byte(content), 0755))
+
+	var skipped atomic.Int64
+	entries := make(chan string, 1)
+	r := newJournalctlReader(readerConfig{
+		journalctlPath: script,
+		onSkip:         func(size int) { skipped.Add(int64(size)) },
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- r.run(ctx, func() {}, func(line []byte) { entries <- string(line) }) }()
+
+	select {
+	case line := <-entries:
+		assert.Equal(t, `{"MESSAGE":"ok"}`, line)
+	case <-time.After(time.Second * 5):
+		t.Fatal("the entry after the oversize one was not read")
+	}
+	assert.Equal(t, int64(maxEntrySize+2), skipped.Load())
+
+	bs, err := os.ReadFile(argsFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(bs), "--output-fields="+strings.Join(journalFields, ","))
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second * 5):
+		t.Fatal("the reader did not stop")
+	}
+}
+
+func Test_fieldString(t *testing.T) {
+	tests := map[string]struct {
+		raw  string
+		want string
+	}{
+		"string":         {raw: `"hello"`, want: "hello"},
+		"binary":         {raw: `[104,105]`, want: "hi"},
+		"multiple value": {raw: `["a","b"]`, want: "a"},
+		"null":           {raw: `null`, want: ""},
+		"empty":          {raw: ``, want: ""},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, fieldString([]byte(test.raw)))
+		})
+	}
+}
+
+func prepareCollector(t *testing.T, reader *mockReader) *Collector {
+	t.Helper()
+
+	collr := New()
+	collr.Patterns = []patternConfig{
+		{Name: "failed", Regex: "(?i)fail"},
+		{Name: "oom", Regex: "(?i)out of memory"},
+	}
+	collr.CursorFile = filepath.Join(t.TempDir(), "cursor")
+	collr.newReader = func(cfg readerConfig) journalReader {
+		reader.setConfig(cfg)
+		return reader
+	}
+	require.NoError(t, collr.Init(context.Background()))
+
+	return collr
+}
+
+type mockReader struct {
+	lines [][]byte
+	err   error
+
+	mu  sync.Mutex
+	cfg readerConfig
+	fed chan struct{}
+}
+
+func (m *mockReader) setConfig(cfg readerConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cfg = cfg
+	if m.fed == nil {
+		m.fed = make(chan struct{})
+	}
+}
+
+func (m *mockReader) config() readerConfig {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.cfg
+}
+
+func (m *mockReader) run(ctx context.Context, onStart func(), onEntry func(line []byte)) error {
+	if m.err != nil {
+		return m.err
+	}
+	onStart()
+	for _, line := range m.lines {
+		onEntry(line)
+	}
+	m.mu.Lock()
+	fed := m.fed
+	m.mu.Unlock()
+	select {
+	case <-fed:
+	default:
+		close(fed)
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (m *mockReader) waitFed(t *testing.T) {
+	t.Helper()
+	m.mu.Lock()
+	fed := m.fed
+	m.mu.Unlock()
+	select {
+	case <-fed:
+	case <-time.After(time.Second * 5):
+		t.Fatal("reader has not fed the lines")
+	}
+}
@@ -0,0 +1,4 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package systemdjournal is a systemd journal message rates collector
+package systemdjournal
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build linux
+
+package systemdjournal
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/pkg/matcher"
+	"github.com/netdata/netdata/go/plugins/plugin/framework/filepersister"
+)
+
+type pattern struct {
+	name   string
+	re     *regexp.Regexp
+	unitSr matcher.Matcher
+}
+
+func (c *Collector) validateConfig() error {
+	if len(c.Include) == 0 {
+		return errors.New("'include' option not set")
+	}
+	seen := make(map[string]bool)
+	for i, p := range c.Patterns {
+		if p.Name == "" {
+			return fmt.Errorf("'patterns[%d]': 'name' not set", i)
+		}
+		if p.Regex == "" {
+			return fmt.Errorf("'patterns[%d]' (%s): 'regex' not set", i, p.Name)
+		}
+		if seen[p.Name] {
+			return fmt.Errorf("'patterns[%d]': duplicate name '%s'", i, p.Name)
+		}
+		seen[p.Name] = true
+	}
+	return nil
+}
+
+func (c *Collector) initUnitSelector() (matcher.Matcher, error) {
+	expr := strings.Join(c.Include, " ")
+	return matcher.NewSimplePatternsMatcher(expr)
+}
+
+func (c *Collector) initPatterns() ([]*pattern, error) {
+	var patterns []*pattern
+
+	for _, cfg := range c.Patterns {
+		re, err := regexp.Compile(cfg.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("pattern '%s': %v", cfg.Name, err)
+		}
+		p := &pattern{name: cfg.Name, re: re, unitSr: matcher.TRUE()}
+		if len(cfg.Units) > 0 {
+			sr, err := matcher.NewSimplePatternsMatcher(strings.Join(cfg.Units, " "))
+			if err != nil {
+				return nil, fmt.Errorf("pattern '%s' units: %v", cfg.Name, err)
+			}
+			p.unitSr = sr
+		}
+		patterns = append(patterns, p)
+	}
+
+	return patterns, nil
+}
+
+// initCursorPath returns the file where the journal cursor is persisted, so that messages logged while
+// the Agent was not running are counted after a restart.
+func (c *Collector) initCursorPath() string {
+	if c.CursorFile != "" {
+		return c.CursorFile
+	}
+
+	// jobs reading different journals keep different cursors
+	id := "local"
+	if c.Directory != "" {
+		id = strings.NewReplacer("/", "_", " ", "_").Replace(strings.Trim(c.Directory, "/"))
+	}
+	return filepersister.StatePath("systemdjournal-" + id + ".cursor")
+}
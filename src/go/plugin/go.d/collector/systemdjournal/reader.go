@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build linux
+
+package systemdjournal
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/framework/filepersister"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/ndexec"
+)
+
+const (
+	readerRestartInterval = 10 * time.Second
+	maxEntrySize          = 1 << 20
+)
+
+// journalFields are the entry fields used by processEntry, journalctl adds __CURSOR to the JSON output.
+var journalFields = []string{"_SYSTEMD_UNIT", "_SYSTEMD_USER_UNIT", "UNIT", "PRIORITY", "MESSAGE"}
+
+type readerConfig struct {
+	journalctlPath string
+	directory      string
+	cursor         string
+	// onSkip is called for every entry larger than maxEntrySize, such entries are not processed.
+	onSkip func(size int)
+}
+
+// journalReader streams journal entries as JSON lines.
+type journalReader interface {
+	// run blocks until the context is cancelled or the reader fails.
+	// onStart is called once the reader is running.
+	run(ctx context.Context, onStart func(), onEntry func(line []byte)) error
+}
+
+type journalctlReader struct {
+	cfg readerConfig
+}
+
+func newJournalctlReader(cfg readerConfig) journalReader {
+	return &journalctlReader{cfg: cfg}
+}
+
+func (r *journalctlReader) run(ctx context.Context, onStart func(), onEntry func(line []byte)) error {
+	path := r.cfg.journalctlPath
+	if path == "" {
+		p, err := ndexec.FindBinary([]string{"journalctl"}, []string{"/usr/bin/journalctl", "/bin/journalctl"})
+		if err != nil {
+			return fmt.Errorf("journalctl binary not found: %v", err)
+		}
+		path = p
+	}
+
+	args := []string{
+		"--output=json",
+		"--output-fields=" + strings.Join(journalFields, ","),
+		"--follow",
+		"--no-pager",
+		"--quiet",
+	}
+	if r.cfg.cursor != "" {
+		args = append(args, "--after-cursor="+r.cfg.cursor)
+	} else {
+		args = append(args, "--lines=0")
+	}
+	if r.cfg.directory != "" {
+		args = append(args, "--directory="+r.cfg.directory)
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	cmd := exec.CommandContext(runCtx, path, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start '%s': %v", path, err)
+	}
+
+	onStart()
+
+	readErr := readLines(stdout, onEntry, r.cfg.onSkip)
+	if readErr != nil {
+		// journalctl blocks on the pipe once nobody reads it, stop it before waiting
+		cancel()
+	}
+	waitErr := cmd.Wait()
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return fmt.Errorf("journalctl exited: %v: %s", waitErr, msg)
+	}
+	if readErr != nil {
+		return fmt.Errorf("read journalctl output: %v", readErr)
+	}
+	if waitErr != nil {
+		return fmt.Errorf("journalctl exited: %v", waitErr)
+	}
+	return errors.New("journalctl exited")
+}
+
+// readLines calls onEntry for every line until EOF. Lines longer than maxEntrySize are discarded
+// and reported to onSkip, so a single huge entry does not stop the reader.
+func readLines(r io.Reader, onEntry func(line []byte), onSkip func(size int)) error {
+	br := bufio.NewReaderSize(r, maxEntrySize)
+
+	for {
+		line, err := br.ReadSlice('\n')
+
+		if errors.Is(err, bufio.ErrBufferFull) {
+			size := len(line)
+			for errors.Is(err, bufio.ErrBufferFull) {
+				line, err = br.ReadSlice('\n')
+				size += len(line)
+			}
+			if err == nil && onSkip != nil {
+				onSkip(size)
+			}
+		} else if line = bytes.TrimSuffix(line, []byte("\n")); len(line) > 0 {
+			onEntry(line)
+		}
+
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func (c *Collector) startReader() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.readerCancel = cancel
+
+	c.readerWg.Go(func() { c.runReader(ctx) })
+}
+
+func (c *Collector) stopReader() {
+	if c.readerCancel == nil {
+		return
+	}
+	c.readerCancel()
+	c.readerWg.Wait()
+	c.readerCancel = nil
+}
+
+func (c *Collector) runReader(ctx context.Context) {
+	var warned, skipWarned bool
+
+	onSkip := func(size int) {
+		if !skipWarned {
+			c.Warningf("skipped a journal entry of %d bytes (max %d bytes), further skipped entries are logged at debug level", size, maxEntrySize)
+			skipWarned = true
+		} else {
+			c.Debugf("skipped a journal entry of %d bytes", size)
+		}
+	}
+
+	for {
+		reader := c.newReader(readerConfig{
+			journalctlPath: c.JournalctlPath,
+			directory:      c.Directory,
+			cursor:         c.stats.getCursor(),
+			onSkip:         onSkip,
+		})
+
+		err := reader.run(ctx, c.stats.setStarted, c.processEntry)
+		if ctx.Err() != nil {
+			return
+		}
+
+		c.stats.setReaderError(err)
+		if !warned {
+			c.Warningf("journal reader: %v (restarting every %s)", err, readerRestartInterval)
+			warned = true
+		} else {
+			c.Debugf("journal reader: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(readerRestartInterval):
+		}
+	}
+}
+
+func readCursor(path string) string {
+	if path == "" {
+		return ""
+	}
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(bs))
+}
+
+type cursorState string
+
+func (s cursorState) Bytes() ([]byte, error) { return []byte(s), nil }
+
+func (c *Collector) saveCursor() {
+	cursor, changed := c.stats.cursorToSave()
+	if !changed {
+		return
+	}
+	filepersister.Save(c.cursorPath, cursorState(cursor))
+}
@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build linux
+
+package systemdjournal
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+)
+
+// syslog priority levels, see journal-fields(7)
+var priorities = []string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+
+const defaultPriority = 6 // info
+
+type (
+	// journalStats accumulates counters from the reader goroutine; they are read on every collection.
+	journalStats struct {
+		mu sync.Mutex
+
+		started bool
+		err     error
+
+		cursor      string
+		savedCursor string
+
+		prio    [8]int64
+		matches map[string]int64
+		units   map[string]*unitStats
+
+		unitsLimitHit bool
+	}
+	unitStats struct {
+		prio    [8]int64
+		matches map[string]int64
+	}
+)
+
+func newJournalStats() *journalStats {
+	return &journalStats{
+		matches: make(map[string]int64),
+		units:   make(map[string]*unitStats),
+	}
+}
+
+func (s *journalStats) setStarted() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.started, s.err = true, nil
+}
+
+func (s *journalStats) setReaderError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.started, s.err = false, err
+}
+
+func (s *journalStats) readerStarted() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.started
+}
+
+func (s *journalStats) readerError() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *journalStats) getCursor() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cursor
+}
+
+// cursorToSave returns the last read cursor and whether it changed since the previous call.
+func (s *journalStats) cursorToSave() (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cursor == "" || s.cursor == s.savedCursor {
+		return "", false
+	}
+	s.savedCursor = s.cursor
+	return s.cursor, true
+}
+
+type journalEntry struct {
+	Cursor   json.RawMessage `json:"__CURSOR"`
+	Unit     json.RawMessage `json:"_SYSTEMD_UNIT"`
+	UserUnit json.RawMessage `json:"_SYSTEMD_USER_UNIT"`
+	ObjUnit  json.RawMessage `json:"UNIT"`
+	Priority json.RawMessage `json:"PRIORITY"`
+	Message  json.RawMessage `json:"MESSAGE"`
+}
+
+// processEntry is called by the reader for every journal entry.
+func (c *Collector) processEntry(line []byte) {
+	var e journalEntry
+	if err := json.Unmarshal(line, &e); err != nil {
+		c.Debugf("failed to parse journal entry: %v", err)
+		return
+	}
+
+	unit := fieldString(e.Unit)
+	if unit == "" {
+		unit = fieldString(e.UserUnit)
+	}
+	// messages logged by systemd (PID 1) about a unit
+	if unit == "init.scope" {
+		if u := fieldString(e.ObjUnit); u != "" {
+			unit = u
+		}
+	}
+
+	prio := defaultPriority
+	if v, err := strconv.Atoi(fieldString(e.Priority)); err == nil && v >= 0 && v < len(priorities) {
+		prio = v
+	}
+
+	var matched []string
+	if len(c.patterns) > 0 {
+		msg := fieldString(e.Message)
+		for _, p := range c.patterns {
+			if p.re.MatchString(msg) && p.unitSr.MatchString(unit) {
+				matched = append(matched, p.name)
+			}
+		}
+	}
+
+	tracked := unit != "" && c.unitSr.MatchString(unit)
+
+	s := c.stats
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if cursor := fieldString(e.Cursor); cursor != "" {
+		s.cursor = cursor
+	}
+
+	s.prio[prio]++
+	for _, name := range matched {
+		s.matches[name]++
+	}
+
+	if !tracked {
+		return
+	}
+
+	us, ok := s.units[unit]
+	if !ok {
+		if c.MaxUnits > 0 && len(s.units) >= c.MaxUnits {
+			if !s.unitsLimitHit {
+				s.unitsLimitHit = true
+				c.Warningf("reached the limit of %d units (max_units), messages of new units are counted only in totals", c.MaxUnits)
+			}
+			return
+		}
+		us = &unitStats{matches: make(map[string]int64)}
+		s.units[unit] = us
+	}
+
+	us.prio[prio]++
+	for _, name := range matched {
+		us.matches[name]++
+	}
+}
+
+// fieldString decodes a journal field value. Fields are strings, arrays of bytes for binary data,
+// or arrays of values if the field is set multiple times (the first one is used).
+func fieldString(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+
+	switch raw[0] {
+	case '"':
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			return s
+		}
+	case '[':
+		var bs []byte
+		var ints []int
+		if err := json.Unmarshal(raw, &ints); err == nil {
+			bs = make([]byte, 0, len(ints))
+			for _, v := range ints {
+				bs = append(bs, byte(v))
+			}
+			return string(bs)
+		}
+		var vals []json.RawMessage
+		if err := json.Unmarshal(raw, &vals); err == nil && len(vals) > 0 {
+			return fieldString(vals[0])
+		}
+	}
+	return ""
+}
@@ -18,6 +18,11 @@ import (
 const (
 	prioUnitState = collectorapi.Priority + iota
 	prioUnitFileState
+	prioTimerSchedule
+	prioTimerStaleness
+	prioTimerLastRunResult
+	prioTimerLastRunExitStatus
+	prioTimerLastRunDuration
 )
 
 func (c *Collector) addUnitCharts(name, typ string) {
@@ -107,6 +112,104 @@ func (c *Collector) removeUnitFileCharts(unitPath string) {
 	c.removeCharts(px)
 }
 
+var timerChartsTmpl = collectorapi.Charts{
+	timerScheduleChartTmpl.Copy(),
+	timerStalenessChartTmpl.Copy(),
+	timerLastRunResultChartTmpl.Copy(),
+	timerLastRunExitStatusChartTmpl.Copy(),
+	timerLastRunDurationChartTmpl.Copy(),
+}
+
+var (
+	timerScheduleChartTmpl = collectorapi.Chart{
+		ID:       "timer_%s_schedule",
+		Title:    "Timer Schedule",
+		Units:    "seconds",
+		Fam:      "timers",
+		Ctx:      "systemd.timer_schedule",
+		Type:     collectorapi.Line,
+		Priority: prioTimerSchedule,
+		Dims: collectorapi.Dims{
+			{ID: "timer_%s_since_last_trigger", Name: "since_last_trigger"},
+			{ID: "timer_%s_until_next_elapse", Name: "until_next_elapse"},
+		},
+	}
+	timerStalenessChartTmpl = collectorapi.Chart{
+		ID:       "timer_%s_staleness",
+		Title:    "Timer Staleness",
+		Units:    "state",
+		Fam:      "timers",
+		Ctx:      "systemd.timer_staleness",
+		Type:     collectorapi.Line,
+		Priority: prioTimerStaleness,
+		Dims: collectorapi.Dims{
+			{ID: "timer_%s_staleness_ok", Name: "ok"},
+			{ID: "timer_%s_staleness_stale", Name: "stale"},
+		},
+	}
+	timerLastRunResultChartTmpl = collectorapi.Chart{
+		ID:       "timer_%s_last_run_result",
+		Title:    "Timer Triggered Unit Last Run Result",
+		Units:    "state",
+		Fam:      "timers",
+		Ctx:      "systemd.timer_last_run_result",
+		Type:     collectorapi.Line,
+		Priority: prioTimerLastRunResult,
+		Dims: collectorapi.Dims{
+			{ID: "timer_%s_last_run_result_success", Name: "success"},
+			{ID: "timer_%s_last_run_result_failed", Name: "failed"},
+		},
+	}
+	timerLastRunExitStatusChartTmpl = collectorapi.Chart{
+		ID:       "timer_%s_last_run_exit_status",
+		Title:    "Timer Triggered Unit Last Run Exit Status",
+		Units:    "status",
+		Fam:      "timers",
+		Ctx:      "systemd.timer_last_run_exit_status",
+		Type:     collectorapi.Line,
+		Priority: prioTimerLastRunExitStatus,
+		Dims: collectorapi.Dims{
+			{ID: "timer_%s_last_run_exit_status", Name: "exit_status"},
+		},
+	}
+	timerLastRunDurationChartTmpl = collectorapi.Chart{
+		ID:       "timer_%s_last_run_duration",
+		Title:    "Timer Triggered Unit Last Run Duration",
+		Units:    "seconds",
+		Fam:      "timers",
+		Ctx:      "systemd.timer_last_run_duration",
+		Type:     collectorapi.Line,
+		Priority: prioTimerLastRunDuration,
+		Dims: collectorapi.Dims{
+			{ID: "timer_%s_last_run_duration", Name: "duration", Div: 1000},
+		},
+	}
+)
+
+func (c *Collector) addTimerCharts(name, triggeredUnit string) {
+	charts := timerChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, name)
+		chart.Labels = []collectorapi.Label{
+			{Key: "unit_name", Value: name},
+			{Key: "triggered_unit", Value: triggeredUnit},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, name)
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) removeTimerCharts(name string) {
+	px := fmt.Sprintf("timer_%s_", name)
+	c.removeCharts(px)
+}
+
 func (c *Collector) removeCharts(prefix string) {
 	for _, chart := range *c.Charts() {
 		if strings.HasPrefix(chart.ID, prefix) {
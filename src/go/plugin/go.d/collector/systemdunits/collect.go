@@ -32,6 +32,13 @@ func (c *Collector) collect() (map[string]int64, error) {
 		return nil, err
 	}
 
+	if c.CollectTimers && len(c.IncludeTimers) > 0 {
+		if err := c.collectTimers(mx, conn); err != nil {
+			c.closeConnection()
+			return mx, err
+		}
+	}
+
 	if c.CollectUnitFiles && len(c.IncludeUnitFiles) > 0 {
 		if err := c.collectUnitFiles(mx, conn); err != nil {
 			c.closeConnection()
@@ -0,0 +1,401 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build linux
+
+package systemdunits
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/oldmetrix"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+	"golang.org/x/sys/unix"
+)
+
+// timerInfo is the scheduling state of a timer unit and the last run of the unit it triggers.
+type timerInfo struct {
+	name          string // timer unit name, e.g. "logrotate.timer"
+	unit          string // triggered unit, e.g. "logrotate.service"
+	activeState   string
+	lastTrigger   time.Time
+	nextElapse    time.Time
+	period        time.Duration // the longest expected time between triggers, 0 if unknown
+	stale         bool
+	hasLastRun    bool
+	running       bool
+	result        string
+	exitCode      string
+	exitStatus    int64
+	lastRunStart  time.Time
+	lastRunExit   time.Time
+	lastRunLength time.Duration
+}
+
+func (c *Collector) collectTimers(mx map[string]int64, conn systemdConnection) error {
+	units, err := c.getTimerUnits(conn)
+	if err != nil {
+		return err
+	}
+
+	now := c.now()
+	seen := make(map[string]bool)
+	timers := make([]timerInfo, 0, len(units))
+
+	for _, unit := range units {
+		name, _, ok := extractUnitNameType(unit.Name)
+		if !ok {
+			continue
+		}
+
+		ti, err := c.getTimerInfo(conn, unit, now)
+		if err != nil {
+			// keep the charts of a timer that is temporarily unavailable, but do not fail the other timers
+			c.Limit("systemdunits:timer:"+unit.Name, 1, time.Hour).Warningf("skipping timer '%s': %v", unit.Name, err)
+			seen[unit.Name] = c.seenTimers[unit.Name]
+			continue
+		}
+		timers = append(timers, ti)
+
+		seen[unit.Name] = true
+		if !c.seenTimers[unit.Name] {
+			c.seenTimers[unit.Name] = true
+			c.addTimerCharts(name, ti.unit)
+		}
+
+		px := fmt.Sprintf("timer_%s_", name)
+
+		if !ti.lastTrigger.IsZero() {
+			mx[px+"since_last_trigger"] = int64(now.Sub(ti.lastTrigger).Seconds())
+		}
+		mx[px+"until_next_elapse"] = 0
+		if !ti.nextElapse.IsZero() && ti.nextElapse.After(now) {
+			mx[px+"until_next_elapse"] = int64(ti.nextElapse.Sub(now).Seconds())
+		}
+
+		mx[px+"staleness_ok"] = oldmetrix.Bool(!ti.stale)
+		mx[px+"staleness_stale"] = oldmetrix.Bool(ti.stale)
+
+		if ti.hasLastRun {
+			mx[px+"last_run_result_success"] = oldmetrix.Bool(ti.result == "success")
+			mx[px+"last_run_result_failed"] = oldmetrix.Bool(ti.result != "success")
+			mx[px+"last_run_exit_status"] = ti.exitStatus
+			mx[px+"last_run_duration"] = ti.lastRunLength.Milliseconds()
+		}
+	}
+
+	for k := range c.seenTimers {
+		if !seen[k] {
+			delete(c.seenTimers, k)
+			if name, _, ok := extractUnitNameType(k); ok {
+				c.removeTimerCharts(name)
+			}
+		}
+	}
+
+	c.timersMu.Lock()
+	c.timers = timers
+	c.timersMu.Unlock()
+
+	return nil
+}
+
+func (c *Collector) getTimerInfo(conn systemdConnection, unit dbus.UnitStatus, now time.Time) (timerInfo, error) {
+	ti := timerInfo{name: unit.Name, activeState: unit.ActiveState}
+
+	props, err := c.getUnitTypeProperties(conn, unit.Name, "Timer")
+	if err != nil {
+		return ti, err
+	}
+
+	ti.unit = cleanUnitName(propString(props, "Unit"))
+	if ti.unit == "" {
+		ti.unit = strings.TrimSuffix(unit.Name, ".timer") + ".service"
+	}
+	ti.lastTrigger = usecToTime(propUint64(props, "LastTriggerUSec"))
+	ti.nextElapse = nextElapseTime(props, now)
+	ti.period = timerPeriod(props)
+
+	// A timer that has not fired for longer than its period (plus grace) missed a run: the system was down
+	// and the timer is not Persistent, the triggered unit is stuck, or the timer is broken.
+	// The next elapse time can not be used for this: systemd moves it to the next occurrence after a missed one.
+	if ti.period > 0 && !ti.lastTrigger.IsZero() {
+		grace := c.TimerStaleGrace.Duration()
+		ti.stale = now.Sub(ti.lastTrigger) > ti.period+grace
+	}
+
+	if !strings.HasSuffix(ti.unit, ".service") {
+		return ti, nil
+	}
+
+	props, err = c.getUnitTypeProperties(conn, ti.unit, "Service")
+	if err != nil {
+		return ti, err
+	}
+
+	ti.lastRunStart = usecToTime(propUint64(props, "ExecMainStartTimestamp"))
+	ti.lastRunExit = usecToTime(propUint64(props, "ExecMainExitTimestamp"))
+	if ti.lastRunStart.IsZero() {
+		// the service has not run since boot (or the last daemon-reload)
+		return ti, nil
+	}
+
+	ti.hasLastRun = true
+	ti.result = propString(props, "Result")
+	ti.exitStatus = propInt64(props, "ExecMainStatus")
+	ti.exitCode = exitCodeString(propInt64(props, "ExecMainCode"))
+
+	if ti.lastRunExit.Before(ti.lastRunStart) {
+		ti.running = true
+		ti.lastRunLength = now.Sub(ti.lastRunStart)
+	} else {
+		ti.lastRunLength = ti.lastRunExit.Sub(ti.lastRunStart)
+	}
+
+	return ti, nil
+}
+
+func (c *Collector) getTimerUnits(conn systemdConnection) ([]dbus.UnitStatus, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout.Duration())
+	defer cancel()
+
+	var units []dbus.UnitStatus
+	var err error
+
+	if c.systemdVersion >= 230 {
+		c.Debugf("calling function 'ListUnitsByPatterns' (timers)")
+		units, err = conn.ListUnitsByPatternsContext(ctx, unitStates, c.IncludeTimers)
+		if err != nil {
+			return nil, fmt.Errorf("error on ListUnitsByPatterns: %v", err)
+		}
+	} else {
+		c.Debugf("calling function 'ListUnits' (timers)")
+		units, err = conn.ListUnitsContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error on ListUnits: %v", err)
+		}
+	}
+
+	for i := range units {
+		units[i].Name = cleanUnitName(units[i].Name)
+	}
+
+	timers := units[:0]
+	for _, unit := range units {
+		if unit.LoadState == "loaded" && strings.HasSuffix(unit.Name, ".timer") && c.timerSr.MatchString(unit.Name) {
+			timers = append(timers, unit)
+		}
+	}
+
+	c.Debugf("got %d timer units", len(timers))
+
+	return timers, nil
+}
+
+func (c *Collector) getUnitTypeProperties(conn systemdConnection, unit, unitType string) (map[string]any, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout.Duration())
+	defer cancel()
+
+	c.Debugf("calling function 'GetUnitTypeProperties' for unit '%s' (%s)", unit, unitType)
+
+	props, err := conn.GetUnitTypePropertiesContext(ctx, unit, unitType)
+	if err != nil {
+		return nil, fmt.Errorf("error on GetUnitTypeProperties(%s): %v", unit, err)
+	}
+	return props, nil
+}
+
+// nextElapseTime returns the wall clock time of the next elapse. Calendar timers (OnCalendar=) report it
+// in realtime, monotonic timers (OnBootSec=, OnUnitActiveSec=, ...) in CLOCK_MONOTONIC; the earliest is used,
+// the same way as 'systemctl list-timers'.
+func nextElapseTime(props map[string]any, now time.Time) time.Time {
+	next := usecToTime(propUint64(props, "NextElapseUSecRealtime"))
+
+	if mono := propUint64(props, "NextElapseUSecMonotonic"); mono > 0 && mono != maxUSec {
+		if monoNow, ok := monotonicNow(); ok {
+			t := now.Add(time.Duration(mono)*time.Microsecond - monoNow)
+			if next.IsZero() || t.Before(next) {
+				next = t
+			}
+		}
+	}
+
+	return next
+}
+
+// timerPeriod returns the longest expected time between two triggers of a timer with recurring
+// OnUnitActiveSec=, OnUnitInactiveSec= or OnCalendar= settings, or 0 if the timer is not recurring.
+// Settings are combined the way systemd does it: the timer elapses on the earliest of them.
+func timerPeriod(props map[string]any) time.Duration {
+	var period time.Duration
+	add := func(d time.Duration) {
+		if d > 0 && (period == 0 || d < period) {
+			period = d
+		}
+	}
+
+	// a(stt): base, value in usec, next elapse
+	for _, t := range propStructs(props, "TimersMonotonic") {
+		if len(t) != 3 {
+			continue
+		}
+		switch base, _ := t[0].(string); base {
+		case "OnUnitActiveUSec", "OnUnitInactiveUSec":
+			if v, ok := t[1].(uint64); ok {
+				add(time.Duration(v) * time.Microsecond)
+			}
+		}
+	}
+	// a(sst): base, calendar specification, next elapse
+	for _, t := range propStructs(props, "TimersCalendar") {
+		if len(t) != 3 {
+			continue
+		}
+		if spec, ok := t[1].(string); ok {
+			add(calendarPeriod(spec))
+		}
+	}
+
+	if period > 0 {
+		period += time.Duration(propUint64(props, "RandomizedDelayUSec")) * time.Microsecond
+	}
+	return period
+}
+
+// calendarPeriod returns an upper bound of the time between two elapses of a normalized calendar specification
+// ("[weekdays] year-month-day hour:minute:second [timezone]", as reported by systemd), or 0 if it does not repeat.
+// The period is the unit of the smallest repeating field, lists and ranges are rounded up to the next field.
+func calendarPeriod(spec string) time.Duration {
+	const day = time.Hour * 24
+
+	var weekdays bool
+	var date, clock []string
+	for _, f := range strings.Fields(spec) {
+		switch {
+		case strings.Count(f, ":") == 2:
+			clock = strings.Split(f, ":")
+		case strings.Count(f, "-") == 2 || strings.Contains(f, "~"):
+			date = strings.FieldsFunc(f, func(r rune) bool { return r == '-' || r == '~' })
+		case date == nil && clock == nil:
+			weekdays = true
+		}
+	}
+	if len(date) != 3 || len(clock) != 3 {
+		return 0
+	}
+
+	fields := []struct {
+		value string
+		unit  time.Duration
+	}{
+		{clock[2], time.Second},
+		{clock[1], time.Minute},
+		{clock[0], time.Hour},
+		{date[2], day},
+		{date[1], day * 31},
+		{date[0], day * 366},
+	}
+
+	var period time.Duration
+	for _, f := range fields {
+		if f.value == "*" {
+			period = f.unit
+			break
+		}
+		if _, rep, ok := strings.Cut(f.value, "/"); ok && !strings.ContainsAny(f.value, ",.") {
+			if n, err := strconv.Atoi(rep); err == nil && n > 0 {
+				period = f.unit * time.Duration(n)
+				break
+			}
+		}
+	}
+	if period > 0 && weekdays {
+		period = max(period, day*7)
+	}
+	return period
+}
+
+// systemd uses USEC_INFINITY for "not set"
+const maxUSec = ^uint64(0)
+
+func usecToTime(usec uint64) time.Time {
+	if usec == 0 || usec == maxUSec {
+		return time.Time{}
+	}
+	return time.UnixMicro(int64(usec))
+}
+
+var monotonicNow = func() (time.Duration, bool) {
+	var ts unix.Timespec
+	if err := unix.ClockGettime(unix.CLOCK_MONOTONIC, &ts); err != nil {
+		return 0, false
+	}
+	return time.Duration(ts.Nano()), true
+}
+
+// https://man7.org/linux/man-pages/man2/sigaction.2.html (si_code values for SIGCHLD)
+func exitCodeString(code int64) string {
+	switch code {
+	case 1:
+		return "exited"
+	case 2:
+		return "killed"
+	case 3:
+		return "dumped"
+	default:
+		return ""
+	}
+}
+
+// propStructs returns an array of structs property, D-Bus structs are decoded as []any.
+func propStructs(props map[string]any, name string) [][]any {
+	switch v := props[name].(type) {
+	case [][]any:
+		return v
+	case []any:
+		var out [][]any
+		for _, e := range v {
+			if s, ok := e.([]any); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+func propString(props map[string]any, name string) string {
+	v, _ := props[name].(string)
+	return v
+}
+
+func propUint64(props map[string]any, name string) uint64 {
+	switch v := props[name].(type) {
+	case uint64:
+		return v
+	case uint32:
+		return uint64(v)
+	case int64:
+		return uint64(max(v, 0))
+	default:
+		return 0
+	}
+}
+
+func propInt64(props map[string]any, name string) int64 {
+	switch v := props[name].(type) {
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	case uint32:
+		return int64(v)
+	default:
+		return 0
+	}
+}
@@ -9,6 +9,7 @@ import (
 	_ "embed"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/coreos/go-systemd/v22/dbus"
@@ -27,8 +28,10 @@ func init() {
 		Defaults: collectorapi.Defaults{
 			UpdateEvery: 10, // gathering systemd units can be a CPU intensive op
 		},
-		Create: func() collectorapi.CollectorV1 { return New() },
-		Config: func() any { return &Config{} },
+		Create:          func() collectorapi.CollectorV1 { return New() },
+		Config:          func() any { return &Config{} },
+		SharedFunctions: systemdunitsMethods,
+		MethodHandler:   systemdunitsFunctionHandler,
 	})
 }
 
@@ -41,12 +44,17 @@ func New() *Collector {
 			CollectUnitFiles:      false,
 			IncludeUnitFiles:      []string{"*.service"},
 			CollectUnitFilesEvery: confopt.Duration(time.Minute * 5),
+			CollectTimers:         false,
+			IncludeTimers:         []string{"*.timer"},
+			TimerStaleGrace:       confopt.Duration(time.Minute * 5),
 		},
 		charts:        &collectorapi.Charts{},
 		client:        newSystemdDBusClient(),
 		seenUnits:     make(map[string]bool),
 		unitTransient: make(map[string]bool),
 		seenUnitFiles: make(map[string]bool),
+		seenTimers:    make(map[string]bool),
+		now:           time.Now,
 	}
 }
 
@@ -58,6 +66,9 @@ type Config struct {
 	CollectUnitFiles      bool             `yaml:"collect_unit_files" json:"collect_unit_files"`
 	IncludeUnitFiles      []string         `yaml:"include_unit_files,omitempty" json:"include_unit_files"`
 	CollectUnitFilesEvery confopt.Duration `yaml:"collect_unit_files_every,omitempty" json:"collect_unit_files_every"`
+	CollectTimers         bool             `yaml:"collect_timers" json:"collect_timers"`
+	IncludeTimers         []string         `yaml:"include_timers,omitempty" json:"include_timers"`
+	TimerStaleGrace       confopt.Duration `yaml:"timer_stale_grace,omitempty" json:"timer_stale_grace"`
 }
 
 type Collector struct {
@@ -77,6 +88,15 @@ type Collector struct {
 	cachedUnitFiles       []dbus.UnitFile
 	seenUnitFiles         map[string]bool
 
+	timerSr    matcher.Matcher
+	seenTimers map[string]bool
+	timersMu   sync.RWMutex
+	timers     []timerInfo // last collected timers, read by the function handler
+
+	now func() time.Time
+
+	funcRouter *funcRouter
+
 	charts *collectorapi.Charts
 }
 
@@ -95,10 +115,20 @@ func (c *Collector) Init(context.Context) error {
 	}
 	c.unitSr = sr
 
+	timerSr, err := c.initTimerSelector()
+	if err != nil {
+		return fmt.Errorf("init timer selector: %v", err)
+	}
+	c.timerSr = timerSr
+
+	c.funcRouter = newFuncRouter(c)
+
 	c.Debugf("timeout: %s", c.Timeout)
 	c.Debugf("units: patterns '%v'", c.Include)
 	c.Debugf("unit files: enabled '%v', every '%s', patterns: %v",
 		c.CollectUnitFiles, c.CollectUnitFilesEvery, c.IncludeUnitFiles)
+	c.Debugf("timers: enabled '%v', patterns: %v, stale grace '%s'",
+		c.CollectTimers, c.IncludeTimers, c.TimerStaleGrace)
 
 	return nil
 }
@@ -132,6 +162,9 @@ func (c *Collector) Collect(context.Context) map[string]int64 {
 	return mx
 }
 
-func (c *Collector) Cleanup(context.Context) {
+func (c *Collector) Cleanup(ctx context.Context) {
 	c.closeConnection()
+	if c.funcRouter != nil {
+		c.funcRouter.Cleanup(ctx)
+	}
 }
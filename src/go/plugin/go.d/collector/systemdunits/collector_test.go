@@ -13,7 +13,9 @@ import (
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/collecttest"
 
 	"github.com/coreos/go-systemd/v22/dbus"
@@ -867,6 +869,222 @@ func TestCollector_Collect(t *testing.T) {
 	}
 }
 
+func TestCollector_Collect_Timers(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	collr := New()
+	collr.Include = []string{"none.service"}
+	collr.CollectTimers = true
+	collr.IncludeTimers = []string{"logrotate.timer", "shadow.timer", "man-db.timer"}
+	collr.now = func() time.Time { return now }
+	client := prepareOKClient(230)
+	client.conn.(*mockConn).unitTypeProps = mockTimerProps(now)
+	collr.client = client
+	require.NoError(t, collr.Init(context.Background()))
+
+	mx := collr.Collect(context.Background())
+
+	expected := map[string]int64{
+		"timer_logrotate_since_last_trigger":      3600,
+		"timer_logrotate_until_next_elapse":       82800,
+		"timer_logrotate_staleness_ok":            1,
+		"timer_logrotate_staleness_stale":         0,
+		"timer_logrotate_last_run_result_success": 1,
+		"timer_logrotate_last_run_result_failed":  0,
+		"timer_logrotate_last_run_exit_status":    0,
+		"timer_logrotate_last_run_duration":       2000,
+
+		"timer_shadow_since_last_trigger":      90000,
+		"timer_shadow_until_next_elapse":       82800,
+		"timer_shadow_staleness_ok":            0,
+		"timer_shadow_staleness_stale":         1,
+		"timer_shadow_last_run_result_success": 1,
+		"timer_shadow_last_run_result_failed":  0,
+		"timer_shadow_last_run_exit_status":    0,
+		"timer_shadow_last_run_duration":       5000,
+
+		"timer_man-db_since_last_trigger":      1800,
+		"timer_man-db_until_next_elapse":       1800,
+		"timer_man-db_staleness_ok":            1,
+		"timer_man-db_staleness_stale":         0,
+		"timer_man-db_last_run_result_success": 0,
+		"timer_man-db_last_run_result_failed":  1,
+		"timer_man-db_last_run_exit_status":    1,
+		"timer_man-db_last_run_duration":       10000,
+	}
+
+	assert.Equal(t, expected, mx)
+	collecttest.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+
+	chart := collr.Charts().Get("timer_man-db_staleness")
+	require.NotNil(t, chart)
+	assert.Contains(t, chart.Labels, collectorapi.Label{Key: "triggered_unit", Value: "man-db.service"})
+
+	t.Run("function", func(t *testing.T) {
+		resp := collr.funcRouter.Handle(context.Background(), timersMethodID, nil)
+		require.Equal(t, 200, resp.Status)
+		data, ok := resp.Data.([][]any)
+		require.True(t, ok)
+		require.Len(t, data, 3)
+
+		rows := make(map[string][]any)
+		for _, row := range data {
+			rows[row[0].(string)] = row
+		}
+		assert.Equal(t, "yes", rows["shadow.timer"][3])
+		assert.Equal(t, "exit-code", rows["man-db.timer"][6])
+		assert.Equal(t, int64(1), rows["man-db.timer"][7])
+	})
+}
+
+func TestCollector_Collect_TimersSkipsUnitOnError(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	collr := New()
+	collr.Include = []string{"none.service"}
+	collr.CollectTimers = true
+	collr.IncludeTimers = []string{"logrotate.timer", "shadow.timer"}
+	collr.now = func() time.Time { return now }
+	client := prepareOKClient(230)
+	conn := client.conn.(*mockConn)
+	conn.unitTypeProps = mockTimerProps(now)
+	collr.client = client
+	require.NoError(t, collr.Init(context.Background()))
+
+	mx := collr.Collect(context.Background())
+	require.Contains(t, mx, "timer_shadow_staleness_stale")
+
+	conn.errOnGetUnitTypeProperties = "shadow.timer"
+	mx = collr.Collect(context.Background())
+
+	assert.Contains(t, mx, "timer_logrotate_staleness_ok")
+	assert.NotContains(t, mx, "timer_shadow_staleness_stale")
+	assert.NotNil(t, collr.Charts().Get("timer_shadow_staleness"), "charts of the skipped timer are kept")
+	assert.False(t, conn.closeCalled)
+	assert.Equal(t, 1, client.connectCalls)
+}
+
+func TestCollector_timersFunction_Disabled(t *testing.T) {
+	collr := New()
+	collr.client = prepareOKClient(230)
+	require.NoError(t, collr.Init(context.Background()))
+
+	resp := collr.funcRouter.Handle(context.Background(), timersMethodID, nil)
+	assert.Equal(t, 503, resp.Status)
+}
+
+func Test_timerPeriod(t *testing.T) {
+	usec := func(d time.Duration) uint64 { return uint64(d / time.Microsecond) }
+
+	tests := map[string]struct {
+		props map[string]any
+		want  time.Duration
+	}{
+		"no timers": {
+			props: map[string]any{},
+			want:  0,
+		},
+		"boot only": {
+			props: map[string]any{"TimersMonotonic": [][]any{{"OnBootUSec", usec(time.Minute * 15), uint64(0)}}},
+			want:  0,
+		},
+		"unit active": {
+			props: map[string]any{"TimersMonotonic": [][]any{
+				{"OnBootUSec", usec(time.Minute * 15), uint64(0)},
+				{"OnUnitActiveUSec", usec(time.Hour * 6), uint64(0)},
+			}},
+			want: time.Hour * 6,
+		},
+		"earliest of calendar and monotonic": {
+			props: map[string]any{
+				"TimersMonotonic": [][]any{{"OnUnitActiveUSec", usec(time.Hour * 6), uint64(0)}},
+				"TimersCalendar":  [][]any{{"OnCalendar", "*-*-* *:00:00", uint64(0)}},
+			},
+			want: time.Hour,
+		},
+		"randomized delay": {
+			props: map[string]any{
+				"TimersCalendar":      [][]any{{"OnCalendar", "*-*-* 00:00:00", uint64(0)}},
+				"RandomizedDelayUSec": usec(time.Hour),
+			},
+			want: time.Hour * 25,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, timerPeriod(test.props))
+		})
+	}
+}
+
+func Test_calendarPeriod(t *testing.T) {
+	const day = time.Hour * 24
+
+	tests := map[string]time.Duration{
+		"*-*-* *:*:00":               time.Minute,      // minutely
+		"*-*-* *:00:00":              time.Hour,        // hourly
+		"*-*-* 00:00:00":             day,              // daily
+		"*-*-* 03:10:00 UTC":         day,              // daily with timezone
+		"Mon *-*-* 00:00:00":         day * 7,          // weekly
+		"Mon..Fri *-*-* 09:00:00":    day * 7,          // working days
+		"*-*-01 00:00:00":            day * 31,         // monthly
+		"*-*~01 00:00:00":            day * 31,         // last day of month
+		"*-01,04,07,10-01 00:00:00":  day * 366,        // quarterly
+		"*-01-01 00:00:00":           day * 366,        // yearly
+		"*-*-* *:00/15:00":           time.Minute * 15, // every 15 minutes
+		"*-*-* 00,12:00:00":          day,              // twice a day, rounded up
+		"2026-10-01 12:00:00":        0,                // once
+		"invalid":                    0,                // not a calendar spec
+		"*-*-* 00/6:30:00":           time.Hour * 6,    // every 6 hours
+		"*-*-* *:*:*":                time.Second,      // every second
+		"Sat,Sun *-*-* *:00:00":      day * 7,          // hourly on weekends
+		"*-*-* 04:00:00 Europe/Rome": day,              // daily with a named timezone
+	}
+
+	for spec, want := range tests {
+		t.Run(spec, func(t *testing.T) {
+			assert.Equal(t, want, calendarPeriod(spec))
+		})
+	}
+}
+
+func Test_nextElapseTime(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	orig := monotonicNow
+	defer func() { monotonicNow = orig }()
+	monotonicNow = func() (time.Duration, bool) { return time.Hour, true }
+
+	tests := map[string]struct {
+		props map[string]any
+		want  time.Time
+	}{
+		"not scheduled": {
+			props: map[string]any{"NextElapseUSecRealtime": uint64(0), "NextElapseUSecMonotonic": maxUSec},
+			want:  time.Time{},
+		},
+		"calendar": {
+			props: map[string]any{"NextElapseUSecRealtime": uint64(now.Add(time.Minute).UnixMicro()), "NextElapseUSecMonotonic": uint64(0)},
+			want:  now.Add(time.Minute),
+		},
+		"monotonic": {
+			props: map[string]any{"NextElapseUSecRealtime": uint64(0), "NextElapseUSecMonotonic": uint64((time.Hour + time.Minute*2) / time.Microsecond)},
+			want:  now.Add(time.Minute * 2),
+		},
+		"earliest of both": {
+			props: map[string]any{"NextElapseUSecRealtime": uint64(now.Add(time.Minute * 5).UnixMicro()), "NextElapseUSecMonotonic": uint64((time.Hour + time.Minute*2) / time.Microsecond)},
+			want:  now.Add(time.Minute * 2),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.True(t, test.want.Equal(nextElapseTime(test.props, now)))
+		})
+	}
+}
+
 func TestCollector_connectionReuse(t *testing.T) {
 	collr := New()
 	collr.Include = []string{"*"}
@@ -943,6 +1161,9 @@ type mockConn struct {
 	unitFiles          []dbus.UnitFile
 	errOnListUnitFiles bool
 
+	unitTypeProps              map[string]map[string]any
+	errOnGetUnitTypeProperties string // unit name
+
 	closeCalled bool
 }
 
@@ -979,6 +1200,20 @@ func (m *mockConn) GetUnitPropertyContext(_ context.Context, unit string, proper
 	return &prop, nil
 }
 
+func (m *mockConn) GetUnitTypePropertiesContext(_ context.Context, unit string, unitType string) (map[string]any, error) {
+	if unit == m.errOnGetUnitTypeProperties {
+		return nil, errors.New("'GetUnitTypeProperties' call error")
+	}
+	props, ok := m.unitTypeProps[unit]
+	if !ok {
+		return map[string]any{}, nil
+	}
+	if want := strings.ToUpper(unitType[:1]) + unitType[1:]; !strings.HasSuffix(unit, "."+strings.ToLower(want)) {
+		return nil, fmt.Errorf("'GetUnitTypeProperties' unit '%s' is not of type '%s'", unit, unitType)
+	}
+	return props, nil
+}
+
 func (m *mockConn) ListUnitsContext(_ context.Context) ([]dbus.UnitStatus, error) {
 	if m.errOnListUnits {
 		return nil, errors.New("'ListUnits' call error")
@@ -1098,6 +1333,56 @@ var mockSystemdUnits = []dbus.UnitStatus{
 	{Name: `logrotate.timer`, LoadState: "loaded", ActiveState: "active"},
 }
 
+func mockTimerProps(now time.Time) map[string]map[string]any {
+	usec := func(d time.Duration) uint64 { return uint64(now.Add(d).UnixMicro()) }
+
+	return map[string]map[string]any{
+		"logrotate.timer": {
+			"Unit":                    "logrotate.service",
+			"LastTriggerUSec":         usec(-time.Hour),
+			"NextElapseUSecRealtime":  usec(time.Hour * 23),
+			"NextElapseUSecMonotonic": uint64(0),
+			"TimersCalendar":          [][]any{{"OnCalendar", "*-*-* 11:00:00", usec(time.Hour * 23)}},
+		},
+		"logrotate.service": {
+			"ExecMainStartTimestamp": usec(-time.Hour),
+			"ExecMainExitTimestamp":  usec(-time.Hour + time.Second*2),
+			"ExecMainStatus":         int32(0),
+			"ExecMainCode":           int32(1),
+			"Result":                 "success",
+		},
+		"shadow.timer": {
+			// missed the last run, systemd moved the next elapse to the next day
+			"Unit":                    "shadow.service",
+			"LastTriggerUSec":         usec(-time.Hour * 25),
+			"NextElapseUSecRealtime":  usec(time.Hour * 23),
+			"NextElapseUSecMonotonic": uint64(0),
+			"TimersCalendar":          [][]any{{"OnCalendar", "*-*-* 11:00:00", usec(time.Hour * 23)}},
+		},
+		"shadow.service": {
+			"ExecMainStartTimestamp": usec(-time.Hour * 25),
+			"ExecMainExitTimestamp":  usec(-time.Hour*25 + time.Second*5),
+			"ExecMainStatus":         int32(0),
+			"ExecMainCode":           int32(1),
+			"Result":                 "success",
+		},
+		"man-db.timer": {
+			"Unit":                    "man-db.service",
+			"LastTriggerUSec":         usec(-time.Minute * 30),
+			"NextElapseUSecRealtime":  usec(time.Minute * 30),
+			"NextElapseUSecMonotonic": uint64(0),
+			"TimersMonotonic":         []any{[]any{"OnUnitActiveUSec", uint64(time.Hour / time.Microsecond), uint64(0)}},
+		},
+		"man-db.service": {
+			"ExecMainStartTimestamp": usec(-time.Minute * 30),
+			"ExecMainExitTimestamp":  usec(-time.Minute*30 + time.Second*10),
+			"ExecMainStatus":         int32(1),
+			"ExecMainCode":           int32(1),
+			"Result":                 "exit-code",
+		},
+	}
+}
+
 var mockSystemdUnitFiles = []dbus.UnitFile{
 	{Path: "/lib/systemd/system/systemd-tmpfiles-clean.timer", Type: "static"},
 	{Path: "/lib/systemd/system/sysstat-summary.timer", Type: "disabled"},
@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build linux
+
+package systemdunits
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/pkg/funcapi"
+)
+
+const timersMethodID = "timers"
+
+const timersHelp = "Systemd timers: when they last fired, when they fire next, and the result of the last run of the triggered unit. " +
+	"A timer is stale when it has not fired for longer than its period (OnCalendar=, OnUnitActiveSec=) plus the grace period."
+
+func timersFunctionConfig() funcapi.FunctionConfig {
+	return funcapi.FunctionConfig{
+		ID:          timersMethodID,
+		Name:        "Systemd Timers",
+		UpdateEvery: 10,
+		Help:        timersHelp,
+	}
+}
+
+// Compile-time interface check.
+var _ funcapi.MethodHandler = (*funcTimers)(nil)
+
+// funcTimers handles the "timers" function.
+type funcTimers struct {
+	router *funcRouter
+}
+
+func newFuncTimers(r *funcRouter) *funcTimers {
+	return &funcTimers{router: r}
+}
+
+// MethodParams implements funcapi.MethodHandler.
+func (f *funcTimers) MethodParams(_ context.Context, method string) ([]funcapi.ParamConfig, error) {
+	if method != timersMethodID {
+		return nil, fmt.Errorf("unknown method: %s", method)
+	}
+	return nil, nil
+}
+
+// Handle implements funcapi.MethodHandler.
+func (f *funcTimers) Handle(_ context.Context, method string, _ funcapi.ResolvedParams) *funcapi.FunctionResponse {
+	if method != timersMethodID {
+		return funcapi.NotFoundResponse(method)
+	}
+
+	c := f.router.collector
+	if !c.CollectTimers {
+		return funcapi.UnavailableResponse("timers collection is disabled, enable it with 'collect_timers'")
+	}
+
+	c.timersMu.RLock()
+	timers := c.timers
+	c.timersMu.RUnlock()
+
+	if timers == nil {
+		return funcapi.UnavailableResponse("timers have not been collected yet, please retry later")
+	}
+
+	cs := timerColumnSet(timerColumns)
+	data := make([][]any, 0, len(timers))
+	for _, ti := range timers {
+		row := make([]any, len(timerColumns))
+		for i, col := range timerColumns {
+			row[i] = col.Value(ti)
+		}
+		data = append(data, row)
+	}
+
+	return &funcapi.FunctionResponse{
+		Status:            200,
+		Help:              timersHelp,
+		Columns:           cs.BuildColumns(),
+		Data:              data,
+		DefaultSortColumn: "Timer",
+	}
+}
+
+// Cleanup implements funcapi.MethodHandler.
+func (f *funcTimers) Cleanup(context.Context) {}
+
+type timerColumn struct {
+	funcapi.ColumnMeta
+	Value func(timerInfo) any
+}
+
+func timerColumnSet(cols []timerColumn) funcapi.ColumnSet[timerColumn] {
+	return funcapi.Columns(cols, func(c timerColumn) funcapi.ColumnMeta { return c.ColumnMeta })
+}
+
+var timerColumns = []timerColumn{
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Timer", Tooltip: "Timer unit", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true, Sticky: true, UniqueKey: true}, Value: func(ti timerInfo) any { return ti.name }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Activates", Tooltip: "Unit triggered by the timer", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true}, Value: func(ti timerInfo) any { return ti.unit }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "State", Tooltip: "Timer unit active state", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true, Visualization: funcapi.FieldVisualPill}, Value: func(ti timerInfo) any { return ti.activeState }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Stale", Tooltip: "The timer has not fired for longer than its period plus the grace period", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true, Visualization: funcapi.FieldVisualPill}, Value: func(ti timerInfo) any { return yesNo(ti.stale) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Last Trigger", Tooltip: "When the timer last fired", Type: funcapi.FieldTypeTimestamp, Visible: true, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummaryMax, Filter: funcapi.FieldFilterRange, Sortable: true, Transform: funcapi.FieldTransformDatetime}, Value: func(ti timerInfo) any { return timeMilliCell(ti.lastTrigger) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Next Elapse", Tooltip: "When the timer fires next", Type: funcapi.FieldTypeTimestamp, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryMin, Filter: funcapi.FieldFilterRange, Sortable: true, Transform: funcapi.FieldTransformDatetime}, Value: func(ti timerInfo) any { return timeMilliCell(ti.nextElapse) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Last Result", Tooltip: "Result of the last run of the triggered unit", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true, Visualization: funcapi.FieldVisualPill}, Value: func(ti timerInfo) any {
+		switch {
+		case !ti.hasLastRun:
+			return nil
+		case ti.running:
+			return "running"
+		default:
+			return ti.result
+		}
+	}},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Exit Status", Tooltip: "Exit status (or signal number if killed) of the main process of the last run", Type: funcapi.FieldTypeInteger, Visible: true, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummaryMax, Filter: funcapi.FieldFilterRange, Sortable: true}, Value: func(ti timerInfo) any {
+		if !ti.hasLastRun || ti.running {
+			return nil
+		}
+		return ti.exitStatus
+	}},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Exit Code", Tooltip: "How the main process of the last run terminated (exited, killed, dumped)", Type: funcapi.FieldTypeString, Visible: false, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true}, Value: func(ti timerInfo) any {
+		if !ti.hasLastRun || ti.running {
+			return nil
+		}
+		return ti.exitCode
+	}},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Duration", Tooltip: "Runtime of the last run, or of the current run if the unit is still running", Type: funcapi.FieldTypeDuration, Units: "seconds", Visible: true, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummaryMax, Filter: funcapi.FieldFilterRange, Sortable: true, Transform: funcapi.FieldTransformDuration}, Value: func(ti timerInfo) any {
+		if !ti.hasLastRun {
+			return nil
+		}
+		return ti.lastRunLength.Seconds()
+	}},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Last Run Started", Tooltip: "When the last run of the triggered unit started", Type: funcapi.FieldTypeTimestamp, Visible: false, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummaryMax, Filter: funcapi.FieldFilterRange, Sortable: true, Transform: funcapi.FieldTransformDatetime}, Value: func(ti timerInfo) any { return timeMilliCell(ti.lastRunStart) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Last Run Exited", Tooltip: "When the last run of the triggered unit exited", Type: funcapi.FieldTypeTimestamp, Visible: false, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummaryMax, Filter: funcapi.FieldFilterRange, Sortable: true, Transform: funcapi.FieldTransformDatetime}, Value: func(ti timerInfo) any {
+		if ti.running {
+			return nil
+		}
+		return timeMilliCell(ti.lastRunExit)
+	}},
+}
+
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
+
+func timeMilliCell(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UnixMilli()
+}
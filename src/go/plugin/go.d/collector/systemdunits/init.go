@@ -26,3 +26,12 @@ func (c *Collector) initUnitSelector() (matcher.Matcher, error) {
 	expr := strings.Join(c.Include, " ")
 	return matcher.NewSimplePatternsMatcher(expr)
 }
+
+func (c *Collector) initTimerSelector() (matcher.Matcher, error) {
+	if len(c.IncludeTimers) == 0 {
+		return matcher.TRUE(), nil
+	}
+
+	expr := strings.Join(c.IncludeTimers, " ")
+	return matcher.NewSimplePatternsMatcher(expr)
+}
@@ -14,11 +14,22 @@ import (
 const (
 	prioZpoolHealthState = 2820 + iota
 	prioVdevHealthState
+	prioVdevErrors
 
 	prioZpoolSpaceUtilization
 	prioZpoolSpaceUsage
 
 	prioZpoolFragmentation
+
+	prioZpoolScanState
+	prioZpoolScanProgress
+	prioZpoolScanTimeRemaining
+	prioZpoolScanErrors
+
+	prioDatasetSpaceUsage
+	prioDatasetReferenced
+	prioDatasetQuotaUtilization
+	prioDatasetCompressionRatio
 )
 
 var zpoolChartsTmpl = collectorapi.Charts{
@@ -28,6 +39,11 @@ var zpoolChartsTmpl = collectorapi.Charts{
 	zpoolSpaceUsageChartTmpl.Copy(),
 
 	zpoolFragmentationChartTmpl.Copy(),
+
+	zpoolScanStateChartTmpl.Copy(),
+	zpoolScanProgressChartTmpl.Copy(),
+	zpoolScanTimeRemainingChartTmpl.Copy(),
+	zpoolScanErrorsChartTmpl.Copy(),
 }
 
 var (
@@ -88,10 +104,63 @@ var (
 			{ID: "zpool_%s_frag", Name: "fragmentation"},
 		},
 	}
+
+	zpoolScanStateChartTmpl = collectorapi.Chart{
+		ID:       "zfspool_%s_scan_state",
+		Title:    "Zpool scrub/resilver state",
+		Units:    "state",
+		Fam:      "scan",
+		Ctx:      "zfspool.pool_scan_state",
+		Type:     collectorapi.Line,
+		Priority: prioZpoolScanState,
+		Dims: collectorapi.Dims{
+			{ID: "zpool_%s_scan_state_idle", Name: "idle"},
+			{ID: "zpool_%s_scan_state_scrubbing", Name: "scrubbing"},
+			{ID: "zpool_%s_scan_state_resilvering", Name: "resilvering"},
+			{ID: "zpool_%s_scan_state_scrub_paused", Name: "scrub_paused"},
+		},
+	}
+	zpoolScanProgressChartTmpl = collectorapi.Chart{
+		ID:       "zfspool_%s_scan_progress",
+		Title:    "Zpool scrub/resilver progress",
+		Units:    "percentage",
+		Fam:      "scan",
+		Ctx:      "zfspool.pool_scan_progress",
+		Type:     collectorapi.Line,
+		Priority: prioZpoolScanProgress,
+		Dims: collectorapi.Dims{
+			{ID: "zpool_%s_scan_progress", Name: "progress", Div: 100},
+		},
+	}
+	zpoolScanTimeRemainingChartTmpl = collectorapi.Chart{
+		ID:       "zfspool_%s_scan_time_remaining",
+		Title:    "Zpool scrub/resilver estimated time remaining",
+		Units:    "seconds",
+		Fam:      "scan",
+		Ctx:      "zfspool.pool_scan_time_remaining",
+		Type:     collectorapi.Line,
+		Priority: prioZpoolScanTimeRemaining,
+		Dims: collectorapi.Dims{
+			{ID: "zpool_%s_scan_time_remaining", Name: "time_remaining"},
+		},
+	}
+	zpoolScanErrorsChartTmpl = collectorapi.Chart{
+		ID:       "zfspool_%s_scan_errors",
+		Title:    "Zpool errors found by the last scrub/resilver",
+		Units:    "errors",
+		Fam:      "scan",
+		Ctx:      "zfspool.pool_scan_errors",
+		Type:     collectorapi.Line,
+		Priority: prioZpoolScanErrors,
+		Dims: collectorapi.Dims{
+			{ID: "zpool_%s_scan_errors", Name: "errors"},
+		},
+	}
 )
 
 var vdevChartsTmpl = collectorapi.Charts{
 	vdevHealthStateChartTmpl.Copy(),
+	vdevErrorsChartTmpl.Copy(),
 }
 
 var (
@@ -113,6 +182,82 @@ var (
 			{ID: "vdev_%s_health_state_suspended", Name: "suspended"},
 		},
 	}
+	vdevErrorsChartTmpl = collectorapi.Chart{
+		ID:       "vdev_%s_errors",
+		Title:    "Zpool Vdev I/O errors",
+		Units:    "errors",
+		Fam:      "errors",
+		Ctx:      "zfspool.vdev_errors",
+		Type:     collectorapi.Line,
+		Priority: prioVdevErrors,
+		Dims: collectorapi.Dims{
+			{ID: "vdev_%s_read_errors", Name: "read"},
+			{ID: "vdev_%s_write_errors", Name: "write"},
+			{ID: "vdev_%s_checksum_errors", Name: "checksum"},
+		},
+	}
+)
+
+var datasetChartsTmpl = collectorapi.Charts{
+	datasetSpaceUsageChartTmpl.Copy(),
+	datasetReferencedChartTmpl.Copy(),
+	datasetCompressionRatioChartTmpl.Copy(),
+}
+
+var datasetQuotaChartsTmpl = collectorapi.Charts{
+	datasetQuotaUtilizationChartTmpl.Copy(),
+}
+
+var (
+	datasetSpaceUsageChartTmpl = collectorapi.Chart{
+		ID:       "dataset_%s_space_usage",
+		Title:    "ZFS dataset space usage",
+		Units:    "bytes",
+		Fam:      "datasets",
+		Ctx:      "zfspool.dataset_space_usage",
+		Type:     collectorapi.Stacked,
+		Priority: prioDatasetSpaceUsage,
+		Dims: collectorapi.Dims{
+			{ID: "dataset_%s_available", Name: "available"},
+			{ID: "dataset_%s_used", Name: "used"},
+		},
+	}
+	datasetReferencedChartTmpl = collectorapi.Chart{
+		ID:       "dataset_%s_referenced",
+		Title:    "ZFS dataset referenced space",
+		Units:    "bytes",
+		Fam:      "datasets",
+		Ctx:      "zfspool.dataset_referenced",
+		Type:     collectorapi.Area,
+		Priority: prioDatasetReferenced,
+		Dims: collectorapi.Dims{
+			{ID: "dataset_%s_referenced", Name: "referenced"},
+		},
+	}
+	datasetQuotaUtilizationChartTmpl = collectorapi.Chart{
+		ID:       "dataset_%s_quota_utilization",
+		Title:    "ZFS dataset quota utilization",
+		Units:    "percentage",
+		Fam:      "datasets",
+		Ctx:      "zfspool.dataset_quota_utilization",
+		Type:     collectorapi.Area,
+		Priority: prioDatasetQuotaUtilization,
+		Dims: collectorapi.Dims{
+			{ID: "dataset_%s_quota_utilization", Name: "utilization", Div: 100},
+		},
+	}
+	datasetCompressionRatioChartTmpl = collectorapi.Chart{
+		ID:       "dataset_%s_compression_ratio",
+		Title:    "ZFS dataset compression ratio",
+		Units:    "ratio",
+		Fam:      "datasets",
+		Ctx:      "zfspool.dataset_compression_ratio",
+		Type:     collectorapi.Line,
+		Priority: prioDatasetCompressionRatio,
+		Dims: collectorapi.Dims{
+			{ID: "dataset_%s_compressratio", Name: "compressratio", Div: 100},
+		},
+	}
 )
 
 func (c *Collector) addZpoolCharts(name string) {
@@ -162,6 +307,59 @@ func (c *Collector) removeVdevCharts(vdev string) {
 	c.removeCharts(px)
 }
 
+func (c *Collector) addDatasetCharts(ds *datasetEntry) {
+	c.addDatasetChartsFromTmpl(datasetChartsTmpl, ds)
+}
+
+func (c *Collector) addDatasetQuotaCharts(ds *datasetEntry) {
+	c.addDatasetChartsFromTmpl(datasetQuotaChartsTmpl, ds)
+}
+
+func (c *Collector) addDatasetChartsFromTmpl(tmpl collectorapi.Charts, ds *datasetEntry) {
+	charts := tmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, cleanVdev(ds.name))
+		chart.Labels = []collectorapi.Label{
+			{Key: "pool", Value: datasetPool(ds.name)},
+			{Key: "dataset", Value: ds.name},
+			{Key: "type", Value: ds.typ},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, ds.name)
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) removeDatasetCharts(name string) {
+	c.removeDatasetChartsFromTmpl(datasetChartsTmpl, name)
+	c.removeDatasetQuotaCharts(name)
+}
+
+func (c *Collector) removeDatasetQuotaCharts(name string) {
+	c.removeDatasetChartsFromTmpl(datasetQuotaChartsTmpl, name)
+}
+
+// removeDatasetChartsFromTmpl matches chart IDs exactly: a prefix match would also
+// remove the charts of "pool/data_old" along with "pool/data".
+func (c *Collector) removeDatasetChartsFromTmpl(tmpl collectorapi.Charts, name string) {
+	ids := make(map[string]bool, len(tmpl))
+	for _, chart := range tmpl {
+		ids[fmt.Sprintf(chart.ID, cleanVdev(name))] = true
+	}
+
+	for _, chart := range *c.Charts() {
+		if ids[chart.ID] {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
+
 func (c *Collector) removeCharts(px string) {
 	for _, chart := range *c.Charts() {
 		if strings.HasPrefix(chart.ID, px) {
@@ -24,6 +24,14 @@ func (c *Collector) collect() (map[string]int64, error) {
 	if err := c.collectZpoolListVdev(mx); err != nil {
 		return mx, err
 	}
+	if err := c.collectZpoolStatus(mx); err != nil {
+		return mx, err
+	}
+	if c.zfsExec != nil {
+		if err := c.collectDatasets(mx); err != nil {
+			return mx, err
+		}
+	}
 
 	return mx, nil
 }
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build linux || freebsd || openbsd || netbsd || dragonfly
+
+package zfspool
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+type datasetEntry struct {
+	name          string
+	typ           string
+	usedBytes     string
+	availBytes    string
+	referBytes    string
+	compressRatio string
+	quotaBytes    string
+}
+
+type datasetState struct {
+	hasQuotaCharts bool
+}
+
+func (c *Collector) collectDatasets(mx map[string]int64) error {
+	bs, err := c.zfsExec.listDatasets()
+	if err != nil {
+		return err
+	}
+
+	datasets, err := parseZfsListOutput(bs)
+	if err != nil {
+		return fmt.Errorf("bad zfs list output: %v", err)
+	}
+
+	seen := make(map[string]bool)
+
+	for _, ds := range datasets {
+		if !c.datasetSr.MatchString(ds.name) {
+			continue
+		}
+
+		seen[ds.name] = true
+
+		state, ok := c.seenDatasets[ds.name]
+		if !ok {
+			state = &datasetState{}
+			c.seenDatasets[ds.name] = state
+			c.addDatasetCharts(ds)
+		}
+
+		px := "dataset_" + ds.name + "_"
+
+		if v, ok := parseInt(ds.usedBytes); ok {
+			mx[px+"used"] = v
+		}
+		if v, ok := parseInt(ds.availBytes); ok {
+			mx[px+"available"] = v
+		}
+		if v, ok := parseInt(ds.referBytes); ok {
+			mx[px+"referenced"] = v
+		}
+		if v, ok := parseFloat(strings.TrimSuffix(ds.compressRatio, "x")); ok {
+			mx[px+"compressratio"] = int64(v * 100)
+		}
+
+		// A quota of 0 means "none"; volumes have no quota at all ("-").
+		quota, ok := parseInt(ds.quotaBytes)
+		hasQuota := ok && quota > 0
+		if hasQuota != state.hasQuotaCharts {
+			state.hasQuotaCharts = hasQuota
+			if hasQuota {
+				c.addDatasetQuotaCharts(ds)
+			} else {
+				c.removeDatasetQuotaCharts(ds.name)
+			}
+		}
+		if hasQuota {
+			used, _ := parseInt(ds.usedBytes)
+			mx[px+"quota_utilization"] = int64(float64(used) * 100 * 100 / float64(quota))
+		}
+	}
+
+	for name := range c.seenDatasets {
+		if !seen[name] {
+			c.removeDatasetCharts(name)
+			delete(c.seenDatasets, name)
+		}
+	}
+
+	return nil
+}
+
+func parseZfsListOutput(bs []byte) ([]*datasetEntry, error) {
+	/*
+	   # zfs list -p -H -t filesystem,volume -o name,type,used,avail,refer,compressratio,quota
+	   rpool	filesystem	1647130456064	2338599194624	98304	1.48	0
+	   rpool/data/vm-100-disk-0	volume	34359738368	2338599194624	21474836480	1.21	-
+	*/
+
+	var datasets []*datasetEntry
+	sc := bufio.NewScanner(bytes.NewReader(bs))
+
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+
+		values := strings.Split(line, "\t")
+		if len(values) != 7 {
+			return nil, fmt.Errorf("unexpected columns: want 7, got %d (line '%s')", len(values), line)
+		}
+
+		datasets = append(datasets, &datasetEntry{
+			name:          values[0],
+			typ:           values[1],
+			usedBytes:     values[2],
+			availBytes:    values[3],
+			referBytes:    values[4],
+			compressRatio: values[5],
+			quotaBytes:    values[6],
+		})
+	}
+
+	return datasets, nil
+}
+
+func datasetPool(name string) string {
+	pool, _, _ := strings.Cut(name, "/")
+	return pool
+}
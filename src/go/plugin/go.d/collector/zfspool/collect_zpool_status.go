@@ -0,0 +1,359 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build linux || freebsd || openbsd || netbsd || dragonfly
+
+package zfspool
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	scanStateIdle        = "idle"
+	scanStateScrubbing   = "scrubbing"
+	scanStateResilvering = "resilvering"
+	scanStateScrubPaused = "scrub_paused"
+)
+
+var scanStates = []string{
+	scanStateIdle,
+	scanStateScrubbing,
+	scanStateResilvering,
+	scanStateScrubPaused,
+}
+
+type poolStatus struct {
+	name  string
+	state string
+	scan  scanStatus
+	vdevs []statusVdev // the first entry is the pool itself
+}
+
+type scanStatus struct {
+	description   string
+	state         string
+	progress      float64 // percentage done
+	timeRemaining time.Duration
+	errors        int64 // errors found by the completed scan
+	hasErrors     bool
+}
+
+type statusVdev struct {
+	name   string
+	vdev   string // The full path of the vdev within the zpool hierarchy, as in "zpool list -v".
+	state  string
+	note   string
+	level  int
+	read   int64
+	write  int64
+	cksum  int64
+	hasErr bool // READ, WRITE and CKSUM columns are present (not for "logs", "cache", spares...)
+	leaf   bool
+}
+
+func (v statusVdev) typ() string {
+	switch {
+	case v.level < 0:
+		return "pool"
+	case v.state == "":
+		return "class"
+	case v.leaf:
+		return "disk"
+	}
+	// "mirror-0" => "mirror", "raidz2-1" => "raidz2", "replacing-0" => "replacing"
+	if i := strings.LastIndexByte(v.name, '-'); i > 0 {
+		if _, err := strconv.Atoi(v.name[i+1:]); err == nil {
+			return v.name[:i]
+		}
+	}
+	return v.name
+}
+
+func (c *Collector) collectZpoolStatus(mx map[string]int64) error {
+	bs, err := c.exec.status()
+	if err != nil {
+		return err
+	}
+
+	pools, err := parseZpoolStatusOutput(bs)
+	if err != nil {
+		return fmt.Errorf("bad zpool status output: %v", err)
+	}
+
+	for _, pool := range pools {
+		if !c.seenZpools[pool.name] {
+			continue
+		}
+
+		px := "zpool_" + pool.name + "_"
+
+		for _, s := range scanStates {
+			mx[px+"scan_state_"+s] = 0
+		}
+		mx[px+"scan_state_"+pool.scan.state] = 1
+		mx[px+"scan_progress"] = int64(pool.scan.progress * 100)
+		mx[px+"scan_time_remaining"] = int64(pool.scan.timeRemaining.Seconds())
+		if pool.scan.hasErrors {
+			c.scanErrors[pool.name] = pool.scan.errors
+		}
+		mx[px+"scan_errors"] = c.scanErrors[pool.name]
+
+		for _, vdev := range pool.vdevs[1:] {
+			if !vdev.hasErr || !c.seenVdevs[vdev.vdev] {
+				continue
+			}
+			px := fmt.Sprintf("vdev_%s_", vdev.vdev)
+			mx[px+"read_errors"] = vdev.read
+			mx[px+"write_errors"] = vdev.write
+			mx[px+"checksum_errors"] = vdev.cksum
+		}
+	}
+
+	for name := range c.scanErrors {
+		if !c.seenZpools[name] {
+			delete(c.scanErrors, name)
+		}
+	}
+
+	c.mu.Lock()
+	c.poolsTree = pools
+	c.mu.Unlock()
+
+	return nil
+}
+
+var reStatusKey = regexp.MustCompile(`^ *([a-z_]+): ?(.*)$`)
+
+func parseZpoolStatusOutput(bs []byte) ([]*poolStatus, error) {
+	/*
+	     pool: rpool
+	    state: ONLINE
+	     scan: scrub in progress since Sun Oct 19 00:24:01 2026
+	           1352399552512 / 1647130456064 scanned at 536870912/s, 879609302016 / 1647130456064 issued at 419430400/s
+	           0 repaired, 53.40% done, 00:30:29 to go
+	   config:
+
+	           NAME           STATE     READ WRITE CKSUM
+	           rpool          ONLINE       0     0     0
+	             mirror-0     ONLINE       0     0     0
+	               nvme2n1p3  ONLINE       0     0     0
+	               nvme0n1p3  ONLINE       0     0     0
+
+	   errors: No known data errors
+	*/
+
+	var pools []*poolStatus
+	var pool *poolStatus
+	var key string
+	var scanLines []string
+	var configLines []string
+
+	finish := func() error {
+		if pool == nil {
+			return nil
+		}
+		pool.scan = parseScanStatus(strings.Join(scanLines, " "))
+		vdevs, err := parseStatusConfig(configLines)
+		if err != nil {
+			return fmt.Errorf("pool '%s': %v", pool.name, err)
+		}
+		pool.vdevs = vdevs
+		pools = append(pools, pool)
+		scanLines, configLines = nil, nil
+		return nil
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(bs))
+
+	for sc.Scan() {
+		line := sc.Text()
+
+		if m := reStatusKey.FindStringSubmatch(line); m != nil {
+			key = m[1]
+			value := strings.TrimSpace(m[2])
+
+			switch key {
+			case "pool":
+				if err := finish(); err != nil {
+					return nil, err
+				}
+				pool = &poolStatus{name: value}
+			case "state":
+				if pool != nil {
+					pool.state = strings.ToLower(value)
+				}
+			case "scan":
+				scanLines = append(scanLines, value)
+			}
+			continue
+		}
+
+		if pool == nil || !strings.HasPrefix(line, "\t") {
+			continue
+		}
+
+		switch key {
+		case "scan":
+			scanLines = append(scanLines, strings.TrimSpace(line))
+		case "config":
+			configLines = append(configLines, strings.TrimPrefix(line, "\t"))
+		}
+	}
+
+	if err := finish(); err != nil {
+		return nil, err
+	}
+
+	if len(pools) == 0 {
+		return nil, fmt.Errorf("no pools found")
+	}
+
+	return pools, nil
+}
+
+func parseStatusConfig(lines []string) ([]statusVdev, error) {
+	var headers []string
+	var vdevs []statusVdev
+	var poolIndent int
+
+	for _, line := range lines {
+		values := strings.Fields(line)
+		if len(values) == 0 {
+			continue
+		}
+
+		if len(headers) == 0 {
+			if values[0] != "NAME" {
+				return nil, fmt.Errorf("missing headers (line '%s')", line)
+			}
+			headers = values
+			continue
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if len(vdevs) == 0 {
+			poolIndent = indent
+		}
+
+		vdev := statusVdev{
+			name:  values[0],
+			level: indent - poolIndent,
+		}
+		if len(vdevs) == 0 {
+			vdev.level = -1 // Pool
+		}
+
+		for i, v := range values[1:min(len(values), len(headers))] {
+			switch headers[i+1] {
+			case "STATE":
+				vdev.state = strings.ToLower(v)
+			case "READ":
+				vdev.read, vdev.hasErr = parseErrorCount(v)
+			case "WRITE":
+				vdev.write, _ = parseErrorCount(v)
+			case "CKSUM":
+				vdev.cksum, _ = parseErrorCount(v)
+			}
+		}
+		if len(values) > len(headers) {
+			vdev.note = strings.Join(values[len(headers):], " ")
+		}
+
+		vdevs = append(vdevs, vdev)
+	}
+
+	if len(vdevs) == 0 {
+		return nil, fmt.Errorf("no vdevs found")
+	}
+
+	// set parent/child relationships, the same way as for "zpool list -v"
+	for i := range vdevs {
+		v := &vdevs[i]
+
+		if i == 0 {
+			v.vdev = v.name
+			continue
+		}
+		for j := i - 1; j >= 0; j-- {
+			if vdevs[j].level < v.level {
+				v.vdev = fmt.Sprintf("%s/%s", vdevs[j].vdev, v.name)
+				break
+			}
+		}
+		if v.vdev == "" {
+			return nil, fmt.Errorf("no parent for vdev '%s'", v.name)
+		}
+		v.leaf = i == len(vdevs)-1 || vdevs[i+1].level <= v.level
+	}
+
+	return vdevs, nil
+}
+
+var (
+	reScanInProgress = regexp.MustCompile(`^(scrub|resilver)\b.*\bin progress since`)
+	reScanPaused     = regexp.MustCompile(`^scrub paused since`)
+	reScanErrors     = regexp.MustCompile(`\bwith (\d+) errors\b`)
+	reScanProgress   = regexp.MustCompile(`([\d.]+)% done`)
+	reScanETA        = regexp.MustCompile(`(?:(\d+) days? )?(\d+):(\d{2}):(\d{2}) to go`)
+	reScanETALegacy  = regexp.MustCompile(`(\d+)h(\d+)m to go`)
+)
+
+func parseScanStatus(s string) scanStatus {
+	/*
+	   none requested
+	   scrub repaired 0B in 00:10:23 with 0 errors on Sun Oct 19 00:34:24 2026
+	   resilvered 1.20G in 00:05:12 with 0 errors on Sun Oct 19 00:34:24 2026
+	   scrub canceled on Sun Oct 19 00:34:24 2026
+	   scrub paused since Sun Oct 19 00:24:01 2026 ...
+	   resilver in progress since Sun Oct 19 00:24:01 2026 ... 1.20G resilvered, 45.00% done, 00:03:00 to go
+	*/
+	scan := scanStatus{description: s, state: scanStateIdle}
+
+	switch m := reScanInProgress.FindStringSubmatch(s); {
+	case m != nil && m[1] == "scrub":
+		scan.state = scanStateScrubbing
+	case m != nil:
+		scan.state = scanStateResilvering
+	case reScanPaused.MatchString(s):
+		scan.state = scanStateScrubPaused
+	default:
+		if m := reScanErrors.FindStringSubmatch(s); m != nil {
+			scan.errors, _ = strconv.ParseInt(m[1], 10, 64)
+			scan.hasErrors = true
+		}
+		return scan
+	}
+
+	if m := reScanProgress.FindStringSubmatch(s); m != nil {
+		scan.progress, _ = strconv.ParseFloat(m[1], 64)
+	}
+
+	if scan.state == scanStateScrubPaused {
+		return scan
+	}
+
+	if m := reScanETA.FindStringSubmatch(s); m != nil {
+		days, _ := strconv.Atoi(m[1])
+		h, _ := strconv.Atoi(m[2])
+		mi, _ := strconv.Atoi(m[3])
+		sec, _ := strconv.Atoi(m[4])
+		scan.timeRemaining = time.Duration(days*24+h)*time.Hour + time.Duration(mi)*time.Minute + time.Duration(sec)*time.Second
+	} else if m := reScanETALegacy.FindStringSubmatch(s); m != nil {
+		h, _ := strconv.Atoi(m[1])
+		mi, _ := strconv.Atoi(m[2])
+		scan.timeRemaining = time.Duration(h)*time.Hour + time.Duration(mi)*time.Minute
+	}
+
+	return scan
+}
+
+func parseErrorCount(s string) (int64, bool) {
+	v, err := strconv.ParseInt(s, 10, 64)
+	return v, err == nil
+}
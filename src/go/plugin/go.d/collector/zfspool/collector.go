@@ -9,9 +9,11 @@ import (
 	_ "embed"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/netdata/netdata/go/plugins/pkg/confopt"
+	"github.com/netdata/netdata/go/plugins/pkg/matcher"
 	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
 )
 
@@ -24,27 +26,36 @@ func init() {
 		Defaults: collectorapi.Defaults{
 			UpdateEvery: 10,
 		},
-		Create: func() collectorapi.CollectorV1 { return New() },
-		Config: func() any { return &Config{} },
+		Create:          func() collectorapi.CollectorV1 { return New() },
+		Config:          func() any { return &Config{} },
+		SharedFunctions: zfspoolMethods,
+		MethodHandler:   zfspoolFunctionHandler,
 	})
 }
 
 func New() *Collector {
 	return &Collector{
 		Config: Config{
-			BinaryPath: "/usr/bin/zpool",
-			Timeout:    confopt.Duration(time.Second * 2),
+			BinaryPath:      "/usr/bin/zpool",
+			ZfsBinaryPath:   "/usr/bin/zfs",
+			DatasetSelector: "!*/* *",
+			Timeout:         confopt.Duration(time.Second * 2),
 		},
-		charts:     &collectorapi.Charts{},
-		seenZpools: make(map[string]bool),
-		seenVdevs:  make(map[string]bool),
+		charts:       &collectorapi.Charts{},
+		datasetSr:    matcher.TRUE(),
+		seenZpools:   make(map[string]bool),
+		seenVdevs:    make(map[string]bool),
+		seenDatasets: make(map[string]*datasetState),
+		scanErrors:   make(map[string]int64),
 	}
 }
 
 type Config struct {
-	UpdateEvery int              `yaml:"update_every,omitempty" json:"update_every"`
-	Timeout     confopt.Duration `yaml:"timeout,omitempty" json:"timeout"`
-	BinaryPath  string           `yaml:"binary_path,omitempty" json:"binary_path"`
+	UpdateEvery     int              `yaml:"update_every,omitempty" json:"update_every"`
+	Timeout         confopt.Duration `yaml:"timeout,omitempty" json:"timeout"`
+	BinaryPath      string           `yaml:"binary_path,omitempty" json:"binary_path"`
+	ZfsBinaryPath   string           `yaml:"zfs_binary_path,omitempty" json:"zfs_binary_path"`
+	DatasetSelector string           `yaml:"dataset_selector,omitempty" json:"dataset_selector"`
 }
 
 type Collector struct {
@@ -53,10 +64,20 @@ type Collector struct {
 
 	charts *collectorapi.Charts
 
-	exec zpoolCli
+	funcRouter *funcRouter
 
-	seenZpools map[string]bool
-	seenVdevs  map[string]bool
+	exec    zpoolCli
+	zfsExec zfsCli
+
+	datasetSr matcher.Matcher
+
+	seenZpools   map[string]bool
+	seenVdevs    map[string]bool
+	seenDatasets map[string]*datasetState
+	scanErrors   map[string]int64 // errors found by the last completed scan, per pool
+
+	mu        sync.RWMutex
+	poolsTree []*poolStatus // the last "zpool status" output, for the vdev tree function
 }
 
 func (c *Collector) Configuration() any {
@@ -74,6 +95,23 @@ func (c *Collector) Init(context.Context) error {
 	}
 	c.exec = zpoolExec
 
+	sr, err := c.initDatasetSelector()
+	if err != nil {
+		return fmt.Errorf("dataset selector initialization: %v", err)
+	}
+	c.datasetSr = sr
+
+	if c.ZfsBinaryPath != "" {
+		zfsExec, err := c.initZfsCLIExec()
+		if err != nil {
+			c.Warningf("zfs exec initialization: %v (datasets are not collected)", err)
+		} else {
+			c.zfsExec = zfsExec
+		}
+	}
+
+	c.funcRouter = newFuncRouter(c)
+
 	return nil
 }
 
@@ -107,4 +145,8 @@ func (c *Collector) Collect(context.Context) map[string]int64 {
 	return mx
 }
 
-func (c *Collector) Cleanup(context.Context) {}
+func (c *Collector) Cleanup(ctx context.Context) {
+	if c.funcRouter != nil {
+		c.funcRouter.Cleanup(ctx)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/collecttest"
@@ -25,6 +26,9 @@ var (
 	dataZpoolList, _                  = os.ReadFile("testdata/zpool-list.txt")
 	dataZpoolListWithVdev, _          = os.ReadFile("testdata/zpool-list-vdev.txt")
 	dataZpoolListWithVdevLogsCache, _ = os.ReadFile("testdata/zpool-list-vdev-logs-cache.txt")
+	dataZpoolStatus, _                = os.ReadFile("testdata/zpool-status.txt")
+	dataZpoolStatusLogsCache, _       = os.ReadFile("testdata/zpool-status-logs-cache.txt")
+	dataZfsList, _                    = os.ReadFile("testdata/zfs-list.txt")
 )
 
 func Test_testDataIsValid(t *testing.T) {
@@ -35,6 +39,9 @@ func Test_testDataIsValid(t *testing.T) {
 		"dataZpoolList":                  dataZpoolList,
 		"dataZpoolListWithVdev":          dataZpoolListWithVdev,
 		"dataZpoolListWithVdevLogsCache": dataZpoolListWithVdevLogsCache,
+		"dataZpoolStatus":                dataZpoolStatus,
+		"dataZpoolStatusLogsCache":       dataZpoolStatusLogsCache,
+		"dataZfsList":                    dataZfsList,
 	} {
 		require.NotNil(t, data, name)
 
@@ -164,6 +171,7 @@ func TestCollector_Collect(t *testing.T) {
 		"success case": {
 			prepareMock: prepareMockOk,
 			wantMetrics: map[string]int64{
+				"vdev_rpool/mirror-0/nvme0n1p3_checksum_errors":        12,
 				"vdev_rpool/mirror-0/nvme0n1p3_health_state_degraded":  0,
 				"vdev_rpool/mirror-0/nvme0n1p3_health_state_faulted":   0,
 				"vdev_rpool/mirror-0/nvme0n1p3_health_state_offline":   0,
@@ -171,6 +179,9 @@ func TestCollector_Collect(t *testing.T) {
 				"vdev_rpool/mirror-0/nvme0n1p3_health_state_removed":   0,
 				"vdev_rpool/mirror-0/nvme0n1p3_health_state_suspended": 0,
 				"vdev_rpool/mirror-0/nvme0n1p3_health_state_unavail":   0,
+				"vdev_rpool/mirror-0/nvme0n1p3_read_errors":            0,
+				"vdev_rpool/mirror-0/nvme0n1p3_write_errors":           0,
+				"vdev_rpool/mirror-0/nvme2n1p3_checksum_errors":        0,
 				"vdev_rpool/mirror-0/nvme2n1p3_health_state_degraded":  0,
 				"vdev_rpool/mirror-0/nvme2n1p3_health_state_faulted":   0,
 				"vdev_rpool/mirror-0/nvme2n1p3_health_state_offline":   0,
@@ -178,6 +189,9 @@ func TestCollector_Collect(t *testing.T) {
 				"vdev_rpool/mirror-0/nvme2n1p3_health_state_removed":   0,
 				"vdev_rpool/mirror-0/nvme2n1p3_health_state_suspended": 0,
 				"vdev_rpool/mirror-0/nvme2n1p3_health_state_unavail":   0,
+				"vdev_rpool/mirror-0/nvme2n1p3_read_errors":            0,
+				"vdev_rpool/mirror-0/nvme2n1p3_write_errors":           0,
+				"vdev_rpool/mirror-0_checksum_errors":                  0,
 				"vdev_rpool/mirror-0_health_state_degraded":            0,
 				"vdev_rpool/mirror-0_health_state_faulted":             0,
 				"vdev_rpool/mirror-0_health_state_offline":             0,
@@ -185,6 +199,9 @@ func TestCollector_Collect(t *testing.T) {
 				"vdev_rpool/mirror-0_health_state_removed":             0,
 				"vdev_rpool/mirror-0_health_state_suspended":           0,
 				"vdev_rpool/mirror-0_health_state_unavail":             0,
+				"vdev_rpool/mirror-0_read_errors":                      0,
+				"vdev_rpool/mirror-0_write_errors":                     0,
+				"vdev_zion/mirror-0/nvme0n1p3_checksum_errors":         0,
 				"vdev_zion/mirror-0/nvme0n1p3_health_state_degraded":   0,
 				"vdev_zion/mirror-0/nvme0n1p3_health_state_faulted":    0,
 				"vdev_zion/mirror-0/nvme0n1p3_health_state_offline":    0,
@@ -192,6 +209,9 @@ func TestCollector_Collect(t *testing.T) {
 				"vdev_zion/mirror-0/nvme0n1p3_health_state_removed":    0,
 				"vdev_zion/mirror-0/nvme0n1p3_health_state_suspended":  0,
 				"vdev_zion/mirror-0/nvme0n1p3_health_state_unavail":    0,
+				"vdev_zion/mirror-0/nvme0n1p3_read_errors":             3,
+				"vdev_zion/mirror-0/nvme0n1p3_write_errors":            1,
+				"vdev_zion/mirror-0/nvme2n1p3_checksum_errors":         0,
 				"vdev_zion/mirror-0/nvme2n1p3_health_state_degraded":   0,
 				"vdev_zion/mirror-0/nvme2n1p3_health_state_faulted":    0,
 				"vdev_zion/mirror-0/nvme2n1p3_health_state_offline":    0,
@@ -199,6 +219,9 @@ func TestCollector_Collect(t *testing.T) {
 				"vdev_zion/mirror-0/nvme2n1p3_health_state_removed":    0,
 				"vdev_zion/mirror-0/nvme2n1p3_health_state_suspended":  0,
 				"vdev_zion/mirror-0/nvme2n1p3_health_state_unavail":    0,
+				"vdev_zion/mirror-0/nvme2n1p3_read_errors":             0,
+				"vdev_zion/mirror-0/nvme2n1p3_write_errors":            0,
+				"vdev_zion/mirror-0_checksum_errors":                   0,
 				"vdev_zion/mirror-0_health_state_degraded":             0,
 				"vdev_zion/mirror-0_health_state_faulted":              0,
 				"vdev_zion/mirror-0_health_state_offline":              0,
@@ -206,30 +229,47 @@ func TestCollector_Collect(t *testing.T) {
 				"vdev_zion/mirror-0_health_state_removed":              0,
 				"vdev_zion/mirror-0_health_state_suspended":            0,
 				"vdev_zion/mirror-0_health_state_unavail":              0,
-				"zpool_rpool_alloc":                  9051643576,
-				"zpool_rpool_cap":                    42,
-				"zpool_rpool_frag":                   33,
-				"zpool_rpool_free":                   12240656794,
-				"zpool_rpool_health_state_degraded":  0,
-				"zpool_rpool_health_state_faulted":   0,
-				"zpool_rpool_health_state_offline":   0,
-				"zpool_rpool_health_state_online":    1,
-				"zpool_rpool_health_state_removed":   0,
-				"zpool_rpool_health_state_suspended": 0,
-				"zpool_rpool_health_state_unavail":   0,
-				"zpool_rpool_size":                   21367462298,
-				"zpool_zion_health_state_degraded":   0,
-				"zpool_zion_health_state_faulted":    1,
-				"zpool_zion_health_state_offline":    0,
-				"zpool_zion_health_state_online":     0,
-				"zpool_zion_health_state_removed":    0,
-				"zpool_zion_health_state_suspended":  0,
-				"zpool_zion_health_state_unavail":    0,
+				"vdev_zion/mirror-0_read_errors":                       0,
+				"vdev_zion/mirror-0_write_errors":                      0,
+				"zpool_rpool_alloc":                                    9051643576,
+				"zpool_rpool_cap":                                      42,
+				"zpool_rpool_frag":                                     33,
+				"zpool_rpool_free":                                     12240656794,
+				"zpool_rpool_health_state_degraded":                    0,
+				"zpool_rpool_health_state_faulted":                     0,
+				"zpool_rpool_health_state_offline":                     0,
+				"zpool_rpool_health_state_online":                      1,
+				"zpool_rpool_health_state_removed":                     0,
+				"zpool_rpool_health_state_suspended":                   0,
+				"zpool_rpool_health_state_unavail":                     0,
+				"zpool_rpool_scan_errors":                              0,
+				"zpool_rpool_scan_progress":                            5340,
+				"zpool_rpool_scan_state_idle":                          0,
+				"zpool_rpool_scan_state_resilvering":                   0,
+				"zpool_rpool_scan_state_scrub_paused":                  0,
+				"zpool_rpool_scan_state_scrubbing":                     1,
+				"zpool_rpool_scan_time_remaining":                      1829,
+				"zpool_rpool_size":                                     21367462298,
+				"zpool_zion_health_state_degraded":                     0,
+				"zpool_zion_health_state_faulted":                      1,
+				"zpool_zion_health_state_offline":                      0,
+				"zpool_zion_health_state_online":                       0,
+				"zpool_zion_health_state_removed":                      0,
+				"zpool_zion_health_state_suspended":                    0,
+				"zpool_zion_health_state_unavail":                      0,
+				"zpool_zion_scan_errors":                               0,
+				"zpool_zion_scan_progress":                             0,
+				"zpool_zion_scan_state_idle":                           1,
+				"zpool_zion_scan_state_resilvering":                    0,
+				"zpool_zion_scan_state_scrub_paused":                   0,
+				"zpool_zion_scan_state_scrubbing":                      0,
+				"zpool_zion_scan_time_remaining":                       0,
 			},
 		},
 		"success case vdev logs and cache": {
 			prepareMock: prepareMockOkVdevLogsCache,
 			wantMetrics: map[string]int64{
+				"vdev_rpool/cache/sdb2_checksum_errors":                                0,
 				"vdev_rpool/cache/sdb2_health_state_degraded":                          0,
 				"vdev_rpool/cache/sdb2_health_state_faulted":                           0,
 				"vdev_rpool/cache/sdb2_health_state_offline":                           0,
@@ -237,6 +277,9 @@ func TestCollector_Collect(t *testing.T) {
 				"vdev_rpool/cache/sdb2_health_state_removed":                           0,
 				"vdev_rpool/cache/sdb2_health_state_suspended":                         0,
 				"vdev_rpool/cache/sdb2_health_state_unavail":                           0,
+				"vdev_rpool/cache/sdb2_read_errors":                                    0,
+				"vdev_rpool/cache/sdb2_write_errors":                                   0,
+				"vdev_rpool/cache/wwn-0x500151795954c095-part2_checksum_errors":        0,
 				"vdev_rpool/cache/wwn-0x500151795954c095-part2_health_state_degraded":  0,
 				"vdev_rpool/cache/wwn-0x500151795954c095-part2_health_state_faulted":   0,
 				"vdev_rpool/cache/wwn-0x500151795954c095-part2_health_state_offline":   0,
@@ -244,6 +287,9 @@ func TestCollector_Collect(t *testing.T) {
 				"vdev_rpool/cache/wwn-0x500151795954c095-part2_health_state_removed":   0,
 				"vdev_rpool/cache/wwn-0x500151795954c095-part2_health_state_suspended": 0,
 				"vdev_rpool/cache/wwn-0x500151795954c095-part2_health_state_unavail":   1,
+				"vdev_rpool/cache/wwn-0x500151795954c095-part2_read_errors":            0,
+				"vdev_rpool/cache/wwn-0x500151795954c095-part2_write_errors":           0,
+				"vdev_rpool/logs/mirror-1/14807975228228307538_checksum_errors":        0,
 				"vdev_rpool/logs/mirror-1/14807975228228307538_health_state_degraded":  0,
 				"vdev_rpool/logs/mirror-1/14807975228228307538_health_state_faulted":   0,
 				"vdev_rpool/logs/mirror-1/14807975228228307538_health_state_offline":   0,
@@ -251,6 +297,9 @@ func TestCollector_Collect(t *testing.T) {
 				"vdev_rpool/logs/mirror-1/14807975228228307538_health_state_removed":   0,
 				"vdev_rpool/logs/mirror-1/14807975228228307538_health_state_suspended": 0,
 				"vdev_rpool/logs/mirror-1/14807975228228307538_health_state_unavail":   1,
+				"vdev_rpool/logs/mirror-1/14807975228228307538_read_errors":            0,
+				"vdev_rpool/logs/mirror-1/14807975228228307538_write_errors":           0,
+				"vdev_rpool/logs/mirror-1/sdb1_checksum_errors":                        0,
 				"vdev_rpool/logs/mirror-1/sdb1_health_state_degraded":                  0,
 				"vdev_rpool/logs/mirror-1/sdb1_health_state_faulted":                   0,
 				"vdev_rpool/logs/mirror-1/sdb1_health_state_offline":                   0,
@@ -258,6 +307,9 @@ func TestCollector_Collect(t *testing.T) {
 				"vdev_rpool/logs/mirror-1/sdb1_health_state_removed":                   0,
 				"vdev_rpool/logs/mirror-1/sdb1_health_state_suspended":                 0,
 				"vdev_rpool/logs/mirror-1/sdb1_health_state_unavail":                   0,
+				"vdev_rpool/logs/mirror-1/sdb1_read_errors":                            0,
+				"vdev_rpool/logs/mirror-1/sdb1_write_errors":                           0,
+				"vdev_rpool/logs/mirror-1_checksum_errors":                             0,
 				"vdev_rpool/logs/mirror-1_health_state_degraded":                       1,
 				"vdev_rpool/logs/mirror-1_health_state_faulted":                        0,
 				"vdev_rpool/logs/mirror-1_health_state_offline":                        0,
@@ -265,6 +317,9 @@ func TestCollector_Collect(t *testing.T) {
 				"vdev_rpool/logs/mirror-1_health_state_removed":                        0,
 				"vdev_rpool/logs/mirror-1_health_state_suspended":                      0,
 				"vdev_rpool/logs/mirror-1_health_state_unavail":                        0,
+				"vdev_rpool/logs/mirror-1_read_errors":                                 0,
+				"vdev_rpool/logs/mirror-1_write_errors":                                0,
+				"vdev_rpool/mirror-0/sdc2_checksum_errors":                             0,
 				"vdev_rpool/mirror-0/sdc2_health_state_degraded":                       0,
 				"vdev_rpool/mirror-0/sdc2_health_state_faulted":                        0,
 				"vdev_rpool/mirror-0/sdc2_health_state_offline":                        0,
@@ -272,6 +327,9 @@ func TestCollector_Collect(t *testing.T) {
 				"vdev_rpool/mirror-0/sdc2_health_state_removed":                        0,
 				"vdev_rpool/mirror-0/sdc2_health_state_suspended":                      0,
 				"vdev_rpool/mirror-0/sdc2_health_state_unavail":                        0,
+				"vdev_rpool/mirror-0/sdc2_read_errors":                                 0,
+				"vdev_rpool/mirror-0/sdc2_write_errors":                                0,
+				"vdev_rpool/mirror-0/sdd2_checksum_errors":                             0,
 				"vdev_rpool/mirror-0/sdd2_health_state_degraded":                       0,
 				"vdev_rpool/mirror-0/sdd2_health_state_faulted":                        0,
 				"vdev_rpool/mirror-0/sdd2_health_state_offline":                        0,
@@ -279,6 +337,9 @@ func TestCollector_Collect(t *testing.T) {
 				"vdev_rpool/mirror-0/sdd2_health_state_removed":                        0,
 				"vdev_rpool/mirror-0/sdd2_health_state_suspended":                      0,
 				"vdev_rpool/mirror-0/sdd2_health_state_unavail":                        0,
+				"vdev_rpool/mirror-0/sdd2_read_errors":                                 0,
+				"vdev_rpool/mirror-0/sdd2_write_errors":                                0,
+				"vdev_rpool/mirror-0_checksum_errors":                                  0,
 				"vdev_rpool/mirror-0_health_state_degraded":                            0,
 				"vdev_rpool/mirror-0_health_state_faulted":                             0,
 				"vdev_rpool/mirror-0_health_state_offline":                             0,
@@ -286,6 +347,9 @@ func TestCollector_Collect(t *testing.T) {
 				"vdev_rpool/mirror-0_health_state_removed":                             0,
 				"vdev_rpool/mirror-0_health_state_suspended":                           0,
 				"vdev_rpool/mirror-0_health_state_unavail":                             0,
+				"vdev_rpool/mirror-0_read_errors":                                      0,
+				"vdev_rpool/mirror-0_write_errors":                                     0,
+				"vdev_zion/cache/sdb2_checksum_errors":                                 0,
 				"vdev_zion/cache/sdb2_health_state_degraded":                           0,
 				"vdev_zion/cache/sdb2_health_state_faulted":                            0,
 				"vdev_zion/cache/sdb2_health_state_offline":                            0,
@@ -293,6 +357,9 @@ func TestCollector_Collect(t *testing.T) {
 				"vdev_zion/cache/sdb2_health_state_removed":                            0,
 				"vdev_zion/cache/sdb2_health_state_suspended":                          0,
 				"vdev_zion/cache/sdb2_health_state_unavail":                            0,
+				"vdev_zion/cache/sdb2_read_errors":                                     0,
+				"vdev_zion/cache/sdb2_write_errors":                                    0,
+				"vdev_zion/cache/wwn-0x500151795954c095-part2_checksum_errors":         0,
 				"vdev_zion/cache/wwn-0x500151795954c095-part2_health_state_degraded":   0,
 				"vdev_zion/cache/wwn-0x500151795954c095-part2_health_state_faulted":    0,
 				"vdev_zion/cache/wwn-0x500151795954c095-part2_health_state_offline":    0,
@@ -300,6 +367,9 @@ func TestCollector_Collect(t *testing.T) {
 				"vdev_zion/cache/wwn-0x500151795954c095-part2_health_state_removed":    0,
 				"vdev_zion/cache/wwn-0x500151795954c095-part2_health_state_suspended":  0,
 				"vdev_zion/cache/wwn-0x500151795954c095-part2_health_state_unavail":    1,
+				"vdev_zion/cache/wwn-0x500151795954c095-part2_read_errors":             0,
+				"vdev_zion/cache/wwn-0x500151795954c095-part2_write_errors":            0,
+				"vdev_zion/logs/mirror-1/14807975228228307538_checksum_errors":         0,
 				"vdev_zion/logs/mirror-1/14807975228228307538_health_state_degraded":   0,
 				"vdev_zion/logs/mirror-1/14807975228228307538_health_state_faulted":    0,
 				"vdev_zion/logs/mirror-1/14807975228228307538_health_state_offline":    0,
@@ -307,6 +377,9 @@ func TestCollector_Collect(t *testing.T) {
 				"vdev_zion/logs/mirror-1/14807975228228307538_health_state_removed":    0,
 				"vdev_zion/logs/mirror-1/14807975228228307538_health_state_suspended":  0,
 				"vdev_zion/logs/mirror-1/14807975228228307538_health_state_unavail":    1,
+				"vdev_zion/logs/mirror-1/14807975228228307538_read_errors":             0,
+				"vdev_zion/logs/mirror-1/14807975228228307538_write_errors":            0,
+				"vdev_zion/logs/mirror-1/sdb1_checksum_errors":                         0,
 				"vdev_zion/logs/mirror-1/sdb1_health_state_degraded":                   0,
 				"vdev_zion/logs/mirror-1/sdb1_health_state_faulted":                    0,
 				"vdev_zion/logs/mirror-1/sdb1_health_state_offline":                    0,
@@ -314,6 +387,9 @@ func TestCollector_Collect(t *testing.T) {
 				"vdev_zion/logs/mirror-1/sdb1_health_state_removed":                    0,
 				"vdev_zion/logs/mirror-1/sdb1_health_state_suspended":                  0,
 				"vdev_zion/logs/mirror-1/sdb1_health_state_unavail":                    0,
+				"vdev_zion/logs/mirror-1/sdb1_read_errors":                             0,
+				"vdev_zion/logs/mirror-1/sdb1_write_errors":                            0,
+				"vdev_zion/logs/mirror-1_checksum_errors":                              0,
 				"vdev_zion/logs/mirror-1_health_state_degraded":                        1,
 				"vdev_zion/logs/mirror-1_health_state_faulted":                         0,
 				"vdev_zion/logs/mirror-1_health_state_offline":                         0,
@@ -321,6 +397,9 @@ func TestCollector_Collect(t *testing.T) {
 				"vdev_zion/logs/mirror-1_health_state_removed":                         0,
 				"vdev_zion/logs/mirror-1_health_state_suspended":                       0,
 				"vdev_zion/logs/mirror-1_health_state_unavail":                         0,
+				"vdev_zion/logs/mirror-1_read_errors":                                  0,
+				"vdev_zion/logs/mirror-1_write_errors":                                 0,
+				"vdev_zion/mirror-0/sdc2_checksum_errors":                              0,
 				"vdev_zion/mirror-0/sdc2_health_state_degraded":                        0,
 				"vdev_zion/mirror-0/sdc2_health_state_faulted":                         0,
 				"vdev_zion/mirror-0/sdc2_health_state_offline":                         0,
@@ -328,6 +407,9 @@ func TestCollector_Collect(t *testing.T) {
 				"vdev_zion/mirror-0/sdc2_health_state_removed":                         0,
 				"vdev_zion/mirror-0/sdc2_health_state_suspended":                       0,
 				"vdev_zion/mirror-0/sdc2_health_state_unavail":                         0,
+				"vdev_zion/mirror-0/sdc2_read_errors":                                  0,
+				"vdev_zion/mirror-0/sdc2_write_errors":                                 0,
+				"vdev_zion/mirror-0/sdd2_checksum_errors":                              0,
 				"vdev_zion/mirror-0/sdd2_health_state_degraded":                        0,
 				"vdev_zion/mirror-0/sdd2_health_state_faulted":                         0,
 				"vdev_zion/mirror-0/sdd2_health_state_offline":                         0,
@@ -335,6 +417,9 @@ func TestCollector_Collect(t *testing.T) {
 				"vdev_zion/mirror-0/sdd2_health_state_removed":                         0,
 				"vdev_zion/mirror-0/sdd2_health_state_suspended":                       0,
 				"vdev_zion/mirror-0/sdd2_health_state_unavail":                         0,
+				"vdev_zion/mirror-0/sdd2_read_errors":                                  0,
+				"vdev_zion/mirror-0/sdd2_write_errors":                                 0,
+				"vdev_zion/mirror-0_checksum_errors":                                   0,
 				"vdev_zion/mirror-0_health_state_degraded":                             0,
 				"vdev_zion/mirror-0_health_state_faulted":                              0,
 				"vdev_zion/mirror-0_health_state_offline":                              0,
@@ -342,25 +427,41 @@ func TestCollector_Collect(t *testing.T) {
 				"vdev_zion/mirror-0_health_state_removed":                              0,
 				"vdev_zion/mirror-0_health_state_suspended":                            0,
 				"vdev_zion/mirror-0_health_state_unavail":                              0,
-				"zpool_rpool_alloc":                  9051643576,
-				"zpool_rpool_cap":                    42,
-				"zpool_rpool_frag":                   33,
-				"zpool_rpool_free":                   12240656794,
-				"zpool_rpool_health_state_degraded":  0,
-				"zpool_rpool_health_state_faulted":   0,
-				"zpool_rpool_health_state_offline":   0,
-				"zpool_rpool_health_state_online":    1,
-				"zpool_rpool_health_state_removed":   0,
-				"zpool_rpool_health_state_suspended": 0,
-				"zpool_rpool_health_state_unavail":   0,
-				"zpool_rpool_size":                   21367462298,
-				"zpool_zion_health_state_degraded":   0,
-				"zpool_zion_health_state_faulted":    1,
-				"zpool_zion_health_state_offline":    0,
-				"zpool_zion_health_state_online":     0,
-				"zpool_zion_health_state_removed":    0,
-				"zpool_zion_health_state_suspended":  0,
-				"zpool_zion_health_state_unavail":    0,
+				"vdev_zion/mirror-0_read_errors":                                       0,
+				"vdev_zion/mirror-0_write_errors":                                      0,
+				"zpool_rpool_alloc":                                                    9051643576,
+				"zpool_rpool_cap":                                                      42,
+				"zpool_rpool_frag":                                                     33,
+				"zpool_rpool_free":                                                     12240656794,
+				"zpool_rpool_health_state_degraded":                                    0,
+				"zpool_rpool_health_state_faulted":                                     0,
+				"zpool_rpool_health_state_offline":                                     0,
+				"zpool_rpool_health_state_online":                                      1,
+				"zpool_rpool_health_state_removed":                                     0,
+				"zpool_rpool_health_state_suspended":                                   0,
+				"zpool_rpool_health_state_unavail":                                     0,
+				"zpool_rpool_scan_errors":                                              2,
+				"zpool_rpool_scan_progress":                                            0,
+				"zpool_rpool_scan_state_idle":                                          1,
+				"zpool_rpool_scan_state_resilvering":                                   0,
+				"zpool_rpool_scan_state_scrub_paused":                                  0,
+				"zpool_rpool_scan_state_scrubbing":                                     0,
+				"zpool_rpool_scan_time_remaining":                                      0,
+				"zpool_rpool_size":                                                     21367462298,
+				"zpool_zion_health_state_degraded":                                     0,
+				"zpool_zion_health_state_faulted":                                      1,
+				"zpool_zion_health_state_offline":                                      0,
+				"zpool_zion_health_state_online":                                       0,
+				"zpool_zion_health_state_removed":                                      0,
+				"zpool_zion_health_state_suspended":                                    0,
+				"zpool_zion_health_state_unavail":                                      0,
+				"zpool_zion_scan_errors":                                               0,
+				"zpool_zion_scan_progress":                                             1500,
+				"zpool_zion_scan_state_idle":                                           0,
+				"zpool_zion_scan_state_resilvering":                                    1,
+				"zpool_zion_scan_state_scrub_paused":                                   0,
+				"zpool_zion_scan_state_scrubbing":                                      0,
+				"zpool_zion_scan_time_remaining":                                       93784,
 			},
 		},
 		"error on list call": {
@@ -494,10 +595,234 @@ cache                               -      -      -        -         -      -
 	}
 }
 
+func TestCollector_CollectDatasets(t *testing.T) {
+	collr := New()
+	collr.DatasetSelector = "*"
+	sr, err := collr.initDatasetSelector()
+	require.NoError(t, err)
+	collr.datasetSr = sr
+	collr.exec = prepareMockOk()
+	collr.zfsExec = &mockZfsCLIExec{listData: dataZfsList}
+
+	mx := collr.Collect(context.Background())
+
+	want := map[string]int64{
+		"dataset_rpool/ROOT/pve-1_available":             53687091200,
+		"dataset_rpool/ROOT/pve-1_compressratio":         162,
+		"dataset_rpool/ROOT/pve-1_quota_utilization":     5000,
+		"dataset_rpool/ROOT/pve-1_referenced":            53687091200,
+		"dataset_rpool/ROOT/pve-1_used":                  53687091200,
+		"dataset_rpool/ROOT_available":                   2338599194624,
+		"dataset_rpool/ROOT_compressratio":               162,
+		"dataset_rpool/ROOT_referenced":                  98304,
+		"dataset_rpool/ROOT_used":                        53687091200,
+		"dataset_rpool/data/vm-100-disk-0_available":     2338599194624,
+		"dataset_rpool/data/vm-100-disk-0_compressratio": 121,
+		"dataset_rpool/data/vm-100-disk-0_referenced":    21474836480,
+		"dataset_rpool/data/vm-100-disk-0_used":          34359738368,
+		"dataset_rpool/data_available":                   2338599194624,
+		"dataset_rpool/data_compressratio":               145,
+		"dataset_rpool/data_referenced":                  98304,
+		"dataset_rpool/data_used":                        1593443364864,
+		"dataset_rpool_available":                        2338599194624,
+		"dataset_rpool_compressratio":                    148,
+		"dataset_rpool_referenced":                       98304,
+		"dataset_rpool_used":                             1647130456064,
+	}
+	for k, v := range want {
+		assert.Equalf(t, v, mx[k], "metric '%s'", k)
+	}
+	assert.Len(t, collr.seenDatasets, 5)
+	assert.True(t, collr.Charts().Has("dataset_rpool/ROOT/pve-1_quota_utilization"))
+	assert.False(t, collr.Charts().Has("dataset_rpool/data_quota_utilization"))
+
+	collecttest.TestMetricsHasAllChartsDimsSkip(t, collr.Charts(), mx, func(chart *collectorapi.Chart, _ *collectorapi.Dim) bool {
+		return strings.HasPrefix(chart.ID, "zfspool_zion") && !strings.HasSuffix(chart.ID, "health_state")
+	})
+
+	// "rpool/data" is destroyed and the "rpool/ROOT/pve-1" quota is removed
+	var lines []string
+	for _, line := range strings.Split(string(dataZfsList), "\n") {
+		if strings.HasPrefix(line, "rpool/data\t") {
+			continue
+		}
+		if strings.HasPrefix(line, "rpool/ROOT/pve-1\t") {
+			line = strings.TrimSuffix(line, "107374182400") + "0"
+		}
+		lines = append(lines, line)
+	}
+	collr.zfsExec = &mockZfsCLIExec{listData: []byte(strings.Join(lines, "\n"))}
+
+	mx = collr.Collect(context.Background())
+
+	assert.NotContains(t, mx, "dataset_rpool/ROOT/pve-1_quota_utilization")
+	assert.NotContains(t, mx, "dataset_rpool/data_used")
+	assert.Contains(t, mx, "dataset_rpool/data/vm-100-disk-0_used")
+
+	for _, chart := range *collr.Charts() {
+		switch {
+		case chart.ID == "dataset_rpool/ROOT/pve-1_quota_utilization",
+			strings.HasPrefix(chart.ID, "dataset_rpool/data_"):
+			assert.Truef(t, chart.Obsolete, "chart '%s' is not removed", chart.ID)
+		case strings.HasPrefix(chart.ID, "dataset_"):
+			assert.Falsef(t, chart.Obsolete, "chart '%s' is removed", chart.ID)
+		}
+	}
+}
+
+func TestCollector_CollectDatasets_Selector(t *testing.T) {
+	collr := New()
+	collr.DatasetSelector = "rpool/ROOT*"
+	sr, err := collr.initDatasetSelector()
+	require.NoError(t, err)
+	collr.datasetSr = sr
+	collr.exec = prepareMockOk()
+	collr.zfsExec = &mockZfsCLIExec{listData: dataZfsList}
+
+	mx := collr.Collect(context.Background())
+
+	assert.Len(t, collr.seenDatasets, 2)
+	assert.Contains(t, mx, "dataset_rpool/ROOT/pve-1_used")
+	assert.NotContains(t, mx, "dataset_rpool_used")
+}
+
+func TestCollector_CollectDatasets_DefaultSelector(t *testing.T) {
+	collr := New()
+	sr, err := collr.initDatasetSelector()
+	require.NoError(t, err)
+	collr.datasetSr = sr
+	collr.exec = prepareMockOk()
+	collr.zfsExec = &mockZfsCLIExec{listData: dataZfsList}
+
+	mx := collr.Collect(context.Background())
+
+	assert.Len(t, collr.seenDatasets, 1, "only pool root datasets are collected by default")
+	assert.Contains(t, mx, "dataset_rpool_used")
+	assert.NotContains(t, mx, "dataset_rpool/ROOT_used")
+}
+
+func TestCollector_CollectDatasets_ErrOnList(t *testing.T) {
+	collr := New()
+	collr.exec = prepareMockOk()
+	collr.zfsExec = &mockZfsCLIExec{errOnList: true}
+
+	mx := collr.Collect(context.Background())
+
+	assert.Contains(t, mx, "zpool_rpool_size")
+	assert.Empty(t, collr.seenDatasets)
+}
+
+func TestCollector_VdevTreeFunction(t *testing.T) {
+	collr := New()
+	collr.funcRouter = newFuncRouter(collr)
+	collr.exec = prepareMockOkVdevLogsCache()
+
+	resp := collr.funcRouter.Handle(context.Background(), vdevTreeMethodID, nil)
+	assert.Equal(t, 503, resp.Status)
+
+	require.NotNil(t, collr.Collect(context.Background()))
+
+	resp = collr.funcRouter.Handle(context.Background(), vdevTreeMethodID, nil)
+	require.Equal(t, 200, resp.Status)
+	data, ok := resp.Data.([][]any)
+	require.True(t, ok)
+	require.Len(t, data, 22)
+
+	rows := make(map[any]map[string]any)
+	for _, values := range data {
+		row := make(map[string]any)
+		for i, col := range vdevTreeColumns {
+			row[col.Name] = values[i]
+		}
+		rows[row["Path"]] = row
+	}
+
+	pool := rows["rpool"]
+	require.NotNil(t, pool)
+	assert.Equal(t, "pool", pool["Type"])
+	assert.Equal(t, "degraded", pool["State"])
+	assert.Equal(t, "scrub repaired 0B in 01:10:23 with 2 errors on Sun Oct 11 01:34:24 2026", pool["Scan"])
+
+	logs := rows["rpool/logs"]
+	require.NotNil(t, logs)
+	assert.Equal(t, "class", logs["Type"])
+	assert.Nil(t, logs["State"])
+	assert.Nil(t, logs["Read Errors"])
+
+	mirror := rows["rpool/logs/mirror-1"]
+	require.NotNil(t, mirror)
+	assert.Equal(t, "mirror", mirror["Type"])
+	assert.Equal(t, "degraded", mirror["State"])
+
+	leg := rows["rpool/logs/mirror-1/14807975228228307538"]
+	require.NotNil(t, leg)
+	assert.Equal(t, "disk", leg["Type"])
+	assert.Equal(t, "unavail", leg["State"])
+	assert.Equal(t, "was /dev/sda1", leg["Note"])
+	assert.Equal(t, int64(0), leg["Checksum Errors"])
+	assert.Equal(t, strings.Repeat(vdevTreeIndent, 3)+"14807975228228307538", leg["Vdev"])
+
+	resilvering := rows["zion/mirror-0/sdd2"]
+	require.NotNil(t, resilvering)
+	assert.Equal(t, "(resilvering)", resilvering["Note"])
+}
+
+func Test_parseScanStatus(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  scanStatus
+	}{
+		"none requested": {
+			input: "none requested",
+			want:  scanStatus{state: scanStateIdle},
+		},
+		"scrub completed": {
+			input: "scrub repaired 0B in 0 days 01:10:23 with 3 errors on Sun Oct 11 01:34:24 2026",
+			want:  scanStatus{state: scanStateIdle, errors: 3, hasErrors: true},
+		},
+		"resilver completed": {
+			input: "resilvered 1.20G in 00:05:12 with 0 errors on Sun Oct 11 01:34:24 2026",
+			want:  scanStatus{state: scanStateIdle, hasErrors: true},
+		},
+		"scrub canceled": {
+			input: "scrub canceled on Sun Oct 11 01:34:24 2026",
+			want:  scanStatus{state: scanStateIdle},
+		},
+		"scrub in progress": {
+			input: "scrub in progress since Sun Oct 18 23:24:01 2026 10.2T / 20.4T scanned at 1.2G/s, 9.1T / 20.4T issued at 1.1G/s 0B repaired, 44.61% done, 02:58:10 to go",
+			want:  scanStatus{state: scanStateScrubbing, progress: 44.61, timeRemaining: 2*time.Hour + 58*time.Minute + 10*time.Second},
+		},
+		"scrub in progress without estimate": {
+			input: "scrub in progress since Sun Oct 18 23:24:01 2026 10.2T / 20.4T scanned, 0B / 20.4T issued 0B repaired, 0.00% done, no estimated completion time",
+			want:  scanStatus{state: scanStateScrubbing},
+		},
+		"scrub paused": {
+			input: "scrub paused since Sun Oct 18 23:24:01 2026 scrub started on Sun Oct 18 20:00:00 2026 9.1T / 20.4T issued, 0B repaired, 44.61% done",
+			want:  scanStatus{state: scanStateScrubPaused, progress: 44.61},
+		},
+		"resilver in progress (legacy)": {
+			input: "resilver in progress since Sun Oct 18 23:24:01 2026 18.0G scanned out of 100G at 100M/s, 0h14m to go 18.0G resilvered, 18.00% done",
+			want:  scanStatus{state: scanStateResilvering, progress: 18, timeRemaining: 14 * time.Minute},
+		},
+		"draid resilver in progress": {
+			input: "resilver (draid2:4d:6c:1s-0) in progress since Sun Oct 18 23:24:01 2026 4.50G resilvered, 15.00% done, 1 days 02:03:04 to go",
+			want:  scanStatus{state: scanStateResilvering, progress: 15, timeRemaining: 26*time.Hour + 3*time.Minute + 4*time.Second},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.want.description = test.input
+			assert.Equal(t, test.want, parseScanStatus(test.input))
+		})
+	}
+}
+
 func prepareMockOk() *mockZpoolCLIExec {
 	return &mockZpoolCLIExec{
 		listData:         dataZpoolList,
 		listWithVdevData: dataZpoolListWithVdev,
+		statusData:       dataZpoolStatus,
 	}
 }
 
@@ -505,6 +830,7 @@ func prepareMockOkVdevLogsCache() *mockZpoolCLIExec {
 	return &mockZpoolCLIExec{
 		listData:         dataZpoolList,
 		listWithVdevData: dataZpoolListWithVdevLogsCache,
+		statusData:       dataZpoolStatusLogsCache,
 	}
 }
 
@@ -532,6 +858,7 @@ type mockZpoolCLIExec struct {
 	errOnList        bool
 	listData         []byte
 	listWithVdevData []byte
+	statusData       []byte
 }
 
 func (m *mockZpoolCLIExec) list() ([]byte, error) {
@@ -548,3 +875,20 @@ func (m *mockZpoolCLIExec) listWithVdev(pool string) ([]byte, error) {
 
 	return []byte(s), nil
 }
+
+func (m *mockZpoolCLIExec) status() ([]byte, error) {
+	return m.statusData, nil
+}
+
+type mockZfsCLIExec struct {
+	errOnList bool
+	listData  []byte
+}
+
+func (m *mockZfsCLIExec) listDatasets() ([]byte, error) {
+	if m.errOnList {
+		return nil, errors.New("mock.listDatasets() error")
+	}
+
+	return m.listData, nil
+}
@@ -14,6 +14,11 @@ import (
 type zpoolCli interface {
 	list() ([]byte, error)
 	listWithVdev(pool string) ([]byte, error)
+	status() ([]byte, error)
+}
+
+type zfsCli interface {
+	listDatasets() ([]byte, error)
 }
 
 func newZpoolCLIExec(binPath string, timeout time.Duration) *zpoolCLIExec {
@@ -37,3 +42,26 @@ func (e *zpoolCLIExec) list() ([]byte, error) {
 func (e *zpoolCLIExec) listWithVdev(pool string) ([]byte, error) {
 	return ndexec.RunUnprivileged(e.Logger, e.timeout, e.binPath, "list", "-p", "-v", "-L", pool)
 }
+
+func (e *zpoolCLIExec) status() ([]byte, error) {
+	return ndexec.RunUnprivileged(e.Logger, e.timeout, e.binPath, "status", "-p", "-L")
+}
+
+func newZfsCLIExec(binPath string, timeout time.Duration) *zfsCLIExec {
+	return &zfsCLIExec{
+		binPath: binPath,
+		timeout: timeout,
+	}
+}
+
+type zfsCLIExec struct {
+	*logger.Logger
+
+	binPath string
+	timeout time.Duration
+}
+
+func (e *zfsCLIExec) listDatasets() ([]byte, error) {
+	return ndexec.RunUnprivileged(e.Logger, e.timeout, e.binPath,
+		"list", "-p", "-H", "-t", "filesystem,volume", "-o", "name,type,used,avail,refer,compressratio,quota")
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build linux || freebsd || openbsd || netbsd || dragonfly
+
+package zfspool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/pkg/funcapi"
+)
+
+const vdevTreeMethodID = "vdev-tree"
+
+// vdevTreeIndent is made of non-breaking spaces, regular ones are collapsed by the UI.
+const vdevTreeIndent = "\u00a0\u00a0"
+
+const vdevTreeHelp = "The vdev tree of every ZFS pool with device state and read, write and checksum error counters, " +
+	"as reported by 'zpool status'. Error counters are cumulative until 'zpool clear'."
+
+func vdevTreeFunctionConfig() funcapi.FunctionConfig {
+	return funcapi.FunctionConfig{
+		ID:          vdevTreeMethodID,
+		Name:        "ZFS Vdev Tree",
+		UpdateEvery: 10,
+		Help:        vdevTreeHelp,
+	}
+}
+
+// Compile-time interface check.
+var _ funcapi.MethodHandler = (*funcVdevTree)(nil)
+
+// funcVdevTree handles the "vdev-tree" function.
+type funcVdevTree struct {
+	router *funcRouter
+}
+
+func newFuncVdevTree(r *funcRouter) *funcVdevTree {
+	return &funcVdevTree{router: r}
+}
+
+// MethodParams implements funcapi.MethodHandler.
+func (f *funcVdevTree) MethodParams(_ context.Context, method string) ([]funcapi.ParamConfig, error) {
+	if method != vdevTreeMethodID {
+		return nil, fmt.Errorf("unknown method: %s", method)
+	}
+	return nil, nil
+}
+
+// Handle implements funcapi.MethodHandler.
+func (f *funcVdevTree) Handle(_ context.Context, method string, _ funcapi.ResolvedParams) *funcapi.FunctionResponse {
+	if method != vdevTreeMethodID {
+		return funcapi.NotFoundResponse(method)
+	}
+
+	c := f.router.collector
+
+	c.mu.RLock()
+	pools := c.poolsTree
+	c.mu.RUnlock()
+
+	if len(pools) == 0 {
+		return funcapi.UnavailableResponse("no pool status has been collected yet, please retry later")
+	}
+
+	cs := vdevTreeColumnSet(vdevTreeColumns)
+	var data [][]any
+	for _, pool := range pools {
+		for _, v := range pool.vdevs {
+			node := vdevTreeNode{pool: pool, vdev: v, order: len(data)}
+			row := make([]any, len(vdevTreeColumns))
+			for j, col := range vdevTreeColumns {
+				row[j] = col.Value(node)
+			}
+			data = append(data, row)
+		}
+	}
+
+	return &funcapi.FunctionResponse{
+		Status:            200,
+		Help:              vdevTreeHelp,
+		Columns:           cs.BuildColumns(),
+		Data:              data,
+		DefaultSortColumn: "Order",
+	}
+}
+
+// Cleanup implements funcapi.MethodHandler.
+func (f *funcVdevTree) Cleanup(context.Context) {}
+
+type vdevTreeNode struct {
+	pool  *poolStatus
+	vdev  statusVdev
+	order int
+}
+
+type vdevTreeColumn struct {
+	funcapi.ColumnMeta
+	Value func(vdevTreeNode) any
+}
+
+func vdevTreeColumnSet(cols []vdevTreeColumn) funcapi.ColumnSet[vdevTreeColumn] {
+	return funcapi.Columns(cols, func(c vdevTreeColumn) funcapi.ColumnMeta { return c.ColumnMeta })
+}
+
+var vdevTreeColumns = []vdevTreeColumn{
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Path", Tooltip: "Vdev path within the pool", Type: funcapi.FieldTypeString, Visible: false, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterNone, Sortable: true, UniqueKey: true}, Value: func(n vdevTreeNode) any { return n.vdev.vdev }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Order", Tooltip: "Position in the 'zpool status' tree", Type: funcapi.FieldTypeInteger, Visible: false, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterNone, Sortable: true}, Value: func(n vdevTreeNode) any { return n.order }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Pool", Tooltip: "Pool", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true, Sticky: true}, Value: func(n vdevTreeNode) any { return n.pool.name }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Vdev", Tooltip: "Vdev, indented by its depth in the tree", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: false, Sticky: true}, Value: func(n vdevTreeNode) any {
+		return strings.Repeat(vdevTreeIndent, max(n.vdev.level/2+1, 0)) + n.vdev.name
+	}},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Type", Tooltip: "pool, mirror, raidz, class (logs, cache, spares, special) or disk", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true}, Value: func(n vdevTreeNode) any { return n.vdev.typ() }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "State", Tooltip: "Device state", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterMultiselect, Sortable: true, Visualization: funcapi.FieldVisualPill}, Value: func(n vdevTreeNode) any { return strOrNil(n.vdev.state) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Read Errors", Tooltip: "Read I/O errors", Type: funcapi.FieldTypeInteger, Visible: true, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummaryMax, Filter: funcapi.FieldFilterRange, Sortable: true}, Value: func(n vdevTreeNode) any { return errCell(n.vdev, n.vdev.read) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Write Errors", Tooltip: "Write I/O errors", Type: funcapi.FieldTypeInteger, Visible: true, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummaryMax, Filter: funcapi.FieldFilterRange, Sortable: true}, Value: func(n vdevTreeNode) any { return errCell(n.vdev, n.vdev.write) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Checksum Errors", Tooltip: "Checksum errors", Type: funcapi.FieldTypeInteger, Visible: true, Sort: funcapi.FieldSortDescending, Summary: funcapi.FieldSummaryMax, Filter: funcapi.FieldFilterRange, Sortable: true}, Value: func(n vdevTreeNode) any { return errCell(n.vdev, n.vdev.cksum) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Note", Tooltip: "Additional information from 'zpool status', e.g. 'was /dev/sda1' or '(resilvering)'", Type: funcapi.FieldTypeString, Visible: true, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterNone, Sortable: false, FullWidth: true}, Value: func(n vdevTreeNode) any { return strOrNil(n.vdev.note) }},
+	{ColumnMeta: funcapi.ColumnMeta{Name: "Scan", Tooltip: "Last or current scrub/resilver of the pool", Type: funcapi.FieldTypeString, Visible: false, Sort: funcapi.FieldSortAscending, Summary: funcapi.FieldSummaryCount, Filter: funcapi.FieldFilterNone, Sortable: false, Wrap: true}, Value: func(n vdevTreeNode) any { return strOrNil(n.pool.scan.description) }},
+}
+
+func errCell(v statusVdev, n int64) any {
+	if !v.hasErr {
+		return nil
+	}
+	return n
+}
+
+func strOrNil(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -10,6 +10,7 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/netdata/netdata/go/plugins/pkg/matcher"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/pathvalidate"
 )
 
@@ -21,27 +22,54 @@ func (c *Collector) validateConfig() error {
 }
 
 func (c *Collector) initZPoolCLIExec() (zpoolCli, error) {
-	binPath := c.BinaryPath
+	binPath, err := resolveBinaryPath(c.BinaryPath)
+	if err != nil {
+		return nil, err
+	}
+
+	zpoolExec := newZpoolCLIExec(binPath, c.Timeout.Duration())
+	zpoolExec.Logger = c.Logger
+
+	return zpoolExec, nil
+}
 
+func (c *Collector) initZfsCLIExec() (zfsCli, error) {
+	binPath, err := resolveBinaryPath(c.ZfsBinaryPath)
+	if err != nil {
+		return nil, err
+	}
+
+	zfsExec := newZfsCLIExec(binPath, c.Timeout.Duration())
+	zfsExec.Logger = c.Logger
+
+	return zfsExec, nil
+}
+
+func (c *Collector) initDatasetSelector() (matcher.Matcher, error) {
+	if c.DatasetSelector == "" {
+		return matcher.TRUE(), nil
+	}
+
+	m, err := matcher.NewSimplePatternsMatcher(c.DatasetSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func resolveBinaryPath(binPath string) (string, error) {
 	if !strings.HasPrefix(binPath, "/") {
 		path, err := exec.LookPath(binPath)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 		binPath = path
 	}
 
 	if _, err := os.Stat(binPath); err != nil {
-		return nil, err
+		return "", err
 	}
 
-	validatedPath, err := pathvalidate.ValidateBinaryPath(binPath)
-	if err != nil {
-		return nil, err
-	}
-
-	zpoolExec := newZpoolCLIExec(validatedPath, c.Timeout.Duration())
-	zpoolExec.Logger = c.Logger
-
-	return zpoolExec, nil
+	return pathvalidate.ValidateBinaryPath(binPath)
 }
@@ -0,0 +1,6 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package diskhealth derives a failure-prediction health score for a disk from
+// its SMART / NVMe health attributes and the growth of its defect counters
+// over a persisted history. It is shared by the smartctl and nvme collectors.
+package diskhealth
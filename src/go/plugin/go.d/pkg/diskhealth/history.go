@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package diskhealth
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/framework/filepersister"
+)
+
+// Defect counts are kept in hourly samples for the trend window and persisted
+// under the Netdata lib dir, so the growth rate survives agent restarts.
+// Disks polled less often than hourly simply have fewer samples.
+
+const (
+	historySampleInterval = time.Hour
+	historyWindow         = 7 * 24 * time.Hour
+	historySaveInterval   = 5 * time.Minute
+	// Growth is averaged over at least a day, so that a couple of new defects
+	// right after the collector started do not look like a storm.
+	minGrowthSpan = 24 * time.Hour
+)
+
+// Trend is the growth of the disk defect counter within the history window.
+type Trend struct {
+	NewDefects   int64
+	Span         time.Duration // observed part of the window
+	GrowthPerDay float64
+}
+
+// History holds the defect history of the disks monitored by all jobs of a
+// collector in one plugin process.
+type History struct {
+	mu    sync.Mutex
+	state filepersister.State
+	disks map[string]*diskHistory
+}
+
+type (
+	diskHistory struct {
+		Samples []defectsSample `json:"samples"`
+	}
+	defectsSample struct {
+		Time    int64 `json:"time"` // first observation in the sample interval, unix seconds
+		Defects int64 `json:"defects"`
+	}
+)
+
+// NewHistory returns a history persisted to the file returned by statePath.
+// A nil statePath, or one returning "", keeps the history in memory only.
+func NewHistory(statePath func() string) *History {
+	return &History{
+		state: filepersister.State{Path: statePath, SaveInterval: historySaveInterval},
+		disks: make(map[string]*diskHistory),
+	}
+}
+
+// Observe records the current defect count of the disk identified by key
+// and returns its trend.
+func (h *History) Observe(key string, now time.Time, defects int64) Trend {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.ensureLoadedLocked(now)
+
+	disk, ok := h.disks[key]
+	if !ok {
+		disk = &diskHistory{}
+		h.disks[key] = disk
+	}
+	disk.observe(now, defects)
+	disk.prune(now)
+
+	if h.state.SaveDue(now) {
+		h.saveLocked(now)
+	}
+
+	return disk.trend(now)
+}
+
+// Save persists the history.
+func (h *History) Save() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.state.Loaded() {
+		h.saveLocked(time.Now())
+	}
+}
+
+func (d *diskHistory) observe(now time.Time, defects int64) {
+	if n := len(d.Samples); n > 0 {
+		last := &d.Samples[n-1]
+		if defects < last.Defects {
+			// counters do not decrease: the disk was replaced or its firmware reset them
+			d.Samples = d.Samples[:0]
+		} else if time.Unix(last.Time, 0).Truncate(historySampleInterval).Equal(now.Truncate(historySampleInterval)) {
+			last.Defects = defects
+			return
+		}
+	}
+	d.Samples = append(d.Samples, defectsSample{Time: now.Unix(), Defects: defects})
+}
+
+func (d *diskHistory) prune(now time.Time) {
+	since := now.Add(-historyWindow).Unix()
+
+	i := 0
+	for i < len(d.Samples)-1 && d.Samples[i].Time < since {
+		i++
+	}
+	d.Samples = d.Samples[i:]
+}
+
+func (d *diskHistory) trend(now time.Time) Trend {
+	if len(d.Samples) < 2 {
+		return Trend{}
+	}
+
+	first, last := d.Samples[0], d.Samples[len(d.Samples)-1]
+	t := Trend{
+		NewDefects: last.Defects - first.Defects,
+		Span:       now.Sub(time.Unix(first.Time, 0)),
+	}
+	t.GrowthPerDay = float64(t.NewDefects) / (max(t.Span, minGrowthSpan).Hours() / 24)
+
+	return t
+}
+
+func (h *History) ensureLoadedLocked(now time.Time) {
+	var state historyState
+	if !h.state.Load(&state) {
+		return
+	}
+
+	since := now.Add(-historyWindow).Unix()
+	for key, disk := range state.Disks {
+		if disk == nil || len(disk.Samples) == 0 || disk.Samples[len(disk.Samples)-1].Time < since {
+			continue
+		}
+		if _, ok := h.disks[key]; !ok {
+			h.disks[key] = disk
+		}
+	}
+}
+
+func (h *History) saveLocked(now time.Time) {
+	h.state.Save(now, historyState{Disks: h.disks})
+}
+
+type historyState struct {
+	Disks map[string]*diskHistory `json:"disks"`
+}
+
+func (s historyState) Bytes() ([]byte, error) {
+	return json.Marshal(s)
+}
@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package diskhealth
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory_Observe(t *testing.T) {
+	h := NewHistory(nil)
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, Trend{}, h.Observe("disk", start, 4))
+	assert.Equal(t, Trend{}, h.Observe("disk", start.Add(10*time.Minute), 4))
+
+	// growth within the first day is averaged over a day
+	trend := h.Observe("disk", start.Add(2*time.Hour), 6)
+	assert.Equal(t, int64(2), trend.NewDefects)
+	assert.Equal(t, 2*time.Hour, trend.Span)
+	assert.Equal(t, 2.0, trend.GrowthPerDay)
+
+	trend = h.Observe("disk", start.Add(4*24*time.Hour), 12)
+	assert.Equal(t, int64(8), trend.NewDefects)
+	assert.Equal(t, 2.0, trend.GrowthPerDay)
+
+	// samples older than the window are dropped
+	trend = h.Observe("disk", start.Add(8*24*time.Hour), 12)
+	assert.Equal(t, int64(0), trend.NewDefects)
+	assert.Equal(t, 4*24*time.Hour, trend.Span)
+
+	// a decreasing counter resets the history
+	trend = h.Observe("disk", start.Add(8*24*time.Hour+time.Hour), 1)
+	assert.Equal(t, Trend{}, trend)
+
+	assert.Equal(t, Trend{}, h.Observe("other", start, 100))
+}
+
+func TestHistory_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "disk-health.json")
+	statePath := func() string { return path }
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	h := NewHistory(statePath)
+	h.Observe("disk", start, 0)
+	h.Observe("disk", start.Add(time.Hour), 3)
+	h.Observe("stale", start.Add(-30*24*time.Hour), 3)
+	h.Save()
+
+	restarted := NewHistory(statePath)
+	trend := restarted.Observe("disk", start.Add(48*time.Hour), 5)
+
+	require.Equal(t, int64(5), trend.NewDefects)
+	assert.Equal(t, 2.5, trend.GrowthPerDay)
+
+	restarted.mu.Lock()
+	defer restarted.mu.Unlock()
+	assert.NotContains(t, restarted.disks, "stale")
+}
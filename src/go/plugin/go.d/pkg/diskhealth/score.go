@@ -0,0 +1,244 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package diskhealth
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+// The score starts at 100 and every attribute known to predict drive failure
+// subtracts a penalty that grows with the attribute value. The attributes and
+// thresholds follow the large-scale field studies of drive failures (Backblaze,
+// Google "Failure Trends in a Large Disk Drive Population"): reallocated,
+// pending and uncorrectable sectors and NVMe media errors are the strongest
+// predictors, CRC errors mostly point at cabling, and a growing defect count
+// is worse than a stable one.
+
+type Risk string
+
+const (
+	RiskLow    Risk = "low"
+	RiskMedium Risk = "medium"
+	RiskHigh   Risk = "high"
+)
+
+var Risks = []Risk{RiskLow, RiskMedium, RiskHigh}
+
+const (
+	MaxScore = 100
+
+	riskMediumBelow = 80
+	riskHighBelow   = 50
+)
+
+// NVMe critical warning bits (Health Information Log Page, byte 0).
+const (
+	CriticalWarningSpare           = 1 << 0
+	CriticalWarningTemperature     = 1 << 1
+	CriticalWarningReliability     = 1 << 2
+	CriticalWarningReadOnly        = 1 << 3
+	CriticalWarningVolatileBackup  = 1 << 4
+	CriticalWarningPersistentMemRO = 1 << 5
+)
+
+// Attributes are the failure-predictive attributes of a disk.
+// Nil fields are not reported by the disk and do not affect the score.
+type Attributes struct {
+	SmartStatusPassed *bool // SMART overall-health self-assessment
+	CriticalWarning   int64 // NVMe critical warning bit field
+
+	ReallocatedSectors   *int64 // ATA 5 Reallocated_Sector_Ct, SCSI grown defect list
+	PendingSectors       *int64 // ATA 197 Current_Pending_Sector
+	OfflineUncorrectable *int64 // ATA 198 Offline_Uncorrectable
+	ReportedUncorrect    *int64 // ATA 187 Reported_Uncorrect, SCSI uncorrected errors
+	CRCErrors            *int64 // ATA 199 UDMA_CRC_Error_Count
+
+	MediaErrors             *int64 // NVMe media and data integrity errors
+	PercentageUsed          *int64 // NVMe percentage used (endurance estimate)
+	AvailableSpare          *int64 // NVMe available spare, percent
+	AvailableSpareThreshold *int64 // NVMe available spare threshold, percent
+}
+
+// Known reports whether the disk reports any of the attributes used for scoring.
+func (a Attributes) Known() bool {
+	if a.SmartStatusPassed != nil {
+		return true
+	}
+	for _, v := range []*int64{
+		a.ReallocatedSectors,
+		a.PendingSectors,
+		a.OfflineUncorrectable,
+		a.ReportedUncorrect,
+		a.CRCErrors,
+		a.MediaErrors,
+		a.PercentageUsed,
+		a.AvailableSpare,
+	} {
+		if v != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Defects is the defect counter whose growth is tracked over time: remapped and
+// pending sectors for ATA/SCSI disks, media errors for NVMe. A pending sector
+// that gets reallocated moves between the counters and is not counted twice.
+// It returns false if the disk reports none of the counters.
+func (a Attributes) Defects() (int64, bool) {
+	var n int64
+	var ok bool
+	for _, v := range []*int64{a.ReallocatedSectors, a.PendingSectors, a.MediaErrors} {
+		if v != nil {
+			n += *v
+			ok = true
+		}
+	}
+	return n, ok
+}
+
+// Assessment is the derived health of a disk.
+type Assessment struct {
+	Score   int64 // 0 (failing) - 100 (no known risk factors)
+	Risk    Risk
+	Reasons []string // risk factors, most severe first
+}
+
+type penaltyStep struct {
+	min     int64
+	penalty int64
+}
+
+var (
+	reallocatedSteps = []penaltyStep{{min: 100, penalty: 35}, {min: 10, penalty: 20}, {min: 1, penalty: 10}}
+	pendingSteps     = []penaltyStep{{min: 10, penalty: 35}, {min: 1, penalty: 20}}
+	uncorrectSteps   = []penaltyStep{{min: 10, penalty: 30}, {min: 1, penalty: 20}}
+	reportedSteps    = []penaltyStep{{min: 10, penalty: 20}, {min: 1, penalty: 10}}
+	crcSteps         = []penaltyStep{{min: 100, penalty: 10}, {min: 1, penalty: 5}}
+	mediaErrorSteps  = []penaltyStep{{min: 10, penalty: 35}, {min: 1, penalty: 20}}
+	usedSteps        = []penaltyStep{{min: 100, penalty: 35}, {min: 90, penalty: 20}, {min: 80, penalty: 10}}
+)
+
+const (
+	smartFailedPenalty     = 60
+	readOnlyPenalty        = 60
+	reliabilityPenalty     = 60
+	volatileBackupPenalty  = 20
+	persistentMemROPenalty = 20
+	temperaturePenalty     = 10
+	spareBelowPenalty      = 40
+	spareLowPenalty        = 15
+	spareLowMargin         = 10
+	growthPenalty          = 15
+	fastGrowthPenalty      = 30
+	fastGrowthPerDay       = 1.0
+)
+
+// Assess scores the disk attributes and the defects trend.
+func Assess(attrs Attributes, trend Trend) Assessment {
+	type factor struct {
+		penalty int64
+		reason  string
+	}
+	var factors []factor
+	add := func(penalty int64, format string, args ...any) {
+		if penalty > 0 {
+			factors = append(factors, factor{penalty: penalty, reason: fmt.Sprintf(format, args...)})
+		}
+	}
+	stepped := func(v *int64, steps []penaltyStep, format string) {
+		if v != nil {
+			add(stepPenalty(*v, steps), format, *v)
+		}
+	}
+
+	if v := attrs.SmartStatusPassed; v != nil && !*v {
+		add(smartFailedPenalty, "SMART overall-health self-assessment failed")
+	}
+
+	cw := attrs.CriticalWarning
+	if cw&CriticalWarningReadOnly != 0 {
+		add(readOnlyPenalty, "media placed in read-only mode")
+	}
+	if cw&CriticalWarningReliability != 0 {
+		add(reliabilityPenalty, "NVM subsystem reliability degraded")
+	}
+	if cw&CriticalWarningVolatileBackup != 0 {
+		add(volatileBackupPenalty, "volatile memory backup device failed")
+	}
+	if cw&CriticalWarningPersistentMemRO != 0 {
+		add(persistentMemROPenalty, "persistent memory region placed in read-only mode")
+	}
+	if cw&CriticalWarningTemperature != 0 {
+		add(temperaturePenalty, "temperature outside of the threshold")
+	}
+
+	switch spare, thr := attrs.AvailableSpare, attrs.AvailableSpareThreshold; {
+	case spare != nil && thr != nil && *spare <= *thr:
+		add(spareBelowPenalty, "available spare %d%% at or below threshold %d%%", *spare, *thr)
+	case cw&CriticalWarningSpare != 0:
+		add(spareBelowPenalty, "available spare below threshold")
+	case spare != nil && thr != nil && *spare < *thr+spareLowMargin:
+		add(spareLowPenalty, "available spare %d%% close to threshold %d%%", *spare, *thr)
+	}
+
+	stepped(attrs.ReallocatedSectors, reallocatedSteps, "%d reallocated sectors")
+	stepped(attrs.PendingSectors, pendingSteps, "%d pending sectors")
+	stepped(attrs.OfflineUncorrectable, uncorrectSteps, "%d offline uncorrectable sectors")
+	stepped(attrs.ReportedUncorrect, reportedSteps, "%d reported uncorrectable errors")
+	stepped(attrs.MediaErrors, mediaErrorSteps, "%d media errors")
+	stepped(attrs.PercentageUsed, usedSteps, "%d%% of rated endurance used")
+	stepped(attrs.CRCErrors, crcSteps, "%d interface CRC errors (check cabling)")
+
+	if trend.NewDefects > 0 {
+		penalty := int64(growthPenalty)
+		if trend.GrowthPerDay >= fastGrowthPerDay {
+			penalty = fastGrowthPenalty
+		}
+		add(penalty, "%d new defects in %s (%.2f/day)", trend.NewDefects, formatSpan(trend.Span), trend.GrowthPerDay)
+	}
+
+	slices.SortStableFunc(factors, func(a, b factor) int { return cmp.Compare(b.penalty, a.penalty) })
+
+	a := Assessment{Score: MaxScore}
+	for _, f := range factors {
+		a.Score -= f.penalty
+		a.Reasons = append(a.Reasons, f.reason)
+	}
+	a.Score = max(a.Score, 0)
+	a.Risk = scoreRisk(a.Score)
+
+	return a
+}
+
+func scoreRisk(score int64) Risk {
+	switch {
+	case score < riskHighBelow:
+		return RiskHigh
+	case score < riskMediumBelow:
+		return RiskMedium
+	default:
+		return RiskLow
+	}
+}
+
+func stepPenalty(v int64, steps []penaltyStep) int64 {
+	for _, s := range steps {
+		if v >= s.min {
+			return s.penalty
+		}
+	}
+	return 0
+}
+
+func formatSpan(d time.Duration) string {
+	days := d.Hours() / 24
+	if days >= 1 {
+		return fmt.Sprintf("%dd", int64(math.Round(days)))
+	}
+	return fmt.Sprintf("%dh", max(int64(math.Round(d.Hours())), 1))
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package diskhealth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssess(t *testing.T) {
+	tests := map[string]struct {
+		attrs       Attributes
+		trend       Trend
+		wantScore   int64
+		wantRisk    Risk
+		wantReasons []string
+	}{
+		"healthy ATA disk": {
+			attrs: Attributes{
+				ReallocatedSectors:   new(int64(0)),
+				PendingSectors:       new(int64(0)),
+				OfflineUncorrectable: new(int64(0)),
+				CRCErrors:            new(int64(0)),
+			},
+			wantScore: 100,
+			wantRisk:  RiskLow,
+		},
+		"few reallocated sectors and CRC errors": {
+			attrs: Attributes{
+				ReallocatedSectors: new(int64(8)),
+				PendingSectors:     new(int64(0)),
+				CRCErrors:          new(int64(3)),
+			},
+			wantScore:   85,
+			wantRisk:    RiskLow,
+			wantReasons: []string{"8 reallocated sectors", "3 interface CRC errors (check cabling)"},
+		},
+		"pending and uncorrectable sectors": {
+			attrs: Attributes{
+				ReallocatedSectors:   new(int64(24)),
+				PendingSectors:       new(int64(16)),
+				OfflineUncorrectable: new(int64(16)),
+			},
+			wantScore:   15,
+			wantRisk:    RiskHigh,
+			wantReasons: []string{"16 pending sectors", "16 offline uncorrectable sectors", "24 reallocated sectors"},
+		},
+		"growing defects": {
+			attrs: Attributes{
+				ReallocatedSectors: new(int64(12)),
+				PendingSectors:     new(int64(0)),
+			},
+			trend:       Trend{NewDefects: 10, Span: 48 * time.Hour, GrowthPerDay: 5},
+			wantScore:   50,
+			wantRisk:    RiskMedium,
+			wantReasons: []string{"10 new defects in 2d (5.00/day)", "12 reallocated sectors"},
+		},
+		"SMART status failed": {
+			attrs:       Attributes{SmartStatusPassed: new(false)},
+			wantScore:   40,
+			wantRisk:    RiskHigh,
+			wantReasons: []string{"SMART overall-health self-assessment failed"},
+		},
+		"worn NVMe with spare close to threshold": {
+			attrs: Attributes{
+				MediaErrors:             new(int64(0)),
+				PercentageUsed:          new(int64(92)),
+				AvailableSpare:          new(int64(12)),
+				AvailableSpareThreshold: new(int64(10)),
+			},
+			wantScore:   65,
+			wantRisk:    RiskMedium,
+			wantReasons: []string{"92% of rated endurance used", "available spare 12% close to threshold 10%"},
+		},
+		"NVMe spare below threshold and read-only": {
+			attrs: Attributes{
+				CriticalWarning:         CriticalWarningSpare | CriticalWarningReadOnly,
+				MediaErrors:             new(int64(120)),
+				AvailableSpare:          new(int64(4)),
+				AvailableSpareThreshold: new(int64(10)),
+			},
+			wantScore:   0,
+			wantRisk:    RiskHigh,
+			wantReasons: []string{"media placed in read-only mode", "available spare 4% at or below threshold 10%", "120 media errors"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := Assess(test.attrs, test.trend)
+
+			assert.Equal(t, test.wantScore, a.Score)
+			assert.Equal(t, test.wantRisk, a.Risk)
+			assert.Equal(t, test.wantReasons, a.Reasons)
+		})
+	}
+}
+
+func TestAttributes_Defects(t *testing.T) {
+	_, ok := Attributes{CRCErrors: new(int64(5))}.Defects()
+	assert.False(t, ok)
+
+	n, ok := Attributes{ReallocatedSectors: new(int64(5)), PendingSectors: new(int64(2)), OfflineUncorrectable: new(int64(2))}.Defects()
+	assert.True(t, ok)
+	assert.Equal(t, int64(7), n)
+}
@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package fixture
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+	"unicode/utf8"
+)
+
+const bundleVersion = 1
+
+// Bundle is a recording of the traffic of one job. It is plain JSON so it can
+// be reviewed, edited and attached to a bug report.
+type Bundle struct {
+	Version int       `json:"version"`
+	Module  string    `json:"module,omitempty"`
+	Job     string    `json:"job,omitempty"`
+	Created time.Time `json:"created"`
+
+	HTTP   []HTTPExchange  `json:"http,omitempty"`
+	SQL    []SQLExchange   `json:"sql,omitempty"`
+	Socket []SocketSession `json:"socket,omitempty"`
+}
+
+// HTTPExchange is one HTTP round trip. Error is set instead of the response
+// when the request failed.
+type HTTPExchange struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	RequestBody Data        `json:"request_body,omitempty"`
+	Status      int         `json:"status,omitempty"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        Data        `json:"body,omitempty"`
+	Error       string      `json:"error,omitempty"`
+}
+
+// SQLExchange is one query or statement execution and its result.
+type SQLExchange struct {
+	Query        string       `json:"query"`
+	Args         []string     `json:"args,omitempty"`
+	Exec         bool         `json:"exec,omitempty"`
+	RowsAffected int64        `json:"rows_affected,omitempty"`
+	Columns      []string     `json:"columns,omitempty"`
+	Rows         [][]SQLValue `json:"rows,omitempty"`
+	Error        string       `json:"error,omitempty"`
+}
+
+// SocketSession is one socket connection: either the dial error or the
+// commands written and the responses read, in order.
+type SocketSession struct {
+	Network   string           `json:"network"`
+	Address   string           `json:"address"`
+	Error     string           `json:"error,omitempty"`
+	Exchanges []SocketExchange `json:"exchanges,omitempty"`
+}
+
+// SocketExchange is one command and everything read after it. Command is empty
+// for data the server sent without being asked.
+type SocketExchange struct {
+	Command  Data `json:"command,omitempty"`
+	Response Data `json:"response,omitempty"`
+}
+
+// LoadBundle reads a bundle written by Save.
+func LoadBundle(path string) (*Bundle, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var b Bundle
+	if err := json.Unmarshal(bs, &b); err != nil {
+		return nil, fmt.Errorf("fixture: parse '%s': %w", path, err)
+	}
+	if b.Version != bundleVersion {
+		return nil, fmt.Errorf("fixture: '%s': unsupported bundle version %d", path, b.Version)
+	}
+	return &b, nil
+}
+
+// Save writes the bundle as indented JSON.
+func (b *Bundle) Save(path string) error {
+	bs, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(bs, '\n'), 0o600)
+}
+
+// Data is recorded payload. It is kept as a JSON string when it is valid UTF-8
+// and as {"base64": "..."} otherwise.
+type Data []byte
+
+func (d Data) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(d) {
+		return json.Marshal(string(d))
+	}
+	return json.Marshal(map[string]string{"base64": base64.StdEncoding.EncodeToString(d)})
+}
+
+func (d *Data) UnmarshalJSON(bs []byte) error {
+	var s string
+	if err := json.Unmarshal(bs, &s); err == nil {
+		*d = Data(s)
+		return nil
+	}
+	var enc struct {
+		Base64 string `json:"base64"`
+	}
+	if err := json.Unmarshal(bs, &enc); err != nil {
+		return err
+	}
+	v, err := base64.StdEncoding.DecodeString(enc.Base64)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// SQLValue is one column value. Numbers, booleans, strings and null map to
+// JSON directly; time values are kept as {"time": "<RFC 3339>"} and binary
+// values as {"base64": "..."}.
+type SQLValue struct {
+	V any
+}
+
+func (v SQLValue) MarshalJSON() ([]byte, error) {
+	switch x := v.V.(type) {
+	case time.Time:
+		return json.Marshal(map[string]string{"time": x.Format(time.RFC3339Nano)})
+	case []byte:
+		return Data(x).MarshalJSON()
+	default:
+		return json.Marshal(x)
+	}
+}
+
+func (v *SQLValue) UnmarshalJSON(bs []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(bs))
+	dec.UseNumber()
+	var raw any
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+	switch x := raw.(type) {
+	case json.Number:
+		if i, err := x.Int64(); err == nil {
+			v.V = i
+			return nil
+		}
+		f, err := x.Float64()
+		if err != nil {
+			return err
+		}
+		v.V = f
+	case map[string]any:
+		if s, ok := x["time"].(string); ok {
+			t, err := time.Parse(time.RFC3339Nano, s)
+			if err != nil {
+				return err
+			}
+			v.V = t
+			return nil
+		}
+		var d Data
+		if err := d.UnmarshalJSON(bs); err != nil {
+			return errors.New("fixture: unknown SQL value encoding")
+		}
+		v.V = []byte(d)
+	default:
+		v.V = x
+	}
+	return nil
+}
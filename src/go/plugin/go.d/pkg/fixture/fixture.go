@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package fixture records the HTTP, SQL and socket traffic of collectors into
+// a Bundle and replays it later without the monitored service.
+//
+// Recording and replay hook into web.NewHTTPClient, sqlquery.Open/OpenDB and
+// socket.Socket for the whole process, so they are meant for a single job run
+// with -m/-j, typically together with --dump.
+package fixture
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/pkg/web"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/socket"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/sqlquery"
+)
+
+var active atomic.Bool
+
+var errActive = errors.New("fixture: recording or replay is already active")
+
+// RecordOptions control what a Recorder keeps.
+type RecordOptions struct {
+	// RequestBodies keeps HTTP request bodies, which may carry login payloads
+	// or queries with credentials. Without them, replay matches HTTP requests
+	// by method and URL only.
+	RequestBodies bool
+}
+
+// Recorder collects traffic into a bundle until it is stopped.
+type Recorder struct {
+	opts   RecordOptions
+	mu     sync.Mutex
+	bundle Bundle
+	// sockets keeps open sessions in dial order; they are copied into the
+	// bundle on Stop because connections may still be writing to them.
+	sockets []*SocketSession
+}
+
+// Record starts recording the traffic of every collector in the process.
+func Record(module, job string, opts RecordOptions) (*Recorder, error) {
+	if !active.CompareAndSwap(false, true) {
+		return nil, errActive
+	}
+	r := &Recorder{opts: opts, bundle: Bundle{
+		Version: bundleVersion,
+		Module:  module,
+		Job:     job,
+		Created: time.Now().UTC(),
+	}}
+	web.SetTransportHook(r.wrapTransport)
+	sqlquery.SetConnectorHook(r.wrapConnector)
+	socket.SetDialHook(r.dial)
+	return r, nil
+}
+
+// Stop removes the hooks and returns the recorded bundle.
+func (r *Recorder) Stop() *Bundle {
+	uninstall()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b := r.bundle
+	for _, s := range r.sockets {
+		b.Socket = append(b.Socket, *s)
+	}
+	return &b
+}
+
+func (r *Recorder) addHTTP(e HTTPExchange) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bundle.HTTP = append(r.bundle.HTTP, e)
+}
+
+func (r *Recorder) addSQL(e SQLExchange) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bundle.SQL = append(r.bundle.SQL, e)
+}
+
+// Replayer serves recorded traffic. Repeated requests get the recorded
+// responses in order; once they run out, the last one is repeated so long runs
+// keep working.
+type Replayer struct {
+	mu      sync.Mutex
+	http    map[string]*sequence[HTTPExchange]
+	httpAny map[string]*sequence[HTTPExchange]
+	sql     map[string]*sequence[SQLExchange]
+	sockets map[string]*sequence[SocketSession]
+}
+
+// Replay starts serving b to every collector in the process.
+func Replay(b *Bundle) (*Replayer, error) {
+	if b == nil {
+		return nil, errors.New("fixture: nil bundle")
+	}
+	if !active.CompareAndSwap(false, true) {
+		return nil, errActive
+	}
+	r := &Replayer{
+		http:    make(map[string]*sequence[HTTPExchange]),
+		httpAny: make(map[string]*sequence[HTTPExchange]),
+		sql:     make(map[string]*sequence[SQLExchange]),
+		sockets: make(map[string]*sequence[SocketSession]),
+	}
+	for _, e := range b.HTTP {
+		add(r.http, httpKey(e.Method, e.URL, e.RequestBody, true), e)
+		add(r.httpAny, httpKey(e.Method, e.URL, e.RequestBody, false), e)
+	}
+	for _, e := range b.SQL {
+		add(r.sql, sqlKey(e.Exec, e.Query, e.Args), e)
+	}
+	for _, s := range b.Socket {
+		add(r.sockets, socketKey(s.Network, s.Address), s)
+	}
+	web.SetTransportHook(r.transport)
+	sqlquery.SetConnectorHook(r.connector)
+	socket.SetDialHook(r.dial)
+	return r, nil
+}
+
+// Stop removes the hooks.
+func (r *Replayer) Stop() {
+	uninstall()
+}
+
+func uninstall() {
+	web.SetTransportHook(nil)
+	sqlquery.SetConnectorHook(nil)
+	socket.SetDialHook(nil)
+	active.Store(false)
+}
+
+type sequence[T any] struct {
+	items []T
+	next  int
+}
+
+func add[T any](m map[string]*sequence[T], key string, v T) {
+	s, ok := m[key]
+	if !ok {
+		s = &sequence[T]{}
+		m[key] = s
+	}
+	s.items = append(s.items, v)
+}
+
+func take[T any](mu *sync.Mutex, m map[string]*sequence[T], key string) (T, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	s, ok := m[key]
+	if !ok || len(s.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	v := s.items[min(s.next, len(s.items)-1)]
+	if s.next < len(s.items) {
+		s.next++
+	}
+	return v, true
+}
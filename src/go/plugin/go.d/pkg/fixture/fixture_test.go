@@ -0,0 +1,284 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package fixture
+
+import (
+	"bufio"
+	"database/sql"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/pkg/web"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/socket"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/sqlquery"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixture_HTTP(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = fmt.Fprintf(w, "%s %s %d", r.Method, r.URL.Path, hits)
+	}))
+
+	get := func(url string) (string, error) {
+		client, err := web.NewHTTPClient(web.ClientConfig{})
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		var body string
+		err = web.DoHTTP(client).Request(req, func(r io.Reader) error {
+			bs, err := io.ReadAll(r)
+			body = string(bs)
+			return err
+		})
+		return body, err
+	}
+
+	rec, err := Record("test", "local", RecordOptions{})
+	require.NoError(t, err)
+	for range 2 {
+		_, err := get(srv.URL + "/metrics")
+		require.NoError(t, err)
+	}
+	b := saveAndLoad(t, rec.Stop())
+	srv.Close()
+
+	require.Len(t, b.HTTP, 2)
+	assert.Equal(t, "text/plain", b.HTTP[0].Header.Get("Content-Type"))
+
+	rep, err := Replay(b)
+	require.NoError(t, err)
+	defer rep.Stop()
+
+	for _, want := range []string{"GET /metrics 1", "GET /metrics 2", "GET /metrics 2"} {
+		body, err := get(srv.URL + "/metrics")
+		require.NoError(t, err)
+		assert.Equal(t, want, body)
+	}
+
+	body, err := get("http://other.host:1234/metrics")
+	require.NoError(t, err)
+	assert.Equal(t, "GET /metrics 1", body, "host-less fallback keeps its own order")
+
+	_, err = get(srv.URL + "/unknown")
+	assert.Error(t, err)
+}
+
+func TestFixture_HTTPRedaction(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "secret"})
+		w.Header().Set("X-Api-Key", "secret")
+		_, _ = fmt.Fprintf(w, "%s %s", r.URL.Query().Get("api_key"), body)
+	}))
+	defer srv.Close()
+
+	post := func(url string) (string, error) {
+		client, err := web.NewHTTPClient(web.ClientConfig{})
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(`{"password":"secret"}`))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer secret")
+		var body string
+		err = web.DoHTTP(client).Request(req, func(r io.Reader) error {
+			bs, err := io.ReadAll(r)
+			body = string(bs)
+			return err
+		})
+		return body, err
+	}
+
+	for name, opts := range map[string]RecordOptions{
+		"without request bodies": {},
+		"with request bodies":    {RequestBodies: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec, err := Record("test", "local", opts)
+			require.NoError(t, err)
+			_, err = post(srv.URL + "/login?user=me&api_key=secret&Token=secret")
+			require.NoError(t, err)
+			b := saveAndLoad(t, rec.Stop())
+
+			require.Len(t, b.HTTP, 1)
+			e := b.HTTP[0]
+			assert.Equal(t, srv.URL+"/login?user=me&api_key=REDACTED&Token=REDACTED", e.URL)
+			for _, h := range []string{"Set-Cookie", "X-Api-Key", "Authorization"} {
+				assert.Empty(t, e.Header.Get(h), h)
+			}
+			if opts.RequestBodies {
+				assert.Equal(t, `{"password":"secret"}`, string(e.RequestBody))
+			} else {
+				assert.Empty(t, e.RequestBody)
+			}
+
+			rep, err := Replay(b)
+			require.NoError(t, err)
+			defer rep.Stop()
+
+			body, err := post(srv.URL + "/login?user=me&api_key=other&Token=other")
+			require.NoError(t, err)
+			assert.Equal(t, `secret {"password":"secret"}`, body)
+		})
+	}
+}
+
+func TestFixture_SQL(t *testing.T) {
+	const dsn = "fixture_sql_test"
+	db, mock, err := sqlmock.NewWithDSN(dsn, sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectQuery("SELECT name, value, ts, raw FROM stats WHERE id = ?").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "value", "ts", "raw"}).
+			AddRow("queries", int64(42), now, []byte{0xff, 0x00}).
+			AddRow("ratio", 0.5, now, []byte("ok")))
+	mock.ExpectQuery("SELECT broken").WillReturnError(fmt.Errorf("table is gone"))
+
+	rec, err := Record("test", "local", RecordOptions{})
+	require.NoError(t, err)
+	conn, err := sqlquery.Open("sqlmock", dsn)
+	require.NoError(t, err)
+	recorded := queryStats(t, conn)
+	_, err = conn.Query("SELECT broken")
+	require.Error(t, err)
+	_ = conn.Close()
+	b := saveAndLoad(t, rec.Stop())
+
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Len(t, b.SQL, 2)
+	assert.Equal(t, []string{"7"}, b.SQL[0].Args)
+	assert.Equal(t, "table is gone", b.SQL[1].Error)
+
+	rep, err := Replay(b)
+	require.NoError(t, err)
+	defer rep.Stop()
+
+	conn, err = sqlquery.Open("sqlmock", dsn)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	require.NoError(t, conn.Ping())
+	assert.Equal(t, recorded, queryStats(t, conn))
+	assert.Equal(t, recorded, queryStats(t, conn), "repeats the last result")
+	_, err = conn.Query("SELECT broken")
+	assert.ErrorContains(t, err, "table is gone")
+	_, err = conn.Query("SELECT unknown")
+	assert.Error(t, err)
+}
+
+func TestFixture_Socket(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				_, _ = conn.Write([]byte("hello\n"))
+				sc := bufio.NewScanner(conn)
+				for sc.Scan() {
+					_, _ = fmt.Fprintf(conn, "echo %s\nEND\n", sc.Text())
+				}
+			}()
+		}
+	}()
+	addr := "tcp://" + ln.Addr().String()
+
+	run := func() []string {
+		sock := socket.New(socket.Config{Address: addr, Timeout: time.Second})
+		require.NoError(t, sock.Connect())
+		defer func() { _ = sock.Disconnect() }()
+
+		var lines []string
+		collect := func(bs []byte) (bool, error) {
+			lines = append(lines, string(bs))
+			return string(bs) != "END", nil
+		}
+		require.NoError(t, sock.Command("stats\n", collect))
+		require.NoError(t, sock.Command("info\n", collect))
+		return lines
+	}
+
+	rec, err := Record("test", "local", RecordOptions{})
+	require.NoError(t, err)
+	recorded := run()
+	b := saveAndLoad(t, rec.Stop())
+	_ = ln.Close()
+
+	require.Len(t, b.Socket, 1)
+	assert.Equal(t, "hello\necho stats\nEND\n", string(b.Socket[0].Exchanges[0].Response))
+
+	rep, err := Replay(b)
+	require.NoError(t, err)
+	defer rep.Stop()
+
+	assert.Equal(t, recorded, run())
+	assert.Equal(t, recorded, run(), "repeats the last session")
+}
+
+func TestFixture_OneActive(t *testing.T) {
+	rec, err := Record("test", "local", RecordOptions{})
+	require.NoError(t, err)
+
+	_, err = Record("test", "local", RecordOptions{})
+	assert.Error(t, err)
+	_, err = Replay(&Bundle{Version: bundleVersion})
+	assert.Error(t, err)
+
+	rec.Stop()
+	rep, err := Replay(&Bundle{Version: bundleVersion})
+	require.NoError(t, err)
+	rep.Stop()
+}
+
+func TestLoadBundle_UnsupportedVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bundle.json")
+	require.NoError(t, (&Bundle{Version: bundleVersion + 1}).Save(path))
+
+	_, err := LoadBundle(path)
+	assert.ErrorContains(t, err, "unsupported bundle version")
+}
+
+func queryStats(t *testing.T, db *sql.DB) []string {
+	t.Helper()
+	rows, err := db.Query("SELECT name, value, ts, raw FROM stats WHERE id = ?", 7)
+	require.NoError(t, err)
+	defer func() { _ = rows.Close() }()
+
+	var out []string
+	for rows.Next() {
+		var name, value string
+		var ts time.Time
+		var raw []byte
+		require.NoError(t, rows.Scan(&name, &value, &ts, &raw))
+		out = append(out, fmt.Sprintf("%s=%s %s %x", name, value, ts.Format(time.RFC3339), raw))
+	}
+	require.NoError(t, rows.Err())
+	return out
+}
+
+func saveAndLoad(t *testing.T, b *Bundle) *Bundle {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "bundle.json")
+	require.NoError(t, b.Save(path))
+	loaded, err := LoadBundle(path)
+	require.NoError(t, err)
+	return loaded
+}
@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package fixture
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// unrecordedHeaders are response headers left out of a bundle because they
+// carry credentials or do not survive replay. Request headers are never
+// recorded.
+var unrecordedHeaders = []string{
+	"Set-Cookie",
+	"Cookie",
+	"Authorization",
+	"Proxy-Authorization",
+	"WWW-Authenticate",
+	"X-Api-Key",
+	"X-Auth-Token",
+	"Content-Length",
+	"Transfer-Encoding",
+}
+
+// secretQueryParams are URL query parameters, compared case-insensitively,
+// whose values are replaced in a bundle.
+var secretQueryParams = map[string]bool{
+	"access_token":  true,
+	"api_key":       true,
+	"apikey":        true,
+	"auth":          true,
+	"auth_token":    true,
+	"key":           true,
+	"pass":          true,
+	"passwd":        true,
+	"password":      true,
+	"refresh_token": true,
+	"secret":        true,
+	"signature":     true,
+	"token":         true,
+}
+
+const redacted = "REDACTED"
+
+func (r *Recorder) wrapTransport(next http.RoundTripper) http.RoundTripper {
+	return &recordTransport{next: next, rec: r}
+}
+
+type recordTransport struct {
+	next http.RoundTripper
+	rec  *Recorder
+}
+
+func (t *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	e := HTTPExchange{Method: req.Method, URL: redactURL(req.URL)}
+
+	if t.rec.opts.RequestBodies && req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		e.RequestBody = body
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		e.Error = err.Error()
+		t.rec.addHTTP(e)
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	e.Status = resp.StatusCode
+	e.Header = resp.Header.Clone()
+	for _, h := range unrecordedHeaders {
+		e.Header.Del(h)
+	}
+	e.Body = body
+	t.rec.addHTTP(e)
+
+	return resp, nil
+}
+
+func (t *recordTransport) CloseIdleConnections() {
+	if c, ok := t.next.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+}
+
+func (r *Replayer) transport(http.RoundTripper) http.RoundTripper {
+	return replayTransport{r}
+}
+
+type replayTransport struct {
+	r *Replayer
+}
+
+func (t replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+	}
+
+	u := redactURL(req.URL)
+	e, ok := t.take(req.Method, u, body)
+	if !ok && len(body) > 0 {
+		// recorded without request bodies
+		e, ok = t.take(req.Method, u, nil)
+	}
+	if !ok {
+		return nil, fmt.Errorf("fixture: no recorded response for %s %s", req.Method, u)
+	}
+	if e.Error != "" {
+		return nil, fmt.Errorf("fixture: recorded error: %s", e.Error)
+	}
+
+	header := e.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status)),
+		StatusCode:    e.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}, nil
+}
+
+func (t replayTransport) take(method, u string, body []byte) (HTTPExchange, bool) {
+	if e, ok := take(&t.r.mu, t.r.http, httpKey(method, u, body, true)); ok {
+		return e, true
+	}
+	return take(&t.r.mu, t.r.httpAny, httpKey(method, u, body, false))
+}
+
+// httpKey identifies a request. The host-less form lets a bundle be replayed
+// against a config that points somewhere else, e.g. the collector defaults.
+func httpKey(method, rawURL string, body []byte, withHost bool) string {
+	target := rawURL
+	if u, err := url.Parse(rawURL); err == nil && !withHost {
+		target = u.RequestURI()
+	}
+	return method + " " + target + "\n" + string(body)
+}
+
+// redactURL drops URL credentials and replaces the values of secret query
+// parameters. Replay applies it to incoming requests too, so they still match.
+func redactURL(u *url.URL) string {
+	v := *u
+	v.User = nil
+
+	if v.RawQuery != "" {
+		parts := strings.Split(v.RawQuery, "&")
+		for i, part := range parts {
+			name, _, ok := strings.Cut(part, "=")
+			if !ok {
+				continue
+			}
+			if key, err := url.QueryUnescape(name); err == nil && secretQueryParams[strings.ToLower(key)] {
+				parts[i] = name + "=" + redacted
+			}
+		}
+		v.RawQuery = strings.Join(parts, "&")
+	}
+
+	return v.String()
+}
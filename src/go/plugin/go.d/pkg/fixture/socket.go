@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package fixture
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+func (r *Recorder) dial(network, address string, dial func() (net.Conn, error)) (net.Conn, error) {
+	session := &SocketSession{Network: network, Address: address}
+
+	conn, err := dial()
+
+	r.mu.Lock()
+	r.sockets = append(r.sockets, session)
+	if err != nil {
+		session.Error = err.Error()
+	}
+	r.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+	return &recordConn{Conn: conn, mu: &r.mu, session: session}, nil
+}
+
+// recordConn appends writes to the current exchange command and reads to its
+// response. A write after a read starts a new exchange.
+type recordConn struct {
+	net.Conn
+	mu      *sync.Mutex
+	session *SocketSession
+}
+
+func (c *recordConn) Write(b []byte) (int, error) {
+	c.mu.Lock()
+	ex := c.session.Exchanges
+	if len(ex) == 0 || len(ex[len(ex)-1].Response) > 0 {
+		c.session.Exchanges = append(ex, SocketExchange{})
+	}
+	last := &c.session.Exchanges[len(c.session.Exchanges)-1]
+	last.Command = append(last.Command, b...)
+	c.mu.Unlock()
+
+	return c.Conn.Write(b)
+}
+
+func (c *recordConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.mu.Lock()
+		if len(c.session.Exchanges) == 0 {
+			c.session.Exchanges = append(c.session.Exchanges, SocketExchange{})
+		}
+		last := &c.session.Exchanges[len(c.session.Exchanges)-1]
+		last.Response = append(last.Response, b[:n]...)
+		c.mu.Unlock()
+	}
+	return n, err
+}
+
+func (r *Replayer) dial(network, address string, _ func() (net.Conn, error)) (net.Conn, error) {
+	s, ok := take(&r.mu, r.sockets, socketKey(network, address))
+	if !ok {
+		return nil, fmt.Errorf("fixture: no recorded session for %s %s", network, address)
+	}
+	if s.Error != "" {
+		return nil, fmt.Errorf("fixture: recorded error: %s", s.Error)
+	}
+	return newReplayConn(s), nil
+}
+
+// replayConn serves a recorded session. A write selects the next exchange with
+// the same command; reads return its response and then io.EOF.
+type replayConn struct {
+	session SocketSession
+	next    int
+	reader  *bytes.Reader
+	closed  bool
+}
+
+func newReplayConn(s SocketSession) *replayConn {
+	c := &replayConn{session: s, reader: bytes.NewReader(nil)}
+	if len(s.Exchanges) > 0 && len(s.Exchanges[0].Command) == 0 {
+		c.reader = bytes.NewReader(s.Exchanges[0].Response)
+		c.next = 1
+	}
+	return c
+}
+
+func (c *replayConn) Write(b []byte) (int, error) {
+	if c.closed {
+		return 0, net.ErrClosed
+	}
+	for i := c.next; i < len(c.session.Exchanges); i++ {
+		if bytes.Equal(c.session.Exchanges[i].Command, b) {
+			c.reader = bytes.NewReader(c.session.Exchanges[i].Response)
+			c.next = i + 1
+			return len(b), nil
+		}
+	}
+	return 0, fmt.Errorf("fixture: no recorded response for command %q to %s", b, c.session.Address)
+}
+
+func (c *replayConn) Read(b []byte) (int, error) {
+	if c.closed {
+		return 0, net.ErrClosed
+	}
+	return c.reader.Read(b)
+}
+
+func (c *replayConn) Close() error {
+	if c.closed {
+		return errors.New("fixture: connection already closed")
+	}
+	c.closed = true
+	return nil
+}
+
+func (c *replayConn) LocalAddr() net.Addr              { return replayAddr{c.session.Network, "fixture"} }
+func (c *replayConn) RemoteAddr() net.Addr             { return replayAddr{c.session.Network, c.session.Address} }
+func (c *replayConn) SetDeadline(time.Time) error      { return nil }
+func (c *replayConn) SetReadDeadline(time.Time) error  { return nil }
+func (c *replayConn) SetWriteDeadline(time.Time) error { return nil }
+
+func socketKey(network, address string) string { return network + " " + address }
+
+type replayAddr struct{ network, address string }
+
+func (a replayAddr) Network() string { return a.network }
+func (a replayAddr) String() string  { return a.address }
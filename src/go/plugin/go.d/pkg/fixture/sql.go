@@ -0,0 +1,351 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package fixture
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+func (r *Recorder) wrapConnector(next driver.Connector) driver.Connector {
+	return &recordConnector{next: next, rec: r}
+}
+
+type recordConnector struct {
+	next driver.Connector
+	rec  *Recorder
+}
+
+func (c *recordConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.next.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &recordSQLConn{conn: conn, rec: c.rec}, nil
+}
+
+func (c *recordConnector) Driver() driver.Driver { return c.next.Driver() }
+
+// recordSQLConn records queries run on conn. Optional driver interfaces are
+// forwarded; driver.ErrSkip makes database/sql fall back to Prepare when conn
+// does not implement them.
+type recordSQLConn struct {
+	conn driver.Conn
+	rec  *Recorder
+}
+
+func (c *recordSQLConn) Prepare(query string) (driver.Stmt, error) {
+	stmt, err := c.conn.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	return &recordStmt{stmt: stmt, query: query, rec: c.rec}, nil
+}
+
+func (c *recordSQLConn) Close() error { return c.conn.Close() }
+
+func (c *recordSQLConn) Begin() (driver.Tx, error) { return c.conn.Begin() } //nolint:staticcheck
+
+func (c *recordSQLConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if b, ok := c.conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+	return c.conn.Begin() //nolint:staticcheck
+}
+
+func (c *recordSQLConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q, ok := c.conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	rows, err := q.QueryContext(ctx, query, args)
+	return c.rec.recordRows(query, args, rows, err)
+}
+
+func (c *recordSQLConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, ok := c.conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	res, err := e.ExecContext(ctx, query, args)
+	return c.rec.recordResult(query, args, res, err)
+}
+
+func (c *recordSQLConn) Ping(ctx context.Context) error {
+	if p, ok := c.conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *recordSQLConn) ResetSession(ctx context.Context) error {
+	if r, ok := c.conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *recordSQLConn) IsValid() bool {
+	if v, ok := c.conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+func (c *recordSQLConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if ch, ok := c.conn.(driver.NamedValueChecker); ok {
+		return ch.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+type recordStmt struct {
+	stmt  driver.Stmt
+	query string
+	rec   *Recorder
+}
+
+func (s *recordStmt) Close() error  { return s.stmt.Close() }
+func (s *recordStmt) NumInput() int { return s.stmt.NumInput() }
+
+//nolint:staticcheck // driver.Stmt requires the legacy methods.
+func (s *recordStmt) Exec(args []driver.Value) (driver.Result, error) {
+	res, err := s.stmt.Exec(args)
+	return s.rec.recordResult(s.query, namedValues(args), res, err)
+}
+
+//nolint:staticcheck // driver.Stmt requires the legacy methods.
+func (s *recordStmt) Query(args []driver.Value) (driver.Rows, error) {
+	rows, err := s.stmt.Query(args)
+	return s.rec.recordRows(s.query, namedValues(args), rows, err)
+}
+
+func (s *recordStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	if q, ok := s.stmt.(driver.StmtQueryContext); ok {
+		rows, err := q.QueryContext(ctx, args)
+		return s.rec.recordRows(s.query, args, rows, err)
+	}
+	return s.Query(plainValues(args))
+}
+
+func (s *recordStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	if e, ok := s.stmt.(driver.StmtExecContext); ok {
+		res, err := e.ExecContext(ctx, args)
+		return s.rec.recordResult(s.query, args, res, err)
+	}
+	return s.Exec(plainValues(args))
+}
+
+func (r *Recorder) recordRows(query string, args []driver.NamedValue, rows driver.Rows, err error) (driver.Rows, error) {
+	if errors.Is(err, driver.ErrSkip) {
+		return nil, err
+	}
+	e := SQLExchange{Query: query, Args: argStrings(args)}
+	if err != nil {
+		e.Error = err.Error()
+		r.addSQL(e)
+		return nil, err
+	}
+	e.Columns = rows.Columns()
+	return &recordRows{rows: rows, rec: r, e: e}, nil
+}
+
+func (r *Recorder) recordResult(query string, args []driver.NamedValue, res driver.Result, err error) (driver.Result, error) {
+	if errors.Is(err, driver.ErrSkip) {
+		return nil, err
+	}
+	e := SQLExchange{Query: query, Args: argStrings(args), Exec: true}
+	if err != nil {
+		e.Error = err.Error()
+	} else if n, err := res.RowsAffected(); err == nil {
+		e.RowsAffected = n
+	}
+	r.addSQL(e)
+	return res, err
+}
+
+// recordRows copies every row it returns and adds the exchange on Close,
+// which database/sql always calls.
+type recordRows struct {
+	rows driver.Rows
+	rec  *Recorder
+	e    SQLExchange
+	done bool
+}
+
+func (r *recordRows) Columns() []string { return r.rows.Columns() }
+
+func (r *recordRows) Next(dest []driver.Value) error {
+	err := r.rows.Next(dest)
+	switch {
+	case err == nil:
+		row := make([]SQLValue, len(dest))
+		for i, v := range dest {
+			if b, ok := v.([]byte); ok {
+				v = slices.Clone(b)
+			}
+			row[i] = SQLValue{V: v}
+		}
+		r.e.Rows = append(r.e.Rows, row)
+	case !errors.Is(err, io.EOF):
+		r.e.Error = err.Error()
+	}
+	return err
+}
+
+func (r *recordRows) Close() error {
+	if !r.done {
+		r.done = true
+		r.rec.addSQL(r.e)
+	}
+	return r.rows.Close()
+}
+
+func (r *Replayer) connector(next driver.Connector) driver.Connector {
+	return &replayConnector{next: next, r: r}
+}
+
+type replayConnector struct {
+	next driver.Connector
+	r    *Replayer
+}
+
+func (c *replayConnector) Connect(context.Context) (driver.Conn, error) {
+	return &replaySQLConn{r: c.r}, nil
+}
+
+func (c *replayConnector) Driver() driver.Driver { return c.next.Driver() }
+
+type replaySQLConn struct {
+	r *Replayer
+}
+
+func (c *replaySQLConn) Prepare(query string) (driver.Stmt, error) {
+	return &replayStmt{conn: c, query: query}, nil
+}
+
+func (c *replaySQLConn) Close() error                             { return nil }
+func (c *replaySQLConn) Begin() (driver.Tx, error)                { return replayTx{}, nil }
+func (c *replaySQLConn) Ping(context.Context) error               { return nil }
+func (c *replaySQLConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+func (c *replaySQLConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	e, err := c.take(false, query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &replayRows{e: e}, nil
+}
+
+func (c *replaySQLConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, err := c.take(true, query, args)
+	if err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(e.RowsAffected), nil
+}
+
+func (c *replaySQLConn) take(exec bool, query string, args []driver.NamedValue) (SQLExchange, error) {
+	e, ok := take(&c.r.mu, c.r.sql, sqlKey(exec, query, argStrings(args)))
+	if !ok {
+		return SQLExchange{}, fmt.Errorf("fixture: no recorded result for query %q", query)
+	}
+	if e.Error != "" && len(e.Rows) == 0 {
+		return SQLExchange{}, fmt.Errorf("fixture: recorded error: %s", e.Error)
+	}
+	return e, nil
+}
+
+type replayStmt struct {
+	conn  *replaySQLConn
+	query string
+}
+
+func (s *replayStmt) Close() error  { return nil }
+func (s *replayStmt) NumInput() int { return -1 }
+
+func (s *replayStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.conn.ExecContext(context.Background(), s.query, namedValues(args))
+}
+
+func (s *replayStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.conn.QueryContext(context.Background(), s.query, namedValues(args))
+}
+
+type replayTx struct{}
+
+func (replayTx) Commit() error   { return nil }
+func (replayTx) Rollback() error { return nil }
+
+type replayRows struct {
+	e    SQLExchange
+	next int
+}
+
+func (r *replayRows) Columns() []string { return r.e.Columns }
+func (r *replayRows) Close() error      { return nil }
+
+func (r *replayRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.e.Rows) {
+		if r.e.Error != "" {
+			return errors.New(r.e.Error)
+		}
+		return io.EOF
+	}
+	row := r.e.Rows[r.next]
+	r.next++
+	for i := range dest {
+		if i < len(row) {
+			dest[i] = row[i].V
+		}
+	}
+	return nil
+}
+
+func sqlKey(exec bool, query string, args []string) string {
+	kind := "query"
+	if exec {
+		kind = "exec"
+	}
+	return kind + "\n" + query + "\n" + strings.Join(args, "\x00")
+}
+
+func argStrings(args []driver.NamedValue) []string {
+	if len(args) == 0 {
+		return nil
+	}
+	out := make([]string, len(args))
+	for i, a := range args {
+		switch v := a.Value.(type) {
+		case []byte:
+			out[i] = string(v)
+		case time.Time:
+			out[i] = v.Format(time.RFC3339Nano)
+		default:
+			out[i] = fmt.Sprint(v)
+		}
+	}
+	return out
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	out := make([]driver.NamedValue, len(args))
+	for i, v := range args {
+		out[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+	return out
+}
+
+func plainValues(args []driver.NamedValue) []driver.Value {
+	out := make([]driver.Value, len(args))
+	for i, a := range args {
+		out[i] = a.Value
+	}
+	return out
+}
@@ -147,7 +147,13 @@ func (s *Socket) read(process Processor) error {
 
 func (s *Socket) dial() (net.Conn, error) {
 	network, address := parseAddress(s.Address)
+	if hook := dialHook.Load(); hook != nil {
+		return (*hook)(network, address, func() (net.Conn, error) { return s.dialNetwork(network, address) })
+	}
+	return s.dialNetwork(network, address)
+}
 
+func (s *Socket) dialNetwork(network, address string) (net.Conn, error) {
 	var d net.Dialer
 	d.Timeout = s.timeout()
 
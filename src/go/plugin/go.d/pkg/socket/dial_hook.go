@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package socket
+
+import (
+	"net"
+	"sync/atomic"
+)
+
+// DialHook replaces how a Socket connects. It receives the parsed network and
+// address and the dial function the Socket would use otherwise.
+type DialHook func(network, address string, dial func() (net.Conn, error)) (net.Conn, error)
+
+var dialHook atomic.Pointer[DialHook]
+
+// SetDialHook installs fn for every Socket connection made afterwards. A nil fn
+// removes the hook. It exists to record and replay collector traffic and is
+// not meant for regular use.
+func SetDialHook(fn DialHook) {
+	if fn == nil {
+		dialHook.Store(nil)
+		return
+	}
+	dialHook.Store(&fn)
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package sqlquery
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"sync/atomic"
+)
+
+// ConnectorHook wraps the connector of a database opened with Open or OpenDB.
+type ConnectorHook func(driver.Connector) driver.Connector
+
+var connectorHook atomic.Pointer[ConnectorHook]
+
+// SetConnectorHook installs fn for every database opened with Open or OpenDB
+// afterwards. A nil fn removes the hook. It exists to record and replay
+// collector traffic and is not meant for regular use.
+func SetConnectorHook(fn ConnectorHook) {
+	if fn == nil {
+		connectorHook.Store(nil)
+		return
+	}
+	connectorHook.Store(&fn)
+}
+
+// Open is sql.Open for collectors: it applies the connector hook when one is
+// installed.
+func Open(driverName, dsn string) (*sql.DB, error) {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	hook := connectorHook.Load()
+	if hook == nil {
+		return db, nil
+	}
+
+	drv := db.Driver()
+	_ = db.Close()
+
+	var connector driver.Connector = dsnConnector{dsn: dsn, driver: drv}
+	if dc, ok := drv.(driver.DriverContext); ok {
+		if connector, err = dc.OpenConnector(dsn); err != nil {
+			return nil, err
+		}
+	}
+	return sql.OpenDB((*hook)(connector)), nil
+}
+
+// OpenDB is sql.OpenDB for collectors: it applies the connector hook when one
+// is installed.
+func OpenDB(connector driver.Connector) *sql.DB {
+	if hook := connectorHook.Load(); hook != nil {
+		connector = (*hook)(connector)
+	}
+	return sql.OpenDB(connector)
+}
+
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) { return c.driver.Open(c.dsn) }
+func (c dsnConnector) Driver() driver.Driver                         { return c.driver }
@@ -12,17 +12,26 @@ import (
 )
 
 func (c *Collector) collect(ctx context.Context) error {
+	if c.job.config.Passive.Enabled {
+		now := c.now()
+		c.applyPassiveResults(now)
+		c.checkPassiveFreshness(now)
+	}
+
 	execMetrics, err := c.collectIfDue(ctx)
 	if err != nil {
 		return err
 	}
+	if state, ok := c.state.hardState(); ok {
+		c.jobStates.setHardState(c.job.config.Name, state)
+	}
 	c.emitMetrics(execMetrics)
 	return nil
 }
 
 func (c *Collector) collectIfDue(ctx context.Context) (executionMetrics, error) {
 	now := c.now()
-	if !c.state.due(now) {
+	if c.job.config.passiveOnly() || !c.state.due(now) {
 		return executionMetrics{}, nil
 	}
 
@@ -30,6 +39,10 @@ func (c *Collector) collectIfDue(ctx context.Context) (executionMetrics, error)
 		return executionMetrics{}, nil
 	}
 
+	if c.skipUnreachable(now) {
+		return executionMetrics{}, nil
+	}
+
 	res, err := c.executeDueCheck(ctx, now)
 	if err != nil {
 		return executionMetrics{}, err
@@ -48,6 +61,24 @@ func (c *Collector) skipDisallowedPeriod(now time.Time) bool {
 	return true
 }
 
+func (c *Collector) skipUnreachable(now time.Time) bool {
+	parent := c.jobStates.failingParent(c.job.config.Name)
+	if parent == "" {
+		if c.failingParent != "" {
+			c.Infof("job '%s': parent job '%s' recovered, resuming checks", c.job.config.Name, c.failingParent)
+			c.failingParent = ""
+		}
+		return false
+	}
+	if parent != c.failingParent {
+		c.Infof("job '%s': parent job '%s' is failing, suppressing checks", c.job.config.Name, parent)
+		c.failingParent = parent
+	}
+	c.state.recordUnreachable()
+	c.state.scheduleRegular(now, c.state.nextAnniversary, c.job.config.CheckInterval.Duration())
+	return true
+}
+
 func (c *Collector) executeDueCheck(ctx context.Context, now time.Time) (checkRunResult, error) {
 	res, err := c.runner.Run(ctx, checkRunRequest{
 		Job:        c.job.config,
@@ -65,7 +96,9 @@ func (c *Collector) executeDueCheck(ctx context.Context, now time.Time) (checkRu
 }
 
 func (c *Collector) completeDueCheck(now time.Time, res checkRunResult) {
-	c.state.completeRun(now, res.ServiceState, res.JobState, c.router.route(c.job.config.CheckName, res.Parsed.Perfdata), c.job.config)
+	perf := c.router.route(c.job.config.CheckName, res.Parsed.Perfdata)
+	perf.series = c.router.routeSamples(c.job.config.CheckName, res.Samples)
+	c.state.completeRun(now, res.ServiceState, res.JobState, perf, c.job.config)
 }
 
 func (c *Collector) emitMetrics(execMetrics executionMetrics) {
@@ -80,7 +113,7 @@ func (c *Collector) emitMetrics(execMetrics executionMetrics) {
 
 	jobLbl := sm.LabelSet(metrix.Label{Key: "nagios_job", Value: jobName})
 	jobMeter := sm.WithLabelSet(jobLbl)
-	jobStatePoint := projectJobExecutionState(c.state.currentJobState(), c.state.isRetrying())
+	jobStatePoint := projectJobExecutionState(c.state.currentJobState(), c.state.isRetrying(), c.state.isFlapping())
 
 	jobMeter.StateSet(
 		"job.execution_state",
@@ -118,6 +151,21 @@ func (c *Collector) emitMetrics(execMetrics executionMetrics) {
 		).Observe(execMetrics.maxRSSBytes)
 	}
 
+	if c.job.config.FlapDetection.Enabled {
+		jobMeter.Gauge(
+			"job.percent_state_change",
+			metrix.WithUnit("percentage"),
+			metrix.WithFloat(true),
+		).Observe(c.state.percentChange)
+	}
+
+	if c.job.config.Passive.Enabled {
+		jobMeter.Gauge(
+			"job.passive_result_age",
+			metrix.WithUnit("seconds"),
+		).Observe(c.passiveResultAge(c.now()))
+	}
+
 	for _, measureSet := range c.state.perfValueSets() {
 		fields := perfMeasureSetValues(measureSet.value)
 		if measureSet.counter {
@@ -139,6 +187,23 @@ func (c *Collector) emitMetrics(execMetrics executionMetrics) {
 		}
 	}
 
+	for _, series := range c.state.scriptSeries() {
+		meter := jobMeter.WithLabels(series.labels...)
+		if series.counter {
+			meter.Counter(
+				series.name,
+				metrix.WithChartFamily(scriptMetricsFamily(series.checkName)),
+				metrix.WithFloat(true),
+			).ObserveTotal(series.value)
+		} else {
+			meter.Gauge(
+				series.name,
+				metrix.WithChartFamily(scriptMetricsFamily(series.checkName)),
+				metrix.WithFloat(true),
+			).Observe(series.value)
+		}
+	}
+
 	for _, thresholdState := range c.state.perfThresholdStates() {
 		inst := jobMeter.StateSet(
 			thresholdState.name,
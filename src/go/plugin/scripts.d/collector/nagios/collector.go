@@ -11,6 +11,7 @@ import (
 	"github.com/netdata/netdata/go/plugins/plugin/framework/collectorapi"
 	"github.com/netdata/netdata/go/plugins/plugin/framework/vnodes"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/pathvalidate"
+	"github.com/netdata/netdata/go/plugins/plugin/scripts.d/collector/nagios/internal/passive"
 	"github.com/netdata/netdata/go/plugins/plugin/scripts.d/pkg/timeperiod"
 )
 
@@ -28,6 +29,7 @@ func init() {
 		},
 		CreateV2: func() collectorapi.CollectorV2 { return New() },
 		Config:   func() any { return &Config{} },
+		Import:   importConfig,
 	})
 }
 
@@ -46,15 +48,21 @@ type Collector struct {
 	collectorapi.Base
 	Config `yaml:",inline" json:",inline"`
 
-	store          metrix.CollectorStore
-	router         *perfdataRouter
-	runner         checkRunner
-	validatePlugin func(string) (string, error)
-	now            func() time.Time
-	vnode          vnodes.VirtualNode
+	store            metrix.CollectorStore
+	router           *perfdataRouter
+	runner           checkRunner
+	validatePlugin   func(string) (string, error)
+	subscribePassive subscribePassiveFunc
+	jobStates        *jobStateRegistry
+	now              func() time.Time
+	vnode            vnodes.VirtualNode
 
-	job   compiledJob
-	state collectState
+	job     compiledJob
+	state   collectState
+	passive passiveIntake
+
+	unregisterJob func()
+	failingParent string
 
 	cadenceWarning string
 }
@@ -65,11 +73,13 @@ func New() *Collector {
 			UpdateEvery: defaultCollectorUpdateEvery,
 			JobConfig:   defaultedJobConfig(JobConfig{}),
 		},
-		store:          metrix.NewCollectorStore(),
-		router:         newPerfdataRouter(defaultPerfdataMetricKeyBudget),
-		runner:         systemCheckRunner{},
-		validatePlugin: pathvalidate.ValidateBinaryPath,
-		now:            time.Now,
+		store:            metrix.NewCollectorStore(),
+		router:           newPerfdataRouter(defaultPerfdataMetricKeyBudget),
+		runner:           systemCheckRunner{},
+		validatePlugin:   pathvalidate.ValidateBinaryPath,
+		subscribePassive: passive.Subscribe,
+		jobStates:        sharedJobStates,
+		now:              time.Now,
 	}
 }
 
@@ -83,7 +93,13 @@ func (c *Collector) Check(context.Context) error { return c.checkCollector() }
 
 func (c *Collector) Collect(ctx context.Context) error { return c.collect(ctx) }
 
-func (c *Collector) Cleanup(context.Context) {}
+func (c *Collector) Cleanup(context.Context) {
+	c.stopPassive()
+	if c.unregisterJob != nil {
+		c.unregisterJob()
+		c.unregisterJob = nil
+	}
+}
 
 func (c *Collector) MetricStore() metrix.CollectorStore { return c.store }
 
@@ -6,9 +6,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"math"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -21,6 +23,8 @@ import (
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/collecttest"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/ndexec"
 	"github.com/netdata/netdata/go/plugins/plugin/scripts.d/collector/nagios/internal/output"
+	"github.com/netdata/netdata/go/plugins/plugin/scripts.d/collector/nagios/internal/passive"
+	"github.com/netdata/netdata/go/plugins/plugin/scripts.d/collector/nagios/internal/textmetrics"
 	"github.com/netdata/netdata/go/plugins/plugin/scripts.d/pkg/timeperiod"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -1158,11 +1162,368 @@ func envSliceToMap(env []string) map[string]string {
 	return out
 }
 
+func TestSystemCheckRunner_OutputFormats(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh scripts")
+	}
+
+	tests := map[string]struct {
+		format    string
+		output    string
+		wantState string
+		wantLen   int
+	}{
+		"influx": {
+			format:    outputFormatInflux,
+			output:    "backup,target=db size=12i,duration=1.5",
+			wantState: nagiosStateOK,
+			wantLen:   2,
+		},
+		"prometheus": {
+			format:    outputFormatPrometheus,
+			output:    "# TYPE backup_runs_total counter\nbackup_runs_total 3",
+			wantState: nagiosStateOK,
+			wantLen:   1,
+		},
+		"unparsable output is unknown": {
+			format:    outputFormatPrometheus,
+			output:    "backup_runs_total{ 3",
+			wantState: nagiosStateUnknown,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			scriptPath := filepath.Join(t.TempDir(), "metrics.sh")
+			writeExecutable(t, scriptPath, "#!/bin/sh\nprintf '"+tc.output+"\\n'\n")
+
+			result, err := systemCheckRunner{}.Run(context.Background(), checkRunRequest{
+				Job: JobConfig{
+					Name:         "metrics",
+					Plugin:       scriptPath,
+					Timeout:      confDuration(5 * time.Second),
+					OutputFormat: tc.format,
+				},
+				Now: time.Date(2026, 3, 22, 12, 0, 0, 0, time.UTC),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantState, result.ServiceState)
+			assert.Len(t, result.Samples, tc.wantLen)
+			assert.Empty(t, result.Parsed.StatusLine())
+		})
+	}
+}
+
+func TestCollector_ScriptMetrics(t *testing.T) {
+	pluginPath := writeTestPluginFile(t, "backup_metrics")
+
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	runner := &fakeRunner{results: []fakeRun{{result: checkRunResult{
+		ServiceState: "OK",
+		JobState:     "OK",
+		Samples: []textmetrics.Sample{
+			{Name: "backup_size", Labels: []textmetrics.Label{{Key: "target", Value: "db"}}, Value: 12},
+			{Name: "backup_runs_total", Value: 3, Counter: true},
+			{Name: "backup_size", Labels: []textmetrics.Label{{Key: "target", Value: "db"}}, Value: 99},
+		},
+	}}}}
+	coll := newTestCollector()
+	coll.Config = Config{UpdateEvery: 1, JobConfig: JobConfig{
+		Name:          "backup",
+		Plugin:        pluginPath,
+		OutputFormat:  outputFormatInflux,
+		CheckInterval: confDuration(time.Minute),
+	}}
+	coll.runner = runner
+	coll.now = func() time.Time { return now }
+	require.NoError(t, coll.Init(context.Background()))
+	defer coll.Cleanup(context.Background())
+
+	runCollectCycle(t, coll)
+	now = now.Add(time.Second)
+	runCollectCycle(t, coll)
+	assert.Equal(t, 1, runner.calls)
+
+	flat := coll.MetricStore().Read(metrix.ReadFlatten())
+	assertMetricValue(t, flat, "job.execution_state", metrix.Labels{"nagios_job": "backup", "job.execution_state": "ok"}, 1)
+	assertMetricValue(t, flat, "metrics.backup_metrics.backup_size", metrix.Labels{"nagios_job": "backup", "target": "db"}, 12)
+	assertMetricChartFamily(t, flat, "metrics.backup_metrics.backup_size", "Metrics/backup_metrics")
+	_, ok := coll.MetricStore().Read(metrix.ReadRaw()).Value("metrics.backup_metrics.backup_runs_total", metrix.Labels{"nagios_job": "backup"})
+	assert.True(t, ok)
+}
+
+func TestPerfdataRouter_RouteSamplesBudget(t *testing.T) {
+	router := newPerfdataRouter(0)
+	router.maxSeriesPerJob = 2
+
+	series := router.routeSamples("check", []textmetrics.Sample{
+		{Name: "c", Value: 1},
+		{Name: "a", Value: 1},
+		{Name: "b", Value: math.NaN()},
+		{Name: "b", Labels: []textmetrics.Label{{Key: "nagios_job", Value: "x"}, {Key: "k", Value: "v"}}, Value: 1},
+	})
+	require.Len(t, series, 2)
+	assert.Equal(t, "metrics.check.a", series[0].name)
+	assert.Equal(t, "metrics.check.b", series[1].name)
+	assert.Equal(t, []metrix.Label{{Key: "k", Value: "v"}}, series[1].labels)
+}
+
 type fakeRun struct {
 	result checkRunResult
 	err    error
 }
 
+func TestCollector_Passive(t *testing.T) {
+	truePluginPath := writeTestPluginFile(t, "true")
+
+	tests := map[string]struct {
+		config JobConfig
+		run    func(*testing.T, *Collector, *fakeRunner, *time.Time, func(passive.Result))
+	}{
+		"passive results drive state and perfdata": {
+			config: JobConfig{
+				Name:    "backup",
+				Passive: PassiveConfig{Enabled: true, Listen: "127.0.0.1:5668"},
+			},
+			run: func(t *testing.T, coll *Collector, runner *fakeRunner, now *time.Time, deliver func(passive.Result)) {
+				deliver(passive.Result{Service: "backup", ReturnCode: 0, Output: "OK | used=10B;20;30", CheckTime: now.Add(-2 * time.Second)})
+				deliver(passive.Result{Service: "backup", ReturnCode: 1, Output: "WARNING | used=25B;20;30", CheckTime: now.Add(-time.Second)})
+				runCollectCycle(t, coll)
+
+				assert.Zero(t, runner.calls)
+				assert.Equal(t, nagiosStateWarning, coll.state.currentServiceState())
+				flat := coll.MetricStore().Read(metrix.ReadFlatten())
+				assertMetricValue(t, flat, "job.execution_state", metrix.Labels{"nagios_job": "backup", "job.execution_state": "warning"}, 1)
+				assertMetricValue(t, flat, "perfdata.backup.bytes_used_value", metrix.Labels{"nagios_job": "backup", metrix.MeasureSetFieldLabel: "value"}, 25)
+				assertMetricValue(t, flat, "job.passive_result_age", metrix.Labels{"nagios_job": "backup"}, 1)
+
+				deliver(passive.Result{Service: "backup", ReturnCode: 2, Output: "CRITICAL", CheckTime: now.Add(-time.Minute)})
+				runCollectCycle(t, coll)
+				assert.Equal(t, nagiosStateWarning, coll.state.currentServiceState(), "out-of-order result is dropped")
+			},
+		},
+		"stale passive-only job goes unknown": {
+			config: JobConfig{
+				Name:             "backup",
+				MaxCheckAttempts: 2,
+				Passive:          PassiveConfig{Enabled: true, CommandFile: "/tmp/nagios.cmd", FreshnessThreshold: confDuration(time.Hour)},
+			},
+			run: func(t *testing.T, coll *Collector, _ *fakeRunner, now *time.Time, deliver func(passive.Result)) {
+				deliver(passive.Result{Service: "backup", ReturnCode: 0, Output: "OK", CheckTime: *now})
+				runCollectCycle(t, coll)
+				assert.Equal(t, nagiosStateOK, coll.state.currentServiceState())
+
+				*now = now.Add(59 * time.Minute)
+				runCollectCycle(t, coll)
+				assert.Equal(t, nagiosStateOK, coll.state.currentServiceState())
+
+				*now = now.Add(time.Minute)
+				runCollectCycle(t, coll)
+				assert.Equal(t, nagiosStateUnknown, coll.state.currentServiceState())
+				assert.True(t, coll.state.isRetrying(), "first stale interval is a soft state")
+
+				*now = now.Add(time.Hour)
+				runCollectCycle(t, coll)
+				assert.False(t, coll.state.isRetrying(), "second stale interval is a hard state")
+			},
+		},
+		"stale job with plugin runs an active check": {
+			config: JobConfig{
+				Name:          "backup",
+				Plugin:        truePluginPath,
+				CheckInterval: confDuration(24 * time.Hour),
+				Passive:       PassiveConfig{Enabled: true, Listen: "127.0.0.1:5668", FreshnessThreshold: confDuration(time.Hour)},
+			},
+			run: func(t *testing.T, coll *Collector, runner *fakeRunner, now *time.Time, deliver func(passive.Result)) {
+				runCollectCycle(t, coll)
+				assert.Equal(t, 1, runner.calls)
+
+				*now = now.Add(30 * time.Minute)
+				deliver(passive.Result{Service: "backup", ReturnCode: 2, Output: "CRITICAL", CheckTime: *now})
+				runCollectCycle(t, coll)
+				assert.Equal(t, 1, runner.calls)
+				assert.Equal(t, nagiosStateCritical, coll.state.currentServiceState())
+
+				*now = now.Add(time.Hour)
+				runCollectCycle(t, coll)
+				assert.Equal(t, 2, runner.calls)
+				assert.Equal(t, nagiosStateOK, coll.state.currentServiceState())
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+			runner := &fakeRunner{results: []fakeRun{
+				{result: checkRunResult{ServiceState: "OK", JobState: "OK"}},
+				{result: checkRunResult{ServiceState: "OK", JobState: "OK"}},
+			}}
+
+			var deliver func(passive.Result)
+			var cancelled bool
+			coll := newTestCollector()
+			coll.Config = Config{UpdateEvery: 1, JobConfig: tc.config}
+			coll.runner = runner
+			coll.now = func() time.Time { return now }
+			coll.subscribePassive = func(_ passive.Endpoints, _, service string, fn func(passive.Result)) (func(), error) {
+				assert.Equal(t, "backup", service)
+				deliver = fn
+				return func() { cancelled = true }, nil
+			}
+
+			require.NoError(t, coll.Init(context.Background()))
+			require.NotNil(t, deliver)
+			tc.run(t, coll, runner, &now, deliver)
+
+			coll.Cleanup(context.Background())
+			assert.True(t, cancelled)
+		})
+	}
+}
+
+func TestCollector_Dependencies(t *testing.T) {
+	hostPluginPath := writeTestPluginFile(t, "check_ping")
+	webPluginPath := writeTestPluginFile(t, "check_http")
+
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	states := newJobStateRegistry()
+	newJob := func(cfg JobConfig, results ...string) (*Collector, *fakeRunner) {
+		runner := &fakeRunner{}
+		for _, state := range results {
+			runner.results = append(runner.results, fakeRun{result: checkRunResult{ServiceState: state, JobState: state}})
+		}
+		coll := newTestCollector()
+		coll.Config = Config{UpdateEvery: 1, JobConfig: cfg}
+		coll.runner = runner
+		coll.jobStates = states
+		coll.now = func() time.Time { return now }
+		require.NoError(t, coll.Init(context.Background()))
+		t.Cleanup(func() { coll.Cleanup(context.Background()) })
+		return coll, runner
+	}
+
+	host, _ := newJob(JobConfig{
+		Name:             "host",
+		Plugin:           hostPluginPath,
+		MaxCheckAttempts: 2,
+		CheckInterval:    confDuration(time.Minute),
+		RetryInterval:    confDuration(time.Minute),
+	}, "CRITICAL", "CRITICAL", "OK")
+	web, webRunner := newJob(JobConfig{
+		Name:          "web",
+		Plugin:        webPluginPath,
+		CheckInterval: confDuration(time.Minute),
+		DependsOn:     DependencyConfig{Parents: []string{"host"}},
+	}, "OK", "OK")
+
+	runCollectCycle(t, host)
+	runCollectCycle(t, web)
+	assert.Equal(t, 1, webRunner.calls, "soft parent state does not suppress children")
+
+	now = now.Add(time.Minute)
+	runCollectCycle(t, host)
+	runCollectCycle(t, web)
+	assert.Equal(t, 1, webRunner.calls)
+	flat := web.MetricStore().Read(metrix.ReadFlatten())
+	assertMetricValue(t, flat, "job.execution_state", metrix.Labels{"nagios_job": "web", "job.execution_state": "unreachable"}, 1)
+	assertMetricValue(t, flat, "job.execution_state", metrix.Labels{"nagios_job": "web", "job.execution_state": "critical"}, 0)
+
+	now = now.Add(time.Minute)
+	runCollectCycle(t, host)
+	runCollectCycle(t, web)
+	assert.Equal(t, 2, webRunner.calls)
+	flat = web.MetricStore().Read(metrix.ReadFlatten())
+	assertMetricValue(t, flat, "job.execution_state", metrix.Labels{"nagios_job": "web", "job.execution_state": "ok"}, 1)
+}
+
+func TestJobStateRegistry_FailingParent(t *testing.T) {
+	states := newJobStateRegistry()
+	states.register("router", DependencyConfig{Parents: []string{"web"}, FailureStates: []string{nagiosStateCritical}})
+	states.register("host", DependencyConfig{Parents: []string{"router"}, FailureStates: []string{nagiosStateCritical}})
+	states.register("web", DependencyConfig{Parents: []string{"host", "missing"}, FailureStates: []string{nagiosStateCritical}})
+
+	assert.Empty(t, states.failingParent("web"), "dependency cycles and unknown parents do not fail")
+
+	states.setHardState("router", nagiosStateCritical)
+	assert.Equal(t, "router", states.failingParent("host"))
+	assert.Equal(t, "host", states.failingParent("web"), "unreachable parents propagate")
+
+	unregister := states.register("other", DependencyConfig{})
+	states.register("other", DependencyConfig{Parents: []string{"router"}, FailureStates: []string{nagiosStateCritical}})
+	unregister()
+	assert.Equal(t, "router", states.failingParent("other"), "stale unregister keeps the newer job")
+}
+
+func TestCollector_FlapDetection(t *testing.T) {
+	pluginPath := writeTestPluginFile(t, "check_link")
+
+	runner := &fakeRunner{}
+	for i := 0; i < 6; i++ {
+		state := "OK"
+		if i%2 == 1 {
+			state = "CRITICAL"
+		}
+		runner.results = append(runner.results, fakeRun{result: checkRunResult{ServiceState: state, JobState: state}})
+	}
+
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	coll := newTestCollector()
+	coll.Config = Config{UpdateEvery: 1, JobConfig: JobConfig{
+		Name:             "link",
+		Plugin:           pluginPath,
+		MaxCheckAttempts: 1,
+		CheckInterval:    confDuration(time.Minute),
+		FlapDetection:    FlapConfig{Enabled: true},
+	}}
+	coll.runner = runner
+	coll.now = func() time.Time { return now }
+	require.NoError(t, coll.Init(context.Background()))
+	defer coll.Cleanup(context.Background())
+
+	for i := 0; i < 5; i++ {
+		runCollectCycle(t, coll)
+		now = now.Add(time.Minute)
+	}
+	assert.False(t, coll.state.isFlapping())
+
+	runCollectCycle(t, coll)
+	assert.True(t, coll.state.isFlapping())
+	flat := coll.MetricStore().Read(metrix.ReadFlatten())
+	assertMetricValue(t, flat, "job.execution_state", metrix.Labels{"nagios_job": "link", "job.execution_state": "critical"}, 1)
+	assertMetricValue(t, flat, "job.execution_state", metrix.Labels{"nagios_job": "link", "job.execution_state": "flapping"}, 1)
+	assertMetricValue(t, flat, "job.percent_state_change", metrix.Labels{"nagios_job": "link"}, percentStateChange([]string{"OK", "CRITICAL", "OK", "CRITICAL", "OK", "CRITICAL"}))
+}
+
+func TestPercentStateChange(t *testing.T) {
+	tests := map[string]struct {
+		history []string
+		want    float64
+	}{
+		"no changes":  {history: []string{"OK", "OK", "OK"}, want: 0},
+		"one oldest":  {history: []string{"OK", "CRITICAL", "CRITICAL"}, want: 3.75},
+		"one newest":  {history: append(slices.Repeat([]string{"OK"}, 20), "CRITICAL"), want: 6.25},
+		"all changes": {history: alternatingStates(21), want: 100},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.InDelta(t, tc.want, percentStateChange(tc.history), 1e-9)
+		})
+	}
+}
+
+func alternatingStates(n int) []string {
+	states := make([]string, n)
+	for i := range states {
+		states[i] = nagiosStateOK
+		if i%2 == 1 {
+			states[i] = nagiosStateCritical
+		}
+	}
+	return states
+}
+
 type fakeRunner struct {
 	results []fakeRun
 	reqs    []checkRunRequest
@@ -1227,6 +1588,7 @@ func writeTestPluginFile(t *testing.T, name string) string {
 func newTestCollector() *Collector {
 	coll := New()
 	coll.validatePlugin = func(path string) (string, error) { return path, nil }
+	coll.jobStates = newJobStateRegistry()
 	return coll
 }
 
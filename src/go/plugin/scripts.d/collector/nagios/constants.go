@@ -9,6 +9,9 @@ const (
 	nagiosStateUnknown  = "UNKNOWN"
 	jobStateTimeout     = "TIMEOUT"
 	jobStatePaused      = "PAUSED"
+	jobStateUnreachable = "UNREACHABLE"
+
+	flapHistorySize = 21
 
 	nagiosHostStateUp   = "UP"
 	nagiosHostStateUpID = "0"
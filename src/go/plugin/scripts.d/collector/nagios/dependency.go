@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package nagios
+
+import (
+	"slices"
+	"sync"
+)
+
+// sharedJobStates lets nagios jobs of the same plugin process see each
+// other's hard states, so a child can be suppressed while its parent fails.
+var sharedJobStates = newJobStateRegistry()
+
+type jobStateRegistry struct {
+	mu   sync.RWMutex
+	jobs map[string]*jobStateEntry
+}
+
+type jobStateEntry struct {
+	parents       []string
+	failureStates []string
+	hardState     string
+}
+
+func newJobStateRegistry() *jobStateRegistry {
+	return &jobStateRegistry{jobs: make(map[string]*jobStateEntry)}
+}
+
+// register adds a job and returns the function that removes it. A later
+// registration under the same name replaces the earlier one, whose removal
+// then becomes a no-op.
+func (r *jobStateRegistry) register(name string, deps DependencyConfig) func() {
+	entry := &jobStateEntry{
+		parents:       deps.Parents,
+		failureStates: deps.FailureStates,
+	}
+
+	r.mu.Lock()
+	r.jobs[name] = entry
+	r.mu.Unlock()
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.jobs[name] == entry {
+			delete(r.jobs, name)
+		}
+	}
+}
+
+func (r *jobStateRegistry) setHardState(name, state string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if entry := r.jobs[name]; entry != nil {
+		entry.hardState = state
+	}
+}
+
+// failingParent returns the parent that makes the job unreachable: one whose
+// hard state is in the job's failure states, or one that is unreachable itself.
+// Parents that are not running or have no hard state yet never count.
+func (r *jobStateRegistry) failingParent(name string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.failingParentLocked(name, map[string]bool{name: true})
+}
+
+func (r *jobStateRegistry) failingParentLocked(name string, visited map[string]bool) string {
+	entry := r.jobs[name]
+	if entry == nil {
+		return ""
+	}
+	for _, parent := range entry.parents {
+		if visited[parent] {
+			continue
+		}
+		visited[parent] = true
+
+		pe := r.jobs[parent]
+		if pe == nil {
+			continue
+		}
+		if slices.Contains(entry.failureStates, pe.hardState) || r.failingParentLocked(parent, visited) != "" {
+			return parent
+		}
+	}
+	return ""
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package nagios
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/netdata/netdata/go/plugins/pkg/confopt"
+	"github.com/netdata/netdata/go/plugins/plugin/scripts.d/collector/nagios/internal/objconf"
+	"github.com/netdata/netdata/go/plugins/plugin/scripts.d/pkg/timeperiod"
+)
+
+var reJobNameInvalid = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// importConfig converts Nagios object configuration or Icinga 2 configuration
+// into nagios jobs, rendered as a YAML 'jobs' list. Whatever could not be
+// carried over is listed as comments at the top.
+func importConfig(sources ...[]byte) ([]byte, error) {
+	res, err := objconf.Parse(sources...)
+	if err != nil {
+		return nil, err
+	}
+
+	periods := make(map[string]timeperiod.Config, len(res.TimePeriods))
+	for _, p := range res.TimePeriods {
+		periods[p.Name] = p
+	}
+
+	seen := make(map[string]int)
+	jobs := make([]Config, 0, len(res.Checks))
+	for _, chk := range res.Checks {
+		cfg := Config{
+			JobConfig: JobConfig{
+				Name:             importedJobName(seen, chk.Host, chk.Service),
+				CheckName:        chk.Service,
+				Plugin:           chk.Plugin,
+				Args:             chk.Args,
+				ArgValues:        chk.ArgValues,
+				CustomVars:       chk.CustomVars,
+				Timeout:          confopt.Duration(chk.Timeout),
+				CheckInterval:    confopt.Duration(chk.CheckInterval),
+				RetryInterval:    confopt.Duration(chk.RetryInterval),
+				MaxCheckAttempts: chk.MaxCheckAttempts,
+				CheckPeriod:      chk.CheckPeriod,
+			},
+			TimePeriods: jobTimePeriods(periods, chk.CheckPeriod),
+			Notes:       "imported from " + chk.Origin,
+		}
+		if chk.Passive {
+			cfg.Passive = PassiveConfig{
+				Enabled:            true,
+				Host:               chk.Host,
+				Service:            chk.Service,
+				FreshnessThreshold: confopt.Duration(chk.FreshnessThreshold),
+				CommandFile:        chk.CommandFile,
+			}
+		}
+		jobs = append(jobs, cfg)
+	}
+
+	bs, err := yaml.Marshal(struct {
+		Jobs []Config `yaml:"jobs"`
+	}{jobs})
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for _, w := range res.Warnings {
+		fmt.Fprintf(&buf, "# warning: %s\n", strings.ReplaceAll(w, "\n", " "))
+	}
+	buf.Write(bs)
+	return buf.Bytes(), nil
+}
+
+// importedJobName builds a job name from the host and service, made unique
+// within one import.
+func importedJobName(seen map[string]int, host, service string) string {
+	base := strings.Trim(reJobNameInvalid.ReplaceAllString(strings.ToLower(host+"_"+service), "_"), "_")
+	if base == "" {
+		base = "check"
+	}
+	// A suffixed name can be the plain name of another check ("a"/"b_2"), so probe until unused.
+	name := base
+	for n := 2; seen[name] > 0; n++ {
+		name = fmt.Sprintf("%s_%d", base, n)
+	}
+	seen[name]++
+	return name
+}
+
+// jobTimePeriods returns the definition of the check period and of every
+// period it excludes, directly or not, since each job carries its own.
+func jobTimePeriods(periods map[string]timeperiod.Config, name string) []timeperiod.Config {
+	var out []timeperiod.Config
+	seen := make(map[string]bool)
+	pending := []string{name}
+	for len(pending) > 0 {
+		name, pending = pending[0], pending[1:]
+		p, ok := periods[name]
+		if !ok || seen[name] {
+			continue
+		}
+		seen[name] = true
+		out = append(out, p)
+		pending = append(pending, p.Exclude...)
+	}
+	return out
+}
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package nagios
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"github.com/netdata/netdata/go/plugins/plugin/scripts.d/pkg/timeperiod"
+)
+
+func TestImportConfig(t *testing.T) {
+	objects := `
+$USER1$=/usr/lib/nagios/plugins
+define command {
+    command_name check_disk
+    command_line $USER1$/check_disk -w $ARG1$ -c $ARG2$ -p /
+}
+define timeperiod {
+    timeperiod_name workhours
+    monday          09:00-17:00
+    exclude         holidays
+}
+define timeperiod {
+    timeperiod_name holidays
+    2026-12-25      00:00-24:00
+}
+define host {
+    host_name web.example.com
+}
+define host {
+    host_name web-example-com
+}
+define service {
+    host_name           web.example.com,web-example-com
+    service_description Disk Root
+    check_command       check_disk!20%!10%
+    check_interval      5
+    check_period        workhours
+}
+define service {
+    host_name              web.example.com
+    service_description    Backup
+    active_checks_enabled  0
+}
+`
+	out, err := importConfig([]byte(objects))
+	require.NoError(t, err)
+
+	text := string(out)
+	assert.True(t, strings.HasPrefix(text, "# warning: nagios service 'Backup' on host 'web.example.com': passive checks need"), text)
+
+	var doc struct {
+		Jobs []Config `yaml:"jobs"`
+	}
+	require.NoError(t, yaml.Unmarshal(out, &doc))
+	require.Len(t, doc.Jobs, 3)
+
+	assert.Equal(t, []string{"web_example_com_backup", "web-example-com_disk_root", "web_example_com_disk_root"},
+		[]string{doc.Jobs[0].Name, doc.Jobs[1].Name, doc.Jobs[2].Name})
+
+	backup := doc.Jobs[0]
+	assert.Empty(t, backup.Plugin)
+	assert.Equal(t, PassiveConfig{Enabled: true, Host: "web.example.com", Service: "Backup"}, backup.Passive)
+
+	disk := doc.Jobs[2]
+	assert.Equal(t, "Disk Root", disk.CheckName)
+	assert.Equal(t, "/usr/lib/nagios/plugins/check_disk", disk.Plugin)
+	assert.Equal(t, []string{"-w", "$ARG1$", "-c", "$ARG2$", "-p", "/"}, disk.Args)
+	assert.Equal(t, []string{"20%", "10%"}, disk.ArgValues)
+	assert.Equal(t, 5*time.Minute, disk.CheckInterval.Duration())
+	assert.Equal(t, "workhours", disk.CheckPeriod)
+	assert.Equal(t, []string{"workhours", "holidays"}, []string{disk.TimePeriods[0].Name, disk.TimePeriods[1].Name})
+	assert.Equal(t, "imported from nagios service 'Disk Root' on host 'web.example.com'", disk.Notes)
+
+	for _, job := range doc.Jobs[1:] {
+		_, err := job.JobConfig.normalized()
+		assert.NoError(t, err, job.Name)
+		_, err = timeperiod.Compile(job.TimePeriods)
+		assert.NoError(t, err, job.Name)
+	}
+}
+
+func Test_importedJobName(t *testing.T) {
+	seen := make(map[string]int)
+
+	assert.Equal(t, "a_b", importedJobName(seen, "a", "b"))
+	assert.Equal(t, "a_b_2", importedJobName(seen, "a", "b_2"))
+	assert.Equal(t, "a_b_3", importedJobName(seen, "a", "b"))
+	assert.Equal(t, "a_b_2_2", importedJobName(seen, "a", "b_2"))
+	assert.Equal(t, "check", importedJobName(seen, "", "!"))
+	assert.Equal(t, "check_2", importedJobName(seen, "", ""))
+}
+
+func TestImportConfig_Error(t *testing.T) {
+	_, err := importConfig([]byte("this is not an object configuration\n"))
+	assert.Error(t, err)
+}
@@ -17,6 +17,14 @@ func (c *Collector) initCollector() error {
 	}
 	c.job = job
 	c.state = newCollectState(c.now(), job.config)
+	c.passive = passiveIntake{}
+	if err := c.startPassive(); err != nil {
+		return err
+	}
+	if c.unregisterJob != nil {
+		c.unregisterJob()
+	}
+	c.unregisterJob = c.jobStates.register(job.config.Name, job.config.DependsOn)
 	return nil
 }
 
@@ -30,6 +38,10 @@ func (c *Collector) compileConfiguredJob() (compiledJob, error) {
 	if err != nil {
 		return compiledJob{}, err
 	}
+	if job.config.passiveOnly() {
+		c.warnCadenceResolution(job)
+		return job, nil
+	}
 
 	pluginPath, args, err := rewriteScriptCommand(job.config.Plugin, job.config.Args)
 	if err != nil {
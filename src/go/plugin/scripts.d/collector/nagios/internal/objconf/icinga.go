@@ -0,0 +1,902 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package objconf
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/scripts.d/pkg/timeperiod"
+)
+
+const (
+	icingaDefaultCheckInterval = 5 * time.Minute
+	icingaDefaultRetryInterval = time.Minute
+	icingaDefaultMaxAttempts   = 3
+	icingaDefaultTimeout       = 60 * time.Second
+	icingaMaxMacroDepth        = 8
+	icingaMaxImportDepth       = 32
+)
+
+// icingaDefaultConsts are the plugin directories of a stock installation
+// (constants.conf); const definitions in the sources override them.
+var icingaDefaultConsts = map[string]any{
+	"PluginDir":          "/usr/lib/nagios/plugins",
+	"PluginContribDir":   "/usr/lib/nagios/plugins",
+	"ManubulonPluginDir": "/usr/lib/nagios/plugins",
+}
+
+// icingaITLTemplates stand in for Icinga Template Library templates that are
+// imported but not part of the sources. Only check related attributes are set.
+var icingaITLTemplates = map[string][]icingaAssign{
+	"plugin-check-command": nil,
+	"generic-host":         nil,
+	"legacy-timeperiod":    nil,
+	"generic-service": {
+		{path: []string{"max_check_attempts"}, op: "=", value: 5.0},
+		{path: []string{"check_interval"}, op: "=", value: 60.0},
+		{path: []string{"retry_interval"}, op: "=", value: 30.0},
+	},
+	"ipv4-or-ipv6": icingaCheckAddress,
+}
+
+// icingaCheckAddress replaces the ITL lambda that picks the IPv4 or IPv6
+// host address; most ITL check commands use it.
+var icingaCheckAddress = []icingaAssign{
+	{path: []string{"vars", "check_address"}, op: "=", value: "$address$"},
+}
+
+var errIcingaUndefinedMacro = errors.New("undefined macro")
+
+type icingaConfig struct {
+	res       *Result
+	consts    map[string]any
+	objects   []*icingaObject
+	templates map[string]*icingaObject // type/name -> template
+	warned    map[*icingaObject]bool
+}
+
+type icingaHost struct {
+	name  string
+	attrs *icingaDict
+}
+
+func parseIcinga(res *Result, sources [][]byte) error {
+	cfg := &icingaConfig{
+		res:       res,
+		consts:    make(map[string]any),
+		templates: make(map[string]*icingaObject),
+		warned:    make(map[*icingaObject]bool),
+	}
+	for k, v := range icingaDefaultConsts {
+		cfg.consts[k] = v
+	}
+
+	for i, src := range sources {
+		toks, err := lexIcinga(string(src))
+		if err != nil {
+			return fmt.Errorf("source %d: %w", i+1, err)
+		}
+		p := &icingaParser{toks: toks, consts: cfg.consts}
+		p.parseFile(cfg.add, func(line int, err error) {
+			res.warnf("icinga2: source %d: line %d: %v, skipping", i+1, line, err)
+		})
+	}
+	cfg.resolve()
+	return nil
+}
+
+func (c *icingaConfig) add(o *icingaObject) {
+	if o.kind == "template" {
+		c.templates[o.typ+"/"+o.name] = o
+		return
+	}
+	c.objects = append(c.objects, o)
+}
+
+// attrs evaluates an object: its imports first, then its own body, as Icinga 2
+// does. problems collects the skipped statements of the object and its
+// templates.
+func (c *icingaConfig) attrs(o *icingaObject) (*icingaDict, []string) {
+	d := newIcingaDict()
+	var problems []string
+	c.execute(o, d, 0, &problems)
+	return d, problems
+}
+
+func (c *icingaConfig) execute(o *icingaObject, d *icingaDict, depth int, problems *[]string) {
+	if depth > icingaMaxImportDepth {
+		*problems = append(*problems, "template import loop")
+		return
+	}
+	*problems = append(*problems, o.problems...)
+
+	for _, name := range o.imports {
+		if t, ok := c.templates[o.typ+"/"+name]; ok {
+			c.execute(t, d, depth+1, problems)
+			if name == "ipv4-or-ipv6" {
+				c.run(icingaCheckAddress, d, problems)
+			}
+			continue
+		}
+		ops, ok := icingaITLTemplates[name]
+		if !ok {
+			*problems = append(*problems, fmt.Sprintf("imported template '%s' is not defined", name))
+			continue
+		}
+		c.run(ops, d, problems)
+	}
+	c.run(o.ops, d, problems)
+}
+
+func (c *icingaConfig) run(ops []icingaAssign, d *icingaDict, problems *[]string) {
+	for _, op := range ops {
+		if err := d.apply(op.path, op.op, op.value); err != nil {
+			*problems = append(*problems, err.Error())
+		}
+	}
+}
+
+func (c *icingaConfig) warnProblems(o *icingaObject, problems []string) {
+	if c.warned[o] {
+		return
+	}
+	c.warned[o] = true
+	for _, p := range problems {
+		c.res.warnf("icinga2: %s '%s': %s", o.typ, o.name, p)
+	}
+}
+
+func (c *icingaConfig) objectsOf(typ string) []*icingaObject {
+	var out []*icingaObject
+	for _, o := range c.objects {
+		if o.typ == typ {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+func (c *icingaConfig) resolve() {
+	hosts := make(map[string]*icingaHost)
+	var hostList []*icingaHost
+	for _, o := range c.objectsOf("Host") {
+		if o.kind != "object" {
+			continue
+		}
+		attrs, _ := c.attrs(o)
+		if _, ok := attrs.vals["display_name"]; !ok {
+			attrs.set("display_name", o.name)
+		}
+		h := &icingaHost{name: o.name, attrs: attrs}
+		hosts[o.name] = h
+		hostList = append(hostList, h)
+	}
+	slices.SortFunc(hostList, func(a, b *icingaHost) int { return strings.Compare(a.name, b.name) })
+	c.assignHostgroups(hostList)
+
+	commands := make(map[string]*icingaObject)
+	for _, o := range c.objectsOf("CheckCommand") {
+		if o.kind == "object" {
+			commands[o.name] = o
+		}
+	}
+
+	periods := make(map[string]periodDef)
+	for _, o := range c.objectsOf("TimePeriod") {
+		if o.kind == "object" {
+			periods[o.name] = c.timeperiod(o)
+		}
+	}
+	usedPeriods := make(map[string]bool)
+
+	addCheck := func(o *icingaObject, svc *icingaDict, problems []string, h *icingaHost) {
+		c.warnProblems(o, problems)
+		origin := fmt.Sprintf("icinga2 service '%s' on host '%s'", o.name, h.name)
+		chk, ok := c.serviceCheck(origin, o.name, svc, h, commands)
+		if !ok {
+			return
+		}
+		if chk.CheckPeriod != "" && chk.CheckPeriod != timeperiod.DefaultPeriodName {
+			if _, ok := periods[chk.CheckPeriod]; !ok {
+				c.res.warnf("%s: check_period '%s' is not defined, using the default", origin, chk.CheckPeriod)
+				chk.CheckPeriod = ""
+			} else {
+				usedPeriods[chk.CheckPeriod] = true
+			}
+		}
+		c.res.Checks = append(c.res.Checks, chk)
+	}
+
+	for _, o := range c.objectsOf("Service") {
+		svc, problems := c.attrs(o)
+
+		if o.kind == "object" {
+			hostName := svc.str("host_name")
+			h, ok := hosts[hostName]
+			if !ok {
+				c.res.warnf("icinga2: Service '%s': host '%s' is not defined, skipping", o.name, hostName)
+				continue
+			}
+			addCheck(o, svc, problems, h)
+			continue
+		}
+
+		if o.unsupported != "" {
+			c.res.warnf("icinga2: apply Service '%s': %s, skipping", o.name, o.unsupported)
+			continue
+		}
+		if len(o.assign) == 0 {
+			c.res.warnf("icinga2: apply Service '%s': no 'assign where' rule, skipping", o.name)
+			continue
+		}
+		var matched []*icingaHost
+		var err error
+		for _, h := range hostList {
+			var ok bool
+			if ok, err = c.matches(o, c.hostScope(h)); err != nil {
+				break
+			}
+			if ok {
+				matched = append(matched, h)
+			}
+		}
+		switch {
+		case err != nil:
+			c.res.warnf("icinga2: apply Service '%s': %v, skipping", o.name, err)
+		case len(matched) == 0:
+			c.res.warnf("icinga2: apply Service '%s': no hosts matched", o.name)
+		}
+		if err == nil {
+			for _, h := range matched {
+				addCheck(o, svc, problems, h)
+			}
+		}
+	}
+
+	dropped := convertTimeperiods(c.res, "icinga2", periods, usedPeriods)
+	for i := range c.res.Checks {
+		if dropped[c.res.Checks[i].CheckPeriod] {
+			c.res.Checks[i].CheckPeriod = ""
+		}
+	}
+}
+
+// assignHostgroups adds the groups whose 'assign where' rules match a host to
+// its 'groups' attribute, so 'in host.groups' conditions see them.
+func (c *icingaConfig) assignHostgroups(hosts []*icingaHost) {
+	for _, g := range c.objectsOf("HostGroup") {
+		if g.kind != "object" || len(g.assign) == 0 {
+			continue
+		}
+		for _, h := range hosts {
+			ok, err := c.matches(g, c.hostScope(h))
+			if err != nil {
+				c.res.warnf("icinga2: HostGroup '%s': %v, ignoring its assign rules", g.name, err)
+				break
+			}
+			if !ok {
+				continue
+			}
+			groups, _ := h.attrs.vals["groups"].([]any)
+			if !slices.ContainsFunc(groups, func(v any) bool { return icingaEqual(v, g.name) }) {
+				h.attrs.set("groups", append(groups, g.name))
+			}
+		}
+	}
+}
+
+func (c *icingaConfig) hostScope(h *icingaHost) func(string) any {
+	return func(path string) any {
+		if path == "host.name" {
+			return h.name
+		}
+		if rest, ok := strings.CutPrefix(path, "host."); ok {
+			v, _ := h.attrs.lookup(strings.Split(rest, ".")...)
+			return v
+		}
+		return c.consts[path]
+	}
+}
+
+// matches reports whether an apply rule or a group assignment applies: one of
+// the 'assign where' conditions holds and none of the 'ignore where' ones.
+func (c *icingaConfig) matches(o *icingaObject, scope func(string) any) (bool, error) {
+	for _, cond := range o.ignore {
+		ok, err := evalIcingaCondition(cond, scope)
+		if err != nil || ok {
+			return false, err
+		}
+	}
+	for _, cond := range o.assign {
+		ok, err := evalIcingaCondition(cond, scope)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+func (c *icingaConfig) timeperiod(o *icingaObject) periodDef {
+	attrs, problems := c.attrs(o)
+	c.warnProblems(o, problems)
+
+	def := periodDef{alias: attrs.str("display_name")}
+	if excludes, ok := attrs.vals["excludes"].([]any); ok {
+		for _, v := range excludes {
+			if s, ok := v.(string); ok {
+				def.exclude = append(def.exclude, s)
+			}
+		}
+	}
+	if ranges, ok := attrs.vals["ranges"].(*icingaDict); ok {
+		for _, k := range ranges.keys {
+			if v, ok := ranges.vals[k].(string); ok {
+				def.rules = append(def.rules, k+" "+v)
+			}
+		}
+	}
+	return def
+}
+
+func (c *icingaConfig) serviceCheck(origin, name string, svc *icingaDict, h *icingaHost, commands map[string]*icingaObject) (Check, bool) {
+	chk := Check{
+		Host:             h.name,
+		Service:          name,
+		CheckInterval:    icingaSeconds(svc, "check_interval", icingaDefaultCheckInterval),
+		RetryInterval:    icingaSeconds(svc, "retry_interval", icingaDefaultRetryInterval),
+		MaxCheckAttempts: icingaDefaultMaxAttempts,
+		CheckPeriod:      svc.str("check_period"),
+		Origin:           origin,
+	}
+	if v, ok := svc.vals["max_check_attempts"].(float64); ok && v > 0 {
+		chk.MaxCheckAttempts = int(v)
+	}
+
+	active := icingaBool(svc, "enable_active_checks", true)
+	passive := icingaBool(svc, "enable_passive_checks", true)
+	if !active {
+		if !passive {
+			c.res.warnf("%s: both active and passive checks are disabled, skipping", origin)
+			return Check{}, false
+		}
+		chk.Passive = true
+		c.res.warnf("%s: passive checks need 'passive.command_file' or 'passive.listen' to be set", origin)
+		return chk, true
+	}
+
+	cmdName := svc.str("check_command")
+	if cmdName == "" {
+		c.res.warnf("%s: no check_command, skipping", origin)
+		return Check{}, false
+	}
+	cmdObj, ok := commands[cmdName]
+	if !ok {
+		c.res.warnf("%s: CheckCommand '%s' is not defined, skipping (for Icinga Template Library commands include their definitions, e.g. /usr/share/icinga2/include/command-plugins.conf)", origin, cmdName)
+		return Check{}, false
+	}
+	cmd, problems := c.attrs(cmdObj)
+	c.warnProblems(cmdObj, problems)
+
+	m := &icingaMacros{host: h, service: svc, command: cmd, serviceName: name}
+	words, err := m.commandLine()
+	if err != nil {
+		c.res.warnf("%s: CheckCommand '%s': %v, skipping", origin, cmdName, err)
+		return Check{}, false
+	}
+	args, argProblems, err := m.arguments()
+	for _, p := range argProblems {
+		c.res.warnf("%s: CheckCommand '%s': %s", origin, cmdName, p)
+	}
+	if err != nil {
+		c.res.warnf("%s: CheckCommand '%s': %v, skipping", origin, cmdName, err)
+		return Check{}, false
+	}
+	if !strings.HasPrefix(words[0], "/") {
+		c.res.warnf("%s: plugin path '%s' is not absolute", origin, words[0])
+	}
+
+	chk.Plugin = words[0]
+	chk.Args = append(words[1:], args...)
+	chk.Timeout = icingaSeconds(svc, "check_timeout", icingaSeconds(cmd, "timeout", icingaDefaultTimeout))
+	return chk, true
+}
+
+func icingaSeconds(d *icingaDict, key string, def time.Duration) time.Duration {
+	if v, ok := d.vals[key].(float64); ok && v > 0 {
+		return time.Duration(v * float64(time.Second))
+	}
+	return def
+}
+
+func icingaBool(d *icingaDict, key string, def bool) bool {
+	v, ok := d.vals[key]
+	if !ok {
+		return def
+	}
+	return icingaTruthy(v)
+}
+
+// icingaMacros resolves Icinga 2 runtime macros for one service on one host.
+// All of them are static here, so commands are fully expanded at import time.
+type icingaMacros struct {
+	host        *icingaHost
+	service     *icingaDict
+	command     *icingaDict
+	serviceName string
+}
+
+// value looks a macro up the way Icinga 2 does: explicit object prefixes
+// first, then custom variables of the service, the host and the command.
+func (m *icingaMacros) value(name string) (any, bool) {
+	switch name {
+	case "host.name":
+		return m.host.name, true
+	case "service.name":
+		return m.serviceName, true
+	case "address", "address6":
+		return m.host.attrs.lookup(name)
+	}
+	for prefix, d := range map[string]*icingaDict{"host.": m.host.attrs, "service.": m.service, "command.": m.command} {
+		if rest, ok := strings.CutPrefix(name, prefix); ok {
+			return d.lookup(strings.Split(rest, ".")...)
+		}
+	}
+	for _, d := range []*icingaDict{m.service, m.host.attrs, m.command} {
+		if v, ok := d.lookup(append([]string{"vars"}, strings.Split(name, ".")...)...); ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// expand resolves the macros in v. A string that is a single macro takes the
+// macro's value as is, so arrays and booleans survive.
+func (m *icingaMacros) expand(v any, depth int) (any, error) {
+	if depth > icingaMaxMacroDepth {
+		return nil, errors.New("macros are nested too deeply")
+	}
+	switch v := v.(type) {
+	case icingaLambda:
+		return nil, errors.New("lambda values are not supported")
+	case []any:
+		out := make([]any, 0, len(v))
+		for _, e := range v {
+			r, err := m.expand(e, depth)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, r)
+		}
+		return out, nil
+	case string:
+		return m.expandString(v, depth)
+	}
+	return v, nil
+}
+
+func (m *icingaMacros) expandString(s string, depth int) (any, error) {
+	if len(s) > 2 && s[0] == '$' && s[len(s)-1] == '$' && !strings.Contains(s[1:len(s)-1], "$") {
+		name := s[1 : len(s)-1]
+		r, ok := m.value(name)
+		if !ok {
+			return nil, fmt.Errorf("%w $%s$", errIcingaUndefinedMacro, name)
+		}
+		return m.expand(r, depth+1)
+	}
+
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(s, '$')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(s[start+1:], '$')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated macro in '%s'", s)
+		}
+		name := s[start+1 : start+1+end]
+		b.WriteString(s[:start])
+		s = s[start+end+2:]
+
+		if name == "" {
+			b.WriteByte('$')
+			continue
+		}
+		r, ok := m.value(name)
+		if !ok {
+			return nil, fmt.Errorf("%w $%s$", errIcingaUndefinedMacro, name)
+		}
+		r, err := m.expand(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		str, err := icingaScalarString(r)
+		if err != nil {
+			return nil, fmt.Errorf("macro $%s$: %v", name, err)
+		}
+		b.WriteString(str)
+	}
+	b.WriteString(s)
+	return b.String(), nil
+}
+
+// commandLine returns the expanded 'command' attribute: the plugin and its fixed
+// arguments.
+func (m *icingaMacros) commandLine() ([]string, error) {
+	raw, ok := m.command.vals["command"]
+	if !ok {
+		return nil, errors.New("no 'command' attribute")
+	}
+	v, err := m.expand(raw, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := v.(type) {
+	case string:
+		plugin, args, err := commandFromLine(v)
+		if err != nil {
+			return nil, err
+		}
+		return append([]string{plugin}, args...), nil
+	case []any:
+		words, err := icingaStrings(v)
+		if err != nil {
+			return nil, err
+		}
+		if len(words) == 0 || words[0] == "" {
+			return nil, errors.New("empty command")
+		}
+		return words, nil
+	}
+	return nil, errors.New("unsupported 'command' attribute")
+}
+
+type icingaArgument struct {
+	key   string
+	order float64
+	spec  *icingaDict
+}
+
+// arguments renders the 'arguments' dictionary: ordered by 'order' and key,
+// honoring set_if, required, skip_key, repeat_key and key. Optional arguments
+// whose value uses an undefined macro are left out, as Icinga 2 does.
+func (m *icingaMacros) arguments() (out, problems []string, err error) {
+	argsDict, _ := m.command.vals["arguments"].(*icingaDict)
+	if argsDict == nil {
+		return nil, nil, nil
+	}
+
+	var list []icingaArgument
+	for _, key := range argsDict.keys {
+		a := icingaArgument{key: key}
+		switch v := argsDict.vals[key].(type) {
+		case nil:
+			continue
+		case *icingaDict:
+			a.spec = v
+		default:
+			a.spec = newIcingaDict()
+			a.spec.set("value", v)
+		}
+		if order, ok := a.spec.vals["order"].(float64); ok {
+			a.order = order
+		}
+		list = append(list, a)
+	}
+	slices.SortStableFunc(list, func(a, b icingaArgument) int {
+		if c := cmp.Compare(a.order, b.order); c != 0 {
+			return c
+		}
+		return strings.Compare(a.key, b.key)
+	})
+
+	for _, a := range list {
+		spec := a.spec
+		required := icingaTruthy(spec.vals["required"])
+		skipKey := icingaTruthy(spec.vals["skip_key"])
+		repeatKey := icingaBool(spec, "repeat_key", true)
+		key := a.key
+		if k := spec.str("key"); k != "" {
+			key = k
+		}
+
+		if setIf, ok := spec.vals["set_if"]; ok {
+			v, err := m.expand(setIf, 0)
+			if err != nil && !errors.Is(err, errIcingaUndefinedMacro) {
+				problems = append(problems, fmt.Sprintf("argument '%s': set_if: %v, leaving the argument out", a.key, err))
+				continue
+			}
+			if err != nil || !icingaTruthy(v) {
+				continue
+			}
+		}
+
+		raw, hasValue := spec.vals["value"]
+		if !hasValue {
+			if !skipKey {
+				out = append(out, key)
+			}
+			continue
+		}
+		v, err := m.expand(raw, 0)
+		if err == nil {
+			if b, ok := v.(bool); ok {
+				if b && !skipKey {
+					out = append(out, key)
+				}
+				continue
+			}
+		}
+		var values []string
+		if err == nil {
+			if arr, ok := v.([]any); ok {
+				values, err = icingaStrings(arr)
+			} else {
+				var s string
+				s, err = icingaScalarString(v)
+				if s != "" {
+					values = []string{s}
+				}
+			}
+		}
+		if err == nil && len(values) == 0 {
+			err = fmt.Errorf("%w: empty value", errIcingaUndefinedMacro)
+		}
+		if err != nil {
+			if required {
+				return nil, problems, fmt.Errorf("required argument '%s': %v", a.key, err)
+			}
+			if !errors.Is(err, errIcingaUndefinedMacro) {
+				problems = append(problems, fmt.Sprintf("argument '%s': %v, leaving the argument out", a.key, err))
+			}
+			continue
+		}
+
+		switch {
+		case skipKey:
+			out = append(out, values...)
+		case repeatKey:
+			for _, v := range values {
+				out = append(out, key, v)
+			}
+		default:
+			out = append(out, key)
+			out = append(out, values...)
+		}
+	}
+	return out, problems, nil
+}
+
+func icingaStrings(arr []any) ([]string, error) {
+	out := make([]string, 0, len(arr))
+	for _, e := range arr {
+		s, err := icingaScalarString(e)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// evalIcingaCondition evaluates an assign/ignore where condition. It supports
+// ||, &&, !, parentheses, comparisons, 'in' and '!in', and the match() and
+// regex() functions.
+func evalIcingaCondition(toks []icingaToken, scope func(string) any) (bool, error) {
+	e := &icingaCondEval{toks: toks, scope: scope}
+	v, err := e.or()
+	if err != nil {
+		return false, err
+	}
+	if e.pos < len(toks) {
+		return false, fmt.Errorf("condition: unexpected %s", describeToken(toks[e.pos]))
+	}
+	return icingaTruthy(v), nil
+}
+
+type icingaCondEval struct {
+	toks  []icingaToken
+	pos   int
+	scope func(string) any
+}
+
+func (e *icingaCondEval) peek() icingaToken {
+	if e.pos >= len(e.toks) {
+		return icingaToken{kind: icingaEOF}
+	}
+	return e.toks[e.pos]
+}
+
+func (e *icingaCondEval) accept(kind icingaTokenKind, text string) bool {
+	if t := e.peek(); t.kind == kind && t.text == text {
+		e.pos++
+		return true
+	}
+	return false
+}
+
+func (e *icingaCondEval) or() (any, error) {
+	v, err := e.and()
+	for err == nil && e.accept(icingaPunct, "||") {
+		var r any
+		if r, err = e.and(); err == nil {
+			v = icingaTruthy(v) || icingaTruthy(r)
+		}
+	}
+	return v, err
+}
+
+func (e *icingaCondEval) and() (any, error) {
+	v, err := e.not()
+	for err == nil && e.accept(icingaPunct, "&&") {
+		var r any
+		if r, err = e.not(); err == nil {
+			v = icingaTruthy(v) && icingaTruthy(r)
+		}
+	}
+	return v, err
+}
+
+func (e *icingaCondEval) not() (any, error) {
+	if e.accept(icingaPunct, "!") {
+		v, err := e.not()
+		return !icingaTruthy(v), err
+	}
+	return e.compare()
+}
+
+func (e *icingaCondEval) compare() (any, error) {
+	l, err := e.operand()
+	if err != nil {
+		return nil, err
+	}
+
+	t := e.peek()
+	negate := false
+	switch {
+	case t.kind == icingaPunct && (t.text == "==" || t.text == "!=" || t.text == "<" || t.text == ">" || t.text == "<=" || t.text == ">="):
+	case t.kind == icingaIdent && t.text == "in":
+	case t.kind == icingaPunct && t.text == "!" && e.pos+1 < len(e.toks) && e.toks[e.pos+1].kind == icingaIdent && e.toks[e.pos+1].text == "in":
+		negate = true
+		e.pos++
+	default:
+		return l, nil
+	}
+	e.pos++
+	op := e.toks[e.pos-1].text
+
+	r, err := e.operand()
+	if err != nil {
+		return nil, err
+	}
+	switch op {
+	case "==":
+		return icingaEqual(l, r), nil
+	case "!=":
+		return !icingaEqual(l, r), nil
+	case "in":
+		arr, _ := r.([]any)
+		return slices.ContainsFunc(arr, func(v any) bool { return icingaEqual(l, v) }) != negate, nil
+	}
+	a, ok1 := l.(float64)
+	b, ok2 := r.(float64)
+	if !ok1 || !ok2 {
+		return false, nil
+	}
+	switch op {
+	case "<":
+		return a < b, nil
+	case ">":
+		return a > b, nil
+	case "<=":
+		return a <= b, nil
+	default:
+		return a >= b, nil
+	}
+}
+
+func (e *icingaCondEval) operand() (any, error) {
+	t := e.peek()
+	e.pos++
+	switch t.kind {
+	case icingaString:
+		return t.text, nil
+	case icingaNumber:
+		return parseIcingaNumber(t.text)
+	case icingaIdent:
+		if e.accept(icingaPunct, "(") {
+			return e.call(t.text)
+		}
+		switch t.text {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		return e.scope(t.text), nil
+	case icingaPunct:
+		switch t.text {
+		case "(":
+			v, err := e.or()
+			if err == nil && !e.accept(icingaPunct, ")") {
+				err = errors.New("condition: expected ')'")
+			}
+			return v, err
+		case "[":
+			var out []any
+			for !e.accept(icingaPunct, "]") {
+				v, err := e.or()
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, v)
+				if !e.accept(icingaPunct, ",") && e.peek().text != "]" {
+					return nil, errors.New("condition: expected ',' or ']'")
+				}
+			}
+			return out, nil
+		}
+	}
+	return nil, fmt.Errorf("condition: unexpected %s", describeToken(t))
+}
+
+func (e *icingaCondEval) call(name string) (any, error) {
+	var args []any
+	for !e.accept(icingaPunct, ")") {
+		v, err := e.or()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, v)
+		if !e.accept(icingaPunct, ",") && e.peek().text != ")" {
+			return nil, fmt.Errorf("condition: %s(): expected ',' or ')'", name)
+		}
+	}
+
+	switch name {
+	case "match", "regex":
+		if len(args) < 2 {
+			return nil, fmt.Errorf("condition: %s() needs a pattern and a value", name)
+		}
+		pattern, _ := args[0].(string)
+		expr := pattern
+		if name == "match" {
+			expr = "^" + strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(regexp.QuoteMeta(pattern)) + "$"
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("condition: %s(): %v", name, err)
+		}
+		values, ok := args[1].([]any)
+		if !ok {
+			values = []any{args[1]}
+		}
+		return slices.ContainsFunc(values, func(v any) bool {
+			s, ok := v.(string)
+			return ok && re.MatchString(s)
+		}), nil
+	case "len":
+		if len(args) != 1 {
+			return nil, errors.New("condition: len() needs one argument")
+		}
+		switch v := args[0].(type) {
+		case string:
+			return float64(len(v)), nil
+		case []any:
+			return float64(len(v)), nil
+		case *icingaDict:
+			return float64(len(v.keys)), nil
+		}
+		return 0.0, nil
+	}
+	return nil, fmt.Errorf("condition: function '%s' is not supported", name)
+}
@@ -0,0 +1,832 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package objconf
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// The Icinga 2 DSL is a full scripting language. Only its declarative subset
+// is read here: object, template and apply blocks with constant attribute
+// values, imports, assign/ignore conditions and const definitions. Anything
+// else inside an object is recorded as a problem of that object and reported
+// only if the object ends up being used.
+
+type icingaTokenKind int
+
+const (
+	icingaEOF icingaTokenKind = iota
+	icingaNewline
+	icingaIdent
+	icingaString
+	icingaNumber
+	icingaPunct
+	icingaLambdaToken
+)
+
+type icingaToken struct {
+	kind icingaTokenKind
+	text string
+	line int
+}
+
+// Longer operators go first.
+var icingaPuncts = []string{
+	"==", "!=", "&&", "||", "+=", "-=", "<=", ">=",
+	"{", "}", "[", "]", "(", ")", "=", ",", ";", "+", "-", "!", "<", ">", ":", "*", "/", "%",
+}
+
+func lexIcinga(src string) ([]icingaToken, error) {
+	var toks []icingaToken
+	line := 1
+	emit := func(kind icingaTokenKind, text string) {
+		toks = append(toks, icingaToken{kind: kind, text: text, line: line})
+	}
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			emit(icingaNewline, "")
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c == '\\' && strings.HasPrefix(src[i+1:], "\n"):
+			line++
+			i += 2
+		case c == '#' || strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated comment", line)
+			}
+			line += strings.Count(src[i:i+2+end], "\n")
+			i += end + 4
+		case strings.HasPrefix(src[i:], "{{{"):
+			end := strings.Index(src[i+3:], "}}}")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated string", line)
+			}
+			text := src[i+3 : i+3+end]
+			emit(icingaString, text)
+			line += strings.Count(text, "\n")
+			i += end + 6
+		case strings.HasPrefix(src[i:], "{{"):
+			end := strings.Index(src[i+2:], "}}")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated lambda", line)
+			}
+			text := src[i+2 : i+2+end]
+			emit(icingaLambdaToken, text)
+			line += strings.Count(text, "\n")
+			i += end + 4
+		case c == '"':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(src) && src[j] != '"'; j++ {
+				if src[j] == '\n' {
+					return nil, fmt.Errorf("line %d: unterminated string", line)
+				}
+				if src[j] == '\\' && j+1 < len(src) {
+					j++
+					switch src[j] {
+					case 'n':
+						b.WriteByte('\n')
+					case 't':
+						b.WriteByte('\t')
+					default:
+						b.WriteByte(src[j])
+					}
+					continue
+				}
+				b.WriteByte(src[j])
+			}
+			if j >= len(src) {
+				return nil, fmt.Errorf("line %d: unterminated string", line)
+			}
+			emit(icingaString, b.String())
+			i = j + 1
+		case isDigit(c):
+			j := i
+			for j < len(src) && (isDigit(src[j]) || src[j] == '.') {
+				j++
+			}
+			for j < len(src) && isLetter(src[j]) {
+				j++
+			}
+			emit(icingaNumber, src[i:j])
+			i = j
+		case isLetter(c) || c == '_':
+			j := i
+			for j < len(src) && (isIdentChar(src[j]) || src[j] == '.' && j+1 < len(src) && (isLetter(src[j+1]) || src[j+1] == '_')) {
+				j++
+			}
+			emit(icingaIdent, src[i:j])
+			i = j
+		default:
+			idx := slices.IndexFunc(icingaPuncts, func(p string) bool { return strings.HasPrefix(src[i:], p) })
+			if idx < 0 {
+				return nil, fmt.Errorf("line %d: unexpected character '%c'", line, c)
+			}
+			emit(icingaPunct, icingaPuncts[idx])
+			i += len(icingaPuncts[idx])
+		}
+	}
+	emit(icingaEOF, "")
+	return toks, nil
+}
+
+func isDigit(c byte) bool     { return c >= '0' && c <= '9' }
+func isLetter(c byte) bool    { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+func isIdentChar(c byte) bool { return isLetter(c) || isDigit(c) || c == '_' }
+
+// parseIcingaNumber parses numbers and duration literals; durations evaluate
+// to seconds, as in Icinga 2.
+func parseIcingaNumber(s string) (float64, error) {
+	num := strings.TrimRightFunc(s, unicode.IsLetter)
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number '%s'", s)
+	}
+	switch unit := s[len(num):]; unit {
+	case "":
+		return v, nil
+	case "ms":
+		return v / 1000, nil
+	case "s":
+		return v, nil
+	case "m":
+		return v * 60, nil
+	case "h":
+		return v * 3600, nil
+	case "d":
+		return v * 86400, nil
+	default:
+		return 0, fmt.Errorf("invalid duration unit in '%s'", s)
+	}
+}
+
+// icingaLambda is a {{ ... }} function body; it is kept but never evaluated.
+type icingaLambda string
+
+// icingaDict is an Icinga 2 dictionary. Key order is kept so that the
+// generated jobs are stable.
+type icingaDict struct {
+	keys []string
+	vals map[string]any
+}
+
+func newIcingaDict() *icingaDict {
+	return &icingaDict{vals: make(map[string]any)}
+}
+
+func (d *icingaDict) set(key string, v any) {
+	if _, ok := d.vals[key]; !ok {
+		d.keys = append(d.keys, key)
+	}
+	d.vals[key] = v
+}
+
+func (d *icingaDict) lookup(path ...string) (any, bool) {
+	var cur any = d
+	for _, k := range path {
+		dd, ok := cur.(*icingaDict)
+		if !ok || dd == nil {
+			return nil, false
+		}
+		if cur, ok = dd.vals[k]; !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+func (d *icingaDict) str(key string) string {
+	s, _ := d.vals[key].(string)
+	return s
+}
+
+// apply performs 'path = v', 'path += v' or 'path -= v'.
+func (d *icingaDict) apply(path []string, op string, v any) error {
+	cur := d
+	for _, k := range path[:len(path)-1] {
+		next, ok := cur.vals[k].(*icingaDict)
+		if !ok {
+			if old := cur.vals[k]; old != nil {
+				return fmt.Errorf("'%s' is not a dictionary", k)
+			}
+			next = newIcingaDict()
+			cur.set(k, next)
+		}
+		cur = next
+	}
+
+	key := path[len(path)-1]
+	v = icingaClone(v)
+	if old := cur.vals[key]; old != nil {
+		var err error
+		switch op {
+		case "+=":
+			v, err = icingaAdd(old, v)
+		case "-=":
+			v, err = icingaSub(old, v)
+		}
+		if err != nil {
+			return fmt.Errorf("'%s': %v", strings.Join(path, "."), err)
+		}
+	}
+	cur.set(key, v)
+	return nil
+}
+
+func icingaClone(v any) any {
+	switch v := v.(type) {
+	case *icingaDict:
+		c := newIcingaDict()
+		for _, k := range v.keys {
+			c.set(k, icingaClone(v.vals[k]))
+		}
+		return c
+	case []any:
+		out := make([]any, len(v))
+		for i := range v {
+			out[i] = icingaClone(v[i])
+		}
+		return out
+	}
+	return v
+}
+
+func icingaAdd(a, b any) (any, error) {
+	switch a := a.(type) {
+	case nil:
+		return b, nil
+	case string:
+		if s, err := icingaScalarString(b); err == nil {
+			return a + s, nil
+		}
+	case float64:
+		switch b := b.(type) {
+		case float64:
+			return a + b, nil
+		case string:
+			return icingaScalarString(a)
+		}
+	case []any:
+		if b, ok := b.([]any); ok {
+			return append(slices.Clone(a), b...), nil
+		}
+	case *icingaDict:
+		if b, ok := b.(*icingaDict); ok {
+			out := icingaClone(a).(*icingaDict)
+			for _, k := range b.keys {
+				out.set(k, icingaClone(b.vals[k]))
+			}
+			return out, nil
+		}
+	}
+	return nil, errors.New("operands of '+' have incompatible types")
+}
+
+func icingaSub(a, b any) (any, error) {
+	switch a := a.(type) {
+	case float64:
+		if b, ok := b.(float64); ok {
+			return a - b, nil
+		}
+	case []any:
+		if b, ok := b.([]any); ok {
+			return slices.DeleteFunc(slices.Clone(a), func(v any) bool {
+				return slices.ContainsFunc(b, func(x any) bool { return icingaEqual(v, x) })
+			}), nil
+		}
+	}
+	return nil, errors.New("operands of '-' have incompatible types")
+}
+
+func icingaEqual(a, b any) bool {
+	switch a := a.(type) {
+	case nil:
+		return b == nil
+	case string:
+		s, ok := b.(string)
+		return ok && a == s
+	case float64:
+		f, ok := b.(float64)
+		return ok && a == f
+	case bool:
+		v, ok := b.(bool)
+		return ok && a == v
+	}
+	return false
+}
+
+func icingaTruthy(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != "" && v != "0" && v != "false"
+	case []any:
+		return len(v) > 0
+	case *icingaDict:
+		return len(v.keys) > 0
+	}
+	return true
+}
+
+func icingaScalarString(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case icingaLambda:
+		return "", errors.New("lambda values are not supported")
+	}
+	return "", errors.New("not a scalar value")
+}
+
+type icingaObject struct {
+	kind string // object, template or apply
+	typ  string
+	name string
+	line int
+
+	imports []string
+	ops     []icingaAssign
+	assign  [][]icingaToken
+	ignore  [][]icingaToken
+
+	// unsupported is set for objects that cannot be imported at all.
+	unsupported string
+	// problems are the statements of the body that were skipped.
+	problems []string
+}
+
+type icingaAssign struct {
+	path  []string
+	op    string
+	value any
+}
+
+type icingaParser struct {
+	toks   []icingaToken
+	pos    int
+	consts map[string]any
+}
+
+func (p *icingaParser) peek() icingaToken {
+	return p.toks[p.pos]
+}
+
+func (p *icingaParser) next() icingaToken {
+	t := p.toks[p.pos]
+	if t.kind != icingaEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *icingaParser) isPunct(s string) bool {
+	t := p.peek()
+	return t.kind == icingaPunct && t.text == s
+}
+
+func (p *icingaParser) isIdent(s string) bool {
+	t := p.peek()
+	return t.kind == icingaIdent && t.text == s
+}
+
+func (p *icingaParser) expectPunct(s string) error {
+	if t := p.next(); t.kind != icingaPunct || t.text != s {
+		return fmt.Errorf("expected '%s', got %s", s, describeToken(t))
+	}
+	return nil
+}
+
+func (p *icingaParser) skipSeparators() {
+	for p.peek().kind == icingaNewline || p.isPunct(";") {
+		p.next()
+	}
+}
+
+func (p *icingaParser) skipNewlines() {
+	for p.peek().kind == icingaNewline {
+		p.next()
+	}
+}
+
+// skipStatement skips to the end of the current statement, nested blocks
+// included. The closing brace of the enclosing block is not consumed.
+func (p *icingaParser) skipStatement() {
+	depth := 0
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == icingaEOF:
+			return
+		case depth == 0 && (t.kind == icingaNewline || t.kind == icingaPunct && t.text == ";"):
+			p.next()
+			return
+		case t.kind == icingaPunct && (t.text == "{" || t.text == "[" || t.text == "("):
+			depth++
+		case t.kind == icingaPunct && (t.text == "}" || t.text == "]" || t.text == ")"):
+			if depth == 0 {
+				return
+			}
+			depth--
+		}
+		p.next()
+	}
+}
+
+// endStatement checks that nothing but a statement separator follows.
+func (p *icingaParser) endStatement() error {
+	t := p.peek()
+	if t.kind == icingaEOF || t.kind == icingaNewline || t.kind == icingaPunct && (t.text == ";" || t.text == "}") {
+		return nil
+	}
+	return fmt.Errorf("unsupported expression near %s", describeToken(t))
+}
+
+func describeToken(t icingaToken) string {
+	switch t.kind {
+	case icingaEOF:
+		return "end of file"
+	case icingaNewline:
+		return "end of line"
+	case icingaString:
+		return strconv.Quote(t.text)
+	case icingaLambdaToken:
+		return "lambda"
+	}
+	return "'" + t.text + "'"
+}
+
+// parseFile reads the top-level statements. Statements that cannot be read
+// are passed to skipped and otherwise ignored.
+func (p *icingaParser) parseFile(add func(*icingaObject), skipped func(line int, err error)) {
+	for {
+		p.skipSeparators()
+		if p.peek().kind == icingaEOF {
+			return
+		}
+		start := p.pos
+		if err := p.parseTopLevel(add); err != nil {
+			skipped(p.toks[start].line, err)
+			p.pos = start
+			p.skipStatement()
+			// A stray closing brace would otherwise stop the loop.
+			if p.isPunct("}") {
+				p.next()
+			}
+		}
+	}
+}
+
+func (p *icingaParser) parseTopLevel(add func(*icingaObject)) error {
+	t := p.next()
+	if t.kind != icingaIdent {
+		return fmt.Errorf("unexpected %s", describeToken(t))
+	}
+	switch t.text {
+	case "object", "template", "apply":
+		o, err := p.parseObject(t.text, t.line)
+		if err != nil {
+			return err
+		}
+		add(o)
+		return nil
+	case "const":
+		name := p.next()
+		if name.kind != icingaIdent {
+			return fmt.Errorf("expected a constant name, got %s", describeToken(name))
+		}
+		if err := p.expectPunct("="); err != nil {
+			return err
+		}
+		v, err := p.parseExpr()
+		if err != nil {
+			return err
+		}
+		p.consts[name.text] = v
+		return p.endStatement()
+	case "include", "include_recursive", "include_zones", "library":
+		p.skipStatement()
+		return nil
+	default:
+		return fmt.Errorf("unsupported statement '%s'", t.text)
+	}
+}
+
+func (p *icingaParser) parseObject(kind string, line int) (*icingaObject, error) {
+	typ := p.next()
+	if typ.kind != icingaIdent {
+		return nil, fmt.Errorf("expected an object type, got %s", describeToken(typ))
+	}
+	v, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	name, ok := v.(string)
+	if !ok || name == "" {
+		return nil, fmt.Errorf("%s %s: the name must be a non-empty string", kind, typ.text)
+	}
+
+	o := &icingaObject{kind: kind, typ: typ.text, name: name, line: line}
+	for p.peek().kind == icingaIdent {
+		switch t := p.next(); t.text {
+		case "ignore_on_error":
+		case "to":
+			p.next()
+		case "for":
+			o.unsupported = "'apply for' rules are not supported"
+			if !p.isPunct("(") {
+				return nil, fmt.Errorf("expected '(' after 'for'")
+			}
+			p.skipParens()
+		default:
+			return nil, fmt.Errorf("unexpected '%s'", t.text)
+		}
+	}
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+	if err := p.parseBody(o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func (p *icingaParser) skipParens() {
+	depth := 0
+	for {
+		t := p.next()
+		switch {
+		case t.kind == icingaEOF:
+			return
+		case t.kind == icingaPunct && t.text == "(":
+			depth++
+		case t.kind == icingaPunct && t.text == ")":
+			if depth--; depth == 0 {
+				return
+			}
+		}
+	}
+}
+
+func (p *icingaParser) parseBody(o *icingaObject) error {
+	for {
+		p.skipSeparators()
+		switch {
+		case p.isPunct("}"):
+			p.next()
+			return nil
+		case p.peek().kind == icingaEOF:
+			return errors.New("unterminated block")
+		}
+		start := p.pos
+		if err := p.parseBodyStatement(o); err != nil {
+			o.problems = append(o.problems, fmt.Sprintf("line %d: %v, skipped", p.toks[start].line, err))
+			p.pos = start
+			p.skipStatement()
+		}
+	}
+}
+
+func (p *icingaParser) parseBodyStatement(o *icingaObject) error {
+	t := p.next()
+	if t.kind != icingaIdent {
+		return fmt.Errorf("unexpected %s", describeToken(t))
+	}
+
+	switch {
+	case t.text == "import":
+		v, err := p.parseExpr()
+		if err != nil {
+			return err
+		}
+		name, ok := v.(string)
+		if !ok {
+			return errors.New("import needs a template name")
+		}
+		o.imports = append(o.imports, name)
+		return p.endStatement()
+	case (t.text == "assign" || t.text == "ignore") && p.isIdent("where"):
+		p.next()
+		cond := p.collectCondition()
+		if len(cond) == 0 {
+			return fmt.Errorf("empty %s where condition", t.text)
+		}
+		if t.text == "assign" {
+			o.assign = append(o.assign, cond)
+		} else {
+			o.ignore = append(o.ignore, cond)
+		}
+		return nil
+	}
+
+	path, err := p.parsePath(t)
+	if err != nil {
+		return err
+	}
+	op := p.next()
+	if op.kind != icingaPunct || op.text != "=" && op.text != "+=" && op.text != "-=" {
+		return fmt.Errorf("unsupported statement '%s'", t.text)
+	}
+	v, err := p.parseExpr()
+	if err != nil {
+		return err
+	}
+	if err := p.endStatement(); err != nil {
+		return err
+	}
+	o.ops = append(o.ops, icingaAssign{path: path, op: op.text, value: v})
+	return nil
+}
+
+// parsePath reads 'a.b.c' and 'a["b"]' attribute paths.
+func (p *icingaParser) parsePath(first icingaToken) ([]string, error) {
+	path := strings.Split(first.text, ".")
+	for p.isPunct("[") {
+		p.next()
+		k := p.next()
+		if k.kind != icingaString {
+			return nil, fmt.Errorf("unsupported index %s", describeToken(k))
+		}
+		if err := p.expectPunct("]"); err != nil {
+			return nil, err
+		}
+		path = append(path, k.text)
+	}
+	return path, nil
+}
+
+// collectCondition returns the tokens of an assign/ignore condition, up to
+// the end of the statement. Line breaks inside parentheses are allowed.
+func (p *icingaParser) collectCondition() []icingaToken {
+	var out []icingaToken
+	depth := 0
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == icingaEOF:
+			return out
+		case t.kind == icingaNewline:
+			if depth == 0 {
+				return out
+			}
+			p.next()
+			continue
+		case t.kind == icingaPunct && (t.text == ";" || t.text == "}") && depth == 0:
+			return out
+		case t.kind == icingaPunct && (t.text == "(" || t.text == "["):
+			depth++
+		case t.kind == icingaPunct && (t.text == ")" || t.text == "]"):
+			depth--
+		}
+		out = append(out, p.next())
+	}
+}
+
+func (p *icingaParser) parseExpr() (any, error) {
+	v, err := p.parsePrimary()
+	for err == nil && p.isPunct("+") {
+		p.next()
+		var r any
+		if r, err = p.parsePrimary(); err == nil {
+			v, err = icingaAdd(v, r)
+		}
+	}
+	return v, err
+}
+
+func (p *icingaParser) parsePrimary() (any, error) {
+	t := p.next()
+	switch t.kind {
+	case icingaString:
+		return t.text, nil
+	case icingaNumber:
+		return parseIcingaNumber(t.text)
+	case icingaLambdaToken:
+		return icingaLambda(t.text), nil
+	case icingaIdent:
+		if p.isPunct("(") {
+			return nil, fmt.Errorf("function calls are not supported ('%s')", t.text)
+		}
+		switch t.text {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		if v, ok := p.consts[t.text]; ok {
+			return icingaClone(v), nil
+		}
+		return nil, fmt.Errorf("unsupported reference '%s'", t.text)
+	case icingaPunct:
+		switch t.text {
+		case "-":
+			n := p.next()
+			if n.kind != icingaNumber {
+				return nil, fmt.Errorf("unexpected %s", describeToken(n))
+			}
+			v, err := parseIcingaNumber(n.text)
+			return -v, err
+		case "(":
+			v, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			return v, p.expectPunct(")")
+		case "[":
+			return p.parseArray()
+		case "{":
+			return p.parseDict()
+		}
+	}
+	return nil, fmt.Errorf("unexpected %s", describeToken(t))
+}
+
+func (p *icingaParser) parseArray() (any, error) {
+	out := []any{}
+	for {
+		p.skipNewlines()
+		if p.isPunct("]") {
+			p.next()
+			return out, nil
+		}
+		v, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+		p.skipNewlines()
+		if p.isPunct(",") {
+			p.next()
+		} else if !p.isPunct("]") {
+			return nil, fmt.Errorf("expected ',' or ']', got %s", describeToken(p.peek()))
+		}
+	}
+}
+
+func (p *icingaParser) parseDict() (any, error) {
+	d := newIcingaDict()
+	for {
+		for p.peek().kind == icingaNewline || p.isPunct(";") || p.isPunct(",") {
+			p.next()
+		}
+		if p.isPunct("}") {
+			p.next()
+			return d, nil
+		}
+
+		k := p.next()
+		var path []string
+		switch k.kind {
+		case icingaString:
+			path = []string{k.text}
+		case icingaIdent:
+			var err error
+			if path, err = p.parsePath(k); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("expected a dictionary key, got %s", describeToken(k))
+		}
+		op := p.next()
+		if op.kind != icingaPunct || op.text != "=" && op.text != "+=" && op.text != "-=" {
+			return nil, fmt.Errorf("expected '=' after dictionary key '%s'", k.text)
+		}
+		v, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if err := d.apply(path, op.text, v); err != nil {
+			return nil, err
+		}
+		if t := p.peek(); !(t.kind == icingaNewline || t.kind == icingaPunct && (t.text == "," || t.text == ";" || t.text == "}")) {
+			return nil, fmt.Errorf("unexpected %s in dictionary", describeToken(t))
+		}
+	}
+}
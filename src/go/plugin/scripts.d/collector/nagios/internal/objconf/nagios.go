@@ -0,0 +1,557 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package objconf
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/scripts.d/pkg/timeperiod"
+)
+
+const (
+	nagiosDefaultIntervalLength = 60 * time.Second
+	nagiosDefaultCheckTimeout   = 60 * time.Second
+)
+
+type nagiosObject struct {
+	typ   string
+	attrs map[string]string
+	// lines keeps every attribute line in order: timeperiod rules share keys
+	// ("monday 1 ...", "monday 2 ...") and cannot be kept in attrs alone.
+	lines []string
+}
+
+func (o *nagiosObject) registered() bool {
+	return o.attrs["register"] != "0"
+}
+
+type nagiosConfig struct {
+	objects   []*nagiosObject
+	templates map[string]map[string]*nagiosObject // type -> template name -> object
+	resource  map[string]string                   // USERn -> value
+	main      map[string]string                   // main config settings
+
+	resolved  map[*nagiosObject]map[string]string
+	resolving map[*nagiosObject]bool
+}
+
+func parseNagios(res *Result, sources [][]byte) error {
+	cfg := &nagiosConfig{
+		templates: make(map[string]map[string]*nagiosObject),
+		resource:  make(map[string]string),
+		main:      make(map[string]string),
+		resolved:  make(map[*nagiosObject]map[string]string),
+		resolving: make(map[*nagiosObject]bool),
+	}
+	for i, src := range sources {
+		if err := cfg.read(src); err != nil {
+			return fmt.Errorf("source %d: %w", i+1, err)
+		}
+	}
+	cfg.resolve(res)
+	return nil
+}
+
+func (c *nagiosConfig) read(src []byte) error {
+	sc := bufio.NewScanner(bytes.NewReader(src))
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var cur *nagiosObject
+	var lineNum int
+	for sc.Scan() {
+		lineNum++
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+
+		if cur == nil {
+			if typ, ok := parseDefineLine(line); ok {
+				cur = &nagiosObject{typ: typ, attrs: make(map[string]string)}
+				continue
+			}
+			key, value, ok := strings.Cut(line, "=")
+			if !ok {
+				return fmt.Errorf("line %d: unexpected '%s'", lineNum, line)
+			}
+			key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+			if strings.HasPrefix(key, "$USER") && strings.HasSuffix(key, "$") {
+				c.resource[strings.Trim(key, "$")] = value
+			} else {
+				c.main[key] = value
+			}
+			continue
+		}
+
+		line = stripInlineComment(line)
+		if line == "}" {
+			c.add(cur)
+			cur = nil
+			continue
+		}
+		closing := strings.HasSuffix(line, "}")
+		line = strings.TrimSpace(strings.TrimSuffix(line, "}"))
+
+		if line != "" {
+			key, value := line, ""
+			if i := strings.IndexAny(line, " \t"); i > 0 {
+				key, value = line[:i], strings.TrimSpace(line[i+1:])
+			}
+			cur.attrs[key] = value
+			cur.lines = append(cur.lines, line)
+		}
+		if closing {
+			c.add(cur)
+			cur = nil
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	if cur != nil {
+		return fmt.Errorf("unterminated 'define %s' block", cur.typ)
+	}
+	return nil
+}
+
+func parseDefineLine(line string) (string, bool) {
+	rest, ok := strings.CutPrefix(line, "define")
+	if !ok || rest == "" || (rest[0] != ' ' && rest[0] != '\t') {
+		return "", false
+	}
+	typ, ok := strings.CutSuffix(strings.TrimSpace(rest), "{")
+	if !ok {
+		return "", false
+	}
+	return strings.TrimSpace(typ), true
+}
+
+// stripInlineComment removes a trailing ';' comment; '\;' is a literal semicolon.
+func stripInlineComment(line string) string {
+	if !strings.Contains(line, ";") {
+		return line
+	}
+	var b strings.Builder
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' && i+1 < len(line) && line[i+1] == ';' {
+			b.WriteByte(';')
+			i++
+			continue
+		}
+		if line[i] == ';' {
+			break
+		}
+		b.WriteByte(line[i])
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func (c *nagiosConfig) add(o *nagiosObject) {
+	c.objects = append(c.objects, o)
+	if name := o.attrs["name"]; name != "" {
+		if c.templates[o.typ] == nil {
+			c.templates[o.typ] = make(map[string]*nagiosObject)
+		}
+		c.templates[o.typ][name] = o
+	}
+}
+
+// attrs returns the object attributes with template inheritance applied:
+// templates listed in 'use' are applied in order (earlier ones win), own
+// attributes override them, a '+' prefix appends to the inherited value and
+// 'null' removes it.
+func (c *nagiosConfig) attrs(o *nagiosObject) map[string]string {
+	if v, ok := c.resolved[o]; ok {
+		return v
+	}
+	if c.resolving[o] {
+		return o.attrs
+	}
+	c.resolving[o] = true
+	defer delete(c.resolving, o)
+
+	inherited := make(map[string]string)
+	for _, name := range splitList(o.attrs["use"]) {
+		tmpl, ok := c.templates[o.typ][name]
+		if !ok {
+			continue
+		}
+		for k, v := range c.attrs(tmpl) {
+			if _, ok := inherited[k]; !ok {
+				inherited[k] = v
+			}
+		}
+	}
+	delete(inherited, "name")
+	delete(inherited, "register")
+	delete(inherited, "use")
+
+	out := inherited
+	for k, v := range o.attrs {
+		switch {
+		case k == "use":
+		case v == "null":
+			delete(out, k)
+		case strings.HasPrefix(v, "+"):
+			if prev := out[k]; prev != "" {
+				out[k] = prev + "," + v[1:]
+			} else {
+				out[k] = v[1:]
+			}
+		default:
+			out[k] = v
+		}
+	}
+	c.resolved[o] = out
+	return out
+}
+
+func (c *nagiosConfig) registeredObjects(typ string) []map[string]string {
+	var out []map[string]string
+	for _, o := range c.objects {
+		if o.typ == typ && o.registered() {
+			out = append(out, c.attrs(o))
+		}
+	}
+	return out
+}
+
+func (c *nagiosConfig) resolve(res *Result) {
+	hosts := make(map[string]map[string]string)
+	var hostNames []string
+	for _, h := range c.registeredObjects("host") {
+		if name := h["host_name"]; name != "" {
+			hosts[name] = h
+			hostNames = append(hostNames, name)
+		}
+	}
+	slices.Sort(hostNames)
+
+	groups := c.hostgroupMembers(hosts)
+
+	commands := make(map[string]string)
+	for _, cmd := range c.registeredObjects("command") {
+		commands[cmd["command_name"]] = cmd["command_line"]
+	}
+
+	periods := make(map[string]periodDef)
+	for _, o := range c.objects {
+		if name := o.attrs["timeperiod_name"]; o.typ == "timeperiod" && o.registered() && name != "" {
+			def := periodDef{alias: o.attrs["alias"], exclude: splitList(o.attrs["exclude"])}
+			for _, line := range o.lines {
+				if key, _, _ := strings.Cut(line, " "); !slices.Contains(timeperiodDirectives, key) {
+					def.rules = append(def.rules, line)
+				}
+			}
+			periods[name] = def
+		}
+	}
+	usedPeriods := make(map[string]bool)
+
+	intervalLength := nagiosDefaultIntervalLength
+	if v, err := strconv.Atoi(c.main["interval_length"]); err == nil && v > 0 {
+		intervalLength = time.Duration(v) * time.Second
+	}
+	timeout := nagiosDefaultCheckTimeout
+	if v, err := strconv.Atoi(c.main["service_check_timeout"]); err == nil && v > 0 {
+		timeout = time.Duration(v) * time.Second
+	}
+
+	for _, svc := range c.registeredObjects("service") {
+		desc := svc["service_description"]
+		if desc == "" {
+			res.warnf("nagios: skipping a service without service_description")
+			continue
+		}
+
+		svcHosts := serviceHosts(svc, hostNames, groups)
+		if len(svcHosts) == 0 {
+			res.warnf("nagios: service '%s': no hosts matched host_name/hostgroup_name, skipping", desc)
+			continue
+		}
+
+		for _, hostName := range svcHosts {
+			origin := fmt.Sprintf("nagios service '%s' on host '%s'", desc, hostName)
+			chk, ok := c.serviceCheck(res, origin, svc, hosts[hostName], hostName, commands, intervalLength, timeout)
+			if !ok {
+				continue
+			}
+			if chk.CheckPeriod != "" && chk.CheckPeriod != timeperiod.DefaultPeriodName {
+				if _, ok := periods[chk.CheckPeriod]; !ok {
+					res.warnf("%s: check_period '%s' is not defined, using the default", origin, chk.CheckPeriod)
+					chk.CheckPeriod = ""
+				} else {
+					usedPeriods[chk.CheckPeriod] = true
+				}
+			}
+			res.Checks = append(res.Checks, chk)
+		}
+	}
+
+	dropped := convertTimeperiods(res, "nagios", periods, usedPeriods)
+	for i := range res.Checks {
+		if dropped[res.Checks[i].CheckPeriod] {
+			res.Checks[i].CheckPeriod = ""
+		}
+	}
+}
+
+func (c *nagiosConfig) serviceCheck(
+	res *Result,
+	origin string,
+	svc, host map[string]string,
+	hostName string,
+	commands map[string]string,
+	intervalLength, timeout time.Duration,
+) (Check, bool) {
+	desc := svc["service_description"]
+	chk := Check{
+		Host:             hostName,
+		Service:          desc,
+		CustomVars:       customVars(svc),
+		CheckInterval:    nagiosInterval(firstAttr(svc, "check_interval", "normal_check_interval"), intervalLength),
+		RetryInterval:    nagiosInterval(firstAttr(svc, "retry_interval", "retry_check_interval"), intervalLength),
+		Timeout:          timeout,
+		MaxCheckAttempts: atoiOrZero(svc["max_check_attempts"]),
+		CheckPeriod:      svc["check_period"],
+		Origin:           origin,
+	}
+
+	active := svc["active_checks_enabled"] != "0"
+	passive := svc["passive_checks_enabled"] != "0"
+	freshness := svc["check_freshness"] == "1"
+	if (!active || freshness) && passive {
+		chk.Passive = true
+		chk.CommandFile = c.main["command_file"]
+		if freshness {
+			chk.FreshnessThreshold = time.Duration(atoiOrZero(svc["freshness_threshold"])) * time.Second
+		}
+		if chk.CommandFile == "" {
+			res.warnf("%s: passive checks need 'passive.command_file' or 'passive.listen' to be set", origin)
+		}
+	}
+	if !active {
+		if !passive {
+			res.warnf("%s: both active and passive checks are disabled, skipping", origin)
+			return Check{}, false
+		}
+		return chk, true
+	}
+
+	cmdName, cmdArgs := splitCheckCommand(svc["check_command"])
+	if cmdName == "" {
+		res.warnf("%s: no check_command, skipping", origin)
+		return Check{}, false
+	}
+	cmdLine, ok := commands[cmdName]
+	if !ok {
+		res.warnf("%s: command '%s' is not defined, skipping", origin, cmdName)
+		return Check{}, false
+	}
+
+	lookup := c.macroLookup(svc, host, hostName)
+	plugin, args, err := commandFromLine(expandMacros(cmdLine, lookup))
+	if err != nil {
+		res.warnf("%s: command '%s': %v, skipping", origin, cmdName, err)
+		return Check{}, false
+	}
+	if !strings.HasPrefix(plugin, "/") {
+		res.warnf("%s: plugin path '%s' is not absolute", origin, plugin)
+	}
+	for _, v := range cmdArgs {
+		chk.ArgValues = append(chk.ArgValues, expandMacros(v, lookup))
+	}
+	chk.Plugin = plugin
+	chk.Args = args
+	return chk, true
+}
+
+// macroLookup resolves the macros that are static for a service on a host:
+// resource macros, host attributes and custom variables, and the service
+// description. $ARGn$ and service custom variables are left for the job,
+// which supports them directly.
+func (c *nagiosConfig) macroLookup(svc, host map[string]string, hostName string) func(string) (string, bool) {
+	hostAttr := func(key string) string {
+		if v := host[key]; v != "" {
+			return v
+		}
+		return hostName
+	}
+	return func(name string) (string, bool) {
+		switch {
+		case strings.HasPrefix(name, "USER"):
+			v, ok := c.resource[name]
+			return v, ok
+		case name == "HOSTNAME":
+			return hostName, true
+		case name == "HOSTADDRESS":
+			return hostAttr("address"), true
+		case name == "HOSTALIAS":
+			return hostAttr("alias"), true
+		case name == "HOSTDISPLAYNAME":
+			return hostAttr("display_name"), true
+		case name == "SERVICEDESC":
+			return svc["service_description"], true
+		case strings.HasPrefix(name, "_HOST"):
+			v, ok := customVars(host)[strings.TrimPrefix(name, "_HOST")]
+			return v, ok
+		}
+		return "", false
+	}
+}
+
+// hostgroupMembers maps every hostgroup to its hosts, combining 'members',
+// the hosts' own 'hostgroups' and nested 'hostgroup_members'.
+func (c *nagiosConfig) hostgroupMembers(hosts map[string]map[string]string) map[string][]string {
+	direct := make(map[string][]string)
+	nested := make(map[string][]string)
+	for _, g := range c.registeredObjects("hostgroup") {
+		name := g["hostgroup_name"]
+		direct[name] = append(direct[name], splitList(g["members"])...)
+		nested[name] = append(nested[name], splitList(g["hostgroup_members"])...)
+	}
+	for hostName, h := range hosts {
+		for _, g := range splitList(h["hostgroups"]) {
+			direct[g] = append(direct[g], hostName)
+		}
+	}
+
+	var expand func(name string, seen map[string]bool) []string
+	expand = func(name string, seen map[string]bool) []string {
+		if seen[name] {
+			return nil
+		}
+		seen[name] = true
+		members := slices.Clone(direct[name])
+		for _, sub := range nested[name] {
+			members = append(members, expand(sub, seen)...)
+		}
+		return members
+	}
+
+	out := make(map[string][]string, len(direct))
+	for name := range direct {
+		out[name] = expand(name, make(map[string]bool))
+	}
+	return out
+}
+
+func serviceHosts(svc map[string]string, allHosts []string, groups map[string][]string) []string {
+	include := make(map[string]bool)
+	exclude := make(map[string]bool)
+
+	for _, h := range splitList(svc["host_name"]) {
+		switch {
+		case h == "*":
+			for _, name := range allHosts {
+				include[name] = true
+			}
+		case strings.HasPrefix(h, "!"):
+			exclude[h[1:]] = true
+		default:
+			if _, ok := slices.BinarySearch(allHosts, h); ok {
+				include[h] = true
+			}
+		}
+	}
+	for _, g := range splitList(svc["hostgroup_name"]) {
+		switch {
+		case g == "*":
+			for _, members := range groups {
+				for _, name := range members {
+					include[name] = true
+				}
+			}
+		case strings.HasPrefix(g, "!"):
+			for _, name := range groups[g[1:]] {
+				exclude[name] = true
+			}
+		default:
+			for _, name := range groups[g] {
+				include[name] = true
+			}
+		}
+	}
+
+	var out []string
+	for name := range include {
+		if !exclude[name] {
+			out = append(out, name)
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+// splitCheckCommand splits "command!arg1!arg2"; '\!' is a literal '!'.
+func splitCheckCommand(s string) (string, []string) {
+	var parts []string
+	var cur strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && s[i+1] == '!' {
+			cur.WriteByte('!')
+			i++
+			continue
+		}
+		if s[i] == '!' {
+			parts = append(parts, cur.String())
+			cur.Reset()
+			continue
+		}
+		cur.WriteByte(s[i])
+	}
+	parts = append(parts, cur.String())
+	return strings.TrimSpace(parts[0]), parts[1:]
+}
+
+// customVars returns the '_NAME' attributes keyed by upper-cased NAME.
+func customVars(attrs map[string]string) map[string]string {
+	var out map[string]string
+	for k, v := range attrs {
+		if name, ok := strings.CutPrefix(k, "_"); ok && name != "" {
+			if out == nil {
+				out = make(map[string]string)
+			}
+			out[strings.ToUpper(name)] = v
+		}
+	}
+	return out
+}
+
+func nagiosInterval(v string, intervalLength time.Duration) time.Duration {
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f <= 0 {
+		return 0
+	}
+	return time.Duration(f * float64(intervalLength))
+}
+
+func firstAttr(attrs map[string]string, keys ...string) string {
+	for _, k := range keys {
+		if v := attrs[k]; v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func atoiOrZero(s string) int {
+	v, _ := strconv.Atoi(strings.TrimSpace(s))
+	return v
+}
@@ -0,0 +1,247 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package objconf reads Nagios object configuration and Icinga 2 configuration
+// and resolves it into the service checks it describes, so existing check
+// definitions can be migrated to nagios collector jobs.
+//
+// Nagios input may contain object definitions (commands, services, hosts,
+// hostgroups, timeperiods and their templates), resource file macros
+// ($USERn$=...) and main config settings (interval_length, command_file).
+// Icinga 2 input may contain CheckCommand, Service, Host and HostGroup objects,
+// templates and apply Service rules.
+//
+// Anything that cannot be represented is skipped and reported as a warning
+// rather than failing the whole import.
+package objconf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/scripts.d/pkg/timeperiod"
+)
+
+// Check is one service check on one host.
+type Check struct {
+	Host    string
+	Service string
+
+	// Plugin is empty for passive-only checks.
+	Plugin     string
+	Args       []string
+	ArgValues  []string
+	CustomVars map[string]string
+
+	CheckInterval      time.Duration
+	RetryInterval      time.Duration
+	Timeout            time.Duration
+	MaxCheckAttempts   int
+	CheckPeriod        string
+	Passive            bool
+	FreshnessThreshold time.Duration
+	CommandFile        string
+
+	// Origin describes the definition the check was created from.
+	Origin string
+}
+
+// Result is the outcome of an import.
+type Result struct {
+	Checks      []Check
+	TimePeriods []timeperiod.Config
+	Warnings    []string
+}
+
+func (r *Result) warnf(format string, args ...any) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// Parse reads the given sources and resolves the checks they define. Each
+// source is one file; Nagios sources are resolved together, so templates,
+// commands and hosts may be spread across files. Icinga 2 sources are
+// resolved together as well.
+func Parse(sources ...[]byte) (*Result, error) {
+	var nagiosSrc, icingaSrc [][]byte
+	for i, src := range sources {
+		switch detectFormat(src) {
+		case formatNagios:
+			nagiosSrc = append(nagiosSrc, src)
+		case formatIcinga:
+			icingaSrc = append(icingaSrc, src)
+		default:
+			return nil, fmt.Errorf("source %d: not a Nagios or Icinga 2 object configuration", i+1)
+		}
+	}
+
+	res := &Result{}
+	if len(nagiosSrc) > 0 {
+		if err := parseNagios(res, nagiosSrc); err != nil {
+			return nil, err
+		}
+	}
+	if len(icingaSrc) > 0 {
+		if err := parseIcinga(res, icingaSrc); err != nil {
+			return nil, err
+		}
+	}
+	if len(res.Checks) == 0 {
+		return nil, errors.New("no service checks found")
+	}
+
+	slices.SortStableFunc(res.Checks, func(a, b Check) int {
+		if c := strings.Compare(a.Service, b.Service); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Host, b.Host)
+	})
+	slices.SortFunc(res.TimePeriods, func(a, b timeperiod.Config) int { return strings.Compare(a.Name, b.Name) })
+	return res, nil
+}
+
+type sourceFormat int
+
+const (
+	formatUnknown sourceFormat = iota
+	formatNagios
+	formatIcinga
+)
+
+func detectFormat(src []byte) sourceFormat {
+	for line := range bytes.Lines(src) {
+		line = bytes.TrimSpace(line)
+		switch {
+		case len(line) == 0, line[0] == '#', line[0] == ';', bytes.HasPrefix(line, []byte("//")):
+		case bytes.HasPrefix(line, []byte("define ")), bytes.HasPrefix(line, []byte("define\t")):
+			return formatNagios
+		case bytes.HasPrefix(line, []byte("$USER")), bytes.IndexByte(line, '=') > 0 && !bytes.Contains(line, []byte(" = ")) && nagiosMainSetting(line):
+			return formatNagios
+		case bytes.HasPrefix(line, []byte("object ")), bytes.HasPrefix(line, []byte("template ")),
+			bytes.HasPrefix(line, []byte("apply ")), bytes.HasPrefix(line, []byte("const ")):
+			return formatIcinga
+		}
+	}
+	return formatUnknown
+}
+
+func nagiosMainSetting(line []byte) bool {
+	key, _, _ := bytes.Cut(line, []byte("="))
+	for _, c := range key {
+		if !(c == '_' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// splitCommandLine splits a command line into words the way a POSIX shell
+// would for simple commands. needsShell reports unquoted shell syntax
+// (pipes, redirections, command lists, substitutions) that only a shell can
+// interpret.
+func splitCommandLine(line string) (words []string, needsShell bool, err error) {
+	var cur strings.Builder
+	inWord := false
+	flush := func() {
+		if inWord {
+			words = append(words, cur.String())
+			cur.Reset()
+			inWord = false
+		}
+	}
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch c {
+		case ' ', '\t', '\n':
+			flush()
+		case '\'':
+			end := strings.IndexByte(line[i+1:], '\'')
+			if end < 0 {
+				return nil, false, errors.New("unterminated single quote")
+			}
+			cur.WriteString(line[i+1 : i+1+end])
+			inWord = true
+			i += end + 1
+		case '"':
+			inWord = true
+			i++
+			for ; i < len(line) && line[i] != '"'; i++ {
+				if line[i] == '\\' && i+1 < len(line) && strings.IndexByte(`"\$`+"`", line[i+1]) >= 0 {
+					i++
+				} else if line[i] == '`' || line[i] == '$' && i+1 < len(line) && line[i+1] == '(' {
+					needsShell = true
+				}
+				cur.WriteByte(line[i])
+			}
+			if i >= len(line) {
+				return nil, false, errors.New("unterminated double quote")
+			}
+		case '\\':
+			if i+1 < len(line) {
+				i++
+				cur.WriteByte(line[i])
+				inWord = true
+			}
+		case '|', '&', ';', '<', '>', '`', '(', ')':
+			needsShell = true
+			cur.WriteByte(c)
+			inWord = true
+		default:
+			if c == '$' && i+1 < len(line) && line[i+1] == '(' {
+				needsShell = true
+			}
+			cur.WriteByte(c)
+			inWord = true
+		}
+	}
+	flush()
+	return words, needsShell, nil
+}
+
+// commandFromLine turns an expanded command line into plugin and arguments,
+// falling back to /bin/sh -c for lines that need a shell.
+func commandFromLine(line string) (plugin string, args []string, err error) {
+	words, needsShell, err := splitCommandLine(line)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(words) == 0 {
+		return "", nil, errors.New("empty command line")
+	}
+	if needsShell {
+		return "/bin/sh", []string{"-c", strings.TrimSpace(line)}, nil
+	}
+	return words[0], words[1:], nil
+}
+
+// expandMacros replaces the $NAME$ macros lookup knows. Unknown macros are
+// kept, so they can still be expanded when the check runs.
+func expandMacros(s string, lookup func(name string) (string, bool)) string {
+	if !strings.Contains(s, "$") {
+		return s
+	}
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(s, '$')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(s[start+1:], '$')
+		if end < 0 {
+			break
+		}
+		name := s[start+1 : start+1+end]
+		b.WriteString(s[:start])
+		if v, ok := lookup(name); ok && name != "" {
+			b.WriteString(v)
+		} else {
+			b.WriteString(s[start : start+end+2])
+		}
+		s = s[start+end+2:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
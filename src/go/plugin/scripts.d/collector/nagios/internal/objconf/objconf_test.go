@@ -0,0 +1,419 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package objconf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/scripts.d/pkg/timeperiod"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const nagiosResource = `
+# resource.cfg
+$USER1$=/usr/lib/nagios/plugins
+$USER3$=s3cret
+`
+
+const nagiosMain = `
+interval_length=30
+command_file=/var/lib/nagios/rw/nagios.cmd
+service_check_timeout=20
+`
+
+const nagiosObjects = `
+define command {
+    command_name    check_http
+    command_line    $USER1$/check_http -H $HOSTADDRESS$ -u $ARG1$ -a admin:$USER3$
+}
+define command {
+    command_name    check_queue
+    command_line    $USER1$/check_queue --name $_SERVICEQUEUE$ | tee /tmp/q ; comment
+}
+
+define timeperiod {
+    timeperiod_name workhours
+    alias           Work hours
+    monday          09:00-17:00
+    tuesday         09:00-17:00
+    sunday 1        10:00-12:00
+    2026-12-24      00:00-24:00
+    exclude         holidays
+}
+define timeperiod {
+    timeperiod_name holidays
+    2026-12-25 - 2026-12-26  00:00-24:00
+}
+
+define host {
+    name                tpl-host
+    register            0
+    _ENV                prod
+}
+define host {
+    use                 tpl-host
+    host_name           web01
+    address             10.0.0.1
+}
+define host {
+    host_name           web02
+    address             10.0.0.2
+    hostgroups          web
+}
+define hostgroup {
+    hostgroup_name      web
+    members             web01
+}
+
+define service {
+    name                generic
+    register            0
+    check_interval      2
+    retry_interval      1
+    max_check_attempts  4
+}
+define service {
+    use                 generic
+    hostgroup_name      web
+    service_description HTTP
+    check_command       check_http!/health
+    check_period        workhours
+}
+define service {
+    use                 generic
+    host_name           web01
+    service_description Queue
+    check_command       check_queue
+    _QUEUE              orders
+}
+define service {
+    host_name              web02
+    service_description    Backup
+    active_checks_enabled  0
+    passive_checks_enabled 1
+    check_freshness        1
+    freshness_threshold    90000
+}
+`
+
+func TestParse_Nagios(t *testing.T) {
+	res, err := Parse([]byte(nagiosResource), []byte(nagiosMain), []byte(nagiosObjects))
+	require.NoError(t, err)
+
+	assert.Equal(t, []Check{
+		{
+			Host:        "web02",
+			Service:     "Backup",
+			Timeout:     20 * time.Second,
+			Passive:     true,
+			CommandFile: "/var/lib/nagios/rw/nagios.cmd",
+
+			FreshnessThreshold: 90000 * time.Second,
+			Origin:             "nagios service 'Backup' on host 'web02'",
+		},
+		{
+			Host:             "web01",
+			Service:          "HTTP",
+			Plugin:           "/usr/lib/nagios/plugins/check_http",
+			Args:             []string{"-H", "10.0.0.1", "-u", "$ARG1$", "-a", "admin:s3cret"},
+			ArgValues:        []string{"/health"},
+			CheckInterval:    time.Minute,
+			RetryInterval:    30 * time.Second,
+			Timeout:          20 * time.Second,
+			MaxCheckAttempts: 4,
+			CheckPeriod:      "workhours",
+			Origin:           "nagios service 'HTTP' on host 'web01'",
+		},
+		{
+			Host:             "web02",
+			Service:          "HTTP",
+			Plugin:           "/usr/lib/nagios/plugins/check_http",
+			Args:             []string{"-H", "10.0.0.2", "-u", "$ARG1$", "-a", "admin:s3cret"},
+			ArgValues:        []string{"/health"},
+			CheckInterval:    time.Minute,
+			RetryInterval:    30 * time.Second,
+			Timeout:          20 * time.Second,
+			MaxCheckAttempts: 4,
+			CheckPeriod:      "workhours",
+			Origin:           "nagios service 'HTTP' on host 'web02'",
+		},
+		{
+			Host:             "web01",
+			Service:          "Queue",
+			Plugin:           "/bin/sh",
+			Args:             []string{"-c", "/usr/lib/nagios/plugins/check_queue --name $_SERVICEQUEUE$ | tee /tmp/q"},
+			CustomVars:       map[string]string{"QUEUE": "orders"},
+			CheckInterval:    time.Minute,
+			RetryInterval:    30 * time.Second,
+			Timeout:          20 * time.Second,
+			MaxCheckAttempts: 4,
+			Origin:           "nagios service 'Queue' on host 'web01'",
+		},
+	}, res.Checks)
+
+	assert.Equal(t, []timeperiod.Config{
+		{
+			Name: "holidays",
+			Rules: []timeperiod.RuleConfig{
+				{Type: "date", Dates: []string{"2026-12-25", "2026-12-26"}, Ranges: []string{"00:00-24:00"}},
+			},
+		},
+		{
+			Name:    "workhours",
+			Alias:   "Work hours",
+			Exclude: []string{"holidays"},
+			Rules: []timeperiod.RuleConfig{
+				{Type: "weekly", Days: []string{"monday", "tuesday"}, Ranges: []string{"09:00-17:00"}},
+				{Type: "nth_weekday", Weekday: "sunday", Nth: 1, Ranges: []string{"10:00-12:00"}},
+				{Type: "date", Dates: []string{"2026-12-24"}, Ranges: []string{"00:00-24:00"}},
+			},
+		},
+	}, res.TimePeriods)
+
+	_, err = timeperiod.Compile(res.TimePeriods)
+	assert.NoError(t, err)
+}
+
+func TestParse_NagiosWarnings(t *testing.T) {
+	res, err := Parse([]byte(`
+define host {
+    host_name   h1
+}
+define command {
+    command_name check_ok
+    command_line /bin/true
+}
+define service {
+    host_name           h1
+    service_description OK
+    check_command       check_ok
+    check_period        nonexistent
+}
+define service {
+    host_name           h1
+    service_description Missing
+    check_command       check_missing
+}
+define service {
+    host_name           unknown
+    service_description Orphan
+    check_command       check_ok
+}
+`))
+	require.NoError(t, err)
+
+	require.Len(t, res.Checks, 1)
+	assert.Equal(t, "/bin/true", res.Checks[0].Plugin)
+	assert.Empty(t, res.Checks[0].CheckPeriod)
+	assert.Equal(t, []string{
+		"nagios service 'OK' on host 'h1': check_period 'nonexistent' is not defined, using the default",
+		"nagios service 'Missing' on host 'h1': command 'check_missing' is not defined, skipping",
+		"nagios: service 'Orphan': no hosts matched host_name/hostgroup_name, skipping",
+	}, res.Warnings)
+}
+
+const icingaObjects = `
+const PluginDir = "/opt/plugins"
+
+object CheckCommand "http" {
+  import "plugin-check-command"
+  import "ipv4-or-ipv6"
+
+  command = [ PluginDir + "/check_http" ]
+  timeout = 30s
+
+  arguments = {
+    "-H" = "$http_vhost$"
+    "-I" = "$check_address$"
+    "-u" = {
+      value = "$http_uri$"
+      order = -1
+    }
+    "--ssl" = {
+      set_if = "$http_ssl$"
+    }
+    "-e" = {
+      value = "$http_expect$"
+      repeat_key = false
+    }
+    "--sni" = {
+      set_if = {{ macro("$http_sni$") }}
+    }
+  }
+  vars.http_vhost = "$host.name$"
+  vars.http_ssl = false
+}
+
+object TimePeriod "workhours" {
+  display_name = "Work hours"
+  ranges = {
+    monday = "09:00-17:00"
+    friday = "09:00-13:00"
+  }
+}
+
+template Service "base" {
+  check_interval = 2m
+  retry_interval = 30s
+  max_check_attempts = 5
+}
+
+object Host "web01" {
+  address = "10.0.0.1"
+  vars.os = "Linux"
+}
+object Host "db01" {
+  address = "10.0.0.2"
+  vars.os = "Linux"
+  vars.http_ssl = true
+  groups = [ "databases" ]
+}
+object Host "win01" {
+  address = "10.0.0.3"
+  vars.os = "Windows"
+}
+
+object HostGroup "linux" {
+  assign where host.vars.os == "Linux"
+}
+
+apply Service "http" {
+  import "base"
+  check_command = "http"
+  check_period = "workhours"
+  vars.http_uri = "/"
+  vars.http_expect = [ "200", "301" ]
+  if (host.vars.os == "Linux") { vars.extra = 1 }
+
+  assign where "linux" in host.groups
+  ignore where match("db*", host.name) && !("databases" in host.groups)
+}
+
+apply Service "disk" for (disk => config in host.vars.disks) {
+  check_command = "disk"
+  assign where host.vars.disks
+}
+
+object Service "ping" {
+  host_name = "win01"
+  check_command = "ping4"
+}
+
+object Service "trap" {
+  host_name = "web01"
+  enable_active_checks = false
+}
+`
+
+func TestParse_Icinga(t *testing.T) {
+	res, err := Parse([]byte(icingaObjects))
+	require.NoError(t, err)
+
+	assert.Equal(t, []Check{
+		{
+			Host:             "db01",
+			Service:          "http",
+			Plugin:           "/opt/plugins/check_http",
+			Args:             []string{"-u", "/", "--ssl", "-H", "db01", "-I", "10.0.0.2", "-e", "200", "301"},
+			CheckInterval:    2 * time.Minute,
+			RetryInterval:    30 * time.Second,
+			Timeout:          30 * time.Second,
+			MaxCheckAttempts: 5,
+			CheckPeriod:      "workhours",
+			Origin:           "icinga2 service 'http' on host 'db01'",
+		},
+		{
+			Host:             "web01",
+			Service:          "http",
+			Plugin:           "/opt/plugins/check_http",
+			Args:             []string{"-u", "/", "-H", "web01", "-I", "10.0.0.1", "-e", "200", "301"},
+			CheckInterval:    2 * time.Minute,
+			RetryInterval:    30 * time.Second,
+			Timeout:          30 * time.Second,
+			MaxCheckAttempts: 5,
+			CheckPeriod:      "workhours",
+			Origin:           "icinga2 service 'http' on host 'web01'",
+		},
+		{
+			Host:             "web01",
+			Service:          "trap",
+			CheckInterval:    5 * time.Minute,
+			RetryInterval:    time.Minute,
+			MaxCheckAttempts: 3,
+			Passive:          true,
+			Origin:           "icinga2 service 'trap' on host 'web01'",
+		},
+	}, res.Checks)
+
+	assert.Equal(t, []timeperiod.Config{
+		{
+			Name:  "workhours",
+			Alias: "Work hours",
+			Rules: []timeperiod.RuleConfig{
+				{Type: "weekly", Days: []string{"monday"}, Ranges: []string{"09:00-17:00"}},
+				{Type: "weekly", Days: []string{"friday"}, Ranges: []string{"09:00-13:00"}},
+			},
+		},
+	}, res.TimePeriods)
+
+	assert.Equal(t, []string{
+		"icinga2: Service 'http': line 72: unsupported statement 'if', skipped",
+		"icinga2 service 'http' on host 'db01': CheckCommand 'http': argument '--sni': set_if: lambda values are not supported, leaving the argument out",
+		"icinga2 service 'http' on host 'web01': CheckCommand 'http': argument '--sni': set_if: lambda values are not supported, leaving the argument out",
+		"icinga2: apply Service 'disk': 'apply for' rules are not supported, skipping",
+		"icinga2 service 'ping' on host 'win01': CheckCommand 'ping4' is not defined, skipping (for Icinga Template Library commands include their definitions, e.g. /usr/share/icinga2/include/command-plugins.conf)",
+		"icinga2 service 'trap' on host 'web01': passive checks need 'passive.command_file' or 'passive.listen' to be set",
+	}, res.Warnings)
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := map[string][]string{
+		"unknown format":   {"just some text\n"},
+		"no checks":        {"define host {\n host_name h\n}\n"},
+		"unterminated":     {"define service {\n host_name h\n"},
+		"icinga bad token": {"object Host \"h\" {\n address = \"1\" @\n}\n"},
+	}
+
+	for name, sources := range tests {
+		t.Run(name, func(t *testing.T) {
+			var srcs [][]byte
+			for _, s := range sources {
+				srcs = append(srcs, []byte(s))
+			}
+			_, err := Parse(srcs...)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSplitCommandLine(t *testing.T) {
+	tests := map[string]struct {
+		line      string
+		want      []string
+		wantShell bool
+		wantErr   bool
+	}{
+		"plain":         {line: "/bin/check -w 1 -c 2", want: []string{"/bin/check", "-w", "1", "-c", "2"}},
+		"quotes":        {line: `/bin/check -s 'a b' -t "c \"d\""`, want: []string{"/bin/check", "-s", "a b", "-t", `c "d"`}},
+		"escaped space": {line: `/bin/check a\ b`, want: []string{"/bin/check", "a b"}},
+		"pipe":          {line: "/bin/check | grep x", want: []string{"/bin/check", "|", "grep", "x"}, wantShell: true},
+		"quoted pipe":   {line: "/bin/check '|'", want: []string{"/bin/check", "|"}},
+		"substitution":  {line: `/bin/check "$(date)"`, want: []string{"/bin/check", "$(date)"}, wantShell: true},
+		"unterminated":  {line: `/bin/check 'x`, wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			words, shell, err := splitCommandLine(test.line)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, words)
+			assert.Equal(t, test.wantShell, shell)
+		})
+	}
+}
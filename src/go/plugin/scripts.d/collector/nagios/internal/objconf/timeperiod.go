@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package objconf
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/scripts.d/pkg/timeperiod"
+)
+
+const maxExpandedDates = 366
+
+var (
+	weekdayNames = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+	reTimeRange = regexp.MustCompile(`^\d{1,2}:\d{2}-\d{1,2}:\d{2}`)
+	reDate      = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+	timeperiodDirectives = []string{"timeperiod_name", "alias", "exclude", "name", "use", "register"}
+)
+
+// periodDef is a timeperiod as written in the source configuration: rules are
+// "<date range> <time ranges>" lines in the Nagios timeperiod syntax, which
+// Icinga 2 TimePeriod 'ranges' use as well.
+type periodDef struct {
+	alias   string
+	exclude []string
+	rules   []string
+}
+
+// convertTimeperiods converts the used timeperiods, and the ones they
+// exclude, into timeperiod configs. It returns the periods that could not be
+// converted; checks using them fall back to the default period.
+func convertTimeperiods(res *Result, source string, periods map[string]periodDef, used map[string]bool) map[string]bool {
+	pending := slices.Sorted(func(yield func(string) bool) {
+		for name := range used {
+			if !yield(name) {
+				return
+			}
+		}
+	})
+	seen := make(map[string]bool)
+	dropped := make(map[string]bool)
+
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		def, ok := periods[name]
+		if !ok {
+			dropped[name] = true
+			continue
+		}
+		cfg := convertTimeperiod(res, source, name, def)
+		if _, err := timeperiod.Compile([]timeperiod.Config{{Name: cfg.Name, Rules: cfg.Rules}}); err != nil {
+			res.warnf("%s: timeperiod '%s': %v, not imported", source, name, err)
+			dropped[name] = true
+			continue
+		}
+		pending = append(pending, cfg.Exclude...)
+		res.TimePeriods = append(res.TimePeriods, cfg)
+	}
+
+	// Excluding a period that was not imported would fail compilation.
+	for i := range res.TimePeriods {
+		res.TimePeriods[i].Exclude = slices.DeleteFunc(res.TimePeriods[i].Exclude, func(name string) bool {
+			if dropped[name] {
+				res.warnf("%s: timeperiod '%s': excluded period '%s' was not imported, ignoring the exclusion", source, res.TimePeriods[i].Name, name)
+				return true
+			}
+			return false
+		})
+	}
+	return dropped
+}
+
+func convertTimeperiod(res *Result, source, name string, def periodDef) timeperiod.Config {
+	cfg := timeperiod.Config{
+		Name:    name,
+		Alias:   def.alias,
+		Exclude: slices.Clone(def.exclude),
+	}
+
+	weekly := make(map[string][]string) // day -> ranges
+	for _, line := range def.rules {
+		fields := strings.Fields(strings.ToLower(line))
+		if len(fields) == 0 {
+			continue
+		}
+		idx := slices.IndexFunc(fields, reTimeRange.MatchString)
+		if idx <= 0 {
+			res.warnf("%s: timeperiod '%s': no time ranges in '%s', skipping the rule", source, name, line)
+			continue
+		}
+		ranges := splitList(strings.Join(fields[idx:], ""))
+		spec := fields[:idx]
+
+		switch {
+		case len(spec) == 1 && slices.Contains(weekdayNames, spec[0]):
+			weekly[spec[0]] = append(weekly[spec[0]], ranges...)
+		case len(spec) == 2 && slices.Contains(weekdayNames, spec[0]):
+			nth, err := strconv.Atoi(spec[1])
+			if err != nil || nth < 1 || nth > 5 {
+				res.warnf("%s: timeperiod '%s': unsupported rule '%s', skipping", source, name, line)
+				continue
+			}
+			cfg.Rules = append(cfg.Rules, timeperiod.RuleConfig{Type: "nth_weekday", Weekday: spec[0], Nth: nth, Ranges: ranges})
+		case len(spec) == 1 && reDate.MatchString(spec[0]):
+			cfg.Rules = append(cfg.Rules, timeperiod.RuleConfig{Type: "date", Dates: []string{spec[0]}, Ranges: ranges})
+		case len(spec) == 3 && spec[1] == "-" && reDate.MatchString(spec[0]) && reDate.MatchString(spec[2]):
+			dates, err := expandDates(spec[0], spec[2])
+			if err != nil {
+				res.warnf("%s: timeperiod '%s': rule '%s': %v, skipping", source, name, line, err)
+				continue
+			}
+			cfg.Rules = append(cfg.Rules, timeperiod.RuleConfig{Type: "date", Dates: dates, Ranges: ranges})
+		default:
+			res.warnf("%s: timeperiod '%s': unsupported rule '%s', skipping", source, name, line)
+		}
+	}
+
+	// Days with the same ranges share one weekly rule, in week order.
+	var weeklyRules []timeperiod.RuleConfig
+	for _, day := range weekdayNames {
+		ranges, ok := weekly[day]
+		if !ok {
+			continue
+		}
+		i := slices.IndexFunc(weeklyRules, func(r timeperiod.RuleConfig) bool { return slices.Equal(r.Ranges, ranges) })
+		if i >= 0 {
+			weeklyRules[i].Days = append(weeklyRules[i].Days, day)
+		} else {
+			weeklyRules = append(weeklyRules, timeperiod.RuleConfig{Type: "weekly", Days: []string{day}, Ranges: ranges})
+		}
+	}
+	cfg.Rules = append(weeklyRules, cfg.Rules...)
+	return cfg
+}
+
+func expandDates(from, to string) ([]string, error) {
+	start, err := time.Parse(time.DateOnly, from)
+	if err != nil {
+		return nil, err
+	}
+	end, err := time.Parse(time.DateOnly, to)
+	if err != nil {
+		return nil, err
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("end date is before start date")
+	}
+	var dates []string
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		if len(dates) == maxExpandedDates {
+			return nil, fmt.Errorf("date range is longer than %d days", maxExpandedDates)
+		}
+		dates = append(dates, d.Format(time.DateOnly))
+	}
+	return dates, nil
+}
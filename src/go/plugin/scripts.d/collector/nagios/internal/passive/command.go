@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package passive
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	cmdProcessServiceCheckResult = "PROCESS_SERVICE_CHECK_RESULT"
+
+	// maxCommandSize is the longest accepted command line, longer lines are dropped.
+	maxCommandSize = 1024 * 1024
+)
+
+var errUnsupportedCommand = errors.New("unsupported external command")
+
+// ParseCommand parses one external command line:
+//
+//	[<timestamp>] PROCESS_SERVICE_CHECK_RESULT;<host>;<service>;<return code>;<output>
+//
+// The timestamp is optional. Escaped newlines ("\n") in the output are
+// expanded so long output and perfdata survive the single-line format.
+func ParseCommand(line string) (Result, error) {
+	line = strings.TrimSpace(line)
+
+	var r Result
+	if strings.HasPrefix(line, "[") {
+		ts, rest, ok := strings.Cut(line[1:], "]")
+		if !ok {
+			return r, errors.New("unterminated timestamp")
+		}
+		sec, err := strconv.ParseInt(strings.TrimSpace(ts), 10, 64)
+		if err != nil {
+			return r, fmt.Errorf("invalid timestamp '%s'", ts)
+		}
+		r.CheckTime = time.Unix(sec, 0)
+		line = strings.TrimSpace(rest)
+	}
+
+	name, args, _ := strings.Cut(line, ";")
+	if name != cmdProcessServiceCheckResult {
+		return r, fmt.Errorf("%w '%s'", errUnsupportedCommand, name)
+	}
+
+	// The output is the last field and may itself contain ';' (perfdata).
+	parts := strings.SplitN(args, ";", 4)
+	if len(parts) != 4 {
+		return r, fmt.Errorf("%s: want 4 arguments, got %d", name, len(parts))
+	}
+	code, err := strconv.Atoi(strings.TrimSpace(parts[2]))
+	if err != nil || code < 0 || code > 3 {
+		return r, fmt.Errorf("%s: invalid return code '%s'", name, parts[2])
+	}
+
+	r.Host = parts[0]
+	r.Service = parts[1]
+	r.ReturnCode = code
+	r.Output = strings.ReplaceAll(parts[3], `\n`, "\n")
+	if r.Service == "" {
+		return r, fmt.Errorf("%s: empty service description", name)
+	}
+	return r, nil
+}
+
+// readCommands dispatches every valid command read from rd until it fails.
+// Unsupported, malformed or oversize commands are skipped: a command file is
+// shared with other writers and one bad line must not stop the intake.
+func readCommands(rd io.Reader, dispatch func(Result) int) error {
+	br := bufio.NewReaderSize(rd, maxCommandSize)
+	for {
+		line, err := br.ReadSlice('\n')
+
+		if errors.Is(err, bufio.ErrBufferFull) {
+			size := len(line)
+			for errors.Is(err, bufio.ErrBufferFull) {
+				line, err = br.ReadSlice('\n')
+				size += len(line)
+			}
+			log.Limit("passive:oversize_command", 1, time.Minute).
+				Warningf("skipped an external command of %d bytes (max %d bytes)", size, maxCommandSize)
+			line = nil
+		}
+
+		if s := strings.TrimSpace(string(line)); s != "" {
+			if r, perr := ParseCommand(s); perr == nil {
+				if r.CheckTime.IsZero() {
+					r.CheckTime = time.Now()
+				}
+				dispatch(r)
+			}
+		}
+
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
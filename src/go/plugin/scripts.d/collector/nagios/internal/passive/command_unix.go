@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build !windows
+
+package passive
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// commandFileReopenInterval is the delay before reopening the command file after a read error.
+var commandFileReopenInterval = time.Second * 5
+
+type commandFile struct {
+	path    string
+	created bool
+
+	mu   sync.Mutex
+	file *os.File
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// openCommandFile opens (creating if needed) the named pipe at path and reads
+// external commands from it until closed. The pipe is opened read-write so the
+// reader never sees EOF when the last writer disconnects.
+func openCommandFile(path string, dispatch func(Result) int) (io.Closer, error) {
+	var created bool
+	fi, err := os.Stat(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		if err := unix.Mkfifo(path, 0o660); err != nil {
+			return nil, fmt.Errorf("create command file: %w", err)
+		}
+		created = true
+	case err != nil:
+		return nil, err
+	case fi.Mode()&os.ModeNamedPipe == 0:
+		return nil, fmt.Errorf("'%s' exists and is not a named pipe", path)
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		if created {
+			_ = os.Remove(path)
+		}
+		return nil, fmt.Errorf("open command file: %w", err)
+	}
+
+	cf := &commandFile{
+		path:    path,
+		created: created,
+		file:    f,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go cf.run(f, dispatch)
+	return cf, nil
+}
+
+// run reads commands until Close, reopening the command file if reading fails.
+func (cf *commandFile) run(f *os.File, dispatch func(Result) int) {
+	defer close(cf.done)
+
+	for {
+		err := readCommands(f, dispatch)
+		if cf.stopped() {
+			return
+		}
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+		log.Warningf("read command file '%s': %v (reopening in %s)", cf.path, err, commandFileReopenInterval)
+		_ = f.Close()
+
+		if f = cf.reopen(); f == nil {
+			return
+		}
+	}
+}
+
+// reopen opens the command file again, retrying until it succeeds or the command file is closed.
+func (cf *commandFile) reopen() *os.File {
+	for {
+		select {
+		case <-cf.stop:
+			return nil
+		case <-time.After(commandFileReopenInterval):
+		}
+
+		f, err := os.OpenFile(cf.path, os.O_RDWR, 0)
+		if err != nil {
+			log.Limit("passive:reopen_command_file:"+cf.path, 1, time.Minute).
+				Warningf("reopen command file: %v", err)
+			continue
+		}
+
+		cf.mu.Lock()
+		if cf.stopped() {
+			cf.mu.Unlock()
+			_ = f.Close()
+			return nil
+		}
+		cf.file = f
+		cf.mu.Unlock()
+		return f
+	}
+}
+
+func (cf *commandFile) stopped() bool {
+	select {
+	case <-cf.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+func (cf *commandFile) Close() error {
+	close(cf.stop)
+	cf.mu.Lock()
+	err := cf.file.Close()
+	cf.mu.Unlock()
+	<-cf.done
+	if errors.Is(err, os.ErrClosed) {
+		// run closed it after a read error
+		err = nil
+	}
+	if cf.created {
+		_ = os.Remove(cf.path)
+	}
+	return err
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build !windows
+
+package passive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribe_CommandFile_ReopensAfterReadError(t *testing.T) {
+	defer func(v time.Duration) { commandFileReopenInterval = v }(commandFileReopenInterval)
+	commandFileReopenInterval = 10 * time.Millisecond
+
+	path := filepath.Join(t.TempDir(), "nagios.cmd")
+
+	var rec recorder
+	cancel, err := Subscribe(Endpoints{CommandFile: path}, "web01", "backup", rec.deliver)
+	require.NoError(t, err)
+	defer cancel()
+
+	// fail the pending read
+	hub.mu.Lock()
+	cf := hub.endpoints["command_file "+path].closer.(*commandFile)
+	hub.mu.Unlock()
+	cf.mu.Lock()
+	_ = cf.file.Close()
+	cf.mu.Unlock()
+
+	// blocks until the command file is reopened
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString("PROCESS_SERVICE_CHECK_RESULT;web01;backup;1;WARNING\n")
+	require.NoError(t, err)
+	_ = f.Close()
+
+	require.Eventually(t, func() bool { return len(rec.get()) == 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"web01/backup=1"}, rec.get())
+}
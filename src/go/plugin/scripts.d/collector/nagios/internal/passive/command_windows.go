@@ -0,0 +1,14 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build windows
+
+package passive
+
+import (
+	"errors"
+	"io"
+)
+
+func openCommandFile(string, func(Result) int) (io.Closer, error) {
+	return nil, errors.New("external command files are not supported on windows")
+}
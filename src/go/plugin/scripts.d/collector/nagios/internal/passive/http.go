@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package passive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const maxBodySize = 1 << 20
+
+// resultJSON is the wire format accepted by the HTTP endpoint. A request body
+// is either one object or an array of them.
+type resultJSON struct {
+	Host       string `json:"host"`
+	Service    string `json:"service"`
+	ReturnCode *int   `json:"return_code"`
+	Output     string `json:"output"`
+	// CheckTime is a unix timestamp in seconds; the receive time is used when unset.
+	CheckTime int64 `json:"check_time,omitempty"`
+}
+
+func (rj resultJSON) result() (Result, error) {
+	if rj.Service == "" {
+		return Result{}, errors.New("'service' is required")
+	}
+	if rj.ReturnCode == nil {
+		return Result{}, errors.New("'return_code' is required")
+	}
+	if *rj.ReturnCode < 0 || *rj.ReturnCode > 3 {
+		return Result{}, fmt.Errorf("invalid 'return_code' %d", *rj.ReturnCode)
+	}
+	r := Result{
+		Host:       rj.Host,
+		Service:    rj.Service,
+		ReturnCode: *rj.ReturnCode,
+		Output:     rj.Output,
+	}
+	if rj.CheckTime > 0 {
+		r.CheckTime = time.Unix(rj.CheckTime, 0)
+	}
+	return r, nil
+}
+
+// ParseJSON parses an HTTP endpoint request body.
+func ParseJSON(data []byte) ([]Result, error) {
+	data = bytes.TrimSpace(data)
+
+	var items []resultJSON
+	if bytes.HasPrefix(data, []byte("[")) {
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, err
+		}
+	} else {
+		var item resultJSON
+		if err := json.Unmarshal(data, &item); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	res := make([]Result, 0, len(items))
+	for i, item := range items {
+		r, err := item.result()
+		if err != nil {
+			return nil, fmt.Errorf("result %d: %w", i, err)
+		}
+		res = append(res, r)
+	}
+	return res, nil
+}
+
+type httpEndpoint struct {
+	srv  *http.Server
+	path string
+}
+
+// listenHTTP serves the JSON intake on a unix socket ("unix:///path") or a
+// loopback TCP address. Passive results drive alerting, so the endpoint is
+// not exposed beyond the local host.
+func listenHTTP(addr string, dispatch func(Result) int) (io.Closer, error) {
+	var ln net.Listener
+	var sockPath string
+
+	if path, ok := strings.CutPrefix(addr, "unix://"); ok {
+		if path == "" {
+			return nil, errors.New("empty unix socket path")
+		}
+		if fi, err := os.Lstat(path); err == nil {
+			if fi.Mode()&os.ModeSocket == 0 {
+				return nil, fmt.Errorf("'%s' exists and is not a socket", path)
+			}
+			_ = os.Remove(path)
+		}
+		l, err := net.Listen("unix", path)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.Chmod(path, 0o660); err != nil {
+			_ = l.Close()
+			return nil, err
+		}
+		ln, sockPath = l, path
+	} else {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return nil, fmt.Errorf("'%s' is not a loopback address", addr)
+		}
+		if ln, err = net.Listen("tcp", addr); err != nil {
+			return nil, err
+		}
+	}
+
+	srv := &http.Server{
+		Handler:           newHandler(dispatch),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+	}
+	go func() { _ = srv.Serve(ln) }()
+
+	return &httpEndpoint{srv: srv, path: sockPath}, nil
+}
+
+func (e *httpEndpoint) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err := e.srv.Shutdown(ctx)
+	if e.path != "" {
+		_ = os.Remove(e.path)
+	}
+	return err
+}
+
+func newHandler(dispatch func(Result) int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		results, err := ParseJSON(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var resp struct {
+			Accepted  int `json:"accepted"`
+			Unmatched int `json:"unmatched"`
+		}
+		now := time.Now()
+		for _, res := range results {
+			if res.CheckTime.IsZero() {
+				res.CheckTime = now
+			}
+			if dispatch(res) > 0 {
+				resp.Accepted++
+			} else {
+				resp.Unmatched++
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
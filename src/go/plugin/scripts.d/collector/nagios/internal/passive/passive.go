@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package passive accepts passive check results for nagios jobs. Results come
+// from a Nagios external command file (PROCESS_SERVICE_CHECK_RESULT) or from a
+// local HTTP endpoint that takes JSON, and are delivered to the jobs that
+// subscribed for the result's host and service.
+//
+// Endpoints are shared: jobs that name the same command file or listen address
+// use one listener, which is closed when the last of them unsubscribes.
+package passive
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/logger"
+)
+
+// log is shared by all jobs: endpoints are not owned by a single job.
+var log = logger.New().With("component", "nagios/passive")
+
+// Result is one passive service check result.
+type Result struct {
+	Host       string
+	Service    string
+	ReturnCode int
+	Output     string
+	CheckTime  time.Time
+}
+
+// Endpoints are the intake endpoints a job listens on. At least one must be set.
+type Endpoints struct {
+	// CommandFile is the path of a Nagios external command file (named pipe).
+	// It is created when it does not exist.
+	CommandFile string
+	// Listen is the address of the HTTP JSON endpoint: "unix:///path/to.sock"
+	// or a loopback "host:port".
+	Listen string
+}
+
+func (e Endpoints) validate() error {
+	if e.CommandFile == "" && e.Listen == "" {
+		return errors.New("passive: at least one of 'command_file' or 'listen' is required")
+	}
+	return nil
+}
+
+type subscriber struct {
+	host    string
+	service string
+	deliver func(Result)
+}
+
+type endpoint struct {
+	refs   int
+	closer io.Closer
+}
+
+type registry struct {
+	mu        sync.Mutex
+	subs      map[*subscriber]bool
+	endpoints map[string]*endpoint
+}
+
+var hub = &registry{
+	subs:      make(map[*subscriber]bool),
+	endpoints: make(map[string]*endpoint),
+}
+
+// Subscribe delivers results for service (and host, unless it is empty) to
+// deliver, opening the endpoints if no other job uses them yet. deliver is
+// called from listener goroutines and must not block. The returned function
+// unsubscribes and closes endpoints that are no longer used.
+func Subscribe(ep Endpoints, host, service string, deliver func(Result)) (func(), error) {
+	return hub.subscribe(ep, host, service, deliver)
+}
+
+// Dispatch delivers r to every matching subscriber and returns their number.
+func Dispatch(r Result) int {
+	return hub.dispatch(r)
+}
+
+func (h *registry) subscribe(ep Endpoints, host, service string, deliver func(Result)) (func(), error) {
+	if err := ep.validate(); err != nil {
+		return nil, err
+	}
+	if service == "" {
+		return nil, errors.New("passive: service is required")
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var keys []string
+	if ep.CommandFile != "" {
+		key := "command_file " + ep.CommandFile
+		if err := h.acquire(key, func() (io.Closer, error) { return openCommandFile(ep.CommandFile, h.dispatch) }); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	if ep.Listen != "" {
+		key := "listen " + ep.Listen
+		if err := h.acquire(key, func() (io.Closer, error) { return listenHTTP(ep.Listen, h.dispatch) }); err != nil {
+			h.release(keys...)
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	sub := &subscriber{host: host, service: service, deliver: deliver}
+	h.subs[sub] = true
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			delete(h.subs, sub)
+			h.release(keys...)
+		})
+	}, nil
+}
+
+func (h *registry) acquire(key string, open func() (io.Closer, error)) error {
+	if e, ok := h.endpoints[key]; ok {
+		e.refs++
+		return nil
+	}
+	c, err := open()
+	if err != nil {
+		return fmt.Errorf("passive: %s: %w", key, err)
+	}
+	h.endpoints[key] = &endpoint{refs: 1, closer: c}
+	return nil
+}
+
+func (h *registry) release(keys ...string) {
+	for _, key := range keys {
+		e, ok := h.endpoints[key]
+		if !ok {
+			continue
+		}
+		if e.refs--; e.refs == 0 {
+			delete(h.endpoints, key)
+			_ = e.closer.Close()
+		}
+	}
+}
+
+func (h *registry) dispatch(r Result) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var n int
+	for sub := range h.subs {
+		if sub.service == r.Service && (sub.host == "" || sub.host == r.Host) {
+			sub.deliver(r)
+			n++
+		}
+	}
+	return n
+}
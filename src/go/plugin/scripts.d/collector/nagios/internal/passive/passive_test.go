@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package passive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := map[string]struct {
+		line    string
+		want    Result
+		wantErr bool
+	}{
+		"with timestamp": {
+			line: "[1700000000] PROCESS_SERVICE_CHECK_RESULT;web01;backup;1;WARNING - 2 files skipped | files=2;1;5",
+			want: Result{Host: "web01", Service: "backup", ReturnCode: 1, Output: "WARNING - 2 files skipped | files=2;1;5", CheckTime: time.Unix(1700000000, 0)},
+		},
+		"without timestamp": {
+			line: "PROCESS_SERVICE_CHECK_RESULT;web01;backup;0;OK",
+			want: Result{Host: "web01", Service: "backup", ReturnCode: 0, Output: "OK"},
+		},
+		"escaped newlines": {
+			line: `PROCESS_SERVICE_CHECK_RESULT;h;s;2;CRITICAL\nlong output`,
+			want: Result{Host: "h", Service: "s", ReturnCode: 2, Output: "CRITICAL\nlong output"},
+		},
+		"host check":          {line: "[1] PROCESS_HOST_CHECK_RESULT;web01;0;UP", wantErr: true},
+		"missing output":      {line: "PROCESS_SERVICE_CHECK_RESULT;h;s;0", wantErr: true},
+		"bad return code":     {line: "PROCESS_SERVICE_CHECK_RESULT;h;s;7;OK", wantErr: true},
+		"empty service":       {line: "PROCESS_SERVICE_CHECK_RESULT;h;;0;OK", wantErr: true},
+		"bad timestamp":       {line: "[now] PROCESS_SERVICE_CHECK_RESULT;h;s;0;OK", wantErr: true},
+		"unterminated header": {line: "[1 PROCESS_SERVICE_CHECK_RESULT;h;s;0;OK", wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseCommand(test.line)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestReadCommands(t *testing.T) {
+	oversize := "PROCESS_SERVICE_CHECK_RESULT;h;big;0;" + strings.Repeat("x", maxCommandSize) + "\n"
+	input := "PROCESS_SERVICE_CHECK_RESULT;h;a;0;OK\n" + oversize + "\nPROCESS_SERVICE_CHECK_RESULT;h;b;1;WARNING\n"
+	readErr := errors.New("read error")
+
+	var rec recorder
+	err := readCommands(io.MultiReader(strings.NewReader(input), iotest.ErrReader(readErr)), func(r Result) int {
+		rec.deliver(r)
+		return 1
+	})
+
+	assert.ErrorIs(t, err, readErr)
+	assert.Equal(t, []string{"h/a=0", "h/b=1"}, rec.get(), "the oversize command is skipped")
+}
+
+func TestParseJSON(t *testing.T) {
+	tests := map[string]struct {
+		body    string
+		want    []Result
+		wantErr bool
+	}{
+		"single object": {
+			body: `{"host":"h","service":"s","return_code":2,"output":"CRITICAL","check_time":1700000000}`,
+			want: []Result{{Host: "h", Service: "s", ReturnCode: 2, Output: "CRITICAL", CheckTime: time.Unix(1700000000, 0)}},
+		},
+		"array": {
+			body: `[{"service":"a","return_code":0},{"service":"b","return_code":3}]`,
+			want: []Result{{Service: "a", ReturnCode: 0}, {Service: "b", ReturnCode: 3}},
+		},
+		"missing return code": {body: `{"service":"s"}`, wantErr: true},
+		"missing service":     {body: `{"return_code":0}`, wantErr: true},
+		"bad return code":     {body: `{"service":"s","return_code":-1}`, wantErr: true},
+		"invalid json":        {body: `{`, wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseJSON([]byte(test.body))
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestSubscribe_HTTP(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix sockets are not used on windows")
+	}
+	sock := filepath.Join(t.TempDir(), "passive.sock")
+	ep := Endpoints{Listen: "unix://" + sock}
+
+	var rec recorder
+	cancel1, err := Subscribe(ep, "", "backup", rec.deliver)
+	require.NoError(t, err)
+	cancel2, err := Subscribe(ep, "web01", "disk", rec.deliver)
+	require.NoError(t, err)
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", sock)
+		},
+	}}
+	post := func(body string) (int, string) {
+		resp, err := client.Post("http://passive/", "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		bs, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, strings.TrimSpace(string(bs))
+	}
+
+	code, body := post(`[{"host":"any","service":"backup","return_code":0},{"host":"web02","service":"disk","return_code":2},{"host":"web01","service":"disk","return_code":1}]`)
+	assert.Equal(t, http.StatusAccepted, code)
+	assert.Equal(t, `{"accepted":2,"unmatched":1}`, body)
+	assert.Equal(t, []string{"any/backup=0", "web01/disk=1"}, rec.get())
+
+	code, _ = post(`{"service":"backup"}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	cancel1()
+	_, err = os.Stat(sock)
+	assert.NoError(t, err, "endpoint stays open while used")
+	cancel2()
+	_, err = os.Stat(sock)
+	assert.True(t, os.IsNotExist(err), "endpoint is closed with the last subscriber")
+}
+
+func TestSubscribe_CommandFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("external command files are not supported on windows")
+	}
+	path := filepath.Join(t.TempDir(), "nagios.cmd")
+
+	var rec recorder
+	cancel, err := Subscribe(Endpoints{CommandFile: path}, "web01", "backup", rec.deliver)
+	require.NoError(t, err)
+
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString("garbage\n[1700000000] PROCESS_HOST_CHECK_RESULT;web01;0;UP\n[1700000000] PROCESS_SERVICE_CHECK_RESULT;web01;backup;2;CRITICAL | age=90000s\n")
+	require.NoError(t, err)
+	_ = f.Close()
+
+	require.Eventually(t, func() bool { return len(rec.get()) == 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"web01/backup=2"}, rec.get())
+
+	cancel()
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "created command file is removed")
+}
+
+func TestSubscribe_Errors(t *testing.T) {
+	deliver := func(Result) {}
+
+	_, err := Subscribe(Endpoints{}, "", "s", deliver)
+	assert.Error(t, err)
+	_, err = Subscribe(Endpoints{Listen: "127.0.0.1:0"}, "", "", deliver)
+	assert.Error(t, err)
+	_, err = Subscribe(Endpoints{Listen: "0.0.0.0:0"}, "", "s", deliver)
+	assert.ErrorContains(t, err, "not a loopback address")
+
+	if runtime.GOOS != "windows" {
+		regular := filepath.Join(t.TempDir(), "regular")
+		require.NoError(t, os.WriteFile(regular, nil, 0o600))
+		_, err = Subscribe(Endpoints{CommandFile: regular}, "", "s", deliver)
+		assert.ErrorContains(t, err, "not a named pipe")
+	}
+}
+
+type recorder struct {
+	mu  sync.Mutex
+	got []string
+}
+
+func (r *recorder) deliver(res Result) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.got = append(r.got, fmt.Sprintf("%s/%s=%d", res.Host, res.Service, res.ReturnCode))
+}
+
+func (r *recorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.got...)
+}
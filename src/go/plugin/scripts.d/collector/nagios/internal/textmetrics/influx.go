@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package textmetrics
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseInflux parses InfluxDB line protocol:
+//
+//	measurement[,tag=value...] field=value[,field=value...] [timestamp]
+//
+// Every numeric or boolean field becomes a gauge named
+// <measurement>_<field>, or just <measurement> for a field called "value", the
+// way Telegraf names them for Prometheus. Tags become labels. String fields and
+// timestamps are ignored.
+func ParseInflux(data []byte) ([]Sample, error) {
+	var samples []Sample
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for num := 1; sc.Scan(); num++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parsed, err := parseInfluxLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", num, err)
+		}
+		samples = append(samples, parsed...)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
+
+func parseInfluxLine(line string) ([]Sample, error) {
+	sections := splitUnescaped(line, ' ', true)
+	if len(sections) < 2 || len(sections) > 3 {
+		return nil, errors.New("expected measurement, fields and an optional timestamp")
+	}
+
+	keys := splitUnescaped(sections[0], ',', false)
+	measurement := unescapeInflux(keys[0])
+	if measurement == "" {
+		return nil, errors.New("missing measurement")
+	}
+
+	var labels []Label
+	for _, tag := range keys[1:] {
+		k, v, ok := splitKeyValue(tag)
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid tag '%s'", tag)
+		}
+		labels = append(labels, Label{Key: k, Value: v})
+	}
+	labels = sortLabels(labels)
+
+	var samples []Sample
+	for _, field := range splitUnescaped(sections[1], ',', true) {
+		k, raw, ok := splitKeyValue(field)
+		if !ok || k == "" || raw == "" {
+			return nil, fmt.Errorf("invalid field '%s'", field)
+		}
+		value, numeric, err := parseInfluxFieldValue(raw)
+		if err != nil {
+			return nil, fmt.Errorf("field '%s': %w", k, err)
+		}
+		if !numeric {
+			continue
+		}
+		name := measurement
+		if k != "value" {
+			name += "_" + k
+		}
+		samples = append(samples, Sample{Name: name, Labels: labels, Value: value})
+	}
+	return samples, nil
+}
+
+func parseInfluxFieldValue(raw string) (float64, bool, error) {
+	switch raw {
+	case "t", "T", "true", "True", "TRUE":
+		return 1, true, nil
+	case "f", "F", "false", "False", "FALSE":
+		return 0, true, nil
+	}
+	if raw[0] == '"' {
+		if len(raw) < 2 || raw[len(raw)-1] != '"' {
+			return 0, false, errors.New("unterminated string")
+		}
+		return 0, false, nil
+	}
+
+	var err error
+	var v float64
+	switch raw[len(raw)-1] {
+	case 'i':
+		var n int64
+		n, err = strconv.ParseInt(raw[:len(raw)-1], 10, 64)
+		v = float64(n)
+	case 'u':
+		var n uint64
+		n, err = strconv.ParseUint(raw[:len(raw)-1], 10, 64)
+		v = float64(n)
+	default:
+		v, err = strconv.ParseFloat(raw, 64)
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid value '%s'", raw)
+	}
+	return v, true, nil
+}
+
+// splitUnescaped splits s on sep, skipping backslash-escaped characters and,
+// when quotes is set, anything inside double quotes.
+func splitUnescaped(s string, sep byte, quotes bool) []string {
+	var parts []string
+	start, quoted := 0, false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\':
+			i++
+		case c == '"' && quotes:
+			quoted = !quoted
+		case c == sep && !quoted:
+			parts = append(parts, s[start:i])
+			start = i + 1
+			if sep == ' ' {
+				for start < len(s) && s[start] == ' ' {
+					start++
+				}
+				i = start - 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+func splitKeyValue(s string) (string, string, bool) {
+	parts := splitUnescaped(s, '=', true)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return unescapeInflux(parts[0]), unescapeInflux(parts[1]), true
+}
+
+func unescapeInflux(s string) string {
+	if !strings.Contains(s, `\`) || strings.HasPrefix(s, `"`) {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && strings.IndexByte(`, ="\`, s[i+1]) >= 0 {
+			i++
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
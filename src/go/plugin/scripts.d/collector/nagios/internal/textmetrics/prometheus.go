@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package textmetrics
+
+import (
+	"math"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/netdata/netdata/go/plugins/pkg/prometheus"
+)
+
+// ParsePrometheus parses the Prometheus text exposition format. Histograms and
+// summaries are kept flat: buckets, sums and counts become counters, quantiles
+// become gauges. NaN samples are dropped.
+func ParsePrometheus(data []byte) ([]Sample, error) {
+	batch, err := prometheus.ParseSamples(data)
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]Sample, 0, len(batch.Samples))
+	for _, s := range batch.Samples {
+		if math.IsNaN(s.Value) {
+			continue
+		}
+		sample := Sample{
+			Name:    s.Name,
+			Value:   s.Value,
+			Counter: isCounter(s),
+		}
+		for _, lbl := range s.Labels {
+			sample.Labels = append(sample.Labels, Label{Key: lbl.Name, Value: lbl.Value})
+		}
+		sample.Labels = sortLabels(sample.Labels)
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+func isCounter(s prometheus.Sample) bool {
+	switch s.Kind {
+	case prometheus.SampleKindHistogramBucket,
+		prometheus.SampleKindHistogramSum,
+		prometheus.SampleKindHistogramCount,
+		prometheus.SampleKindSummarySum,
+		prometheus.SampleKindSummaryCount:
+		return true
+	case prometheus.SampleKindScalar:
+		return s.FamilyType == model.MetricTypeCounter
+	default:
+		return false
+	}
+}
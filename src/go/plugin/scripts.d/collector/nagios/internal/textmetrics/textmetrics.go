@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package textmetrics parses script output written in metric exposition
+// formats other than Nagios plugin output: InfluxDB line protocol, as consumed
+// by the Telegraf exec input, and the Prometheus text format, as read by the
+// node_exporter textfile collector.
+package textmetrics
+
+import (
+	"sort"
+)
+
+// Sample is one parsed series.
+type Sample struct {
+	Name    string
+	Labels  []Label // sorted by key
+	Value   float64
+	Counter bool
+}
+
+// Label is a series label.
+type Label struct {
+	Key   string
+	Value string
+}
+
+func sortLabels(lbs []Label) []Label {
+	sort.Slice(lbs, func(i, j int) bool { return lbs[i].Key < lbs[j].Key })
+	return lbs
+}
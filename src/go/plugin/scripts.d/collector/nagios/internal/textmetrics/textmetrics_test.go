@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package textmetrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInflux(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    []Sample
+		wantErr bool
+	}{
+		"fields, tags and timestamp": {
+			input: "# comment\n\nbackup,host=web01,dir=/var size=12i,ok=t,duration=1.5,msg=\"done, ok\" 1700000000000000000\n",
+			want: []Sample{
+				{Name: "backup_size", Labels: []Label{{"dir", "/var"}, {"host", "web01"}}, Value: 12},
+				{Name: "backup_ok", Labels: []Label{{"dir", "/var"}, {"host", "web01"}}, Value: 1},
+				{Name: "backup_duration", Labels: []Label{{"dir", "/var"}, {"host", "web01"}}, Value: 1.5},
+			},
+		},
+		"value field and escapes": {
+			input: `queue\ depth,name=a\,b value=3u`,
+			want: []Sample{
+				{Name: "queue depth", Labels: []Label{{"name", "a,b"}}, Value: 3},
+			},
+		},
+		"missing fields": {
+			input:   "backup,host=web01",
+			wantErr: true,
+		},
+		"invalid value": {
+			input:   "backup size=12x",
+			wantErr: true,
+		},
+		"invalid tag": {
+			input:   "backup,host size=1",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseInflux([]byte(tc.input))
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParsePrometheus(t *testing.T) {
+	input := `# HELP node_backup_age_seconds Age of the last backup.
+# TYPE node_backup_age_seconds gauge
+node_backup_age_seconds{target="db"} 3600
+# TYPE node_backup_runs_total counter
+node_backup_runs_total 42
+# TYPE node_backup_duration_seconds histogram
+node_backup_duration_seconds_bucket{le="60"} 3
+node_backup_duration_seconds_bucket{le="+Inf"} 4
+node_backup_duration_seconds_sum 190
+node_backup_duration_seconds_count 4
+node_backup_pending NaN
+`
+	got, err := ParsePrometheus([]byte(input))
+	require.NoError(t, err)
+	assert.Equal(t, []Sample{
+		{Name: "node_backup_age_seconds", Labels: []Label{{"target", "db"}}, Value: 3600},
+		{Name: "node_backup_runs_total", Value: 42, Counter: true},
+		{Name: "node_backup_duration_seconds_bucket", Labels: []Label{{"le", "60.0"}}, Value: 3, Counter: true},
+		{Name: "node_backup_duration_seconds_bucket", Labels: []Label{{"le", "+Inf"}}, Value: 4, Counter: true},
+		{Name: "node_backup_duration_seconds_sum", Value: 190, Counter: true},
+		{Name: "node_backup_duration_seconds_count", Value: 4, Counter: true},
+	}, got)
+
+	_, err = ParsePrometheus([]byte("node_backup_age_seconds{ 1\n"))
+	assert.Error(t, err)
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"maps"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/netdata/netdata/go/plugins/pkg/confopt"
@@ -18,6 +20,9 @@ const (
 	defaultTimeout          = 5 * time.Second
 	defaultMaxCheckAttempts = 3
 	maxArgMacros            = 32
+
+	defaultFlapLowThreshold  = 5.0
+	defaultFlapHighThreshold = 20.0
 )
 
 // JobConfig is the user-facing Nagios job configuration surface.
@@ -36,6 +41,49 @@ type JobConfig struct {
 	WorkingDirectory string            `yaml:"working_directory,omitempty" json:"working_directory"`
 	CustomVars       map[string]string `yaml:"custom_vars,omitempty" json:"custom_vars"`
 	CheckPeriod      string            `yaml:"check_period,omitempty" json:"check_period"`
+	OutputFormat     string            `yaml:"output_format,omitempty" json:"output_format"`
+	Passive          PassiveConfig     `yaml:"passive,omitempty" json:"passive"`
+	DependsOn        DependencyConfig  `yaml:"depends_on,omitempty" json:"depends_on"`
+	FlapDetection    FlapConfig        `yaml:"flap_detection,omitempty" json:"flap_detection"`
+}
+
+// PassiveConfig lets a job accept passive check results submitted by external
+// processes. A passive job without a plugin never runs a check itself.
+type PassiveConfig struct {
+	Enabled bool `yaml:"enabled,omitempty" json:"enabled"`
+	// Host and Service select the results for this job. An empty host matches
+	// any host; an empty service defaults to the job name.
+	Host    string `yaml:"host,omitempty" json:"host"`
+	Service string `yaml:"service,omitempty" json:"service"`
+	// FreshnessThreshold is how long a passive result stays current. A stale job
+	// runs its plugin when it has one and goes UNKNOWN otherwise. Zero disables
+	// freshness checking.
+	FreshnessThreshold confopt.Duration `yaml:"freshness_threshold,omitempty" json:"freshness_threshold"`
+	CommandFile        string           `yaml:"command_file,omitempty" json:"command_file"`
+	Listen             string           `yaml:"listen,omitempty" json:"listen"`
+}
+
+// DependencyConfig names the jobs this job depends on, typically the check of
+// the host its service runs on. While a parent is in one of the failure states,
+// the job does not run its check and reports UNREACHABLE instead.
+type DependencyConfig struct {
+	Parents []string `yaml:"parents,omitempty" json:"parents"`
+	// FailureStates are the parent hard states that suppress this job.
+	// Defaults to CRITICAL.
+	FailureStates []string `yaml:"failure_states,omitempty" json:"failure_states"`
+}
+
+// FlapConfig enables Nagios-style flap detection over the last 21 results.
+// A job starts flapping when its weighted percent state change exceeds
+// HighThreshold and stops when it drops below LowThreshold.
+type FlapConfig struct {
+	Enabled       bool    `yaml:"enabled,omitempty" json:"enabled"`
+	LowThreshold  float64 `yaml:"low_threshold,omitempty" json:"low_threshold"`
+	HighThreshold float64 `yaml:"high_threshold,omitempty" json:"high_threshold"`
+}
+
+func (cfg JobConfig) passiveOnly() bool {
+	return cfg.Passive.Enabled && cfg.Plugin == ""
 }
 
 func defaultedJobConfig(cfg JobConfig) JobConfig {
@@ -60,6 +108,20 @@ func defaultedJobConfig(cfg JobConfig) JobConfig {
 	if cfg.CheckPeriod == "" {
 		cfg.CheckPeriod = timeperiod.DefaultPeriodName
 	}
+	if cfg.OutputFormat == "" {
+		cfg.OutputFormat = outputFormatNagios
+	}
+	if len(cfg.DependsOn.Parents) > 0 && len(cfg.DependsOn.FailureStates) == 0 {
+		cfg.DependsOn.FailureStates = []string{nagiosStateCritical}
+	}
+	if cfg.FlapDetection.Enabled {
+		if cfg.FlapDetection.LowThreshold == 0 {
+			cfg.FlapDetection.LowThreshold = defaultFlapLowThreshold
+		}
+		if cfg.FlapDetection.HighThreshold == 0 {
+			cfg.FlapDetection.HighThreshold = defaultFlapHighThreshold
+		}
+	}
 	return cfg
 }
 
@@ -71,10 +133,10 @@ func (cfg JobConfig) validate() error {
 	if cfg.Name == "" {
 		return fmt.Errorf("job name is required")
 	}
-	if cfg.Plugin == "" {
+	if cfg.Plugin == "" && !cfg.Passive.Enabled {
 		return fmt.Errorf("job '%s': plugin path is required", cfg.Name)
 	}
-	if !filepath.IsAbs(cfg.Plugin) {
+	if cfg.Plugin != "" && !filepath.IsAbs(cfg.Plugin) {
 		return fmt.Errorf("job '%s': plugin path must be absolute", cfg.Name)
 	}
 	if len(cfg.ArgValues) > maxArgMacros {
@@ -92,6 +154,35 @@ func (cfg JobConfig) validate() error {
 	if cfg.MaxCheckAttempts < 1 {
 		return fmt.Errorf("job '%s': max_check_attempts must be >= 1", cfg.Name)
 	}
+	switch cfg.OutputFormat {
+	case outputFormatNagios, outputFormatInflux, outputFormatPrometheus:
+	default:
+		return fmt.Errorf("job '%s': output_format must be one of '%s', '%s' or '%s'",
+			cfg.Name, outputFormatNagios, outputFormatInflux, outputFormatPrometheus)
+	}
+	if cfg.Passive.Enabled {
+		if cfg.Passive.CommandFile == "" && cfg.Passive.Listen == "" {
+			return fmt.Errorf("job '%s': passive requires 'command_file' or 'listen'", cfg.Name)
+		}
+		if cfg.Passive.FreshnessThreshold < 0 {
+			return fmt.Errorf("job '%s': passive freshness_threshold must be >= 0", cfg.Name)
+		}
+	}
+	for _, parent := range cfg.DependsOn.Parents {
+		if parent == "" || parent == cfg.Name {
+			return fmt.Errorf("job '%s': depends_on parents must name other jobs", cfg.Name)
+		}
+	}
+	for _, state := range cfg.DependsOn.FailureStates {
+		if normalizeState(state) != strings.ToUpper(strings.TrimSpace(state)) {
+			return fmt.Errorf("job '%s': depends_on failure state '%s' is not a Nagios service state", cfg.Name, state)
+		}
+	}
+	if flap := cfg.FlapDetection; flap.Enabled {
+		if flap.LowThreshold < 0 || flap.HighThreshold > 100 || flap.LowThreshold >= flap.HighThreshold {
+			return fmt.Errorf("job '%s': flap_detection thresholds must satisfy 0 <= low_threshold < high_threshold <= 100", cfg.Name)
+		}
+	}
 	return nil
 }
 
@@ -100,11 +191,22 @@ func (cfg JobConfig) normalized() (JobConfig, error) {
 	if err := cfg.validate(); err != nil {
 		return JobConfig{}, err
 	}
+	if cfg.passiveOnly() && cfg.CheckName == "" {
+		cfg.CheckName = cfg.Name
+	}
 	cfg.CheckName = normalizedCheckName(cfg.CheckName, cfg.Plugin)
+	if cfg.Passive.Enabled && cfg.Passive.Service == "" {
+		cfg.Passive.Service = cfg.Name
+	}
 
 	cfg.Args = append([]string{}, cfg.Args...)
 	cfg.ArgValues = append([]string{}, cfg.ArgValues...)
 	cfg.Environment = maps.Clone(cfg.Environment)
 	cfg.CustomVars = maps.Clone(cfg.CustomVars)
+	cfg.DependsOn.Parents = slices.Clone(cfg.DependsOn.Parents)
+	cfg.DependsOn.FailureStates = slices.Clone(cfg.DependsOn.FailureStates)
+	for i, state := range cfg.DependsOn.FailureStates {
+		cfg.DependsOn.FailureStates[i] = normalizeState(state)
+	}
 	return cfg, nil
 }
@@ -43,6 +43,42 @@ func TestJobConfigValidate(t *testing.T) {
 			cfg:     JobConfig{Name: "sample", Plugin: "check_ping"},
 			wantErr: true,
 		},
+		"passive without plugin": {
+			cfg: JobConfig{Name: "sample", Passive: PassiveConfig{Enabled: true, Listen: "127.0.0.1:5668"}},
+		},
+		"passive without endpoints": {
+			cfg:     JobConfig{Name: "sample", Passive: PassiveConfig{Enabled: true}},
+			wantErr: true,
+		},
+		"passive negative freshness_threshold": {
+			cfg:     JobConfig{Name: "sample", Passive: PassiveConfig{Enabled: true, CommandFile: "/tmp/nagios.cmd", FreshnessThreshold: -1}},
+			wantErr: true,
+		},
+		"prometheus output format": {
+			cfg: JobConfig{Name: "sample", Plugin: "/bin/true", OutputFormat: "prometheus"},
+		},
+		"unknown output format": {
+			cfg:     JobConfig{Name: "sample", Plugin: "/bin/true", OutputFormat: "graphite"},
+			wantErr: true,
+		},
+		"depends on other jobs": {
+			cfg: JobConfig{Name: "sample", Plugin: "/bin/true", DependsOn: DependencyConfig{Parents: []string{"host"}, FailureStates: []string{"warning", "CRITICAL"}}},
+		},
+		"depends on itself": {
+			cfg:     JobConfig{Name: "sample", Plugin: "/bin/true", DependsOn: DependencyConfig{Parents: []string{"sample"}}},
+			wantErr: true,
+		},
+		"depends_on invalid failure state": {
+			cfg:     JobConfig{Name: "sample", Plugin: "/bin/true", DependsOn: DependencyConfig{Parents: []string{"host"}, FailureStates: []string{"DOWN"}}},
+			wantErr: true,
+		},
+		"flap_detection default thresholds": {
+			cfg: JobConfig{Name: "sample", Plugin: "/bin/true", FlapDetection: FlapConfig{Enabled: true}},
+		},
+		"flap_detection inverted thresholds": {
+			cfg:     JobConfig{Name: "sample", Plugin: "/bin/true", FlapDetection: FlapConfig{Enabled: true, LowThreshold: 30, HighThreshold: 10}},
+			wantErr: true,
+		},
 	}
 
 	for name, tc := range tests {
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package nagios
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/scripts.d/collector/nagios/internal/output"
+	"github.com/netdata/netdata/go/plugins/plugin/scripts.d/collector/nagios/internal/passive"
+)
+
+// maxPendingPassiveResults bounds the results queued between collection cycles;
+// only the newest ones matter, the state machine keeps the latest state anyway.
+const maxPendingPassiveResults = 100
+
+type subscribePassiveFunc func(ep passive.Endpoints, host, service string, deliver func(passive.Result)) (func(), error)
+
+type passiveIntake struct {
+	mu      sync.Mutex
+	pending []passive.Result
+	cancel  func()
+
+	// lastCheck is the check time of the last applied result.
+	lastCheck time.Time
+	// freshSince is where the freshness threshold is counted from: the last
+	// applied result, the start of the intake, or the last staleness action.
+	freshSince time.Time
+}
+
+func (p *passiveIntake) push(r passive.Result) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.pending) >= maxPendingPassiveResults {
+		p.pending = p.pending[1:]
+	}
+	p.pending = append(p.pending, r)
+}
+
+func (p *passiveIntake) drain() []passive.Result {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	res := p.pending
+	p.pending = nil
+	return res
+}
+
+func (c *Collector) startPassive() error {
+	c.stopPassive()
+	cfg := c.job.config.Passive
+	if !cfg.Enabled {
+		return nil
+	}
+
+	ep := passive.Endpoints{CommandFile: cfg.CommandFile, Listen: cfg.Listen}
+	cancel, err := c.subscribePassive(ep, cfg.Host, cfg.Service, c.passive.push)
+	if err != nil {
+		return err
+	}
+	c.passive.cancel = cancel
+	c.passive.freshSince = c.now()
+	return nil
+}
+
+func (c *Collector) stopPassive() {
+	if c.passive.cancel != nil {
+		c.passive.cancel()
+		c.passive.cancel = nil
+	}
+}
+
+// applyPassiveResults feeds queued passive results through the same state
+// machine and perfdata router as active checks, oldest first. Results older
+// than the last applied one are dropped. Passive results do not reschedule
+// active checks.
+func (c *Collector) applyPassiveResults(now time.Time) {
+	results := c.passive.drain()
+	slices.SortStableFunc(results, func(a, b passive.Result) int { return a.CheckTime.Compare(b.CheckTime) })
+
+	for _, r := range results {
+		if r.CheckTime.Before(c.passive.lastCheck) {
+			continue
+		}
+		state := serviceStateFromExecution(r.ReturnCode, nil)
+		parsed := output.Parse([]byte(r.Output))
+		c.state.recordResult(state, state)
+		c.state.rememberPerf(c.router.route(c.job.config.CheckName, parsed.Perfdata))
+		c.passive.lastCheck = r.CheckTime
+		// A sender clock running ahead must not keep the job fresh indefinitely.
+		c.passive.freshSince = minTime(r.CheckTime, now)
+	}
+}
+
+// checkPassiveFreshness handles a job whose last passive result is older than
+// freshness_threshold: a job with a plugin runs an active check right away, a
+// passive-only job goes UNKNOWN. The threshold then starts over, so a job
+// that stays silent keeps going through soft attempts to a hard state.
+func (c *Collector) checkPassiveFreshness(now time.Time) {
+	cfg := c.job.config.Passive
+	threshold := cfg.FreshnessThreshold.Duration()
+	if !cfg.Enabled || threshold <= 0 || now.Sub(c.passive.freshSince) < threshold {
+		return
+	}
+	c.passive.freshSince = now
+
+	if !c.job.config.passiveOnly() {
+		c.state.nextDue = now
+		return
+	}
+	c.state.recordResult(nagiosStateUnknown, nagiosStateUnknown)
+	c.state.rememberPerf(perfRouteResult{})
+}
+
+func (c *Collector) passiveResultAge(now time.Time) float64 {
+	last := c.passive.lastCheck
+	if last.IsZero() {
+		last = c.passive.freshSince
+	}
+	return max(now.Sub(last).Seconds(), 0)
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
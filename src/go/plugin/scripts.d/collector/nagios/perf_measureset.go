@@ -49,6 +49,7 @@ type perfThresholdStateSet struct {
 type perfRouteResult struct {
 	values          []perfValueMeasureSet
 	thresholdStates []perfThresholdStateSet
+	series          []scriptSeries
 }
 
 func perfMeasureSetFieldSpecs() []metrix.MeasureFieldSpec {
@@ -13,7 +13,8 @@ import (
 const defaultPerfdataMetricKeyBudget = 64
 
 type perfdataRouter struct {
-	maxPerJob int
+	maxPerJob       int
+	maxSeriesPerJob int
 }
 
 func newPerfdataRouter(maxPerJob int) *perfdataRouter {
@@ -21,7 +22,8 @@ func newPerfdataRouter(maxPerJob int) *perfdataRouter {
 		maxPerJob = defaultPerfdataMetricKeyBudget
 	}
 	return &perfdataRouter{
-		maxPerJob: maxPerJob,
+		maxPerJob:       maxPerJob,
+		maxSeriesPerJob: defaultScriptSeriesBudget,
 	}
 }
 
@@ -9,13 +9,15 @@ import (
 )
 
 const (
-	metricJobStateOK       = "ok"
-	metricJobStateWarning  = "warning"
-	metricJobStateCritical = "critical"
-	metricJobStateUnknown  = "unknown"
-	metricJobStateTimeout  = "timeout"
-	metricJobStatePaused   = "paused"
-	metricStateRetry       = "retry"
+	metricJobStateOK          = "ok"
+	metricJobStateWarning     = "warning"
+	metricJobStateCritical    = "critical"
+	metricJobStateUnknown     = "unknown"
+	metricJobStateTimeout     = "timeout"
+	metricJobStatePaused      = "paused"
+	metricJobStateUnreachable = "unreachable"
+	metricStateRetry          = "retry"
+	metricStateFlapping       = "flapping"
 )
 
 var jobExecutionStateNames = []string{
@@ -25,16 +27,21 @@ var jobExecutionStateNames = []string{
 	metricJobStateUnknown,
 	metricJobStateTimeout,
 	metricJobStatePaused,
+	metricJobStateUnreachable,
 	metricStateRetry,
+	metricStateFlapping,
 }
 
 // projectJobExecutionState maps runtime state into the public metric surface.
-func projectJobExecutionState(state string, retrying bool) metrix.StateSetPoint {
+func projectJobExecutionState(state string, retrying, flapping bool) metrix.StateSetPoint {
 	normalized := normalizeJobStateForMetric(state)
 	actives := []string{normalized}
 	if retrying && normalized != metricJobStatePaused {
 		actives = append(actives, metricStateRetry)
 	}
+	if flapping {
+		actives = append(actives, metricStateFlapping)
+	}
 	return stateSetPoint(jobExecutionStateNames, actives...)
 }
 
@@ -62,6 +69,8 @@ func normalizeJobStateForMetric(state string) string {
 		return metricJobStateTimeout
 	case jobStatePaused:
 		return metricJobStatePaused
+	case jobStateUnreachable:
+		return metricJobStateUnreachable
 	default:
 		return metricJobStateUnknown
 	}
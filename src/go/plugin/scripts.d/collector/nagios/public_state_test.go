@@ -10,16 +10,29 @@ import (
 
 func TestProjectJobExecutionState(t *testing.T) {
 	t.Run("paused suppresses retry", func(t *testing.T) {
-		point := projectJobExecutionState(jobStatePaused, true)
+		point := projectJobExecutionState(jobStatePaused, true, false)
 		assert.True(t, point.States[metricJobStatePaused])
 		assert.False(t, point.States[metricStateRetry])
 	})
 
 	t.Run("warning keeps retry", func(t *testing.T) {
-		point := projectJobExecutionState(nagiosStateWarning, true)
+		point := projectJobExecutionState(nagiosStateWarning, true, false)
 		assert.True(t, point.States[metricJobStateWarning])
 		assert.True(t, point.States[metricStateRetry])
 	})
+
+	t.Run("flapping is reported alongside the state", func(t *testing.T) {
+		point := projectJobExecutionState(nagiosStateCritical, false, true)
+		assert.True(t, point.States[metricJobStateCritical])
+		assert.True(t, point.States[metricStateFlapping])
+		assert.False(t, point.States[metricStateRetry])
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		point := projectJobExecutionState(jobStateUnreachable, false, false)
+		assert.True(t, point.States[metricJobStateUnreachable])
+		assert.False(t, point.States[metricJobStateUnknown])
+	})
 }
 
 func TestProjectPerfThresholdAlertState(t *testing.T) {
@@ -9,6 +9,7 @@ import (
 	"github.com/netdata/netdata/go/plugins/logger"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/ndexec"
 	outputpkg "github.com/netdata/netdata/go/plugins/plugin/scripts.d/collector/nagios/internal/output"
+	"github.com/netdata/netdata/go/plugins/plugin/scripts.d/collector/nagios/internal/textmetrics"
 )
 
 type checkRunner interface {
@@ -28,6 +29,7 @@ type checkRunResult struct {
 	ServiceState string
 	JobState     string
 	Parsed       outputpkg.ParsedOutput
+	Samples      []textmetrics.Sample
 	Duration     time.Duration
 	Usage        ndexec.ResourceUsage
 }
@@ -77,7 +79,22 @@ func (systemCheckRunner) Run(ctx context.Context, req checkRunRequest) (checkRun
 	}
 	result.ServiceState = serviceStateFromExecution(result.ExitCode, err)
 	result.JobState = jobStateFromExecution(result.ExitCode, err)
-	result.Parsed = outputpkg.Parse(output)
+	if req.Job.OutputFormat != outputFormatInflux && req.Job.OutputFormat != outputFormatPrometheus {
+		result.Parsed = outputpkg.Parse(output)
+		return result, err
+	}
+
+	samples, parseErr := parseScriptMetrics(req.Job.OutputFormat, output)
+	if parseErr != nil {
+		if req.Log != nil {
+			req.Log.Warningf("job '%s': failed to parse %s output: %v", req.Job.Name, req.Job.OutputFormat, parseErr)
+		}
+		if err == nil {
+			result.ServiceState = nagiosStateUnknown
+			result.JobState = nagiosStateUnknown
+		}
+	}
+	result.Samples = samples
 	return result, err
 }
 
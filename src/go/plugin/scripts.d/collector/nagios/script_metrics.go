@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package nagios
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/pkg/metrix"
+	"github.com/netdata/netdata/go/plugins/plugin/scripts.d/collector/nagios/internal/textmetrics"
+)
+
+const defaultScriptSeriesBudget = 256
+
+const (
+	outputFormatNagios     = "nagios"
+	outputFormatInflux     = "influx"
+	outputFormatPrometheus = "prometheus"
+)
+
+type scriptSeries struct {
+	name      string
+	checkName string
+	labels    []metrix.Label
+	counter   bool
+	value     metrix.SampleValue
+}
+
+func parseScriptMetrics(format string, output []byte) ([]textmetrics.Sample, error) {
+	switch format {
+	case outputFormatInflux:
+		return textmetrics.ParseInflux(output)
+	case outputFormatPrometheus:
+		return textmetrics.ParsePrometheus(output)
+	default:
+		return nil, fmt.Errorf("unknown output format '%s'", format)
+	}
+}
+
+// routeSamples turns samples parsed from influx or prometheus output into
+// series named metrics.<check>.<metric>. Like perfdata, the series are capped
+// per job, keeping the first ones in lexical order of their identity.
+func (r *perfdataRouter) routeSamples(checkName string, samples []textmetrics.Sample) []scriptSeries {
+	if len(samples) == 0 {
+		return nil
+	}
+	source := perfSourceFromCheckName(checkName)
+
+	type keyed struct {
+		key    string
+		series scriptSeries
+	}
+	items := make([]keyed, 0, len(samples))
+	for _, s := range samples {
+		if !isFinite(s.Value) {
+			continue
+		}
+		series := scriptSeries{
+			name:      fmt.Sprintf("metrics.%s.%s", source, sanitizeMetricKey(s.Name)),
+			checkName: source,
+			counter:   s.Counter,
+			value:     s.Value,
+		}
+		var key strings.Builder
+		key.WriteString(series.name)
+		for _, lbl := range s.Labels {
+			if lbl.Key == "nagios_job" {
+				continue
+			}
+			series.labels = append(series.labels, metrix.Label{Key: lbl.Key, Value: lbl.Value})
+			fmt.Fprintf(&key, "\x00%s=%s", lbl.Key, lbl.Value)
+		}
+		items = append(items, keyed{key: key.String(), series: series})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool { return items[i].key < items[j].key })
+
+	out := make([]scriptSeries, 0, min(len(items), r.maxSeriesPerJob))
+	for i, item := range items {
+		if i > 0 && item.key == items[i-1].key {
+			continue
+		}
+		if len(out) == r.maxSeriesPerJob {
+			break
+		}
+		out = append(out, item.series)
+	}
+	return out
+}
+
+func scriptMetricsFamily(checkName string) string {
+	return "Metrics/" + checkName
+}
@@ -17,8 +17,14 @@ type collectState struct {
 	retrying     bool
 	maxAttempts  int
 
+	flap          FlapConfig
+	stateHistory  []string
+	percentChange float64
+	flapping      bool
+
 	lastPerfValues          []perfValueMeasureSet
 	lastPerfThresholdStates []perfThresholdStateSet
+	lastScriptSeries        []scriptSeries
 }
 
 func newCollectState(now time.Time, job JobConfig) collectState {
@@ -28,6 +34,7 @@ func newCollectState(now time.Time, job JobConfig) collectState {
 		serviceState:    nagiosStateUnknown,
 		jobState:        nagiosStateUnknown,
 		maxAttempts:     max(job.MaxCheckAttempts, 1),
+		flap:            job.FlapDetection,
 	}
 }
 
@@ -53,6 +60,19 @@ func (s *collectState) isRetrying() bool {
 	return s != nil && s.retrying
 }
 
+func (s *collectState) isFlapping() bool {
+	return s != nil && s.flapping
+}
+
+// hardState returns the service state once it is confirmed, or false while
+// the job is still retrying or reports UNREACHABLE.
+func (s *collectState) hardState() (string, bool) {
+	if s.retrying || s.jobState == jobStateUnreachable {
+		return "", false
+	}
+	return s.currentServiceState(), true
+}
+
 func (s *collectState) currentAttempt() int {
 	if s == nil {
 		return 1
@@ -97,18 +117,65 @@ func (s *collectState) recordResult(serviceState, jobState string) {
 	}
 	s.serviceState = serviceState
 	s.jobState = jobState
+	s.recordFlapHistory(serviceState)
 }
 
 func (s *collectState) recordPeriodBlocked() {
 	s.jobState = jobStatePaused
+	s.clearPerfThresholdStates()
+}
+
+// recordUnreachable drops any pending retries: the check starts over once
+// its parents recover.
+func (s *collectState) recordUnreachable() {
+	s.jobState = jobStateUnreachable
+	s.softAttempts = 0
+	s.retrying = false
+	s.clearPerfThresholdStates()
+}
+
+func (s *collectState) clearPerfThresholdStates() {
 	for i := range s.lastPerfThresholdStates {
 		s.lastPerfThresholdStates[i].state = ""
 	}
 }
 
+// recordFlapHistory updates flap detection the way Nagios does: state changes
+// over the last flapHistorySize results are weighted from 0.75 for the oldest
+// to 1.25 for the newest, and the threshold pair gives hysteresis.
+func (s *collectState) recordFlapHistory(serviceState string) {
+	if !s.flap.Enabled {
+		return
+	}
+	if len(s.stateHistory) == flapHistorySize {
+		s.stateHistory = append(s.stateHistory[:0], s.stateHistory[1:]...)
+	}
+	s.stateHistory = append(s.stateHistory, serviceState)
+	s.percentChange = percentStateChange(s.stateHistory)
+
+	switch {
+	case !s.flapping && s.percentChange > s.flap.HighThreshold:
+		s.flapping = true
+	case s.flapping && s.percentChange < s.flap.LowThreshold:
+		s.flapping = false
+	}
+}
+
+func percentStateChange(history []string) float64 {
+	const lowWeight, highWeight = 0.75, 1.25
+	var changes float64
+	for i := 1; i < len(history); i++ {
+		if history[i] != history[i-1] {
+			changes += float64(i-1)*(highWeight-lowWeight)/float64(flapHistorySize-2) + lowWeight
+		}
+	}
+	return changes * 100 / float64(flapHistorySize-1)
+}
+
 func (s *collectState) rememberPerf(result perfRouteResult) {
 	s.lastPerfValues = append(s.lastPerfValues[:0], result.values...)
 	s.lastPerfThresholdStates = append(s.lastPerfThresholdStates[:0], result.thresholdStates...)
+	s.lastScriptSeries = append(s.lastScriptSeries[:0], result.series...)
 }
 
 func (s *collectState) perfValueSets() []perfValueMeasureSet {
@@ -119,6 +186,10 @@ func (s *collectState) perfThresholdStates() []perfThresholdStateSet {
 	return s.lastPerfThresholdStates
 }
 
+func (s *collectState) scriptSeries() []scriptSeries {
+	return s.lastScriptSeries
+}
+
 func (s *collectState) completeRun(now time.Time, serviceState, jobState string, perf perfRouteResult, job JobConfig) {
 	s.recordResult(serviceState, jobState)
 	s.rememberPerf(perf)
@@ -148,6 +219,8 @@ func normalizeJobState(state string) string {
 		return jobStateTimeout
 	case jobStatePaused:
 		return jobStatePaused
+	case jobStateUnreachable:
+		return jobStateUnreachable
 	default:
 		return normalizeState(state)
 	}
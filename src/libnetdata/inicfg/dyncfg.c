@@ -126,6 +126,10 @@ static struct {
     { .cmd = DYNCFG_CMD_DISABLE, .name = "disable" },
     { .cmd = DYNCFG_CMD_RESTART, .name = "restart" },
     { .cmd = DYNCFG_CMD_USERCONFIG, .name = "userconfig" },
+    { .cmd = DYNCFG_CMD_HISTORY, .name = "history" },
+    { .cmd = DYNCFG_CMD_DIFF, .name = "diff" },
+    { .cmd = DYNCFG_CMD_ROLLBACK, .name = "rollback" },
+    { .cmd = DYNCFG_CMD_IMPORT, .name = "import" },
 };
 
 const char *dyncfg_id2cmd_one(DYNCFG_CMDS cmd) {
@@ -53,6 +53,10 @@ typedef enum __attribute__((packed)) {
     DYNCFG_CMD_DISABLE      = (1 << 7),
     DYNCFG_CMD_RESTART      = (1 << 8),
     DYNCFG_CMD_USERCONFIG   = (1 << 9),
+    DYNCFG_CMD_HISTORY      = (1 << 10),
+    DYNCFG_CMD_DIFF         = (1 << 11),
+    DYNCFG_CMD_ROLLBACK     = (1 << 12),
+    DYNCFG_CMD_IMPORT       = (1 << 13),
 } DYNCFG_CMDS;
 
 DYNCFG_CMDS dyncfg_cmds2id(const char *cmds);
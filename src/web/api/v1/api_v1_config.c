@@ -76,6 +76,17 @@ int api_v1_config(RRDHOST *host, struct web_client *w, char *url __maybe_unused)
             }
             snprintfz(cmd, len, PLUGINSD_FUNCTION_CONFIG " %s %s %s", id, dyncfg_id2cmd_one(c), add_name);
         }
+        else if((c == DYNCFG_CMD_ROLLBACK || c == DYNCFG_CMD_DIFF) && add_name && *add_name) {
+            // the optional revision to roll back to, or to diff against the latest one
+            for(const char *p = add_name; *p; p++) {
+                if(!isdigit((uint8_t)*p)) {
+                    rrd_call_function_error(w->response.data, "Invalid revision", HTTP_RESP_BAD_REQUEST);
+                    freez(cmd);
+                    return HTTP_RESP_BAD_REQUEST;
+                }
+            }
+            snprintfz(cmd, len, PLUGINSD_FUNCTION_CONFIG " %s %s %s", id, dyncfg_id2cmd_one(c), add_name);
+        }
         else
             snprintfz(cmd, len, PLUGINSD_FUNCTION_CONFIG " %s %s", id, dyncfg_id2cmd_one(c));
     }